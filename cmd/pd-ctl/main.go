@@ -30,11 +30,15 @@ import (
 var (
 	url    string
 	detach bool
+	token  string
+	user   string
 )
 
 func init() {
 	flag.StringVarP(&url, "pd", "u", "http://127.0.0.1:2379", "The pd address")
 	flag.BoolVarP(&detach, "detach", "d", false, "Run pdctl without readline")
+	flag.StringVar(&token, "token", "", "The token to authenticate the changes")
+	flag.StringVar(&user, "user", "", "The user name and password to authenticate the changes, in the form of name:password")
 }
 
 func main() {
@@ -86,6 +90,7 @@ func loop() {
 		InterruptPrompt:   "^C",
 		EOFPrompt:         "^D",
 		HistorySearchFold: true,
+		AutoComplete:      pdctl.GetCompleter(url),
 	})
 	if err != nil {
 		panic(err)
@@ -107,6 +112,12 @@ func loop() {
 		}
 		args := strings.Split(strings.TrimSpace(line), " ")
 		args = append(args, "-u", url)
+		if token != "" {
+			args = append(args, "--token", token)
+		}
+		if user != "" {
+			args = append(args, "--user", user)
+		}
 		pdctl.Start(args)
 	}
 }
@@ -12,7 +12,7 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 )
 
 var (
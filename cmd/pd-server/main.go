@@ -59,7 +59,7 @@ func main() {
 	// TODO: Make it configurable if it has big impact on performance.
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
-	metricutil.Push(&cfg.Metric)
+	metricutil.Push(&cfg.Metric, server.MetricsGatherer())
 
 	err = server.PrepareJoinCluster(cfg)
 	if err != nil {
@@ -81,6 +81,13 @@ func main() {
 	go svr.Run()
 
 	sig := <-sc
+	for sig == syscall.SIGHUP {
+		log.Info("Got SIGHUP, reload config.")
+		if err = svr.ReloadConfig(); err != nil {
+			log.Errorf("reload config failed - %v", err)
+		}
+		sig = <-sc
+	}
 	svr.Close()
 	log.Infof("Got signal [%d] to exit.", sig)
 	switch sig {
@@ -22,10 +22,11 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // Client is a PD (Placement Driver) client.
@@ -43,16 +44,38 @@ type Client interface {
 	// Also it may return nil if PD finds no Region for the key temporarily,
 	// client should retry later.
 	GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error)
+	// GetPrevRegion gets the previous region and its leader Peer of the region
+	// where the key is located.
+	GetPrevRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error)
 	// GetRegionByID gets a region and its leader Peer from PD by id.
 	GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error)
 	// GetStore gets a store from PD by store id.
 	// The store may expire later. Caller is responsible for caching and taking care
 	// of store change.
 	GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error)
+	// GetAllStores gets all the stores from PD, including the tombstone ones
+	// unless WithExcludeTombstone is given.
+	GetAllStores(ctx context.Context, opts ...GetStoreOption) ([]*metapb.Store, error)
+	// ScanRegions gets the regions and their leaders in [key, endKey) from PD
+	// in the key order, an empty endKey means the end of the key space and
+	// limit 0 means no limit. The leader is nil if PD doesn't know it.
+	ScanRegions(ctx context.Context, key, endKey []byte, limit int) ([]*metapb.Region, []*metapb.Peer, error)
 	// Close closes the client.
 	Close()
 }
 
+// GetStoreOption configures the stores got from PD.
+type GetStoreOption func(*getStoreOp)
+
+type getStoreOp struct {
+	excludeTombstone bool
+}
+
+// WithExcludeTombstone excludes the tombstone stores.
+func WithExcludeTombstone() GetStoreOption {
+	return func(op *getStoreOp) { op.excludeTombstone = true }
+}
+
 type tsoRequest struct {
 	start    time.Time
 	ctx      context.Context
@@ -79,6 +102,7 @@ var (
 type client struct {
 	urls        []string
 	clusterID   uint64
+	token       string
 	tsoRequests chan *tsoRequest
 
 	connMu struct {
@@ -97,10 +121,17 @@ type client struct {
 
 // NewClient creates a PD client.
 func NewClient(pdAddrs []string) (Client, error) {
+	return NewClientWithToken(pdAddrs, "")
+}
+
+// NewClientWithToken creates a PD client which sends the token with its Tso
+// requests, PD names the client by the token's user when limiting the quota.
+func NewClientWithToken(pdAddrs []string, token string) (Client, error) {
 	log.Infof("[pd] create pd client with endpoints %v", pdAddrs)
 	ctx, cancel := context.WithCancel(context.Background())
 	c := &client{
 		urls:          addrsToUrls(pdAddrs),
+		token:         token,
 		tsoRequests:   make(chan *tsoRequest, maxMergeTSORequests),
 		tsDeadlineCh:  make(chan deadline, 1),
 		checkLeaderCh: make(chan struct{}, 1),
@@ -297,6 +328,9 @@ func (c *client) tsLoop() {
 		if stream == nil {
 			var ctx context.Context
 			ctx, cancel = context.WithCancel(c.ctx)
+			if c.token != "" {
+				ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", "Bearer "+c.token))
+			}
 			stream, err = c.leaderClient().Tso(ctx)
 			if err != nil {
 				log.Errorf("[pd] create tso stream error: %v", err)
@@ -490,6 +524,25 @@ func (c *client) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *me
 	return resp.GetRegion(), resp.GetLeader(), nil
 }
 
+func (c *client) GetPrevRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	start := time.Now()
+	defer func() { cmdDuration.WithLabelValues("get_prev_region").Observe(time.Since(start).Seconds()) }()
+	ctx, cancel := context.WithTimeout(ctx, pdTimeout)
+	resp, err := c.leaderClient().GetPrevRegion(ctx, &pdpb.GetRegionRequest{
+		Header:    c.requestHeader(),
+		RegionKey: key,
+	})
+	requestDuration.WithLabelValues("get_prev_region").Observe(time.Since(start).Seconds())
+	cancel()
+
+	if err != nil {
+		cmdFailedDuration.WithLabelValues("get_prev_region").Observe(time.Since(start).Seconds())
+		c.scheduleCheckLeader()
+		return nil, nil, errors.Trace(err)
+	}
+	return resp.GetRegion(), resp.GetLeader(), nil
+}
+
 func (c *client) GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error) {
 	start := time.Now()
 	defer func() { cmdDuration.WithLabelValues("get_region_byid").Observe(time.Since(start).Seconds()) }()
@@ -535,6 +588,58 @@ func (c *client) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, e
 	return store, nil
 }
 
+func (c *client) GetAllStores(ctx context.Context, opts ...GetStoreOption) ([]*metapb.Store, error) {
+	op := &getStoreOp{}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	start := time.Now()
+	defer func() { cmdDuration.WithLabelValues("get_all_stores").Observe(time.Since(start).Seconds()) }()
+	ctx, cancel := context.WithTimeout(ctx, pdTimeout)
+	resp, err := c.leaderClient().GetAllStores(ctx, &pdpb.GetAllStoresRequest{
+		Header:                 c.requestHeader(),
+		ExcludeTombstoneStores: op.excludeTombstone,
+	})
+	requestDuration.WithLabelValues("get_all_stores").Observe(time.Since(start).Seconds())
+	cancel()
+
+	if err != nil {
+		cmdFailedDuration.WithLabelValues("get_all_stores").Observe(time.Since(start).Seconds())
+		c.scheduleCheckLeader()
+		return nil, errors.Trace(err)
+	}
+	return resp.GetStores(), nil
+}
+
+func (c *client) ScanRegions(ctx context.Context, key, endKey []byte, limit int) ([]*metapb.Region, []*metapb.Peer, error) {
+	start := time.Now()
+	defer func() { cmdDuration.WithLabelValues("scan_regions").Observe(time.Since(start).Seconds()) }()
+	ctx, cancel := context.WithTimeout(ctx, pdTimeout)
+	resp, err := c.leaderClient().ScanRegions(ctx, &pdpb.ScanRegionsRequest{
+		Header:   c.requestHeader(),
+		StartKey: key,
+		EndKey:   endKey,
+		Limit:    int32(limit),
+	})
+	requestDuration.WithLabelValues("scan_regions").Observe(time.Since(start).Seconds())
+	cancel()
+
+	if err != nil {
+		cmdFailedDuration.WithLabelValues("scan_regions").Observe(time.Since(start).Seconds())
+		c.scheduleCheckLeader()
+		return nil, nil, errors.Trace(err)
+	}
+	// PD returns an empty peer if the leader is unknown.
+	leaders := resp.GetLeaders()
+	for i, leader := range leaders {
+		if leader.GetId() == 0 {
+			leaders[i] = nil
+		}
+	}
+	return resp.GetRegionMetas(), leaders, nil
+}
+
 func (c *client) requestHeader() *pdpb.RequestHeader {
 	return &pdpb.RequestHeader{
 		ClusterId: c.clusterID,
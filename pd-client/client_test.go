@@ -22,8 +22,8 @@ import (
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 	"github.com/pingcap/pd/server/api"
 	"golang.org/x/net/context"
@@ -217,6 +217,24 @@ func (s *testClientSuite) TestGetRegion(c *C) {
 	c.Assert(leader, DeepEquals, peer)
 }
 
+func (s *testClientSuite) TestGetPrevRegion(c *C) {
+	req := &pdpb.RegionHeartbeatRequest{
+		Header: newHeader(s.srv),
+		Region: region,
+		Leader: peer,
+	}
+	err := s.regionHeartbeat.Send(req)
+	c.Assert(err, IsNil)
+
+	time.Sleep(time.Millisecond * 200)
+
+	// The region covers all the keys, so there is no previous region.
+	r, leader, err := s.client.GetPrevRegion(context.Background(), []byte("a"))
+	c.Assert(err, IsNil)
+	c.Assert(r, IsNil)
+	c.Assert(leader, IsNil)
+}
+
 func (s *testClientSuite) TestGetRegionByID(c *C) {
 	req := &pdpb.RegionHeartbeatRequest{
 		Header: newHeader(s.srv),
@@ -232,6 +250,29 @@ func (s *testClientSuite) TestGetRegionByID(c *C) {
 	c.Assert(leader, DeepEquals, peer)
 }
 
+func (s *testClientSuite) TestScanRegions(c *C) {
+	req := &pdpb.RegionHeartbeatRequest{
+		Header: newHeader(s.srv),
+		Region: region,
+		Leader: peer,
+	}
+	err := s.regionHeartbeat.Send(req)
+	c.Assert(err, IsNil)
+
+	time.Sleep(time.Millisecond * 200)
+
+	// The region covers all the keys.
+	regions, leaders, err := s.client.ScanRegions(context.Background(), []byte("a"), nil, 10)
+	c.Assert(err, IsNil)
+	c.Assert(regions, DeepEquals, []*metapb.Region{region})
+	c.Assert(leaders, DeepEquals, []*metapb.Peer{peer})
+
+	regions, leaders, err = s.client.ScanRegions(context.Background(), nil, []byte("b"), 0)
+	c.Assert(err, IsNil)
+	c.Assert(regions, DeepEquals, []*metapb.Region{region})
+	c.Assert(leaders, DeepEquals, []*metapb.Peer{peer})
+}
+
 func (s *testClientSuite) TestGetStore(c *C) {
 	cluster := s.srv.GetRaftCluster()
 	c.Assert(cluster, NotNil)
@@ -258,3 +299,41 @@ func (s *testClientSuite) TestGetStore(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(n, IsNil)
 }
+
+func (s *testClientSuite) TestGetAllStores(c *C) {
+	cluster := s.srv.GetRaftCluster()
+	c.Assert(cluster, NotNil)
+
+	newStore := &metapb.Store{
+		Id:      10,
+		Address: "localhost:10",
+	}
+	_, err := s.grpcPDClient.PutStore(context.Background(), &pdpb.PutStoreRequest{
+		Header: newHeader(s.srv),
+		Store:  newStore,
+	})
+	c.Assert(err, IsNil)
+
+	stores, err := s.client.GetAllStores(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(getStoreByID(stores, newStore.GetId()), DeepEquals, newStore)
+
+	// The tombstone stores are excluded if asked.
+	c.Assert(cluster.RemoveStore(newStore.GetId()), IsNil)
+	c.Assert(cluster.BuryStore(newStore.GetId(), true), IsNil)
+	stores, err = s.client.GetAllStores(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(getStoreByID(stores, newStore.GetId()).GetState(), Equals, metapb.StoreState_Tombstone)
+	stores, err = s.client.GetAllStores(context.Background(), WithExcludeTombstone())
+	c.Assert(err, IsNil)
+	c.Assert(getStoreByID(stores, newStore.GetId()), IsNil)
+}
+
+func getStoreByID(stores []*metapb.Store, storeID uint64) *metapb.Store {
+	for _, store := range stores {
+		if store.GetId() == storeID {
+			return store
+		}
+	}
+	return nil
+}
@@ -83,8 +83,10 @@ func (s *testLeaderChangeSuite) TestLeaderConfigChange(c *C) {
 	leader := s.mustGetLeader(c, cli.(*client), endpoints)
 	s.verifyLeader(c, cli.(*client), leader)
 
-	r := server.ReplicationConfig{MaxReplicas: 5}
-	svrs[leader].SetReplicationConfig(r)
+	// Only 1 store is up, so the max replicas can only be decreased.
+	r := server.ReplicationConfig{MaxReplicas: 1}
+	err = svrs[leader].SetReplicationConfig(r)
+	c.Assert(err, IsNil)
 	svrs[leader].Close()
 	// wait leader changes
 	changed := false
@@ -95,7 +97,7 @@ func (s *testLeaderChangeSuite) TestLeaderConfigChange(c *C) {
 			s.verifyLeader(c, cli.(*client), newLeader)
 			changed = true
 			nr := svrs[newLeader].GetConfig().Replication.MaxReplicas
-			c.Assert(nr, Equals, uint64(5))
+			c.Assert(nr, Equals, uint64(1))
 			break
 		}
 		time.Sleep(500 * time.Millisecond)
@@ -68,6 +68,7 @@ func NewSetConfigCommand() *cobra.Command {
 		Short: "set the option with value",
 		Run:   setConfigCommandFunc,
 	}
+	sc.Flags().Bool("dry-run", false, "only show what would change without applying it")
 	return sc
 }
 
@@ -89,7 +90,7 @@ func showAllConfigCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Println(r)
 }
 
-func postConfigDataWithPath(cmd *cobra.Command, key, value, path string) error {
+func postConfigDataWithPath(cmd *cobra.Command, key, value, path string) (string, error) {
 	var val interface{}
 	data := make(map[string]interface{})
 	val, err := strconv.ParseFloat(value, 64)
@@ -100,13 +101,9 @@ func postConfigDataWithPath(cmd *cobra.Command, key, value, path string) error {
 	reqData, err := json.Marshal(data)
 	req, err := getRequest(cmd, path, http.MethodPost, "application/json", bytes.NewBuffer(reqData))
 	if err != nil {
-		return err
+		return "", err
 	}
-	_, err = dail(req)
-	if err != nil {
-		return err
-	}
-	return nil
+	return dail(req)
 }
 
 func setConfigCommandFunc(cmd *cobra.Command, args []string) {
@@ -115,10 +112,23 @@ func setConfigCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	opt, val := args[0], args[1]
-	err := postConfigDataWithPath(cmd, opt, val, configPrefix)
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	path := configPrefix
+	if dryRun {
+		path += "?dry_run=true"
+	}
+	r, err := postConfigDataWithPath(cmd, opt, val, path)
 	if err != nil {
 		fmt.Printf("Failed to set config: %s", err)
 		return
 	}
+	if dryRun {
+		fmt.Println(r)
+		return
+	}
 	fmt.Println("Success!")
 }
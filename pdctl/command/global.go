@@ -22,10 +22,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
 	"github.com/pingcap/pd/pd-client"
+	"github.com/pingcap/pd/pkg/urlutil"
 	"github.com/spf13/cobra"
 )
 
@@ -47,9 +49,25 @@ func getRequest(cmd *cobra.Command, prefix string, method string, bodyType strin
 		return nil, err
 	}
 	req.Header.Set("Content-Type", bodyType)
+	setAuth(cmd, req)
 	return req, err
 }
 
+// setAuth sets the credentials given by the flags to the request.
+func setAuth(cmd *cobra.Command, req *http.Request) {
+	if token, _ := cmd.Flags().GetString("token"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return
+	}
+	if user, _ := cmd.Flags().GetString("user"); user != "" {
+		name, password := user, ""
+		if i := strings.Index(user, ":"); i >= 0 {
+			name, password = user[:i], user[i+1:]
+		}
+		req.SetBasicAuth(name, password)
+	}
+}
+
 func dail(req *http.Request) (string, error) {
 	var res string
 	reps, err := dailClient.Do(req)
@@ -117,12 +135,10 @@ func getAddressFromCmd(cmd *cobra.Command, prefix string) string {
 		os.Exit(1)
 	}
 
-	u, err := url.Parse(p)
+	u, err := url.Parse(urlutil.AddScheme(p))
 	if err != nil {
-		fmt.Println("address is wrong format,should like 'http://127.0.0.1:2379'")
-	}
-	if u.Scheme == "" {
-		u.Scheme = "http"
+		fmt.Println("address is wrong format,should like 'http://127.0.0.1:2379' or 'http://[::1]:2379'")
+		os.Exit(1)
 	}
 	s := fmt.Sprintf("%s/%s", u, prefix)
 	return s
@@ -134,13 +150,10 @@ func printResponseError(r *http.Response) {
 }
 
 func validPDAddr(pd string) error {
-	u, err := url.Parse(pd)
+	u, err := urlutil.ParseURL(urlutil.AddScheme(pd))
 	if err != nil {
 		return err
 	}
-	if u.Scheme == "" {
-		u.Scheme = "http"
-	}
 	addr := u.String()
 	reps, err := http.Get(fmt.Sprintf("%s/%s", addr, pingPrefix))
 	if err != nil {
@@ -161,8 +174,12 @@ func postJSON(cmd *cobra.Command, prefix string, input map[string]interface{}) {
 		return
 	}
 
-	url := getAddressFromCmd(cmd, prefix)
-	r, err := http.Post(url, "application/json", bytes.NewBuffer(data))
+	req, err := getRequest(cmd, prefix, http.MethodPost, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	r, err := dailClient.Do(req)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	hotRegionsPrefix = "pd/api/v1/hotspot/regions"
-	hotStoresPrefix  = "pd/api/v1/hotspot/stores"
+	hotRegionsPrefix     = "pd/api/v1/hotspot/regions/write"
+	hotReadRegionsPrefix = "pd/api/v1/hotspot/regions/read"
+	hotStoresPrefix      = "pd/api/v1/hotspot/stores"
 )
 
 // NewHotSpotCommand return a hot subcommand of rootCmd
@@ -32,6 +33,7 @@ func NewHotSpotCommand() *cobra.Command {
 		Short: "show the hotspot status of the cluster",
 	}
 	cmd.AddCommand(NewHotRegionCommand())
+	cmd.AddCommand(NewHotReadRegionCommand())
 	cmd.AddCommand(NewHotStoreCommand())
 	return cmd
 }
@@ -40,7 +42,7 @@ func NewHotSpotCommand() *cobra.Command {
 func NewHotRegionCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "region",
-		Short: "show the hot regions",
+		Short: "show the write hot regions",
 		Run:   showHotRegionsCommandFunc,
 	}
 	return cmd
@@ -55,11 +57,30 @@ func showHotRegionsCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Println(r)
 }
 
+// NewHotReadRegionCommand return a hot read regions subcommand of hotSpotCmd
+func NewHotReadRegionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read",
+		Short: "show the read hot regions",
+		Run:   showHotReadRegionsCommandFunc,
+	}
+	return cmd
+}
+
+func showHotReadRegionsCommandFunc(cmd *cobra.Command, args []string) {
+	r, err := doRequest(cmd, hotReadRegionsPrefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get hotspot: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
 // NewHotStoreCommand return a hot stores subcommand of hotSpotCmd
 func NewHotStoreCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "store",
-		Short: "show the hot stores",
+		Short: "show the written flow and the read hot flow of the stores",
 		Run:   showHotStoresCommandFunc,
 	}
 	return cmd
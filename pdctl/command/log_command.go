@@ -0,0 +1,53 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+const logPrefix = "pd/api/v1/admin/log"
+
+// NewLogCommand returns a log subcommand of rootCmd
+func NewLogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log [debug|info|warn|error]",
+		Short: "show or change the log level of the leader at runtime",
+		Run:   logCommandFunc,
+	}
+	return cmd
+}
+
+func logCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		r, err := doRequest(cmd, logPrefix, http.MethodGet)
+		if err != nil {
+			fmt.Printf("Failed to get the log level: %s\n", err)
+			return
+		}
+		fmt.Println(r)
+		return
+	}
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	input := map[string]interface{}{
+		"level": args[0],
+	}
+	postJSON(cmd, logPrefix, input)
+}
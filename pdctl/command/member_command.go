@@ -16,48 +16,99 @@ package command
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	membersPrefix      = "pd/api/v1/members"
-	memberPrefix       = "pd/api/v1/members/%s"
-	leaderMemberPrefix = "pd/api/v1/leader"
+	membersPrefix        = "pd/api/v1/members"
+	memberPrefix         = "pd/api/v1/members/%s"
+	memberIDPrefix       = "pd/api/v1/members/id/%s"
+	leaderPriorityPrefix = "pd/api/v1/members/%s/leader-priority"
+	leaderMemberPrefix   = "pd/api/v1/leader"
+	resignPrefix         = "pd/api/v1/leader/resign"
+	transferPrefix       = "pd/api/v1/leader/transfer/%s"
+	healthPrefix         = "pd/api/v1/health"
 )
 
 // NewMemberCommand return a member subcommand of rootCmd
 func NewMemberCommand() *cobra.Command {
 	m := &cobra.Command{
-		Use:   "member [leader|delete]",
+		Use:   "member [leader|health|delete|leader_priority]",
 		Short: "show the pd member status",
 		Run:   showMemberCommandFunc,
 	}
 	m.AddCommand(NewLeaderMemberCommand())
+	m.AddCommand(NewHealthMemberCommand())
 	m.AddCommand(NewDeleteMemberCommand())
+	m.AddCommand(NewLeaderPriorityCommand())
 	return m
 }
 
 // NewDeleteMemberCommand return a delete subcommand of memberCmd
 func NewDeleteMemberCommand() *cobra.Command {
 	d := &cobra.Command{
-		Use:   "delete <member_name>",
-		Short: "delete the member",
+		Use:   "delete [id] <member_name>",
+		Short: "delete the member by the name, or by the id with the id subcommand",
 		Run:   deleteMemberCommandFunc,
 	}
+	d.AddCommand(&cobra.Command{
+		Use:   "id <member_id>",
+		Short: "delete the member by the id",
+		Run:   deleteMemberByIDCommandFunc,
+	})
 	return d
 }
 
+// NewLeaderPriorityCommand returns a leader_priority subcommand of memberCmd
+func NewLeaderPriorityCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "leader_priority <member_name> <priority>",
+		Short: "set the leader priority of the member, the leader moves to the reachable member of the highest priority",
+		Run:   setLeaderPriorityCommandFunc,
+	}
+}
+
 // NewLeaderMemberCommand return a leader subcommand of memberCmd
 func NewLeaderMemberCommand() *cobra.Command {
 	l := &cobra.Command{
-		Use:   "leader",
+		Use:   "leader [resign|transfer]",
 		Short: "show the leader member status",
 		Run:   getLeaderMemberCommandFunc,
 	}
+	l.AddCommand(&cobra.Command{
+		Use:   "resign",
+		Short: "let the leader resign, the next leader is elected from the other members",
+		Run:   resignLeaderMemberCommandFunc,
+	})
+	l.AddCommand(&cobra.Command{
+		Use:   "transfer <member_name>",
+		Short: "transfer the leadership to the member",
+		Run:   transferLeaderMemberCommandFunc,
+	})
 	return l
 }
 
+// NewHealthMemberCommand return a health subcommand of memberCmd
+func NewHealthMemberCommand() *cobra.Command {
+	h := &cobra.Command{
+		Use:   "health",
+		Short: "show whether the etcd cluster has a quorum and the members are reachable",
+		Run:   getHealthMemberCommandFunc,
+	}
+	return h
+}
+
+func getHealthMemberCommandFunc(cmd *cobra.Command, args []string) {
+	r, err := doRequest(cmd, healthPrefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get the health of pd members: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
 func showMemberCommandFunc(cmd *cobra.Command, args []string) {
 	r, err := doRequest(cmd, membersPrefix, http.MethodGet)
 	if err != nil {
@@ -81,6 +132,61 @@ func deleteMemberCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Println("Success!")
 }
 
+func resignLeaderMemberCommandFunc(cmd *cobra.Command, args []string) {
+	_, err := doRequest(cmd, resignPrefix, http.MethodPost)
+	if err != nil {
+		fmt.Printf("Failed to resign the leader: %s", err)
+		return
+	}
+	fmt.Println("Success!")
+}
+
+func transferLeaderMemberCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: member leader transfer <member_name>")
+		return
+	}
+	_, err := doRequest(cmd, fmt.Sprintf(transferPrefix, args[0]), http.MethodPost)
+	if err != nil {
+		fmt.Printf("Failed to transfer the leader to %s: %s", args[0], err)
+		return
+	}
+	fmt.Println("Success!")
+}
+
+func deleteMemberByIDCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: member delete id <member_id>")
+		return
+	}
+	if _, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+		fmt.Println("member_id should be a number")
+		return
+	}
+	_, err := doRequest(cmd, fmt.Sprintf(memberIDPrefix, args[0]), http.MethodDelete)
+	if err != nil {
+		fmt.Printf("Failed to delete member %s: %s", args[0], err)
+		return
+	}
+	fmt.Println("Success!")
+}
+
+func setLeaderPriorityCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		fmt.Println("Usage: member leader_priority <member_name> <priority>")
+		return
+	}
+	priority, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Println("priority should be a number")
+		return
+	}
+	input := map[string]interface{}{
+		"leader-priority": priority,
+	}
+	postJSON(cmd, fmt.Sprintf(leaderPriorityPrefix, args[0]), input)
+}
+
 func getLeaderMemberCommandFunc(cmd *cobra.Command, args []string) {
 	r, err := doRequest(cmd, leaderMemberPrefix, http.MethodGet)
 	if err != nil {
@@ -0,0 +1,139 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/spf13/cobra"
+)
+
+var namespacesPrefix = "pd/api/v1/namespaces"
+
+// NewNamespaceCommand return a namespace subcommand of rootCmd
+func NewNamespaceCommand() *cobra.Command {
+	n := &cobra.Command{
+		Use:   "namespace [set|delete]",
+		Short: "show the namespaces",
+		Run:   showNamespacesCommandFunc,
+	}
+	n.AddCommand(NewSetNamespaceCommand())
+	n.AddCommand(NewDeleteNamespaceCommand())
+	return n
+}
+
+// NewSetNamespaceCommand return a set subcommand of namespaceCmd
+func NewSetNamespaceCommand() *cobra.Command {
+	s := &cobra.Command{
+		Use:   "set <name> <max_replicas>",
+		Short: "add or update a namespace, 0 max_replicas means using max-replicas",
+		Run:   setNamespaceCommandFunc,
+	}
+	s.Flags().StringArray("range", nil, "the key range of the namespace, such as start_key,end_key, can be repeated")
+	s.Flags().StringSlice("stores", nil, "the IDs of the stores dedicated to the namespace, such as 1,2,3")
+	return s
+}
+
+// NewDeleteNamespaceCommand return a delete subcommand of namespaceCmd
+func NewDeleteNamespaceCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "delete the namespace",
+		Run:   deleteNamespaceCommandFunc,
+	}
+	return d
+}
+
+func showNamespacesCommandFunc(cmd *cobra.Command, args []string) {
+	r, err := doRequest(cmd, namespacesPrefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get namespaces: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
+func parseKeyRange(s string) (map[string][]byte, error) {
+	keys := strings.Split(s, ",")
+	if len(keys) != 2 {
+		return nil, errors.Errorf("invalid range %q, should be start_key,end_key", s)
+	}
+	return map[string][]byte{"start_key": []byte(keys[0]), "end_key": []byte(keys[1])}, nil
+}
+
+func setNamespaceCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	maxReplicas, err := strconv.Atoi(args[1])
+	if err != nil || maxReplicas < 0 {
+		fmt.Println("max_replicas should be a number that >= 0")
+		return
+	}
+
+	ranges, err := cmd.Flags().GetStringArray("range")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	keyRanges := make([]map[string][]byte, 0, len(ranges))
+	for _, s := range ranges {
+		keyRange, err := parseKeyRange(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		keyRanges = append(keyRanges, keyRange)
+	}
+
+	stores, err := cmd.Flags().GetStringSlice("stores")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	storeIDs := make([]uint64, 0, len(stores))
+	for _, s := range stores {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			fmt.Printf("invalid store ID %q\n", s)
+			return
+		}
+		storeIDs = append(storeIDs, id)
+	}
+
+	input := map[string]interface{}{
+		"name":         args[0],
+		"ranges":       keyRanges,
+		"store_ids":    storeIDs,
+		"max_replicas": maxReplicas,
+	}
+	postJSON(cmd, namespacesPrefix, input)
+}
+
+func deleteNamespaceCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	_, err := doRequest(cmd, namespacesPrefix+"/"+args[0], http.MethodDelete)
+	if err != nil {
+		fmt.Printf("Failed to delete namespace: %s", err)
+		return
+	}
+}
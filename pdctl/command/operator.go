@@ -76,6 +76,12 @@ func NewAddOperatorCommand() *cobra.Command {
 	c.AddCommand(NewTransferLeaderCommand())
 	c.AddCommand(NewTransferRegionCommand())
 	c.AddCommand(NewTransferPeerCommand())
+	c.AddCommand(NewChangePeerRoleCommand())
+	c.AddCommand(NewAddPeerCommand())
+	c.AddCommand(NewRemovePeerCommand())
+	c.AddCommand(NewSplitRegionCommand())
+	c.AddCommand(NewMergeRegionCommand())
+	c.AddCommand(NewScatterRegionCommand())
 	return c
 }
 
@@ -167,6 +173,165 @@ func transferPeerCommandFunc(cmd *cobra.Command, args []string) {
 	postJSON(cmd, operatorsPrefix, input)
 }
 
+// NewChangePeerRoleCommand returns a command to change the role of a peer.
+func NewChangePeerRoleCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "change-peer-role <region_id> <store_id> <voter|learner|observer>",
+		Short: "change the role of a region's peer on the specified store",
+		Run:   changePeerRoleCommandFunc,
+	}
+	return c
+}
+
+func changePeerRoleCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 3 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	ids, err := parseUint64s(args[:2])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["region_id"] = ids[0]
+	input["store_id"] = ids[1]
+	input["role"] = args[2]
+	postJSON(cmd, operatorsPrefix, input)
+}
+
+// NewAddPeerCommand returns a command to add a peer.
+func NewAddPeerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "add-peer <region_id> <store_id>",
+		Short: "add a region peer on the specified store",
+		Run:   storePeerCommandFunc,
+	}
+	return c
+}
+
+// NewRemovePeerCommand returns a command to remove a peer.
+func NewRemovePeerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "remove-peer <region_id> <store_id>",
+		Short: "remove the region peer on the specified store",
+		Run:   storePeerCommandFunc,
+	}
+	return c
+}
+
+func storePeerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	ids, err := parseUint64s(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["region_id"] = ids[0]
+	input["store_id"] = ids[1]
+	postJSON(cmd, operatorsPrefix, input)
+}
+
+// NewSplitRegionCommand returns a command to split a region.
+func NewSplitRegionCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "split-region <region_id> [--policy=approximate|scan] [hex_key...]",
+		Short: "split a region by the policy, or at the specified keys",
+		Run:   splitRegionCommandFunc,
+	}
+	c.Flags().String("policy", "approximate", "the policy to find the split keys")
+	return c
+}
+
+func splitRegionCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	ids, err := parseUint64s(args[:1])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["region_id"] = ids[0]
+	input["policy"], _ = cmd.Flags().GetString("policy")
+	if len(args) > 1 {
+		input["keys"] = args[1:]
+	}
+	postJSON(cmd, operatorsPrefix, input)
+}
+
+// NewMergeRegionCommand returns a command to merge regions.
+func NewMergeRegionCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "merge-region <region_id> <target_region_id>",
+		Short: "merge a region into the adjacent target region",
+		Run:   mergeRegionCommandFunc,
+	}
+	return c
+}
+
+func mergeRegionCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	ids, err := parseUint64s(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["region_id"] = ids[0]
+	input["target_region_id"] = ids[1]
+	postJSON(cmd, operatorsPrefix, input)
+}
+
+// NewScatterRegionCommand returns a command to scatter a region.
+func NewScatterRegionCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "scatter-region <region_id>",
+		Short: "scatter the peers and the leader of a region",
+		Run:   scatterRegionCommandFunc,
+	}
+	return c
+}
+
+func scatterRegionCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	ids, err := parseUint64s(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["region_id"] = ids[0]
+	postJSON(cmd, operatorsPrefix, input)
+}
+
 // NewRemoveOperatorCommand returns a command to remove operators.
 func NewRemoveOperatorCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -0,0 +1,208 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/spf13/cobra"
+)
+
+var placementsPrefix = "pd/api/v1/placements"
+
+// NewPlacementCommand return a placement subcommand of rootCmd
+func NewPlacementCommand() *cobra.Command {
+	p := &cobra.Command{
+		Use:   "placement [set|delete]",
+		Short: "show the key range placements",
+		Run:   showPlacementsCommandFunc,
+	}
+	p.AddCommand(NewSetPlacementCommand())
+	p.AddCommand(NewDeletePlacementCommand())
+	return p
+}
+
+// NewSetPlacementCommand return a set subcommand of placementCmd
+func NewSetPlacementCommand() *cobra.Command {
+	s := &cobra.Command{
+		Use:   "set <id> <start_key> <end_key> <replicas>",
+		Short: "add or update the placement of a key range, 0 replicas means using max-replicas or the rules",
+		Run:   setPlacementCommandFunc,
+	}
+	s.Flags().String("leader-label", "", "only place the leaders on the stores with the label, such as zone=z1")
+	s.Flags().StringSlice("constraints", nil, "only place the peers on the stores with all the labels, such as zone=z1,disk=ssd")
+	s.Flags().Int("learners", 0, "the number of the learners besides the replicas, 0 leaves the learners to the admin")
+	s.Flags().StringSlice("learner-constraints", nil, "only place the learners on the stores with all the labels")
+	s.Flags().StringArray("rule", nil, "place the peers by the rule of <role>:<count>[:<labels>] instead of the replicas, such as voter:3:zone=z1 and learner:1:zone=z2,engine=column, the flag can be repeated")
+	return s
+}
+
+// NewDeletePlacementCommand return a delete subcommand of placementCmd
+func NewDeletePlacementCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "delete the placement",
+		Run:   deletePlacementCommandFunc,
+	}
+	return d
+}
+
+func showPlacementsCommandFunc(cmd *cobra.Command, args []string) {
+	r, err := doRequest(cmd, placementsPrefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get placements: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
+func parseStoreLabel(s string) (map[string]string, error) {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+		return nil, errors.Errorf("invalid label %q, should be key=value", s)
+	}
+	return map[string]string{"key": kv[0], "value": kv[1]}, nil
+}
+
+func setPlacementCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 4 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	replicas, err := strconv.Atoi(args[3])
+	if err != nil || replicas < 0 {
+		fmt.Println("replicas should be a number that >= 0")
+		return
+	}
+	input := map[string]interface{}{
+		"id":        args[0],
+		"start_key": []byte(args[1]),
+		"end_key":   []byte(args[2]),
+		"replicas":  replicas,
+	}
+
+	leaderLabel, err := cmd.Flags().GetString("leader-label")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if leaderLabel != "" {
+		label, err := parseStoreLabel(leaderLabel)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		input["leader_label"] = label
+	}
+
+	for _, flag := range []string{"constraints", "learner-constraints"} {
+		labels, err := getStoreLabelsFlag(cmd, flag)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if len(labels) > 0 {
+			input[strings.Replace(flag, "-", "_", -1)] = labels
+		}
+	}
+
+	learners, err := cmd.Flags().GetInt("learners")
+	if err != nil || learners < 0 {
+		fmt.Println("learners should be a number that >= 0")
+		return
+	}
+	if learners > 0 {
+		input["learners"] = learners
+	}
+
+	ruleFlags, err := cmd.Flags().GetStringArray("rule")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var rules []map[string]interface{}
+	for _, s := range ruleFlags {
+		rule, err := parsePlacementRule(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) > 0 {
+		input["rules"] = rules
+	}
+	postJSON(cmd, placementsPrefix, input)
+}
+
+// parsePlacementRule parses the rule of <role>:<count>[:<labels>], the labels
+// are separated by commas.
+func parsePlacementRule(s string) (map[string]interface{}, error) {
+	fields := strings.SplitN(s, ":", 3)
+	if len(fields) < 2 {
+		return nil, errors.Errorf("invalid rule %q, should be <role>:<count>[:<labels>]", s)
+	}
+	count, err := strconv.Atoi(fields[1])
+	if err != nil || count <= 0 {
+		return nil, errors.Errorf("invalid count of rule %q", s)
+	}
+	rule := map[string]interface{}{
+		"role":  fields[0],
+		"count": count,
+	}
+	if len(fields) == 3 {
+		var labels []map[string]string
+		for _, l := range strings.Split(fields[2], ",") {
+			label, err := parseStoreLabel(l)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			labels = append(labels, label)
+		}
+		rule["constraints"] = labels
+	}
+	return rule, nil
+}
+
+func getStoreLabelsFlag(cmd *cobra.Command, flag string) ([]map[string]string, error) {
+	values, err := cmd.Flags().GetStringSlice(flag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var labels []map[string]string
+	for _, s := range values {
+		label, err := parseStoreLabel(s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+func deletePlacementCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	_, err := doRequest(cmd, placementsPrefix+"/"+args[0], http.MethodDelete)
+	if err != nil {
+		fmt.Printf("Failed to delete placement: %s", err)
+		return
+	}
+}
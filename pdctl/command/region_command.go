@@ -15,19 +15,27 @@ package command
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/spf13/cobra"
 )
 
 var (
-	regionsPrefix   = "pd/api/v1/regions"
-	regionIDPrefix  = "pd/api/v1/region/id"
-	regionKeyPrefix = "pd/api/v1/region/key"
+	regionsPrefix      = "pd/api/v1/regions"
+	regionsCheckPrefix = "pd/api/v1/regions/check"
+	regionsTopPrefix   = "pd/api/v1/regions/top"
+	regionStatsPrefix  = "pd/api/v1/stats/region"
+	regionCachePrefix  = "pd/api/v1/admin/cache/region"
+	regionsCachePrefix = "pd/api/v1/admin/cache/regions"
+	regionIDPrefix     = "pd/api/v1/region/id"
+	regionKeyPrefix    = "pd/api/v1/region/key"
 )
 
 type regionInfo struct {
@@ -38,11 +46,22 @@ type regionInfo struct {
 // NewRegionCommand return a region subcommand of rootCmd
 func NewRegionCommand() *cobra.Command {
 	r := &cobra.Command{
-		Use:   "region <region_id>",
+		Use:   "region [--start-key=<key>] [--limit=<limit>] [--store=<store_id>] [--state=<state>] <region_id>",
 		Short: "show the region status",
 		Run:   showRegionCommandFunc,
 	}
+	r.Flags().String("start-key", "", "list the regions from the raw key")
+	r.Flags().Int("limit", 0, "the max number of the regions to list, 0 means no limit")
+	r.Flags().Uint64("store", 0, "only list the regions with a peer on the store")
+	r.Flags().String("state", "", "only list the regions in the state: down-peer, pending-peer, miss-peer, extra-peer, offline-peer or empty-region")
 	r.AddCommand(NewRegionWithKeyCommand())
+	r.AddCommand(NewRegionCheckCommand())
+	r.AddCommand(NewRegionStatsCommand())
+	r.AddCommand(NewRegionDropCommand())
+	r.AddCommand(NewRegionTopCommand("topread", "readflow", "read flow"))
+	r.AddCommand(NewRegionTopCommand("topwrite", "writeflow", "write flow"))
+	r.AddCommand(NewRegionTopCommand("topsize", "size", "approximate size"))
+	r.AddCommand(NewRegionTopCommand("topconfver", "confver", "conf version"))
 	return r
 }
 
@@ -55,6 +74,8 @@ func showRegionCommandFunc(cmd *cobra.Command, args []string) {
 			return
 		}
 		prefix = regionIDPrefix + "/" + args[0]
+	} else if query := regionsQuery(cmd); query != "" {
+		prefix += "?" + query
 	}
 	r, err := doRequest(cmd, prefix, http.MethodGet)
 	if err != nil {
@@ -64,10 +85,154 @@ func showRegionCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Println(r)
 }
 
+// regionsQuery encodes the flags to list the regions.
+func regionsQuery(cmd *cobra.Command) string {
+	query := url.Values{}
+	if key, _ := cmd.Flags().GetString("start-key"); key != "" {
+		query.Set("start_key", base64.StdEncoding.EncodeToString([]byte(key)))
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if storeID, _ := cmd.Flags().GetUint64("store"); storeID != 0 {
+		query.Set("store_id", strconv.FormatUint(storeID, 10))
+	}
+	if state, _ := cmd.Flags().GetString("state"); state != "" {
+		query.Set("state", state)
+	}
+	return query.Encode()
+}
+
+// NewRegionCheckCommand returns a region check subcommand of regionCmd
+func NewRegionCheckCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "check [--start-key=<key>] [--limit=<limit>] [--store=<store_id>] <miss-peer|extra-peer|down-peer|pending-peer|offline-peer|empty-region>",
+		Short: "show the regions in the abnormal state",
+		Run:   showRegionCheckCommandFunc,
+	}
+	r.Flags().String("start-key", "", "list the regions from the raw key")
+	r.Flags().Int("limit", 0, "the max number of the regions to list, 0 means no limit")
+	r.Flags().Uint64("store", 0, "only list the regions with a peer on the store")
+	return r
+}
+
+func showRegionCheckCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	prefix := regionsCheckPrefix + "/" + args[0]
+	if query := regionsQuery(cmd); query != "" {
+		prefix += "?" + query
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get regions: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
+// NewRegionTopCommand returns a region top subcommand of regionCmd, which lists
+// the regions by the order in descending order
+func NewRegionTopCommand(name, order, desc string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " [<limit>]",
+		Short: fmt.Sprintf("show the regions with the highest %s, 16 by default", desc),
+		Run: func(cmd *cobra.Command, args []string) {
+			showRegionTopCommandFunc(cmd, args, order)
+		},
+	}
+}
+
+func showRegionTopCommandFunc(cmd *cobra.Command, args []string, order string) {
+	prefix := regionsTopPrefix + "/" + order
+	if len(args) == 1 {
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			fmt.Println("limit should be a number")
+			return
+		}
+		prefix += "?limit=" + args[0]
+	} else if len(args) > 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get the top regions: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
+// NewRegionStatsCommand returns a region stats subcommand of regionCmd
+func NewRegionStatsCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "stats [--start-key=<key>] [--end-key=<key>]",
+		Short: "show the statistics of the regions in the key range",
+		Run:   showRegionStatsCommandFunc,
+	}
+	r.Flags().String("start-key", "", "the raw start key of the range")
+	r.Flags().String("end-key", "", "the raw end key of the range, empty means unbounded")
+	return r
+}
+
+func showRegionStatsCommandFunc(cmd *cobra.Command, args []string) {
+	query := url.Values{}
+	for _, name := range []string{"start-key", "end-key"} {
+		if key, _ := cmd.Flags().GetString(name); key != "" {
+			query.Set(strings.Replace(name, "-", "_", 1), base64.StdEncoding.EncodeToString([]byte(key)))
+		}
+	}
+	prefix := regionStatsPrefix
+	if len(query) > 0 {
+		prefix += "?" + query.Encode()
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get region stats: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
+// NewRegionDropCommand returns a region drop subcommand of regionCmd
+func NewRegionDropCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "drop [--all] <region_id>",
+		Short: "drop the region or all the regions from the cache of PD, they are learned again from the heartbeats",
+		Run:   dropRegionCommandFunc,
+	}
+	r.Flags().Bool("all", false, "drop all the regions")
+	return r
+}
+
+func dropRegionCommandFunc(cmd *cobra.Command, args []string) {
+	var prefix string
+	if all, _ := cmd.Flags().GetBool("all"); all && len(args) == 0 {
+		prefix = regionsCachePrefix
+	} else if !all && len(args) == 1 {
+		if _, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+			fmt.Println("region_id should be a number")
+			return
+		}
+		prefix = regionCachePrefix + "/" + args[0]
+	} else {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	_, err := doRequest(cmd, prefix, http.MethodDelete)
+	if err != nil {
+		fmt.Printf("Failed to drop the region: %s\n", err)
+		return
+	}
+	fmt.Println("Success!")
+}
+
 // NewRegionWithKeyCommand return a region with key subcommand of regionCmd
 func NewRegionWithKeyCommand() *cobra.Command {
 	r := &cobra.Command{
-		Use:   "key [--format=raw|pb|proto|protobuf] <key>",
+		Use:   "key [--format=raw|hex|pb|proto|protobuf] <key>",
 		Short: "show the region with key",
 		Run:   showRegionWithTableCommandFunc,
 	}
@@ -82,14 +247,17 @@ func showRegionWithTableCommandFunc(cmd *cobra.Command, args []string) {
 	}
 
 	var (
-		key string
-		err error
+		key   string
+		query string
+		err   error
 	)
 
 	format := cmd.Flags().Lookup("format").Value.String()
 	switch format {
 	case "raw":
 		key = args[0]
+	case "hex":
+		key, query = args[0], "?format=hex"
 	case "pb", "proto", "protobuf":
 		key, err = decodeProtobufText(args[0])
 		if err != nil {
@@ -100,8 +268,7 @@ func showRegionWithTableCommandFunc(cmd *cobra.Command, args []string) {
 		fmt.Println("Error: unknown format")
 		return
 	}
-	// TODO: Deal with path escaped
-	prefix := regionKeyPrefix + "/" + key
+	prefix := regionKeyPrefix + "/" + url.PathEscape(key) + query
 	r, err := doRequest(cmd, prefix, http.MethodGet)
 	if err != nil {
 		fmt.Printf("Failed to get region: %s", err)
@@ -22,7 +22,8 @@ import (
 )
 
 var (
-	schedulersPrefix = "pd/api/v1/schedulers"
+	schedulersPrefix    = "pd/api/v1/schedulers"
+	schedulePausePrefix = "pd/api/v1/schedule/pause"
 )
 
 // NewSchedulerCommand returns a scheduler command.
@@ -34,6 +35,11 @@ func NewSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewShowSchedulerCommand())
 	c.AddCommand(NewAddSchedulerCommand())
 	c.AddCommand(NewRemoveSchedulerCommand())
+	c.AddCommand(NewPauseSchedulerCommand())
+	c.AddCommand(NewResumeSchedulerCommand())
+	c.AddCommand(NewPauseAllCommand())
+	c.AddCommand(NewResumeAllCommand())
+	c.AddCommand(NewShowPauseAllCommand())
 	return c
 }
 
@@ -44,6 +50,7 @@ func NewShowSchedulerCommand() *cobra.Command {
 		Short: "show schedulers",
 		Run:   showSchedulerCommandFunc,
 	}
+	c.Flags().Bool("paused", false, "only show the paused schedulers")
 	return c
 }
 
@@ -53,7 +60,11 @@ func showSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	r, err := doRequest(cmd, schedulersPrefix, http.MethodGet)
+	path := schedulersPrefix
+	if paused, _ := cmd.Flags().GetBool("paused"); paused {
+		path += "?status=paused"
+	}
+	r, err := doRequest(cmd, path, http.MethodGet)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -67,10 +78,33 @@ func NewAddSchedulerCommand() *cobra.Command {
 		Use:   "add <scheduler>",
 		Short: "add a scheduler",
 	}
+	c.AddCommand(NewBalanceHotRegionSchedulerCommand())
+	c.AddCommand(NewBalanceHotReadRegionSchedulerCommand())
 	c.AddCommand(NewGrantLeaderSchedulerCommand())
 	c.AddCommand(NewEvictLeaderSchedulerCommand())
 	c.AddCommand(NewShuffleLeaderSchedulerCommand())
 	c.AddCommand(NewShuffleRegionSchedulerCommand())
+	c.AddCommand(NewScatterRangeSchedulerCommand())
+	return c
+}
+
+// NewBalanceHotRegionSchedulerCommand returns a command to add a balance-hot-region-scheduler.
+func NewBalanceHotRegionSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-hot-region-scheduler",
+		Short: "add a scheduler to balance the hot write regions between stores",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewBalanceHotReadRegionSchedulerCommand returns a command to add a balance-hot-read-region-scheduler.
+func NewBalanceHotReadRegionSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-hot-read-region-scheduler",
+		Short: "add a scheduler to balance the leaders of the hot read regions between stores",
+		Run:   addSchedulerCommandFunc,
+	}
 	return c
 }
 
@@ -132,6 +166,30 @@ func NewShuffleRegionSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewScatterRangeSchedulerCommand returns a command to add a scatter-range-scheduler.
+func NewScatterRangeSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "scatter-range-scheduler <start_key> <end_key> <range_name>",
+		Short: "add a scheduler to spread the leaders and regions in a key range between stores",
+		Run:   addSchedulerForRangeCommandFunc,
+	}
+	return c
+}
+
+func addSchedulerForRangeCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 3 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	input["start_key"] = args[0]
+	input["end_key"] = args[1]
+	input["range_name"] = args[2]
+	postJSON(cmd, schedulersPrefix, input)
+}
+
 func addSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
 		fmt.Println(cmd.UsageString())
@@ -166,3 +224,125 @@ func removeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 }
+
+// NewPauseSchedulerCommand returns a command to pause a scheduler.
+func NewPauseSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pause <scheduler> [<delay_seconds>]",
+		Short: "pause a scheduler, until resumed if no delay is given",
+		Run:   pauseSchedulerCommandFunc,
+	}
+	return c
+}
+
+func pauseSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 && len(args) != 2 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]interface{})
+	if len(args) == 2 {
+		delay, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil || delay == 0 {
+			fmt.Println("delay_seconds should be a number that > 0")
+			return
+		}
+		input["delay"] = delay
+	}
+	postJSON(cmd, schedulersPrefix+"/"+args[0]+"/pause", input)
+}
+
+// NewResumeSchedulerCommand returns a command to resume a scheduler.
+func NewResumeSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "resume <scheduler>",
+		Short: "resume a paused scheduler",
+		Run:   resumeSchedulerCommandFunc,
+	}
+	return c
+}
+
+func resumeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	_, err := doRequest(cmd, schedulersPrefix+"/"+args[0]+"/pause", http.MethodDelete)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+}
+
+// NewPauseAllCommand returns a command to pause all the scheduling.
+func NewPauseAllCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pause-all [<delay_seconds>]",
+		Short: "pause all the schedulers and checkers, until resumed if no delay is given",
+		Run:   pauseAllCommandFunc,
+	}
+	return c
+}
+
+func pauseAllCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]interface{})
+	if len(args) == 1 {
+		delay, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil || delay == 0 {
+			fmt.Println("delay_seconds should be a number that > 0")
+			return
+		}
+		input["delay"] = delay
+	}
+	postJSON(cmd, schedulePausePrefix, input)
+}
+
+// NewResumeAllCommand returns a command to resume all the scheduling.
+func NewResumeAllCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "resume-all",
+		Short: "resume the scheduling paused by pause-all",
+		Run:   resumeAllCommandFunc,
+	}
+	return c
+}
+
+func resumeAllCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	_, err := doRequest(cmd, schedulePausePrefix, http.MethodDelete)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+}
+
+// NewShowPauseAllCommand returns a command to show whether all the scheduling
+// is paused.
+func NewShowPauseAllCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show-pause-all",
+		Short: "show whether all the scheduling is paused",
+		Run:   showPauseAllCommandFunc,
+	}
+	return c
+}
+
+func showPauseAllCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, schedulePausePrefix, http.MethodGet)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(r)
+}
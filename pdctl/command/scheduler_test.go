@@ -0,0 +1,142 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/spf13/cobra"
+)
+
+func TestCommand(t *testing.T) {
+	TestingT(t)
+}
+
+// mockPD records the requests and replies them with the responses of the
+// paths.
+type mockPD struct {
+	*httptest.Server
+
+	sync.Mutex
+	responses map[string]string
+	requests  []string
+}
+
+func newMockPD(responses map[string]string) *mockPD {
+	pd := &mockPD{responses: responses}
+	pd.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		pd.Lock()
+		pd.requests = append(pd.requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		resp, ok := pd.responses[r.URL.Path]
+		pd.Unlock()
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	return pd
+}
+
+func (pd *mockPD) getRequests() []string {
+	pd.Lock()
+	defer pd.Unlock()
+	return append([]string(nil), pd.requests...)
+}
+
+// runCommand runs the command with the arguments against pd and returns the
+// output.
+func runCommand(c *C, pd *mockPD, cmd *cobra.Command, args ...string) string {
+	return runCommands(c, pd, []*cobra.Command{cmd}, args...)
+}
+
+// runCommands runs the arguments against pd with the commands added to the
+// root command.
+func runCommands(c *C, pd *mockPD, cmds []*cobra.Command, args ...string) string {
+	root := &cobra.Command{Use: "pdctl"}
+	root.PersistentFlags().String("pd", pd.URL, "pd address")
+	root.PersistentFlags().String("token", "", "token")
+	root.PersistentFlags().String("user", "", "user")
+	root.AddCommand(cmds...)
+	root.SetArgs(args)
+
+	r, w, err := os.Pipe()
+	c.Assert(err, IsNil)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	done := make(chan []byte)
+	go func() {
+		out, _ := ioutil.ReadAll(r)
+		done <- out
+	}()
+	c.Assert(root.Execute(), IsNil)
+	w.Close()
+	return string(<-done)
+}
+
+var _ = Suite(&testSchedulerSuite{})
+
+type testSchedulerSuite struct{}
+
+func (s *testSchedulerSuite) TestShow(c *C) {
+	statuses := `[{"name":"balance-hot-region-scheduler","paused":true,"pause_until":1500000000},` +
+		`{"name":"balance-leader-scheduler","paused":true},{"name":"balance-region-scheduler","paused":false}]`
+	pd := newMockPD(map[string]string{"/" + schedulersPrefix: statuses})
+	defer pd.Close()
+
+	out := runCommand(c, pd, NewSchedulerCommand(), "scheduler", "show")
+	var shown []map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(out), &shown), IsNil)
+	c.Assert(shown, HasLen, 3)
+	c.Assert(shown[0]["paused"], Equals, true)
+	c.Assert(shown[0]["pause_until"], Equals, float64(1500000000))
+	c.Assert(shown[1]["paused"], Equals, true)
+	c.Assert(shown[2]["paused"], Equals, false)
+
+	runCommand(c, pd, NewSchedulerCommand(), "scheduler", "show", "--paused")
+	c.Assert(pd.getRequests(), DeepEquals, []string{
+		"GET /" + schedulersPrefix,
+		"GET /" + schedulersPrefix + "?status=paused",
+	})
+}
+
+func (s *testSchedulerSuite) TestPause(c *C) {
+	pausePath := "/" + schedulersPrefix + "/balance-leader-scheduler/pause"
+	pd := newMockPD(map[string]string{pausePath: "null"})
+	defer pd.Close()
+
+	runCommand(c, pd, NewSchedulerCommand(), "scheduler", "pause", "balance-leader-scheduler", "60")
+	runCommand(c, pd, NewSchedulerCommand(), "scheduler", "pause", "balance-leader-scheduler")
+	runCommand(c, pd, NewSchedulerCommand(), "scheduler", "resume", "balance-leader-scheduler")
+	c.Assert(pd.getRequests(), DeepEquals, []string{
+		"POST " + pausePath + ` {"delay":60}`,
+		"POST " + pausePath + " {}",
+		"DELETE " + pausePath,
+	})
+
+	// The delay must be positive.
+	out := runCommand(c, pd, NewSchedulerCommand(), "scheduler", "pause", "balance-leader-scheduler", "0")
+	c.Assert(strings.Contains(out, "delay_seconds should be a number that > 0"), IsTrue)
+	c.Assert(pd.getRequests(), HasLen, 3)
+}
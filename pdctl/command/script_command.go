@@ -0,0 +1,356 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/spf13/cobra"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const scriptRetryInterval = time.Second
+
+// NewScriptCommand return a script subcommand of rootCmd
+func NewScriptCommand() *cobra.Command {
+	s := &cobra.Command{
+		Use:   "script <file> [args...]",
+		Short: "run a Lua runbook against PD",
+		Long: `run a Lua runbook against PD. The args are in the arg table, arg[0] is
+the file. Besides the Lua standard libraries, the following functions are
+supported:
+  pd.run(<command> [, args...])  run a pd-ctl command and return its output,
+                                 $1, $2... in a single command line are
+                                 replaced by the args and ${name} by the
+                                 global variable
+  pd.get(<path>)                 get the PD API and return the decoded JSON
+  pd.post(<path> [, table])      post the table as JSON to the PD API
+  pd.delete(<path>)              delete the PD API
+  sleep(<duration>)              sleep for a while, such as "10s"
+  expect(<text>, <regexp>)       fail if the text doesn't match
+  wait(<timeout>, <function>)    call the function until it returns true
+
+For example, drain store 5 then wait until no region misses a peer:
+  pd.run("store delete $1")
+  wait("30m", function()
+    return pd.get("pd/api/v1/store/" .. arg[1]).status.region_count == 0
+  end)
+  wait("10m", function()
+    return pd.get("pd/api/v1/regions/check/miss-peer").count == 0
+  end)`,
+		Run: scriptCommandFunc,
+	}
+	return s
+}
+
+func scriptCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	s := newScript(cmd, args[0], args[1:])
+	defer s.close()
+	if err := s.state.DoFile(args[0]); err != nil {
+		fmt.Printf("Failed to run %s: %s\n", args[0], err)
+	}
+}
+
+type script struct {
+	cmd   *cobra.Command
+	args  []string
+	state *lua.LState
+}
+
+func newScript(cmd *cobra.Command, file string, args []string) *script {
+	s := &script{
+		cmd:   cmd,
+		args:  args,
+		state: lua.NewState(),
+	}
+	l := s.state
+
+	arg := l.NewTable()
+	arg.RawSetInt(0, lua.LString(file))
+	for i, a := range args {
+		arg.RawSetInt(i+1, lua.LString(a))
+	}
+	l.SetGlobal("arg", arg)
+
+	l.SetGlobal("pd", l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"run":    s.luaRun,
+		"get":    s.luaGet,
+		"post":   s.luaPost,
+		"delete": s.luaDelete,
+	}))
+	l.SetGlobal("sleep", l.NewFunction(luaSleep))
+	l.SetGlobal("expect", l.NewFunction(luaExpect))
+	l.SetGlobal("wait", l.NewFunction(luaWait))
+	return s
+}
+
+func (s *script) close() {
+	s.state.Close()
+}
+
+// expand returns the arg of the number or the global variable of the name.
+func (s *script) expand(name string) string {
+	if i, err := strconv.Atoi(name); err == nil {
+		if i > 0 && i <= len(s.args) {
+			return s.args[i-1]
+		}
+		return ""
+	}
+	switch v := s.state.GetGlobal(name).(type) {
+	case lua.LString, lua.LNumber:
+		return v.String()
+	}
+	return ""
+}
+
+// luaRun runs a pd-ctl command. A single command line is expanded and split,
+// multiple args are passed as they are.
+func (s *script) luaRun(l *lua.LState) int {
+	var args []string
+	if l.GetTop() == 1 {
+		var err error
+		args, err = splitScriptArgs(os.Expand(l.CheckString(1), s.expand))
+		if err != nil {
+			l.RaiseError("%s", err)
+		}
+	} else {
+		for i := 1; i <= l.GetTop(); i++ {
+			args = append(args, l.CheckString(i))
+		}
+	}
+	if len(args) == 0 {
+		l.ArgError(1, "command expected")
+	}
+	if args[0] == "script" {
+		l.RaiseError("nested script is not supported")
+	}
+
+	root := s.cmd.Root()
+	output := captureStdout(func() {
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			fmt.Println(err)
+		}
+	})
+	l.Push(lua.LString(output))
+	return 1
+}
+
+func (s *script) luaGet(l *lua.LState) int {
+	return s.request(l, http.MethodGet, l.CheckString(1), nil)
+}
+
+func (s *script) luaPost(l *lua.LState) int {
+	var body io.Reader
+	if t := l.OptTable(2, nil); t != nil {
+		data, err := json.Marshal(fromLuaValue(t))
+		if err != nil {
+			l.RaiseError("%s", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	return s.request(l, http.MethodPost, l.CheckString(1), body)
+}
+
+func (s *script) luaDelete(l *lua.LState) int {
+	return s.request(l, http.MethodDelete, l.CheckString(1), nil)
+}
+
+// request sends the request to the PD API and pushes the decoded response,
+// the errors are raised to the script.
+func (s *script) request(l *lua.LState, method, prefix string, body io.Reader) int {
+	req, err := getRequest(s.cmd, prefix, method, "application/json", body)
+	if err != nil {
+		l.RaiseError("%s", err)
+	}
+	res, err := dail(req)
+	if err != nil {
+		l.RaiseError("%s %s: %s", method, prefix, err)
+	}
+	var v interface{}
+	if strings.TrimSpace(res) != "" {
+		if err = json.Unmarshal([]byte(res), &v); err != nil {
+			l.RaiseError("%s %s: %s", method, prefix, err)
+		}
+	}
+	l.Push(toLuaValue(l, v))
+	return 1
+}
+
+func luaSleep(l *lua.LState) int {
+	d, err := time.ParseDuration(l.CheckString(1))
+	if err != nil {
+		l.ArgError(1, err.Error())
+	}
+	time.Sleep(d)
+	return 0
+}
+
+func luaExpect(l *lua.LState) int {
+	text, pattern := l.CheckString(1), l.CheckString(2)
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		l.ArgError(2, err.Error())
+	}
+	if !re.MatchString(text) {
+		l.RaiseError("%q doesn't match %s", text, pattern)
+	}
+	return 0
+}
+
+func luaWait(l *lua.LState) int {
+	timeout := l.CheckString(1)
+	f := l.CheckFunction(2)
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		l.ArgError(1, err.Error())
+	}
+	deadline := time.Now().Add(d)
+	for {
+		l.Push(f)
+		l.Call(0, 1)
+		ok := lua.LVAsBool(l.Get(-1))
+		l.Pop(1)
+		if ok {
+			return 0
+		}
+		if time.Now().After(deadline) {
+			l.RaiseError("wait timeout after %s", timeout)
+		}
+		time.Sleep(scriptRetryInterval)
+	}
+}
+
+// toLuaValue converts the decoded JSON to Lua, the arrays become sequences.
+func toLuaValue(l *lua.LState, v interface{}) lua.LValue {
+	switch v := v.(type) {
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []interface{}:
+		t := l.CreateTable(len(v), 0)
+		for i, e := range v {
+			t.RawSetInt(i+1, toLuaValue(l, e))
+		}
+		return t
+	case map[string]interface{}:
+		t := l.CreateTable(0, len(v))
+		for k, e := range v {
+			t.RawSetString(k, toLuaValue(l, e))
+		}
+		return t
+	}
+	return lua.LNil
+}
+
+// fromLuaValue converts the Lua value to be encoded as JSON, the sequences
+// become arrays and the other tables become objects.
+func fromLuaValue(v lua.LValue) interface{} {
+	switch v := v.(type) {
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		if n := v.MaxN(); n > 0 {
+			a := make([]interface{}, 0, n)
+			for i := 1; i <= n; i++ {
+				a = append(a, fromLuaValue(v.RawGetInt(i)))
+			}
+			return a
+		}
+		m := make(map[string]interface{})
+		v.ForEach(func(k, e lua.LValue) {
+			m[k.String()] = fromLuaValue(e)
+		})
+		return m
+	}
+	return nil
+}
+
+func captureStdout(f func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		f()
+		return ""
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	ch := make(chan string)
+	go func() {
+		b, _ := ioutil.ReadAll(r)
+		ch <- string(b)
+	}()
+
+	f()
+	w.Close()
+	os.Stdout = stdout
+	output := <-ch
+	r.Close()
+	return output
+}
+
+// splitScriptArgs splits a line by spaces, double quotes can be used to keep
+// spaces in an arg.
+func splitScriptArgs(line string) ([]string, error) {
+	var (
+		args   []string
+		arg    []byte
+		quoted bool
+		inArg  bool
+	)
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '"':
+			quoted = !quoted
+			inArg = true
+		case (ch == ' ' || ch == '\t') && !quoted:
+			if inArg {
+				args = append(args, string(arg))
+				arg, inArg = arg[:0], false
+			}
+		default:
+			arg = append(arg, ch)
+			inArg = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unclosed quote")
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	return args, nil
+}
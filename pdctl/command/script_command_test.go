@@ -0,0 +1,160 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/spf13/cobra"
+)
+
+var _ = Suite(&testScriptSuite{})
+
+type testScriptSuite struct{}
+
+// runScript runs the Lua code as a script with the args against pd.
+func runScript(c *C, pd *mockPD, code string, args ...string) string {
+	f, err := ioutil.TempFile("", "pdctl-script")
+	c.Assert(err, IsNil)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(code)
+	c.Assert(err, IsNil)
+	c.Assert(f.Close(), IsNil)
+
+	cmds := []*cobra.Command{NewScriptCommand(), NewSchedulerCommand()}
+	return runCommands(c, pd, cmds, append([]string{"script", f.Name()}, args...)...)
+}
+
+func (s *testScriptSuite) TestSplitArgs(c *C) {
+	tbl := []struct {
+		line string
+		args []string
+	}{
+		{"", nil},
+		{"store  delete\t5 ", []string{"store", "delete", "5"}},
+		{`label store 1 zone "us east"`, []string{"label", "store", "1", "zone", "us east"}},
+		{`echo ""`, []string{"echo", ""}},
+		{`a"b c"d`, []string{"ab cd"}},
+	}
+	for _, t := range tbl {
+		args, err := splitScriptArgs(t.line)
+		c.Assert(err, IsNil)
+		c.Assert(args, DeepEquals, t.args)
+	}
+	_, err := splitScriptArgs(`store "delete 5`)
+	c.Assert(err, NotNil)
+}
+
+func (s *testScriptSuite) TestRun(c *C) {
+	pausePath := "/" + schedulersPrefix + "/balance-leader-scheduler/pause"
+	pd := newMockPD(map[string]string{pausePath: "null"})
+	defer pd.Close()
+
+	// $1 is the arg, ${name} and $name are the global variables, and the
+	// missing ones are empty.
+	out := runScript(c, pd, `
+name = "balance-leader-scheduler"
+pd.run("scheduler pause ${name} $1")
+pd.run("scheduler pause $name $2")
+pd.run("scheduler", "resume", name)
+print(arg[0] ~= nil, arg[1], arg[2])
+`, "60")
+	c.Assert(out, Equals, "true\t60\tnil\n")
+	c.Assert(pd.getRequests(), DeepEquals, []string{
+		"POST " + pausePath + ` {"delay":60}`,
+		"POST " + pausePath + " {}",
+		"DELETE " + pausePath,
+	})
+
+	// The output of the command is returned.
+	out = runScript(c, pd, `
+local out = pd.run("scheduler pause balance-leader-scheduler 0")
+expect(out, "delay_seconds should be a number")
+print("ok")
+`)
+	c.Assert(out, Equals, "ok\n")
+}
+
+func (s *testScriptSuite) TestAPI(c *C) {
+	pd := newMockPD(map[string]string{
+		"/pd/api/v1/stores/drain":            "null",
+		"/pd/api/v1/store/5":                 `{"store":{"id":5},"status":{"region_count":0}}`,
+		"/pd/api/v1/regions/check/miss-peer": `{"regions":[{"id":2},{"id":3}],"count":2}`,
+	})
+	defer pd.Close()
+
+	out := runScript(c, pd, `
+pd.post("pd/api/v1/stores/drain", {stores = {tonumber(arg[1])}})
+wait("10s", function()
+  return pd.get("pd/api/v1/store/" .. arg[1]).status.region_count == 0
+end)
+local check = pd.get("pd/api/v1/regions/check/miss-peer")
+print(check.count, #check.regions, check.regions[2].id)
+pd.delete("pd/api/v1/stores/drain")
+`, "5")
+	c.Assert(out, Equals, "2\t2\t3\n")
+	c.Assert(pd.getRequests(), DeepEquals, []string{
+		`POST /pd/api/v1/stores/drain {"stores":[5]}`,
+		"GET /pd/api/v1/store/5",
+		"GET /pd/api/v1/regions/check/miss-peer",
+		"DELETE /pd/api/v1/stores/drain",
+	})
+}
+
+func (s *testScriptSuite) TestWait(c *C) {
+	pd := newMockPD(nil)
+	defer pd.Close()
+
+	// The function is called until it returns true.
+	out := runScript(c, pd, `
+local n = 0
+wait("10s", function()
+  n = n + 1
+  return n == 2
+end)
+print(n)
+`)
+	c.Assert(out, Equals, "2\n")
+}
+
+func (s *testScriptSuite) TestFailures(c *C) {
+	pd := newMockPD(nil)
+	defer pd.Close()
+
+	tbl := []struct {
+		code string
+		err  string
+	}{
+		{`expect("region 1", "^store")`, `"region 1" doesn't match ^store`},
+		{`expect("region 1", "(")`, "error parsing regexp"},
+		{`wait("0s", function() return false end)`, "wait timeout after 0s"},
+		{`wait("1x", function() return true end)`, "unknown unit"},
+		{`wait("1s", function() error("boom") end)`, "boom"},
+		{`sleep("1x")`, "unknown unit"},
+		{`pd.get("pd/api/v1/unknown")`, "GET pd/api/v1/unknown: [404] not found"},
+		{`pd.run('store "delete 5')`, "unclosed quote"},
+		{`pd.run("")`, "command expected"},
+		{`pd.run("script x")`, "nested script is not supported"},
+	}
+	for _, t := range tbl {
+		// The script stops at the failure.
+		out := runScript(c, pd, t.code+"\nprint(\"unreachable\")\n")
+		c.Assert(strings.HasPrefix(out, "Failed to run "), IsTrue, Commentf("%s: %s", t.code, out))
+		c.Assert(strings.Contains(out, t.err), IsTrue, Commentf("%s: %s", t.code, out))
+		c.Assert(strings.Contains(out, "unreachable"), IsFalse)
+	}
+}
@@ -14,26 +14,41 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
+	"github.com/pingcap/pd/pkg/urlutil"
 	"github.com/spf13/cobra"
 )
 
 var (
 	storesPrefix = "pd/api/v1/stores"
 	storePrefix  = "pd/api/v1/store/%s"
+	statePrefix  = "pd/api/v1/store/%s/state"
+	labelPrefix  = "pd/api/v1/store/%s/label"
+	weightPrefix = "pd/api/v1/store/%s/weight"
+	limitPrefix  = "pd/api/v1/store/%s/limit"
+	limitsPrefix = "pd/api/v1/stores/limit"
+	drainPrefix  = "pd/api/v1/stores/drain"
 )
 
 // NewStoreCommand return a store subcommand of rootCmd
 func NewStoreCommand() *cobra.Command {
 	s := &cobra.Command{
-		Use:   "store [delete] <store_id>",
+		Use:   "store [delete|state|label|drain|weight|limit] [--state=<state>] <store_id>",
 		Short: "show the store status",
 		Run:   showStoreCommandFunc,
 	}
+	s.Flags().StringSlice("state", nil, "list the stores in the states, Up, Offline or Tombstone")
 	s.AddCommand(NewDeleteStoreCommand())
+	s.AddCommand(NewStoreStateCommand())
+	s.AddCommand(NewStoreLabelCommand())
+	s.AddCommand(NewStoreDrainCommand())
+	s.AddCommand(NewStoreWeightCommand())
+	s.AddCommand(NewStoreLimitCommand())
 	return s
 }
 
@@ -47,9 +62,176 @@ func NewDeleteStoreCommand() *cobra.Command {
 	return d
 }
 
+// NewStoreStateCommand return a state subcommand of storeCmd
+func NewStoreStateCommand() *cobra.Command {
+	s := &cobra.Command{
+		Use:   "state <store_id> <Up|Offline>",
+		Short: "set the state of the store, set an offline store Up to cancel the deletion",
+		Run:   setStoreStateCommandFunc,
+	}
+	return s
+}
+
+func setStoreStateCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.Atoi(args[0]); err != nil {
+		fmt.Println("store_id should be a number")
+		return
+	}
+	prefix := fmt.Sprintf(statePrefix, args[0]) + "?state=" + url.QueryEscape(args[1])
+	_, err := doRequest(cmd, prefix, http.MethodPost)
+	if err != nil {
+		fmt.Printf("Failed to set the state of store %s: %s", args[0], err)
+		return
+	}
+	fmt.Println("Success!")
+}
+
+// NewStoreLabelCommand return a label subcommand of storeCmd
+func NewStoreLabelCommand() *cobra.Command {
+	l := &cobra.Command{
+		Use:   "label <store_id> <key> <value> [<key> <value>]...",
+		Short: "add or update the labels of the store",
+		Run:   setStoreLabelCommandFunc,
+	}
+	return l
+}
+
+func setStoreLabelCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 3 || len(args)%2 != 1 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.Atoi(args[0]); err != nil {
+		fmt.Println("store_id should be a number")
+		return
+	}
+	labels := make(map[string]interface{})
+	for i := 1; i < len(args); i += 2 {
+		labels[args[i]] = args[i+1]
+	}
+	postJSON(cmd, fmt.Sprintf(labelPrefix, args[0]), labels)
+}
+
+// NewStoreDrainCommand return a drain subcommand of storeCmd
+func NewStoreDrainCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use:   "drain",
+		Short: "show the drain progress and ETA of the offline and blocked stores",
+		Run:   showStoreDrainCommandFunc,
+	}
+	return d
+}
+
+// NewStoreWeightCommand return a weight subcommand of storeCmd
+func NewStoreWeightCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use:   "weight <store_id> <leader_weight> <region_weight>",
+		Short: "set the leader and region weights of the store",
+		Run:   setStoreWeightCommandFunc,
+	}
+	return d
+}
+
+func setStoreWeightCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 3 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.Atoi(args[0]); err != nil {
+		fmt.Println("store_id should be a number")
+		return
+	}
+	leader, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || leader < 0 {
+		fmt.Println("leader_weight should be a number that >= 0")
+		return
+	}
+	region, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || region < 0 {
+		fmt.Println("region_weight should be a number that >= 0")
+		return
+	}
+	prefix := fmt.Sprintf(weightPrefix, args[0])
+	postJSON(cmd, prefix, map[string]interface{}{
+		"leader": leader,
+		"region": region,
+	})
+}
+
+// NewStoreLimitCommand return a limit subcommand of storeCmd
+func NewStoreLimitCommand() *cobra.Command {
+	l := &cobra.Command{
+		Use:   "limit [<store_id> <rate> [add-peer|remove-peer]]",
+		Short: "show or set the max number of add-peer and remove-peer operators of the store per minute, 0 means no limit",
+		Run:   storeLimitCommandFunc,
+	}
+	return l
+}
+
+func storeLimitCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		r, err := doRequest(cmd, limitsPrefix, http.MethodGet)
+		if err != nil {
+			fmt.Printf("Failed to get store limits: %s", err)
+			return
+		}
+		fmt.Println(r)
+		return
+	}
+	if len(args) < 2 || len(args) > 3 {
+		fmt.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.Atoi(args[0]); err != nil {
+		fmt.Println("store_id should be a number")
+		return
+	}
+	rate, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || rate < 0 {
+		fmt.Println("rate should be a number that >= 0")
+		return
+	}
+	input := map[string]interface{}{
+		"add_peer":    rate,
+		"remove_peer": rate,
+	}
+	if len(args) == 3 {
+		switch args[2] {
+		case "add-peer":
+			delete(input, "remove_peer")
+		case "remove-peer":
+			delete(input, "add_peer")
+		default:
+			fmt.Println(cmd.UsageString())
+			return
+		}
+	}
+	postJSON(cmd, fmt.Sprintf(limitPrefix, args[0]), input)
+}
+
+func showStoreDrainCommandFunc(cmd *cobra.Command, args []string) {
+	r, err := doRequest(cmd, drainPrefix, http.MethodGet)
+	if err != nil {
+		fmt.Printf("Failed to get store drain status: %s", err)
+		return
+	}
+	fmt.Println(r)
+}
+
 func showStoreCommandFunc(cmd *cobra.Command, args []string) {
 	var prefix string
 	prefix = storesPrefix
+	if states, _ := cmd.Flags().GetStringSlice("state"); len(states) > 0 {
+		query := make(url.Values)
+		for _, state := range states {
+			query.Add("state", state)
+		}
+		prefix += "?" + query.Encode()
+	}
 	if len(args) == 1 {
 		if _, err := strconv.Atoi(args[0]); err != nil {
 			fmt.Println("store_id should be a number")
@@ -82,3 +264,35 @@ func deleteStoreCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println("Success!")
 }
+
+// GetStoreIDs returns the IDs of the stores of the PD at addr, which are used
+// to complete the commands in the interactive mode.
+func GetStoreIDs(addr string) ([]string, error) {
+	u, err := urlutil.ParseURL(urlutil.AddScheme(addr))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dailClient.Get(fmt.Sprintf("%s/%s", u, storesPrefix))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, genResponseError(resp)
+	}
+	var stores struct {
+		Stores []struct {
+			Store struct {
+				ID uint64 `json:"id"`
+			} `json:"store"`
+		} `json:"stores"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&stores); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(stores.Stores))
+	for _, s := range stores.Stores {
+		ids = append(ids, strconv.FormatUint(s.Store.ID, 10))
+	}
+	return ids, nil
+}
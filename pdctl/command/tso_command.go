@@ -15,6 +15,7 @@ package command
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -22,33 +23,43 @@ import (
 )
 
 const (
+	tsoPrefix         = "pd/api/v1/tso"
 	physicalShiftBits = 18
 	logicalBits       = 0x3FFFF
 )
 
-// NewTSOCommand return a ping subcommand of rootCmd
+// NewTSOCommand return a tso subcommand of rootCmd
 func NewTSOCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "tso <timestamp>",
-		Short: "parse TSO to the system and logic time",
+		Use:   "tso [timestamp]",
+		Short: "parse TSO to the system and logic time, or show the current TSO",
 		Run:   showTSOCommandFunc,
 	}
 	return cmd
 }
 
 func showTSOCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		r, err := doRequest(cmd, tsoPrefix, http.MethodGet)
+		if err != nil {
+			fmt.Printf("Failed to get the current TSO: %s\n", err)
+			return
+		}
+		fmt.Println(r)
+		return
+	}
 	if len(args) != 1 {
-		fmt.Println("Usage: tso <timestamp>")
+		fmt.Println("Usage: tso [timestamp]")
 		return
 	}
 	ts, err := strconv.ParseUint(args[0], 10, 64)
 	if err != nil {
-		fmt.Printf("Failed to parse TSO: %s", err)
+		fmt.Printf("Failed to parse TSO: %s\n", err)
 		return
 	}
 	logical := ts & logicalBits
 	physical := ts >> physicalShiftBits
-	physicalTime := time.Unix(int64(physical/1000), 0)
+	physicalTime := time.Unix(0, int64(physical)*int64(time.Millisecond))
 	fmt.Println("system: ", physicalTime)
 	fmt.Println("logic: ", logical)
 }
@@ -0,0 +1,58 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdctl
+
+import (
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/pingcap/pd/pdctl/command"
+	"github.com/spf13/cobra"
+)
+
+// GetCompleter returns the completer of the commands for the interactive
+// mode, the store IDs are completed with the stores of the PD at addr.
+func GetCompleter(addr string) readline.AutoCompleter {
+	storeIDs := func(string) []string {
+		ids, _ := command.GetStoreIDs(addr)
+		return ids
+	}
+	return readline.NewPrefixCompleter(commandItems(rootCmd, storeIDs)...)
+}
+
+func commandItems(cmd *cobra.Command, storeIDs readline.DynamicCompleteFunc) []readline.PrefixCompleterInterface {
+	var items []readline.PrefixCompleterInterface
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() {
+			continue
+		}
+		children := commandItems(sub, storeIDs)
+		if takesStoreID(sub) {
+			children = append(children, readline.PcItemDynamic(storeIDs))
+		}
+		items = append(items, readline.PcItem(sub.Name(), children...))
+	}
+	return items
+}
+
+// takesStoreID returns whether the first argument of the command is a store
+// ID, the optional arguments in brackets are skipped.
+func takesStoreID(cmd *cobra.Command) bool {
+	for _, arg := range strings.Fields(cmd.Use)[1:] {
+		if !strings.HasPrefix(arg, "[") {
+			return arg == "<store_id>"
+		}
+	}
+	return false
+}
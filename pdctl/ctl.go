@@ -23,7 +23,9 @@ import (
 
 // CommandFlags are flags that used in all Commands
 type CommandFlags struct {
-	URL string
+	URL   string
+	Token string
+	User  string
 }
 
 var (
@@ -36,6 +38,8 @@ var (
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&commandFlags.URL, "pd", "u", "http://127.0.0.1:2379", "pd address")
+	rootCmd.PersistentFlags().StringVar(&commandFlags.Token, "token", "", "token to authenticate the changes")
+	rootCmd.PersistentFlags().StringVar(&commandFlags.User, "user", "", "user name and password to authenticate the changes, in the form of name:password")
 	rootCmd.AddCommand(
 		command.NewConfigCommand(),
 		command.NewRegionCommand(),
@@ -49,6 +53,10 @@ func init() {
 		command.NewTSOCommand(),
 		command.NewHotSpotCommand(),
 		command.NewClusterCommand(),
+		command.NewScriptCommand(),
+		command.NewPlacementCommand(),
+		command.NewNamespaceCommand(),
+		command.NewLogCommand(),
 	)
 	cobra.EnablePrefixMatching = true
 }
@@ -14,6 +14,7 @@
 package etcdutil
 
 import (
+	"crypto/tls"
 	"net/url"
 	"strings"
 	"time"
@@ -47,8 +48,9 @@ const (
 var unixToHTTP = strings.NewReplacer("unix://", "http://", "unixs://", "http://")
 
 // CheckClusterID checks Etcd's cluster ID, returns an error if mismatch.
-// This function will never block even quorum is not satisfied.
-func CheckClusterID(localClusterID types.ID, um types.URLsMap) error {
+// This function will never block even quorum is not satisfied. The peers are
+// connected with tlsConfig if it is not nil.
+func CheckClusterID(localClusterID types.ID, um types.URLsMap, tlsConfig *tls.Config) error {
 	if len(um) == 0 {
 		return nil
 	}
@@ -64,6 +66,7 @@ func CheckClusterID(localClusterID types.ID, um types.URLsMap) error {
 			return errors.Trace(gerr)
 		}
 		trp := apiutil.NewHTTPTransport(u.Scheme)
+		trp.TLSClientConfig = tlsConfig
 
 		// For tests, change scheme to http.
 		// etcdserver/api/v3rpc does not recognize unix protocol.
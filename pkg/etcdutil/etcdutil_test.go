@@ -140,7 +140,7 @@ func (s *testEtcdutilSuite) TestMemberHelpers(c *C) {
 	// Test CheckClusterID
 	urlmap, err := types.NewURLsMap(cfg2.InitialCluster)
 	c.Assert(err, IsNil)
-	err = CheckClusterID(etcd1.Server.Cluster().ID(), urlmap)
+	err = CheckClusterID(etcd1.Server.Cluster().ID(), urlmap, nil)
 	c.Assert(err, IsNil)
 
 	// Test RemoveEtcdMember
@@ -139,6 +139,17 @@ func stringToLogLevel(level string) log.Level {
 	return defaultLogLevel
 }
 
+// SetLevel changes the log level online.
+func SetLevel(level string) error {
+	switch strings.ToLower(level) {
+	case "fatal", "error", "warn", "warning", "debug", "info":
+	default:
+		return errors.Errorf("invalid log level %q", level)
+	}
+	log.SetLevel(stringToLogLevel(level))
+	return nil
+}
+
 // textFormatter is for compatability with ngaut/log
 type textFormatter struct {
 	DisableTimestamp bool
@@ -51,6 +51,14 @@ func (s *testLogSuite) TestStringToLogLevel(c *C) {
 	c.Assert(stringToLogLevel("whatever"), Equals, log.InfoLevel)
 }
 
+func (s *testLogSuite) TestSetLevel(c *C) {
+	defer log.SetLevel(log.GetLevel())
+	c.Assert(SetLevel("DEBUG"), IsNil)
+	c.Assert(log.GetLevel(), Equals, log.DebugLevel)
+	c.Assert(SetLevel("whatever"), NotNil)
+	c.Assert(log.GetLevel(), Equals, log.DebugLevel)
+}
+
 // TestLogging assure log format and log redirection works.
 func (s *testLogSuite) TestLogging(c *C) {
 	conf := &LogConfig{Level: "warn", File: FileLogConfig{}}
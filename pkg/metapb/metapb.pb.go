@@ -3,18 +3,20 @@
 // DO NOT EDIT!
 
 /*
-	Package metapb is a generated protocol buffer package.
-
-	It is generated from these files:
-		metapb.proto
-
-	It has these top-level messages:
-		Cluster
-		StoreLabel
-		Store
-		RegionEpoch
-		Region
-		Peer
+Package metapb is a generated protocol buffer package.
+
+It is generated from these files:
+
+	metapb.proto
+
+It has these top-level messages:
+
+	Cluster
+	StoreLabel
+	Store
+	RegionEpoch
+	Region
+	Peer
 */
 package metapb
 
@@ -74,6 +76,58 @@ func (x *StoreState) UnmarshalJSON(data []byte) error {
 }
 func (StoreState) EnumDescriptor() ([]byte, []int) { return fileDescriptorMetapb, []int{0} }
 
+type PeerRole int32
+
+const (
+	// Voters vote and can become leaders.
+	PeerRole_Voter PeerRole = 0
+	// Learners and observers receive the raft log but don't vote, observers
+	// are never promoted automatically.
+	PeerRole_Learner  PeerRole = 1
+	PeerRole_Observer PeerRole = 2
+	// Witnesses vote but only keep the raft log, they never become leaders.
+	PeerRole_Witness PeerRole = 3
+	// The voters in the joint consensus, incoming voters are being promoted
+	// and demoting voters are being demoted to learners.
+	PeerRole_IncomingVoter PeerRole = 4
+	PeerRole_DemotingVoter PeerRole = 5
+)
+
+var PeerRole_name = map[int32]string{
+	0: "Voter",
+	1: "Learner",
+	2: "Observer",
+	3: "Witness",
+	4: "IncomingVoter",
+	5: "DemotingVoter",
+}
+var PeerRole_value = map[string]int32{
+	"Voter":         0,
+	"Learner":       1,
+	"Observer":      2,
+	"Witness":       3,
+	"IncomingVoter": 4,
+	"DemotingVoter": 5,
+}
+
+func (x PeerRole) Enum() *PeerRole {
+	p := new(PeerRole)
+	*p = x
+	return p
+}
+func (x PeerRole) String() string {
+	return proto.EnumName(PeerRole_name, int32(x))
+}
+func (x *PeerRole) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(PeerRole_value, data, "PeerRole")
+	if err != nil {
+		return err
+	}
+	*x = PeerRole(value)
+	return nil
+}
+func (PeerRole) EnumDescriptor() ([]byte, []int) { return fileDescriptorMetapb, []int{1} }
+
 type Cluster struct {
 	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id"`
 	// max peer count for a region.
@@ -246,9 +300,10 @@ func (m *Region) GetPeers() []*Peer {
 }
 
 type Peer struct {
-	Id               uint64 `protobuf:"varint,1,opt,name=id" json:"id"`
-	StoreId          uint64 `protobuf:"varint,2,opt,name=store_id,json=storeId" json:"store_id"`
-	XXX_unrecognized []byte `json:"-"`
+	Id               uint64   `protobuf:"varint,1,opt,name=id" json:"id"`
+	StoreId          uint64   `protobuf:"varint,2,opt,name=store_id,json=storeId" json:"store_id"`
+	Role             PeerRole `protobuf:"varint,3,opt,name=role,enum=metapb.PeerRole" json:"role"`
+	XXX_unrecognized []byte   `json:"-"`
 }
 
 func (m *Peer) Reset()                    { *m = Peer{} }
@@ -270,6 +325,13 @@ func (m *Peer) GetStoreId() uint64 {
 	return 0
 }
 
+func (m *Peer) GetRole() PeerRole {
+	if m != nil {
+		return m.Role
+	}
+	return PeerRole_Voter
+}
+
 func init() {
 	proto.RegisterType((*Cluster)(nil), "metapb.Cluster")
 	proto.RegisterType((*StoreLabel)(nil), "metapb.StoreLabel")
@@ -278,6 +340,7 @@ func init() {
 	proto.RegisterType((*Region)(nil), "metapb.Region")
 	proto.RegisterType((*Peer)(nil), "metapb.Peer")
 	proto.RegisterEnum("metapb.StoreState", StoreState_name, StoreState_value)
+	proto.RegisterEnum("metapb.PeerRole", PeerRole_name, PeerRole_value)
 }
 func (m *Cluster) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -484,6 +547,9 @@ func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x10
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.StoreId))
+	dAtA[i] = 0x18
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Role))
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -604,6 +670,7 @@ func (m *Peer) Size() (n int) {
 	_ = l
 	n += 1 + sovMetapb(uint64(m.Id))
 	n += 1 + sovMetapb(uint64(m.StoreId))
+	n += 1 + sovMetapb(uint64(m.Role))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1322,6 +1389,25 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= (PeerRole(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -1452,34 +1538,39 @@ var (
 func init() { proto.RegisterFile("metapb.proto", fileDescriptorMetapb) }
 
 var fileDescriptorMetapb = []byte{
-	// 463 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xce, 0xe6, 0xc7, 0x4e, 0xc6, 0x6e, 0x15, 0x2d, 0x15, 0x58, 0x45, 0x72, 0x2c, 0x9f, 0xac,
-	0x1c, 0x0c, 0xea, 0x81, 0x1b, 0x12, 0x6a, 0xc5, 0x01, 0x15, 0x01, 0x72, 0x81, 0xab, 0xe5, 0xd8,
-	0x93, 0x60, 0xc5, 0xf6, 0x5a, 0xbb, 0x1b, 0xab, 0x7d, 0x13, 0x78, 0x0b, 0x1e, 0xa3, 0x47, 0x9e,
-	0x00, 0xa1, 0xf0, 0x22, 0x68, 0xd7, 0x36, 0x6a, 0x2a, 0xe5, 0xe6, 0xfd, 0xbe, 0x99, 0xcf, 0xdf,
-	0x37, 0x33, 0x60, 0x97, 0x28, 0x93, 0x7a, 0x15, 0xd6, 0x9c, 0x49, 0x46, 0x8d, 0xf6, 0x75, 0x7e,
-	0xb6, 0x61, 0x1b, 0xa6, 0xa1, 0x17, 0xea, 0xab, 0x65, 0xfd, 0x6b, 0x30, 0xaf, 0x8a, 0x9d, 0x90,
-	0xc8, 0xe9, 0x19, 0x0c, 0xf3, 0xcc, 0x21, 0x1e, 0x09, 0xc6, 0x97, 0xe3, 0xfb, 0xdf, 0x8b, 0x41,
-	0x34, 0xcc, 0x33, 0xba, 0x84, 0xd3, 0x32, 0xb9, 0x8d, 0x6b, 0x44, 0x1e, 0xa7, 0x6c, 0x57, 0x49,
-	0x67, 0xe8, 0x91, 0xe0, 0xa4, 0xab, 0xb0, 0xcb, 0xe4, 0xf6, 0x13, 0x22, 0xbf, 0x52, 0x8c, 0xff,
-	0x06, 0xe0, 0x46, 0x32, 0x8e, 0xef, 0x93, 0x15, 0x16, 0xf4, 0x29, 0x8c, 0xb6, 0x78, 0xa7, 0x05,
-	0x67, 0x5d, 0xb9, 0x02, 0xe8, 0x39, 0x4c, 0x9a, 0xa4, 0xd8, 0xa1, 0x16, 0xea, 0x99, 0x16, 0xf2,
-	0x7f, 0x10, 0x98, 0x68, 0x89, 0x23, 0x6e, 0x5c, 0x30, 0x93, 0x2c, 0xe3, 0x28, 0xc4, 0x41, 0x77,
-	0x0f, 0xd2, 0x10, 0x26, 0x42, 0x26, 0x12, 0x9d, 0x91, 0x47, 0x82, 0xd3, 0x0b, 0x1a, 0x76, 0xa3,
-	0xd0, 0x9a, 0x37, 0x8a, 0xe9, 0xff, 0xa7, 0xcb, 0xe8, 0x12, 0x8c, 0x42, 0x99, 0x15, 0xce, 0xd8,
-	0x1b, 0x05, 0xd6, 0xa3, 0x06, 0x9d, 0x23, 0xea, 0x2a, 0xfc, 0x0f, 0x60, 0x45, 0xb8, 0xc9, 0x59,
-	0xf5, 0xb6, 0x66, 0xe9, 0x37, 0xba, 0x80, 0x69, 0xca, 0xaa, 0x75, 0xdc, 0x20, 0x3f, 0xb0, 0x69,
-	0x2a, 0xf4, 0x2b, 0x72, 0xe5, 0xb5, 0x41, 0x2e, 0x72, 0x56, 0x69, 0xaf, 0xff, 0xf9, 0x0e, 0xf4,
-	0x7f, 0x12, 0x30, 0x5a, 0xc1, 0x23, 0x61, 0x9f, 0xc3, 0x4c, 0xc8, 0x84, 0xcb, 0x58, 0x8d, 0x51,
-	0x49, 0xd8, 0xd1, 0x54, 0x03, 0xd7, 0x78, 0x47, 0x9f, 0x81, 0x89, 0x55, 0xa6, 0xa9, 0x91, 0xa6,
-	0x0c, 0xac, 0x32, 0x45, 0xbc, 0x02, 0x9b, 0x6b, 0xd5, 0x18, 0x95, 0x4f, 0x67, 0xec, 0x91, 0xc0,
-	0xba, 0x78, 0xd2, 0x07, 0x7b, 0x10, 0x21, 0xb2, 0xf8, 0x83, 0x3c, 0x3e, 0x4c, 0xd4, 0x92, 0x85,
-	0x33, 0xd1, 0x93, 0xb0, 0xfb, 0x06, 0xb5, 0xde, 0xa8, 0xa5, 0xfc, 0xd7, 0x30, 0x56, 0xcf, 0x23,
-	0x7e, 0x17, 0x30, 0x15, 0x6a, 0x6c, 0x71, 0x9e, 0x1d, 0x26, 0xd6, 0xe8, 0xbb, 0x6c, 0xf9, 0xb2,
-	0xbb, 0x0f, 0xbd, 0x08, 0x6a, 0xc0, 0xf0, 0x4b, 0x3d, 0x1f, 0x50, 0x0b, 0xcc, 0x8f, 0xeb, 0x75,
-	0x91, 0x57, 0x38, 0x27, 0xf4, 0x04, 0x66, 0x9f, 0x59, 0xb9, 0x12, 0x92, 0x55, 0x38, 0x1f, 0x5e,
-	0x2e, 0xef, 0xf7, 0x2e, 0xf9, 0xb5, 0x77, 0xc9, 0x9f, 0xbd, 0x4b, 0xbe, 0xff, 0x75, 0x07, 0xe0,
-	0xa4, 0xac, 0x0c, 0xeb, 0xbc, 0xda, 0xa4, 0x49, 0x1d, 0xca, 0x7c, 0xdb, 0x84, 0xdb, 0x46, 0x9f,
-	0xf2, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5f, 0x7f, 0x55, 0x83, 0xf7, 0x02, 0x00, 0x00,
+	// 531 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xed, 0x38, 0x76, 0x7e, 0x6e, 0xdc, 0xca, 0xdf, 0x7c, 0x15, 0x58, 0x45, 0x4a, 0x23, 0xaf,
+	0xa2, 0x2c, 0x0c, 0xea, 0x82, 0x35, 0x6a, 0x61, 0x51, 0xb5, 0xa2, 0xc8, 0x85, 0xb2, 0xb4, 0x1c,
+	0xfb, 0x26, 0x58, 0xb1, 0x67, 0xac, 0x99, 0x89, 0xd5, 0xbe, 0x09, 0xbc, 0x05, 0x8f, 0xd1, 0x25,
+	0x4f, 0x80, 0x50, 0x78, 0x11, 0x34, 0xe3, 0x98, 0x26, 0x48, 0xd9, 0xe5, 0x9e, 0x73, 0xef, 0x99,
+	0x73, 0xef, 0x89, 0xc1, 0x2d, 0x51, 0x25, 0xd5, 0x2c, 0xac, 0x04, 0x57, 0x9c, 0x76, 0x9b, 0xea,
+	0xe4, 0x78, 0xc1, 0x17, 0xdc, 0x40, 0x2f, 0xf5, 0xaf, 0x86, 0x0d, 0xae, 0xa0, 0x77, 0x51, 0xac,
+	0xa4, 0x42, 0x41, 0x8f, 0xc1, 0xca, 0x33, 0x9f, 0x8c, 0xc9, 0xc4, 0x3e, 0xb7, 0x1f, 0x7f, 0x9e,
+	0x1e, 0x44, 0x56, 0x9e, 0xd1, 0x29, 0x1c, 0x95, 0xc9, 0x7d, 0x5c, 0x21, 0x8a, 0x38, 0xe5, 0x2b,
+	0xa6, 0x7c, 0x6b, 0x4c, 0x26, 0x87, 0x9b, 0x0e, 0xb7, 0x4c, 0xee, 0x3f, 0x20, 0x8a, 0x0b, 0xcd,
+	0x04, 0x6f, 0x00, 0x6e, 0x15, 0x17, 0x78, 0x9d, 0xcc, 0xb0, 0xa0, 0xcf, 0xa0, 0xb3, 0xc4, 0x07,
+	0x23, 0x38, 0xd8, 0xb4, 0x6b, 0x80, 0x9e, 0x80, 0x53, 0x27, 0xc5, 0x0a, 0x7d, 0x6b, 0x8b, 0x69,
+	0xa0, 0xe0, 0x1b, 0x01, 0xc7, 0x48, 0xec, 0x71, 0x33, 0x82, 0x5e, 0x92, 0x65, 0x02, 0xa5, 0xdc,
+	0x99, 0x6e, 0x41, 0x1a, 0x82, 0x23, 0x55, 0xa2, 0xd0, 0xef, 0x8c, 0xc9, 0xe4, 0xe8, 0x8c, 0x86,
+	0x9b, 0x53, 0x18, 0xcd, 0x5b, 0xcd, 0xb4, 0xef, 0x99, 0x36, 0x3a, 0x85, 0x6e, 0xa1, 0xcd, 0x4a,
+	0xdf, 0x1e, 0x77, 0x26, 0xc3, 0x7f, 0x06, 0xcc, 0x1e, 0xd1, 0xa6, 0x23, 0x78, 0x0f, 0xc3, 0x08,
+	0x17, 0x39, 0x67, 0xef, 0x2a, 0x9e, 0x7e, 0xa1, 0xa7, 0xd0, 0x4f, 0x39, 0x9b, 0xc7, 0x35, 0x8a,
+	0x1d, 0x9b, 0x3d, 0x8d, 0xde, 0xa1, 0xd0, 0x5e, 0x6b, 0x14, 0x32, 0xe7, 0xcc, 0xb7, 0xb6, 0xf9,
+	0x0d, 0x18, 0x7c, 0x27, 0xd0, 0x6d, 0x04, 0xf7, 0x2c, 0xfb, 0x02, 0x06, 0x52, 0x25, 0x42, 0xc5,
+	0xfa, 0x8c, 0x5a, 0xc2, 0x8d, 0xfa, 0x06, 0xb8, 0xc2, 0x07, 0xfa, 0x1c, 0x7a, 0xc8, 0x32, 0x43,
+	0x75, 0x0c, 0xd5, 0x45, 0x96, 0x69, 0xe2, 0x35, 0xb8, 0xc2, 0xa8, 0xc6, 0xa8, 0x7d, 0xfa, 0xf6,
+	0x98, 0x4c, 0x86, 0x67, 0xff, 0xb7, 0x8b, 0x6d, 0xad, 0x10, 0x0d, 0xc5, 0x53, 0x41, 0x03, 0x70,
+	0x74, 0xc8, 0xd2, 0x77, 0xcc, 0x25, 0xdc, 0x76, 0x40, 0xc7, 0x1b, 0x35, 0x54, 0x90, 0x83, 0xad,
+	0xcb, 0x3d, 0x7e, 0x4f, 0xa1, 0x2f, 0xf5, 0xd9, 0xe2, 0x3c, 0xdb, 0xdd, 0xd8, 0xa0, 0x97, 0xfa,
+	0xbf, 0x64, 0x0b, 0x5e, 0xb4, 0xe1, 0x78, 0x3b, 0x2f, 0xf0, 0xa2, 0x8d, 0xc6, 0xf4, 0x4c, 0x5f,
+	0x01, 0x3c, 0x85, 0x46, 0xbb, 0x60, 0x7d, 0xaa, 0xbc, 0x03, 0x3a, 0x84, 0xde, 0xcd, 0x7c, 0x5e,
+	0xe4, 0x0c, 0x3d, 0x42, 0x0f, 0x61, 0xf0, 0x91, 0x97, 0x33, 0xa9, 0x38, 0x43, 0xcf, 0x9a, 0xa6,
+	0xd0, 0x6f, 0x95, 0xe8, 0x00, 0x9c, 0x3b, 0xae, 0x50, 0x34, 0x23, 0xd7, 0x98, 0x08, 0x86, 0xc2,
+	0x23, 0xd4, 0x85, 0xfe, 0xcd, 0x4c, 0xa2, 0xa8, 0x51, 0x78, 0x96, 0xa6, 0x3e, 0xe7, 0x8a, 0xa1,
+	0x94, 0x5e, 0x87, 0xfe, 0x07, 0x87, 0x97, 0x2c, 0xe5, 0x65, 0xce, 0x16, 0xcd, 0xa8, 0xad, 0xa1,
+	0xb7, 0x58, 0x72, 0xf5, 0x17, 0x72, 0xce, 0xa7, 0x8f, 0xeb, 0x11, 0xf9, 0xb1, 0x1e, 0x91, 0x5f,
+	0xeb, 0x11, 0xf9, 0xfa, 0x7b, 0x74, 0x00, 0x7e, 0xca, 0xcb, 0xb0, 0xca, 0xd9, 0x22, 0x4d, 0xaa,
+	0x50, 0xe5, 0xcb, 0x3a, 0x5c, 0xd6, 0xe6, 0xdb, 0xfa, 0x33, 0x00, 0x34, 0xec, 0x35, 0x1e, 0x88,
+	0x03, 0x00, 0x00,
 }
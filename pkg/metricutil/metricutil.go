@@ -62,12 +62,12 @@ func camelCaseToSnakeCase(str string) string {
 }
 
 // prometheusPushClient pushs metrics to Prometheus Pushgateway.
-func prometheusPushClient(job, addr string, interval time.Duration) {
+func prometheusPushClient(job, addr string, interval time.Duration, gatherer prometheus.Gatherer) {
 	for {
 		err := push.FromGatherer(
 			job, push.HostnameGroupingKey(),
 			addr,
-			prometheus.DefaultGatherer,
+			gatherer,
 		)
 		if err != nil {
 			log.Errorf("could not push metrics to Prometheus Pushgateway: %v", err)
@@ -77,8 +77,8 @@ func prometheusPushClient(job, addr string, interval time.Duration) {
 	}
 }
 
-// Push metircs in background.
-func Push(cfg *MetricConfig) {
+// Push metircs of the gatherer in background.
+func Push(cfg *MetricConfig, gatherer prometheus.Gatherer) {
 	if cfg.PushInterval.Duration == zeroDuration || len(cfg.PushAddress) == 0 {
 		log.Info("disable Prometheus push client")
 		return
@@ -87,5 +87,5 @@ func Push(cfg *MetricConfig) {
 	log.Info("start Prometheus push client")
 
 	interval := cfg.PushInterval.Duration
-	go prometheusPushClient(cfg.PushJob, cfg.PushAddress, interval)
+	go prometheusPushClient(cfg.PushJob, cfg.PushAddress, interval, gatherer)
 }
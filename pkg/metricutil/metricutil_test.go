@@ -19,6 +19,7 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/pd/pkg/typeutil"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func Test(t *testing.T) {
@@ -81,6 +82,6 @@ func (s *testMetricsSuite) TestCoverage(c *C) {
 	}
 
 	for _, cfg := range cfgs {
-		Push(cfg)
+		Push(cfg, prometheus.DefaultGatherer)
 	}
 }
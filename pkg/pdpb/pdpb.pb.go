@@ -3,50 +3,80 @@
 // DO NOT EDIT!
 
 /*
-	Package pdpb is a generated protocol buffer package.
-
-	It is generated from these files:
-		pdpb.proto
-
-	It has these top-level messages:
-		RequestHeader
-		ResponseHeader
-		Error
-		TsoRequest
-		Timestamp
-		TsoResponse
-		BootstrapRequest
-		BootstrapResponse
-		IsBootstrappedRequest
-		IsBootstrappedResponse
-		AllocIDRequest
-		AllocIDResponse
-		GetStoreRequest
-		GetStoreResponse
-		PutStoreRequest
-		PutStoreResponse
-		GetRegionRequest
-		GetRegionResponse
-		GetRegionByIDRequest
-		GetClusterConfigRequest
-		GetClusterConfigResponse
-		PutClusterConfigRequest
-		PutClusterConfigResponse
-		Member
-		GetMembersRequest
-		GetMembersResponse
-		PeerStats
-		RegionHeartbeatRequest
-		ChangePeer
-		TransferLeader
-		RegionHeartbeatResponse
-		AskSplitRequest
-		AskSplitResponse
-		ReportSplitRequest
-		ReportSplitResponse
-		StoreStats
-		StoreHeartbeatRequest
-		StoreHeartbeatResponse
+Package pdpb is a generated protocol buffer package.
+
+It is generated from these files:
+
+	pdpb.proto
+
+It has these top-level messages:
+
+	RequestHeader
+	ResponseHeader
+	Error
+	TsoRequest
+	Timestamp
+	TsoResponse
+	BootstrapRequest
+	BootstrapResponse
+	IsBootstrappedRequest
+	IsBootstrappedResponse
+	AllocIDRequest
+	AllocIDResponse
+	GetStoreRequest
+	GetStoreResponse
+	PutStoreRequest
+	PutStoreResponse
+	GetRegionRequest
+	GetRegionResponse
+	GetRegionByIDRequest
+	GetClusterConfigRequest
+	GetClusterConfigResponse
+	PutClusterConfigRequest
+	PutClusterConfigResponse
+	Member
+	GetMembersRequest
+	GetMembersResponse
+	PeerStats
+	RegionHeartbeatRequest
+	ChangePeer
+	ChangePeerV2
+	TransferLeader
+	RegionHeartbeatResponse
+	Merge
+	SplitRegion
+	AskSplitRequest
+	AskSplitResponse
+	ReportSplitRequest
+	ReportSplitResponse
+	StoreStats
+	StoreHeartbeatRequest
+	StoreHeartbeatResponse
+	GetAllStoresRequest
+	GetAllStoresResponse
+	ScanRegionsRequest
+	ScanRegionsResponse
+	ScatterRegionRequest
+	ScatterRegionResponse
+	GetOperatorRequest
+	GetOperatorResponse
+	GetGCSafePointRequest
+	GetGCSafePointResponse
+	UpdateGCSafePointRequest
+	UpdateGCSafePointResponse
+	UpdateServiceGCSafePointRequest
+	UpdateServiceGCSafePointResponse
+	AskBatchSplitRequest
+	SplitID
+	AskBatchSplitResponse
+	ReportBatchSplitRequest
+	ReportBatchSplitResponse
+	SyncRegionRequest
+	SyncRegionResponse
+	ReplicationStatus
+	StoreStateCount
+	GetClusterStatusRequest
+	GetClusterStatusResponse
 */
 package pdpb
 
@@ -57,7 +87,7 @@ import (
 
 	proto "github.com/golang/protobuf/proto"
 
-	metapb "github.com/pingcap/kvproto/pkg/metapb"
+	metapb "github.com/pingcap/pd/pkg/metapb"
 
 	context "golang.org/x/net/context"
 
@@ -111,17 +141,20 @@ func (ErrorType) EnumDescriptor() ([]byte, []int) { return fileDescriptorPdpb, [
 type ConfChangeType int32
 
 const (
-	ConfChangeType_AddNode    ConfChangeType = 0
-	ConfChangeType_RemoveNode ConfChangeType = 1
+	ConfChangeType_AddNode        ConfChangeType = 0
+	ConfChangeType_RemoveNode     ConfChangeType = 1
+	ConfChangeType_AddLearnerNode ConfChangeType = 2
 )
 
 var ConfChangeType_name = map[int32]string{
 	0: "AddNode",
 	1: "RemoveNode",
+	2: "AddLearnerNode",
 }
 var ConfChangeType_value = map[string]int32{
-	"AddNode":    0,
-	"RemoveNode": 1,
+	"AddNode":        0,
+	"RemoveNode":     1,
+	"AddLearnerNode": 2,
 }
 
 func (x ConfChangeType) String() string {
@@ -129,9 +162,95 @@ func (x ConfChangeType) String() string {
 }
 func (ConfChangeType) EnumDescriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{1} }
 
+type CheckPolicy int32
+
+const (
+	CheckPolicy_SCAN        CheckPolicy = 0
+	CheckPolicy_APPROXIMATE CheckPolicy = 1
+	CheckPolicy_USEKEY      CheckPolicy = 2
+)
+
+var CheckPolicy_name = map[int32]string{
+	0: "SCAN",
+	1: "APPROXIMATE",
+	2: "USEKEY",
+}
+var CheckPolicy_value = map[string]int32{
+	"SCAN":        0,
+	"APPROXIMATE": 1,
+	"USEKEY":      2,
+}
+
+func (x CheckPolicy) String() string {
+	return proto.EnumName(CheckPolicy_name, int32(x))
+}
+func (CheckPolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{2} }
+
+type OperatorStatus int32
+
+const (
+	OperatorStatus_NONE     OperatorStatus = 0
+	OperatorStatus_WAITING  OperatorStatus = 1
+	OperatorStatus_RUNNING  OperatorStatus = 2
+	OperatorStatus_FINISHED OperatorStatus = 3
+	OperatorStatus_TIMEOUT  OperatorStatus = 4
+	OperatorStatus_REPLACED OperatorStatus = 5
+)
+
+var OperatorStatus_name = map[int32]string{
+	0: "NONE",
+	1: "WAITING",
+	2: "RUNNING",
+	3: "FINISHED",
+	4: "TIMEOUT",
+	5: "REPLACED",
+}
+var OperatorStatus_value = map[string]int32{
+	"NONE":     0,
+	"WAITING":  1,
+	"RUNNING":  2,
+	"FINISHED": 3,
+	"TIMEOUT":  4,
+	"REPLACED": 5,
+}
+
+func (x OperatorStatus) String() string {
+	return proto.EnumName(OperatorStatus_name, int32(x))
+}
+func (OperatorStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{3} }
+
+// A clone of metapb.StoreState, it exists because proto2 enums cannot be used
+// directly in proto3 syntax.
+type StoreState int32
+
+const (
+	StoreState_Up        StoreState = 0
+	StoreState_Offline   StoreState = 1
+	StoreState_Tombstone StoreState = 2
+)
+
+var StoreState_name = map[int32]string{
+	0: "Up",
+	1: "Offline",
+	2: "Tombstone",
+}
+var StoreState_value = map[string]int32{
+	"Up":        0,
+	"Offline":   1,
+	"Tombstone": 2,
+}
+
+func (x StoreState) String() string {
+	return proto.EnumName(StoreState_name, int32(x))
+}
+func (StoreState) EnumDescriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{4} }
+
 type RequestHeader struct {
 	// cluster_id is the ID of the cluster which be sent to.
 	ClusterId uint64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	// If not 0, read-only requests can be served by followers with data no
+	// older than it.
+	MaxStalenessMs uint64 `protobuf:"varint,2,opt,name=max_staleness_ms,json=maxStalenessMs,proto3" json:"max_staleness_ms,omitempty"`
 }
 
 func (m *RequestHeader) Reset()                    { *m = RequestHeader{} }
@@ -146,6 +265,13 @@ func (m *RequestHeader) GetClusterId() uint64 {
 	return 0
 }
 
+func (m *RequestHeader) GetMaxStalenessMs() uint64 {
+	if m != nil {
+		return m.MaxStalenessMs
+	}
+	return 0
+}
+
 type ResponseHeader struct {
 	// cluster_id is the ID of the cluster which sent the response.
 	ClusterId uint64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
@@ -198,6 +324,9 @@ func (m *Error) GetMessage() string {
 type TsoRequest struct {
 	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Count  uint32         `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Allocate the timestamps from the local allocator of the data center,
+	// empty means the global allocator.
+	DcLocation string `protobuf:"bytes,3,opt,name=dc_location,json=dcLocation,proto3" json:"dc_location,omitempty"`
 }
 
 func (m *TsoRequest) Reset()                    { *m = TsoRequest{} }
@@ -219,6 +348,13 @@ func (m *TsoRequest) GetCount() uint32 {
 	return 0
 }
 
+func (m *TsoRequest) GetDcLocation() string {
+	if m != nil {
+		return m.DcLocation
+	}
+	return ""
+}
+
 type Timestamp struct {
 	Physical int64 `protobuf:"varint,1,opt,name=physical,proto3" json:"physical,omitempty"`
 	Logical  int64 `protobuf:"varint,2,opt,name=logical,proto3" json:"logical,omitempty"`
@@ -365,6 +501,8 @@ func (m *IsBootstrappedResponse) GetBootstrapped() bool {
 
 type AllocIDRequest struct {
 	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The number of consecutive IDs to allocate, 0 means 1.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
 func (m *AllocIDRequest) Reset()                    { *m = AllocIDRequest{} }
@@ -379,9 +517,18 @@ func (m *AllocIDRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *AllocIDRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type AllocIDResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Id     uint64          `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// The IDs allocated are [id, id+count).
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 }
 
 func (m *AllocIDResponse) Reset()                    { *m = AllocIDResponse{} }
@@ -403,6 +550,13 @@ func (m *AllocIDResponse) GetId() uint64 {
 	return 0
 }
 
+func (m *AllocIDResponse) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type GetStoreRequest struct {
 	Header  *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	StoreId uint64         `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
@@ -516,9 +670,11 @@ func (m *GetRegionRequest) GetRegionKey() []byte {
 }
 
 type GetRegionResponse struct {
-	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	Region *metapb.Region  `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
-	Leader *metapb.Peer    `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
+	Header       *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Region       *metapb.Region  `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
+	Leader       *metapb.Peer    `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
+	DownPeers    []*PeerStats    `protobuf:"bytes,4,rep,name=down_peers,json=downPeers" json:"down_peers,omitempty"`
+	PendingPeers []*metapb.Peer  `protobuf:"bytes,5,rep,name=pending_peers,json=pendingPeers" json:"pending_peers,omitempty"`
 }
 
 func (m *GetRegionResponse) Reset()                    { *m = GetRegionResponse{} }
@@ -547,6 +703,20 @@ func (m *GetRegionResponse) GetLeader() *metapb.Peer {
 	return nil
 }
 
+func (m *GetRegionResponse) GetDownPeers() []*PeerStats {
+	if m != nil {
+		return m.DownPeers
+	}
+	return nil
+}
+
+func (m *GetRegionResponse) GetPendingPeers() []*metapb.Peer {
+	if m != nil {
+		return m.PendingPeers
+	}
+	return nil
+}
+
 type GetRegionByIDRequest struct {
 	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	RegionId uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
@@ -658,6 +828,8 @@ type Member struct {
 	MemberId   uint64   `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
 	PeerUrls   []string `protobuf:"bytes,3,rep,name=peer_urls,json=peerUrls" json:"peer_urls,omitempty"`
 	ClientUrls []string `protobuf:"bytes,4,rep,name=client_urls,json=clientUrls" json:"client_urls,omitempty"`
+	// The data center of the member, empty if not set.
+	DcLocation string `protobuf:"bytes,5,opt,name=dc_location,json=dcLocation,proto3" json:"dc_location,omitempty"`
 }
 
 func (m *Member) Reset()                    { *m = Member{} }
@@ -693,6 +865,13 @@ func (m *Member) GetClientUrls() []string {
 	return nil
 }
 
+func (m *Member) GetDcLocation() string {
+	if m != nil {
+		return m.DcLocation
+	}
+	return ""
+}
+
 type GetMembersRequest struct {
 	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
@@ -713,6 +892,8 @@ type GetMembersResponse struct {
 	Header  *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Members []*Member       `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
 	Leader  *Member         `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
+	// The members serving the local TSO allocators of the data centers.
+	LocalTsoLeaders []*Member `protobuf:"bytes,4,rep,name=local_tso_leaders,json=localTsoLeaders" json:"local_tso_leaders,omitempty"`
 }
 
 func (m *GetMembersResponse) Reset()                    { *m = GetMembersResponse{} }
@@ -741,6 +922,13 @@ func (m *GetMembersResponse) GetLeader() *Member {
 	return nil
 }
 
+func (m *GetMembersResponse) GetLocalTsoLeaders() []*Member {
+	if m != nil {
+		return m.LocalTsoLeaders
+	}
+	return nil
+}
+
 type PeerStats struct {
 	Peer        *metapb.Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
 	DownSeconds uint64       `protobuf:"varint,2,opt,name=down_seconds,json=downSeconds,proto3" json:"down_seconds,omitempty"`
@@ -781,6 +969,10 @@ type RegionHeartbeatRequest struct {
 	// Keys read/written during this period.
 	KeysWritten uint64 `protobuf:"varint,8,opt,name=keys_written,json=keysWritten,proto3" json:"keys_written,omitempty"`
 	KeysRead    uint64 `protobuf:"varint,9,opt,name=keys_read,json=keysRead,proto3" json:"keys_read,omitempty"`
+	// Approximate region size in bytes, 0 if unknown.
+	ApproximateSize uint64 `protobuf:"varint,10,opt,name=approximate_size,json=approximateSize,proto3" json:"approximate_size,omitempty"`
+	// Approximate number of keys in the region.
+	ApproximateKeys uint64 `protobuf:"varint,11,opt,name=approximate_keys,json=approximateKeys,proto3" json:"approximate_keys,omitempty"`
 }
 
 func (m *RegionHeartbeatRequest) Reset()                    { *m = RegionHeartbeatRequest{} }
@@ -851,6 +1043,20 @@ func (m *RegionHeartbeatRequest) GetKeysRead() uint64 {
 	return 0
 }
 
+func (m *RegionHeartbeatRequest) GetApproximateSize() uint64 {
+	if m != nil {
+		return m.ApproximateSize
+	}
+	return 0
+}
+
+func (m *RegionHeartbeatRequest) GetApproximateKeys() uint64 {
+	if m != nil {
+		return m.ApproximateKeys
+	}
+	return 0
+}
+
 type ChangePeer struct {
 	Peer *metapb.Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
 	// FIXME: replace with actual ConfChangeType once eraftpb uses proto3.
@@ -876,6 +1082,25 @@ func (m *ChangePeer) GetChangeType() ConfChangeType {
 	return ConfChangeType_AddNode
 }
 
+// Change the peers in a joint consensus. The changes enter the joint state,
+// the voters are promoted by AddNode and demoted by AddLearnerNode. Empty
+// changes leave the joint state.
+type ChangePeerV2 struct {
+	Changes []*ChangePeer `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *ChangePeerV2) Reset()                    { *m = ChangePeerV2{} }
+func (m *ChangePeerV2) String() string            { return proto.CompactTextString(m) }
+func (*ChangePeerV2) ProtoMessage()               {}
+func (*ChangePeerV2) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{29} }
+
+func (m *ChangePeerV2) GetChanges() []*ChangePeer {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
 type TransferLeader struct {
 	Peer *metapb.Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
 }
@@ -883,7 +1108,7 @@ type TransferLeader struct {
 func (m *TransferLeader) Reset()                    { *m = TransferLeader{} }
 func (m *TransferLeader) String() string            { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()               {}
-func (*TransferLeader) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{29} }
+func (*TransferLeader) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{30} }
 
 func (m *TransferLeader) GetPeer() *metapb.Peer {
 	if m != nil {
@@ -916,12 +1141,22 @@ type RegionHeartbeatResponse struct {
 	RegionEpoch *metapb.RegionEpoch `protobuf:"bytes,5,opt,name=region_epoch,json=regionEpoch" json:"region_epoch,omitempty"`
 	// Leader of the region at the moment of the corresponding request was made.
 	TargetPeer *metapb.Peer `protobuf:"bytes,6,opt,name=target_peer,json=targetPeer" json:"target_peer,omitempty"`
+	// The interval for the leader to send heartbeats of the region, 0 means
+	// the configured one.
+	HeartbeatIntervalSecs uint64 `protobuf:"varint,7,opt,name=heartbeat_interval_secs,json=heartbeatIntervalSecs,proto3" json:"heartbeat_interval_secs,omitempty"`
+	// Pd can return merge to let TiKV merge the region into the target.
+	Merge *Merge `protobuf:"bytes,8,opt,name=merge" json:"merge,omitempty"`
+	// Pd can return split_region to let TiKV split the region.
+	SplitRegion *SplitRegion `protobuf:"bytes,9,opt,name=split_region,json=splitRegion" json:"split_region,omitempty"`
+	// Pd can return change_peer_v2 to let TiKV enter or leave the joint
+	// consensus.
+	ChangePeerV2 *ChangePeerV2 `protobuf:"bytes,10,opt,name=change_peer_v2,json=changePeerV2" json:"change_peer_v2,omitempty"`
 }
 
 func (m *RegionHeartbeatResponse) Reset()                    { *m = RegionHeartbeatResponse{} }
 func (m *RegionHeartbeatResponse) String() string            { return proto.CompactTextString(m) }
 func (*RegionHeartbeatResponse) ProtoMessage()               {}
-func (*RegionHeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{30} }
+func (*RegionHeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{31} }
 
 func (m *RegionHeartbeatResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -965,6 +1200,78 @@ func (m *RegionHeartbeatResponse) GetTargetPeer() *metapb.Peer {
 	return nil
 }
 
+func (m *RegionHeartbeatResponse) GetHeartbeatIntervalSecs() uint64 {
+	if m != nil {
+		return m.HeartbeatIntervalSecs
+	}
+	return 0
+}
+
+func (m *RegionHeartbeatResponse) GetMerge() *Merge {
+	if m != nil {
+		return m.Merge
+	}
+	return nil
+}
+
+func (m *RegionHeartbeatResponse) GetSplitRegion() *SplitRegion {
+	if m != nil {
+		return m.SplitRegion
+	}
+	return nil
+}
+
+func (m *RegionHeartbeatResponse) GetChangePeerV2() *ChangePeerV2 {
+	if m != nil {
+		return m.ChangePeerV2
+	}
+	return nil
+}
+
+// Merge the region into the adjacent target region, the peers of both regions
+// are on the same stores.
+type Merge struct {
+	Target *metapb.Region `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
+}
+
+func (m *Merge) Reset()                    { *m = Merge{} }
+func (m *Merge) String() string            { return proto.CompactTextString(m) }
+func (*Merge) ProtoMessage()               {}
+func (*Merge) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{32} }
+
+func (m *Merge) GetTarget() *metapb.Region {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// Split the region, at the keys if the policy is USEKEY, otherwise at the keys
+// found by TiKV with the policy.
+type SplitRegion struct {
+	Policy CheckPolicy `protobuf:"varint,1,opt,name=policy,proto3,enum=pdpb.CheckPolicy" json:"policy,omitempty"`
+	Keys   [][]byte    `protobuf:"bytes,2,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (m *SplitRegion) Reset()                    { *m = SplitRegion{} }
+func (m *SplitRegion) String() string            { return proto.CompactTextString(m) }
+func (*SplitRegion) ProtoMessage()               {}
+func (*SplitRegion) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{33} }
+
+func (m *SplitRegion) GetPolicy() CheckPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return CheckPolicy_SCAN
+}
+
+func (m *SplitRegion) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
 type AskSplitRequest struct {
 	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Region *metapb.Region `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
@@ -973,7 +1280,7 @@ type AskSplitRequest struct {
 func (m *AskSplitRequest) Reset()                    { *m = AskSplitRequest{} }
 func (m *AskSplitRequest) String() string            { return proto.CompactTextString(m) }
 func (*AskSplitRequest) ProtoMessage()               {}
-func (*AskSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{31} }
+func (*AskSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{34} }
 
 func (m *AskSplitRequest) GetHeader() *RequestHeader {
 	if m != nil {
@@ -1002,7 +1309,7 @@ type AskSplitResponse struct {
 func (m *AskSplitResponse) Reset()                    { *m = AskSplitResponse{} }
 func (m *AskSplitResponse) String() string            { return proto.CompactTextString(m) }
 func (*AskSplitResponse) ProtoMessage()               {}
-func (*AskSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{32} }
+func (*AskSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{35} }
 
 func (m *AskSplitResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1034,7 +1341,7 @@ type ReportSplitRequest struct {
 func (m *ReportSplitRequest) Reset()                    { *m = ReportSplitRequest{} }
 func (m *ReportSplitRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReportSplitRequest) ProtoMessage()               {}
-func (*ReportSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{33} }
+func (*ReportSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{36} }
 
 func (m *ReportSplitRequest) GetHeader() *RequestHeader {
 	if m != nil {
@@ -1064,7 +1371,7 @@ type ReportSplitResponse struct {
 func (m *ReportSplitResponse) Reset()                    { *m = ReportSplitResponse{} }
 func (m *ReportSplitResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReportSplitResponse) ProtoMessage()               {}
-func (*ReportSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{34} }
+func (*ReportSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{37} }
 
 func (m *ReportSplitResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1097,12 +1404,17 @@ type StoreStats struct {
 	BytesWritten uint64 `protobuf:"varint,11,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
 	// Keys written for the store.
 	KeysWritten uint64 `protobuf:"varint,12,opt,name=keys_written,json=keysWritten,proto3" json:"keys_written,omitempty"`
+	// Bytes of the snapshots sent and received since the last heartbeat.
+	SnapshotBytes uint64 `protobuf:"varint,13,opt,name=snapshot_bytes,json=snapshotBytes,proto3" json:"snapshot_bytes,omitempty"`
+	// Total time spent on transferring the snapshots since the last
+	// heartbeat in milliseconds.
+	SnapshotDurationMs uint64 `protobuf:"varint,14,opt,name=snapshot_duration_ms,json=snapshotDurationMs,proto3" json:"snapshot_duration_ms,omitempty"`
 }
 
 func (m *StoreStats) Reset()                    { *m = StoreStats{} }
 func (m *StoreStats) String() string            { return proto.CompactTextString(m) }
 func (*StoreStats) ProtoMessage()               {}
-func (*StoreStats) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{35} }
+func (*StoreStats) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{38} }
 
 func (m *StoreStats) GetStoreId() uint64 {
 	if m != nil {
@@ -1188,6 +1500,20 @@ func (m *StoreStats) GetKeysWritten() uint64 {
 	return 0
 }
 
+func (m *StoreStats) GetSnapshotBytes() uint64 {
+	if m != nil {
+		return m.SnapshotBytes
+	}
+	return 0
+}
+
+func (m *StoreStats) GetSnapshotDurationMs() uint64 {
+	if m != nil {
+		return m.SnapshotDurationMs
+	}
+	return 0
+}
+
 type StoreHeartbeatRequest struct {
 	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Stats  *StoreStats    `protobuf:"bytes,2,opt,name=stats" json:"stats,omitempty"`
@@ -1196,7 +1522,7 @@ type StoreHeartbeatRequest struct {
 func (m *StoreHeartbeatRequest) Reset()                    { *m = StoreHeartbeatRequest{} }
 func (m *StoreHeartbeatRequest) String() string            { return proto.CompactTextString(m) }
 func (*StoreHeartbeatRequest) ProtoMessage()               {}
-func (*StoreHeartbeatRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{36} }
+func (*StoreHeartbeatRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{39} }
 
 func (m *StoreHeartbeatRequest) GetHeader() *RequestHeader {
 	if m != nil {
@@ -1214,12 +1540,15 @@ func (m *StoreHeartbeatRequest) GetStats() *StoreStats {
 
 type StoreHeartbeatResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The interval for the store to send store heartbeats, 0 means the
+	// configured one.
+	HeartbeatIntervalSecs uint64 `protobuf:"varint,2,opt,name=heartbeat_interval_secs,json=heartbeatIntervalSecs,proto3" json:"heartbeat_interval_secs,omitempty"`
 }
 
 func (m *StoreHeartbeatResponse) Reset()                    { *m = StoreHeartbeatResponse{} }
 func (m *StoreHeartbeatResponse) String() string            { return proto.CompactTextString(m) }
 func (*StoreHeartbeatResponse) ProtoMessage()               {}
-func (*StoreHeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{37} }
+func (*StoreHeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{40} }
 
 func (m *StoreHeartbeatResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1228,2072 +1557,1924 @@ func (m *StoreHeartbeatResponse) GetHeader() *ResponseHeader {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*RequestHeader)(nil), "pdpb.RequestHeader")
-	proto.RegisterType((*ResponseHeader)(nil), "pdpb.ResponseHeader")
-	proto.RegisterType((*Error)(nil), "pdpb.Error")
-	proto.RegisterType((*TsoRequest)(nil), "pdpb.TsoRequest")
-	proto.RegisterType((*Timestamp)(nil), "pdpb.Timestamp")
-	proto.RegisterType((*TsoResponse)(nil), "pdpb.TsoResponse")
-	proto.RegisterType((*BootstrapRequest)(nil), "pdpb.BootstrapRequest")
-	proto.RegisterType((*BootstrapResponse)(nil), "pdpb.BootstrapResponse")
-	proto.RegisterType((*IsBootstrappedRequest)(nil), "pdpb.IsBootstrappedRequest")
-	proto.RegisterType((*IsBootstrappedResponse)(nil), "pdpb.IsBootstrappedResponse")
-	proto.RegisterType((*AllocIDRequest)(nil), "pdpb.AllocIDRequest")
-	proto.RegisterType((*AllocIDResponse)(nil), "pdpb.AllocIDResponse")
-	proto.RegisterType((*GetStoreRequest)(nil), "pdpb.GetStoreRequest")
-	proto.RegisterType((*GetStoreResponse)(nil), "pdpb.GetStoreResponse")
-	proto.RegisterType((*PutStoreRequest)(nil), "pdpb.PutStoreRequest")
-	proto.RegisterType((*PutStoreResponse)(nil), "pdpb.PutStoreResponse")
-	proto.RegisterType((*GetRegionRequest)(nil), "pdpb.GetRegionRequest")
-	proto.RegisterType((*GetRegionResponse)(nil), "pdpb.GetRegionResponse")
-	proto.RegisterType((*GetRegionByIDRequest)(nil), "pdpb.GetRegionByIDRequest")
-	proto.RegisterType((*GetClusterConfigRequest)(nil), "pdpb.GetClusterConfigRequest")
-	proto.RegisterType((*GetClusterConfigResponse)(nil), "pdpb.GetClusterConfigResponse")
-	proto.RegisterType((*PutClusterConfigRequest)(nil), "pdpb.PutClusterConfigRequest")
-	proto.RegisterType((*PutClusterConfigResponse)(nil), "pdpb.PutClusterConfigResponse")
-	proto.RegisterType((*Member)(nil), "pdpb.Member")
-	proto.RegisterType((*GetMembersRequest)(nil), "pdpb.GetMembersRequest")
-	proto.RegisterType((*GetMembersResponse)(nil), "pdpb.GetMembersResponse")
-	proto.RegisterType((*PeerStats)(nil), "pdpb.PeerStats")
-	proto.RegisterType((*RegionHeartbeatRequest)(nil), "pdpb.RegionHeartbeatRequest")
-	proto.RegisterType((*ChangePeer)(nil), "pdpb.ChangePeer")
-	proto.RegisterType((*TransferLeader)(nil), "pdpb.TransferLeader")
-	proto.RegisterType((*RegionHeartbeatResponse)(nil), "pdpb.RegionHeartbeatResponse")
-	proto.RegisterType((*AskSplitRequest)(nil), "pdpb.AskSplitRequest")
-	proto.RegisterType((*AskSplitResponse)(nil), "pdpb.AskSplitResponse")
-	proto.RegisterType((*ReportSplitRequest)(nil), "pdpb.ReportSplitRequest")
-	proto.RegisterType((*ReportSplitResponse)(nil), "pdpb.ReportSplitResponse")
-	proto.RegisterType((*StoreStats)(nil), "pdpb.StoreStats")
-	proto.RegisterType((*StoreHeartbeatRequest)(nil), "pdpb.StoreHeartbeatRequest")
-	proto.RegisterType((*StoreHeartbeatResponse)(nil), "pdpb.StoreHeartbeatResponse")
-	proto.RegisterEnum("pdpb.ErrorType", ErrorType_name, ErrorType_value)
-	proto.RegisterEnum("pdpb.ConfChangeType", ConfChangeType_name, ConfChangeType_value)
+func (m *StoreHeartbeatResponse) GetHeartbeatIntervalSecs() uint64 {
+	if m != nil {
+		return m.HeartbeatIntervalSecs
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type GetAllStoresRequest struct {
+	Header                 *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	ExcludeTombstoneStores bool           `protobuf:"varint,2,opt,name=exclude_tombstone_stores,json=excludeTombstoneStores,proto3" json:"exclude_tombstone_stores,omitempty"`
+}
 
-// Client API for PD service
+func (m *GetAllStoresRequest) Reset()                    { *m = GetAllStoresRequest{} }
+func (m *GetAllStoresRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAllStoresRequest) ProtoMessage()               {}
+func (*GetAllStoresRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{41} }
 
-type PDClient interface {
-	// GetMembers get the member list of this cluster. It does not require
-	// the cluster_id in request matchs the id of this cluster.
-	GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error)
-	Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error)
-	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
-	IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error)
-	AllocID(ctx context.Context, in *AllocIDRequest, opts ...grpc.CallOption) (*AllocIDResponse, error)
-	GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*GetStoreResponse, error)
-	PutStore(ctx context.Context, in *PutStoreRequest, opts ...grpc.CallOption) (*PutStoreResponse, error)
-	StoreHeartbeat(ctx context.Context, in *StoreHeartbeatRequest, opts ...grpc.CallOption) (*StoreHeartbeatResponse, error)
-	RegionHeartbeat(ctx context.Context, opts ...grpc.CallOption) (PD_RegionHeartbeatClient, error)
-	GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error)
-	GetRegionByID(ctx context.Context, in *GetRegionByIDRequest, opts ...grpc.CallOption) (*GetRegionResponse, error)
-	AskSplit(ctx context.Context, in *AskSplitRequest, opts ...grpc.CallOption) (*AskSplitResponse, error)
-	ReportSplit(ctx context.Context, in *ReportSplitRequest, opts ...grpc.CallOption) (*ReportSplitResponse, error)
-	GetClusterConfig(ctx context.Context, in *GetClusterConfigRequest, opts ...grpc.CallOption) (*GetClusterConfigResponse, error)
-	PutClusterConfig(ctx context.Context, in *PutClusterConfigRequest, opts ...grpc.CallOption) (*PutClusterConfigResponse, error)
+func (m *GetAllStoresRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
 
-type pDClient struct {
-	cc *grpc.ClientConn
+func (m *GetAllStoresRequest) GetExcludeTombstoneStores() bool {
+	if m != nil {
+		return m.ExcludeTombstoneStores
+	}
+	return false
 }
 
-func NewPDClient(cc *grpc.ClientConn) PDClient {
-	return &pDClient{cc}
+type GetAllStoresResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Stores []*metapb.Store `protobuf:"bytes,2,rep,name=stores" json:"stores,omitempty"`
 }
 
-func (c *pDClient) GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error) {
-	out := new(GetMembersResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/GetMembers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetAllStoresResponse) Reset()                    { *m = GetAllStoresResponse{} }
+func (m *GetAllStoresResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetAllStoresResponse) ProtoMessage()               {}
+func (*GetAllStoresResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{42} }
+
+func (m *GetAllStoresResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_PD_serviceDesc.Streams[0], c.cc, "/pdpb.PD/Tso", opts...)
-	if err != nil {
-		return nil, err
+func (m *GetAllStoresResponse) GetStores() []*metapb.Store {
+	if m != nil {
+		return m.Stores
 	}
-	x := &pDTsoClient{stream}
-	return x, nil
+	return nil
 }
 
-type PD_TsoClient interface {
-	Send(*TsoRequest) error
-	Recv() (*TsoResponse, error)
-	grpc.ClientStream
+type ScanRegionsRequest struct {
+	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	StartKey []byte         `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	// 0 means no limit.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Exclusive, empty means the end of the key space.
+	EndKey []byte `protobuf:"bytes,4,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
 }
 
-type pDTsoClient struct {
-	grpc.ClientStream
-}
+func (m *ScanRegionsRequest) Reset()                    { *m = ScanRegionsRequest{} }
+func (m *ScanRegionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScanRegionsRequest) ProtoMessage()               {}
+func (*ScanRegionsRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{43} }
 
-func (x *pDTsoClient) Send(m *TsoRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *ScanRegionsRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
 
-func (x *pDTsoClient) Recv() (*TsoResponse, error) {
-	m := new(TsoResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ScanRegionsRequest) GetStartKey() []byte {
+	if m != nil {
+		return m.StartKey
 	}
-	return m, nil
+	return nil
 }
 
-func (c *pDClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
-	out := new(BootstrapResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/Bootstrap", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScanRegionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pDClient) IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error) {
-	out := new(IsBootstrappedResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/IsBootstrapped", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScanRegionsRequest) GetEndKey() []byte {
+	if m != nil {
+		return m.EndKey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) AllocID(ctx context.Context, in *AllocIDRequest, opts ...grpc.CallOption) (*AllocIDResponse, error) {
-	out := new(AllocIDResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/AllocID", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ScanRegionsResponse struct {
+	Header      *ResponseHeader  `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionMetas []*metapb.Region `protobuf:"bytes,2,rep,name=region_metas,json=regionMetas" json:"region_metas,omitempty"`
+	// The leaders of the regions in the same order, an empty peer if the
+	// leader is unknown.
+	Leaders []*metapb.Peer `protobuf:"bytes,3,rep,name=leaders" json:"leaders,omitempty"`
+}
+
+func (m *ScanRegionsResponse) Reset()                    { *m = ScanRegionsResponse{} }
+func (m *ScanRegionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ScanRegionsResponse) ProtoMessage()               {}
+func (*ScanRegionsResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{44} }
+
+func (m *ScanRegionsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*GetStoreResponse, error) {
-	out := new(GetStoreResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/GetStore", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScanRegionsResponse) GetRegionMetas() []*metapb.Region {
+	if m != nil {
+		return m.RegionMetas
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) PutStore(ctx context.Context, in *PutStoreRequest, opts ...grpc.CallOption) (*PutStoreResponse, error) {
-	out := new(PutStoreResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/PutStore", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ScanRegionsResponse) GetLeaders() []*metapb.Peer {
+	if m != nil {
+		return m.Leaders
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) StoreHeartbeat(ctx context.Context, in *StoreHeartbeatRequest, opts ...grpc.CallOption) (*StoreHeartbeatResponse, error) {
-	out := new(StoreHeartbeatResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/StoreHeartbeat", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ScatterRegionRequest struct {
+	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	// Used if PD doesn't know the region yet, e.g. just split.
+	Region *metapb.Region `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	Leader *metapb.Peer   `protobuf:"bytes,4,opt,name=leader" json:"leader,omitempty"`
+}
+
+func (m *ScatterRegionRequest) Reset()                    { *m = ScatterRegionRequest{} }
+func (m *ScatterRegionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScatterRegionRequest) ProtoMessage()               {}
+func (*ScatterRegionRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{45} }
+
+func (m *ScatterRegionRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) RegionHeartbeat(ctx context.Context, opts ...grpc.CallOption) (PD_RegionHeartbeatClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_PD_serviceDesc.Streams[1], c.cc, "/pdpb.PD/RegionHeartbeat", opts...)
-	if err != nil {
-		return nil, err
+func (m *ScatterRegionRequest) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
 	}
-	x := &pDRegionHeartbeatClient{stream}
-	return x, nil
+	return 0
 }
 
-type PD_RegionHeartbeatClient interface {
-	Send(*RegionHeartbeatRequest) error
-	Recv() (*RegionHeartbeatResponse, error)
-	grpc.ClientStream
+func (m *ScatterRegionRequest) GetRegion() *metapb.Region {
+	if m != nil {
+		return m.Region
+	}
+	return nil
 }
 
-type pDRegionHeartbeatClient struct {
-	grpc.ClientStream
+func (m *ScatterRegionRequest) GetLeader() *metapb.Peer {
+	if m != nil {
+		return m.Leader
+	}
+	return nil
 }
 
-func (x *pDRegionHeartbeatClient) Send(m *RegionHeartbeatRequest) error {
-	return x.ClientStream.SendMsg(m)
+type ScatterRegionResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
 
-func (x *pDRegionHeartbeatClient) Recv() (*RegionHeartbeatResponse, error) {
-	m := new(RegionHeartbeatResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ScatterRegionResponse) Reset()                    { *m = ScatterRegionResponse{} }
+func (m *ScatterRegionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ScatterRegionResponse) ProtoMessage()               {}
+func (*ScatterRegionResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{46} }
+
+func (m *ScatterRegionResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return m, nil
+	return nil
 }
 
-func (c *pDClient) GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error) {
-	out := new(GetRegionResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/GetRegion", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type GetOperatorRequest struct {
+	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+}
+
+func (m *GetOperatorRequest) Reset()                    { *m = GetOperatorRequest{} }
+func (m *GetOperatorRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetOperatorRequest) ProtoMessage()               {}
+func (*GetOperatorRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{47} }
+
+func (m *GetOperatorRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) GetRegionByID(ctx context.Context, in *GetRegionByIDRequest, opts ...grpc.CallOption) (*GetRegionResponse, error) {
-	out := new(GetRegionResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/GetRegionByID", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetOperatorRequest) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pDClient) AskSplit(ctx context.Context, in *AskSplitRequest, opts ...grpc.CallOption) (*AskSplitResponse, error) {
-	out := new(AskSplitResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/AskSplit", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type GetOperatorResponse struct {
+	Header   *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId uint64          `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	// NONE if the region has no operator, otherwise the status of the running
+	// or the last finished operator.
+	Status OperatorStatus `protobuf:"varint,3,opt,name=status,proto3,enum=pdpb.OperatorStatus" json:"status,omitempty"`
+	Kind   string         `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The description of each step of the operator.
+	Steps         []string `protobuf:"bytes,5,rep,name=steps" json:"steps,omitempty"`
+	FinishedSteps uint32   `protobuf:"varint,6,opt,name=finished_steps,json=finishedSteps,proto3" json:"finished_steps,omitempty"`
+}
+
+func (m *GetOperatorResponse) Reset()                    { *m = GetOperatorResponse{} }
+func (m *GetOperatorResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetOperatorResponse) ProtoMessage()               {}
+func (*GetOperatorResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{48} }
+
+func (m *GetOperatorResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return out, nil
+	return nil
 }
 
-func (c *pDClient) ReportSplit(ctx context.Context, in *ReportSplitRequest, opts ...grpc.CallOption) (*ReportSplitResponse, error) {
-	out := new(ReportSplitResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/ReportSplit", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetOperatorResponse) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pDClient) GetClusterConfig(ctx context.Context, in *GetClusterConfigRequest, opts ...grpc.CallOption) (*GetClusterConfigResponse, error) {
-	out := new(GetClusterConfigResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/GetClusterConfig", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetOperatorResponse) GetStatus() OperatorStatus {
+	if m != nil {
+		return m.Status
 	}
-	return out, nil
+	return OperatorStatus_NONE
 }
 
-func (c *pDClient) PutClusterConfig(ctx context.Context, in *PutClusterConfigRequest, opts ...grpc.CallOption) (*PutClusterConfigResponse, error) {
-	out := new(PutClusterConfigResponse)
-	err := grpc.Invoke(ctx, "/pdpb.PD/PutClusterConfig", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetOperatorResponse) GetKind() string {
+	if m != nil {
+		return m.Kind
 	}
-	return out, nil
+	return ""
 }
 
-// Server API for PD service
+func (m *GetOperatorResponse) GetSteps() []string {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
 
-type PDServer interface {
-	// GetMembers get the member list of this cluster. It does not require
-	// the cluster_id in request matchs the id of this cluster.
-	GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error)
-	Tso(PD_TsoServer) error
-	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
-	IsBootstrapped(context.Context, *IsBootstrappedRequest) (*IsBootstrappedResponse, error)
-	AllocID(context.Context, *AllocIDRequest) (*AllocIDResponse, error)
-	GetStore(context.Context, *GetStoreRequest) (*GetStoreResponse, error)
-	PutStore(context.Context, *PutStoreRequest) (*PutStoreResponse, error)
-	StoreHeartbeat(context.Context, *StoreHeartbeatRequest) (*StoreHeartbeatResponse, error)
-	RegionHeartbeat(PD_RegionHeartbeatServer) error
-	GetRegion(context.Context, *GetRegionRequest) (*GetRegionResponse, error)
-	GetRegionByID(context.Context, *GetRegionByIDRequest) (*GetRegionResponse, error)
-	AskSplit(context.Context, *AskSplitRequest) (*AskSplitResponse, error)
-	ReportSplit(context.Context, *ReportSplitRequest) (*ReportSplitResponse, error)
-	GetClusterConfig(context.Context, *GetClusterConfigRequest) (*GetClusterConfigResponse, error)
-	PutClusterConfig(context.Context, *PutClusterConfigRequest) (*PutClusterConfigResponse, error)
+func (m *GetOperatorResponse) GetFinishedSteps() uint32 {
+	if m != nil {
+		return m.FinishedSteps
+	}
+	return 0
 }
 
-func RegisterPDServer(s *grpc.Server, srv PDServer) {
-	s.RegisterService(&_PD_serviceDesc, srv)
+type GetGCSafePointRequest struct {
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
 
-func _PD_GetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMembersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).GetMembers(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/GetMembers",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).GetMembers(ctx, req.(*GetMembersRequest))
+func (m *GetGCSafePointRequest) Reset()                    { *m = GetGCSafePointRequest{} }
+func (m *GetGCSafePointRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGCSafePointRequest) ProtoMessage()               {}
+func (*GetGCSafePointRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{49} }
+
+func (m *GetGCSafePointRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_Tso_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(PDServer).Tso(&pDTsoServer{stream})
+type GetGCSafePointResponse struct {
+	Header    *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	SafePoint uint64          `protobuf:"varint,2,opt,name=safe_point,json=safePoint,proto3" json:"safe_point,omitempty"`
 }
 
-type PD_TsoServer interface {
-	Send(*TsoResponse) error
-	Recv() (*TsoRequest, error)
-	grpc.ServerStream
+func (m *GetGCSafePointResponse) Reset()                    { *m = GetGCSafePointResponse{} }
+func (m *GetGCSafePointResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetGCSafePointResponse) ProtoMessage()               {}
+func (*GetGCSafePointResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{50} }
+
+func (m *GetGCSafePointResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
 
-type pDTsoServer struct {
-	grpc.ServerStream
+func (m *GetGCSafePointResponse) GetSafePoint() uint64 {
+	if m != nil {
+		return m.SafePoint
+	}
+	return 0
 }
 
-func (x *pDTsoServer) Send(m *TsoResponse) error {
-	return x.ServerStream.SendMsg(m)
+type UpdateGCSafePointRequest struct {
+	Header    *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	SafePoint uint64         `protobuf:"varint,2,opt,name=safe_point,json=safePoint,proto3" json:"safe_point,omitempty"`
 }
 
-func (x *pDTsoServer) Recv() (*TsoRequest, error) {
-	m := new(TsoRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *UpdateGCSafePointRequest) Reset()                    { *m = UpdateGCSafePointRequest{} }
+func (m *UpdateGCSafePointRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateGCSafePointRequest) ProtoMessage()               {}
+func (*UpdateGCSafePointRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{51} }
+
+func (m *UpdateGCSafePointRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return m, nil
+	return nil
 }
 
-func _PD_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BootstrapRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).Bootstrap(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/Bootstrap",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).Bootstrap(ctx, req.(*BootstrapRequest))
+func (m *UpdateGCSafePointRequest) GetSafePoint() uint64 {
+	if m != nil {
+		return m.SafePoint
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PD_IsBootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IsBootstrappedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).IsBootstrapped(ctx, in)
+type UpdateGCSafePointResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The safe point is never moved backward or beyond the service safe
+	// points, so it may differ from the requested one.
+	NewSafePoint uint64 `protobuf:"varint,2,opt,name=new_safe_point,json=newSafePoint,proto3" json:"new_safe_point,omitempty"`
+}
+
+func (m *UpdateGCSafePointResponse) Reset()                    { *m = UpdateGCSafePointResponse{} }
+func (m *UpdateGCSafePointResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateGCSafePointResponse) ProtoMessage()               {}
+func (*UpdateGCSafePointResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{52} }
+
+func (m *UpdateGCSafePointResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/IsBootstrapped",
+	return nil
+}
+
+func (m *UpdateGCSafePointResponse) GetNewSafePoint() uint64 {
+	if m != nil {
+		return m.NewSafePoint
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).IsBootstrapped(ctx, req.(*IsBootstrappedRequest))
+	return 0
+}
+
+type UpdateServiceGCSafePointRequest struct {
+	Header    *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	ServiceId []byte         `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// In seconds, the safe point of the service is removed if it's not
+	// positive.
+	Ttl       int64  `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	SafePoint uint64 `protobuf:"varint,4,opt,name=safe_point,json=safePoint,proto3" json:"safe_point,omitempty"`
+}
+
+func (m *UpdateServiceGCSafePointRequest) Reset()         { *m = UpdateServiceGCSafePointRequest{} }
+func (m *UpdateServiceGCSafePointRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateServiceGCSafePointRequest) ProtoMessage()    {}
+func (*UpdateServiceGCSafePointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPdpb, []int{53}
+}
+
+func (m *UpdateServiceGCSafePointRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_AllocID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AllocIDRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *UpdateServiceGCSafePointRequest) GetServiceId() []byte {
+	if m != nil {
+		return m.ServiceId
 	}
-	if interceptor == nil {
-		return srv.(PDServer).AllocID(ctx, in)
+	return nil
+}
+
+func (m *UpdateServiceGCSafePointRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/AllocID",
+	return 0
+}
+
+func (m *UpdateServiceGCSafePointRequest) GetSafePoint() uint64 {
+	if m != nil {
+		return m.SafePoint
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).AllocID(ctx, req.(*AllocIDRequest))
+	return 0
+}
+
+// The service safe point with the minimum safe point after the update, the
+// service_id is empty if there is none.
+type UpdateServiceGCSafePointResponse struct {
+	Header       *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	ServiceId    []byte          `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Ttl          int64           `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	MinSafePoint uint64          `protobuf:"varint,4,opt,name=min_safe_point,json=minSafePoint,proto3" json:"min_safe_point,omitempty"`
+}
+
+func (m *UpdateServiceGCSafePointResponse) Reset()         { *m = UpdateServiceGCSafePointResponse{} }
+func (m *UpdateServiceGCSafePointResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateServiceGCSafePointResponse) ProtoMessage()    {}
+func (*UpdateServiceGCSafePointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorPdpb, []int{54}
+}
+
+func (m *UpdateServiceGCSafePointResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_GetStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStoreRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *UpdateServiceGCSafePointResponse) GetServiceId() []byte {
+	if m != nil {
+		return m.ServiceId
 	}
-	if interceptor == nil {
-		return srv.(PDServer).GetStore(ctx, in)
+	return nil
+}
+
+func (m *UpdateServiceGCSafePointResponse) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/GetStore",
+	return 0
+}
+
+func (m *UpdateServiceGCSafePointResponse) GetMinSafePoint() uint64 {
+	if m != nil {
+		return m.MinSafePoint
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).GetStore(ctx, req.(*GetStoreRequest))
+	return 0
+}
+
+type AskBatchSplitRequest struct {
+	Header     *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Region     *metapb.Region `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
+	SplitCount uint32         `protobuf:"varint,3,opt,name=split_count,json=splitCount,proto3" json:"split_count,omitempty"`
+}
+
+func (m *AskBatchSplitRequest) Reset()                    { *m = AskBatchSplitRequest{} }
+func (m *AskBatchSplitRequest) String() string            { return proto.CompactTextString(m) }
+func (*AskBatchSplitRequest) ProtoMessage()               {}
+func (*AskBatchSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{55} }
+
+func (m *AskBatchSplitRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_PutStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutStoreRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *AskBatchSplitRequest) GetRegion() *metapb.Region {
+	if m != nil {
+		return m.Region
 	}
-	if interceptor == nil {
-		return srv.(PDServer).PutStore(ctx, in)
+	return nil
+}
+
+func (m *AskBatchSplitRequest) GetSplitCount() uint32 {
+	if m != nil {
+		return m.SplitCount
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/PutStore",
+	return 0
+}
+
+type SplitID struct {
+	NewRegionId uint64   `protobuf:"varint,1,opt,name=new_region_id,json=newRegionId,proto3" json:"new_region_id,omitempty"`
+	NewPeerIds  []uint64 `protobuf:"varint,2,rep,packed,name=new_peer_ids,json=newPeerIds" json:"new_peer_ids,omitempty"`
+}
+
+func (m *SplitID) Reset()                    { *m = SplitID{} }
+func (m *SplitID) String() string            { return proto.CompactTextString(m) }
+func (*SplitID) ProtoMessage()               {}
+func (*SplitID) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{56} }
+
+func (m *SplitID) GetNewRegionId() uint64 {
+	if m != nil {
+		return m.NewRegionId
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).PutStore(ctx, req.(*PutStoreRequest))
+	return 0
+}
+
+func (m *SplitID) GetNewPeerIds() []uint64 {
+	if m != nil {
+		return m.NewPeerIds
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_StoreHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoreHeartbeatRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type AskBatchSplitResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Ids    []*SplitID      `protobuf:"bytes,2,rep,name=ids" json:"ids,omitempty"`
+}
+
+func (m *AskBatchSplitResponse) Reset()                    { *m = AskBatchSplitResponse{} }
+func (m *AskBatchSplitResponse) String() string            { return proto.CompactTextString(m) }
+func (*AskBatchSplitResponse) ProtoMessage()               {}
+func (*AskBatchSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{57} }
+
+func (m *AskBatchSplitResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	if interceptor == nil {
-		return srv.(PDServer).StoreHeartbeat(ctx, in)
+	return nil
+}
+
+func (m *AskBatchSplitResponse) GetIds() []*SplitID {
+	if m != nil {
+		return m.Ids
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/StoreHeartbeat",
+	return nil
+}
+
+type ReportBatchSplitRequest struct {
+	Header  *RequestHeader   `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Regions []*metapb.Region `protobuf:"bytes,2,rep,name=regions" json:"regions,omitempty"`
+}
+
+func (m *ReportBatchSplitRequest) Reset()                    { *m = ReportBatchSplitRequest{} }
+func (m *ReportBatchSplitRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReportBatchSplitRequest) ProtoMessage()               {}
+func (*ReportBatchSplitRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{58} }
+
+func (m *ReportBatchSplitRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).StoreHeartbeat(ctx, req.(*StoreHeartbeatRequest))
+	return nil
+}
+
+func (m *ReportBatchSplitRequest) GetRegions() []*metapb.Region {
+	if m != nil {
+		return m.Regions
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_RegionHeartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(PDServer).RegionHeartbeat(&pDRegionHeartbeatServer{stream})
+type ReportBatchSplitResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
 
-type PD_RegionHeartbeatServer interface {
-	Send(*RegionHeartbeatResponse) error
-	Recv() (*RegionHeartbeatRequest, error)
-	grpc.ServerStream
+func (m *ReportBatchSplitResponse) Reset()                    { *m = ReportBatchSplitResponse{} }
+func (m *ReportBatchSplitResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReportBatchSplitResponse) ProtoMessage()               {}
+func (*ReportBatchSplitResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{59} }
+
+func (m *ReportBatchSplitResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
 
-type pDRegionHeartbeatServer struct {
-	grpc.ServerStream
+type SyncRegionRequest struct {
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Member *Member        `protobuf:"bytes,2,opt,name=member" json:"member,omitempty"`
+	// The index of the first region change to sync, 0 means all the regions.
+	StartIndex uint64 `protobuf:"varint,3,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
 }
 
-func (x *pDRegionHeartbeatServer) Send(m *RegionHeartbeatResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *SyncRegionRequest) Reset()                    { *m = SyncRegionRequest{} }
+func (m *SyncRegionRequest) String() string            { return proto.CompactTextString(m) }
+func (*SyncRegionRequest) ProtoMessage()               {}
+func (*SyncRegionRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{60} }
+
+func (m *SyncRegionRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
 
-func (x *pDRegionHeartbeatServer) Recv() (*RegionHeartbeatRequest, error) {
-	m := new(RegionHeartbeatRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SyncRegionRequest) GetMember() *Member {
+	if m != nil {
+		return m.Member
 	}
-	return m, nil
+	return nil
 }
 
-func _PD_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRegionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SyncRegionRequest) GetStartIndex() uint64 {
+	if m != nil {
+		return m.StartIndex
 	}
-	if interceptor == nil {
-		return srv.(PDServer).GetRegion(ctx, in)
+	return 0
+}
+
+type SyncRegionResponse struct {
+	Header  *ResponseHeader  `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Regions []*metapb.Region `protobuf:"bytes,2,rep,name=regions" json:"regions,omitempty"`
+	// The leaders of the regions, in the same order.
+	RegionLeaders []*metapb.Peer `protobuf:"bytes,3,rep,name=region_leaders,json=regionLeaders" json:"region_leaders,omitempty"`
+	// The index to sync from after the regions are applied.
+	NextIndex uint64 `protobuf:"varint,4,opt,name=next_index,json=nextIndex,proto3" json:"next_index,omitempty"`
+}
+
+func (m *SyncRegionResponse) Reset()                    { *m = SyncRegionResponse{} }
+func (m *SyncRegionResponse) String() string            { return proto.CompactTextString(m) }
+func (*SyncRegionResponse) ProtoMessage()               {}
+func (*SyncRegionResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{61} }
+
+func (m *SyncRegionResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/GetRegion",
+	return nil
+}
+
+func (m *SyncRegionResponse) GetRegions() []*metapb.Region {
+	if m != nil {
+		return m.Regions
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).GetRegion(ctx, req.(*GetRegionRequest))
+	return nil
+}
+
+func (m *SyncRegionResponse) GetRegionLeaders() []*metapb.Peer {
+	if m != nil {
+		return m.RegionLeaders
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PD_GetRegionByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRegionByIDRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SyncRegionResponse) GetNextIndex() uint64 {
+	if m != nil {
+		return m.NextIndex
 	}
-	if interceptor == nil {
-		return srv.(PDServer).GetRegionByID(ctx, in)
+	return 0
+}
+
+type ReplicationStatus struct {
+	MaxReplicas            uint64 `protobuf:"varint,1,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`
+	UnderReplicatedRegions uint64 `protobuf:"varint,2,opt,name=under_replicated_regions,json=underReplicatedRegions,proto3" json:"under_replicated_regions,omitempty"`
+	OverReplicatedRegions  uint64 `protobuf:"varint,3,opt,name=over_replicated_regions,json=overReplicatedRegions,proto3" json:"over_replicated_regions,omitempty"`
+	DownPeerRegions        uint64 `protobuf:"varint,4,opt,name=down_peer_regions,json=downPeerRegions,proto3" json:"down_peer_regions,omitempty"`
+	PendingPeerRegions     uint64 `protobuf:"varint,5,opt,name=pending_peer_regions,json=pendingPeerRegions,proto3" json:"pending_peer_regions,omitempty"`
+}
+
+func (m *ReplicationStatus) Reset()                    { *m = ReplicationStatus{} }
+func (m *ReplicationStatus) String() string            { return proto.CompactTextString(m) }
+func (*ReplicationStatus) ProtoMessage()               {}
+func (*ReplicationStatus) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{62} }
+
+func (m *ReplicationStatus) GetMaxReplicas() uint64 {
+	if m != nil {
+		return m.MaxReplicas
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/GetRegionByID",
+	return 0
+}
+
+func (m *ReplicationStatus) GetUnderReplicatedRegions() uint64 {
+	if m != nil {
+		return m.UnderReplicatedRegions
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).GetRegionByID(ctx, req.(*GetRegionByIDRequest))
+	return 0
+}
+
+func (m *ReplicationStatus) GetOverReplicatedRegions() uint64 {
+	if m != nil {
+		return m.OverReplicatedRegions
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PD_AskSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AskSplitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ReplicationStatus) GetDownPeerRegions() uint64 {
+	if m != nil {
+		return m.DownPeerRegions
 	}
-	if interceptor == nil {
-		return srv.(PDServer).AskSplit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/AskSplit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).AskSplit(ctx, req.(*AskSplitRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PD_ReportSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReportSplitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).ReportSplit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/ReportSplit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).ReportSplit(ctx, req.(*ReportSplitRequest))
+func (m *ReplicationStatus) GetPendingPeerRegions() uint64 {
+	if m != nil {
+		return m.PendingPeerRegions
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PD_GetClusterConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetClusterConfigRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).GetClusterConfig(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/GetClusterConfig",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).GetClusterConfig(ctx, req.(*GetClusterConfigRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type StoreStateCount struct {
+	State StoreState `protobuf:"varint,1,opt,name=state,proto3,enum=pdpb.StoreState" json:"state,omitempty"`
+	Count uint64     `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func _PD_PutClusterConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutClusterConfigRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PDServer).PutClusterConfig(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pdpb.PD/PutClusterConfig",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PDServer).PutClusterConfig(ctx, req.(*PutClusterConfigRequest))
+func (m *StoreStateCount) Reset()                    { *m = StoreStateCount{} }
+func (m *StoreStateCount) String() string            { return proto.CompactTextString(m) }
+func (*StoreStateCount) ProtoMessage()               {}
+func (*StoreStateCount) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{63} }
+
+func (m *StoreStateCount) GetState() StoreState {
+	if m != nil {
+		return m.State
 	}
-	return interceptor(ctx, in, info, handler)
+	return StoreState_Up
 }
 
-var _PD_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pdpb.PD",
-	HandlerType: (*PDServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetMembers",
-			Handler:    _PD_GetMembers_Handler,
-		},
-		{
-			MethodName: "Bootstrap",
-			Handler:    _PD_Bootstrap_Handler,
-		},
-		{
-			MethodName: "IsBootstrapped",
-			Handler:    _PD_IsBootstrapped_Handler,
-		},
-		{
-			MethodName: "AllocID",
-			Handler:    _PD_AllocID_Handler,
-		},
-		{
-			MethodName: "GetStore",
-			Handler:    _PD_GetStore_Handler,
-		},
-		{
-			MethodName: "PutStore",
-			Handler:    _PD_PutStore_Handler,
-		},
-		{
-			MethodName: "StoreHeartbeat",
-			Handler:    _PD_StoreHeartbeat_Handler,
-		},
-		{
-			MethodName: "GetRegion",
-			Handler:    _PD_GetRegion_Handler,
-		},
-		{
-			MethodName: "GetRegionByID",
-			Handler:    _PD_GetRegionByID_Handler,
-		},
-		{
-			MethodName: "AskSplit",
-			Handler:    _PD_AskSplit_Handler,
-		},
-		{
-			MethodName: "ReportSplit",
-			Handler:    _PD_ReportSplit_Handler,
-		},
-		{
-			MethodName: "GetClusterConfig",
-			Handler:    _PD_GetClusterConfig_Handler,
-		},
-		{
-			MethodName: "PutClusterConfig",
-			Handler:    _PD_PutClusterConfig_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Tso",
-			Handler:       _PD_Tso_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "RegionHeartbeat",
-			Handler:       _PD_RegionHeartbeat_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "pdpb.proto",
+func (m *StoreStateCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
 }
 
-func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type GetClusterStatusRequest struct {
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 }
 
-func (m *RequestHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ClusterId != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ClusterId))
+func (m *GetClusterStatusRequest) Reset()                    { *m = GetClusterStatusRequest{} }
+func (m *GetClusterStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetClusterStatusRequest) ProtoMessage()               {}
+func (*GetClusterStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{64} }
+
+func (m *GetClusterStatusRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
 	}
-	return i, nil
+	return nil
 }
 
-func (m *ResponseHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type GetClusterStatusResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// Unix time in nanoseconds, 0 if the cluster is not bootstrapped.
+	RaftBootstrapTime int64 `protobuf:"varint,2,opt,name=raft_bootstrap_time,json=raftBootstrapTime,proto3" json:"raft_bootstrap_time,omitempty"`
+	// Whether the first region is fully replicated or has split.
+	IsInitialized     bool               `protobuf:"varint,3,opt,name=is_initialized,json=isInitialized,proto3" json:"is_initialized,omitempty"`
+	ReplicationStatus *ReplicationStatus `protobuf:"bytes,4,opt,name=replication_status,json=replicationStatus" json:"replication_status,omitempty"`
+	StoreCounts       []*StoreStateCount `protobuf:"bytes,5,rep,name=store_counts,json=storeCounts" json:"store_counts,omitempty"`
 }
 
-func (m *ResponseHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ClusterId != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ClusterId))
-	}
-	if m.Error != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Error.Size()))
-		n1, err := m.Error.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+func (m *GetClusterStatusResponse) Reset()                    { *m = GetClusterStatusResponse{} }
+func (m *GetClusterStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetClusterStatusResponse) ProtoMessage()               {}
+func (*GetClusterStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorPdpb, []int{65} }
+
+func (m *GetClusterStatusResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Error) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *GetClusterStatusResponse) GetRaftBootstrapTime() int64 {
+	if m != nil {
+		return m.RaftBootstrapTime
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Error) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Type != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Type))
-	}
-	if len(m.Message) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+func (m *GetClusterStatusResponse) GetIsInitialized() bool {
+	if m != nil {
+		return m.IsInitialized
 	}
-	return i, nil
+	return false
 }
 
-func (m *TsoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *GetClusterStatusResponse) GetReplicationStatus() *ReplicationStatus {
+	if m != nil {
+		return m.ReplicationStatus
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *TsoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n2, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
-	}
-	if m.Count != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
+func (m *GetClusterStatusResponse) GetStoreCounts() []*StoreStateCount {
+	if m != nil {
+		return m.StoreCounts
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Timestamp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func init() {
+	proto.RegisterType((*RequestHeader)(nil), "pdpb.RequestHeader")
+	proto.RegisterType((*ResponseHeader)(nil), "pdpb.ResponseHeader")
+	proto.RegisterType((*Error)(nil), "pdpb.Error")
+	proto.RegisterType((*TsoRequest)(nil), "pdpb.TsoRequest")
+	proto.RegisterType((*Timestamp)(nil), "pdpb.Timestamp")
+	proto.RegisterType((*TsoResponse)(nil), "pdpb.TsoResponse")
+	proto.RegisterType((*BootstrapRequest)(nil), "pdpb.BootstrapRequest")
+	proto.RegisterType((*BootstrapResponse)(nil), "pdpb.BootstrapResponse")
+	proto.RegisterType((*IsBootstrappedRequest)(nil), "pdpb.IsBootstrappedRequest")
+	proto.RegisterType((*IsBootstrappedResponse)(nil), "pdpb.IsBootstrappedResponse")
+	proto.RegisterType((*AllocIDRequest)(nil), "pdpb.AllocIDRequest")
+	proto.RegisterType((*AllocIDResponse)(nil), "pdpb.AllocIDResponse")
+	proto.RegisterType((*GetStoreRequest)(nil), "pdpb.GetStoreRequest")
+	proto.RegisterType((*GetStoreResponse)(nil), "pdpb.GetStoreResponse")
+	proto.RegisterType((*PutStoreRequest)(nil), "pdpb.PutStoreRequest")
+	proto.RegisterType((*PutStoreResponse)(nil), "pdpb.PutStoreResponse")
+	proto.RegisterType((*GetRegionRequest)(nil), "pdpb.GetRegionRequest")
+	proto.RegisterType((*GetRegionResponse)(nil), "pdpb.GetRegionResponse")
+	proto.RegisterType((*GetRegionByIDRequest)(nil), "pdpb.GetRegionByIDRequest")
+	proto.RegisterType((*GetClusterConfigRequest)(nil), "pdpb.GetClusterConfigRequest")
+	proto.RegisterType((*GetClusterConfigResponse)(nil), "pdpb.GetClusterConfigResponse")
+	proto.RegisterType((*PutClusterConfigRequest)(nil), "pdpb.PutClusterConfigRequest")
+	proto.RegisterType((*PutClusterConfigResponse)(nil), "pdpb.PutClusterConfigResponse")
+	proto.RegisterType((*Member)(nil), "pdpb.Member")
+	proto.RegisterType((*GetMembersRequest)(nil), "pdpb.GetMembersRequest")
+	proto.RegisterType((*GetMembersResponse)(nil), "pdpb.GetMembersResponse")
+	proto.RegisterType((*PeerStats)(nil), "pdpb.PeerStats")
+	proto.RegisterType((*RegionHeartbeatRequest)(nil), "pdpb.RegionHeartbeatRequest")
+	proto.RegisterType((*ChangePeer)(nil), "pdpb.ChangePeer")
+	proto.RegisterType((*ChangePeerV2)(nil), "pdpb.ChangePeerV2")
+	proto.RegisterType((*TransferLeader)(nil), "pdpb.TransferLeader")
+	proto.RegisterType((*RegionHeartbeatResponse)(nil), "pdpb.RegionHeartbeatResponse")
+	proto.RegisterType((*Merge)(nil), "pdpb.Merge")
+	proto.RegisterType((*SplitRegion)(nil), "pdpb.SplitRegion")
+	proto.RegisterType((*AskSplitRequest)(nil), "pdpb.AskSplitRequest")
+	proto.RegisterType((*AskSplitResponse)(nil), "pdpb.AskSplitResponse")
+	proto.RegisterType((*ReportSplitRequest)(nil), "pdpb.ReportSplitRequest")
+	proto.RegisterType((*ReportSplitResponse)(nil), "pdpb.ReportSplitResponse")
+	proto.RegisterType((*StoreStats)(nil), "pdpb.StoreStats")
+	proto.RegisterType((*StoreHeartbeatRequest)(nil), "pdpb.StoreHeartbeatRequest")
+	proto.RegisterType((*StoreHeartbeatResponse)(nil), "pdpb.StoreHeartbeatResponse")
+	proto.RegisterType((*GetAllStoresRequest)(nil), "pdpb.GetAllStoresRequest")
+	proto.RegisterType((*GetAllStoresResponse)(nil), "pdpb.GetAllStoresResponse")
+	proto.RegisterType((*ScanRegionsRequest)(nil), "pdpb.ScanRegionsRequest")
+	proto.RegisterType((*ScanRegionsResponse)(nil), "pdpb.ScanRegionsResponse")
+	proto.RegisterType((*ScatterRegionRequest)(nil), "pdpb.ScatterRegionRequest")
+	proto.RegisterType((*ScatterRegionResponse)(nil), "pdpb.ScatterRegionResponse")
+	proto.RegisterType((*GetOperatorRequest)(nil), "pdpb.GetOperatorRequest")
+	proto.RegisterType((*GetOperatorResponse)(nil), "pdpb.GetOperatorResponse")
+	proto.RegisterType((*GetGCSafePointRequest)(nil), "pdpb.GetGCSafePointRequest")
+	proto.RegisterType((*GetGCSafePointResponse)(nil), "pdpb.GetGCSafePointResponse")
+	proto.RegisterType((*UpdateGCSafePointRequest)(nil), "pdpb.UpdateGCSafePointRequest")
+	proto.RegisterType((*UpdateGCSafePointResponse)(nil), "pdpb.UpdateGCSafePointResponse")
+	proto.RegisterType((*UpdateServiceGCSafePointRequest)(nil), "pdpb.UpdateServiceGCSafePointRequest")
+	proto.RegisterType((*UpdateServiceGCSafePointResponse)(nil), "pdpb.UpdateServiceGCSafePointResponse")
+	proto.RegisterType((*AskBatchSplitRequest)(nil), "pdpb.AskBatchSplitRequest")
+	proto.RegisterType((*SplitID)(nil), "pdpb.SplitID")
+	proto.RegisterType((*AskBatchSplitResponse)(nil), "pdpb.AskBatchSplitResponse")
+	proto.RegisterType((*ReportBatchSplitRequest)(nil), "pdpb.ReportBatchSplitRequest")
+	proto.RegisterType((*ReportBatchSplitResponse)(nil), "pdpb.ReportBatchSplitResponse")
+	proto.RegisterType((*SyncRegionRequest)(nil), "pdpb.SyncRegionRequest")
+	proto.RegisterType((*SyncRegionResponse)(nil), "pdpb.SyncRegionResponse")
+	proto.RegisterType((*ReplicationStatus)(nil), "pdpb.ReplicationStatus")
+	proto.RegisterType((*StoreStateCount)(nil), "pdpb.StoreStateCount")
+	proto.RegisterType((*GetClusterStatusRequest)(nil), "pdpb.GetClusterStatusRequest")
+	proto.RegisterType((*GetClusterStatusResponse)(nil), "pdpb.GetClusterStatusResponse")
+	proto.RegisterEnum("pdpb.ErrorType", ErrorType_name, ErrorType_value)
+	proto.RegisterEnum("pdpb.ConfChangeType", ConfChangeType_name, ConfChangeType_value)
+	proto.RegisterEnum("pdpb.CheckPolicy", CheckPolicy_name, CheckPolicy_value)
+	proto.RegisterEnum("pdpb.OperatorStatus", OperatorStatus_name, OperatorStatus_value)
+	proto.RegisterEnum("pdpb.StoreState", StoreState_name, StoreState_value)
 }
 
-func (m *Timestamp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Physical != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Physical))
-	}
-	if m.Logical != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Logical))
-	}
-	return i, nil
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for PD service
+
+type PDClient interface {
+	// GetMembers get the member list of this cluster. It does not require
+	// the cluster_id in request matchs the id of this cluster.
+	GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error)
+	Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error)
+	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+	IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error)
+	AllocID(ctx context.Context, in *AllocIDRequest, opts ...grpc.CallOption) (*AllocIDResponse, error)
+	GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*GetStoreResponse, error)
+	PutStore(ctx context.Context, in *PutStoreRequest, opts ...grpc.CallOption) (*PutStoreResponse, error)
+	StoreHeartbeat(ctx context.Context, in *StoreHeartbeatRequest, opts ...grpc.CallOption) (*StoreHeartbeatResponse, error)
+	RegionHeartbeat(ctx context.Context, opts ...grpc.CallOption) (PD_RegionHeartbeatClient, error)
+	GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error)
+	GetRegionByID(ctx context.Context, in *GetRegionByIDRequest, opts ...grpc.CallOption) (*GetRegionResponse, error)
+	AskSplit(ctx context.Context, in *AskSplitRequest, opts ...grpc.CallOption) (*AskSplitResponse, error)
+	ReportSplit(ctx context.Context, in *ReportSplitRequest, opts ...grpc.CallOption) (*ReportSplitResponse, error)
+	GetClusterConfig(ctx context.Context, in *GetClusterConfigRequest, opts ...grpc.CallOption) (*GetClusterConfigResponse, error)
+	PutClusterConfig(ctx context.Context, in *PutClusterConfigRequest, opts ...grpc.CallOption) (*PutClusterConfigResponse, error)
+	GetAllStores(ctx context.Context, in *GetAllStoresRequest, opts ...grpc.CallOption) (*GetAllStoresResponse, error)
+	ScanRegions(ctx context.Context, in *ScanRegionsRequest, opts ...grpc.CallOption) (*ScanRegionsResponse, error)
+	ScatterRegion(ctx context.Context, in *ScatterRegionRequest, opts ...grpc.CallOption) (*ScatterRegionResponse, error)
+	GetOperator(ctx context.Context, in *GetOperatorRequest, opts ...grpc.CallOption) (*GetOperatorResponse, error)
+	GetGCSafePoint(ctx context.Context, in *GetGCSafePointRequest, opts ...grpc.CallOption) (*GetGCSafePointResponse, error)
+	UpdateGCSafePoint(ctx context.Context, in *UpdateGCSafePointRequest, opts ...grpc.CallOption) (*UpdateGCSafePointResponse, error)
+	UpdateServiceGCSafePoint(ctx context.Context, in *UpdateServiceGCSafePointRequest, opts ...grpc.CallOption) (*UpdateServiceGCSafePointResponse, error)
+	AskBatchSplit(ctx context.Context, in *AskBatchSplitRequest, opts ...grpc.CallOption) (*AskBatchSplitResponse, error)
+	ReportBatchSplit(ctx context.Context, in *ReportBatchSplitRequest, opts ...grpc.CallOption) (*ReportBatchSplitResponse, error)
+	// SyncRegions streams the region changes from the leader to the followers.
+	SyncRegions(ctx context.Context, opts ...grpc.CallOption) (PD_SyncRegionsClient, error)
+	GetPrevRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error)
+	GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*GetClusterStatusResponse, error)
 }
 
-func (m *TsoResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+type pDClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPDClient(cc *grpc.ClientConn) PDClient {
+	return &pDClient{cc}
+}
+
+func (c *pDClient) GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error) {
+	out := new(GetMembersResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetMembers", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *TsoResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n3, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
-	}
-	if m.Count != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
+func (c *pDClient) Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_PD_serviceDesc.Streams[0], c.cc, "/pdpb.PD/Tso", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Timestamp != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Timestamp.Size()))
-		n4, err := m.Timestamp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+	x := &pDTsoClient{stream}
+	return x, nil
+}
+
+type PD_TsoClient interface {
+	Send(*TsoRequest) error
+	Recv() (*TsoResponse, error)
+	grpc.ClientStream
+}
+
+type pDTsoClient struct {
+	grpc.ClientStream
+}
+
+func (x *pDTsoClient) Send(m *TsoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pDTsoClient) Recv() (*TsoResponse, error) {
+	m := new(TsoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
+	out := new(BootstrapResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/Bootstrap", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n5, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
-	}
-	if m.Store != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
-		n6, err := m.Store.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+func (c *pDClient) IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error) {
+	out := new(IsBootstrappedResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/IsBootstrapped", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Region != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
-		n7, err := m.Region.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+	return out, nil
+}
+
+func (c *pDClient) AllocID(ctx context.Context, in *AllocIDRequest, opts ...grpc.CallOption) (*AllocIDResponse, error) {
+	out := new(AllocIDResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/AllocID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*GetStoreResponse, error) {
+	out := new(GetStoreResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetStore", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n8, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+func (c *pDClient) PutStore(ctx context.Context, in *PutStoreRequest, opts ...grpc.CallOption) (*PutStoreResponse, error) {
+	out := new(PutStoreResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/PutStore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *IsBootstrappedRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) StoreHeartbeat(ctx context.Context, in *StoreHeartbeatRequest, opts ...grpc.CallOption) (*StoreHeartbeatResponse, error) {
+	out := new(StoreHeartbeatResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/StoreHeartbeat", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *IsBootstrappedRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n9, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+func (c *pDClient) RegionHeartbeat(ctx context.Context, opts ...grpc.CallOption) (PD_RegionHeartbeatClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_PD_serviceDesc.Streams[1], c.cc, "/pdpb.PD/RegionHeartbeat", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	x := &pDRegionHeartbeatClient{stream}
+	return x, nil
 }
 
-func (m *IsBootstrappedResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type PD_RegionHeartbeatClient interface {
+	Send(*RegionHeartbeatRequest) error
+	Recv() (*RegionHeartbeatResponse, error)
+	grpc.ClientStream
+}
+
+type pDRegionHeartbeatClient struct {
+	grpc.ClientStream
+}
+
+func (x *pDRegionHeartbeatClient) Send(m *RegionHeartbeatRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pDRegionHeartbeatClient) Recv() (*RegionHeartbeatResponse, error) {
+	m := new(RegionHeartbeatResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *IsBootstrappedResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n10, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
-	}
-	if m.Bootstrapped {
-		dAtA[i] = 0x10
-		i++
-		if m.Bootstrapped {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+func (c *pDClient) GetRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error) {
+	out := new(GetRegionResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetRegion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AllocIDRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) GetRegionByID(ctx context.Context, in *GetRegionByIDRequest, opts ...grpc.CallOption) (*GetRegionResponse, error) {
+	out := new(GetRegionResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetRegionByID", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AllocIDRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n11, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
+func (c *pDClient) AskSplit(ctx context.Context, in *AskSplitRequest, opts ...grpc.CallOption) (*AskSplitResponse, error) {
+	out := new(AskSplitResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/AskSplit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *AllocIDResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) ReportSplit(ctx context.Context, in *ReportSplitRequest, opts ...grpc.CallOption) (*ReportSplitResponse, error) {
+	out := new(ReportSplitResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/ReportSplit", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AllocIDResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n12, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
-	}
-	if m.Id != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Id))
+func (c *pDClient) GetClusterConfig(ctx context.Context, in *GetClusterConfigRequest, opts ...grpc.CallOption) (*GetClusterConfigResponse, error) {
+	out := new(GetClusterConfigResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetClusterConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *GetStoreRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) PutClusterConfig(ctx context.Context, in *PutClusterConfigRequest, opts ...grpc.CallOption) (*PutClusterConfigResponse, error) {
+	out := new(PutClusterConfigResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/PutClusterConfig", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetStoreRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n13, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n13
-	}
-	if m.StoreId != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.StoreId))
+func (c *pDClient) GetAllStores(ctx context.Context, in *GetAllStoresRequest, opts ...grpc.CallOption) (*GetAllStoresResponse, error) {
+	out := new(GetAllStoresResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetAllStores", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *GetStoreResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) ScanRegions(ctx context.Context, in *ScanRegionsRequest, opts ...grpc.CallOption) (*ScanRegionsResponse, error) {
+	out := new(ScanRegionsResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/ScanRegions", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetStoreResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n14, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n14
+func (c *pDClient) ScatterRegion(ctx context.Context, in *ScatterRegionRequest, opts ...grpc.CallOption) (*ScatterRegionResponse, error) {
+	out := new(ScatterRegionResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/ScatterRegion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Store != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
-		n15, err := m.Store.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n15
+	return out, nil
+}
+
+func (c *pDClient) GetOperator(ctx context.Context, in *GetOperatorRequest, opts ...grpc.CallOption) (*GetOperatorResponse, error) {
+	out := new(GetOperatorResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetOperator", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *PutStoreRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) GetGCSafePoint(ctx context.Context, in *GetGCSafePointRequest, opts ...grpc.CallOption) (*GetGCSafePointResponse, error) {
+	out := new(GetGCSafePointResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetGCSafePoint", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *PutStoreRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n16, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
+func (c *pDClient) UpdateGCSafePoint(ctx context.Context, in *UpdateGCSafePointRequest, opts ...grpc.CallOption) (*UpdateGCSafePointResponse, error) {
+	out := new(UpdateGCSafePointResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/UpdateGCSafePoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Store != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
-		n17, err := m.Store.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n17
+	return out, nil
+}
+
+func (c *pDClient) UpdateServiceGCSafePoint(ctx context.Context, in *UpdateServiceGCSafePointRequest, opts ...grpc.CallOption) (*UpdateServiceGCSafePointResponse, error) {
+	out := new(UpdateServiceGCSafePointResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/UpdateServiceGCSafePoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *PutStoreResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) AskBatchSplit(ctx context.Context, in *AskBatchSplitRequest, opts ...grpc.CallOption) (*AskBatchSplitResponse, error) {
+	out := new(AskBatchSplitResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/AskBatchSplit", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *PutStoreResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n18, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
+func (c *pDClient) ReportBatchSplit(ctx context.Context, in *ReportBatchSplitRequest, opts ...grpc.CallOption) (*ReportBatchSplitResponse, error) {
+	out := new(ReportBatchSplitResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/ReportBatchSplit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *GetRegionRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) SyncRegions(ctx context.Context, opts ...grpc.CallOption) (PD_SyncRegionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_PD_serviceDesc.Streams[2], c.cc, "/pdpb.PD/SyncRegions", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &pDSyncRegionsClient{stream}
+	return x, nil
 }
 
-func (m *GetRegionRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n19, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n19
-	}
-	if len(m.RegionKey) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(len(m.RegionKey)))
-		i += copy(dAtA[i:], m.RegionKey)
-	}
-	return i, nil
+type PD_SyncRegionsClient interface {
+	Send(*SyncRegionRequest) error
+	Recv() (*SyncRegionResponse, error)
+	grpc.ClientStream
 }
 
-func (m *GetRegionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type pDSyncRegionsClient struct {
+	grpc.ClientStream
 }
 
-func (m *GetRegionResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n20, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n20
-	}
-	if m.Region != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
-		n21, err := m.Region.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n21
-	}
-	if m.Leader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
-		n22, err := m.Leader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n22
-	}
-	return i, nil
+func (x *pDSyncRegionsClient) Send(m *SyncRegionRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func (m *GetRegionByIDRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func (x *pDSyncRegionsClient) Recv() (*SyncRegionResponse, error) {
+	m := new(SyncRegionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *GetRegionByIDRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n23, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n23
-	}
-	if m.RegionId != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+func (c *pDClient) GetPrevRegion(ctx context.Context, in *GetRegionRequest, opts ...grpc.CallOption) (*GetRegionResponse, error) {
+	out := new(GetRegionResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetPrevRegion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *GetClusterConfigRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *pDClient) GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*GetClusterStatusResponse, error) {
+	out := new(GetClusterStatusResponse)
+	err := grpc.Invoke(ctx, "/pdpb.PD/GetClusterStatus", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n24, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n24
-	}
-	return i, nil
-}
+// Server API for PD service
 
-func (m *GetClusterConfigResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type PDServer interface {
+	// GetMembers get the member list of this cluster. It does not require
+	// the cluster_id in request matchs the id of this cluster.
+	GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error)
+	Tso(PD_TsoServer) error
+	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+	IsBootstrapped(context.Context, *IsBootstrappedRequest) (*IsBootstrappedResponse, error)
+	AllocID(context.Context, *AllocIDRequest) (*AllocIDResponse, error)
+	GetStore(context.Context, *GetStoreRequest) (*GetStoreResponse, error)
+	PutStore(context.Context, *PutStoreRequest) (*PutStoreResponse, error)
+	StoreHeartbeat(context.Context, *StoreHeartbeatRequest) (*StoreHeartbeatResponse, error)
+	RegionHeartbeat(PD_RegionHeartbeatServer) error
+	GetRegion(context.Context, *GetRegionRequest) (*GetRegionResponse, error)
+	GetRegionByID(context.Context, *GetRegionByIDRequest) (*GetRegionResponse, error)
+	AskSplit(context.Context, *AskSplitRequest) (*AskSplitResponse, error)
+	ReportSplit(context.Context, *ReportSplitRequest) (*ReportSplitResponse, error)
+	GetClusterConfig(context.Context, *GetClusterConfigRequest) (*GetClusterConfigResponse, error)
+	PutClusterConfig(context.Context, *PutClusterConfigRequest) (*PutClusterConfigResponse, error)
+	GetAllStores(context.Context, *GetAllStoresRequest) (*GetAllStoresResponse, error)
+	ScanRegions(context.Context, *ScanRegionsRequest) (*ScanRegionsResponse, error)
+	ScatterRegion(context.Context, *ScatterRegionRequest) (*ScatterRegionResponse, error)
+	GetOperator(context.Context, *GetOperatorRequest) (*GetOperatorResponse, error)
+	GetGCSafePoint(context.Context, *GetGCSafePointRequest) (*GetGCSafePointResponse, error)
+	UpdateGCSafePoint(context.Context, *UpdateGCSafePointRequest) (*UpdateGCSafePointResponse, error)
+	UpdateServiceGCSafePoint(context.Context, *UpdateServiceGCSafePointRequest) (*UpdateServiceGCSafePointResponse, error)
+	AskBatchSplit(context.Context, *AskBatchSplitRequest) (*AskBatchSplitResponse, error)
+	ReportBatchSplit(context.Context, *ReportBatchSplitRequest) (*ReportBatchSplitResponse, error)
+	// SyncRegions streams the region changes from the leader to the followers.
+	SyncRegions(PD_SyncRegionsServer) error
+	GetPrevRegion(context.Context, *GetRegionRequest) (*GetRegionResponse, error)
+	GetClusterStatus(context.Context, *GetClusterStatusRequest) (*GetClusterStatusResponse, error)
 }
 
-func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n25, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n25
-	}
-	if m.Cluster != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Cluster.Size()))
-		n26, err := m.Cluster.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n26
-	}
-	return i, nil
+func RegisterPDServer(s *grpc.Server, srv PDServer) {
+	s.RegisterService(&_PD_serviceDesc, srv)
 }
 
-func (m *PutClusterConfigRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_GetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMembersRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n27, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n27
+	if interceptor == nil {
+		return srv.(PDServer).GetMembers(ctx, in)
 	}
-	if m.Cluster != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Cluster.Size()))
-		n28, err := m.Cluster.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n28
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetMembers",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetMembers(ctx, req.(*GetMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PutClusterConfigResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func _PD_Tso_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PDServer).Tso(&pDTsoServer{stream})
 }
 
-func (m *PutClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n29, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n29
-	}
-	return i, nil
+type PD_TsoServer interface {
+	Send(*TsoResponse) error
+	Recv() (*TsoRequest, error)
+	grpc.ServerStream
 }
 
-func (m *Member) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type pDTsoServer struct {
+	grpc.ServerStream
+}
+
+func (x *pDTsoServer) Send(m *TsoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pDTsoServer) Recv() (*TsoRequest, error) {
+	m := new(TsoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *Member) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+func _PD_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.MemberId != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.MemberId))
+	if interceptor == nil {
+		return srv.(PDServer).Bootstrap(ctx, in)
 	}
-	if len(m.PeerUrls) > 0 {
-		for _, s := range m.PeerUrls {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/Bootstrap",
 	}
-	if len(m.ClientUrls) > 0 {
-		for _, s := range m.ClientUrls {
-			dAtA[i] = 0x22
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).Bootstrap(ctx, req.(*BootstrapRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetMembersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_IsBootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBootstrappedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GetMembersRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n30, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n30
+	if interceptor == nil {
+		return srv.(PDServer).IsBootstrapped(ctx, in)
 	}
-	return i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/IsBootstrapped",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).IsBootstrapped(ctx, req.(*IsBootstrappedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetMembersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_AllocID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocIDRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GetMembersResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n31, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n31
+	if interceptor == nil {
+		return srv.(PDServer).AllocID(ctx, in)
 	}
-	if len(m.Members) > 0 {
-		for _, msg := range m.Members {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/AllocID",
 	}
-	if m.Leader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
-		n32, err := m.Leader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n32
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).AllocID(ctx, req.(*AllocIDRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PeerStats) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_GetStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoreRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).GetStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetStore(ctx, req.(*GetStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PeerStats) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Peer != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
-		n33, err := m.Peer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n33
+func _PD_PutStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.DownSeconds != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.DownSeconds))
+	if interceptor == nil {
+		return srv.(PDServer).PutStore(ctx, in)
 	}
-	return i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/PutStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).PutStore(ctx, req.(*PutStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RegionHeartbeatRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_StoreHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreHeartbeatRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).StoreHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/StoreHeartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).StoreHeartbeat(ctx, req.(*StoreHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n34, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n34
+func _PD_RegionHeartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PDServer).RegionHeartbeat(&pDRegionHeartbeatServer{stream})
+}
+
+type PD_RegionHeartbeatServer interface {
+	Send(*RegionHeartbeatResponse) error
+	Recv() (*RegionHeartbeatRequest, error)
+	grpc.ServerStream
+}
+
+type pDRegionHeartbeatServer struct {
+	grpc.ServerStream
+}
+
+func (x *pDRegionHeartbeatServer) Send(m *RegionHeartbeatResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pDRegionHeartbeatServer) Recv() (*RegionHeartbeatRequest, error) {
+	m := new(RegionHeartbeatRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if m.Region != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
-		n35, err := m.Region.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n35
+	return m, nil
+}
+
+func _PD_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Leader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
-		n36, err := m.Leader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n36
+	if interceptor == nil {
+		return srv.(PDServer).GetRegion(ctx, in)
 	}
-	if len(m.DownPeers) > 0 {
-		for _, msg := range m.DownPeers {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetRegion",
 	}
-	if len(m.PendingPeers) > 0 {
-		for _, msg := range m.PendingPeers {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetRegion(ctx, req.(*GetRegionRequest))
 	}
-	if m.BytesWritten != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesWritten))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PD_GetRegionByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.BytesRead != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesRead))
+	if interceptor == nil {
+		return srv.(PDServer).GetRegionByID(ctx, in)
 	}
-	if m.KeysWritten != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysWritten))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetRegionByID",
 	}
-	if m.KeysRead != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysRead))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetRegionByID(ctx, req.(*GetRegionByIDRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_AskSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskSplitRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).AskSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/AskSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).AskSplit(ctx, req.(*AskSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Peer != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
-		n37, err := m.Peer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n37
+func _PD_ReportSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.ChangeType != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ChangeType))
+	if interceptor == nil {
+		return srv.(PDServer).ReportSplit(ctx, in)
 	}
-	return i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/ReportSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).ReportSplit(ctx, req.(*ReportSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_GetClusterConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterConfigRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).GetClusterConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetClusterConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetClusterConfig(ctx, req.(*GetClusterConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Peer != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
-		n38, err := m.Peer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n38
+func _PD_PutClusterConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutClusterConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return i, nil
+	if interceptor == nil {
+		return srv.(PDServer).PutClusterConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/PutClusterConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).PutClusterConfig(ctx, req.(*PutClusterConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_GetAllStores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllStoresRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).GetAllStores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetAllStores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetAllStores(ctx, req.(*GetAllStoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n39, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n39
+func _PD_ScanRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n40, err := m.ChangePeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n40
-	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n41, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n41
-	}
-	if m.RegionId != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+	if interceptor == nil {
+		return srv.(PDServer).ScanRegions(ctx, in)
 	}
-	if m.RegionEpoch != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionEpoch.Size()))
-		n42, err := m.RegionEpoch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n42
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/ScanRegions",
 	}
-	if m.TargetPeer != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.TargetPeer.Size()))
-		n43, err := m.TargetPeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n43
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).ScanRegions(ctx, req.(*ScanRegionsRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AskSplitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_ScatterRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScatterRegionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).ScatterRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/ScatterRegion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).ScatterRegion(ctx, req.(*ScatterRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n44, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n44
+func _PD_GetOperator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Region != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
-		n45, err := m.Region.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n45
+	if interceptor == nil {
+		return srv.(PDServer).GetOperator(ctx, in)
 	}
-	return i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetOperator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetOperator(ctx, req.(*GetOperatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AskSplitResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_GetGCSafePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGCSafePointRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).GetGCSafePoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetGCSafePoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetGCSafePoint(ctx, req.(*GetGCSafePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n46, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n46
+func _PD_UpdateGCSafePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGCSafePointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.NewRegionId != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.NewRegionId))
+	if interceptor == nil {
+		return srv.(PDServer).UpdateGCSafePoint(ctx, in)
 	}
-	if len(m.NewPeerIds) > 0 {
-		dAtA48 := make([]byte, len(m.NewPeerIds)*10)
-		var j47 int
-		for _, num := range m.NewPeerIds {
-			for num >= 1<<7 {
-				dAtA48[j47] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j47++
-			}
-			dAtA48[j47] = uint8(num)
-			j47++
-		}
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(j47))
-		i += copy(dAtA[i:], dAtA48[:j47])
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/UpdateGCSafePoint",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).UpdateGCSafePoint(ctx, req.(*UpdateGCSafePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReportSplitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_UpdateServiceGCSafePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceGCSafePointRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).UpdateServiceGCSafePoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/UpdateServiceGCSafePoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).UpdateServiceGCSafePoint(ctx, req.(*UpdateServiceGCSafePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n49, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n49
+func _PD_AskBatchSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskBatchSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Left != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Left.Size()))
-		n50, err := m.Left.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n50
+	if interceptor == nil {
+		return srv.(PDServer).AskBatchSplit(ctx, in)
 	}
-	if m.Right != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Right.Size()))
-		n51, err := m.Right.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n51
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/AskBatchSplit",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).AskBatchSplit(ctx, req.(*AskBatchSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReportSplitResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _PD_ReportBatchSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportBatchSplitRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PDServer).ReportBatchSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/ReportBatchSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).ReportBatchSplit(ctx, req.(*ReportBatchSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n52, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n52
-	}
-	return i, nil
+func _PD_SyncRegions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PDServer).SyncRegions(&pDSyncRegionsServer{stream})
 }
 
-func (m *StoreStats) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type PD_SyncRegionsServer interface {
+	Send(*SyncRegionResponse) error
+	Recv() (*SyncRegionRequest, error)
+	grpc.ServerStream
+}
+
+type pDSyncRegionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *pDSyncRegionsServer) Send(m *SyncRegionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pDSyncRegionsServer) Recv() (*SyncRegionRequest, error) {
+	m := new(SyncRegionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.StoreId != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.StoreId))
+func _PD_GetPrevRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Capacity != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Capacity))
-	}
-	if m.Available != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Available))
+	if interceptor == nil {
+		return srv.(PDServer).GetPrevRegion(ctx, in)
 	}
-	if m.RegionCount != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionCount))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetPrevRegion",
 	}
-	if m.SendingSnapCount != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.SendingSnapCount))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetPrevRegion(ctx, req.(*GetRegionRequest))
 	}
-	if m.ReceivingSnapCount != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ReceivingSnapCount))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PD_GetClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.StartTime != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.StartTime))
+	if interceptor == nil {
+		return srv.(PDServer).GetClusterStatus(ctx, in)
 	}
-	if m.ApplyingSnapCount != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.ApplyingSnapCount))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pdpb.PD/GetClusterStatus",
 	}
-	if m.IsBusy {
-		dAtA[i] = 0x48
-		i++
-		if m.IsBusy {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetClusterStatus(ctx, req.(*GetClusterStatusRequest))
 	}
-	if m.UsedSize != 0 {
-		dAtA[i] = 0x50
-		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.UsedSize))
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PD_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pdpb.PD",
+	HandlerType: (*PDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMembers",
+			Handler:    _PD_GetMembers_Handler,
+		},
+		{
+			MethodName: "Bootstrap",
+			Handler:    _PD_Bootstrap_Handler,
+		},
+		{
+			MethodName: "IsBootstrapped",
+			Handler:    _PD_IsBootstrapped_Handler,
+		},
+		{
+			MethodName: "AllocID",
+			Handler:    _PD_AllocID_Handler,
+		},
+		{
+			MethodName: "GetStore",
+			Handler:    _PD_GetStore_Handler,
+		},
+		{
+			MethodName: "PutStore",
+			Handler:    _PD_PutStore_Handler,
+		},
+		{
+			MethodName: "StoreHeartbeat",
+			Handler:    _PD_StoreHeartbeat_Handler,
+		},
+		{
+			MethodName: "GetRegion",
+			Handler:    _PD_GetRegion_Handler,
+		},
+		{
+			MethodName: "GetRegionByID",
+			Handler:    _PD_GetRegionByID_Handler,
+		},
+		{
+			MethodName: "AskSplit",
+			Handler:    _PD_AskSplit_Handler,
+		},
+		{
+			MethodName: "ReportSplit",
+			Handler:    _PD_ReportSplit_Handler,
+		},
+		{
+			MethodName: "GetClusterConfig",
+			Handler:    _PD_GetClusterConfig_Handler,
+		},
+		{
+			MethodName: "PutClusterConfig",
+			Handler:    _PD_PutClusterConfig_Handler,
+		},
+		{
+			MethodName: "GetAllStores",
+			Handler:    _PD_GetAllStores_Handler,
+		},
+		{
+			MethodName: "ScanRegions",
+			Handler:    _PD_ScanRegions_Handler,
+		},
+		{
+			MethodName: "ScatterRegion",
+			Handler:    _PD_ScatterRegion_Handler,
+		},
+		{
+			MethodName: "GetOperator",
+			Handler:    _PD_GetOperator_Handler,
+		},
+		{
+			MethodName: "GetGCSafePoint",
+			Handler:    _PD_GetGCSafePoint_Handler,
+		},
+		{
+			MethodName: "UpdateGCSafePoint",
+			Handler:    _PD_UpdateGCSafePoint_Handler,
+		},
+		{
+			MethodName: "UpdateServiceGCSafePoint",
+			Handler:    _PD_UpdateServiceGCSafePoint_Handler,
+		},
+		{
+			MethodName: "AskBatchSplit",
+			Handler:    _PD_AskBatchSplit_Handler,
+		},
+		{
+			MethodName: "ReportBatchSplit",
+			Handler:    _PD_ReportBatchSplit_Handler,
+		},
+		{
+			MethodName: "GetPrevRegion",
+			Handler:    _PD_GetPrevRegion_Handler,
+		},
+		{
+			MethodName: "GetClusterStatus",
+			Handler:    _PD_GetClusterStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Tso",
+			Handler:       _PD_Tso_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RegionHeartbeat",
+			Handler:       _PD_RegionHeartbeat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SyncRegions",
+			Handler:       _PD_SyncRegions_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pdpb.proto",
+}
+
+func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.BytesWritten != 0 {
-		dAtA[i] = 0x58
+	return dAtA[:n], nil
+}
+
+func (m *RequestHeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ClusterId != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesWritten))
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ClusterId))
 	}
-	if m.KeysWritten != 0 {
-		dAtA[i] = 0x60
+	if m.MaxStalenessMs != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysWritten))
+		i = encodeVarintPdpb(dAtA, i, uint64(m.MaxStalenessMs))
 	}
 	return i, nil
 }
 
-func (m *StoreHeartbeatRequest) Marshal() (dAtA []byte, err error) {
+func (m *ResponseHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3303,35 +3484,30 @@ func (m *StoreHeartbeatRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StoreHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseHeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		dAtA[i] = 0xa
+	if m.ClusterId != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n53, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n53
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ClusterId))
 	}
-	if m.Stats != nil {
+	if m.Error != nil {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintPdpb(dAtA, i, uint64(m.Stats.Size()))
-		n54, err := m.Stats.MarshalTo(dAtA[i:])
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Error.Size()))
+		n1, err := m.Error.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n1
 	}
 	return i, nil
 }
 
-func (m *StoreHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+func (m *Error) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3341,7 +3517,36 @@ func (m *StoreHeartbeatResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StoreHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *Error) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *TsoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TsoRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -3350,652 +3555,7440 @@ func (m *StoreHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
-		n55, err := m.Header.MarshalTo(dAtA[i:])
+		n2, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n55
+		i += n2
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
+	}
+	if len(m.DcLocation) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.DcLocation)))
+		i += copy(dAtA[i:], m.DcLocation)
 	}
 	return i, nil
 }
 
-func encodeFixed64Pdpb(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Pdpb(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintPdpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *RequestHeader) Size() (n int) {
-	var l int
-	_ = l
-	if m.ClusterId != 0 {
-		n += 1 + sovPdpb(uint64(m.ClusterId))
+func (m *Timestamp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ResponseHeader) Size() (n int) {
+func (m *Timestamp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ClusterId != 0 {
-		n += 1 + sovPdpb(uint64(m.ClusterId))
+	if m.Physical != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Physical))
 	}
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.Logical != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Logical))
 	}
-	return n
+	return i, nil
 }
 
-func (m *Error) Size() (n int) {
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovPdpb(uint64(m.Type))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *TsoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *TsoRequest) Size() (n int) {
+func (m *TsoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n3, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
 	}
 	if m.Count != 0 {
-		n += 1 + sovPdpb(uint64(m.Count))
-	}
-	return n
-}
-
-func (m *Timestamp) Size() (n int) {
-	var l int
-	_ = l
-	if m.Physical != 0 {
-		n += 1 + sovPdpb(uint64(m.Physical))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
 	}
-	if m.Logical != 0 {
-		n += 1 + sovPdpb(uint64(m.Logical))
+	if m.Timestamp != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Timestamp.Size()))
+		n4, err := m.Timestamp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
-	return n
+	return i, nil
 }
 
-func (m *TsoResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.Count != 0 {
-		n += 1 + sovPdpb(uint64(m.Count))
-	}
-	if m.Timestamp != nil {
-		l = m.Timestamp.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BootstrapRequest) Size() (n int) {
+func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n5, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
 	}
 	if m.Store != nil {
-		l = m.Store.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
+		n6, err := m.Store.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
 	}
 	if m.Region != nil {
-		l = m.Region.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n7, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	return n
+	return i, nil
 }
 
-func (m *BootstrapResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *IsBootstrappedRequest) Size() (n int) {
+func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n8, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	return n
+	return i, nil
 }
 
-func (m *IsBootstrappedResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.Bootstrapped {
-		n += 2
+func (m *IsBootstrappedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AllocIDRequest) Size() (n int) {
+func (m *IsBootstrappedRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n9, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
-	return n
+	return i, nil
 }
 
-func (m *AllocIDResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.Id != 0 {
-		n += 1 + sovPdpb(uint64(m.Id))
+func (m *IsBootstrappedResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetStoreRequest) Size() (n int) {
+func (m *IsBootstrappedResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n10, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
-	if m.StoreId != 0 {
-		n += 1 + sovPdpb(uint64(m.StoreId))
+	if m.Bootstrapped {
+		dAtA[i] = 0x10
+		i++
+		if m.Bootstrapped {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetStoreResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.Store != nil {
-		l = m.Store.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *AllocIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PutStoreRequest) Size() (n int) {
+func (m *AllocIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n11, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
 	}
-	if m.Store != nil {
-		l = m.Store.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutStoreResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *AllocIDResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetRegionRequest) Size() (n int) {
+func (m *AllocIDResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n12, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
 	}
-	l = len(m.RegionKey)
-	if l > 0 {
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.Id != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Id))
 	}
-	return n
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
 }
 
-func (m *GetRegionResponse) Size() (n int) {
+func (m *GetStoreRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStoreRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n13, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
 	}
-	if m.Region != nil {
-		l = m.Region.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.StoreId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.StoreId))
 	}
-	if m.Leader != nil {
-		l = m.Leader.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	return i, nil
+}
+
+func (m *GetStoreResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetRegionByIDRequest) Size() (n int) {
+func (m *GetStoreResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n14, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
-	if m.RegionId != 0 {
-		n += 1 + sovPdpb(uint64(m.RegionId))
+	if m.Store != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
+		n15, err := m.Store.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetClusterConfigRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *PutStoreRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetClusterConfigResponse) Size() (n int) {
+func (m *PutStoreRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n16, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
 	}
-	if m.Cluster != nil {
-		l = m.Cluster.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.Store != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Store.Size()))
+		n17, err := m.Store.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutClusterConfigRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.Cluster != nil {
-		l = m.Cluster.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *PutStoreResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PutClusterConfigResponse) Size() (n int) {
+func (m *PutStoreResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n18, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
 	}
-	return n
+	return i, nil
 }
 
-func (m *Member) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *GetRegionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.MemberId != 0 {
-		n += 1 + sovPdpb(uint64(m.MemberId))
-	}
-	if len(m.PeerUrls) > 0 {
-		for _, s := range m.PeerUrls {
-			l = len(s)
-			n += 1 + l + sovPdpb(uint64(l))
-		}
-	}
-	if len(m.ClientUrls) > 0 {
-		for _, s := range m.ClientUrls {
-			l = len(s)
-			n += 1 + l + sovPdpb(uint64(l))
-		}
-	}
-	return n
-}
-
-func (m *GetMembersRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetMembersResponse) Size() (n int) {
+func (m *GetRegionRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n19, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n19
 	}
-	if m.Leader != nil {
-		l = m.Leader.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if len(m.RegionKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.RegionKey)))
+		i += copy(dAtA[i:], m.RegionKey)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PeerStats) Size() (n int) {
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = m.Peer.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.DownSeconds != 0 {
-		n += 1 + sovPdpb(uint64(m.DownSeconds))
+func (m *GetRegionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RegionHeartbeatRequest) Size() (n int) {
+func (m *GetRegionResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n20, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
 	}
 	if m.Region != nil {
-		l = m.Region.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n21, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
 	}
 	if m.Leader != nil {
-		l = m.Leader.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
+		n22, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
 	}
 	if len(m.DownPeers) > 0 {
-		for _, e := range m.DownPeers {
-			l = e.Size()
-			n += 1 + l + sovPdpb(uint64(l))
+		for _, msg := range m.DownPeers {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
 	if len(m.PendingPeers) > 0 {
-		for _, e := range m.PendingPeers {
-			l = e.Size()
-			n += 1 + l + sovPdpb(uint64(l))
+		for _, msg := range m.PendingPeers {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if m.BytesWritten != 0 {
-		n += 1 + sovPdpb(uint64(m.BytesWritten))
-	}
-	if m.BytesRead != 0 {
-		n += 1 + sovPdpb(uint64(m.BytesRead))
-	}
-	if m.KeysWritten != 0 {
-		n += 1 + sovPdpb(uint64(m.KeysWritten))
-	}
-	if m.KeysRead != 0 {
-		n += 1 + sovPdpb(uint64(m.KeysRead))
-	}
-	return n
-}
-
-func (m *ChangePeer) Size() (n int) {
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = m.Peer.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.ChangeType != 0 {
-		n += 1 + sovPdpb(uint64(m.ChangeType))
-	}
-	return n
+	return i, nil
 }
 
-func (m *TransferLeader) Size() (n int) {
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = m.Peer.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+func (m *GetRegionByIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RegionHeartbeatResponse) Size() (n int) {
+func (m *GetRegionByIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.ChangePeer != nil {
-		l = m.ChangePeer.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.TransferLeader != nil {
-		l = m.TransferLeader.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n23, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
 	}
 	if m.RegionId != 0 {
-		n += 1 + sovPdpb(uint64(m.RegionId))
-	}
-	if m.RegionEpoch != nil {
-		l = m.RegionEpoch.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
 	}
-	if m.TargetPeer != nil {
-		l = m.TargetPeer.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	return i, nil
+}
+
+func (m *GetClusterConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AskSplitRequest) Size() (n int) {
+func (m *GetClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n24, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
 	}
-	if m.Region != nil {
-		l = m.Region.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	return i, nil
+}
+
+func (m *GetClusterConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AskSplitResponse) Size() (n int) {
+func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	if m.NewRegionId != 0 {
-		n += 1 + sovPdpb(uint64(m.NewRegionId))
-	}
-	if len(m.NewPeerIds) > 0 {
-		l = 0
-		for _, e := range m.NewPeerIds {
-			l += sovPdpb(uint64(e))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n25, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		n += 1 + sovPdpb(uint64(l)) + l
+		i += n25
 	}
-	return n
+	if m.Cluster != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Cluster.Size()))
+		n26, err := m.Cluster.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	return i, nil
 }
 
-func (m *ReportSplitRequest) Size() (n int) {
+func (m *PutClusterConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n27, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
 	}
-	if m.Left != nil {
-		l = m.Left.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	if m.Cluster != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Cluster.Size()))
+		n28, err := m.Cluster.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
 	}
-	if m.Right != nil {
-		l = m.Right.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	return i, nil
+}
+
+func (m *PutClusterConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ReportSplitResponse) Size() (n int) {
+func (m *PutClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n29, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
 	}
-	return n
+	return i, nil
 }
 
-func (m *StoreStats) Size() (n int) {
+func (m *Member) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Member) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.StoreId != 0 {
-		n += 1 + sovPdpb(uint64(m.StoreId))
-	}
-	if m.Capacity != 0 {
-		n += 1 + sovPdpb(uint64(m.Capacity))
-	}
-	if m.Available != 0 {
-		n += 1 + sovPdpb(uint64(m.Available))
-	}
-	if m.RegionCount != 0 {
-		n += 1 + sovPdpb(uint64(m.RegionCount))
-	}
-	if m.SendingSnapCount != 0 {
-		n += 1 + sovPdpb(uint64(m.SendingSnapCount))
-	}
-	if m.ReceivingSnapCount != 0 {
-		n += 1 + sovPdpb(uint64(m.ReceivingSnapCount))
-	}
-	if m.StartTime != 0 {
-		n += 1 + sovPdpb(uint64(m.StartTime))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	if m.ApplyingSnapCount != 0 {
-		n += 1 + sovPdpb(uint64(m.ApplyingSnapCount))
+	if m.MemberId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.MemberId))
 	}
-	if m.IsBusy {
-		n += 2
+	if len(m.PeerUrls) > 0 {
+		for _, s := range m.PeerUrls {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	if m.UsedSize != 0 {
-		n += 1 + sovPdpb(uint64(m.UsedSize))
+	if len(m.ClientUrls) > 0 {
+		for _, s := range m.ClientUrls {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	if m.BytesWritten != 0 {
-		n += 1 + sovPdpb(uint64(m.BytesWritten))
+	if len(m.DcLocation) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.DcLocation)))
+		i += copy(dAtA[i:], m.DcLocation)
 	}
-	if m.KeysWritten != 0 {
-		n += 1 + sovPdpb(uint64(m.KeysWritten))
+	return i, nil
+}
+
+func (m *GetMembersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *StoreHeartbeatRequest) Size() (n int) {
+func (m *GetMembersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n30, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
 	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
-		n += 1 + l + sovPdpb(uint64(l))
+	return i, nil
+}
+
+func (m *GetMembersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *StoreHeartbeatResponse) Size() (n int) {
+func (m *GetMembersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPdpb(uint64(l))
-	}
-	return n
-}
-
-func sovPdpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n31, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n31
 	}
-	return n
-}
-func sozPdpb(x uint64) (n int) {
-	return sovPdpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *RequestHeader) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			i += n
+		}
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
+		n32, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	if len(m.LocalTsoLeaders) > 0 {
+		for _, msg := range m.LocalTsoLeaders {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PeerStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Peer != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
+		n33, err := m.Peer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
+	}
+	if m.DownSeconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.DownSeconds))
+	}
+	return i, nil
+}
+
+func (m *RegionHeartbeatRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n34, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n35, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
+		n36, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
+	}
+	if len(m.DownPeers) > 0 {
+		for _, msg := range m.DownPeers {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, msg := range m.PendingPeers {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.BytesWritten != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesWritten))
+	}
+	if m.BytesRead != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesRead))
+	}
+	if m.KeysWritten != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysWritten))
+	}
+	if m.KeysRead != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysRead))
+	}
+	if m.ApproximateSize != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ApproximateSize))
+	}
+	if m.ApproximateKeys != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ApproximateKeys))
+	}
+	return i, nil
+}
+
+func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Peer != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
+		n37, err := m.Peer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
+	}
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ChangeType))
+	}
+	return i, nil
+}
+
+func (m *ChangePeerV2) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChangePeerV2) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Peer != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Peer.Size()))
+		n38, err := m.Peer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
+	}
+	return i, nil
+}
+
+func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n39, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	if m.ChangePeer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n40, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n41, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
+	}
+	if m.RegionId != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.RegionEpoch != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionEpoch.Size()))
+		n42, err := m.RegionEpoch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	if m.TargetPeer != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.TargetPeer.Size()))
+		n43, err := m.TargetPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	if m.HeartbeatIntervalSecs != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.HeartbeatIntervalSecs))
+	}
+	if m.Merge != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Merge.Size()))
+		n44, err := m.Merge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	if m.SplitRegion != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SplitRegion.Size()))
+		n45, err := m.SplitRegion.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
+	}
+	if m.ChangePeerV2 != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ChangePeerV2.Size()))
+		n46, err := m.ChangePeerV2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
+	}
+	return i, nil
+}
+
+func (m *Merge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Merge) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Target != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Target.Size()))
+		n47, err := m.Target.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
+	}
+	return i, nil
+}
+
+func (m *SplitRegion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitRegion) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Policy != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Policy))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *AskSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n48, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n48
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n49, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
+	return i, nil
+}
+
+func (m *AskSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n50, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	if m.NewRegionId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		dAtA52 := make([]byte, len(m.NewPeerIds)*10)
+		var j51 int
+		for _, num := range m.NewPeerIds {
+			for num >= 1<<7 {
+				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j51++
+			}
+			dAtA52[j51] = uint8(num)
+			j51++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(j51))
+		i += copy(dAtA[i:], dAtA52[:j51])
+	}
+	return i, nil
+}
+
+func (m *ReportSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n53, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.Left != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Left.Size()))
+		n54, err := m.Left.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if m.Right != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Right.Size()))
+		n55, err := m.Right.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	return i, nil
+}
+
+func (m *ReportSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n56, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	return i, nil
+}
+
+func (m *StoreStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.StoreId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.StoreId))
+	}
+	if m.Capacity != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Available))
+	}
+	if m.RegionCount != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionCount))
+	}
+	if m.SendingSnapCount != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SendingSnapCount))
+	}
+	if m.ReceivingSnapCount != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ReceivingSnapCount))
+	}
+	if m.StartTime != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.StartTime))
+	}
+	if m.ApplyingSnapCount != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ApplyingSnapCount))
+	}
+	if m.IsBusy {
+		dAtA[i] = 0x48
+		i++
+		if m.IsBusy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.UsedSize != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.UsedSize))
+	}
+	if m.BytesWritten != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.BytesWritten))
+	}
+	if m.KeysWritten != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.KeysWritten))
+	}
+	if m.SnapshotBytes != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SnapshotBytes))
+	}
+	if m.SnapshotDurationMs != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SnapshotDurationMs))
+	}
+	return i, nil
+}
+
+func (m *StoreHeartbeatRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n57, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	if m.Stats != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Stats.Size()))
+		n58, err := m.Stats.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	return i, nil
+}
+
+func (m *StoreHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n59, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n59
+	}
+	if m.HeartbeatIntervalSecs != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.HeartbeatIntervalSecs))
+	}
+	return i, nil
+}
+
+func (m *GetAllStoresRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAllStoresRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n60, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
+	}
+	if m.ExcludeTombstoneStores {
+		dAtA[i] = 0x10
+		i++
+		if m.ExcludeTombstoneStores {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *GetAllStoresResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAllStoresResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n61, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n61
+	}
+	if len(m.Stores) > 0 {
+		for _, msg := range m.Stores {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ScanRegionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanRegionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n62, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n62
+	}
+	if len(m.StartKey) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.StartKey)))
+		i += copy(dAtA[i:], m.StartKey)
+	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Limit))
+	}
+	if len(m.EndKey) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.EndKey)))
+		i += copy(dAtA[i:], m.EndKey)
+	}
+	return i, nil
+}
+
+func (m *ScanRegionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScanRegionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n63, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n63
+	}
+	if len(m.RegionMetas) > 0 {
+		for _, msg := range m.RegionMetas {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for _, msg := range m.Leaders {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ScatterRegionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScatterRegionRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n64, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n64
+	}
+	if m.RegionId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n65, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n65
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Leader.Size()))
+		n66, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n66
+	}
+	return i, nil
+}
+
+func (m *ScatterRegionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScatterRegionResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n67, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n67
+	}
+	return i, nil
+}
+
+func (m *GetOperatorRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperatorRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n68, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n68
+	}
+	if m.RegionId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+	}
+	return i, nil
+}
+
+func (m *GetOperatorResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperatorResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n69, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n69
+	}
+	if m.RegionId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.Status != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Status))
+	}
+	if len(m.Kind) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.Kind)))
+		i += copy(dAtA[i:], m.Kind)
+	}
+	if len(m.Steps) > 0 {
+		for _, s := range m.Steps {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.FinishedSteps != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.FinishedSteps))
+	}
+	return i, nil
+}
+
+func (m *GetGCSafePointRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n70, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n70
+	}
+	return i, nil
+}
+
+func (m *GetGCSafePointResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n71, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n71
+	}
+	if m.SafePoint != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SafePoint))
+	}
+	return i, nil
+}
+
+func (m *UpdateGCSafePointRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n72, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n72
+	}
+	if m.SafePoint != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SafePoint))
+	}
+	return i, nil
+}
+
+func (m *UpdateGCSafePointResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n73, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n73
+	}
+	if m.NewSafePoint != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.NewSafePoint))
+	}
+	return i, nil
+}
+
+func (m *UpdateServiceGCSafePointRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateServiceGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n74, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n74
+	}
+	if len(m.ServiceId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.ServiceId)))
+		i += copy(dAtA[i:], m.ServiceId)
+	}
+	if m.Ttl != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Ttl))
+	}
+	if m.SafePoint != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SafePoint))
+	}
+	return i, nil
+}
+
+func (m *UpdateServiceGCSafePointResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateServiceGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n75, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n75
+	}
+	if len(m.ServiceId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(len(m.ServiceId)))
+		i += copy(dAtA[i:], m.ServiceId)
+	}
+	if m.Ttl != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Ttl))
+	}
+	if m.MinSafePoint != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.MinSafePoint))
+	}
+	return i, nil
+}
+
+func (m *AskBatchSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskBatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n76, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n76
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Region.Size()))
+		n77, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n77
+	}
+	if m.SplitCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.SplitCount))
+	}
+	return i, nil
+}
+
+func (m *SplitID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NewRegionId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		dAtA79 := make([]byte, len(m.NewPeerIds)*10)
+		var j78 int
+		for _, num := range m.NewPeerIds {
+			for num >= 1<<7 {
+				dAtA79[j78] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j78++
+			}
+			dAtA79[j78] = uint8(num)
+			j78++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(j78))
+		i += copy(dAtA[i:], dAtA79[:j78])
+	}
+	return i, nil
+}
+
+func (m *AskBatchSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskBatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n80, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n80
+	}
+	if len(m.Ids) > 0 {
+		for _, msg := range m.Ids {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ReportBatchSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportBatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n81, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n81
+	}
+	if len(m.Regions) > 0 {
+		for _, msg := range m.Regions {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ReportBatchSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportBatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n82, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n82
+	}
+	return i, nil
+}
+
+func (m *SyncRegionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncRegionRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n83, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n83
+	}
+	if m.Member != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Member.Size()))
+		n84, err := m.Member.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n84
+	}
+	if m.StartIndex != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.StartIndex))
+	}
+	return i, nil
+}
+
+func (m *SyncRegionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncRegionResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n85, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n85
+	}
+	if len(m.Regions) > 0 {
+		for _, msg := range m.Regions {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.RegionLeaders) > 0 {
+		for _, msg := range m.RegionLeaders {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.NextIndex != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.NextIndex))
+	}
+	return i, nil
+}
+
+func (m *ReplicationStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicationStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxReplicas != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.MaxReplicas))
+	}
+	if m.UnderReplicatedRegions != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.UnderReplicatedRegions))
+	}
+	if m.OverReplicatedRegions != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.OverReplicatedRegions))
+	}
+	if m.DownPeerRegions != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.DownPeerRegions))
+	}
+	if m.PendingPeerRegions != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.PendingPeerRegions))
+	}
+	return i, nil
+}
+
+func (m *StoreStateCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreStateCount) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.State))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
+}
+
+func (m *GetClusterStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetClusterStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n86, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n86
+	}
+	return i, nil
+}
+
+func (m *GetClusterStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetClusterStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.Header.Size()))
+		n87, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n87
+	}
+	if m.RaftBootstrapTime != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.RaftBootstrapTime))
+	}
+	if m.IsInitialized {
+		dAtA[i] = 0x18
+		i++
+		if m.IsInitialized {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ReplicationStatus != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPdpb(dAtA, i, uint64(m.ReplicationStatus.Size()))
+		n88, err := m.ReplicationStatus.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n88
+	}
+	if len(m.StoreCounts) > 0 {
+		for _, msg := range m.StoreCounts {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPdpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64Pdpb(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Pdpb(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintPdpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *RequestHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.ClusterId != 0 {
+		n += 1 + sovPdpb(uint64(m.ClusterId))
+	}
+	if m.MaxStalenessMs != 0 {
+		n += 1 + sovPdpb(uint64(m.MaxStalenessMs))
+	}
+	return n
+}
+
+func (m *ResponseHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.ClusterId != 0 {
+		n += 1 + sovPdpb(uint64(m.ClusterId))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Error) Size() (n int) {
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovPdpb(uint64(m.Type))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *TsoRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPdpb(uint64(m.Count))
+	}
+	l = len(m.DcLocation)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Timestamp) Size() (n int) {
+	var l int
+	_ = l
+	if m.Physical != 0 {
+		n += 1 + sovPdpb(uint64(m.Physical))
+	}
+	if m.Logical != 0 {
+		n += 1 + sovPdpb(uint64(m.Logical))
+	}
+	return n
+}
+
+func (m *TsoResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPdpb(uint64(m.Count))
+	}
+	if m.Timestamp != nil {
+		l = m.Timestamp.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *BootstrapRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Store != nil {
+		l = m.Store.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *BootstrapResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *IsBootstrappedRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *IsBootstrappedResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Bootstrapped {
+		n += 2
+	}
+	return n
+}
+
+func (m *AllocIDRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPdpb(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *AllocIDResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Id != 0 {
+		n += 1 + sovPdpb(uint64(m.Id))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPdpb(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *GetStoreRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.StoreId != 0 {
+		n += 1 + sovPdpb(uint64(m.StoreId))
+	}
+	return n
+}
+
+func (m *GetStoreResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Store != nil {
+		l = m.Store.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *PutStoreRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Store != nil {
+		l = m.Store.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *PutStoreResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRegionRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	l = len(m.RegionKey)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRegionResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.DownPeers) > 0 {
+		for _, e := range m.DownPeers {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, e := range m.PendingPeers {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRegionByIDRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionId))
+	}
+	return n
+}
+
+func (m *GetClusterConfigRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetClusterConfigResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Cluster != nil {
+		l = m.Cluster.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *PutClusterConfigRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Cluster != nil {
+		l = m.Cluster.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *PutClusterConfigResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Member) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.MemberId != 0 {
+		n += 1 + sovPdpb(uint64(m.MemberId))
+	}
+	if len(m.PeerUrls) > 0 {
+		for _, s := range m.PeerUrls {
+			l = len(s)
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if len(m.ClientUrls) > 0 {
+		for _, s := range m.ClientUrls {
+			l = len(s)
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	l = len(m.DcLocation)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetMembersRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetMembersResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.LocalTsoLeaders) > 0 {
+		for _, e := range m.LocalTsoLeaders {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PeerStats) Size() (n int) {
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.DownSeconds != 0 {
+		n += 1 + sovPdpb(uint64(m.DownSeconds))
+	}
+	return n
+}
+
+func (m *RegionHeartbeatRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.DownPeers) > 0 {
+		for _, e := range m.DownPeers {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, e := range m.PendingPeers {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if m.BytesWritten != 0 {
+		n += 1 + sovPdpb(uint64(m.BytesWritten))
+	}
+	if m.BytesRead != 0 {
+		n += 1 + sovPdpb(uint64(m.BytesRead))
+	}
+	if m.KeysWritten != 0 {
+		n += 1 + sovPdpb(uint64(m.KeysWritten))
+	}
+	if m.KeysRead != 0 {
+		n += 1 + sovPdpb(uint64(m.KeysRead))
+	}
+	if m.ApproximateSize != 0 {
+		n += 1 + sovPdpb(uint64(m.ApproximateSize))
+	}
+	if m.ApproximateKeys != 0 {
+		n += 1 + sovPdpb(uint64(m.ApproximateKeys))
+	}
+	return n
+}
+
+func (m *ChangePeer) Size() (n int) {
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.ChangeType != 0 {
+		n += 1 + sovPdpb(uint64(m.ChangeType))
+	}
+	return n
+}
+
+func (m *ChangePeerV2) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TransferLeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *RegionHeartbeatResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.ChangePeer != nil {
+		l = m.ChangePeer.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.TransferLeader != nil {
+		l = m.TransferLeader.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionId))
+	}
+	if m.RegionEpoch != nil {
+		l = m.RegionEpoch.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.TargetPeer != nil {
+		l = m.TargetPeer.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.HeartbeatIntervalSecs != 0 {
+		n += 1 + sovPdpb(uint64(m.HeartbeatIntervalSecs))
+	}
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.SplitRegion != nil {
+		l = m.SplitRegion.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.ChangePeerV2 != nil {
+		l = m.ChangePeerV2.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Merge) Size() (n int) {
+	var l int
+	_ = l
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SplitRegion) Size() (n int) {
+	var l int
+	_ = l
+	if m.Policy != 0 {
+		n += 1 + sovPdpb(uint64(m.Policy))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AskSplitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *AskSplitResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.NewRegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		l = 0
+		for _, e := range m.NewPeerIds {
+			l += sovPdpb(uint64(e))
+		}
+		n += 1 + sovPdpb(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *ReportSplitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Left != nil {
+		l = m.Left.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Right != nil {
+		l = m.Right.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ReportSplitResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *StoreStats) Size() (n int) {
+	var l int
+	_ = l
+	if m.StoreId != 0 {
+		n += 1 + sovPdpb(uint64(m.StoreId))
+	}
+	if m.Capacity != 0 {
+		n += 1 + sovPdpb(uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		n += 1 + sovPdpb(uint64(m.Available))
+	}
+	if m.RegionCount != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionCount))
+	}
+	if m.SendingSnapCount != 0 {
+		n += 1 + sovPdpb(uint64(m.SendingSnapCount))
+	}
+	if m.ReceivingSnapCount != 0 {
+		n += 1 + sovPdpb(uint64(m.ReceivingSnapCount))
+	}
+	if m.StartTime != 0 {
+		n += 1 + sovPdpb(uint64(m.StartTime))
+	}
+	if m.ApplyingSnapCount != 0 {
+		n += 1 + sovPdpb(uint64(m.ApplyingSnapCount))
+	}
+	if m.IsBusy {
+		n += 2
+	}
+	if m.UsedSize != 0 {
+		n += 1 + sovPdpb(uint64(m.UsedSize))
+	}
+	if m.BytesWritten != 0 {
+		n += 1 + sovPdpb(uint64(m.BytesWritten))
+	}
+	if m.KeysWritten != 0 {
+		n += 1 + sovPdpb(uint64(m.KeysWritten))
+	}
+	if m.SnapshotBytes != 0 {
+		n += 1 + sovPdpb(uint64(m.SnapshotBytes))
+	}
+	if m.SnapshotDurationMs != 0 {
+		n += 1 + sovPdpb(uint64(m.SnapshotDurationMs))
+	}
+	return n
+}
+
+func (m *StoreHeartbeatRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *StoreHeartbeatResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.HeartbeatIntervalSecs != 0 {
+		n += 1 + sovPdpb(uint64(m.HeartbeatIntervalSecs))
+	}
+	return n
+}
+
+func (m *GetAllStoresRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.ExcludeTombstoneStores {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetAllStoresResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Stores) > 0 {
+		for _, e := range m.Stores {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ScanRegionsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	l = len(m.StartKey)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovPdpb(uint64(m.Limit))
+	}
+	l = len(m.EndKey)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ScanRegionsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.RegionMetas) > 0 {
+		for _, e := range m.RegionMetas {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for _, e := range m.Leaders {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ScatterRegionRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionId))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *ScatterRegionResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetOperatorRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionId))
+	}
+	return n
+}
+
+func (m *GetOperatorResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.RegionId))
+	}
+	if m.Status != 0 {
+		n += 1 + sovPdpb(uint64(m.Status))
+	}
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Steps) > 0 {
+		for _, s := range m.Steps {
+			l = len(s)
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if m.FinishedSteps != 0 {
+		n += 1 + sovPdpb(uint64(m.FinishedSteps))
+	}
+	return n
+}
+
+func (m *GetGCSafePointRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetGCSafePointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.SafePoint != 0 {
+		n += 1 + sovPdpb(uint64(m.SafePoint))
+	}
+	return n
+}
+
+func (m *UpdateGCSafePointRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.SafePoint != 0 {
+		n += 1 + sovPdpb(uint64(m.SafePoint))
+	}
+	return n
+}
+
+func (m *UpdateGCSafePointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.NewSafePoint != 0 {
+		n += 1 + sovPdpb(uint64(m.NewSafePoint))
+	}
+	return n
+}
+
+func (m *UpdateServiceGCSafePointRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	l = len(m.ServiceId)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Ttl != 0 {
+		n += 1 + sovPdpb(uint64(m.Ttl))
+	}
+	if m.SafePoint != 0 {
+		n += 1 + sovPdpb(uint64(m.SafePoint))
+	}
+	return n
+}
+
+func (m *UpdateServiceGCSafePointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	l = len(m.ServiceId)
+	if l > 0 {
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Ttl != 0 {
+		n += 1 + sovPdpb(uint64(m.Ttl))
+	}
+	if m.MinSafePoint != 0 {
+		n += 1 + sovPdpb(uint64(m.MinSafePoint))
+	}
+	return n
+}
+
+func (m *AskBatchSplitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.SplitCount != 0 {
+		n += 1 + sovPdpb(uint64(m.SplitCount))
+	}
+	return n
+}
+
+func (m *SplitID) Size() (n int) {
+	var l int
+	_ = l
+	if m.NewRegionId != 0 {
+		n += 1 + sovPdpb(uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		l = 0
+		for _, e := range m.NewPeerIds {
+			l += sovPdpb(uint64(e))
+		}
+		n += 1 + sovPdpb(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *AskBatchSplitResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Ids) > 0 {
+		for _, e := range m.Ids {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReportBatchSplitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReportBatchSplitResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *SyncRegionRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.StartIndex != 0 {
+		n += 1 + sovPdpb(uint64(m.StartIndex))
+	}
+	return n
+}
+
+func (m *SyncRegionResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if len(m.RegionLeaders) > 0 {
+		for _, e := range m.RegionLeaders {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	if m.NextIndex != 0 {
+		n += 1 + sovPdpb(uint64(m.NextIndex))
+	}
+	return n
+}
+
+func (m *ReplicationStatus) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxReplicas != 0 {
+		n += 1 + sovPdpb(uint64(m.MaxReplicas))
+	}
+	if m.UnderReplicatedRegions != 0 {
+		n += 1 + sovPdpb(uint64(m.UnderReplicatedRegions))
+	}
+	if m.OverReplicatedRegions != 0 {
+		n += 1 + sovPdpb(uint64(m.OverReplicatedRegions))
+	}
+	if m.DownPeerRegions != 0 {
+		n += 1 + sovPdpb(uint64(m.DownPeerRegions))
+	}
+	if m.PendingPeerRegions != 0 {
+		n += 1 + sovPdpb(uint64(m.PendingPeerRegions))
+	}
+	return n
+}
+
+func (m *StoreStateCount) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovPdpb(uint64(m.State))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPdpb(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *GetClusterStatusRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetClusterStatusResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if m.RaftBootstrapTime != 0 {
+		n += 1 + sovPdpb(uint64(m.RaftBootstrapTime))
+	}
+	if m.IsInitialized {
+		n += 2
+	}
+	if m.ReplicationStatus != nil {
+		l = m.ReplicationStatus.Size()
+		n += 1 + l + sovPdpb(uint64(l))
+	}
+	if len(m.StoreCounts) > 0 {
+		for _, e := range m.StoreCounts {
+			l = e.Size()
+			n += 1 + l + sovPdpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovPdpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPdpb(x uint64) (n int) {
+	return sovPdpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RequestHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+			}
+			m.ClusterId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStalenessMs", wireType)
+			}
+			m.MaxStalenessMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStalenessMs |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+			}
+			m.ClusterId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Error) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (ErrorType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TsoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TsoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TsoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DcLocation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DcLocation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Timestamp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Timestamp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Timestamp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Physical", wireType)
+			}
+			m.Physical = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Physical |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logical", wireType)
+			}
+			m.Logical = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Logical |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TsoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TsoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TsoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timestamp == nil {
+				m.Timestamp = &Timestamp{}
+			}
+			if err := m.Timestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Store == nil {
+				m.Store = &metapb.Store{}
+			}
+			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IsBootstrappedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IsBootstrappedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IsBootstrappedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IsBootstrappedResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IsBootstrappedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bootstrapped", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bootstrapped = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStoreRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+			}
+			m.StoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStoreResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Store == nil {
+				m.Store = &metapb.Store{}
+			}
+			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutStoreRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutStoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Store == nil {
+				m.Store = &metapb.Store{}
+			}
+			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutStoreResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutStoreResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutStoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRegionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRegionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RegionKey = append(m.RegionKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.RegionKey == nil {
+				m.RegionKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRegionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DownPeers = append(m.DownPeers, &PeerStats{})
+			if err := m.DownPeers[len(m.DownPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingPeers = append(m.PendingPeers, &metapb.Peer{})
+			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRegionByIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRegionByIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRegionByIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetClusterConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetClusterConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cluster == nil {
+				m.Cluster = &metapb.Cluster{}
+			}
+			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutClusterConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cluster == nil {
+				m.Cluster = &metapb.Cluster{}
+			}
+			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutClusterConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberId", wireType)
+			}
+			m.MemberId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerUrls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerUrls = append(m.PeerUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientUrls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DcLocation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DcLocation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetMembersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetMembersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetMembersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetMembersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leader == nil {
+				m.Leader = &Member{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalTsoLeaders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalTsoLeaders = append(m.LocalTsoLeaders, &Member{})
+			if err := m.LocalTsoLeaders[len(m.LocalTsoLeaders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownSeconds", wireType)
+			}
+			m.DownSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DownSeconds |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegionHeartbeatRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegionHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DownPeers = append(m.DownPeers, &PeerStats{})
+			if err := m.DownPeers[len(m.DownPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingPeers = append(m.PendingPeers, &metapb.Peer{})
+			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesWritten", wireType)
+			}
+			m.BytesWritten = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesWritten |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesRead", wireType)
+			}
+			m.BytesRead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesRead |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeysWritten", wireType)
+			}
+			m.KeysWritten = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeysWritten |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeysRead", wireType)
+			}
+			m.KeysRead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeysRead |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateSize", wireType)
+			}
+			m.ApproximateSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApproximateSize |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateKeys", wireType)
+			}
+			m.ApproximateKeys = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApproximateKeys |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangePeer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangeType |= (ConfChangeType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangePeerV2) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangePeerV2: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangePeerV2: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &ChangePeer{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
@@ -4003,21 +10996,120 @@ func (m *RequestHeader) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestHeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestHeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeer{}
+			}
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeader{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.ClusterId = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4027,66 +11119,82 @@ func (m *RequestHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClusterId |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseHeader) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
+			if m.RegionEpoch == nil {
+				m.RegionEpoch = &metapb.RegionEpoch{}
 			}
-			if iNdEx >= l {
+			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.TargetPeer == nil {
+				m.TargetPeer = &metapb.Peer{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseHeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatIntervalSecs", wireType)
 			}
-			m.ClusterId = 0
+			m.HeartbeatIntervalSecs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4096,14 +11204,14 @@ func (m *ResponseHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClusterId |= (uint64(b) & 0x7F) << shift
+				m.HeartbeatIntervalSecs |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4127,68 +11235,18 @@ func (m *ResponseHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &Error{}
+			if m.Merge == nil {
+				m.Merge = &Merge{}
 			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Error) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitRegion", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4198,16 +11256,30 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (ErrorType(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SplitRegion == nil {
+				m.SplitRegion = &SplitRegion{}
+			}
+			if err := m.SplitRegion.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeerV2", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4217,20 +11289,24 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			if m.ChangePeerV2 == nil {
+				m.ChangePeerV2 = &ChangePeerV2{}
+			}
+			if err := m.ChangePeerV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4253,7 +11329,7 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TsoRequest) Unmarshal(dAtA []byte) error {
+func (m *Merge) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4276,50 +11352,17 @@ func (m *TsoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TsoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TsoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			m.Count = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4329,11 +11372,25 @@ func (m *TsoRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= (uint32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Target == nil {
+				m.Target = &metapb.Region{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -4355,7 +11412,7 @@ func (m *TsoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Timestamp) Unmarshal(dAtA []byte) error {
+func (m *SplitRegion) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4378,17 +11435,17 @@ func (m *Timestamp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Timestamp: wiretype end group for non-group")
+			return fmt.Errorf("proto: SplitRegion: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Timestamp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SplitRegion: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Physical", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
-			m.Physical = 0
+			m.Policy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4398,16 +11455,16 @@ func (m *Timestamp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Physical |= (int64(b) & 0x7F) << shift
+				m.Policy |= (CheckPolicy(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logical", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			m.Logical = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4417,11 +11474,21 @@ func (m *Timestamp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Logical |= (int64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -4443,7 +11510,7 @@ func (m *Timestamp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TsoResponse) Unmarshal(dAtA []byte) error {
+func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4466,10 +11533,10 @@ func (m *TsoResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TsoResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TsoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4499,34 +11566,15 @@ func (m *TsoResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
-			}
-			m.Count = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Count |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4550,10 +11598,10 @@ func (m *TsoResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Timestamp == nil {
-				m.Timestamp = &Timestamp{}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
 			}
-			if err := m.Timestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4578,7 +11626,7 @@ func (m *TsoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4601,10 +11649,10 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4634,17 +11682,17 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
 			}
-			var msglen int
+			m.NewRegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4654,58 +11702,73 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.NewRegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Store == nil {
-				m.Store = &metapb.Store{}
-			}
-			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				if packedLen < 0 {
+					return ErrInvalidLengthPdpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPdpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIds = append(m.NewPeerIds, v)
 				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPeerIds = append(m.NewPeerIds, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -4727,7 +11790,7 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
+func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4750,15 +11813,81 @@ func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Left == nil {
+				m.Left = &metapb.Region{}
+			}
+			if err := m.Left.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4782,10 +11911,10 @@ func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+			if m.Right == nil {
+				m.Right = &metapb.Region{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Right.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4810,7 +11939,7 @@ func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IsBootstrappedRequest) Unmarshal(dAtA []byte) error {
+func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4833,10 +11962,10 @@ func (m *IsBootstrappedRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IsBootstrappedRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IsBootstrappedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4866,7 +11995,7 @@ func (m *IsBootstrappedRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -4893,7 +12022,7 @@ func (m *IsBootstrappedRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
+func (m *StoreStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4916,17 +12045,246 @@ func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IsBootstrappedResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IsBootstrappedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+			}
+			m.StoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			m.Capacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capacity |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			m.Available = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Available |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionCount", wireType)
+			}
+			m.RegionCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendingSnapCount", wireType)
+			}
+			m.SendingSnapCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SendingSnapCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivingSnapCount", wireType)
+			}
+			m.ReceivingSnapCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReceivingSnapCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			m.StartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTime |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyingSnapCount", wireType)
+			}
+			m.ApplyingSnapCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyingSnapCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsBusy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsBusy = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedSize", wireType)
+			}
+			m.UsedSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsedSize |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesWritten", wireType)
+			}
+			m.BytesWritten = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesWritten |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeysWritten", wireType)
+			}
+			m.KeysWritten = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeysWritten |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotBytes", wireType)
 			}
-			var msglen int
+			m.SnapshotBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4936,30 +12294,16 @@ func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.SnapshotBytes |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 14:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bootstrapped", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotDurationMs", wireType)
 			}
-			var v int
+			m.SnapshotDurationMs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -4969,12 +12313,11 @@ func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.SnapshotDurationMs |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Bootstrapped = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -4996,7 +12339,7 @@ func (m *IsBootstrappedResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AllocIDRequest) Unmarshal(dAtA []byte) error {
+func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5019,10 +12362,10 @@ func (m *AllocIDRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5058,59 +12401,9 @@ func (m *AllocIDRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AllocIDResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5134,32 +12427,13 @@ func (m *AllocIDResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+			if m.Stats == nil {
+				m.Stats = &StoreStats{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			m.Id = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Id |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -5181,7 +12455,7 @@ func (m *AllocIDResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
+func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5204,10 +12478,10 @@ func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStoreRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5237,7 +12511,7 @@ func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -5245,9 +12519,9 @@ func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatIntervalSecs", wireType)
 			}
-			m.StoreId = 0
+			m.HeartbeatIntervalSecs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -5257,7 +12531,7 @@ func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreId |= (uint64(b) & 0x7F) << shift
+				m.HeartbeatIntervalSecs |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5283,7 +12557,7 @@ func (m *GetStoreRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
+func (m *GetAllStoresRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5306,10 +12580,10 @@ func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStoreResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAllStoresRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAllStoresRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5339,17 +12613,17 @@ func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeTombstoneStores", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -5359,25 +12633,12 @@ func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Store == nil {
-				m.Store = &metapb.Store{}
-			}
-			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.ExcludeTombstoneStores = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -5399,7 +12660,7 @@ func (m *GetStoreResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
+func (m *GetAllStoresResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5422,10 +12683,10 @@ func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutStoreRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAllStoresResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutStoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAllStoresResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5455,7 +12716,7 @@ func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -5463,7 +12724,7 @@ func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5487,10 +12748,8 @@ func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Store == nil {
-				m.Store = &metapb.Store{}
-			}
-			if err := m.Store.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Stores = append(m.Stores, &metapb.Store{})
+			if err := m.Stores[len(m.Stores)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5515,7 +12774,7 @@ func (m *PutStoreRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutStoreResponse) Unmarshal(dAtA []byte) error {
+func (m *ScanRegionsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5538,10 +12797,10 @@ func (m *PutStoreResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutStoreResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScanRegionsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutStoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScanRegionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5571,67 +12830,17 @@ func (m *PutStoreResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetRegionRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetRegionRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -5641,28 +12850,45 @@ func (m *GetRegionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5686,9 +12912,9 @@ func (m *GetRegionRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RegionKey = append(m.RegionKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.RegionKey == nil {
-				m.RegionKey = []byte{}
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5712,7 +12938,7 @@ func (m *GetRegionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
+func (m *ScanRegionsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5735,10 +12961,10 @@ func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRegionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScanRegionsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScanRegionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5776,7 +13002,7 @@ func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionMetas", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5800,16 +13026,14 @@ func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.RegionMetas = append(m.RegionMetas, &metapb.Region{})
+			if err := m.RegionMetas[len(m.RegionMetas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5833,10 +13057,8 @@ func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Leaders = append(m.Leaders, &metapb.Peer{})
+			if err := m.Leaders[len(m.Leaders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5861,7 +13083,7 @@ func (m *GetRegionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRegionByIDRequest) Unmarshal(dAtA []byte) error {
+func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5884,10 +13106,10 @@ func (m *GetRegionByIDRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRegionByIDRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterRegionRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRegionByIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5942,59 +13164,42 @@ func (m *GetRegionByIDRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetClusterConfigRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6018,10 +13223,10 @@ func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6046,7 +13251,7 @@ func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6069,10 +13274,10 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetClusterConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterRegionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6108,39 +13313,6 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Cluster == nil {
-				m.Cluster = &metapb.Cluster{}
-			}
-			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -6162,7 +13334,7 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6185,10 +13357,10 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutClusterConfigRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6225,10 +13397,10 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			var msglen int
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6238,25 +13410,11 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Cluster == nil {
-				m.Cluster = &metapb.Cluster{}
-			}
-			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -6278,7 +13436,7 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6301,10 +13459,10 @@ func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutClusterConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6340,61 +13498,11 @@ func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Member) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Member: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			var stringLen uint64
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6404,26 +13512,16 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.MemberId = 0
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6433,14 +13531,14 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MemberId |= (uint64(b) & 0x7F) << shift
+				m.Status |= (OperatorStatus(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerUrls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6465,13 +13563,42 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerUrls = append(m.PeerUrls, string(dAtA[iNdEx:postIndex]))
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientUrls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Steps", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Steps = append(m.Steps, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedSteps", wireType)
 			}
-			var stringLen uint64
+			m.FinishedSteps = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6481,21 +13608,11 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.FinishedSteps |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -6517,7 +13634,7 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6540,10 +13657,10 @@ func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMembersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMembersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6600,7 +13717,7 @@ func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6623,10 +13740,10 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMembersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMembersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6663,10 +13780,10 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
 			}
-			var msglen int
+			m.SafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6676,26 +13793,64 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6719,13 +13874,32 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Leader == nil {
-				m.Leader = &Member{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+			}
+			m.SafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -6747,7 +13921,7 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeerStats) Unmarshal(dAtA []byte) error {
+func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6770,15 +13944,15 @@ func (m *PeerStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeerStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeerStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6802,18 +13976,18 @@ func (m *PeerStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Peer == nil {
-				m.Peer = &metapb.Peer{}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownSeconds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewSafePoint", wireType)
 			}
-			m.DownSeconds = 0
+			m.NewSafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6823,7 +13997,7 @@ func (m *PeerStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DownSeconds |= (uint64(b) & 0x7F) << shift
+				m.NewSafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6849,7 +14023,7 @@ func (m *PeerStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateServiceGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6872,10 +14046,10 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionHeartbeatRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateServiceGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateServiceGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6913,9 +14087,9 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6925,30 +14099,28 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ServiceId = append(m.ServiceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.ServiceId == nil {
+				m.ServiceId = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
 			}
-			var msglen int
+			m.Ttl = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6958,30 +14130,16 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Ttl |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownPeers", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
 			}
-			var msglen int
+			m.SafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -6991,26 +14149,64 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPdpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DownPeers = append(m.DownPeers, &PeerStats{})
-			if err := m.DownPeers[len(m.DownPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateServiceGCSafePointResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
 			}
-			iNdEx = postIndex
-		case 5:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateServiceGCSafePointResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateServiceGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7034,16 +14230,18 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PendingPeers = append(m.PendingPeers, &metapb.Peer{})
-			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BytesWritten", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
 			}
-			m.BytesWritten = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7053,35 +14251,28 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BytesWritten |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BytesRead", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthPdpb
 			}
-			m.BytesRead = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BytesRead |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		case 8:
+			m.ServiceId = append(m.ServiceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.ServiceId == nil {
+				m.ServiceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeysWritten", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
 			}
-			m.KeysWritten = 0
+			m.Ttl = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7091,16 +14282,16 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.KeysWritten |= (uint64(b) & 0x7F) << shift
+				m.Ttl |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeysRead", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSafePoint", wireType)
 			}
-			m.KeysRead = 0
+			m.MinSafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7110,7 +14301,7 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.KeysRead |= (uint64(b) & 0x7F) << shift
+				m.MinSafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7136,7 +14327,7 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangePeer) Unmarshal(dAtA []byte) error {
+func (m *AskBatchSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7159,15 +14350,15 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskBatchSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskBatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7191,18 +14382,51 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Peer == nil {
-				m.Peer = &metapb.Peer{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitCount", wireType)
 			}
-			m.ChangeType = 0
+			m.SplitCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7212,7 +14436,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= (ConfChangeType(b) & 0x7F) << shift
+				m.SplitCount |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7238,7 +14462,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+func (m *SplitID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7261,17 +14485,17 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
 			}
-			var msglen int
+			m.NewRegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7281,25 +14505,73 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.NewRegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Peer == nil {
-				m.Peer = &metapb.Peer{}
-			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 2:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPdpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPdpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIds = append(m.NewPeerIds, v)
+				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPdpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPeerIds = append(m.NewPeerIds, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -7321,7 +14593,7 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *AskBatchSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7344,10 +14616,10 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskBatchSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskBatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7383,127 +14655,9 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeer{}
-			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeader{}
-			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
-			}
-			m.RegionId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.RegionEpoch == nil {
-				m.RegionEpoch = &metapb.RegionEpoch{}
-			}
-			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ids", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7527,10 +14681,8 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TargetPeer == nil {
-				m.TargetPeer = &metapb.Peer{}
-			}
-			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Ids = append(m.Ids, &SplitID{})
+			if err := m.Ids[len(m.Ids)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7555,7 +14707,7 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *ReportBatchSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7578,10 +14730,10 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportBatchSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportBatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7619,7 +14771,7 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7643,10 +14795,8 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Regions = append(m.Regions, &metapb.Region{})
+			if err := m.Regions[len(m.Regions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7671,7 +14821,7 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
+func (m *ReportBatchSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7694,10 +14844,10 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportBatchSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportBatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7733,87 +14883,6 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
-			}
-			m.NewRegionId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NewRegionId |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPdpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPdpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPdpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.NewPeerIds = append(m.NewPeerIds, v)
-				}
-			} else if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPdpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.NewPeerIds = append(m.NewPeerIds, v)
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -7835,7 +14904,7 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *SyncRegionRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7858,10 +14927,10 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SyncRegionRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SyncRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7899,7 +14968,7 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7923,18 +14992,18 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Left == nil {
-				m.Left = &metapb.Region{}
+			if m.Member == nil {
+				m.Member = &Member{}
 			}
-			if err := m.Left.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartIndex", wireType)
 			}
-			var msglen int
+			m.StartIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -7944,25 +15013,11 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.StartIndex |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Right == nil {
-				m.Right = &metapb.Region{}
-			}
-			if err := m.Right.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -7984,7 +15039,7 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
+func (m *SyncRegionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8007,10 +15062,10 @@ func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SyncRegionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SyncRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8046,61 +15101,11 @@ func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPdpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StoreStats) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPdpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StoreStats: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreStats: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
 			}
-			m.StoreId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8110,16 +15115,28 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreId |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
 			}
-			m.Capacity = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regions = append(m.Regions, &metapb.Region{})
+			if err := m.Regions[len(m.Regions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionLeaders", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8129,16 +15146,28 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Capacity |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RegionLeaders = append(m.RegionLeaders, &metapb.Peer{})
+			if err := m.RegionLeaders[len(m.RegionLeaders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextIndex", wireType)
 			}
-			m.Available = 0
+			m.NextIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8148,16 +15177,66 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Available |= (uint64(b) & 0x7F) << shift
+				m.NextIndex |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplicationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplicas", wireType)
 			}
-			m.RegionCount = 0
+			m.MaxReplicas = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8167,16 +15246,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionCount |= (uint32(b) & 0x7F) << shift
+				m.MaxReplicas |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SendingSnapCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UnderReplicatedRegions", wireType)
 			}
-			m.SendingSnapCount = 0
+			m.UnderReplicatedRegions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8186,16 +15265,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SendingSnapCount |= (uint32(b) & 0x7F) << shift
+				m.UnderReplicatedRegions |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReceivingSnapCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OverReplicatedRegions", wireType)
 			}
-			m.ReceivingSnapCount = 0
+			m.OverReplicatedRegions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8205,16 +15284,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReceivingSnapCount |= (uint32(b) & 0x7F) << shift
+				m.OverReplicatedRegions |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DownPeerRegions", wireType)
 			}
-			m.StartTime = 0
+			m.DownPeerRegions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8224,16 +15303,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTime |= (uint32(b) & 0x7F) << shift
+				m.DownPeerRegions |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplyingSnapCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeerRegions", wireType)
 			}
-			m.ApplyingSnapCount = 0
+			m.PendingPeerRegions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8243,55 +15322,66 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ApplyingSnapCount |= (uint32(b) & 0x7F) << shift
+				m.PendingPeerRegions |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsBusy", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthPdpb
 			}
-			m.IsBusy = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UsedSize", wireType)
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.UsedSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UsedSize |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreStateCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPdpb
 			}
-		case 11:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreStateCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreStateCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BytesWritten", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			m.BytesWritten = 0
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8301,16 +15391,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BytesWritten |= (uint64(b) & 0x7F) << shift
+				m.State |= (StoreState(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 12:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeysWritten", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			m.KeysWritten = 0
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPdpb
@@ -8320,7 +15410,7 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.KeysWritten |= (uint64(b) & 0x7F) << shift
+				m.Count |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -8346,7 +15436,7 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+func (m *GetClusterStatusRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8369,10 +15459,10 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterStatusRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8408,39 +15498,6 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPdpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPdpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Stats == nil {
-				m.Stats = &StoreStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -8462,7 +15519,7 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *GetClusterStatusResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8485,10 +15542,10 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterStatusResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8524,6 +15581,109 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RaftBootstrapTime", wireType)
+			}
+			m.RaftBootstrapTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RaftBootstrapTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsInitialized", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsInitialized = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReplicationStatus == nil {
+				m.ReplicationStatus = &ReplicationStatus{}
+			}
+			if err := m.ReplicationStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreCounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StoreCounts = append(m.StoreCounts, &StoreStateCount{})
+			if err := m.StoreCounts[len(m.StoreCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPdpb(dAtA[iNdEx:])
@@ -8653,113 +15813,202 @@ var (
 func init() { proto.RegisterFile("pdpb.proto", fileDescriptorPdpb) }
 
 var fileDescriptorPdpb = []byte{
-	// 1725 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
-	0x11, 0xd6, 0xf0, 0x9f, 0xc5, 0x5f, 0xb7, 0x65, 0x69, 0x96, 0xb6, 0x14, 0x6d, 0x7b, 0x11, 0x38,
-	0xce, 0x9a, 0xf1, 0x2a, 0x48, 0x10, 0x60, 0xb1, 0xc1, 0x52, 0x3f, 0x5e, 0x0b, 0x5e, 0x8b, 0x42,
-	0x93, 0x8b, 0xc5, 0x5e, 0xc2, 0x0c, 0x39, 0x6d, 0x6a, 0x22, 0x72, 0x66, 0x76, 0xba, 0x29, 0x81,
-	0x7b, 0xca, 0x29, 0x97, 0x04, 0x48, 0x4e, 0x41, 0x5e, 0x23, 0x0f, 0x90, 0x7b, 0x8e, 0x79, 0x84,
-	0xc0, 0x79, 0x8b, 0x9c, 0x82, 0xfe, 0x99, 0xe1, 0xcc, 0x90, 0x72, 0x94, 0x71, 0xf6, 0xc4, 0xe9,
-	0xaa, 0xea, 0xaf, 0xab, 0xbe, 0xae, 0xea, 0xee, 0x22, 0x80, 0x6f, 0xfb, 0xe3, 0xae, 0x1f, 0x78,
-	0xdc, 0x43, 0x05, 0xf1, 0xdd, 0xa9, 0xcf, 0x29, 0xb7, 0x42, 0x59, 0x67, 0x7b, 0xea, 0x4d, 0x3d,
-	0xf9, 0xf9, 0x13, 0xf1, 0xa5, 0xa4, 0xb8, 0x0b, 0x0d, 0x42, 0xbf, 0x5d, 0x50, 0xc6, 0x5f, 0x52,
-	0xcb, 0xa6, 0x01, 0xda, 0x03, 0x98, 0xcc, 0x16, 0x8c, 0xd3, 0x60, 0xe4, 0xd8, 0xa6, 0x71, 0x60,
-	0x3c, 0x29, 0x90, 0xaa, 0x96, 0x9c, 0xd9, 0x98, 0x40, 0x93, 0x50, 0xe6, 0x7b, 0x2e, 0xa3, 0x77,
-	0x9a, 0x80, 0x3e, 0x84, 0x22, 0x0d, 0x02, 0x2f, 0x30, 0x73, 0x07, 0xc6, 0x93, 0xda, 0x61, 0xad,
-	0x2b, 0xdd, 0x3c, 0x15, 0x22, 0xa2, 0x34, 0xf8, 0x05, 0x14, 0xe5, 0x18, 0x3d, 0x86, 0x02, 0x5f,
-	0xfa, 0x54, 0x82, 0x34, 0x0f, 0x5b, 0x31, 0xd3, 0xe1, 0xd2, 0xa7, 0x44, 0x2a, 0x91, 0x09, 0xe5,
-	0x39, 0x65, 0xcc, 0x9a, 0x52, 0x09, 0x59, 0x25, 0xe1, 0x10, 0xf7, 0x01, 0x86, 0xcc, 0xd3, 0xe1,
-	0xa0, 0x1f, 0x43, 0xe9, 0x52, 0x7a, 0x28, 0xe1, 0x6a, 0x87, 0xf7, 0x15, 0x5c, 0x22, 0x5a, 0xa2,
-	0x4d, 0xd0, 0x36, 0x14, 0x27, 0xde, 0xc2, 0xe5, 0x12, 0xb2, 0x41, 0xd4, 0x00, 0xf7, 0xa0, 0x3a,
-	0x74, 0xe6, 0x94, 0x71, 0x6b, 0xee, 0xa3, 0x0e, 0x54, 0xfc, 0xcb, 0x25, 0x73, 0x26, 0xd6, 0x4c,
-	0x22, 0xe6, 0x49, 0x34, 0x16, 0x3e, 0xcd, 0xbc, 0xa9, 0x54, 0xe5, 0xa4, 0x2a, 0x1c, 0xe2, 0xdf,
-	0x1a, 0x50, 0x93, 0x4e, 0x29, 0xce, 0xd0, 0xc7, 0x29, 0xaf, 0xb6, 0x43, 0xaf, 0xe2, 0x9c, 0xbe,
-	0xdb, 0x2d, 0xf4, 0x0c, 0xaa, 0x3c, 0x74, 0xcb, 0xcc, 0x4b, 0x18, 0xcd, 0x55, 0xe4, 0x2d, 0x59,
-	0x59, 0xe0, 0x3f, 0x18, 0xd0, 0x3e, 0xf2, 0x3c, 0xce, 0x78, 0x60, 0xf9, 0x99, 0xd8, 0x79, 0x0c,
-	0x45, 0xc6, 0xbd, 0x80, 0xea, 0x3d, 0x6c, 0x74, 0x75, 0x62, 0x0d, 0x84, 0x90, 0x28, 0x1d, 0xfa,
-	0x21, 0x94, 0x02, 0x3a, 0x75, 0x3c, 0x57, 0xbb, 0xd4, 0x0c, 0xad, 0x88, 0x94, 0x12, 0xad, 0xc5,
-	0x3d, 0xb8, 0x17, 0xf3, 0x26, 0x0b, 0x2d, 0xf8, 0x04, 0x1e, 0x9c, 0xb1, 0x08, 0xc4, 0xa7, 0x76,
-	0x96, 0xa8, 0xf0, 0x6f, 0x60, 0x27, 0x8d, 0x92, 0x69, 0x93, 0x30, 0xd4, 0xc7, 0x31, 0x14, 0x49,
-	0x52, 0x85, 0x24, 0x64, 0xf8, 0x33, 0x68, 0xf6, 0x66, 0x33, 0x6f, 0x72, 0x76, 0x92, 0xc9, 0xd5,
-	0x3e, 0xb4, 0xa2, 0xe9, 0x99, 0x7c, 0x6c, 0x42, 0xce, 0x51, 0x9e, 0x15, 0x48, 0xce, 0xb1, 0xf1,
-	0x37, 0xd0, 0xfa, 0x82, 0x72, 0xb5, 0x7f, 0x59, 0x32, 0xe2, 0x03, 0xa8, 0xc8, 0x5d, 0x1f, 0x45,
-	0xa8, 0x65, 0x39, 0x3e, 0xb3, 0x31, 0x85, 0xf6, 0x0a, 0x3a, 0x93, 0xb3, 0x77, 0x49, 0x37, 0x3c,
-	0x81, 0xd6, 0xc5, 0xe2, 0x3d, 0x22, 0xb8, 0xd3, 0x22, 0x9f, 0x43, 0x7b, 0xb5, 0x48, 0xa6, 0x54,
-	0xfd, 0x95, 0x64, 0x43, 0x97, 0x40, 0x16, 0x3f, 0xf7, 0x00, 0x54, 0xe1, 0x8c, 0xae, 0xe8, 0x52,
-	0x3a, 0x5b, 0x27, 0x55, 0x25, 0x79, 0x45, 0x97, 0xf8, 0x8f, 0x06, 0xdc, 0x8b, 0x2d, 0x90, 0x89,
-	0xef, 0x55, 0xe5, 0xe6, 0xde, 0x55, 0xb9, 0xe8, 0x23, 0x28, 0xcd, 0x14, 0xaa, 0xaa, 0xf0, 0x7a,
-	0x68, 0x77, 0x41, 0x05, 0x9a, 0xd2, 0xe1, 0x5f, 0xc3, 0x76, 0xe4, 0xd0, 0xd1, 0x32, 0x5b, 0xc2,
-	0xa3, 0x87, 0xa0, 0x63, 0x5c, 0x25, 0x58, 0x45, 0x09, 0xce, 0x6c, 0xfc, 0x02, 0x76, 0xbf, 0xa0,
-	0xfc, 0x58, 0x5d, 0x31, 0xc7, 0x9e, 0xfb, 0xc6, 0x99, 0x66, 0xaa, 0x2a, 0x06, 0xe6, 0x3a, 0x4e,
-	0x26, 0x06, 0x7f, 0x04, 0x65, 0x7d, 0xe3, 0x69, 0x0a, 0x5b, 0x21, 0x35, 0x1a, 0x9d, 0x84, 0x7a,
-	0xfc, 0x2d, 0xec, 0x5e, 0x2c, 0xde, 0xdf, 0xf9, 0xff, 0x65, 0xc9, 0x97, 0x60, 0xae, 0x2f, 0x99,
-	0x29, 0x9b, 0x6f, 0xa0, 0xf4, 0x9a, 0xce, 0xc7, 0x34, 0x40, 0x08, 0x0a, 0xae, 0x35, 0x57, 0x57,
-	0x75, 0x95, 0xc8, 0x6f, 0xb1, 0x69, 0x73, 0xa9, 0x8d, 0x6d, 0x9a, 0x12, 0x9c, 0xd9, 0x42, 0xe9,
-	0x53, 0x1a, 0x8c, 0x16, 0xc1, 0x8c, 0x99, 0xf9, 0x83, 0xfc, 0x93, 0x2a, 0xa9, 0x08, 0xc1, 0x57,
-	0xc1, 0x8c, 0xa1, 0x1f, 0x40, 0x6d, 0x32, 0x73, 0xa8, 0xcb, 0x95, 0xba, 0x20, 0xd5, 0xa0, 0x44,
-	0xc2, 0x00, 0x7f, 0x2e, 0xb3, 0x5c, 0xad, 0xcd, 0x32, 0x6d, 0xf6, 0x9f, 0x0c, 0x40, 0x71, 0x88,
-	0x8c, 0x95, 0x52, 0x56, 0x01, 0x31, 0x33, 0x77, 0x90, 0x97, 0x25, 0x20, 0xcd, 0x15, 0x2a, 0x09,
-	0x95, 0x1b, 0x2a, 0x25, 0x6e, 0x16, 0x56, 0xca, 0x05, 0x54, 0x45, 0xe5, 0x0c, 0xb8, 0xc5, 0x19,
-	0x3a, 0x80, 0x82, 0xa0, 0x43, 0xbb, 0x91, 0x2c, 0x2d, 0xa9, 0x41, 0x1f, 0x42, 0xdd, 0xf6, 0x6e,
-	0xdc, 0x11, 0xa3, 0x13, 0xcf, 0xb5, 0x99, 0x66, 0xb8, 0x26, 0x64, 0x03, 0x25, 0xc2, 0xff, 0xce,
-	0xc1, 0x8e, 0xaa, 0xbc, 0x97, 0xd4, 0x0a, 0xf8, 0x98, 0x5a, 0x3c, 0x53, 0x72, 0xfd, 0x5f, 0x4f,
-	0x04, 0xd4, 0x05, 0x90, 0x8e, 0x8b, 0x28, 0xd4, 0xe6, 0x46, 0x0f, 0x96, 0x28, 0x7e, 0x52, 0x15,
-	0x26, 0x62, 0xc8, 0xd0, 0x27, 0xd0, 0xf0, 0xa9, 0x6b, 0x3b, 0xee, 0x54, 0x4f, 0x29, 0x6a, 0xae,
-	0xe3, 0xe0, 0x75, 0x6d, 0xa2, 0xa6, 0x3c, 0x86, 0xc6, 0x78, 0xc9, 0x29, 0x1b, 0xdd, 0x04, 0x0e,
-	0xe7, 0xd4, 0x35, 0x4b, 0x92, 0x9c, 0xba, 0x14, 0x7e, 0xad, 0x64, 0xe2, 0x28, 0x55, 0x46, 0x01,
-	0xb5, 0x6c, 0xb3, 0xac, 0x5e, 0xaa, 0x52, 0x42, 0xa8, 0x25, 0x5e, 0xaa, 0xf5, 0x2b, 0xba, 0x5c,
-	0x41, 0x54, 0x14, 0xbf, 0x42, 0x16, 0x22, 0x3c, 0x84, 0xaa, 0x34, 0x91, 0x00, 0x55, 0x95, 0xe1,
-	0x42, 0x20, 0xe6, 0x63, 0x0a, 0x70, 0x7c, 0x69, 0xb9, 0x53, 0x2a, 0x5c, 0xba, 0xc3, 0x7e, 0xfe,
-	0x0c, 0x6a, 0x13, 0x69, 0x3f, 0x92, 0x8f, 0xde, 0x9c, 0x7c, 0xf4, 0xea, 0xfc, 0x13, 0x55, 0xaa,
-	0xc0, 0xe4, 0xcb, 0x17, 0x26, 0xd1, 0x37, 0x3e, 0x84, 0xe6, 0x30, 0xb0, 0x5c, 0xf6, 0x86, 0x06,
-	0x5f, 0x2a, 0x7e, 0xff, 0xeb, 0x52, 0xf8, 0x6f, 0x39, 0xd8, 0x5d, 0xcb, 0x8b, 0x4c, 0x15, 0xf0,
-	0x49, 0xe4, 0xb4, 0x5c, 0x52, 0xa5, 0x47, 0x5b, 0x3b, 0x1d, 0x45, 0x1f, 0x3a, 0x2c, 0x99, 0xf8,
-	0x0c, 0x5a, 0x5c, 0x3b, 0x3c, 0x4a, 0x64, 0x8b, 0x5e, 0x29, 0x19, 0x0d, 0x69, 0xf2, 0x64, 0x74,
-	0x89, 0xab, 0xa0, 0x90, 0xbc, 0x0a, 0xd0, 0xcf, 0xa1, 0xae, 0x95, 0xd4, 0xf7, 0x26, 0x97, 0x66,
-	0x51, 0xe7, 0x76, 0x22, 0x5d, 0x4f, 0x85, 0x8a, 0xd4, 0x82, 0xd5, 0x00, 0x3d, 0x83, 0x1a, 0xb7,
-	0x82, 0x29, 0xe5, 0x2a, 0x8c, 0xd2, 0x06, 0xe6, 0x40, 0x19, 0x88, 0x6f, 0xfc, 0x06, 0x5a, 0x3d,
-	0x76, 0x35, 0xf0, 0x67, 0xce, 0xf7, 0x5a, 0x4f, 0xf8, 0x77, 0x06, 0xb4, 0x57, 0x0b, 0x65, 0x7c,
-	0x8d, 0x36, 0x5c, 0x7a, 0x33, 0x4a, 0xdf, 0x9e, 0x35, 0x97, 0xde, 0x90, 0x90, 0xb5, 0x03, 0xa8,
-	0x0b, 0x1b, 0x79, 0x1e, 0x3b, 0xb6, 0x3a, 0x8e, 0x0b, 0x04, 0x5c, 0x7a, 0x23, 0xa2, 0x3d, 0xb3,
-	0x19, 0xfe, 0xbd, 0x01, 0x88, 0x50, 0xdf, 0x0b, 0x78, 0xf6, 0xa0, 0x31, 0x14, 0x66, 0xf4, 0x0d,
-	0xbf, 0x25, 0x64, 0xa9, 0x43, 0x1f, 0x41, 0x31, 0x70, 0xa6, 0x97, 0xfc, 0x96, 0x9e, 0x41, 0x29,
-	0xf1, 0x31, 0xdc, 0x4f, 0x38, 0x93, 0xe9, 0xee, 0xfa, 0x6b, 0x1e, 0x40, 0xbe, 0xe4, 0xd4, 0x79,
-	0x1b, 0x7f, 0xc1, 0x1a, 0x89, 0x17, 0xac, 0xe8, 0xf4, 0x26, 0x96, 0x6f, 0x4d, 0x1c, 0xbe, 0x0c,
-	0xaf, 0xb1, 0x70, 0x8c, 0x1e, 0x41, 0xd5, 0xba, 0xb6, 0x9c, 0x99, 0x35, 0x9e, 0x51, 0xe9, 0x74,
-	0x81, 0xac, 0x04, 0xe2, 0x08, 0xd1, 0xc4, 0xab, 0xb6, 0xad, 0x20, 0xdb, 0x36, 0x9d, 0x79, 0xc7,
-	0xb2, 0x79, 0xfb, 0x18, 0x10, 0xd3, 0x87, 0x1b, 0x73, 0x2d, 0x5f, 0x1b, 0x16, 0xa5, 0x61, 0x5b,
-	0x6b, 0x06, 0xae, 0xe5, 0x2b, 0xeb, 0xe7, 0xb0, 0x1d, 0xd0, 0x09, 0x75, 0xae, 0x53, 0xf6, 0x25,
-	0x69, 0x8f, 0x22, 0xdd, 0x6a, 0xc6, 0x1e, 0x00, 0xe3, 0x56, 0xc0, 0x47, 0xa2, 0x01, 0x94, 0x87,
-	0x5c, 0x83, 0x54, 0xa5, 0x44, 0x34, 0x87, 0xa8, 0x0b, 0xf7, 0x2d, 0xdf, 0x9f, 0x2d, 0x53, 0x78,
-	0x15, 0x69, 0x77, 0x2f, 0x54, 0xad, 0xe0, 0x76, 0xa1, 0xec, 0xb0, 0xd1, 0x78, 0xc1, 0x96, 0xf2,
-	0xbc, 0xab, 0x90, 0x92, 0xc3, 0x8e, 0x16, 0x6c, 0x29, 0xca, 0x72, 0xc1, 0xa8, 0x3d, 0x62, 0xce,
-	0x77, 0xd4, 0x04, 0xc5, 0x92, 0x10, 0x0c, 0x9c, 0xef, 0xe8, 0xfa, 0x71, 0x5c, 0xdb, 0x70, 0x1c,
-	0xa7, 0xcf, 0xdb, 0xfa, 0xda, 0x79, 0x8b, 0x67, 0xf0, 0x40, 0x6e, 0xd9, 0xfb, 0xde, 0x66, 0x45,
-	0x26, 0xf6, 0x3c, 0x79, 0x5a, 0xad, 0x72, 0x81, 0x28, 0x35, 0x7e, 0x01, 0x3b, 0xe9, 0xd5, 0xb2,
-	0x64, 0xda, 0x53, 0x0a, 0xd5, 0xe8, 0x4f, 0x0b, 0x54, 0x82, 0x5c, 0xff, 0x55, 0x7b, 0x0b, 0xd5,
-	0xa0, 0xfc, 0xd5, 0xf9, 0xab, 0xf3, 0xfe, 0xd7, 0xe7, 0x6d, 0x03, 0x6d, 0x43, 0xfb, 0xbc, 0x3f,
-	0x1c, 0x1d, 0xf5, 0xfb, 0xc3, 0xc1, 0x90, 0xf4, 0x2e, 0x2e, 0x4e, 0x4f, 0xda, 0x39, 0x74, 0x1f,
-	0x5a, 0x83, 0x61, 0x9f, 0x9c, 0x8e, 0x86, 0xfd, 0xd7, 0x47, 0x83, 0x61, 0xff, 0xfc, 0xb4, 0x9d,
-	0x47, 0x26, 0x6c, 0xf7, 0xbe, 0x24, 0xa7, 0xbd, 0x93, 0x6f, 0x92, 0xe6, 0x85, 0xa7, 0xcf, 0xa0,
-	0x99, 0xbc, 0x26, 0xc4, 0x1a, 0x3d, 0xdb, 0x3e, 0xf7, 0x6c, 0xda, 0xde, 0x42, 0x4d, 0x00, 0x42,
-	0xe7, 0xde, 0x35, 0x95, 0x63, 0xe3, 0xf0, 0xcf, 0x15, 0xc8, 0x5d, 0x9c, 0xa0, 0x1e, 0xc0, 0xea,
-	0x19, 0x84, 0x76, 0x55, 0x20, 0x6b, 0x6f, 0xab, 0x8e, 0xb9, 0xae, 0x50, 0xb1, 0xe2, 0x2d, 0xf4,
-	0x1c, 0xf2, 0x43, 0xe6, 0x21, 0xcd, 0xe3, 0xea, 0x2f, 0x97, 0xce, 0xbd, 0x98, 0x24, 0xb4, 0x7e,
-	0x62, 0x3c, 0x37, 0xd0, 0x2f, 0xa1, 0x1a, 0x35, 0xda, 0x68, 0x47, 0x59, 0xa5, 0xff, 0x92, 0xe8,
-	0xec, 0xae, 0xc9, 0xa3, 0x15, 0x5f, 0x43, 0x33, 0xd9, 0xaa, 0xa3, 0x87, 0xca, 0x78, 0xe3, 0xdf,
-	0x00, 0x9d, 0x47, 0x9b, 0x95, 0x11, 0xdc, 0x2f, 0xa0, 0xac, 0xdb, 0x69, 0xa4, 0x77, 0x32, 0xd9,
-	0x9c, 0x77, 0x1e, 0xa4, 0xa4, 0xd1, 0xcc, 0x4f, 0xa1, 0x12, 0x36, 0xb7, 0xe8, 0x41, 0x44, 0x51,
-	0xbc, 0x0b, 0xed, 0xec, 0xa4, 0xc5, 0xf1, 0xc9, 0x61, 0x37, 0x19, 0x4e, 0x4e, 0xb5, 0xb0, 0xe1,
-	0xe4, 0x74, 0xd3, 0xa9, 0x28, 0x48, 0x26, 0x67, 0x48, 0xc1, 0xc6, 0x02, 0x09, 0x29, 0xd8, 0x9c,
-	0xcf, 0x78, 0x0b, 0x0d, 0xa1, 0x95, 0x7a, 0x10, 0xa0, 0x47, 0x61, 0x52, 0x6f, 0x7a, 0x3f, 0x76,
-	0xf6, 0x6e, 0xd1, 0xa6, 0xf7, 0x39, 0xea, 0xfd, 0xd0, 0x8a, 0x88, 0x44, 0xfb, 0xdb, 0xd9, 0x5d,
-	0x93, 0x47, 0x5e, 0xbd, 0x80, 0x46, 0xa2, 0x77, 0x44, 0x9d, 0x94, 0x6d, 0xac, 0xa1, 0x7c, 0x17,
-	0xce, 0xa7, 0x50, 0x09, 0xaf, 0xd1, 0x90, 0xe9, 0xd4, 0xfd, 0x1d, 0x32, 0x9d, 0xbe, 0x6d, 0xf1,
-	0x16, 0x3a, 0x81, 0x5a, 0xec, 0xb6, 0x41, 0x66, 0x18, 0x78, 0xfa, 0x36, 0xec, 0x7c, 0xb0, 0x41,
-	0x13, 0xa1, 0x0c, 0x64, 0xe3, 0x9f, 0x68, 0xba, 0xd0, 0x5e, 0xe4, 0xf1, 0xa6, 0xfe, 0xaf, 0xb3,
-	0x7f, 0x9b, 0x3a, 0x0e, 0x9a, 0xee, 0xe4, 0x42, 0xd0, 0x5b, 0x9a, 0xca, 0x10, 0xf4, 0xb6, 0x06,
-	0x10, 0x6f, 0x1d, 0x3d, 0xfd, 0xfb, 0xdb, 0x7d, 0xe3, 0x1f, 0x6f, 0xf7, 0x8d, 0x7f, 0xbe, 0xdd,
-	0x37, 0xfe, 0xf2, 0xaf, 0xfd, 0x2d, 0x30, 0x27, 0xde, 0xbc, 0xeb, 0x3b, 0xee, 0x74, 0x62, 0xf9,
-	0x5d, 0xee, 0x5c, 0x5d, 0x77, 0xaf, 0xae, 0xe5, 0xdf, 0xc5, 0xe3, 0x92, 0xfc, 0xf9, 0xe9, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x9e, 0xdd, 0x36, 0xc7, 0x6d, 0x16, 0x00, 0x00,
+	// 3144 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3a, 0x4b, 0x6f, 0x23, 0xc7,
+	0xd1, 0x3b, 0x24, 0x45, 0x91, 0xc5, 0xa7, 0x5a, 0x2f, 0x2e, 0xf7, 0xe9, 0xf1, 0xae, 0xb1, 0xd6,
+	0x67, 0xcb, 0x6b, 0xd9, 0x9f, 0xb1, 0xf8, 0x8c, 0x2f, 0x30, 0xf5, 0xd8, 0x5d, 0x66, 0x57, 0x12,
+	0x31, 0xe4, 0xfa, 0x71, 0x48, 0x26, 0xa3, 0x99, 0x96, 0x34, 0x11, 0x39, 0x33, 0x9e, 0x1e, 0x6a,
+	0x97, 0x46, 0x10, 0xe4, 0xe4, 0x04, 0x89, 0x73, 0xf7, 0x29, 0x40, 0x4e, 0xb9, 0xe5, 0x37, 0xe4,
+	0x18, 0x04, 0x08, 0xe0, 0x7f, 0x90, 0xc0, 0xf9, 0x07, 0xf9, 0x05, 0x41, 0xbf, 0xe6, 0x45, 0x52,
+	0xbb, 0x1e, 0xd9, 0x87, 0x9c, 0x38, 0x5d, 0x55, 0x5d, 0x5d, 0x5d, 0x5d, 0x55, 0x5d, 0x55, 0x4d,
+	0x00, 0xcf, 0xf2, 0x8e, 0x36, 0x3d, 0xdf, 0x0d, 0x5c, 0x54, 0xa0, 0xdf, 0xed, 0xea, 0x08, 0x07,
+	0x86, 0x84, 0xb5, 0x57, 0x4e, 0xdc, 0x13, 0x97, 0x7d, 0xbe, 0x43, 0xbf, 0x38, 0x54, 0xfd, 0x14,
+	0x6a, 0x1a, 0xfe, 0x7c, 0x8c, 0x49, 0xf0, 0x18, 0x1b, 0x16, 0xf6, 0xd1, 0x0d, 0x00, 0x73, 0x38,
+	0x26, 0x01, 0xf6, 0x75, 0xdb, 0x6a, 0x29, 0xb7, 0x95, 0x7b, 0x05, 0xad, 0x2c, 0x20, 0x5d, 0x0b,
+	0xdd, 0x83, 0xe6, 0xc8, 0x78, 0xa1, 0x93, 0xc0, 0x18, 0x62, 0x07, 0x13, 0xa2, 0x8f, 0x48, 0x2b,
+	0xc7, 0x88, 0xea, 0x23, 0xe3, 0x45, 0x5f, 0x82, 0xf7, 0x89, 0xaa, 0x41, 0x5d, 0xc3, 0xc4, 0x73,
+	0x1d, 0x82, 0x5f, 0x8d, 0xf5, 0x6b, 0xb0, 0x80, 0x7d, 0xdf, 0xf5, 0x19, 0xbf, 0xca, 0x56, 0x65,
+	0x93, 0x6d, 0x68, 0x8f, 0x82, 0x34, 0x8e, 0x51, 0x1f, 0xc2, 0x02, 0x1b, 0xa3, 0xd7, 0xa1, 0x10,
+	0x4c, 0x3c, 0xcc, 0x98, 0xd4, 0xb7, 0x1a, 0x31, 0xd2, 0xc1, 0xc4, 0xc3, 0x1a, 0x43, 0xa2, 0x16,
+	0x2c, 0x8e, 0x30, 0x21, 0xc6, 0x09, 0x66, 0x2c, 0xcb, 0x9a, 0x1c, 0xaa, 0x1e, 0xc0, 0x80, 0xb8,
+	0x62, 0xe3, 0xe8, 0x7f, 0xa0, 0x78, 0xca, 0x24, 0x64, 0xec, 0x2a, 0x5b, 0xcb, 0x9c, 0x5d, 0x42,
+	0x2f, 0x9a, 0x20, 0x41, 0x2b, 0xb0, 0x60, 0xba, 0x63, 0x27, 0x60, 0x2c, 0x6b, 0x1a, 0x1f, 0xa0,
+	0x5b, 0x50, 0xb1, 0x4c, 0x7d, 0xe8, 0x9a, 0x46, 0x60, 0xbb, 0x4e, 0x2b, 0xcf, 0x96, 0x03, 0xcb,
+	0x7c, 0x2a, 0x20, 0x6a, 0x07, 0xca, 0x03, 0x7b, 0x84, 0x49, 0x60, 0x8c, 0x3c, 0xd4, 0x86, 0x92,
+	0x77, 0x3a, 0x21, 0xb6, 0x69, 0x0c, 0xd9, 0x92, 0x79, 0x2d, 0x1c, 0x53, 0xa1, 0x87, 0xee, 0x09,
+	0x43, 0xe5, 0x18, 0x4a, 0x0e, 0xd5, 0x5f, 0x29, 0x50, 0x61, 0x52, 0x73, 0xa5, 0xa2, 0xb7, 0x52,
+	0x62, 0xaf, 0x48, 0xb1, 0xe3, 0x4a, 0x7f, 0x89, 0xdc, 0x6f, 0x43, 0x39, 0x90, 0x62, 0x31, 0xa9,
+	0x2b, 0x52, 0x99, 0xa1, 0xb4, 0x5a, 0x44, 0xa1, 0x7e, 0xa5, 0x40, 0x73, 0xdb, 0x75, 0x03, 0x12,
+	0xf8, 0x86, 0x97, 0x49, 0x7d, 0xaf, 0xc3, 0x02, 0x09, 0x5c, 0x1f, 0x8b, 0x43, 0xae, 0x6d, 0x0a,
+	0x1b, 0xed, 0x53, 0xa0, 0xc6, 0x71, 0xe8, 0x0d, 0x28, 0xfa, 0xf8, 0x44, 0x2a, 0xb2, 0xb2, 0x55,
+	0x97, 0x54, 0x1a, 0x83, 0x6a, 0x02, 0xab, 0x76, 0x60, 0x29, 0x26, 0x4d, 0x16, 0xb5, 0xa8, 0xbb,
+	0xb0, 0xda, 0x25, 0x21, 0x13, 0x0f, 0x5b, 0x59, 0x76, 0xa5, 0xfe, 0x1c, 0xd6, 0xd2, 0x5c, 0x32,
+	0x1d, 0x92, 0x0a, 0xd5, 0xa3, 0x18, 0x17, 0xa6, 0xa4, 0x92, 0x96, 0x80, 0xa9, 0x7d, 0xa8, 0x77,
+	0x86, 0x43, 0xd7, 0xec, 0xee, 0x7e, 0x7f, 0xf6, 0xab, 0x62, 0x68, 0x84, 0x4c, 0x33, 0x49, 0x5e,
+	0x87, 0x9c, 0x6d, 0x89, 0x48, 0x90, 0xb3, 0xad, 0x68, 0x99, 0x7c, 0x7c, 0x99, 0xcf, 0xa0, 0xf1,
+	0x08, 0x07, 0xfc, 0xac, 0xb3, 0x08, 0x7f, 0x15, 0x4a, 0xcc, 0x42, 0xf4, 0x70, 0xad, 0x45, 0x36,
+	0xee, 0x5a, 0x2a, 0x86, 0x66, 0xc4, 0x3a, 0xd3, 0x16, 0x5e, 0xc5, 0x34, 0x55, 0x13, 0x1a, 0xbd,
+	0xf1, 0x25, 0x76, 0xf0, 0x4a, 0x8b, 0x7c, 0x04, 0xcd, 0x68, 0x91, 0x4c, 0x66, 0xfd, 0x53, 0xa6,
+	0x0d, 0xe1, 0x2e, 0x59, 0xe4, 0xbc, 0x01, 0xc0, 0x9d, 0x4c, 0x3f, 0xc3, 0x13, 0x26, 0x6c, 0x55,
+	0x2b, 0x73, 0xc8, 0x13, 0x3c, 0x51, 0xff, 0xad, 0xc0, 0x52, 0x6c, 0x81, 0x4c, 0xfa, 0x8e, 0xbc,
+	0x3c, 0x77, 0x91, 0x97, 0xa3, 0x3b, 0x50, 0x1c, 0x72, 0xae, 0x3c, 0x1a, 0x54, 0x25, 0x5d, 0x0f,
+	0x53, 0x6e, 0x1c, 0x87, 0x36, 0x01, 0x2c, 0xf7, 0xb9, 0xa3, 0x7b, 0x18, 0xfb, 0xa4, 0x55, 0xb8,
+	0x9d, 0x8f, 0x42, 0x19, 0xa5, 0xeb, 0x07, 0x46, 0x40, 0xb4, 0x32, 0x25, 0xa1, 0x43, 0x82, 0xde,
+	0x85, 0x9a, 0x87, 0x1d, 0xcb, 0x76, 0x4e, 0xc4, 0x94, 0x85, 0xdb, 0xf9, 0x29, 0xe6, 0x55, 0x41,
+	0xc2, 0xa6, 0xa8, 0x3f, 0x83, 0x95, 0x70, 0xcf, 0xdb, 0x93, 0x8c, 0xfe, 0x77, 0x0d, 0x84, 0x1a,
+	0x23, 0x1b, 0x2e, 0x71, 0x40, 0xd7, 0x52, 0x1f, 0xc2, 0xfa, 0x23, 0x1c, 0xec, 0xf0, 0x2b, 0x71,
+	0xc7, 0x75, 0x8e, 0xed, 0x93, 0x4c, 0xf1, 0x88, 0x40, 0x6b, 0x9a, 0x4f, 0xa6, 0x43, 0x7a, 0x13,
+	0x16, 0xc5, 0x0d, 0x2d, 0x4e, 0xa9, 0x21, 0x15, 0x24, 0xb8, 0x6b, 0x12, 0xaf, 0x7e, 0x0e, 0xeb,
+	0xbd, 0xf1, 0xe5, 0x85, 0xff, 0x2e, 0x4b, 0x3e, 0x86, 0xd6, 0xf4, 0x92, 0x99, 0x1c, 0xe6, 0x6b,
+	0x05, 0x8a, 0xfb, 0x78, 0x74, 0x84, 0x7d, 0x84, 0xa0, 0xe0, 0x18, 0x23, 0x9e, 0x5b, 0x94, 0x35,
+	0xf6, 0x4d, 0x4f, 0x6d, 0xc4, 0xb0, 0xb1, 0x53, 0xe3, 0x80, 0xae, 0x45, 0x91, 0x1e, 0xc6, 0xbe,
+	0x3e, 0xf6, 0x87, 0xa4, 0x95, 0xbf, 0x9d, 0xbf, 0x57, 0xd6, 0x4a, 0x14, 0xf0, 0xcc, 0x1f, 0x12,
+	0x9a, 0x19, 0x98, 0x43, 0x1b, 0x3b, 0x01, 0x47, 0x17, 0x18, 0x1a, 0x38, 0x48, 0x12, 0xc4, 0x53,
+	0x87, 0x85, 0xa9, 0xd4, 0xe1, 0x23, 0xe6, 0x6a, 0x5c, 0x38, 0x92, 0xc9, 0x1c, 0xfe, 0xa6, 0x00,
+	0x8a, 0xb3, 0xc8, 0xe8, 0xae, 0x8b, 0x7c, 0xc7, 0x34, 0xe1, 0xe3, 0xae, 0xc2, 0xc8, 0x39, 0x57,
+	0x4d, 0x22, 0x67, 0xb8, 0x6b, 0x9c, 0x4c, 0xe0, 0xd0, 0x03, 0x58, 0xa2, 0x5b, 0x1e, 0xea, 0x01,
+	0x71, 0x75, 0x0e, 0x93, 0x5e, 0x9b, 0x9c, 0xd0, 0x60, 0x64, 0x03, 0xe2, 0x3e, 0xe5, 0x44, 0x6a,
+	0x0f, 0xca, 0xa1, 0x43, 0xa3, 0xdb, 0x50, 0xf0, 0x70, 0xb8, 0x81, 0xa4, 0xf3, 0x32, 0x0c, 0x7a,
+	0x0d, 0xaa, 0x2c, 0x2e, 0x10, 0x6c, 0xba, 0x8e, 0x25, 0x93, 0xd5, 0x0a, 0x85, 0xf5, 0x39, 0x48,
+	0xfd, 0x26, 0x0f, 0x6b, 0xdc, 0xab, 0x1f, 0x63, 0xc3, 0x0f, 0x8e, 0xb0, 0x11, 0x64, 0x32, 0xdc,
+	0xff, 0xb6, 0x80, 0x86, 0x5e, 0x87, 0xda, 0xd1, 0x24, 0xc0, 0x44, 0x7f, 0xee, 0xdb, 0x41, 0x80,
+	0x9d, 0x56, 0x91, 0x29, 0xa7, 0xca, 0x80, 0x9f, 0x70, 0x18, 0xbd, 0x09, 0x38, 0x91, 0x8f, 0x0d,
+	0xab, 0xb5, 0xc8, 0xb3, 0x76, 0x06, 0xd1, 0xb0, 0x41, 0xb3, 0xf6, 0xea, 0x19, 0x9e, 0x44, 0x2c,
+	0x4a, 0x5c, 0xbf, 0x14, 0x26, 0x39, 0x5c, 0x83, 0x32, 0x23, 0x61, 0x0c, 0xca, 0xdc, 0x79, 0x28,
+	0x80, 0xcd, 0x7f, 0x13, 0x9a, 0x86, 0xe7, 0xf9, 0xee, 0x0b, 0x7b, 0x64, 0x04, 0x58, 0x27, 0xf6,
+	0x17, 0xb8, 0x05, 0x8c, 0xa6, 0x11, 0x83, 0xf7, 0xed, 0x2f, 0x70, 0x9a, 0x94, 0xb2, 0x68, 0x55,
+	0xa6, 0x48, 0x9f, 0xe0, 0x09, 0x51, 0x31, 0xc0, 0xce, 0xa9, 0xe1, 0x9c, 0x60, 0xba, 0xd1, 0x57,
+	0xb0, 0x92, 0xff, 0x85, 0x8a, 0xc9, 0xe8, 0x75, 0x56, 0x56, 0xe4, 0x58, 0x59, 0x21, 0xfc, 0x81,
+	0xc6, 0x15, 0xce, 0x8c, 0xd5, 0x16, 0x60, 0x86, 0xdf, 0xea, 0xff, 0x41, 0x35, 0x5a, 0xe6, 0xe3,
+	0x2d, 0xb4, 0x01, 0x8b, 0x1c, 0x4b, 0x5a, 0x0a, 0xd3, 0x7e, 0x53, 0xb0, 0x08, 0x89, 0x34, 0x49,
+	0xa0, 0x6e, 0x41, 0x7d, 0xe0, 0x1b, 0x0e, 0x39, 0xc6, 0x3e, 0x37, 0xed, 0x97, 0x8b, 0xa9, 0xfe,
+	0xa6, 0x00, 0xeb, 0x53, 0x96, 0x9a, 0xc9, 0x9b, 0xdf, 0x0d, 0x37, 0xcc, 0x96, 0xe4, 0x06, 0x3b,
+	0x2d, 0x2d, 0x98, 0xe1, 0x37, 0xfa, 0x7f, 0x68, 0x04, 0x42, 0x60, 0x3d, 0x61, 0xbf, 0x62, 0xa5,
+	0xe4, 0x6e, 0xb4, 0x7a, 0x90, 0xdc, 0x5d, 0xe2, 0xe2, 0x2b, 0x24, 0x2f, 0x3e, 0xf4, 0x01, 0x54,
+	0x05, 0x12, 0x7b, 0xae, 0x79, 0xda, 0x5a, 0x10, 0xde, 0x96, 0x70, 0xa0, 0x3d, 0x8a, 0xd2, 0x2a,
+	0x7e, 0x34, 0x40, 0x6f, 0x43, 0x25, 0x30, 0xfc, 0x13, 0x1c, 0xf0, 0x6d, 0x14, 0x67, 0x68, 0x0e,
+	0x38, 0x01, 0xdb, 0xc2, 0x07, 0xb0, 0x7e, 0x2a, 0x15, 0xa7, 0xdb, 0x4e, 0x80, 0xfd, 0x73, 0x63,
+	0x48, 0x43, 0x03, 0x11, 0x86, 0xbd, 0x1a, 0xa2, 0xbb, 0x02, 0xdb, 0xc7, 0x26, 0xa1, 0xa5, 0xe9,
+	0x08, 0xfb, 0x27, 0xb8, 0x55, 0x8a, 0x97, 0xa6, 0xfb, 0x14, 0xa4, 0x71, 0x0c, 0x7a, 0x1f, 0xaa,
+	0xc4, 0x1b, 0xda, 0x81, 0x2e, 0x42, 0x40, 0x99, 0x51, 0x2e, 0x71, 0xca, 0x3e, 0xc5, 0x88, 0x28,
+	0x50, 0x21, 0xd1, 0x00, 0x3d, 0x80, 0x7a, 0xec, 0x18, 0xf4, 0xf3, 0x2d, 0x66, 0xfb, 0x95, 0x2d,
+	0x94, 0x3e, 0x89, 0x8f, 0xb7, 0xb4, 0xaa, 0x19, 0x1b, 0xa9, 0xef, 0xc0, 0x02, 0x5b, 0x9f, 0x46,
+	0x1d, 0xbe, 0xc3, 0x96, 0x32, 0x3b, 0xea, 0x70, 0xac, 0xfa, 0x14, 0x2a, 0x31, 0x31, 0xd0, 0x9b,
+	0x50, 0xf4, 0xdc, 0xa1, 0x6d, 0x4e, 0x44, 0x0d, 0xbd, 0x24, 0x57, 0xc4, 0xe6, 0x59, 0x8f, 0x21,
+	0x34, 0x41, 0x40, 0x2f, 0x44, 0xe6, 0x6b, 0x34, 0xec, 0x57, 0x35, 0xf6, 0xad, 0x1e, 0x43, 0xa3,
+	0x43, 0xce, 0x04, 0xc3, 0x1f, 0x2e, 0x56, 0xaa, 0x5f, 0x2a, 0xd0, 0x8c, 0x16, 0xca, 0x58, 0x54,
+	0xd5, 0x1c, 0xfc, 0x5c, 0x4f, 0x67, 0x5d, 0x15, 0x07, 0x3f, 0xd7, 0xa4, 0xfd, 0xdd, 0x86, 0x2a,
+	0xa5, 0x61, 0x87, 0x60, 0x5b, 0xfc, 0x16, 0x2f, 0x68, 0xe0, 0xe0, 0xe7, 0x54, 0xdd, 0x5d, 0x8b,
+	0xa8, 0xbf, 0x53, 0x00, 0x69, 0xd8, 0x73, 0xfd, 0x20, 0xfb, 0xa6, 0x55, 0x28, 0x0c, 0xf1, 0x71,
+	0x30, 0x67, 0xcb, 0x0c, 0x87, 0xee, 0xc0, 0x82, 0x6f, 0x9f, 0x9c, 0x06, 0x73, 0x4a, 0x5f, 0x8e,
+	0x54, 0x77, 0x60, 0x39, 0x21, 0x4c, 0xa6, 0x9c, 0xe7, 0xb7, 0x05, 0x00, 0x56, 0x64, 0xf0, 0xbb,
+	0x34, 0x5e, 0x5c, 0x29, 0x89, 0xe2, 0x8a, 0x36, 0x2c, 0x4c, 0xc3, 0x33, 0x4c, 0x3b, 0x98, 0xc8,
+	0xec, 0x47, 0x8e, 0xd1, 0x75, 0x28, 0x1b, 0xe7, 0x86, 0x3d, 0x34, 0x8e, 0x86, 0x98, 0x09, 0x5d,
+	0xd0, 0x22, 0x00, 0xbd, 0x1e, 0x84, 0xe2, 0x79, 0x39, 0x58, 0x60, 0xe5, 0xa0, 0xf0, 0xe1, 0x1d,
+	0x0a, 0x42, 0x6f, 0x01, 0x22, 0xe2, 0xe2, 0x22, 0x8e, 0xe1, 0x09, 0xc2, 0x05, 0x46, 0xd8, 0x14,
+	0x98, 0xbe, 0x63, 0x78, 0x9c, 0xfa, 0x3e, 0xac, 0xf8, 0xd8, 0xc4, 0xf6, 0x79, 0x8a, 0xbe, 0xc8,
+	0xe8, 0x51, 0x88, 0x8b, 0x66, 0xdc, 0x00, 0x20, 0x81, 0xe1, 0x07, 0x3a, 0xed, 0x63, 0x30, 0x3f,
+	0xaf, 0x69, 0x65, 0x06, 0xa1, 0x3d, 0x0e, 0xb4, 0x09, 0xcb, 0x86, 0xe7, 0x0d, 0x27, 0x29, 0x7e,
+	0x25, 0x46, 0xb7, 0x24, 0x51, 0x11, 0xbb, 0x75, 0x58, 0xb4, 0x89, 0x7e, 0x34, 0x26, 0x13, 0xe6,
+	0xe3, 0x25, 0xad, 0x68, 0x93, 0xed, 0x31, 0x99, 0xd0, 0x00, 0x37, 0x26, 0xd8, 0x8a, 0x5f, 0x61,
+	0x25, 0x0a, 0x60, 0x77, 0xd7, 0xd4, 0x55, 0x5b, 0x99, 0x71, 0xd5, 0xa6, 0xef, 0xd2, 0xea, 0xf4,
+	0x5d, 0x7a, 0x17, 0xea, 0x54, 0x48, 0x72, 0xea, 0x06, 0x3a, 0x9b, 0xdb, 0xaa, 0x31, 0xa2, 0x9a,
+	0x84, 0x6e, 0x53, 0x20, 0xd5, 0x52, 0x48, 0x66, 0x8d, 0x7d, 0x96, 0x48, 0xd2, 0x56, 0x5d, 0x9d,
+	0x11, 0x23, 0x89, 0xdb, 0x15, 0xa8, 0x7d, 0xa2, 0x0e, 0x61, 0x95, 0xd9, 0xc2, 0x65, 0x53, 0xa0,
+	0x05, 0x42, 0x8d, 0x29, 0x79, 0xa1, 0x44, 0x46, 0xa6, 0x71, 0xb4, 0xfa, 0x4b, 0x58, 0x4b, 0xaf,
+	0x96, 0xc9, 0xb7, 0x2f, 0x08, 0xe8, 0xb9, 0x0b, 0x02, 0xba, 0xfa, 0x0b, 0x58, 0x7e, 0x84, 0x83,
+	0xce, 0x70, 0xc8, 0xa4, 0xc8, 0x94, 0x55, 0xa3, 0x07, 0xd0, 0xc2, 0x2f, 0xcc, 0xe1, 0xd8, 0xc2,
+	0x7a, 0xe0, 0x8e, 0x8e, 0x48, 0xe0, 0x3a, 0x58, 0x67, 0x1e, 0x43, 0x44, 0xe3, 0x66, 0x4d, 0xe0,
+	0x07, 0x12, 0xcd, 0x57, 0x53, 0xcf, 0x60, 0x25, 0xb9, 0x7a, 0xa6, 0xbd, 0xdf, 0x85, 0x62, 0xb8,
+	0x5a, 0x7e, 0xba, 0x97, 0x20, 0x90, 0xea, 0xef, 0x15, 0x40, 0x7d, 0xd3, 0x70, 0x78, 0x00, 0x21,
+	0x59, 0x8b, 0x56, 0xee, 0x42, 0x51, 0x33, 0xa0, 0xc4, 0x00, 0x4f, 0xf0, 0x84, 0xb6, 0x7a, 0x86,
+	0xf6, 0xc8, 0xe6, 0x11, 0x6b, 0x41, 0xe3, 0x03, 0xea, 0x26, 0xd8, 0xb1, 0xd8, 0x84, 0x02, 0x9b,
+	0x50, 0xc4, 0x8e, 0x45, 0x5b, 0x07, 0x7f, 0x50, 0x60, 0x39, 0x21, 0x4f, 0xc6, 0xfc, 0x45, 0xc6,
+	0x15, 0xba, 0x69, 0xa9, 0x82, 0x74, 0xb4, 0x14, 0x71, 0x66, 0x9f, 0x92, 0xd0, 0x02, 0x46, 0x16,
+	0x1a, 0xf9, 0x19, 0xa9, 0xb1, 0x44, 0xaa, 0x7f, 0x56, 0x60, 0xa5, 0x6f, 0x1a, 0x41, 0x80, 0xfd,
+	0x4b, 0x34, 0x50, 0x2e, 0xaa, 0xf3, 0x5f, 0xb5, 0xc1, 0x19, 0xab, 0x14, 0x0a, 0xf3, 0x2b, 0x05,
+	0x75, 0x0f, 0x56, 0x53, 0xf2, 0x66, 0xec, 0x19, 0xd1, 0x22, 0xf1, 0xd0, 0xc3, 0xbe, 0x11, 0xb8,
+	0xfe, 0xf7, 0xdf, 0xdc, 0xf8, 0x87, 0x02, 0xcb, 0x89, 0x05, 0x32, 0x1d, 0xfc, 0x85, 0x7a, 0x7d,
+	0x8b, 0xba, 0x84, 0x11, 0x8c, 0x49, 0x2b, 0x1f, 0xcf, 0xe0, 0xe5, 0x92, 0x7d, 0x86, 0xd3, 0x04,
+	0x0d, 0xcb, 0x6b, 0x6c, 0x87, 0x27, 0xa3, 0x65, 0x8d, 0x7d, 0x53, 0x63, 0x26, 0x01, 0xf6, 0x78,
+	0xf5, 0x54, 0xd6, 0xf8, 0x80, 0x46, 0xdd, 0x63, 0xdb, 0xb1, 0xc9, 0x29, 0x0d, 0xef, 0x0c, 0xcd,
+	0xaf, 0x9b, 0x9a, 0x84, 0xf6, 0x29, 0x90, 0x36, 0x93, 0x1f, 0xe1, 0xe0, 0xd1, 0x4e, 0xdf, 0x38,
+	0xc6, 0x3d, 0xd7, 0x76, 0x32, 0xc5, 0x50, 0x15, 0xc3, 0x5a, 0x9a, 0x4b, 0x26, 0x4d, 0xd1, 0x7b,
+	0xcf, 0x38, 0xc6, 0xba, 0x47, 0x79, 0x08, 0x55, 0x95, 0x89, 0x64, 0xaa, 0x1e, 0x43, 0xeb, 0x99,
+	0x67, 0x19, 0x01, 0xbe, 0xa4, 0xbc, 0x2f, 0x5b, 0xc7, 0x85, 0xab, 0x33, 0xd6, 0xc9, 0xb4, 0xa3,
+	0x3b, 0x50, 0xa7, 0x59, 0xda, 0xd4, 0x6a, 0x34, 0x77, 0x0b, 0x79, 0xd3, 0x00, 0x73, 0x8b, 0xaf,
+	0xd8, 0xc7, 0xfe, 0xb9, 0x6d, 0x7e, 0x2f, 0x1b, 0xe4, 0x9c, 0xa4, 0xcd, 0x55, 0xb5, 0xb2, 0x80,
+	0x74, 0x2d, 0xd4, 0x84, 0x7c, 0x10, 0x0c, 0x99, 0xc5, 0xe5, 0x35, 0xfa, 0x99, 0xd2, 0x48, 0x21,
+	0xad, 0x91, 0x3f, 0x29, 0x70, 0x7b, 0xbe, 0x80, 0x99, 0xcf, 0xfa, 0x3b, 0x89, 0x78, 0x07, 0xea,
+	0x23, 0xdb, 0xd1, 0xa7, 0xc4, 0xac, 0x8e, 0x6c, 0x27, 0x52, 0xe5, 0x57, 0x0a, 0xac, 0x74, 0xc8,
+	0xd9, 0xb6, 0x11, 0x98, 0xa7, 0x3f, 0x78, 0xae, 0x4f, 0x3b, 0x61, 0xbc, 0x84, 0x8a, 0xbf, 0x1c,
+	0x00, 0x03, 0xb1, 0xd4, 0x4b, 0x3d, 0x84, 0x45, 0x26, 0x45, 0x77, 0x77, 0x3a, 0xa9, 0x57, 0x5e,
+	0x9e, 0xd4, 0xe7, 0xa6, 0x92, 0xfa, 0x63, 0x58, 0x4d, 0x6d, 0x2f, 0x93, 0xf6, 0x6f, 0x41, 0xde,
+	0xb6, 0xa2, 0x6b, 0x38, 0x2a, 0xf9, 0xba, 0xbb, 0x1a, 0xc5, 0xa8, 0x1e, 0xac, 0xf3, 0x74, 0xfd,
+	0x92, 0x9a, 0xbc, 0x07, 0x8b, 0x7c, 0xc7, 0xf3, 0x2e, 0x3c, 0x89, 0xa6, 0x9d, 0xd1, 0xe9, 0x15,
+	0x33, 0x5d, 0x0b, 0xbf, 0x56, 0x60, 0xa9, 0x3f, 0x71, 0xcc, 0x4b, 0xdc, 0x85, 0x77, 0xa0, 0xc8,
+	0xbb, 0x83, 0xc2, 0x00, 0x52, 0x2d, 0x41, 0x8e, 0x63, 0xc7, 0xcf, 0x92, 0x0c, 0xdb, 0xb1, 0xf0,
+	0x0b, 0x51, 0x4a, 0xf0, 0xd4, 0xbd, 0x4b, 0x21, 0xea, 0x5f, 0x68, 0x26, 0x13, 0x93, 0x24, 0xd3,
+	0x59, 0xbd, 0xb2, 0x0a, 0xd1, 0x7b, 0x50, 0x17, 0xe6, 0x75, 0x51, 0xda, 0x50, 0xe3, 0x34, 0xa2,
+	0x3b, 0x49, 0x1d, 0xd1, 0xc1, 0x2f, 0xe4, 0x1e, 0x84, 0xeb, 0x53, 0x08, 0xdf, 0xc2, 0x97, 0x39,
+	0x58, 0xd2, 0xb0, 0x37, 0xb4, 0x79, 0x6f, 0x97, 0x5f, 0x48, 0x34, 0xef, 0xa7, 0x8f, 0xea, 0x3e,
+	0x47, 0x10, 0x69, 0xcb, 0x23, 0xe3, 0x85, 0xa0, 0x25, 0x34, 0xd9, 0x1c, 0x3b, 0x16, 0xf6, 0x25,
+	0x51, 0x80, 0x2d, 0x3d, 0xda, 0x07, 0x25, 0x5f, 0x63, 0x78, 0x2d, 0x44, 0x8b, 0xfc, 0x8a, 0xa6,
+	0xc8, 0xee, 0xf9, 0xec, 0x89, 0x5c, 0xc5, 0xab, 0xee, 0xf9, 0xac, 0x79, 0x1b, 0xb0, 0x14, 0xf6,
+	0x1f, 0xc3, 0x19, 0x7c, 0x43, 0x0d, 0xd9, 0x75, 0x94, 0xb4, 0xf7, 0x61, 0x25, 0xde, 0x7b, 0x0c,
+	0xc9, 0x17, 0x18, 0x39, 0x8a, 0x35, 0x1d, 0xc5, 0x0c, 0xf5, 0x10, 0x1a, 0x61, 0x55, 0x80, 0x79,
+	0x61, 0x25, 0x6a, 0x07, 0xf9, 0xa8, 0x9f, 0xae, 0x1d, 0x30, 0xaf, 0x1d, 0x70, 0xf2, 0x05, 0xb3,
+	0x20, 0x9f, 0x16, 0x13, 0x4f, 0x27, 0xe2, 0xa2, 0xcf, 0x72, 0xfb, 0xfe, 0x31, 0x07, 0xad, 0x69,
+	0x46, 0x99, 0x4c, 0x6d, 0x13, 0x96, 0x7d, 0xe3, 0x38, 0xd0, 0xc3, 0xe7, 0x5b, 0x5e, 0x81, 0xf2,
+	0x67, 0xfd, 0x25, 0x8a, 0x0a, 0x9f, 0x8c, 0x59, 0x25, 0x7a, 0x17, 0xea, 0x36, 0xd1, 0x6d, 0xc7,
+	0x0e, 0x6c, 0x63, 0x68, 0x7f, 0x81, 0x2d, 0x76, 0x40, 0x25, 0xad, 0x66, 0x93, 0x6e, 0x04, 0x44,
+	0x0f, 0x01, 0xf9, 0x91, 0x09, 0xe9, 0x22, 0xe1, 0xe1, 0x09, 0xe2, 0xba, 0x14, 0x28, 0x65, 0x62,
+	0xda, 0x92, 0x9f, 0x06, 0xa1, 0x07, 0x50, 0xe5, 0xf5, 0x3e, 0x53, 0xa0, 0xec, 0x17, 0xaf, 0xa6,
+	0xd5, 0xce, 0x0e, 0x47, 0xab, 0x30, 0x52, 0xf6, 0x4d, 0x36, 0x30, 0x94, 0xc3, 0xff, 0x5a, 0xa0,
+	0x22, 0xe4, 0x0e, 0x9f, 0x34, 0xaf, 0xa0, 0x0a, 0x2c, 0x3e, 0x3b, 0x78, 0x72, 0x70, 0xf8, 0xc9,
+	0x41, 0x53, 0x41, 0x2b, 0xd0, 0x3c, 0x38, 0x1c, 0xe8, 0xdb, 0x87, 0x87, 0x83, 0xfe, 0x40, 0xeb,
+	0xf4, 0x7a, 0x7b, 0xbb, 0xcd, 0x1c, 0x5a, 0x86, 0x46, 0x7f, 0x70, 0xa8, 0xed, 0xe9, 0x83, 0xc3,
+	0xfd, 0xed, 0xfe, 0xe0, 0xf0, 0x60, 0xaf, 0x99, 0x47, 0x2d, 0x58, 0xe9, 0x3c, 0xd5, 0xf6, 0x3a,
+	0xbb, 0x9f, 0x25, 0xc9, 0x0b, 0x1b, 0x1d, 0xa8, 0x27, 0x7b, 0xaf, 0x74, 0x8d, 0x8e, 0x65, 0x1d,
+	0xb8, 0x16, 0x6e, 0x5e, 0x41, 0x75, 0x00, 0x0d, 0x8f, 0xdc, 0x73, 0xcc, 0xc6, 0x0a, 0x42, 0x50,
+	0xef, 0x58, 0xd6, 0x53, 0x6c, 0xf8, 0x0e, 0xf6, 0x19, 0x2c, 0xb7, 0xf1, 0x3e, 0x54, 0x62, 0x1d,
+	0x2d, 0x54, 0x82, 0x42, 0x7f, 0xa7, 0x73, 0xd0, 0xbc, 0x82, 0x1a, 0x50, 0xe9, 0xf4, 0x7a, 0xda,
+	0xe1, 0xa7, 0xdd, 0xfd, 0xce, 0x60, 0xaf, 0xa9, 0x20, 0x80, 0xe2, 0xb3, 0xfe, 0xde, 0x93, 0xbd,
+	0xcf, 0x9a, 0xb9, 0x8d, 0x9f, 0x40, 0x3d, 0x99, 0x32, 0xd2, 0x89, 0x07, 0x54, 0x5c, 0xb6, 0xcd,
+	0x4f, 0x3a, 0xdd, 0x41, 0xf7, 0xe0, 0x51, 0x53, 0xa1, 0x03, 0xed, 0xd9, 0xc1, 0x01, 0x1d, 0xe4,
+	0x50, 0x15, 0x4a, 0x0f, 0xbb, 0x07, 0xdd, 0xfe, 0xe3, 0xbd, 0xdd, 0x66, 0x9e, 0xa2, 0x06, 0xdd,
+	0xfd, 0xbd, 0xc3, 0x67, 0x83, 0x66, 0x81, 0xa2, 0xb4, 0xbd, 0xde, 0xd3, 0xce, 0xce, 0xde, 0x6e,
+	0x73, 0x61, 0xe3, 0x7e, 0xac, 0xed, 0xc2, 0xf4, 0xf7, 0xcc, 0xe3, 0x8c, 0x0f, 0x8f, 0x8f, 0x87,
+	0xb6, 0x43, 0xf7, 0x52, 0x83, 0x72, 0x58, 0x34, 0x36, 0x73, 0x5b, 0x7f, 0x6f, 0x40, 0xae, 0xb7,
+	0x8b, 0x3a, 0x00, 0xd1, 0x33, 0x0e, 0x12, 0x67, 0x3d, 0xf5, 0x36, 0xd4, 0x6e, 0x4d, 0x23, 0xb8,
+	0x7d, 0xaa, 0x57, 0xd0, 0x7d, 0xc8, 0x0f, 0x88, 0x8b, 0x84, 0x73, 0x45, 0x7f, 0x82, 0x69, 0x2f,
+	0xc5, 0x20, 0x92, 0xfa, 0x9e, 0x72, 0x5f, 0x41, 0x3f, 0x82, 0x72, 0x68, 0xa6, 0x68, 0x8d, 0x53,
+	0xa5, 0xff, 0x03, 0xd2, 0x5e, 0x9f, 0x82, 0x87, 0x2b, 0xee, 0x43, 0x3d, 0xf9, 0xdf, 0x08, 0x74,
+	0x8d, 0x13, 0xcf, 0xfc, 0xdf, 0x45, 0xfb, 0xfa, 0x6c, 0x64, 0xc8, 0xee, 0x01, 0x2c, 0x8a, 0x7f,
+	0x2a, 0x20, 0xe1, 0x7d, 0xc9, 0x7f, 0x43, 0xb4, 0x57, 0x53, 0xd0, 0x70, 0xe6, 0x87, 0x50, 0x92,
+	0xff, 0x10, 0x40, 0xab, 0xa1, 0x8a, 0xe2, 0x4f, 0xf9, 0xed, 0xb5, 0x34, 0x38, 0x3e, 0xb9, 0x37,
+	0x4e, 0x4e, 0xee, 0x8d, 0x67, 0x4e, 0x4e, 0xbf, 0xdc, 0x73, 0x15, 0x24, 0xbb, 0x1d, 0x52, 0x05,
+	0x33, 0x3b, 0x2e, 0xed, 0xeb, 0xb3, 0x91, 0x21, 0xbb, 0x01, 0x34, 0x52, 0x8f, 0x00, 0xe8, 0xba,
+	0xf4, 0xfb, 0x59, 0xaf, 0x58, 0xed, 0x1b, 0x73, 0xb0, 0xe9, 0x73, 0x0e, 0x5f, 0xb7, 0x51, 0xa4,
+	0x88, 0xc4, 0xb5, 0xdf, 0x5e, 0x9f, 0x82, 0x87, 0x52, 0x3d, 0x84, 0x5a, 0xe2, 0x75, 0x1c, 0xb5,
+	0x53, 0xb4, 0xb1, 0x27, 0xf3, 0x8b, 0xf8, 0x7c, 0x08, 0x25, 0xd9, 0xf0, 0x95, 0x9a, 0x4e, 0x75,
+	0x9a, 0xdb, 0x6b, 0x69, 0x70, 0x38, 0x79, 0x17, 0x2a, 0xb1, 0xbe, 0x28, 0x6a, 0x85, 0xe1, 0x30,
+	0xd5, 0xb7, 0x6d, 0x5f, 0x9d, 0x81, 0x09, 0xb9, 0xf4, 0xa1, 0x19, 0x5d, 0x01, 0xfc, 0x59, 0x19,
+	0xdd, 0x08, 0x25, 0x9e, 0xf5, 0xc2, 0xdd, 0xbe, 0x39, 0x0f, 0x1d, 0x67, 0xda, 0x1b, 0xcf, 0x66,
+	0xda, 0x1b, 0x5f, 0xc8, 0x74, 0xde, 0x13, 0xb7, 0x7a, 0x05, 0x3d, 0x82, 0x6a, 0xbc, 0x93, 0x84,
+	0xae, 0x86, 0x62, 0xa4, 0x7b, 0x5b, 0xed, 0xf6, 0x2c, 0x54, 0x5c, 0x71, 0xb1, 0xa6, 0x8c, 0x54,
+	0xdc, 0x74, 0xdf, 0xa8, 0x7d, 0x75, 0x06, 0x26, 0xe4, 0xf2, 0x63, 0xa8, 0x25, 0x3a, 0x11, 0xd2,
+	0x06, 0x66, 0xb5, 0x53, 0xda, 0xd7, 0x66, 0xe2, 0xe2, 0x12, 0xc5, 0xba, 0x05, 0x28, 0x0a, 0x6a,
+	0xa9, 0x0e, 0x45, 0xfb, 0xea, 0x0c, 0x4c, 0xdc, 0xf5, 0x92, 0xc5, 0xb4, 0x74, 0xbd, 0x99, 0x85,
+	0x7a, 0xfb, 0xfa, 0x6c, 0x64, 0xc8, 0xee, 0x63, 0x58, 0x9a, 0x2a, 0x66, 0x91, 0x38, 0xa6, 0x79,
+	0xd5, 0x74, 0xfb, 0xd6, 0x5c, 0x7c, 0xc8, 0xf7, 0x0c, 0x5a, 0xf3, 0x2a, 0x42, 0x74, 0x37, 0x3e,
+	0x7d, 0x6e, 0x49, 0xdb, 0x7e, 0xe3, 0x65, 0x64, 0xf1, 0x53, 0x4a, 0x54, 0x3d, 0xf2, 0x94, 0x66,
+	0x55, 0x7a, 0xed, 0x6b, 0x33, 0x71, 0x71, 0xab, 0x4e, 0xd7, 0x19, 0xe8, 0x46, 0xdc, 0xb7, 0xa6,
+	0x39, 0xde, 0x9c, 0x87, 0x8e, 0x85, 0x92, 0x4a, 0x94, 0xe7, 0x87, 0x17, 0xdd, 0x54, 0x11, 0xd2,
+	0x6e, 0x4d, 0x23, 0x12, 0x21, 0x6d, 0x9b, 0x85, 0xa4, 0x9e, 0x8f, 0xcf, 0xb3, 0x87, 0xb5, 0x44,
+	0x2c, 0x10, 0xd9, 0xc0, 0x54, 0x2c, 0x48, 0xe4, 0x9b, 0xed, 0x9b, 0xf3, 0xd0, 0x92, 0xe9, 0xf6,
+	0xc6, 0x5f, 0xbf, 0xbd, 0xa9, 0x7c, 0xf3, 0xed, 0x4d, 0xe5, 0x9f, 0xdf, 0xde, 0x54, 0xbe, 0xfe,
+	0xd7, 0xcd, 0x2b, 0xd0, 0x32, 0xdd, 0xd1, 0xa6, 0x67, 0x3b, 0x27, 0xa6, 0xe1, 0x6d, 0x06, 0xf6,
+	0xd9, 0xf9, 0xe6, 0xd9, 0x39, 0xfb, 0x87, 0xee, 0x51, 0x91, 0xfd, 0xbc, 0xf7, 0x9f, 0x01, 0x00,
+	0xba, 0x72, 0x1f, 0xfc, 0xe0, 0x2b, 0x00, 0x00,
 }
@@ -0,0 +1,108 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package urlutil
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ParseURL parses the url and checks its host. IPv6 literals must be
+// bracketed, like http://[::1]:2379.
+func ParseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("invalid url %q, it should be like http://127.0.0.1:2379", s)
+	}
+	if !strings.HasPrefix(u.Host, "[") && strings.Count(u.Host, ":") > 1 {
+		return nil, errors.Errorf("invalid url %q, IPv6 address should be bracketed like http://[::1]:2379", s)
+	}
+	return u, nil
+}
+
+// Canonical returns the canonical form of the url, so that urls with
+// different forms of the same IP address are equal.
+func Canonical(u *url.URL) string {
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+	} else {
+		host = strings.ToLower(host)
+	}
+	if port := u.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return strings.ToLower(u.Scheme) + "://" + host + strings.TrimSuffix(u.Path, "/")
+}
+
+// ContainsAny returns true if any url in a is also in b.
+func ContainsAny(a, b []string) bool {
+	set := make(map[string]struct{}, len(b))
+	for _, s := range b {
+		if u, err := ParseURL(s); err == nil {
+			set[Canonical(u)] = struct{}{}
+		}
+	}
+	for _, s := range a {
+		if u, err := ParseURL(s); err == nil {
+			if _, ok := set[Canonical(u)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Equal returns true if a and b contain the same urls.
+func Equal(a, b []string) bool {
+	canonical := func(urls []string) map[string]struct{} {
+		set := make(map[string]struct{}, len(urls))
+		for _, s := range urls {
+			if u, err := ParseURL(s); err == nil {
+				set[Canonical(u)] = struct{}{}
+			} else {
+				set[s] = struct{}{}
+			}
+		}
+		return set
+	}
+	sa, sb := canonical(a), canonical(b)
+	if len(sa) != len(sb) {
+		return false
+	}
+	for k := range sa {
+		if _, ok := sb[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// AddScheme adds the default scheme "http://" to the address if it has no
+// scheme, the address may be an IPv6 literal like [::1]:2379.
+func AddScheme(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if strings.Contains(addr, "://") {
+		return addr
+	}
+	return "http://" + addr
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package urlutil
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+func TestURLUtil(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testURLUtilSuite{})
+
+type testURLUtilSuite struct{}
+
+func (s *testURLUtilSuite) TestParseURL(c *C) {
+	tbl := []struct {
+		url       string
+		host      string
+		canonical string
+	}{
+		{"http://127.0.0.1:2379", "127.0.0.1:2379", "http://127.0.0.1:2379"},
+		{" HTTP://PD1:2379/ ", "PD1:2379", "http://pd1:2379"},
+		{"http://[::1]:2379", "[::1]:2379", "http://[::1]:2379"},
+		{"http://[0:0:0:0:0:0:0:1]:2379", "[0:0:0:0:0:0:0:1]:2379", "http://[::1]:2379"},
+		{"https://[FE80::1]", "[FE80::1]", "https://[fe80::1]"},
+		{"unix://localhost:1", "localhost:1", "unix://localhost:1"},
+	}
+	for _, t := range tbl {
+		u, err := ParseURL(t.url)
+		c.Assert(err, IsNil)
+		c.Assert(u.Host, Equals, t.host)
+		c.Assert(Canonical(u), Equals, t.canonical)
+	}
+
+	for _, s := range []string{"", "127.0.0.1:2379", "http://", "http://::1:2379", "http://fe80::1"} {
+		_, err := ParseURL(s)
+		c.Assert(err, NotNil, Commentf("url %q", s))
+	}
+}
+
+func (s *testURLUtilSuite) TestCompare(c *C) {
+	a := []string{"http://[::1]:2379", "http://10.0.0.1:2379"}
+	b := []string{"http://10.0.0.1:2379", "http://[0::1]:2379"}
+	c.Assert(Equal(a, b), IsTrue)
+	c.Assert(Equal(a, b[:1]), IsFalse)
+	c.Assert(ContainsAny([]string{"http://[0:0::1]:2379"}, a), IsTrue)
+	c.Assert(ContainsAny([]string{"http://[::1]:2380"}, a), IsFalse)
+}
+
+func (s *testURLUtilSuite) TestAddScheme(c *C) {
+	c.Assert(AddScheme("[::1]:2379"), Equals, "http://[::1]:2379")
+	c.Assert(AddScheme("127.0.0.1:2379"), Equals, "http://127.0.0.1:2379")
+	c.Assert(AddScheme("https://[::1]:2379"), Equals, "https://[::1]:2379")
+}
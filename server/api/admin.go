@@ -0,0 +1,94 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/pkg/logutil"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type logLevel struct {
+	Level string `json:"level"`
+}
+
+type adminHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newAdminHandler(svr *server.Server, rd *render.Render) *adminHandler {
+	return &adminHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// GetLogLevel returns the current log level of the server.
+func (h *adminHandler) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, &logLevel{Level: log.GetLevel().String()})
+}
+
+// SetLogLevel changes the log level of the leader at runtime. The level is
+// not persisted, it's reset by restarting or reloading the config file.
+func (h *adminHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	level := &logLevel{}
+	if err := readJSON(r.Body, level); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := logutil.SetLevel(level.Level); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Warnf("log level is changed to %s", level.Level)
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// DropCacheRegion removes the region from the cache of the leader, so it's
+// learned again from the next heartbeat.
+func (h *adminHandler) DropCacheRegion(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+	regionID, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = cluster.DropCacheRegion(regionID); err != nil {
+		h.rd.JSON(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// DropCacheRegions removes all the regions from the cache of the leader, they
+// are learned again from the heartbeats.
+func (h *adminHandler) DropCacheRegions(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+	cluster.DropCacheRegions()
+	h.rd.JSON(w, http.StatusOK, nil)
+}
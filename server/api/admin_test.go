@@ -0,0 +1,95 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testAdminSuite{})
+
+type testAdminSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testAdminSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1/admin", httpAddr, apiPrefix)
+
+	mustBootstrapCluster(c, s.svr)
+}
+
+func (s *testAdminSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testAdminSuite) TestLogLevel(c *C) {
+	defer log.SetLevel(log.GetLevel())
+
+	err := postJSON(unixClient, s.urlPrefix+"/log", []byte(`{"level":"debug"}`))
+	c.Assert(err, IsNil)
+	c.Assert(log.GetLevel(), Equals, log.DebugLevel)
+	level := &logLevel{}
+	err = readJSONWithURL(s.urlPrefix+"/log", level)
+	c.Assert(err, IsNil)
+	c.Assert(level.Level, Equals, "debug")
+
+	err = postJSON(unixClient, s.urlPrefix+"/log", []byte(`{"level":"warn"}`))
+	c.Assert(err, IsNil)
+	c.Assert(log.GetLevel(), Equals, log.WarnLevel)
+
+	for _, data := range []string{`{"level":"verbose"}`, `{}`, `debug`} {
+		resp, err := unixClient.Post(s.urlPrefix+"/log", "application/json", bytes.NewBufferString(data))
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, http.StatusBadRequest, Commentf("%s", data))
+	}
+	c.Assert(log.GetLevel(), Equals, log.WarnLevel)
+}
+
+func (s *testAdminSuite) TestDropRegion(c *C) {
+	cluster := s.svr.GetRaftCluster()
+	c.Assert(cluster.GetRegionInfoByID(region.GetId()), NotNil)
+
+	testCases := []struct {
+		path   string
+		status int
+	}{
+		{fmt.Sprintf("/cache/region/%d", region.GetId()), http.StatusOK},
+		{fmt.Sprintf("/cache/region/%d", region.GetId()), http.StatusNotFound},
+		{"/cache/region/x", http.StatusBadRequest},
+		{"/cache/regions", http.StatusOK},
+	}
+	for _, t := range testCases {
+		req, err := http.NewRequest(http.MethodDelete, s.urlPrefix+t.path, nil)
+		c.Assert(err, IsNil)
+		resp, err := unixClient.Do(req)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, t.status, Commentf("%s", t.path))
+	}
+	c.Assert(cluster.GetRegionInfoByID(region.GetId()), IsNil)
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pingcap/pd/server"
+)
+
+const (
+	// auditBodyLimit is the max size of the request body in the audit log.
+	auditBodyLimit = 1024
+	// auditBodyReadLimit is the max size of the request body read to redact
+	// the secrets, only the size of a larger body is logged.
+	auditBodyReadLimit = 64 * 1024
+)
+
+// auditSecretKeys are the JSON keys whose values are redacted in the audit
+// log, they are matched case-insensitively as substrings.
+var auditSecretKeys = []string{"password", "passwd", "token", "secret", "credential", "authorization"}
+
+// authenticator rejects the mutating requests which are not authenticated if
+// the authentication is enabled, and logs who changed what for the audit. It
+// runs after the redirector, so the requests are checked by the leader.
+type authenticator struct {
+	s *server.Server
+}
+
+func newAuthenticator(s *server.Server) *authenticator {
+	return &authenticator{s: s}
+}
+
+func (h *authenticator) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		next(w, r)
+		return
+	}
+	auth := h.s.GetAuthConfig()
+	if !auth.Enabled() {
+		next(w, r)
+		return
+	}
+
+	user, ok := auth.Authenticate(r)
+	if !ok {
+		log.Warnf("audit: reject unauthenticated %s %s from %s", r.Method, r.URL.RequestURI(), remoteAddr(r))
+		w.Header().Set("WWW-Authenticate", `Basic realm="PD"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	log.Infof("audit: user %s %s %s from %s, body: %s", user, r.Method, r.URL.RequestURI(), remoteAddr(r), auditBody(r))
+	next(w, r)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// auditBody returns the request body for the audit log and keeps it for the
+// next handlers. The JSON body is logged with the secrets redacted and
+// truncated to auditBodyLimit, only the sizes of the other bodies are logged.
+func auditBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, auditBodyReadLimit+1))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), r.Body), Closer: r.Body}
+	if err != nil {
+		return fmt.Sprintf("<read error: %v>", err)
+	}
+	if len(data) > auditBodyReadLimit {
+		return fmt.Sprintf("<more than %d bytes>", auditBodyReadLimit)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+
+	// The numbers are kept as they are, such as the IDs of uint64.
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err = d.Decode(&v); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+	redacted, err := json.Marshal(redactSecrets(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+	if len(redacted) > auditBodyLimit {
+		return string(redacted[:auditBodyLimit]) + "..."
+	}
+	return string(redacted)
+}
+
+// redactSecrets replaces the values of the secret keys in the decoded JSON.
+func redactSecrets(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if isSecretKey(k) {
+				v[k] = "***"
+			} else {
+				v[k] = redactSecrets(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactSecrets(e)
+		}
+	}
+	return v
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range auditSecretKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteAddr returns the address of the client, and the member which
+// redirected the request if it is redirected.
+func remoteAddr(r *http.Request) string {
+	if name := r.Header.Get(redirectorHeader); name != "" {
+		return r.RemoteAddr + " via " + name
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testAuthSuite{})
+
+type testAuthSuite struct {
+	svr       *server.Server
+	cfg       *server.Config
+	urlPrefix string
+}
+
+func (s *testAuthSuite) SetUpSuite(c *C) {
+	s.cfg = server.NewTestSingleConfig()
+	s.cfg.Auth = server.AuthConfig{
+		Tokens: map[string]string{"t1": "ops"},
+		Users:  map[string]string{"admin": "passwd"},
+	}
+	s.svr = server.CreateServer(s.cfg)
+	c.Assert(s.svr.StartEtcd(NewHandler(s.svr)), IsNil)
+	go s.svr.Run()
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1", httpAddr, apiPrefix)
+}
+
+func (s *testAuthSuite) TearDownSuite(c *C) {
+	s.svr.Close()
+	cleanServer(s.cfg)
+}
+
+func (s *testAuthSuite) mustPost(c *C, setAuth func(r *http.Request), status int) {
+	req, err := http.NewRequest(http.MethodPost, s.urlPrefix+"/config", bytes.NewBufferString(`{"max-snapshot-count":8}`))
+	c.Assert(err, IsNil)
+	setAuth(req)
+	resp, err := unixClient.Do(req)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+	c.Assert(resp.StatusCode, Equals, status)
+}
+
+func (s *testAuthSuite) TestAuth(c *C) {
+	s.mustPost(c, func(r *http.Request) {}, http.StatusUnauthorized)
+	s.mustPost(c, func(r *http.Request) { r.Header.Set("Authorization", "Bearer t2") }, http.StatusUnauthorized)
+	s.mustPost(c, func(r *http.Request) { r.SetBasicAuth("admin", "wrong") }, http.StatusUnauthorized)
+	s.mustPost(c, func(r *http.Request) { r.Header.Set("Authorization", "Bearer t1") }, http.StatusOK)
+	s.mustPost(c, func(r *http.Request) { r.SetBasicAuth("admin", "passwd") }, http.StatusOK)
+
+	// The reads are not authenticated, and the credentials are not exposed.
+	resp, err := unixClient.Get(s.urlPrefix + "/config")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	c.Assert(bytes.Contains(body, []byte("passwd")), IsFalse)
+}
+
+func (s *testAuthSuite) TestAuditBody(c *C) {
+	large := `{"keys":"` + strings.Repeat("k", auditBodyLimit) + `"}`
+	tbl := []struct {
+		body  string
+		audit string
+	}{
+		{"", ""},
+		{`{"max-snapshot-count":8, "store_id":18446744073709551615}`, `{"max-snapshot-count":8,"store_id":18446744073709551615}`},
+		{`{"user":"a","Password":"p","nested":[{"token":"t"}]}`, `{"Password":"***","nested":[{"token":"***"}],"user":"a"}`},
+		{large, large[:auditBodyLimit] + "..."},
+		{"a=b", "<3 bytes>"},
+		{strings.Repeat(" ", auditBodyReadLimit+1), fmt.Sprintf("<more than %d bytes>", auditBodyReadLimit)},
+	}
+	for _, t := range tbl {
+		r := httptest.NewRequest(http.MethodPost, "/pd/api/v1/config", strings.NewReader(t.body))
+		c.Assert(auditBody(r), Equals, t.audit)
+		// The body is kept for the handlers.
+		body, err := ioutil.ReadAll(r.Body)
+		c.Assert(err, IsNil)
+		c.Assert(string(body), Equals, t.body)
+	}
+}
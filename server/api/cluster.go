@@ -37,10 +37,13 @@ func (h *clusterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *clusterHandler) GetClusterStatus(w http.ResponseWriter, r *http.Request) {
-	status, err := h.svr.GetClusterStatus()
+	status, err := h.svr.GetRaftClusterStatus()
 	if err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if cluster := h.svr.GetRaftCluster(); cluster != nil {
+		status.WarmUp = cluster.GetWarmUpStatus()
+	}
 	h.rd.JSON(w, http.StatusOK, status)
 }
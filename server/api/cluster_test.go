@@ -18,7 +18,7 @@ import (
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/server"
 )
 
@@ -51,7 +51,8 @@ func (s *testClusterInfo) TestCluster(c *C) {
 
 	c2 := &metapb.Cluster{}
 	r := server.ReplicationConfig{MaxReplicas: 6}
-	s.svr.SetReplicationConfig(r)
+	err = s.svr.SetReplicationConfig(r)
+	c.Assert(err, IsNil)
 	err = readJSONWithURL(url, c2)
 	c.Assert(err, IsNil)
 
@@ -64,9 +65,20 @@ func (s *testClusterInfo) TestGetClusterStatus(c *C) {
 	status := server.ClusterStatus{}
 	err := readJSONWithURL(url, &status)
 	c.Assert(status.RaftBootstrapTime.IsZero(), IsTrue)
+	c.Assert(status.IsInitialized, IsFalse)
+	c.Assert(status.ReplicationStatus, IsNil)
+	c.Assert(status.WarmUp, IsNil)
 	now := time.Now()
 	mustBootstrapCluster(c, s.svr)
 	err = readJSONWithURL(url, &status)
 	c.Assert(err, IsNil)
 	c.Assert(status.RaftBootstrapTime.After(now), IsTrue)
+	// The bootstrapped region has 1 peer, less than max replicas.
+	c.Assert(status.IsInitialized, IsFalse)
+	c.Assert(status.ReplicationStatus.UnderReplicatedRegions, Equals, 1)
+	c.Assert(status.StoreCounts, DeepEquals, map[string]int{"Up": 1})
+	// The bootstrapped region has not heartbeated.
+	c.Assert(status.WarmUp.Ready, IsFalse)
+	c.Assert(status.WarmUp.RegionCount, Equals, 1)
+	c.Assert(status.WarmUp.ActiveRegions, Equals, 0)
 }
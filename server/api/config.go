@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
@@ -56,9 +57,7 @@ func (h *confHandler) Post(w http.ResponseWriter, r *http.Request) {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	h.svr.SetScheduleConfig(config.Schedule)
-	h.svr.SetReplicationConfig(config.Replication)
-	h.rd.JSON(w, http.StatusOK, nil)
+	h.applyConfig(w, r, &config.Schedule, &config.Replication)
 }
 
 func (h *confHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
@@ -73,8 +72,7 @@ func (h *confHandler) SetSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.svr.SetScheduleConfig(*config)
-	h.rd.JSON(w, http.StatusOK, nil)
+	h.applyConfig(w, r, config, nil)
 }
 
 func (h *confHandler) GetReplication(w http.ResponseWriter, r *http.Request) {
@@ -89,6 +87,34 @@ func (h *confHandler) SetReplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.svr.SetReplicationConfig(*config)
+	h.applyConfig(w, r, nil, config)
+}
+
+// applyConfig validates and applies the config change, a nil config means it
+// is not changed. If `dry_run` is set, nothing is applied and the changes are
+// returned.
+func (h *confHandler) applyConfig(w http.ResponseWriter, r *http.Request, schedule *server.ScheduleConfig, rep *server.ReplicationConfig) {
+	result, err := h.svr.CheckConfig(schedule, rep)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dryRun {
+		h.rd.JSON(w, http.StatusOK, result)
+		return
+	}
+
+	if schedule != nil {
+		if err = h.svr.SetScheduleConfig(*schedule); err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if rep != nil {
+		if err = h.svr.SetReplicationConfig(*rep); err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 	h.rd.JSON(w, http.StatusOK, nil)
 }
@@ -14,6 +14,7 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"math/rand"
 	"net/http"
@@ -143,3 +144,40 @@ func (s *testConfigSuite) TestConfigReplication(c *C) {
 		c.Assert(*rc, DeepEquals, *rc3)
 	}
 }
+
+func (s *testConfigSuite) TestConfigDryRun(c *C) {
+	cfgs, _, clean := mustNewCluster(c, 1)
+	defer clean()
+
+	parts := []string{cfgs[0].ClientUrls, apiPrefix, "/api/v1/config"}
+	addr := mustUnixAddrToHTTPAddr(c, strings.Join(parts, ""))
+	resp, err := s.hc.Get(addr)
+	c.Assert(err, IsNil)
+	cfg := &server.Config{}
+	err = readJSON(resp.Body, cfg)
+	c.Assert(err, IsNil)
+
+	postData, err := json.Marshal(map[string]int{"region-schedule-limit": 10})
+	c.Assert(err, IsNil)
+	resp, err = s.hc.Post(addr+"?dry_run=true", "application/json", bytes.NewBuffer(postData))
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	result := &server.ConfigCheckResult{}
+	err = readJSON(resp.Body, result)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 1)
+	c.Assert(result.Changes[0].Name, Equals, "region-schedule-limit")
+
+	// Invalid config is rejected.
+	postData, err = json.Marshal(map[string]int{"max-replicas": 0})
+	c.Assert(err, IsNil)
+	err = postJSON(s.hc, addr, postData)
+	c.Assert(err, NotNil)
+
+	resp, err = s.hc.Get(addr)
+	c.Assert(err, IsNil)
+	newCfg := &server.Config{}
+	err = readJSON(resp.Body, newCfg)
+	c.Assert(err, IsNil)
+	c.Assert(newCfg, DeepEquals, cfg)
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type diagnoseHandler struct {
+	*server.Handler
+	r *render.Render
+}
+
+func newDiagnoseHandler(handler *server.Handler, r *render.Render) *diagnoseHandler {
+	return &diagnoseHandler{
+		Handler: handler,
+		r:       r,
+	}
+}
+
+// Region explains why the region is or isn't scheduled, nothing is scheduled
+// by it.
+func (h *diagnoseHandler) Region(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	diagnosis, err := h.DiagnoseRegion(id)
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, diagnosis)
+}
+
+// Store returns the filters which keep the store from being a source or a
+// target of scheduling.
+func (h *diagnoseHandler) Store(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	diagnosis, err := h.DiagnoseStore(id)
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, diagnosis)
+}
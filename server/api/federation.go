@@ -0,0 +1,55 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type federationHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newFederationHandler(svr *server.Server, rd *render.Render) *federationHandler {
+	return &federationHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+type logicalClusterInfo struct {
+	ClusterID uint64 `json:"cluster_id"`
+}
+
+func (h *federationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ids := h.svr.GetLogicalClusters()
+	clusters := make([]*logicalClusterInfo, 0, len(ids))
+	for _, id := range ids {
+		clusters = append(clusters, &logicalClusterInfo{ClusterID: id})
+	}
+	h.rd.JSON(w, http.StatusOK, clusters)
+}
+
+func (h *federationHandler) Post(w http.ResponseWriter, r *http.Request) {
+	id, err := h.svr.CreateLogicalCluster()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, &logicalClusterInfo{ClusterID: id})
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"github.com/pingcap/pd/server"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const gatewayPrefix = "/rpc/v1"
+
+// gatewayMethod transcodes a HTTP/JSON request to a unary PD gRPC call.
+type gatewayMethod struct {
+	path string
+	call func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error)
+}
+
+// The read-only methods served by the gateway. The request is the JSON form
+// of the gRPC request in the body of POST, and the header can be omitted.
+var gatewayMethods = []gatewayMethod{
+	{"members", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.GetMembersRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		return svr.GetPDServer().GetMembers(ctx, &req)
+	}},
+	{"store", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.GetStoreRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		req.Header = gatewayHeader(svr, req.Header)
+		return svr.GetPDServer().GetStore(ctx, &req)
+	}},
+	{"region", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.GetRegionRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		req.Header = gatewayHeader(svr, req.Header)
+		return svr.GetPDServer().GetRegion(ctx, &req)
+	}},
+	{"region/id", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.GetRegionByIDRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		req.Header = gatewayHeader(svr, req.Header)
+		return svr.GetPDServer().GetRegionByID(ctx, &req)
+	}},
+	{"regions/scan", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.ScanRegionsRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		req.Header = gatewayHeader(svr, req.Header)
+		return svr.GetPDServer().ScanRegions(ctx, &req)
+	}},
+	{"config", func(ctx context.Context, svr *server.Server, dec runtime.Decoder) (proto.Message, error) {
+		var req pdpb.GetClusterConfigRequest
+		if err := decodeGatewayRequest(dec, &req); err != nil {
+			return nil, err
+		}
+		req.Header = gatewayHeader(svr, req.Header)
+		return svr.GetPDServer().GetClusterConfig(ctx, &req)
+	}},
+}
+
+// newGatewayHandler serves the PD gRPC service over HTTP/JSON like
+// grpc-gateway, the calls are made to the local service directly.
+func newGatewayHandler(prefix string, svr *server.Server) http.Handler {
+	mux := runtime.NewServeMux()
+	for _, m := range gatewayMethods {
+		call := m.call
+		pattern := newGatewayPattern(prefix + gatewayPrefix + "/" + m.path)
+		mux.Handle("POST", pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+			ctx, cancel := context.WithCancel(req.Context())
+			defer cancel()
+			inbound, outbound := runtime.MarshalerForRequest(mux, req)
+			resp, err := call(ctx, svr, inbound.NewDecoder(req.Body))
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, req, err)
+				return
+			}
+			runtime.ForwardResponseMessage(ctx, mux, outbound, w, req, resp)
+		})
+	}
+	return mux
+}
+
+func newGatewayPattern(path string) runtime.Pattern {
+	var (
+		ops  []int
+		pool []string
+	)
+	for _, c := range strings.Split(strings.Trim(path, "/"), "/") {
+		ops = append(ops, int(utilities.OpLitPush), len(pool))
+		pool = append(pool, c)
+	}
+	return runtime.MustPattern(runtime.NewPattern(1, ops, pool, ""))
+}
+
+// decodeGatewayRequest decodes the request, an empty body is an empty request.
+func decodeGatewayRequest(dec runtime.Decoder, req proto.Message) error {
+	if err := dec.Decode(req); err != nil && err != io.EOF {
+		return grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
+
+// gatewayHeader fills the cluster ID for the clients which don't know it.
+func gatewayHeader(svr *server.Server, header *pdpb.RequestHeader) *pdpb.RequestHeader {
+	if header == nil {
+		header = &pdpb.RequestHeader{}
+	}
+	if header.GetClusterId() == 0 {
+		header.ClusterId = svr.ClusterID()
+	}
+	return header
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"github.com/pingcap/pd/server"
+	"golang.org/x/net/context"
+)
+
+var _ = Suite(&testGatewaySuite{})
+
+type testGatewaySuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+	cli       *http.Client
+}
+
+func (s *testGatewaySuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s%s", httpAddr, apiPrefix, gatewayPrefix)
+	s.cli = newUnixSocketClient()
+
+	mustBootstrapCluster(c, s.svr)
+	grpcPDClient := mustNewGrpcClient(c, s.svr.GetAddr())
+	regionHeartbeat, err := grpcPDClient.RegionHeartbeat(context.Background())
+	c.Assert(err, IsNil)
+	r := newTestRegionInfo(2, 1, []byte("a"), []byte("b"))
+	mustRegionHeartBeat(c, regionHeartbeat, s.svr.ClusterID(), r)
+}
+
+func (s *testGatewaySuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testGatewaySuite) call(c *C, path, body string, resp proto.Message) int {
+	res, err := s.cli.Post(s.urlPrefix+path, "application/json", strings.NewReader(body))
+	c.Assert(err, IsNil)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		c.Assert(jsonpb.Unmarshal(res.Body, resp), IsNil)
+	}
+	return res.StatusCode
+}
+
+func (s *testGatewaySuite) TestGateway(c *C) {
+	members := &pdpb.GetMembersResponse{}
+	c.Assert(s.call(c, "/members", "", members), Equals, http.StatusOK)
+	c.Assert(members.GetLeader().GetName(), Equals, s.svr.Name())
+
+	// The header is filled if it's omitted.
+	region := &pdpb.GetRegionResponse{}
+	c.Assert(s.call(c, "/region", `{"region_key": "YQ=="}`, region), Equals, http.StatusOK)
+	c.Assert(region.GetRegion().GetId(), Equals, uint64(2))
+	c.Assert(region.GetHeader().GetClusterId(), Equals, s.svr.ClusterID())
+
+	region = &pdpb.GetRegionResponse{}
+	c.Assert(s.call(c, "/region/id", `{"region_id": 2}`, region), Equals, http.StatusOK)
+	c.Assert(string(region.GetRegion().GetEndKey()), Equals, "b")
+
+	scan := &pdpb.ScanRegionsResponse{}
+	c.Assert(s.call(c, "/regions/scan", `{"start_key": "YQ==", "limit": 10}`, scan), Equals, http.StatusOK)
+	c.Assert(scan.GetRegionMetas(), HasLen, 1)
+	c.Assert(scan.GetRegionMetas()[0].GetId(), Equals, uint64(2))
+	c.Assert(scan.GetLeaders(), HasLen, 1)
+
+	store := &pdpb.GetStoreResponse{}
+	c.Assert(s.call(c, "/store", `{"store_id": 1}`, store), Equals, http.StatusOK)
+	c.Assert(store.GetStore().GetId(), Equals, uint64(1))
+
+	body := fmt.Sprintf(`{"header": {"cluster_id": %d}}`, s.svr.ClusterID()+1)
+	c.Assert(s.call(c, "/config", body, nil), Not(Equals), http.StatusOK)
+	c.Assert(s.call(c, "/region", `{"region_id": "x"`, nil), Equals, http.StatusBadRequest)
+	c.Assert(s.call(c, "/unknown", "", nil), Equals, http.StatusNotFound)
+}
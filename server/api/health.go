@@ -0,0 +1,44 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type healthHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newHealthHandler(svr *server.Server, rd *render.Render) *healthHandler {
+	return &healthHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// ServeHTTP returns whether the etcd cluster has a quorum, the leader, and
+// whether each member is reachable.
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	health, err := h.svr.GetClusterHealth()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, health)
+}
@@ -75,3 +75,31 @@ func (h *historyHandler) GetOperatorsOfKind(w http.ResponseWriter, r *http.Reque
 
 	h.r.JSON(w, http.StatusOK, ops[:limit])
 }
+
+// GetRecords returns the operators which ended in the time range with their
+// outcomes. The time range is given by `start_time` and `end_time` in unix
+// seconds and defaults to the last hour, `region_id` can be used to only show
+// the operators of a region.
+func (h *historyHandler) GetRecords(w http.ResponseWriter, r *http.Request) {
+	start, end, err := parseTimeRange(r)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var regionID uint64
+	if v := r.URL.Query().Get("region_id"); len(v) != 0 {
+		regionID, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	records, err := h.GetOperatorRecords(regionID, start, end)
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, records)
+}
@@ -19,7 +19,7 @@ import (
 	"net/http"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 	"golang.org/x/net/context"
 )
@@ -116,3 +116,39 @@ func (s *testHistorySuite) TestHistroyOperators(c *C) {
 		c.Assert(len(res), Equals, t.result)
 	}
 }
+
+func (s *testHistorySuite) TestOperatorRecords(c *C) {
+	err := addTransferLeaderOperator(s.cli, s.urlPrefix, 2, 1)
+	c.Assert(err, IsNil)
+	url := fmt.Sprintf("%s/operators/2", s.urlPrefix)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	c.Assert(err, IsNil)
+	resp, err := s.cli.Do(req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	resp.Body.Close()
+
+	url = fmt.Sprintf("%s/history/records?region_id=2", s.urlPrefix)
+	resp, err = s.cli.Get(url)
+	c.Assert(err, IsNil)
+	var records []map[string]interface{}
+	err = readJSON(resp.Body, &records)
+	c.Assert(err, IsNil)
+	// The operators of other tests may be replaced by this one.
+	c.Assert(len(records), Greater, 0)
+	c.Assert(records[len(records)-1]["outcome"], Equals, "canceled")
+
+	url = fmt.Sprintf("%s/history/records?region_id=3", s.urlPrefix)
+	resp, err = s.cli.Get(url)
+	c.Assert(err, IsNil)
+	records = nil
+	err = readJSON(resp.Body, &records)
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 0)
+
+	url = fmt.Sprintf("%s/history/records?region_id=x", s.urlPrefix)
+	resp, err = s.cli.Get(url)
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+	resp.Body.Close()
+}
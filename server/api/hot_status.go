@@ -15,6 +15,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
@@ -32,10 +34,99 @@ func newHotStatusHandler(handler *server.Handler, rd *render.Render) *hotStatusH
 	}
 }
 
-func (h *hotStatusHandler) GetHotRegions(w http.ResponseWriter, r *http.Request) {
-	h.rd.JSON(w, http.StatusOK, h.GetHotWriteRegions())
+// GetHotWriteRegions returns the write hot regions of each store as the peer
+// and as the leader.
+func (h *hotStatusHandler) GetHotWriteRegions(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.Handler.GetHotWriteRegions())
 }
 
+// GetHotReadRegions returns the read hot regions of each store as the leader.
+func (h *hotStatusHandler) GetHotReadRegions(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.Handler.GetHotReadRegions())
+}
+
+// GetHotStores returns the written flow and the read hot flow of the stores.
 func (h *hotStatusHandler) GetHotStores(w http.ResponseWriter, r *http.Request) {
-	h.rd.JSON(w, http.StatusOK, h.GetHotWriteStores())
+	h.rd.JSON(w, http.StatusOK, h.Handler.GetHotStores())
+}
+
+const defaultHotRegionHistoryRange = time.Hour
+
+// parseTimeRange parses the time range given by `start_time` and `end_time`
+// in unix seconds, it defaults to the last hour.
+func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	end := time.Now()
+	if v := query.Get("end_time"); len(v) != 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = time.Unix(ts, 0)
+	}
+	start := end.Add(-defaultHotRegionHistoryRange)
+	if v := query.Get("start_time"); len(v) != 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = time.Unix(ts, 0)
+	}
+	return start, end, nil
+}
+
+// GetHistory returns the saved hot region snapshots. The time range
+// is given by `start_time` and `end_time` in unix seconds and defaults to the
+// last hour, `store_id` can be used to only show the statistics of a store.
+func (h *hotStatusHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	start, end, err := parseTimeRange(r)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var storeID uint64
+	if v := r.URL.Query().Get("store_id"); len(v) != 0 {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		storeID = id
+	}
+
+	histories, err := h.GetHotRegionHistory(start, end, storeID)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, histories)
+}
+
+// GetKeyHeatmap returns the read and write flow aggregated by key ranges over
+// time. The time range is the same as GetHistory, `buckets` limits the
+// number of key ranges.
+func (h *hotStatusHandler) GetKeyHeatmap(w http.ResponseWriter, r *http.Request) {
+	start, end, err := parseTimeRange(r)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var buckets int
+	if v := r.URL.Query().Get("buckets"); len(v) != 0 {
+		buckets, err = strconv.Atoi(v)
+		if err != nil || buckets <= 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid buckets")
+			return
+		}
+	}
+
+	heatmap, err := h.GetHeatmap(start, end, buckets)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, heatmap)
 }
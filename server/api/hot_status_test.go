@@ -0,0 +1,65 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testHotStatusSuite{})
+
+type testHotStatusSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testHotStatusSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1/hotspot", httpAddr, apiPrefix)
+
+	mustBootstrapCluster(c, s.svr)
+}
+
+func (s *testHotStatusSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testHotStatusSuite) TestGetHotStatus(c *C) {
+	for _, path := range []string{"/regions", "/regions/write"} {
+		regions := &server.StoreHotRegionInfos{}
+		err := readJSONWithURL(s.urlPrefix+path, regions)
+		c.Assert(err, IsNil)
+		c.Assert(regions.AsPeer, NotNil)
+		c.Assert(regions.AsLeader, NotNil)
+	}
+
+	readRegions := make(map[uint64]*server.HotRegionsStat)
+	err := readJSONWithURL(s.urlPrefix+"/regions/read", &readRegions)
+	c.Assert(err, IsNil)
+	c.Assert(readRegions, HasLen, 0)
+
+	stores := &server.StoreHotStats{}
+	err = readJSONWithURL(s.urlPrefix+"/stores", stores)
+	c.Assert(err, IsNil)
+	c.Assert(stores.BytesWritten, NotNil)
+	c.Assert(stores.HotBytesRead, NotNil)
+}
@@ -18,7 +18,7 @@ import (
 	"regexp"
 
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
 )
@@ -17,7 +17,7 @@ import (
 	"fmt"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/server"
 )
 
@@ -16,11 +16,13 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/juju/errors"
 	"github.com/pingcap/pd/pkg/etcdutil"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
 )
@@ -39,6 +41,12 @@ func newMemberListHandler(svr *server.Server, rd *render.Render) *memberListHand
 	}
 }
 
+// memberInfo is the member with its leader priority.
+type memberInfo struct {
+	*pdpb.Member
+	LeaderPriority int `json:"leader_priority"`
+}
+
 func (h *memberListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	client := h.svr.GetClient()
 
@@ -47,53 +55,130 @@ func (h *memberListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	ret := make(map[string][]*pdpb.Member)
-	ret["members"] = members
+	infos := make([]*memberInfo, 0, len(members))
+	for _, m := range members {
+		priority, err := h.svr.GetMemberLeaderPriority(m.GetMemberId())
+		if err != nil {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		infos = append(infos, &memberInfo{Member: m, LeaderPriority: priority})
+	}
+	ret := make(map[string][]*memberInfo)
+	ret["members"] = infos
 	h.rd.JSON(w, http.StatusOK, ret)
 }
 
-type memberDeleteHandler struct {
+type memberHandler struct {
 	svr *server.Server
 	rd  *render.Render
 }
 
-func newMemberDeleteHandler(svr *server.Server, rd *render.Render) *memberDeleteHandler {
-	return &memberDeleteHandler{
+func newMemberHandler(svr *server.Server, rd *render.Render) *memberHandler {
+	return &memberHandler{
 		svr: svr,
 		rd:  rd,
 	}
 }
 
-func (h *memberDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	client := h.svr.GetClient()
+// getMemberID returns the etcd ID of the member named name, 0 if not found.
+func (h *memberHandler) getMemberID(name string) (uint64, error) {
+	listResp, err := etcdutil.ListEtcdMembers(h.svr.GetClient())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, m := range listResp.Members {
+		if name == m.Name {
+			return m.ID, nil
+		}
+	}
+	return 0, nil
+}
 
-	// step 1. get etcd id
-	// TODO: GetPDMembers.
-	var id uint64
-	name := (mux.Vars(r))["name"]
-	listResp, err := etcdutil.ListEtcdMembers(client)
+// DeleteByName removes the member named by the path.
+func (h *memberHandler) DeleteByName(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	id, err := h.getMemberID(name)
 	if err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	for _, m := range listResp.Members {
-		if name == m.Name {
-			id = m.ID
+	if id == 0 {
+		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("not found, pd: %s", name))
+		return
+	}
+
+	if err = h.svr.RemoveMember(id); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, fmt.Sprintf("removed, pd: %s", name))
+}
+
+// DeleteByID removes the member of the etcd ID given by the path.
+func (h *memberHandler) DeleteByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	members, err := server.GetMembers(h.svr.GetClient())
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	found := false
+	for _, m := range members {
+		if m.GetMemberId() == id {
+			found = true
 			break
 		}
 	}
+	if !found {
+		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("not found, pd: %d", id))
+		return
+	}
+
+	if err = h.svr.RemoveMember(id); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, fmt.Sprintf("removed, pd: %d", id))
+}
+
+type leaderPriority struct {
+	LeaderPriority *int `json:"leader-priority"`
+}
+
+// SetLeaderPriority sets the leader priority of the member named by the path,
+// the leader transfers the leadership to the reachable member of the highest
+// priority if it's higher than the leader's.
+func (h *memberHandler) SetLeaderPriority(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	input := &leaderPriority{}
+	if err := readJSON(r.Body, input); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.LeaderPriority == nil {
+		h.rd.JSON(w, http.StatusBadRequest, "leader-priority is not set")
+		return
+	}
+	id, err := h.getMemberID(name)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	if id == 0 {
 		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("not found, pd: %s", name))
 		return
 	}
 
-	// step 2. remove member by id
-	_, err = etcdutil.RemoveEtcdMember(client, id)
-	if err != nil {
+	if err = h.svr.SetMemberLeaderPriority(id, *input.LeaderPriority); err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	h.rd.JSON(w, http.StatusOK, fmt.Sprintf("removed, pd: %s", name))
+	h.rd.JSON(w, http.StatusOK, nil)
 }
 
 type leaderHandler struct {
@@ -117,3 +202,23 @@ func (h *leaderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	h.rd.JSON(w, http.StatusOK, leader)
 }
+
+// Resign lets the leader resign, the next leader is elected from the other
+// members.
+func (h *leaderHandler) Resign(w http.ResponseWriter, r *http.Request) {
+	if err := h.svr.ResignLeader(""); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// Transfer lets the leader resign and transfers the leadership to the member
+// given by the path.
+func (h *leaderHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	if err := h.svr.ResignLeader(mux.Vars(r)["next_leader"]); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
@@ -24,7 +24,7 @@ import (
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 )
 
@@ -183,3 +183,136 @@ func (s *testMemberAPISuite) TestMemberLeader(c *C) {
 	c.Assert(got.GetClientUrls(), DeepEquals, leader.GetClientUrls())
 	c.Assert(got.GetMemberId(), Equals, leader.GetMemberId())
 }
+
+func waitOtherLeader(c *C, svrs []*server.Server, old *server.Server) *server.Server {
+	for i := 0; i < 100; i++ {
+		for _, svr := range svrs {
+			if svr != old && svr.IsLeader() {
+				return svr
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Fatal("the leader is not changed")
+	return nil
+}
+
+func (s *testMemberAPISuite) TestLeaderResign(c *C) {
+	cfgs, svrs, clean := mustNewCluster(c, 3)
+	defer clean()
+
+	leader := mustWaitLeader(c, svrs)
+	var next *server.Server
+	for _, svr := range svrs {
+		if svr != leader {
+			next = svr
+		}
+	}
+	urlPrefix := mustUnixAddrToHTTPAddr(c, cfgs[0].ClientUrls+apiPrefix+"/api/v1/leader")
+
+	// The leadership is transferred to the given member.
+	err := postJSON(s.hc, fmt.Sprintf("%s/transfer/%s", urlPrefix, next.Name()), nil)
+	c.Assert(err, IsNil)
+	c.Assert(waitOtherLeader(c, svrs, leader), Equals, next)
+
+	err = postJSON(s.hc, urlPrefix+"/resign", nil)
+	c.Assert(err, IsNil)
+	waitOtherLeader(c, svrs, next)
+
+	err = postJSON(s.hc, urlPrefix+"/transfer/unknown", nil)
+	c.Assert(err, NotNil)
+}
+
+func (s *testMemberAPISuite) TestMemberLeaderPriority(c *C) {
+	cfgs, svrs, clean := mustNewCluster(c, 3)
+	defer clean()
+
+	leader := mustWaitLeader(c, svrs)
+	urlPrefix := mustUnixAddrToHTTPAddr(c, cfgs[0].ClientUrls+apiPrefix+"/api/v1/members")
+	// The priority is lower than the leader's, so the leader is kept.
+	err := postJSON(s.hc, urlPrefix+"/"+leader.Name()+"/leader-priority", []byte(`{"leader-priority": 5}`))
+	c.Assert(err, IsNil)
+	priority, err := leader.GetMemberLeaderPriority(leader.ID())
+	c.Assert(err, IsNil)
+	c.Assert(priority, Equals, 5)
+
+	got := make(map[string][]*memberInfo)
+	err = readJSONWithURL(urlPrefix, &got)
+	c.Assert(err, IsNil)
+	c.Assert(got["members"], HasLen, 3)
+	for _, m := range got["members"] {
+		if m.GetName() == leader.Name() {
+			c.Assert(m.LeaderPriority, Equals, 5)
+		} else {
+			c.Assert(m.LeaderPriority, Equals, 0)
+		}
+	}
+
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodPost, "/unknown/leader-priority", `{"leader-priority": 1}`, http.StatusNotFound},
+		{http.MethodPost, "/" + leader.Name() + "/leader-priority", `{}`, http.StatusBadRequest},
+		{http.MethodPost, "/" + leader.Name() + "/leader-priority", `{"leader-priority": "1"}`, http.StatusBadRequest},
+		{http.MethodDelete, "/id/x", "", http.StatusBadRequest},
+		{http.MethodDelete, "/id/1", "", http.StatusNotFound},
+		{http.MethodDelete, "/name/unknown", "", http.StatusNotFound},
+	}
+	for _, t := range testCases {
+		req, err := http.NewRequest(t.method, urlPrefix+t.path, strings.NewReader(t.body))
+		c.Assert(err, IsNil)
+		resp, err := s.hc.Do(req)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, t.status, Commentf("%s %s", t.method, t.path))
+	}
+}
+
+func (s *testMemberAPISuite) getHealth(c *C, clientURL string) *server.ClusterHealth {
+	addr := mustUnixAddrToHTTPAddr(c, clientURL+apiPrefix+"/api/v1/health")
+	health := &server.ClusterHealth{}
+	err := readJSONWithURL(addr, health)
+	c.Assert(err, IsNil)
+	return health
+}
+
+func (s *testMemberAPISuite) TestMemberHealth(c *C) {
+	cfgs, svrs, clean := mustNewCluster(c, 3)
+	defer clean()
+
+	leader := mustWaitLeader(c, svrs)
+	var follower, down *server.Server
+	for _, svr := range svrs {
+		if svr == leader {
+			continue
+		}
+		if follower == nil {
+			follower = svr
+		} else {
+			down = svr
+		}
+	}
+	health := s.getHealth(c, leader.GetConfig().ClientUrls)
+	c.Assert(health.Quorum, IsTrue)
+	c.Assert(health.Leader, Equals, leader.Name())
+	c.Assert(health.Members, HasLen, len(cfgs))
+	for _, member := range health.Members {
+		c.Assert(member.Reachable, IsTrue)
+		c.Assert(member.IsLeader, Equals, member.Name == leader.Name())
+	}
+
+	// The follower serves the health without redirecting to the leader, it
+	// hasn't connected to the closed member before.
+	down.Close()
+	health = s.getHealth(c, follower.GetConfig().ClientUrls)
+	c.Assert(health.Quorum, IsTrue)
+	for _, member := range health.Members {
+		c.Assert(member.Reachable, Equals, member.Name != down.Name())
+		if !member.Reachable {
+			c.Assert(member.Error, Not(Equals), "")
+		}
+	}
+}
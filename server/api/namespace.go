@@ -0,0 +1,68 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type namespaceHandler struct {
+	*server.Handler
+	r *render.Render
+}
+
+func newNamespaceHandler(handler *server.Handler, r *render.Render) *namespaceHandler {
+	return &namespaceHandler{
+		Handler: handler,
+		r:       r,
+	}
+}
+
+func (h *namespaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	namespaces, err := h.GetNamespaces()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, namespaces)
+}
+
+// Post adds or updates a namespace, such as {"name": "tenant1", "ranges":
+// [{"start_key": "dDE=", "end_key": "dDI="}], "store_ids": [1, 2, 3],
+// "max_replicas": 3}, the keys are base64 encoded.
+func (h *namespaceHandler) Post(w http.ResponseWriter, r *http.Request) {
+	namespace := &server.Namespace{}
+	if err := readJSON(r.Body, namespace); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.PutNamespace(namespace); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+func (h *namespaceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if err := h.RemoveNamespace(name); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
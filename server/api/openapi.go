@@ -0,0 +1,172 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"github.com/urfave/negroni"
+)
+
+// The OpenAPI (Swagger 2.0) description of the HTTP API, which is generated
+// from the routes, so it's always in sync with them.
+
+type openAPISpec struct {
+	Swagger  string                                 `json:"swagger"`
+	Info     openAPIInfo                            `json:"info"`
+	BasePath string                                 `json:"basePath"`
+	Paths    map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+}
+
+type openAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// openAPIMethods are the methods probed on each route.
+var openAPIMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// pathVarRegexp matches the variables like {id} or {key:.+} in the paths.
+var pathVarRegexp = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+type openAPIHandler struct {
+	routers []*mux.Router
+	rd      *render.Render
+}
+
+func newOpenAPIHandler(rd *render.Render, routers ...*mux.Router) *openAPIHandler {
+	return &openAPIHandler{
+		routers: routers,
+		rd:      rd,
+	}
+}
+
+// ServeHTTP returns the OpenAPI description of the /pd/api/v1 endpoints.
+func (h *openAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	spec, err := h.generate()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, spec)
+}
+
+func (h *openAPIHandler) generate() (*openAPISpec, error) {
+	basePath := apiPrefix + "/api/v1"
+	spec := &openAPISpec{
+		Swagger:  "2.0",
+		Info:     openAPIInfo{Title: "PD HTTP API", Version: "v1"},
+		BasePath: basePath,
+		Paths:    make(map[string]map[string]openAPIOperation),
+	}
+	walk := func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		// The routes delegating to other routers are described by them.
+		switch route.GetHandler().(type) {
+		case nil, *mux.Router, *negroni.Negroni:
+			return nil
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(tpl, basePath+"/") {
+			return nil
+		}
+		path := pathVarRegexp.ReplaceAllString(strings.TrimPrefix(tpl, basePath), "{$1}")
+		for _, method := range routeMethods(route, tpl) {
+			if spec.Paths[path] == nil {
+				spec.Paths[path] = make(map[string]openAPIOperation)
+			}
+			spec.Paths[path][strings.ToLower(method)] = newOpenAPIOperation(route, path)
+		}
+		return nil
+	}
+	for _, router := range h.routers {
+		if err := router.Walk(walk); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// routeMethods returns the methods matched by the route, which are found by
+// matching a request of each method with the variables in the path filled.
+func routeMethods(route *mux.Route, tpl string) []string {
+	path := pathVarRegexp.ReplaceAllString(tpl, "1")
+	var methods []string
+	for _, method := range openAPIMethods {
+		req, err := http.NewRequest(method, path, nil)
+		if err != nil {
+			continue
+		}
+		if route.Match(req, &mux.RouteMatch{}) {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+func newOpenAPIOperation(route *mux.Route, path string) openAPIOperation {
+	op := openAPIOperation{
+		OperationID: handlerName(route.GetHandler()),
+		Tags:        []string{strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]},
+		Responses: map[string]openAPIResponse{
+			"200": {Description: "OK"},
+		},
+	}
+	for _, m := range pathVarRegexp.FindAllStringSubmatch(path, -1) {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Type:     "string",
+		})
+	}
+	return op
+}
+
+// handlerName returns the name like "storeHandler.Get" of the handler, which
+// is a method value or a handler type.
+func handlerName(h http.Handler) string {
+	var name string
+	if f, ok := h.(http.HandlerFunc); ok {
+		name = runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	} else {
+		name = reflect.TypeOf(h).String() + ".ServeHTTP"
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	return strings.NewReplacer("api.", "", "(", "", ")", "", "*", "").Replace(name)
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testOpenAPISuite{})
+
+type testOpenAPISuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testOpenAPISuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1", httpAddr, apiPrefix)
+}
+
+func (s *testOpenAPISuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testOpenAPISuite) TestOpenAPI(c *C) {
+	spec := &openAPISpec{}
+	err := readJSONWithURL(s.urlPrefix+"/openapi.json", spec)
+	c.Assert(err, IsNil)
+	c.Assert(spec.Swagger, Equals, "2.0")
+	c.Assert(spec.BasePath, Equals, "/pd/api/v1")
+
+	c.Assert(spec.Paths["/store/{id}"], HasLen, 2)
+	op := spec.Paths["/store/{id}"]["delete"]
+	c.Assert(op.OperationID, Equals, "storeHandler.Delete")
+	c.Assert(op.Tags, DeepEquals, []string{"store"})
+	c.Assert(op.Parameters, DeepEquals, []openAPIParameter{{Name: "id", In: "path", Required: true, Type: "string"}})
+	c.Assert(spec.Paths["/region/key/{key}"], HasKey, "get")
+	c.Assert(spec.Paths["/regions"]["get"].OperationID, Equals, "regionsHandler.ServeHTTP")
+	c.Assert(spec.Paths["/health"], HasKey, "get")
+	c.Assert(spec.Paths["/debug/pprof/symbol"], HasLen, 2)
+	c.Assert(spec.Paths["/openapi.json"], HasKey, "get")
+	c.Assert(spec.Paths, Not(HasKey), "/ping")
+}
@@ -14,10 +14,14 @@
 package api
 
 import (
+	"encoding/hex"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
 )
@@ -155,6 +159,101 @@ func (h *operatorHandler) Post(w http.ResponseWriter, r *http.Request) {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case "change-peer-role":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing store id of the peer")
+			return
+		}
+		role, ok := parsePeerRole(input["role"])
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "invalid role, should be voter, learner or observer")
+			return
+		}
+		if err := h.ChangePeerRole(uint64(regionID), uint64(storeID), role); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "add-peer":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing store id to add peer to")
+			return
+		}
+		if err := h.AddAddPeerOperator(uint64(regionID), uint64(storeID)); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "remove-peer":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		storeID, ok := input["store_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing store id to remove peer from")
+			return
+		}
+		if err := h.AddRemovePeerOperator(uint64(regionID), uint64(storeID)); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "split-region":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		policy, ok := parseCheckPolicy(input["policy"])
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "invalid policy, should be scan, approximate or usekey")
+			return
+		}
+		keys, ok := parseHexKeys(input["keys"])
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "invalid keys, should be hex encoded strings")
+			return
+		}
+		if err := h.AddSplitRegionOperator(uint64(regionID), policy, keys); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "merge-region":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		targetID, ok := input["target_region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing target region id to merge into")
+			return
+		}
+		if err := h.AddMergeRegionOperator(uint64(regionID), uint64(targetID)); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "scatter-region":
+		regionID, ok := input["region_id"].(float64)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing region id")
+			return
+		}
+		if err := h.AddScatterRegionOperator(uint64(regionID)); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	default:
 		h.r.JSON(w, http.StatusBadRequest, "unknown operator")
 		return
@@ -195,3 +294,52 @@ func parseStoreIDs(v interface{}) (map[uint64]struct{}, bool) {
 	}
 	return ids, true
 }
+
+func parsePeerRole(v interface{}) (metapb.PeerRole, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, false
+	}
+	for value, name := range metapb.PeerRole_name {
+		if strings.EqualFold(s, name) {
+			return metapb.PeerRole(value), true
+		}
+	}
+	return 0, false
+}
+
+// parseCheckPolicy parses the split policy, it's approximate if missing.
+func parseCheckPolicy(v interface{}) (pdpb.CheckPolicy, bool) {
+	if v == nil {
+		return pdpb.CheckPolicy_APPROXIMATE, true
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, false
+	}
+	value, ok := pdpb.CheckPolicy_value[strings.ToUpper(s)]
+	return pdpb.CheckPolicy(value), ok
+}
+
+func parseHexKeys(v interface{}) ([][]byte, bool) {
+	if v == nil {
+		return nil, true
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	keys := make([][]byte, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		key, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, false
+		}
+		keys = append(keys, key)
+	}
+	return keys, true
+}
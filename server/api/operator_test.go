@@ -0,0 +1,142 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/server"
+	"golang.org/x/net/context"
+)
+
+var _ = Suite(&testOperatorSuite{})
+
+type testOperatorSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testOperatorSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1", httpAddr, apiPrefix)
+
+	mustBootstrapCluster(c, s.svr)
+	mustPutStore(c, s.svr, &metapb.Store{Id: 2, Address: "localhost:2"})
+
+	client := mustNewGrpcClient(c, s.svr.GetAddr())
+	regionHeartbeat, err := client.RegionHeartbeat(context.Background())
+	c.Assert(err, IsNil)
+	mustRegionHeartBeat(c, regionHeartbeat, s.svr.ClusterID(), &server.RegionInfo{Region: region, Leader: peers[0]})
+}
+
+func (s *testOperatorSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testOperatorSuite) postOperator(c *C, input map[string]interface{}) int {
+	data, err := json.Marshal(input)
+	c.Assert(err, IsNil)
+	resp, err := unixClient.Post(s.urlPrefix+"/operators", "application/json", strings.NewReader(string(data)))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (s *testOperatorSuite) mustGetOperator(c *C, regionID uint64) map[string]interface{} {
+	var op map[string]interface{}
+	err := readJSONWithURL(fmt.Sprintf("%s/operators/%d", s.urlPrefix, regionID), &op)
+	c.Assert(err, IsNil)
+	return op
+}
+
+func (s *testOperatorSuite) mustDeleteOperator(c *C, regionID uint64) {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/operators/%d", s.urlPrefix, regionID), nil)
+	c.Assert(err, IsNil)
+	resp, err := unixClient.Do(req)
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+}
+
+func (s *testOperatorSuite) TestAddOperators(c *C) {
+	regionID := region.GetId()
+
+	testCases := []struct {
+		input map[string]interface{}
+		step  string
+	}{
+		{map[string]interface{}{"name": "add-peer", "region_id": regionID, "store_id": 2}, "add_peer"},
+		{map[string]interface{}{"name": "split-region", "region_id": regionID}, "split_region"},
+		{map[string]interface{}{"name": "split-region", "region_id": regionID, "keys": []string{"6161"}}, "split_region"},
+	}
+	for _, t := range testCases {
+		c.Assert(s.postOperator(c, t.input), Equals, http.StatusOK)
+		op := s.mustGetOperator(c, regionID)
+		c.Assert(op["name"], Equals, "admin_operator")
+		steps := op["ops"].([]interface{})
+		c.Assert(steps, HasLen, 1)
+		c.Assert(steps[0].(map[string]interface{})["name"], Equals, t.step)
+
+		var ops []interface{}
+		err := readJSONWithURL(s.urlPrefix+"/operators?kind=admin", &ops)
+		c.Assert(err, IsNil)
+		c.Assert(ops, HasLen, 1)
+
+		s.mustDeleteOperator(c, regionID)
+	}
+}
+
+func (s *testOperatorSuite) TestAddOperatorErrors(c *C) {
+	regionID := region.GetId()
+
+	testCases := []struct {
+		input  map[string]interface{}
+		status int
+	}{
+		{map[string]interface{}{"name": "unknown", "region_id": regionID}, http.StatusBadRequest},
+		{map[string]interface{}{"name": "add-peer", "region_id": regionID}, http.StatusBadRequest},
+		// The region already has a peer in store 1.
+		{map[string]interface{}{"name": "add-peer", "region_id": regionID, "store_id": 1}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "add-peer", "region_id": regionID, "store_id": 100}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "remove-peer", "region_id": regionID, "store_id": 2}, http.StatusInternalServerError},
+		// The leader can't be removed without any follower.
+		{map[string]interface{}{"name": "remove-peer", "region_id": regionID, "store_id": 1}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "split-region", "region_id": regionID, "policy": "unknown"}, http.StatusBadRequest},
+		{map[string]interface{}{"name": "split-region", "region_id": regionID, "keys": []string{"zz"}}, http.StatusBadRequest},
+		// The split key can't be the start key of the region.
+		{map[string]interface{}{"name": "split-region", "region_id": regionID, "keys": []string{""}}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "split-region", "region_id": regionID, "policy": "usekey"}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "merge-region", "region_id": regionID}, http.StatusBadRequest},
+		{map[string]interface{}{"name": "merge-region", "region_id": regionID, "target_region_id": 100}, http.StatusInternalServerError},
+		{map[string]interface{}{"name": "scatter-region", "region_id": 100}, http.StatusInternalServerError},
+	}
+	for _, t := range testCases {
+		c.Assert(s.postOperator(c, t.input), Equals, t.status, Commentf("%v", t.input))
+	}
+
+	var ops []interface{}
+	err := readJSONWithURL(s.urlPrefix+"/operators", &ops)
+	c.Assert(err, IsNil)
+	c.Assert(ops, HasLen, 0)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type placementHandler struct {
+	*server.Handler
+	r *render.Render
+}
+
+func newPlacementHandler(handler *server.Handler, r *render.Render) *placementHandler {
+	return &placementHandler{
+		Handler: handler,
+		r:       r,
+	}
+}
+
+func (h *placementHandler) List(w http.ResponseWriter, r *http.Request) {
+	placements, err := h.GetPlacements()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, placements)
+}
+
+// Post adds or updates the placement of a key range, such as
+// {"id": "t1", "start_key": "dDE=", "end_key": "dDI=", "replicas": 5,
+// "leader_label": {"key": "zone", "value": "z1"},
+// "constraints": [{"key": "disk", "value": "ssd"}], "witnesses": 1,
+// "witness_constraints": [{"key": "disk", "value": "hdd"}], "learners": 1,
+// "learner_constraints": [{"key": "engine", "value": "column"}]}, or by the
+// rules instead of the replicas, witnesses and learners, such as
+// {"id": "t1", "start_key": "dDE=", "end_key": "dDI=", "rules": [
+// {"role": "voter", "count": 3, "constraints": [{"key": "zone", "value": "z1"}]},
+// {"role": "learner", "count": 1, "constraints": [{"key": "zone", "value": "z2"}]}]},
+// the keys are base64 encoded.
+func (h *placementHandler) Post(w http.ResponseWriter, r *http.Request) {
+	placement := &server.KeyRangePlacement{}
+	if err := readJSON(r.Body, placement); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.PutPlacement(placement); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+func (h *placementHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.RemovePlacement(id); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
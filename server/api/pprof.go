@@ -0,0 +1,140 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	netpprof "net/http/pprof"
+	"runtime/pprof"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+const defaultCPUProfileSeconds = 10
+
+// zipProfiles are the profiles collected into the zip besides the CPU
+// profile, with the debug level passed to the profile.
+var zipProfiles = []struct {
+	name  string
+	debug int
+}{
+	{"heap", 0},
+	{"goroutine", 2},
+	{"mutex", 0},
+	{"block", 0},
+	{"threadcreate", 0},
+}
+
+type pprofHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+// newPprofRouter creates the router of the runtime profiles.
+func newPprofRouter(prefix string, svr *server.Server, rd *render.Render) *mux.Router {
+	h := &pprofHandler{
+		svr: svr,
+		rd:  rd,
+	}
+	router := mux.NewRouter().PathPrefix(prefix).Subrouter()
+	router.HandleFunc("/zip", h.Zip).Methods("GET")
+	router.HandleFunc("/profile", netpprof.Profile).Methods("GET")
+	router.HandleFunc("/trace", netpprof.Trace).Methods("GET")
+	router.HandleFunc("/cmdline", netpprof.Cmdline).Methods("GET")
+	router.HandleFunc("/symbol", netpprof.Symbol).Methods("GET", "POST")
+	router.HandleFunc("/{name}", h.Profile).Methods("GET")
+	return router
+}
+
+// Profile serves the named runtime profile, such as heap or goroutine.
+func (h *pprofHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if pprof.Lookup(name) == nil {
+		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("unknown profile %s", name))
+		return
+	}
+	netpprof.Handler(name).ServeHTTP(w, r)
+}
+
+// Zip collects the CPU profile of the given seconds, the other runtime
+// profiles and the config into a zip file.
+func (h *pprofHandler) Zip(w http.ResponseWriter, r *http.Request) {
+	seconds := defaultCPUProfileSeconds
+	if s := r.URL.Query().Get("seconds"); s != "" {
+		var err error
+		if seconds, err = strconv.Atoi(s); err != nil || seconds <= 0 {
+			h.rd.JSON(w, http.StatusBadRequest, fmt.Sprintf("invalid seconds %s", s))
+			return
+		}
+	}
+
+	var cpu bytes.Buffer
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="pd-debug.zip"`)
+	zw := zip.NewWriter(w)
+	if err := writeZipEntry(zw, "profile", cpu.Bytes()); err != nil {
+		log.Errorf("write cpu profile into zip error: %v", err)
+		return
+	}
+	for _, p := range zipProfiles {
+		var buf bytes.Buffer
+		err := pprof.Lookup(p.name).WriteTo(&buf, p.debug)
+		if err == nil {
+			err = writeZipEntry(zw, p.name, buf.Bytes())
+		}
+		if err != nil {
+			log.Errorf("write %s profile into zip error: %v", p.name, err)
+			return
+		}
+	}
+	cfg, err := json.MarshalIndent(h.svr.GetConfig(), "", "  ")
+	if err == nil {
+		err = writeZipEntry(zw, "config.json", cfg)
+	}
+	if err != nil {
+		log.Errorf("write config into zip error: %v", err)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		log.Errorf("close debug zip error: %v", err)
+	}
+}
+
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
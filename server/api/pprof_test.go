@@ -0,0 +1,80 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testPprofSuite{})
+
+type testPprofSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testPprofSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1/debug/pprof", httpAddr, apiPrefix)
+}
+
+func (s *testPprofSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testPprofSuite) mustGet(c *C, url string, status int) []byte {
+	resp, err := unixClient.Get(url)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, status)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	return body
+}
+
+func (s *testPprofSuite) TestProfile(c *C) {
+	body := s.mustGet(c, s.urlPrefix+"/goroutine?debug=1", http.StatusOK)
+	c.Assert(bytes.Contains(body, []byte("goroutine profile")), IsTrue)
+	c.Assert(s.mustGet(c, s.urlPrefix+"/heap", http.StatusOK), Not(HasLen), 0)
+	s.mustGet(c, s.urlPrefix+"/cmdline", http.StatusOK)
+	s.mustGet(c, s.urlPrefix+"/unknown", http.StatusNotFound)
+}
+
+func (s *testPprofSuite) TestZip(c *C) {
+	s.mustGet(c, s.urlPrefix+"/zip?seconds=0", http.StatusBadRequest)
+
+	body := s.mustGet(c, s.urlPrefix+"/zip?seconds=1", http.StatusOK)
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	c.Assert(err, IsNil)
+	files := make(map[string]struct{})
+	for _, f := range zr.File {
+		files[f.Name] = struct{}{}
+	}
+	c.Assert(files, HasKey, "profile")
+	c.Assert(files, HasKey, "goroutine")
+	c.Assert(files, HasKey, "heap")
+	c.Assert(files, HasKey, "config.json")
+}
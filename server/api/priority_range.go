@@ -0,0 +1,68 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+type priorityRangeHandler struct {
+	*server.Handler
+	r *render.Render
+}
+
+func newPriorityRangeHandler(handler *server.Handler, r *render.Render) *priorityRangeHandler {
+	return &priorityRangeHandler{
+		Handler: handler,
+		r:       r,
+	}
+}
+
+func (h *priorityRangeHandler) List(w http.ResponseWriter, r *http.Request) {
+	ranges, err := h.GetPriorityRanges()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, ranges)
+}
+
+// Post adds or updates a priority key range, such as
+// {"id": "meta", "start_key": "", "end_key": "bg=="}, the keys are base64
+// encoded. The regions in the range are checked and repaired first.
+func (h *priorityRangeHandler) Post(w http.ResponseWriter, r *http.Request) {
+	keyRange := &server.PriorityKeyRange{}
+	if err := readJSON(r.Body, keyRange); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.PutPriorityRange(keyRange); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+func (h *priorityRangeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.RemovePriorityRange(id); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, nil)
+}
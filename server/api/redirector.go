@@ -14,10 +14,13 @@
 package api
 
 import (
+	"bytes"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/ngaut/log"
 	"github.com/pingcap/pd/server"
@@ -34,12 +37,28 @@ const (
 
 type redirector struct {
 	s *server.Server
+
+	// The clients are shared by the redirected requests, so the connections
+	// to the leader are kept alive and reused. They're created on the first
+	// redirect since the TLS config is loaded when the server starts.
+	clientsOnce sync.Once
+	client      *http.Client
+	unixClient  *http.Client
 }
 
 func newRedirector(s *server.Server) *redirector {
 	return &redirector{s: s}
 }
 
+func (h *redirector) initClients() {
+	h.client = &http.Client{}
+	if tlsConfig := h.s.GetTLSConfig(); tlsConfig != nil {
+		h.client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	// Use unix socket in tests.
+	h.unixClient = &http.Client{Transport: &http.Transport{Dial: unixDial}}
+}
+
 func (h *redirector) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	if h.s.IsLeader() {
 		next(w, r)
@@ -67,7 +86,8 @@ func (h *redirector) ServeHTTP(w http.ResponseWriter, r *http.Request, next http
 		return
 	}
 
-	newCustomReverseProxies(urls).ServeHTTP(w, r)
+	h.clientsOnce.Do(h.initClients)
+	newCustomReverseProxies(urls, h.client, h.unixClient).ServeHTTP(w, r)
 }
 
 type customReverseProxies struct {
@@ -75,32 +95,41 @@ type customReverseProxies struct {
 	clients []*http.Client
 }
 
-func newCustomReverseProxies(urls []url.URL) *customReverseProxies {
+func newCustomReverseProxies(urls []url.URL, client, unixClient *http.Client) *customReverseProxies {
 	p := &customReverseProxies{}
 
 	for _, u := range urls {
-		var client *http.Client
-
-		// Use unix socket in tests.
+		c := client
 		if u.Scheme == "unix" {
 			u.Scheme = "http"
-			client = &http.Client{Transport: &http.Transport{Dial: unixDial}}
-		} else {
-			client = &http.Client{}
+			c = unixClient
 		}
 
 		p.urls = append(p.urls, u)
-		p.clients = append(p.clients, client)
+		p.clients = append(p.clients, c)
 	}
 
 	return p
 }
 
 func (p *customReverseProxies) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Read the body once, so it's sent again when retrying another URL.
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	for i, client := range p.clients {
 		r.RequestURI = ""
 		r.URL.Host = p.urls[i].Host
 		r.URL.Scheme = p.urls[i].Scheme
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
 
 		resp, err := client.Do(r)
 		if err != nil {
@@ -108,26 +137,41 @@ func (p *customReverseProxies) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			continue
 		}
 
-		b, err := ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			log.Error(err)
-			continue
-		}
-
+		// The response is streamed to the client, the other URLs can't be
+		// tried once the header is written.
 		copyHeader(w.Header(), resp.Header)
 		w.WriteHeader(resp.StatusCode)
-		if _, err := w.Write(b); err != nil {
+		if _, err := io.Copy(newFlushWriter(w), resp.Body); err != nil {
 			log.Error(err)
-			continue
 		}
-
+		resp.Body.Close()
 		return
 	}
 
 	http.Error(w, errRedirectFailed, http.StatusInternalServerError)
 }
 
+// flushWriter flushes each write, so the streamed response of the leader is
+// passed to the client without being held by the follower.
+type flushWriter struct {
+	w http.ResponseWriter
+	f http.Flusher
+}
+
+func newFlushWriter(w http.ResponseWriter) io.Writer {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		return w
+	}
+	return &flushWriter{w: w, f: f}
+}
+
+func (fw *flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	fw.f.Flush()
+	return n, err
+}
+
 func copyHeader(dst, src http.Header) {
 	for k, vv := range src {
 		for _, v := range vv {
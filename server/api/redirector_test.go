@@ -14,8 +14,13 @@
 package api
 
 import (
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	. "github.com/pingcap/check"
@@ -120,6 +125,88 @@ func (s *testRedirectorSuite) TestNotLeader(c *C) {
 	c.Assert(resp.StatusCode, Not(Equals), http.StatusOK)
 }
 
+func (s *testRedirectorSuite) TestRetryWithBody(c *C) {
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer echo.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	var urls []url.URL
+	for _, addr := range []string{closed.URL, echo.URL} {
+		u, err := url.Parse(addr)
+		c.Assert(err, IsNil)
+		urls = append(urls, *u)
+	}
+	// The body is sent again to the second URL after the first one fails.
+	r := httptest.NewRequest(http.MethodPost, "/pd/api/v1/config", strings.NewReader("body"))
+	w := httptest.NewRecorder()
+	newCustomReverseProxies(urls, &http.Client{}, nil).ServeHTTP(w, r)
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Assert(w.Body.String(), Equals, "body")
+}
+
+func (s *testRedirectorSuite) TestStream(c *C) {
+	release := make(chan struct{})
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("second"))
+	}))
+	defer leader.Close()
+	var retried int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&retried, 1)
+	}))
+	defer other.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer broken.Close()
+
+	proxy := func(addrs ...string) *httptest.Server {
+		var urls []url.URL
+		for _, addr := range addrs {
+			u, err := url.Parse(addr)
+			c.Assert(err, IsNil)
+			urls = append(urls, *u)
+		}
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			newCustomReverseProxies(urls, &http.Client{}, nil).ServeHTTP(w, r)
+		}))
+	}
+
+	// The response is passed to the client before the leader finishes it.
+	p := proxy(leader.URL)
+	defer p.Close()
+	resp, err := http.Get(p.URL)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	first := make([]byte, len("first"))
+	_, err = io.ReadFull(resp.Body, first)
+	c.Assert(err, IsNil)
+	c.Assert(string(first), Equals, "first")
+	close(release)
+	rest, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	c.Assert(string(rest), Equals, "second")
+
+	// The other URLs are not tried after the response is started.
+	p = proxy(broken.URL, other.URL)
+	defer p.Close()
+	resp, err = http.Get(p.URL)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	ioutil.ReadAll(resp.Body)
+	c.Assert(atomic.LoadInt32(&retried), Equals, int32(0))
+}
+
 func mustRequest(c *C, s *server.Server) *http.Response {
 	unixAddr := []string{s.GetAddr(), apiPrefix, "/api/v1/version"}
 	httpAddr := mustUnixAddrToHTTPAddr(c, strings.Join(unixAddr, ""))
@@ -14,11 +14,20 @@
 package api
 
 import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
 )
@@ -28,9 +37,14 @@ type regionInfo struct {
 	Leader *metapb.Peer   `json:"leader"`
 }
 
+// regionsInfo is the response of listing the regions, which is written by
+// regionsStream.
 type regionsInfo struct {
 	Count   int              `json:"count"`
 	Regions []*metapb.Region `json:"regions"`
+	// NextKey is the start_key of the next page, it's omitted after the last
+	// page.
+	NextKey []byte `json:"next_key,omitempty"`
 }
 
 type regionHandler struct {
@@ -45,6 +59,8 @@ func newRegionHandler(svr *server.Server, rd *render.Render) *regionHandler {
 	}
 }
 
+// GetRegionByID returns the region with its leader, down peers and pending
+// peers as GetRegionByID of gRPC does.
 func (h *regionHandler) GetRegionByID(w http.ResponseWriter, r *http.Request) {
 	cluster := h.svr.GetRaftCluster()
 	if cluster == nil {
@@ -56,14 +72,21 @@ func (h *regionHandler) GetRegionByID(w http.ResponseWriter, r *http.Request) {
 	regionIDStr := vars["id"]
 	regionID, err := strconv.ParseUint(regionIDStr, 10, 64)
 	if err != nil {
-		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	regionInfo := cluster.GetRegionInfoByID(regionID)
+	if regionInfo == nil {
+		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("region %d not found", regionID))
+		return
+	}
 	h.rd.JSON(w, http.StatusOK, regionInfo)
 }
 
+// GetRegionByKey returns the region which contains the key as GetRegion of
+// gRPC does. The key is escaped in the path, or hex encoded with the query
+// `format=hex`.
 func (h *regionHandler) GetRegionByKey(w http.ResponseWriter, r *http.Request) {
 	cluster := h.svr.GetRaftCluster()
 	if cluster == nil {
@@ -71,8 +94,25 @@ func (h *regionHandler) GetRegionByKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	vars := mux.Vars(r)
-	key := vars["key"]
-	regionInfo := cluster.GetRegionInfoByKey([]byte(key))
+	key := []byte(vars["key"])
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "raw":
+	case "hex":
+		var err error
+		if key, err = hex.DecodeString(vars["key"]); err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	default:
+		h.rd.JSON(w, http.StatusBadRequest, fmt.Sprintf("unknown key format %q", format))
+		return
+	}
+
+	regionInfo := cluster.GetRegionInfoByKey(key)
+	if regionInfo == nil {
+		h.rd.JSON(w, http.StatusNotFound, fmt.Sprintf("region of key %q not found", key))
+		return
+	}
 	h.rd.JSON(w, http.StatusOK, regionInfo)
 }
 
@@ -88,17 +128,185 @@ func newRegionsHandler(svr *server.Server, rd *render.Render) *regionsHandler {
 	}
 }
 
+// ServeHTTP lists the regions in key order. The optional query parameters are
+// `start_key`, the base64 encoded key to start from, `limit`, the max number
+// of the regions to return, `store_id`, to only list the regions with a peer
+// on the store, and `state`, which is one of down-peer, pending-peer,
+// miss-peer, extra-peer, offline-peer and empty-region. All the regions are
+// listed without parameters.
 func (h *regionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query, err := parseRegionQuery(r)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.queryRegions(w, r, query)
+}
+
+// CheckRegions lists the regions in the state given by the path, it takes the
+// same query parameters as listing the regions except `state`.
+func (h *regionsHandler) CheckRegions(w http.ResponseWriter, r *http.Request) {
+	query, err := parseRegionQuery(r)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query.State = mux.Vars(r)["state"]
+	h.queryRegions(w, r, query)
+}
+
+func (h *regionsHandler) queryRegions(w http.ResponseWriter, r *http.Request, query *server.RegionQuery) {
 	cluster := h.svr.GetRaftCluster()
 	if cluster == nil {
 		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
 		return
 	}
 
-	regions := cluster.GetRegions()
-	regionsInfo := &regionsInfo{
+	stream := newRegionsStream(w, r)
+	nextKey, err := cluster.WalkRegions(query, stream.writeRegion)
+	if err == nil {
+		err = stream.finish(nextKey)
+	}
+	if err != nil {
+		if !stream.started() {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Errorf("write regions error: %v", err)
+	}
+}
+
+// regionsStream writes the regions as regionsInfo one by one, so the response
+// of a large cluster is not built in memory. The regions come before the count
+// in the object, and the response is gzip compressed if the client accepts it.
+type regionsStream struct {
+	w     http.ResponseWriter
+	r     *http.Request
+	out   io.Writer
+	gz    *gzip.Writer
+	count int
+}
+
+func newRegionsStream(w http.ResponseWriter, r *http.Request) *regionsStream {
+	return &regionsStream{
+		w: w,
+		r: r,
+	}
+}
+
+func (s *regionsStream) started() bool {
+	return s.out != nil
+}
+
+// start writes the header on the first write, so an error can still be
+// returned as a normal response before any region is written.
+func (s *regionsStream) start() error {
+	s.w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	s.out = s.w
+	if strings.Contains(s.r.Header.Get("Accept-Encoding"), "gzip") {
+		s.w.Header().Set("Content-Encoding", "gzip")
+		s.gz = gzip.NewWriter(s.w)
+		s.out = s.gz
+	}
+	s.w.WriteHeader(http.StatusOK)
+	_, err := io.WriteString(s.out, `{"regions":[`)
+	return errors.Trace(err)
+}
+
+func (s *regionsStream) writeRegion(region *metapb.Region) error {
+	if !s.started() {
+		if err := s.start(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	data, err := json.Marshal(region)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.count > 0 {
+		data = append([]byte{','}, data...)
+	}
+	if _, err = s.out.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	s.count++
+	return nil
+}
+
+func (s *regionsStream) finish(nextKey []byte) error {
+	if !s.started() {
+		if err := s.start(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	tail := fmt.Sprintf(`],"count":%d`, s.count)
+	if nextKey != nil {
+		tail += fmt.Sprintf(`,"next_key":%q`, base64.StdEncoding.EncodeToString(nextKey))
+	}
+	if _, err := io.WriteString(s.out, tail+"}"); err != nil {
+		return errors.Trace(err)
+	}
+	if s.gz != nil {
+		return errors.Trace(s.gz.Close())
+	}
+	return nil
+}
+
+// defaultTopRegionsLimit is the number of the top regions returned if the
+// limit is not given.
+const defaultTopRegionsLimit = 16
+
+type topRegionsInfo struct {
+	Count   int                  `json:"count"`
+	Regions []*server.RegionInfo `json:"regions"`
+}
+
+// GetTopRegions returns the regions with the highest read flow, write flow,
+// size or conf version given by the path in descending order, the optional
+// query parameter `limit` is the number of the regions, 16 by default.
+func (h *regionsHandler) GetTopRegions(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+	limit := defaultTopRegionsLimit
+	if v := r.URL.Query().Get("limit"); len(v) != 0 {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			h.rd.JSON(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %s", v))
+			return
+		}
+	}
+	regions, err := cluster.GetTopRegions(mux.Vars(r)["by"], limit)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, &topRegionsInfo{
 		Count:   len(regions),
 		Regions: regions,
+	})
+}
+
+func parseRegionQuery(r *http.Request) (*server.RegionQuery, error) {
+	values := r.URL.Query()
+	query := &server.RegionQuery{State: values.Get("state")}
+	var err error
+	if v := values.Get("start_key"); len(v) != 0 {
+		if query.StartKey, err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if v := values.Get("limit"); len(v) != 0 {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if v := values.Get("store_id"); len(v) != 0 {
+		if query.StoreID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	return query, nil
 }
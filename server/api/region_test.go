@@ -14,11 +14,16 @@
 package api
 
 import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 	"golang.org/x/net/context"
 )
@@ -66,6 +71,8 @@ func newTestRegionInfo(regionID, storeID uint64, start, end []byte) *server.Regi
 			StartKey: start,
 			EndKey:   end,
 			Peers:    []*metapb.Peer{leader},
+			// The region is split from the bootstrapped region.
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 2},
 		},
 		Leader:       leader,
 		DownPeers:    make([]*pdpb.PeerStats, 0),
@@ -88,3 +95,153 @@ func (s *testRegionSuite) TestRegion(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(r2, DeepEquals, r)
 }
+
+func (s *testRegionSuite) TestRegionLookup(c *C) {
+	r := newTestRegionInfo(2, 1, []byte("a"), []byte("b"))
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r)
+
+	// Escaped key.
+	r1 := &server.RegionInfo{}
+	err := readJSONWithURL(fmt.Sprintf("%s/region/key/%s", s.urlPrefix, url.PathEscape("a/1")), r1)
+	c.Assert(err, IsNil)
+	c.Assert(r1, DeepEquals, r)
+
+	// Hex encoded key.
+	r2 := &server.RegionInfo{}
+	err = readJSONWithURL(fmt.Sprintf("%s/region/key/%s?format=hex", s.urlPrefix, hex.EncodeToString([]byte("a/2"))), r2)
+	c.Assert(err, IsNil)
+	c.Assert(r2, DeepEquals, r)
+
+	testCases := []struct {
+		path   string
+		status int
+	}{
+		{"/region/id/100", http.StatusNotFound},
+		{"/region/id/x", http.StatusBadRequest},
+		{"/region/key/zzz", http.StatusNotFound},
+		{"/region/key/zz?format=hex", http.StatusBadRequest},
+		{"/region/key/a?format=base32", http.StatusBadRequest},
+	}
+	for _, t := range testCases {
+		resp, err := unixClient.Get(s.urlPrefix + t.path)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, t.status, Commentf("%s", t.path))
+	}
+}
+
+func (s *testRegionSuite) TestRegions(c *C) {
+	r3 := newTestRegionInfo(3, 1, []byte("b"), []byte("c"))
+	r4 := newTestRegionInfo(4, 1, []byte("c"), []byte("d"))
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r3)
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r4)
+
+	startKey := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("b")))
+	regions := &regionsInfo{}
+	err := readJSONWithURL(fmt.Sprintf("%s/regions?start_key=%s&limit=1", s.urlPrefix, startKey), regions)
+	c.Assert(err, IsNil)
+	c.Assert(regions.Count, Equals, 1)
+	c.Assert(regions.Regions[0], DeepEquals, r3.Region)
+	c.Assert(string(regions.NextKey), Equals, "c")
+
+	regions = &regionsInfo{}
+	err = readJSONWithURL(fmt.Sprintf("%s/regions?start_key=%s&store_id=1", s.urlPrefix, startKey), regions)
+	c.Assert(err, IsNil)
+	c.Assert(regions.Regions, DeepEquals, []*metapb.Region{r3.Region, r4.Region})
+	c.Assert(regions.NextKey, IsNil)
+
+	resp, err := unixClient.Get(fmt.Sprintf("%s/regions?state=down", s.urlPrefix))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+}
+
+func (s *testRegionSuite) TestTopRegions(c *C) {
+	r5 := newTestRegionInfo(5, 1, []byte("x"), []byte("y"))
+	r5.RegionEpoch.ConfVer = 5
+	r6 := newTestRegionInfo(6, 1, []byte("y"), []byte("z"))
+	r6.RegionEpoch.ConfVer = 6
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r5)
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r6)
+
+	regions := &topRegionsInfo{}
+	err := readJSONWithURL(s.urlPrefix+"/regions/top/confver?limit=2", regions)
+	c.Assert(err, IsNil)
+	c.Assert(regions.Count, Equals, 2)
+	c.Assert(regions.Regions[0].Region, DeepEquals, r6.Region)
+	c.Assert(regions.Regions[1].Region, DeepEquals, r5.Region)
+
+	for _, path := range []string{"/regions/top/flow", "/regions/top/size?limit=0"} {
+		resp, err := unixClient.Get(s.urlPrefix + path)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, http.StatusBadRequest, Commentf("%s", path))
+	}
+}
+
+func (s *testRegionSuite) TestRegionsGzip(c *C) {
+	r3 := newTestRegionInfo(3, 1, []byte("b"), []byte("c"))
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r3)
+
+	startKey := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("b")))
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/regions?start_key=%s&limit=1", s.urlPrefix, startKey), nil)
+	c.Assert(err, IsNil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := unixClient.Do(req)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Encoding"), Equals, "gzip")
+	gz, err := gzip.NewReader(resp.Body)
+	c.Assert(err, IsNil)
+	regions := &regionsInfo{}
+	c.Assert(readJSON(gz, regions), IsNil)
+	c.Assert(regions.Count, Equals, 1)
+	c.Assert(regions.Regions[0], DeepEquals, r3.Region)
+}
+
+func (s *testRegionSuite) TestRegionStats(c *C) {
+	r3 := newTestRegionInfo(3, 1, []byte("b"), []byte("c"))
+	r4 := newTestRegionInfo(4, 1, []byte("c"), []byte("d"))
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r3)
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r4)
+
+	startKey := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("b")))
+	endKey := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("c1")))
+	stats := &server.RegionStats{}
+	err := readJSONWithURL(fmt.Sprintf("%s/stats/region?start_key=%s&end_key=%s", s.urlPrefix, startKey, endKey), stats)
+	c.Assert(err, IsNil)
+	c.Assert(stats.Count, Equals, 2)
+	c.Assert(stats.StoreLeaderCount, DeepEquals, map[uint64]int{1: 2})
+
+	resp, err := unixClient.Get(fmt.Sprintf("%s/stats/region?start_key=b", s.urlPrefix))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+}
+
+func (s *testRegionSuite) TestCheckRegions(c *C) {
+	r3 := newTestRegionInfo(3, 1, []byte("b"), []byte("c"))
+	r4 := newTestRegionInfo(4, 1, []byte("c"), []byte("d"))
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r3)
+	mustRegionHeartBeat(c, s.regionHeartbeat, s.svr.ClusterID(), r4)
+
+	// The regions only have 1 peer of the 3 replicas.
+	startKey := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("b")))
+	regions := &regionsInfo{}
+	err := readJSONWithURL(fmt.Sprintf("%s/regions/check/miss-peer?start_key=%s", s.urlPrefix, startKey), regions)
+	c.Assert(err, IsNil)
+	c.Assert(regions.Regions, DeepEquals, []*metapb.Region{r3.Region, r4.Region})
+
+	for _, state := range []string{"extra-peer", "down-peer", "pending-peer", "offline-peer", "empty-region"} {
+		regions = &regionsInfo{}
+		err = readJSONWithURL(fmt.Sprintf("%s/regions/check/%s", s.urlPrefix, state), regions)
+		c.Assert(err, IsNil)
+		c.Assert(regions.Count, Equals, 0, Commentf("%s", state))
+	}
+
+	resp, err := unixClient.Get(fmt.Sprintf("%s/regions/check/down", s.urlPrefix))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+}
@@ -32,6 +32,7 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	historyHanlder := newHistoryHandler(handler, rd)
 	router.HandleFunc("/api/v1/history", historyHanlder.GetOperators).Methods("GET")
 	router.HandleFunc("/api/v1/history/{kind}/{limit}", historyHanlder.GetOperatorsOfKind).Methods("GET")
+	router.HandleFunc("/api/v1/history/records", historyHanlder.GetRecords).Methods("GET")
 
 	operatorHandler := newOperatorHandler(handler, rd)
 	router.HandleFunc("/api/v1/operators", operatorHandler.List).Methods("GET")
@@ -39,10 +40,30 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	router.HandleFunc("/api/v1/operators/{region_id}", operatorHandler.Get).Methods("GET")
 	router.HandleFunc("/api/v1/operators/{region_id}", operatorHandler.Delete).Methods("DELETE")
 
+	placementHandler := newPlacementHandler(handler, rd)
+	router.HandleFunc("/api/v1/placements", placementHandler.List).Methods("GET")
+	router.HandleFunc("/api/v1/placements", placementHandler.Post).Methods("POST")
+	router.HandleFunc("/api/v1/placements/{id}", placementHandler.Delete).Methods("DELETE")
+
+	priorityRangeHandler := newPriorityRangeHandler(handler, rd)
+	router.HandleFunc("/api/v1/priority-ranges", priorityRangeHandler.List).Methods("GET")
+	router.HandleFunc("/api/v1/priority-ranges", priorityRangeHandler.Post).Methods("POST")
+	router.HandleFunc("/api/v1/priority-ranges/{id}", priorityRangeHandler.Delete).Methods("DELETE")
+
+	namespaceHandler := newNamespaceHandler(handler, rd)
+	router.HandleFunc("/api/v1/namespaces", namespaceHandler.List).Methods("GET")
+	router.HandleFunc("/api/v1/namespaces", namespaceHandler.Post).Methods("POST")
+	router.HandleFunc("/api/v1/namespaces/{name}", namespaceHandler.Delete).Methods("DELETE")
+
 	schedulerHandler := newSchedulerHandler(handler, rd)
 	router.HandleFunc("/api/v1/schedulers", schedulerHandler.List).Methods("GET")
 	router.HandleFunc("/api/v1/schedulers", schedulerHandler.Post).Methods("POST")
 	router.HandleFunc("/api/v1/schedulers/{name}", schedulerHandler.Delete).Methods("DELETE")
+	router.HandleFunc("/api/v1/schedulers/{name}/pause", schedulerHandler.Pause).Methods("POST")
+	router.HandleFunc("/api/v1/schedulers/{name}/pause", schedulerHandler.Resume).Methods("DELETE")
+	router.HandleFunc("/api/v1/schedule/pause", schedulerHandler.GetPauseAll).Methods("GET")
+	router.HandleFunc("/api/v1/schedule/pause", schedulerHandler.PauseAll).Methods("POST")
+	router.HandleFunc("/api/v1/schedule/pause", schedulerHandler.ResumeAll).Methods("DELETE")
 
 	router.Handle("/api/v1/cluster", newClusterHandler(svr, rd)).Methods("GET")
 	router.HandleFunc("/api/v1/cluster/status", newClusterHandler(svr, rd).GetClusterStatus).Methods("GET")
@@ -55,32 +76,79 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	router.HandleFunc("/api/v1/config/replicate", confHandler.SetReplication).Methods("POST")
 	router.HandleFunc("/api/v1/config/replicate", confHandler.GetReplication).Methods("GET")
 
+	adminHandler := newAdminHandler(svr, rd)
+	router.HandleFunc("/api/v1/admin/log", adminHandler.GetLogLevel).Methods("GET")
+	router.HandleFunc("/api/v1/admin/log", adminHandler.SetLogLevel).Methods("POST")
+	router.HandleFunc("/api/v1/admin/cache/region/{id}", adminHandler.DropCacheRegion).Methods("DELETE")
+	router.HandleFunc("/api/v1/admin/cache/regions", adminHandler.DropCacheRegions).Methods("DELETE")
+
 	storeHandler := newStoreHandler(svr, rd)
 	router.HandleFunc("/api/v1/store/{id}", storeHandler.Get).Methods("GET")
 	router.HandleFunc("/api/v1/store/{id}", storeHandler.Delete).Methods("DELETE")
-	router.Handle("/api/v1/stores", newStoresHandler(svr, rd)).Methods("GET")
+	router.HandleFunc("/api/v1/store/{id}/state", storeHandler.SetState).Methods("POST")
+	router.HandleFunc("/api/v1/store/{id}/label", storeHandler.SetLabels).Methods("POST")
+	router.HandleFunc("/api/v1/store/{id}/weight", storeHandler.SetWeight).Methods("POST")
+	router.HandleFunc("/api/v1/store/{id}/limit", storeHandler.SetLimit).Methods("POST")
+	storesHandler := newStoresHandler(svr, rd)
+	router.Handle("/api/v1/stores", storesHandler).Methods("GET")
+	router.HandleFunc("/api/v1/stores/limit", storesHandler.GetLimits).Methods("GET")
+	router.HandleFunc("/api/v1/stores/drain", newStoreDrainHandler(handler, rd).List).Methods("GET")
 
 	labelsHandler := newLabelsHandler(svr, rd)
 	router.HandleFunc("/api/v1/labels", labelsHandler.Get).Methods("GET")
 	router.HandleFunc("/api/v1/labels/stores", labelsHandler.GetStores).Methods("GET")
 
 	hotStatusHandler := newHotStatusHandler(handler, rd)
-	router.HandleFunc("/api/v1/hotspot/regions", hotStatusHandler.GetHotRegions).Methods("GET")
+	// The write hot regions are served at /hotspot/regions too for compatibility.
+	router.HandleFunc("/api/v1/hotspot/regions", hotStatusHandler.GetHotWriteRegions).Methods("GET")
+	router.HandleFunc("/api/v1/hotspot/regions/write", hotStatusHandler.GetHotWriteRegions).Methods("GET")
+	router.HandleFunc("/api/v1/hotspot/regions/read", hotStatusHandler.GetHotReadRegions).Methods("GET")
 	router.HandleFunc("/api/v1/hotspot/stores", hotStatusHandler.GetHotStores).Methods("GET")
+	router.HandleFunc("/api/v1/hotspot/history", hotStatusHandler.GetHistory).Methods("GET")
+	router.HandleFunc("/api/v1/hotspot/heatmap", hotStatusHandler.GetKeyHeatmap).Methods("GET")
+
+	tsoHandler := newTsoHandler(handler, rd)
+	router.HandleFunc("/api/v1/tso", tsoHandler.Get).Methods("GET")
+	router.HandleFunc("/api/v1/tso/decode/{ts}", tsoHandler.Decode).Methods("GET")
+	router.HandleFunc("/api/v1/tso/clients", tsoHandler.GetClients).Methods("GET")
+
+	federationHandler := newFederationHandler(svr, rd)
+	router.HandleFunc("/api/v1/federation/clusters", federationHandler.List).Methods("GET")
+	router.HandleFunc("/api/v1/federation/clusters", federationHandler.Post).Methods("POST")
+
 	router.Handle("/api/v1/events", newEventsHandler(svr, rd)).Methods("GET")
 	router.Handle("/api/v1/feed", newFeedHandler(svr, rd)).Methods("GET")
 
 	regionHandler := newRegionHandler(svr, rd)
 	router.HandleFunc("/api/v1/region/id/{id}", regionHandler.GetRegionByID).Methods("GET")
-	router.HandleFunc("/api/v1/region/key/{key}", regionHandler.GetRegionByKey).Methods("GET")
+	router.HandleFunc("/api/v1/region/key/{key:.+}", regionHandler.GetRegionByKey).Methods("GET")
+
+	regionsHandler := newRegionsHandler(svr, rd)
+	router.Handle("/api/v1/regions", regionsHandler).Methods("GET")
+	router.HandleFunc("/api/v1/regions/check/{state}", regionsHandler.CheckRegions).Methods("GET")
+	router.HandleFunc("/api/v1/regions/top/{by}", regionsHandler.GetTopRegions).Methods("GET")
+
+	statsHandler := newStatsHandler(svr, rd)
+	router.HandleFunc("/api/v1/stats/region", statsHandler.Region).Methods("GET")
 
-	router.Handle("/api/v1/regions", newRegionsHandler(svr, rd)).Methods("GET")
+	diagnoseHandler := newDiagnoseHandler(handler, rd)
+	router.HandleFunc("/api/v1/diagnose/region/{id}", diagnoseHandler.Region).Methods("GET")
+	router.HandleFunc("/api/v1/diagnose/store/{id}", diagnoseHandler.Store).Methods("GET")
 	router.Handle("/api/v1/version", newVersionHandler(rd)).Methods("GET")
 	router.Handle("/api/v1/status", newStatusHandler(rd)).Methods("GET")
 
 	router.Handle("/api/v1/members", newMemberListHandler(svr, rd)).Methods("GET")
-	router.Handle("/api/v1/members/{name}", newMemberDeleteHandler(svr, rd)).Methods("DELETE")
-	router.Handle("/api/v1/leader", newLeaderHandler(svr, rd)).Methods("GET")
+	memberHandler := newMemberHandler(svr, rd)
+	router.HandleFunc("/api/v1/members/{name}", memberHandler.DeleteByName).Methods("DELETE")
+	router.HandleFunc("/api/v1/members/name/{name}", memberHandler.DeleteByName).Methods("DELETE")
+	router.HandleFunc("/api/v1/members/id/{id}", memberHandler.DeleteByID).Methods("DELETE")
+	router.HandleFunc("/api/v1/members/{name}/leader-priority", memberHandler.SetLeaderPriority).Methods("POST")
+	leaderHandler := newLeaderHandler(svr, rd)
+	router.Handle("/api/v1/leader", leaderHandler).Methods("GET")
+	router.HandleFunc("/api/v1/leader/resign", leaderHandler.Resign).Methods("POST")
+	router.HandleFunc("/api/v1/leader/transfer/{next_leader}", leaderHandler.Transfer).Methods("POST")
+
+	router.PathPrefix(gatewayPrefix).Handler(newGatewayHandler(prefix, svr))
 
 	router.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
 	return router
@@ -15,6 +15,7 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pingcap/pd/server"
@@ -33,13 +34,24 @@ func newSchedulerHandler(handler *server.Handler, r *render.Render) *schedulerHa
 	}
 }
 
+// List returns the statuses of the schedulers, or the paused ones with
+// "?status=paused".
 func (h *schedulerHandler) List(w http.ResponseWriter, r *http.Request) {
-	schedulers, err := h.GetSchedulers()
+	statuses, err := h.GetSchedulerStatuses()
 	if err != nil {
 		h.r.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	h.r.JSON(w, http.StatusOK, schedulers)
+	if r.URL.Query().Get("status") == "paused" {
+		paused := statuses[:0]
+		for _, s := range statuses {
+			if s.Paused {
+				paused = append(paused, s)
+			}
+		}
+		statuses = paused
+	}
+	h.r.JSON(w, http.StatusOK, statuses)
 }
 
 func (h *schedulerHandler) Post(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +73,16 @@ func (h *schedulerHandler) Post(w http.ResponseWriter, r *http.Request) {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case "balance-hot-region-scheduler":
+		if err := h.AddBalanceHotRegionScheduler(); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "balance-hot-read-region-scheduler":
+		if err := h.AddBalanceHotReadRegionScheduler(); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case "grant-leader-scheduler":
 		storeID, ok := input["store_id"].(float64)
 		if !ok {
@@ -91,6 +113,26 @@ func (h *schedulerHandler) Post(w http.ResponseWriter, r *http.Request) {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case "scatter-range-scheduler":
+		startKey, ok := input["start_key"].(string)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing start key")
+			return
+		}
+		endKey, ok := input["end_key"].(string)
+		if !ok {
+			h.r.JSON(w, http.StatusBadRequest, "missing end key")
+			return
+		}
+		rangeName, ok := input["range_name"].(string)
+		if !ok || rangeName == "" {
+			h.r.JSON(w, http.StatusBadRequest, "missing range name")
+			return
+		}
+		if err := h.AddScatterRangeScheduler([]byte(startKey), []byte(endKey), rangeName); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	default:
 		h.r.JSON(w, http.StatusBadRequest, "unknown scheduler")
 		return
@@ -109,3 +151,81 @@ func (h *schedulerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	h.r.JSON(w, http.StatusOK, nil)
 }
+
+// Pause pauses the scheduler, the input is like {"delay": 60}, the scheduler
+// is paused until it's resumed without delay.
+func (h *schedulerHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	var input map[string]interface{}
+	if err := readJSON(r.Body, &input); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var delay float64
+	if v, ok := input["delay"]; ok {
+		if delay, ok = v.(float64); !ok || delay < 0 {
+			h.r.JSON(w, http.StatusBadRequest, "invalid delay")
+			return
+		}
+	}
+
+	if err := h.PauseScheduler(mux.Vars(r)["name"], time.Duration(delay)*time.Second); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+// Resume resumes the scheduler paused by Pause.
+func (h *schedulerHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	if err := h.ResumeScheduler(mux.Vars(r)["name"]); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+// GetPauseAll returns whether all the scheduling is paused.
+func (h *schedulerHandler) GetPauseAll(w http.ResponseWriter, r *http.Request) {
+	status, err := h.GetSchedulePauseStatus()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, status)
+}
+
+// PauseAll halts all the schedulers and checkers, the input is like
+// {"delay": 60}, the scheduling is paused until it's resumed without delay.
+func (h *schedulerHandler) PauseAll(w http.ResponseWriter, r *http.Request) {
+	var input map[string]interface{}
+	if err := readJSON(r.Body, &input); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var delay float64
+	if v, ok := input["delay"]; ok {
+		if delay, ok = v.(float64); !ok || delay < 0 {
+			h.r.JSON(w, http.StatusBadRequest, "invalid delay")
+			return
+		}
+	}
+
+	if err := h.PauseSchedule(time.Duration(delay) * time.Second); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.r.JSON(w, http.StatusOK, nil)
+}
+
+// ResumeAll resumes the scheduling paused by PauseAll.
+func (h *schedulerHandler) ResumeAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.ResumeSchedule(); err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.r.JSON(w, http.StatusOK, nil)
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testSchedulerSuite{})
+
+type testSchedulerSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testSchedulerSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1", httpAddr, apiPrefix)
+
+	mustBootstrapCluster(c, s.svr)
+}
+
+func (s *testSchedulerSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testSchedulerSuite) mustGetSchedulers(c *C, query string) []*server.SchedulerStatus {
+	var statuses []*server.SchedulerStatus
+	c.Assert(readJSONWithURL(s.urlPrefix+"/schedulers"+query, &statuses), IsNil)
+	return statuses
+}
+
+func (s *testSchedulerSuite) TestPause(c *C) {
+	for _, name := range []string{"balance-leader-scheduler", "balance-hot-region-scheduler"} {
+		c.Assert(postJSON(unixClient, s.urlPrefix+"/schedulers", []byte(`{"name": "`+name+`"}`)), IsNil)
+	}
+	statuses := s.mustGetSchedulers(c, "")
+	c.Assert(statuses, HasLen, 2)
+	for _, status := range statuses {
+		c.Assert(status.Paused, IsFalse)
+	}
+
+	// Pause a scheduler for a while, and another one until it's resumed.
+	url := s.urlPrefix + "/schedulers/balance-hot-region-scheduler/pause"
+	c.Assert(postJSON(unixClient, url, []byte(`{"delay": 60}`)), IsNil)
+	url = s.urlPrefix + "/schedulers/balance-leader-scheduler/pause"
+	c.Assert(postJSON(unixClient, url, []byte(`{}`)), IsNil)
+	c.Assert(postJSON(unixClient, s.urlPrefix+"/schedulers/unknown/pause", []byte(`{}`)), NotNil)
+	c.Assert(postJSON(unixClient, url, []byte(`{"delay": -1}`)), NotNil)
+
+	statuses = s.mustGetSchedulers(c, "")
+	c.Assert(statuses, HasLen, 2)
+	for _, status := range statuses {
+		switch status.Name {
+		case "balance-hot-region-scheduler":
+			c.Assert(status.Paused, IsTrue)
+			c.Assert(status.PauseUntil, Greater, time.Now().Unix())
+			c.Assert(status.PauseUntil, LessEqual, time.Now().Add(time.Minute).Unix())
+		case "balance-leader-scheduler":
+			c.Assert(status.Paused, IsTrue)
+			c.Assert(status.PauseUntil, Equals, int64(0))
+		}
+	}
+	c.Assert(s.mustGetSchedulers(c, "?status=paused"), HasLen, 2)
+
+	// Resume the schedulers.
+	for _, name := range []string{"balance-hot-region-scheduler", "balance-leader-scheduler"} {
+		req, err := http.NewRequest(http.MethodDelete, s.urlPrefix+"/schedulers/"+name+"/pause", nil)
+		c.Assert(err, IsNil)
+		resp, err := unixClient.Do(req)
+		c.Assert(err, IsNil)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	}
+	c.Assert(s.mustGetSchedulers(c, "?status=paused"), HasLen, 0)
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
 	"github.com/urfave/negroni"
 )
 
@@ -31,9 +32,22 @@ func NewHandler(svr *server.Server) http.Handler {
 	engine.Use(recovery)
 
 	router := mux.NewRouter()
+	rd := render.New(render.Options{
+		IndentJSON: true,
+	})
+	apiRouter := createRouter(apiPrefix, svr)
+	pprofPrefix := apiPrefix + "/api/v1/debug/pprof"
+	pprofRouter := newPprofRouter(pprofPrefix, svr, rd)
+	// The health, the profiles and the API description are served by any
+	// member without redirecting to the leader, so they work when there is
+	// no leader.
+	router.Handle(apiPrefix+"/api/v1/health", newHealthHandler(svr, rd)).Methods("GET")
+	router.PathPrefix(pprofPrefix).Handler(pprofRouter)
+	router.Handle(apiPrefix+"/api/v1/openapi.json", newOpenAPIHandler(rd, router, apiRouter, pprofRouter)).Methods("GET")
 	router.PathPrefix(apiPrefix).Handler(negroni.New(
 		newRedirector(svr),
-		negroni.Wrap(createRouter(apiPrefix, svr)),
+		newAuthenticator(svr),
+		negroni.Wrap(apiRouter),
 	))
 
 	engine.UseHandler(router)
@@ -16,12 +16,14 @@ package api
 import (
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/pkg/typeutil"
 	"github.com/pingcap/pd/server"
 	"github.com/unrolled/render"
@@ -38,6 +40,10 @@ type storeStatus struct {
 	Available          typeutil.ByteSize `json:"available"`
 	LeaderCount        int               `json:"leader_count"`
 	RegionCount        int               `json:"region_count"`
+	RegionSize         typeutil.ByteSize `json:"region_size"`
+	PendingPeerCount   int               `json:"pending_peer_count"`
+	LeaderWeight       float64           `json:"leader_weight"`
+	RegionWeight       float64           `json:"region_weight"`
 	SendingSnapCount   uint32            `json:"sending_snap_count"`
 	ReceivingSnapCount uint32            `json:"receiving_snap_count"`
 	ApplyingSnapCount  uint32            `json:"applying_snap_count"`
@@ -67,6 +73,10 @@ func newStoreInfo(store *metapb.Store, status *server.StoreStatus) *storeInfo {
 			Available:          typeutil.ByteSize(status.Available),
 			LeaderCount:        status.LeaderCount,
 			RegionCount:        status.RegionCount,
+			RegionSize:         typeutil.ByteSize(status.RegionSize),
+			PendingPeerCount:   status.PendingPeerCount,
+			LeaderWeight:       status.LeaderWeight,
+			RegionWeight:       status.RegionWeight,
 			SendingSnapCount:   status.SendingSnapCount,
 			ReceivingSnapCount: status.ReceivingSnapCount,
 			ApplyingSnapCount:  status.ApplyingSnapCount,
@@ -110,7 +120,7 @@ func (h *storeHandler) Get(w http.ResponseWriter, r *http.Request) {
 	storeIDStr := vars["id"]
 	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
 	if err != nil {
-		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -135,7 +145,7 @@ func (h *storeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	storeIDStr := vars["id"]
 	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
 	if err != nil {
-		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -154,6 +164,169 @@ func (h *storeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.rd.JSON(w, http.StatusOK, nil)
 }
 
+// SetState sets the state of the store by the query `state=Up|Offline`. An
+// offline store can be set up again to cancel its removal.
+func (h *storeHandler) SetState(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	storeIDStr := vars["id"]
+	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	state, err := parseStoreState(r.URL.Query().Get("state"))
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	switch state {
+	case metapb.StoreState_Up:
+		err = cluster.RestoreStore(storeID)
+	case metapb.StoreState_Offline:
+		err = cluster.RemoveStore(storeID)
+	default:
+		h.rd.JSON(w, http.StatusBadRequest, "the state should be Up or Offline, delete the store with force to set it Tombstone")
+		return
+	}
+
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// SetLabels adds or updates the labels of the store, the input is like
+// {"zone": "z1", "host": "h1"}.
+func (h *storeHandler) SetLabels(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	storeIDStr := vars["id"]
+	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var input map[string]string
+	if err = readJSON(r.Body, &input); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(input) == 0 {
+		h.rd.JSON(w, http.StatusBadRequest, "missing store labels")
+		return
+	}
+	labels := make([]*metapb.StoreLabel, 0, len(input))
+	for k, v := range input {
+		labels = append(labels, &metapb.StoreLabel{Key: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].GetKey() < labels[j].GetKey() })
+
+	if err = cluster.SetStoreLabels(storeID, labels); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// SetWeight sets the leader and region weights of the store, the input is
+// like {"leader": 2, "region": 1}.
+func (h *storeHandler) SetWeight(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	storeIDStr := vars["id"]
+	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var input map[string]interface{}
+	if err = readJSON(r.Body, &input); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	leader, ok := input["leader"].(float64)
+	if !ok {
+		h.rd.JSON(w, http.StatusBadRequest, "missing leader weight")
+		return
+	}
+	region, ok := input["region"].(float64)
+	if !ok {
+		h.rd.JSON(w, http.StatusBadRequest, "missing region weight")
+		return
+	}
+
+	if err = cluster.SetStoreWeight(storeID, leader, region); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
+// SetLimit sets the max number of the add-peer and remove-peer operators of
+// the store per minute, the input is like {"add_peer": 15, "remove_peer": 15},
+// a missing item is not changed.
+func (h *storeHandler) SetLimit(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	storeIDStr := vars["id"]
+	storeID, err := strconv.ParseUint(storeIDStr, 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var input map[string]interface{}
+	if err = readJSON(r.Body, &input); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit := cluster.GetStoreLimit(storeID)
+	addPeer, removePeer := limit.AddPeer, limit.RemovePeer
+	if v, ok := input["add_peer"]; ok {
+		if addPeer, ok = v.(float64); !ok {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid add_peer limit")
+			return
+		}
+	}
+	if v, ok := input["remove_peer"]; ok {
+		if removePeer, ok = v.(float64); !ok {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid remove_peer limit")
+			return
+		}
+	}
+
+	if err = cluster.SetStoreLimit(storeID, addPeer, removePeer); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, nil)
+}
+
 type storesHandler struct {
 	svr *server.Server
 	rd  *render.Render
@@ -200,6 +373,38 @@ func (h *storesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.rd.JSON(w, http.StatusOK, storesInfo)
 }
 
+// GetLimits returns the add-peer and remove-peer limits of the stores.
+func (h *storesHandler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	cluster := h.svr.GetRaftCluster()
+	if cluster == nil {
+		h.rd.JSON(w, http.StatusInternalServerError, server.ErrNotBootstrapped.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, cluster.GetStoreLimits())
+}
+
+type storeDrainHandler struct {
+	*server.Handler
+	rd *render.Render
+}
+
+func newStoreDrainHandler(handler *server.Handler, rd *render.Render) *storeDrainHandler {
+	return &storeDrainHandler{
+		Handler: handler,
+		rd:      rd,
+	}
+}
+
+// List returns the drain progress and ETA of the offline and blocked stores.
+func (h *storeDrainHandler) List(w http.ResponseWriter, r *http.Request) {
+	drains, err := h.GetStoreDrains()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, drains)
+}
+
 type storeStateFilter struct {
 	accepts []metapb.StoreState
 }
@@ -208,18 +413,11 @@ func newStoreStateFilter(u *url.URL) (*storeStateFilter, error) {
 	var acceptStates []metapb.StoreState
 	if v, ok := u.Query()["state"]; ok {
 		for _, s := range v {
-			state, err := strconv.Atoi(s)
+			storeState, err := parseStoreState(s)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
-
-			storeState := metapb.StoreState(state)
-			switch storeState {
-			case metapb.StoreState_Up, metapb.StoreState_Offline, metapb.StoreState_Tombstone:
-				acceptStates = append(acceptStates, storeState)
-			default:
-				return nil, errors.Errorf("unknown StoreState: %v", storeState)
-			}
+			acceptStates = append(acceptStates, storeState)
 		}
 	} else {
 		// Accepts Up and Offline by default.
@@ -244,3 +442,23 @@ func (filter *storeStateFilter) filter(stores []*metapb.Store) []*metapb.Store {
 	}
 	return ret
 }
+
+// parseStoreState parses the store state by its name or its number.
+func parseStoreState(s string) (metapb.StoreState, error) {
+	for value, name := range metapb.StoreState_name {
+		if strings.EqualFold(s, name) {
+			return metapb.StoreState(value), nil
+		}
+	}
+	state, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Errorf("unknown StoreState: %v", s)
+	}
+	storeState := metapb.StoreState(state)
+	switch storeState {
+	case metapb.StoreState_Up, metapb.StoreState_Offline, metapb.StoreState_Tombstone:
+		return storeState, nil
+	default:
+		return 0, errors.Errorf("unknown StoreState: %v", storeState)
+	}
+}
@@ -21,8 +21,8 @@ import (
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/server"
 )
 
@@ -99,12 +99,16 @@ func (s *testStoreSuite) TestStoresList(c *C) {
 	c.Assert(err, IsNil)
 	checkStoresInfo(c, info.Stores, s.stores[:3])
 
+	// Decode into new structs, the stores without labels don't overwrite the
+	// decoded labels.
 	url = fmt.Sprintf("%s/stores?state=0", s.urlPrefix)
+	info = new(storesInfo)
 	err = readJSONWithURL(url, info)
 	c.Assert(err, IsNil)
 	checkStoresInfo(c, info.Stores, s.stores[:2])
 
 	url = fmt.Sprintf("%s/stores?state=1", s.urlPrefix)
+	info = new(storesInfo)
 	err = readJSONWithURL(url, info)
 	c.Assert(err, IsNil)
 	checkStoresInfo(c, info.Stores, s.stores[2:3])
@@ -119,6 +123,103 @@ func (s *testStoreSuite) TestStoreGet(c *C) {
 	checkStoresInfo(c, []*storeInfo{info}, s.stores[:1])
 }
 
+func (s *testStoreSuite) TestStoreSetWeight(c *C) {
+	url := fmt.Sprintf("%s/store/1/weight", s.urlPrefix)
+	client := newUnixSocketClient()
+	err := postJSON(client, url, []byte(`{"leader": 2, "region": 1.5}`))
+	c.Assert(err, IsNil)
+
+	info := new(storeInfo)
+	err = readJSONWithURL(fmt.Sprintf("%s/store/1", s.urlPrefix), info)
+	c.Assert(err, IsNil)
+	c.Assert(info.Status.LeaderWeight, Equals, 2.0)
+	c.Assert(info.Status.RegionWeight, Equals, 1.5)
+
+	// Invalid weights.
+	c.Assert(postJSON(client, url, []byte(`{"leader": -1, "region": 1}`)), NotNil)
+	c.Assert(postJSON(client, url, []byte(`{"leader": 1}`)), NotNil)
+	c.Assert(postJSON(client, fmt.Sprintf("%s/store/100/weight", s.urlPrefix), []byte(`{"leader": 1, "region": 1}`)), NotNil)
+	c.Assert(postJSON(client, fmt.Sprintf("%s/store/7/weight", s.urlPrefix), []byte(`{"leader": 1, "region": 1}`)), NotNil)
+}
+
+func (s *testStoreSuite) TestStoreSetState(c *C) {
+	table := []struct {
+		id     uint64
+		state  string
+		status int
+		want   metapb.StoreState
+	}{
+		// Cancel the deletion of the offline store.
+		{6, "Up", http.StatusOK, metapb.StoreState_Up},
+		{6, "Offline", http.StatusOK, metapb.StoreState_Offline},
+		{6, "Tombstone", http.StatusBadRequest, metapb.StoreState_Offline},
+		{6, "foo", http.StatusBadRequest, metapb.StoreState_Offline},
+		// The tombstone store can't be up again.
+		{7, "Up", http.StatusInternalServerError, metapb.StoreState_Tombstone},
+		{100, "Up", http.StatusInternalServerError, 0},
+	}
+	client := newUnixSocketClient()
+	for _, t := range table {
+		resp, err := client.Post(fmt.Sprintf("%s/store/%d/state?state=%s", s.urlPrefix, t.id, t.state), "", nil)
+		c.Assert(err, IsNil)
+		ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		c.Assert(resp.StatusCode, Equals, t.status)
+		if t.id == 100 {
+			continue
+		}
+		store, _, err := s.svr.GetRaftCluster().GetStore(t.id)
+		c.Assert(err, IsNil)
+		c.Assert(store.GetState(), Equals, t.want)
+	}
+}
+
+func (s *testStoreSuite) TestStoreSetLabels(c *C) {
+	url := fmt.Sprintf("%s/store/4/label", s.urlPrefix)
+	client := newUnixSocketClient()
+	c.Assert(postJSON(client, url, []byte(`{"zone": "z1", "host": "h1"}`)), IsNil)
+	c.Assert(postJSON(client, url, []byte(`{"zone": "z2"}`)), IsNil)
+
+	info := new(storeInfo)
+	err := readJSONWithURL(fmt.Sprintf("%s/store/4", s.urlPrefix), info)
+	c.Assert(err, IsNil)
+	labels := []*metapb.StoreLabel{{Key: "host", Value: "h1"}, {Key: "zone", Value: "z2"}}
+	c.Assert(info.Store.GetLabels(), DeepEquals, labels)
+	// Keep the stores of the suite the same as the server.
+	s.stores[1].Labels = labels
+
+	// Invalid labels.
+	c.Assert(postJSON(client, url, []byte(`{}`)), NotNil)
+	c.Assert(postJSON(client, url, []byte(`{"zone": ""}`)), NotNil)
+	c.Assert(postJSON(client, url, []byte(`{"zone": 1}`)), NotNil)
+	c.Assert(postJSON(client, fmt.Sprintf("%s/store/7/label", s.urlPrefix), []byte(`{"zone": "z1"}`)), NotNil)
+	c.Assert(postJSON(client, fmt.Sprintf("%s/store/100/label", s.urlPrefix), []byte(`{"zone": "z1"}`)), NotNil)
+}
+
+func (s *testStoreSuite) TestStoreSetLimit(c *C) {
+	url := fmt.Sprintf("%s/store/1/limit", s.urlPrefix)
+	client := newUnixSocketClient()
+	c.Assert(postJSON(client, url, []byte(`{"add_peer": 10}`)), IsNil)
+	c.Assert(postJSON(client, url, []byte(`{"remove_peer": 20}`)), IsNil)
+
+	var limits []*server.StoreLimit
+	err := readJSONWithURL(fmt.Sprintf("%s/stores/limit", s.urlPrefix), &limits)
+	c.Assert(err, IsNil)
+	c.Assert(limits, Not(HasLen), 0)
+	for _, limit := range limits {
+		if limit.StoreID == 1 {
+			c.Assert(limit, DeepEquals, &server.StoreLimit{StoreID: 1, AddPeer: 10, RemovePeer: 20})
+		} else {
+			c.Assert(limit, DeepEquals, &server.StoreLimit{StoreID: limit.StoreID})
+		}
+	}
+
+	// Invalid limits.
+	c.Assert(postJSON(client, url, []byte(`{"add_peer": -1}`)), NotNil)
+	c.Assert(postJSON(client, url, []byte(`{"add_peer": "1"}`)), NotNil)
+	c.Assert(postJSON(client, fmt.Sprintf("%s/store/100/limit", s.urlPrefix), []byte(`{"add_peer": 1}`)), NotNil)
+}
+
 func (s *testStoreSuite) TestStoreDelete(c *C) {
 	table := []struct {
 		id     int
@@ -166,6 +267,14 @@ func (s *testStoreSuite) TestUrlStoreFilter(c *C) {
 			u:    "http://localhost:2379/pd/api/v1/stores?state=2&state=1",
 			want: s.stores[2:],
 		},
+		{
+			u:    "http://localhost:2379/pd/api/v1/stores?state=Tombstone",
+			want: s.stores[3:],
+		},
+		{
+			u:    "http://localhost:2379/pd/api/v1/stores?state=up&state=Offline",
+			want: s.stores[:3],
+		},
 	}
 
 	for _, t := range table {
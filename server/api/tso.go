@@ -0,0 +1,83 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/pd/server"
+	"github.com/unrolled/render"
+)
+
+const (
+	physicalShiftBits = 18
+	logicalMask       = 1<<physicalShiftBits - 1
+)
+
+// tsoInfo is a timestamp with its physical and logical parts.
+type tsoInfo struct {
+	TSO      uint64 `json:"tso"`
+	Physical int64  `json:"physical"`
+	Logical  int64  `json:"logical"`
+	// Time is the physical part as the wall time.
+	Time time.Time `json:"time"`
+}
+
+func newTsoInfo(physical, logical int64) *tsoInfo {
+	return &tsoInfo{
+		TSO:      uint64(physical)<<physicalShiftBits + uint64(logical),
+		Physical: physical,
+		Logical:  logical,
+		Time:     time.Unix(0, physical*int64(time.Millisecond)),
+	}
+}
+
+type tsoHandler struct {
+	*server.Handler
+	rd *render.Render
+}
+
+func newTsoHandler(handler *server.Handler, rd *render.Render) *tsoHandler {
+	return &tsoHandler{
+		Handler: handler,
+		rd:      rd,
+	}
+}
+
+func (h *tsoHandler) GetClients(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.GetTsoClientStats())
+}
+
+// Get returns a new timestamp.
+func (h *tsoHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ts, err := h.GetTS()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, newTsoInfo(ts.GetPhysical(), ts.GetLogical()))
+}
+
+// Decode splits the timestamp into the physical and logical parts.
+func (h *tsoHandler) Decode(w http.ResponseWriter, r *http.Request) {
+	ts, err := strconv.ParseUint(mux.Vars(r)["ts"], 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, newTsoInfo(int64(ts>>physicalShiftBits), int64(ts&logicalMask)))
+}
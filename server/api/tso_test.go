@@ -0,0 +1,65 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/server"
+)
+
+var _ = Suite(&testTsoSuite{})
+
+type testTsoSuite struct {
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func (s *testTsoSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustNewServer(c)
+	mustWaitLeader(c, []*server.Server{s.svr})
+
+	addr := s.svr.GetAddr()
+	httpAddr := mustUnixAddrToHTTPAddr(c, addr)
+	s.urlPrefix = fmt.Sprintf("%s%s/api/v1", httpAddr, apiPrefix)
+}
+
+func (s *testTsoSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testTsoSuite) TestTso(c *C) {
+	var ts tsoInfo
+	err := readJSONWithURL(s.urlPrefix+"/tso", &ts)
+	c.Assert(err, IsNil)
+	// The physical time is updated periodically.
+	c.Assert(time.Since(ts.Time), Less, time.Second)
+	c.Assert(ts.TSO>>physicalShiftBits, Equals, uint64(ts.Physical))
+
+	// Decode the timestamp.
+	var decoded tsoInfo
+	err = readJSONWithURL(fmt.Sprintf("%s/tso/decode/%d", s.urlPrefix, ts.TSO), &decoded)
+	c.Assert(err, IsNil)
+	c.Assert(decoded.Physical, Equals, ts.Physical)
+	c.Assert(decoded.Logical, Equals, ts.Logical)
+	c.Assert(decoded.Time.Equal(ts.Time), IsTrue)
+
+	err = readJSONWithURL(fmt.Sprintf("%s/tso/decode/%d", s.urlPrefix, 393216000000000001), &decoded)
+	c.Assert(err, IsNil)
+	c.Assert(decoded.Physical, Equals, int64(1500000000000))
+	c.Assert(decoded.Logical, Equals, int64(1))
+}
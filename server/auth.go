@@ -0,0 +1,60 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Enabled returns whether the HTTP requests need to be authenticated.
+func (c AuthConfig) Enabled() bool {
+	return len(c.Tokens) > 0 || len(c.Users) > 0
+}
+
+// Authenticate returns the name of the user of the HTTP request by the bearer
+// token or the basic auth credentials, it returns false if the request is not
+// authenticated.
+func (c AuthConfig) Authenticate(r *http.Request) (string, bool) {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return c.authenticateBearer(auth)
+	}
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return "", false
+	}
+	expected, ok := c.Users[user]
+	if !ok || subtle.ConstantTimeCompare([]byte(expected), []byte(password)) != 1 {
+		return "", false
+	}
+	return user, true
+}
+
+// authenticateBearer returns the user of the "Bearer" token.
+func (c AuthConfig) authenticateBearer(auth string) (string, bool) {
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimPrefix(auth, "Bearer ")
+	// All the tokens are compared so the time does not tell which one is
+	// close.
+	var name string
+	for t, user := range c.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			name = user
+		}
+	}
+	return name, name != ""
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testAuthSuite{})
+
+type testAuthSuite struct{}
+
+func (s *testAuthSuite) TestAuthenticate(c *C) {
+	auth := AuthConfig{
+		Tokens: map[string]string{"t1": "ops"},
+		Users:  map[string]string{"admin": "passwd"},
+	}
+	c.Assert(auth.validate(), IsNil)
+	c.Assert(auth.Enabled(), IsTrue)
+	c.Assert(AuthConfig{}.Enabled(), IsFalse)
+	c.Assert(AuthConfig{Tokens: map[string]string{"t1": ""}}.validate(), NotNil)
+	c.Assert(AuthConfig{Users: map[string]string{"admin": ""}}.validate(), NotNil)
+
+	testCases := []struct {
+		token, user, password string
+		name                  string
+		ok                    bool
+	}{
+		{name: "", ok: false},
+		{token: "t1", name: "ops", ok: true},
+		{token: "t2", name: "", ok: false},
+		{user: "admin", password: "passwd", name: "admin", ok: true},
+		{user: "admin", password: "wrong", name: "", ok: false},
+		{user: "ops", password: "t1", name: "", ok: false},
+	}
+	for _, t := range testCases {
+		r, err := http.NewRequest(http.MethodPost, "http://pd/pd/api/v1/config", nil)
+		c.Assert(err, IsNil)
+		if t.token != "" {
+			r.Header.Set("Authorization", "Bearer "+t.token)
+		}
+		if t.user != "" {
+			r.SetBasicAuth(t.user, t.password)
+		}
+		name, ok := auth.Authenticate(r)
+		c.Assert(name, Equals, t.name)
+		c.Assert(ok, Equals, t.ok)
+	}
+}
+
+func (s *testAuthSuite) TestRedact(c *C) {
+	cfg := NewConfig()
+	cfg.Auth = AuthConfig{
+		Tokens: map[string]string{"secret-token": "ops"},
+		Users:  map[string]string{"admin": "secret-passwd"},
+	}
+	str := cfg.String()
+	c.Assert(strings.Contains(str, "secret"), IsFalse)
+	c.Assert(strings.Contains(str, "Auth:{Tokens:[ops] Users:[admin]}"), IsTrue)
+}
@@ -21,7 +21,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/montanaflynn/stats"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 )
 
 const (
@@ -38,9 +38,13 @@ const (
 	byLeader
 )
 
-// minBalanceDiff returns the minimal diff to do balance. The formula is based
-// on experience to let the diff increase alone with the count slowly.
-func minBalanceDiff(count uint64) float64 {
+// minBalanceDiff returns the minimal diff to do balance. It's the
+// tolerant-size-ratio if set, or the formula based on experience to let the
+// diff increase alone with the count slowly.
+func minBalanceDiff(count uint64, tolerantRatio float64) float64 {
+	if tolerantRatio > 0 {
+		return tolerantRatio
+	}
 	if count < bootstrapBalanceCount {
 		return bootstrapBalanceDiff
 	}
@@ -50,7 +54,7 @@ func minBalanceDiff(count uint64) float64 {
 // shouldBalance returns true if we should balance the source and target store.
 // The min balance diff provides a buffer to make the cluster stable, so that we
 // don't need to schedule very frequently.
-func shouldBalance(source, target *storeInfo, kind ResourceKind) bool {
+func shouldBalance(source, target *storeInfo, kind ResourceKind, opt *scheduleOption) bool {
 	sourceCount := source.resourceCount(kind)
 	sourceScore := source.resourceScore(kind)
 	targetScore := target.resourceScore(kind)
@@ -59,7 +63,7 @@ func shouldBalance(source, target *storeInfo, kind ResourceKind) bool {
 	}
 	diffRatio := 1 - targetScore/sourceScore
 	diffCount := diffRatio * float64(sourceCount)
-	return diffCount >= minBalanceDiff(sourceCount)
+	return diffCount >= minBalanceDiff(sourceCount, opt.GetTolerantSizeRatio())
 }
 
 func adjustBalanceLimit(cluster *clusterInfo, kind ResourceKind) uint64 {
@@ -85,6 +89,8 @@ func newBalanceLeaderScheduler(opt *scheduleOption) *balanceLeaderScheduler {
 	filters = append(filters, newBlockFilter())
 	filters = append(filters, newStateFilter(opt))
 	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newLeaderWeightFilter())
+	filters = append(filters, newRejectLeaderFilter(opt))
 
 	return &balanceLeaderScheduler{
 		opt:      opt,
@@ -110,6 +116,16 @@ func (l *balanceLeaderScheduler) Prepare(cluster *clusterInfo) error { return ni
 func (l *balanceLeaderScheduler) Cleanup(cluster *clusterInfo) {}
 
 func (l *balanceLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
+	// The leaders are balanced in each namespace separately.
+	for _, nc := range cluster.getNamespaceClusters() {
+		if op := l.schedule(nc); op != nil {
+			return op
+		}
+	}
+	return nil
+}
+
+func (l *balanceLeaderScheduler) schedule(cluster *clusterInfo) Operator {
 	region, newLeader := scheduleTransferLeader(cluster, l.selector)
 	if region == nil {
 		return nil
@@ -117,7 +133,15 @@ func (l *balanceLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
 
 	source := cluster.getStore(region.Leader.GetStoreId())
 	target := cluster.getStore(newLeader.GetStoreId())
-	if !shouldBalance(source, target, l.GetResourceKind()) {
+	// The leader may be on a store of another namespace.
+	if source == nil || target == nil {
+		return nil
+	}
+	// Stores with 0 leader weight should have no leader at all.
+	if source.leaderWeight() > 0 && !shouldBalance(source, target, l.GetResourceKind(), l.opt) {
+		return nil
+	}
+	if !cluster.getRegionPlacement(region).allowLeader(target) {
 		return nil
 	}
 	l.limit = adjustBalanceLimit(cluster, l.GetResourceKind())
@@ -140,6 +164,7 @@ func newBalanceRegionScheduler(opt *scheduleOption) *balanceRegionScheduler {
 	filters = append(filters, newStateFilter(opt))
 	filters = append(filters, newHealthFilter(opt))
 	filters = append(filters, newSnapshotCountFilter(opt))
+	filters = append(filters, newPendingPeerCountFilter(opt))
 	filters = append(filters, newStorageThresholdFilter(opt))
 
 	return &balanceRegionScheduler{
@@ -168,14 +193,30 @@ func (s *balanceRegionScheduler) Prepare(cluster *clusterInfo) error { return ni
 func (s *balanceRegionScheduler) Cleanup(cluster *clusterInfo) {}
 
 func (s *balanceRegionScheduler) Schedule(cluster *clusterInfo) Operator {
-	// Select a peer from the store with most regions.
-	region, oldPeer := scheduleRemovePeer(cluster, s.selector)
+	// The regions are balanced in each namespace separately.
+	for _, nc := range cluster.getNamespaceClusters() {
+		if op := s.schedule(nc); op != nil {
+			return op
+		}
+	}
+	return nil
+}
+
+func (s *balanceRegionScheduler) schedule(cluster *clusterInfo) Operator {
+	// Move the regions off the stores short of space first, then select a
+	// peer from the store with most regions.
+	region, oldPeer := scheduleRemovePeer(cluster, s.selector, newLowSpaceFilter(s.opt))
+	if region == nil {
+		region, oldPeer = scheduleRemovePeer(cluster, s.selector)
+	}
 	if region == nil {
 		return nil
 	}
 
-	// We don't schedule region with abnormal number of replicas.
-	if len(region.GetPeers()) != s.rep.GetMaxReplicas() {
+	// We don't schedule region with abnormal number of replicas. The
+	// witnesses and learners are placed by the replica checker only.
+	if len(region.GetPeers()) != cluster.getRegionPeerCount(region, s.rep.GetMaxReplicas()) ||
+		oldPeer.GetRole() != metapb.PeerRole_Voter {
 		return nil
 	}
 
@@ -193,20 +234,26 @@ func (s *balanceRegionScheduler) transferPeer(cluster *clusterInfo, region *Regi
 	stores := cluster.getRegionStores(region)
 	source := cluster.getStore(oldPeer.GetStoreId())
 	scoreGuard := newDistinctScoreFilter(s.rep, stores, source)
+	pendingFilter := newPendingPeerCountFilter(s.opt)
 
 	checker := newReplicaChecker(s.opt, cluster)
-	newPeer, _ := checker.selectBestPeer(region, scoreGuard)
+	rule := cluster.getRegionPeerRule(region, oldPeer, s.opt.GetMaxReplicas())
+	newPeer, _ := checker.selectBestPeer(region, rule, scoreGuard, pendingFilter)
 	if newPeer == nil {
 		return nil
 	}
 
+	// The stores short of space are drained even if they are balanced.
 	target := cluster.getStore(newPeer.GetStoreId())
-	if !shouldBalance(source, target, s.GetResourceKind()) {
+	if !source.isLowSpace(s.opt.GetLowSpaceRatio()) && !shouldBalance(source, target, s.GetResourceKind(), s.opt) {
+		return nil
+	}
+	if !cluster.getRegionPlacement(region).allowTransferPeer(rule, stores, source, target) {
 		return nil
 	}
 	s.limit = adjustBalanceLimit(cluster, s.GetResourceKind())
 
-	return newTransferPeer(region, RegionKind, oldPeer, newPeer)
+	return newTransferPeer(region, RegionKind, oldPeer, newPeer, s.opt)
 }
 
 // replicaChecker ensures region has the best replicas.
@@ -237,32 +284,28 @@ func (r *replicaChecker) Check(region *RegionInfo) Operator {
 	if op := r.checkOfflinePeer(region); op != nil {
 		return op
 	}
-
-	if len(region.GetPeers()) < r.rep.GetMaxReplicas() {
-		newPeer, _ := r.selectBestPeer(region, r.filters...)
-		if newPeer == nil {
-			return nil
-		}
-		return newAddPeer(region, newPeer)
+	if op := r.checkRules(region); op != nil {
+		return op
 	}
-
-	if len(region.GetPeers()) > r.rep.GetMaxReplicas() {
-		oldPeer, _ := r.selectWorstPeer(region)
-		if oldPeer == nil {
-			return nil
-		}
-		return newRemovePeer(region, oldPeer)
+	if op := r.checkLeaderPlacement(region); op != nil {
+		return op
+	}
+	if op := r.checkRejectLeader(region); op != nil {
+		return op
 	}
 
 	return r.checkBestReplacement(region)
 }
 
-// selectBestPeer returns the best peer in other stores.
-func (r *replicaChecker) selectBestPeer(region *RegionInfo, filters ...Filter) (*metapb.Peer, float64) {
+// selectBestPeer returns the best peer of the rule's role in other stores
+// which the rule allows.
+func (r *replicaChecker) selectBestPeer(region *RegionInfo, rule *PlacementRule, filters ...Filter) (*metapb.Peer, float64) {
 	// Add some must have filters.
 	filters = append(filters, newStateFilter(r.opt))
 	filters = append(filters, newStorageThresholdFilter(r.opt))
 	filters = append(filters, newExcludedFilter(nil, region.GetStoreIds()))
+	filters = append(filters, newPlacementRuleFilter(rule))
+	filters = append(filters, newNamespaceFilter(r.cluster, r.cluster.getRegionNamespace(region)))
 
 	var (
 		bestStore *storeInfo
@@ -286,12 +329,18 @@ func (r *replicaChecker) selectBestPeer(region *RegionInfo, filters ...Filter) (
 	if bestStore == nil || filterTarget(bestStore, r.filters) {
 		return nil, 0
 	}
+	// The leader sends the snapshot to the new peer, so it should not be
+	// busy with snapshots either.
+	if leader := r.cluster.getLeaderStore(region); leader != nil && newSnapshotCountFilter(r.opt).FilterSource(leader) {
+		return nil, 0
+	}
 
 	newPeer, err := r.cluster.allocPeer(bestStore.GetId())
 	if err != nil {
 		log.Errorf("failed to allocate peer: %v", err)
 		return nil, 0
 	}
+	newPeer.Role = rule.peerRole()
 	return newPeer, bestScore
 }
 
@@ -322,36 +371,65 @@ func (r *replicaChecker) selectWorstPeer(region *RegionInfo, filters ...Filter)
 	return region.GetStorePeer(worstStore.GetId()), worstScore
 }
 
-// selectBestReplacement returns the best peer to replace the region peer.
-func (r *replicaChecker) selectBestReplacement(region *RegionInfo, peer *metapb.Peer) (*metapb.Peer, float64) {
+// selectBestReplacement returns the best peer to replace the region peer,
+// which is placed by the same rule.
+func (r *replicaChecker) selectBestReplacement(region *RegionInfo, peer *metapb.Peer, filters ...Filter) (*metapb.Peer, float64) {
+	rule := r.cluster.getRegionPeerRule(region, peer, r.opt.GetMaxReplicas())
 	// Get a new region without the peer we are going to replace.
 	newRegion := region.clone()
 	newRegion.RemoveStorePeer(peer.GetStoreId())
-	return r.selectBestPeer(newRegion, newExcludedFilter(nil, region.GetStoreIds()))
+	filters = append(filters, newExcludedFilter(nil, region.GetStoreIds()))
+	return r.selectBestPeer(newRegion, rule, filters...)
 }
 
+// checkDownPeer replaces the peers on the stores which have been down for
+// longer than max-store-down-time with the peers on the healthy stores. The
+// new peer is added before the down one is removed, so the region doesn't
+// lose a replica which is still up if it is scheduled.
 func (r *replicaChecker) checkDownPeer(region *RegionInfo) Operator {
-	for _, stats := range region.DownPeers {
-		peer := stats.GetPeer()
-		if peer == nil {
-			continue
-		}
+	for _, peer := range region.GetPeers() {
 		store := r.cluster.getStore(peer.GetStoreId())
 		if store == nil {
 			log.Infof("lost the store %d,maybe you are recovering the PD cluster.", peer.GetStoreId())
 			return nil
 		}
-		if store.downTime() < r.opt.GetMaxStoreDownTime() {
+		if !r.isDownPeer(region, peer, store) {
 			continue
 		}
-		if stats.GetDownSeconds() < uint64(r.opt.GetMaxStoreDownTime().Seconds()) {
-			continue
+
+		if len(region.GetPeers()) > r.cluster.getRegionPeerCount(region, r.opt.GetMaxReplicas()) {
+			return newRemovePeer(region, peer)
 		}
-		return newRemovePeer(region, peer)
+		newPeer, _ := r.selectBestReplacement(region, peer)
+		if newPeer == nil {
+			// The down peer is useless anyway, remove it if no store can
+			// take its place.
+			return newRemovePeer(region, peer)
+		}
+		return newTransferPeer(region, RegionKind, peer, newPeer, r.opt)
 	}
 	return nil
 }
 
+// isDownPeer checks whether the store of the peer has missed its heartbeats
+// for longer than max-store-down-time. If no heartbeat of the store is
+// received since PD started, the down time reported by the leader is used.
+func (r *replicaChecker) isDownPeer(region *RegionInfo, peer *metapb.Peer, store *storeInfo) bool {
+	maxDownTime := r.opt.GetMaxStoreDownTime()
+	if store.downTime() < maxDownTime {
+		return false
+	}
+	if !store.status.LastHeartbeatTS.IsZero() {
+		return true
+	}
+	for _, stats := range region.DownPeers {
+		if stats.GetPeer().GetId() == peer.GetId() {
+			return stats.GetDownSeconds() >= uint64(maxDownTime.Seconds())
+		}
+	}
+	return false
+}
+
 func (r *replicaChecker) checkOfflinePeer(region *RegionInfo) Operator {
 	for _, peer := range region.GetPeers() {
 		store := r.cluster.getStore(peer.GetStoreId())
@@ -364,22 +442,75 @@ func (r *replicaChecker) checkOfflinePeer(region *RegionInfo) Operator {
 		}
 
 		// check the number of replicas firstly
-		if len(region.GetPeers()) > r.opt.GetMaxReplicas() {
+		if len(region.GetPeers()) > r.cluster.getRegionPeerCount(region, r.opt.GetMaxReplicas()) {
 			return newRemovePeer(region, peer)
 		}
 
-		newPeer, _ := r.selectBestPeer(region)
+		// The offline peer is going away, so the new peer should be distinct
+		// from the remaining peers only.
+		newPeer, _ := r.selectBestReplacement(region, peer)
 		if newPeer == nil {
 			return nil
 		}
-		return newTransferPeer(region, RegionKind, peer, newPeer)
+		return newTransferPeer(region, RegionKind, peer, newPeer, r.opt)
+	}
+
+	return nil
+}
+
+// checkRules makes the peers of the region fit its placement rules. The
+// orphan peers on the stores which no rule allows are moved to the stores of
+// the rules short of peers, or removed if no rule is short or the region has
+// too many peers of the role. Then the rules short of peers get new peers,
+// and the extra peers of the rules are removed.
+func (r *replicaChecker) checkRules(region *RegionInfo) Operator {
+	fit := r.cluster.fitRegion(region, r.opt.GetMaxReplicas())
+	for _, peer := range fit.orphans {
+		var peers int
+		for _, p := range region.GetPeers() {
+			if p.GetRole() == peer.GetRole() {
+				peers++
+			}
+		}
+		if !fit.isShort(fit.getPeerRule(peer)) || peers > countRulePeers(fit.rules, peer.GetRole()) {
+			return newRemovePeer(region, peer)
+		}
+		newPeer, _ := r.selectBestReplacement(region, peer)
+		if newPeer == nil {
+			continue
+		}
+		return newTransferPeer(region, RegionKind, peer, newPeer, r.opt)
 	}
 
+	for i, rule := range fit.rules {
+		if len(fit.peers[i]) >= rule.Count {
+			continue
+		}
+		newPeer, _ := r.selectBestPeer(region, rule, r.filters...)
+		if newPeer == nil {
+			continue
+		}
+		return newAddPeer(region, newPeer, r.opt.IsRaftLearnerEnabled())
+	}
+
+	for i, rule := range fit.rules {
+		if len(fit.peers[i]) <= rule.Count {
+			continue
+		}
+		// Only the peers of the rule are candidates.
+		excluded := region.GetStoreIds()
+		for _, peer := range fit.peers[i] {
+			delete(excluded, peer.GetStoreId())
+		}
+		if oldPeer, _ := r.selectWorstPeer(region, newExcludedFilter(excluded, nil)); oldPeer != nil {
+			return newRemovePeer(region, oldPeer)
+		}
+	}
 	return nil
 }
 
 func (r *replicaChecker) checkBestReplacement(region *RegionInfo) Operator {
-	oldPeer, oldScore := r.selectWorstPeer(region)
+	oldPeer, oldScore := r.selectWorstPeer(region, newExcludedFilter(region.getNonVoterStoreIds(), nil))
 	if oldPeer == nil {
 		return nil
 	}
@@ -391,13 +522,77 @@ func (r *replicaChecker) checkBestReplacement(region *RegionInfo) Operator {
 	if newScore <= oldScore {
 		return nil
 	}
-	return newTransferPeer(region, RegionKind, oldPeer, newPeer)
+	source := r.cluster.getStore(oldPeer.GetStoreId())
+	target := r.cluster.getStore(newPeer.GetStoreId())
+	rule := r.cluster.getRegionPeerRule(region, oldPeer, r.opt.GetMaxReplicas())
+	if !r.cluster.getRegionPlacement(region).allowTransferPeer(rule, r.cluster.getRegionStores(region), source, target) {
+		return nil
+	}
+	return newTransferPeer(region, RegionKind, oldPeer, newPeer, r.opt)
+}
+
+// checkLeaderPlacement makes sure the leader is on a store allowed by the
+// placement of the region. If no peer is on such store, a follower will be
+// moved to one.
+func (r *replicaChecker) checkLeaderPlacement(region *RegionInfo) Operator {
+	placement := r.cluster.getRegionPlacement(region)
+	if placement == nil || placement.LeaderLabel == nil {
+		return nil
+	}
+	leaderStore := r.cluster.getStore(region.Leader.GetStoreId())
+	if leaderStore == nil || placement.allowLeader(leaderStore) {
+		return nil
+	}
+
+	rejectLeader := newRejectLeaderFilter(r.opt)
+	for _, peer := range region.GetFollowers() {
+		store := r.cluster.getStore(peer.GetStoreId())
+		if store != nil && placement.allowLeader(store) && !filterTarget(store, r.filters) && !rejectLeader.FilterTarget(store) {
+			return newTransferLeader(region, peer)
+		}
+	}
+
+	excluded := region.getNonVoterStoreIds()
+	excluded[leaderStore.GetId()] = struct{}{}
+	oldPeer, _ := r.selectWorstPeer(region, newExcludedFilter(excluded, nil))
+	if oldPeer == nil {
+		return nil
+	}
+	newPeer, _ := r.selectBestReplacement(region, oldPeer, newPlacementLeaderFilter(placement))
+	if newPeer == nil {
+		return nil
+	}
+	return newTransferPeer(region, RegionKind, oldPeer, newPeer, r.opt)
+}
+
+// checkRejectLeader moves the leader out of the store whose labels reject
+// leaders, if a follower is able to take it.
+func (r *replicaChecker) checkRejectLeader(region *RegionInfo) Operator {
+	rejectLeader := newRejectLeaderFilter(r.opt)
+	leaderStore := r.cluster.getStore(region.Leader.GetStoreId())
+	if leaderStore == nil || !rejectLeader.FilterTarget(leaderStore) {
+		return nil
+	}
+
+	placement := r.cluster.getRegionPlacement(region)
+	for _, peer := range region.GetFollowers() {
+		store := r.cluster.getStore(peer.GetStoreId())
+		if store == nil || store.isBlocked() || !placement.allowLeader(store) {
+			continue
+		}
+		if !filterTarget(store, r.filters) && !rejectLeader.FilterTarget(store) {
+			return newTransferLeader(region, peer)
+		}
+	}
+	return nil
 }
 
 // RegionStat records each hot region's statistics
 type RegionStat struct {
 	RegionID     uint64 `json:"region_id"`
 	WrittenBytes uint64 `json:"written_bytes"`
+	ReadBytes    uint64 `json:"read_bytes,omitempty"`
+	ReadKeys     uint64 `json:"read_keys,omitempty"`
 	// HotDegree records the hot region update times
 	HotDegree int `json:"hot_degree"`
 	// LastUpdateTime used to calculate average write
@@ -419,6 +614,7 @@ func (m RegionsStat) Less(i, j int) bool { return m[i].WrittenBytes < m[j].Writt
 // HotRegionsStat records all hot regions statistics
 type HotRegionsStat struct {
 	WrittenBytes uint64      `json:"total_written_bytes"`
+	ReadBytes    uint64      `json:"total_read_bytes,omitempty"`
 	RegionsCount int         `json:"regions_count"`
 	RegionsStat  RegionsStat `json:"statistics"`
 }
@@ -467,7 +663,7 @@ func (h *balanceHotRegionScheduler) Schedule(cluster *clusterInfo) Operator {
 	// balance by peer
 	srcRegion, srcPeer, destPeer := h.balanceByPeer(cluster)
 	if srcRegion != nil {
-		return newTransferPeer(srcRegion, PriorityKind, srcPeer, destPeer)
+		return newTransferPeer(srcRegion, PriorityKind, srcPeer, destPeer, h.opt)
 	}
 
 	// balance by leader
@@ -482,9 +678,14 @@ func (h *balanceHotRegionScheduler) Schedule(cluster *clusterInfo) Operator {
 func (h *balanceHotRegionScheduler) calcScore(cluster *clusterInfo) {
 	h.Lock()
 	defer h.Unlock()
+	h.statisticsAsPeer, h.statisticsAsLeader = calcHotWriteRegionStats(cluster)
+}
 
-	h.statisticsAsPeer = make(map[uint64]*HotRegionsStat)
-	h.statisticsAsLeader = make(map[uint64]*HotRegionsStat)
+// calcHotWriteRegionStats groups the write hot regions by the stores of their
+// peers and leaders.
+func calcHotWriteRegionStats(cluster *clusterInfo) (asPeer, asLeader map[uint64]*HotRegionsStat) {
+	asPeer = make(map[uint64]*HotRegionsStat)
+	asLeader = make(map[uint64]*HotRegionsStat)
 	items := cluster.writeStatistics.elems()
 	for _, item := range items {
 		r, ok := item.value.(*RegionStat)
@@ -496,22 +697,25 @@ func (h *balanceHotRegionScheduler) calcScore(cluster *clusterInfo) {
 		}
 
 		regionInfo := cluster.getRegion(r.RegionID)
+		if regionInfo == nil {
+			continue
+		}
 		leaderStoreID := regionInfo.Leader.GetStoreId()
 		storeIDs := regionInfo.GetStoreIds()
 		for storeID := range storeIDs {
-			peerStat, ok := h.statisticsAsPeer[storeID]
+			peerStat, ok := asPeer[storeID]
 			if !ok {
 				peerStat = &HotRegionsStat{
 					RegionsStat: make(RegionsStat, 0, storeHotRegionsDefaultLen),
 				}
-				h.statisticsAsPeer[storeID] = peerStat
+				asPeer[storeID] = peerStat
 			}
-			leaderStat, ok := h.statisticsAsLeader[storeID]
+			leaderStat, ok := asLeader[storeID]
 			if !ok {
 				leaderStat = &HotRegionsStat{
 					RegionsStat: make(RegionsStat, 0, storeHotRegionsDefaultLen),
 				}
-				h.statisticsAsLeader[storeID] = leaderStat
+				asLeader[storeID] = leaderStat
 			}
 
 			stat := RegionStat{
@@ -534,6 +738,7 @@ func (h *balanceHotRegionScheduler) calcScore(cluster *clusterInfo) {
 			}
 		}
 	}
+	return asPeer, asLeader
 }
 
 func (h *balanceHotRegionScheduler) balanceByPeer(cluster *clusterInfo) (*RegionInfo, *metapb.Peer, *metapb.Peer) {
@@ -568,8 +773,12 @@ func (h *balanceHotRegionScheduler) balanceByPeer(cluster *clusterInfo) (*Region
 		var filters []Filter
 		filters = append(filters, newExcludedFilter(srcRegion.GetStoreIds(), srcRegion.GetStoreIds()))
 		filters = append(filters, newDistinctScoreFilter(h.opt.GetReplication(), stores, cluster.getLeaderStore(srcRegion)))
+		filters = append(filters, newPlacementRuleFilter(cluster.getRegionPeerRule(srcRegion, srcRegion.GetStorePeer(srcStoreID), h.opt.GetMaxReplicas())))
+		filters = append(filters, newBlockFilter())
 		filters = append(filters, newStateFilter(h.opt))
 		filters = append(filters, newStorageThresholdFilter(h.opt))
+		filters = append(filters, newSnapshotCountFilter(h.opt))
+		filters = append(filters, newPendingPeerCountFilter(h.opt))
 		destStoreIDs := make([]uint64, 0, len(stores))
 		for _, store := range stores {
 			if filterTarget(store, filters) {
@@ -699,7 +908,7 @@ func (h *balanceHotRegionScheduler) balanceByLeader(cluster *clusterInfo) (*Regi
 			continue
 		}
 
-		destPeer := h.selectDestStoreByLeader(srcRegion)
+		destPeer := h.selectDestStoreByLeader(cluster, srcRegion)
 		if destPeer != nil {
 			h.adjustBalanceLimit(srcStoreID, byLeader)
 			return srcRegion, destPeer
@@ -708,7 +917,7 @@ func (h *balanceHotRegionScheduler) balanceByLeader(cluster *clusterInfo) (*Regi
 	return nil, nil
 }
 
-func (h *balanceHotRegionScheduler) selectDestStoreByLeader(srcRegion *RegionInfo) *metapb.Peer {
+func (h *balanceHotRegionScheduler) selectDestStoreByLeader(cluster *clusterInfo, srcRegion *RegionInfo) *metapb.Peer {
 	sr := h.statisticsAsLeader[srcRegion.Leader.GetStoreId()]
 	srcWrittenBytes := sr.WrittenBytes
 	srcHotRegionsCount := sr.RegionsStat.Len()
@@ -719,6 +928,11 @@ func (h *balanceHotRegionScheduler) selectDestStoreByLeader(srcRegion *RegionInf
 	)
 	minRegionsCount := int(math.MaxInt32)
 	for storeID, peer := range srcRegion.GetFollowers() {
+		// The blocked stores, such as the ones whose leaders are evicted,
+		// should not get new leaders.
+		if store := cluster.getStore(storeID); store == nil || store.isBlocked() || newRejectLeaderFilter(h.opt).FilterTarget(store) {
+			continue
+		}
 		if s, ok := h.statisticsAsLeader[storeID]; ok {
 			if srcHotRegionsCount-s.RegionsStat.Len() > 1 && minRegionsCount > s.RegionsStat.Len() {
 				destPeer = peer
@@ -739,6 +953,22 @@ func (h *balanceHotRegionScheduler) selectDestStoreByLeader(srcRegion *RegionInf
 	return destPeer
 }
 
+// StoreHotStats records the flow of the stores. The stores only report the
+// written flow, the read flow is summed from the read hot regions.
+type StoreHotStats struct {
+	BytesWritten map[uint64]uint64 `json:"bytes_written"`
+	KeysWritten  map[uint64]uint64 `json:"keys_written"`
+	HotBytesRead map[uint64]uint64 `json:"hot_bytes_read"`
+}
+
+func newStoreHotStats() *StoreHotStats {
+	return &StoreHotStats{
+		BytesWritten: make(map[uint64]uint64),
+		KeysWritten:  make(map[uint64]uint64),
+		HotBytesRead: make(map[uint64]uint64),
+	}
+}
+
 // StoreHotRegionInfos : used to get human readable description for hot regions.
 type StoreHotRegionInfos struct {
 	AsPeer   map[uint64]*HotRegionsStat `json:"as_peer"`
@@ -763,3 +993,188 @@ func (h *balanceHotRegionScheduler) GetStatus() *StoreHotRegionInfos {
 		AsLeader: asLeader,
 	}
 }
+
+// balanceHotReadRegionScheduler transfers the leaders of the read hot regions
+// away from the stores whose read flow exceeds the average, since the reads
+// are served by the leaders.
+type balanceHotReadRegionScheduler struct {
+	sync.RWMutex
+	opt     *scheduleOption
+	limit   uint64
+	filters []Filter
+
+	// store id -> read hot regions statistics as the role of leader
+	statistics map[uint64]*HotRegionsStat
+	r          *rand.Rand
+}
+
+func newBalanceHotReadRegionScheduler(opt *scheduleOption) *balanceHotReadRegionScheduler {
+	var filters []Filter
+	filters = append(filters, newBlockFilter())
+	filters = append(filters, newStateFilter(opt))
+	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newRejectLeaderFilter(opt))
+
+	return &balanceHotReadRegionScheduler{
+		opt:        opt,
+		limit:      1,
+		filters:    filters,
+		statistics: make(map[uint64]*HotRegionsStat),
+		r:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (h *balanceHotReadRegionScheduler) GetName() string {
+	return hotReadRegionScheduleName
+}
+
+func (h *balanceHotReadRegionScheduler) GetResourceKind() ResourceKind {
+	return PriorityKind
+}
+
+func (h *balanceHotReadRegionScheduler) GetResourceLimit() uint64 {
+	return h.limit
+}
+
+func (h *balanceHotReadRegionScheduler) Prepare(cluster *clusterInfo) error { return nil }
+
+func (h *balanceHotReadRegionScheduler) Cleanup(cluster *clusterInfo) {}
+
+func (h *balanceHotReadRegionScheduler) Schedule(cluster *clusterInfo) Operator {
+	h.calcScore(cluster)
+
+	srcRegion, newLeader := h.balanceByLeader(cluster)
+	if srcRegion == nil {
+		return nil
+	}
+	return newPriorityTransferLeader(srcRegion, newLeader)
+}
+
+func (h *balanceHotReadRegionScheduler) calcScore(cluster *clusterInfo) {
+	h.Lock()
+	defer h.Unlock()
+	h.statistics = calcHotReadRegionStats(cluster)
+}
+
+// calcHotReadRegionStats groups the read hot regions by the stores of their
+// leaders, which serve the reads.
+func calcHotReadRegionStats(cluster *clusterInfo) map[uint64]*HotRegionsStat {
+	statistics := make(map[uint64]*HotRegionsStat)
+	for _, item := range cluster.readStatistics.elems() {
+		r, ok := item.value.(*RegionStat)
+		if !ok || r.HotDegree < hotRegionLowThreshold {
+			continue
+		}
+		regionInfo := cluster.getRegion(r.RegionID)
+		if regionInfo == nil || regionInfo.Leader == nil {
+			continue
+		}
+
+		leaderStoreID := regionInfo.Leader.GetStoreId()
+		stat, ok := statistics[leaderStoreID]
+		if !ok {
+			stat = &HotRegionsStat{
+				RegionsStat: make(RegionsStat, 0, storeHotRegionsDefaultLen),
+			}
+			statistics[leaderStoreID] = stat
+		}
+		regionStat := *r
+		regionStat.StoreID = leaderStoreID
+		stat.ReadBytes += r.ReadBytes
+		stat.RegionsCount++
+		stat.RegionsStat = append(stat.RegionsStat, regionStat)
+	}
+	return statistics
+}
+
+func (h *balanceHotReadRegionScheduler) balanceByLeader(cluster *clusterInfo) (*RegionInfo, *metapb.Peer) {
+	var upStoreCount, totalReadBytes, totalRegionCount uint64
+	for _, s := range cluster.getStores() {
+		if s.isUp() {
+			upStoreCount++
+		}
+	}
+	for _, stat := range h.statistics {
+		totalReadBytes += stat.ReadBytes
+		totalRegionCount += uint64(stat.RegionsCount)
+	}
+	if upStoreCount == 0 {
+		return nil, nil
+	}
+	avgReadBytes := totalReadBytes / upStoreCount
+
+	// select the store with the most read flow above the average
+	var (
+		srcStoreID   uint64
+		maxReadBytes uint64
+	)
+	for storeID, stat := range h.statistics {
+		if stat.ReadBytes > avgReadBytes && stat.ReadBytes > maxReadBytes {
+			srcStoreID = storeID
+			maxReadBytes = stat.ReadBytes
+		}
+	}
+	if srcStoreID == 0 {
+		return nil, nil
+	}
+
+	srcStatistics := h.statistics[srcStoreID]
+	for _, i := range h.r.Perm(srcStatistics.RegionsStat.Len()) {
+		rs := srcStatistics.RegionsStat[i]
+		srcRegion := cluster.getRegion(rs.RegionID)
+		if srcRegion == nil || len(srcRegion.DownPeers) != 0 || len(srcRegion.PendingPeers) != 0 {
+			continue
+		}
+
+		destPeer := h.selectDestStoreByLeader(cluster, srcRegion, srcStatistics.ReadBytes, rs.ReadBytes)
+		if destPeer != nil {
+			// Multiplied by hotRegionLimitFactor to avoid transfer back and forth
+			avgRegionCount := float64(totalRegionCount) / float64(upStoreCount)
+			limit := uint64((float64(srcStatistics.RegionsCount) - avgRegionCount) * hotRegionLimitFactor)
+			h.limit = maxUint64(1, limit)
+			return srcRegion, destPeer
+		}
+	}
+	return nil, nil
+}
+
+// selectDestStoreByLeader selects the follower with the least read flow, which
+// is still less than the source store after the region is transferred.
+func (h *balanceHotReadRegionScheduler) selectDestStoreByLeader(cluster *clusterInfo, srcRegion *RegionInfo, srcReadBytes, regionReadBytes uint64) *metapb.Peer {
+	placement := cluster.getRegionPlacement(srcRegion)
+
+	var (
+		destPeer     *metapb.Peer
+		minReadBytes uint64 = math.MaxUint64
+	)
+	for storeID, peer := range srcRegion.GetFollowers() {
+		store := cluster.getStore(storeID)
+		if store == nil || filterTarget(store, h.filters) || !placement.allowLeader(store) {
+			continue
+		}
+		var readBytes uint64
+		if s, ok := h.statistics[storeID]; ok {
+			readBytes = s.ReadBytes
+		}
+		if readBytes+regionReadBytes >= uint64(float64(srcReadBytes)*hotRegionScheduleFactor) {
+			continue
+		}
+		if readBytes < minReadBytes {
+			minReadBytes = readBytes
+			destPeer = peer
+		}
+	}
+	return destPeer
+}
+
+// GetStatus returns the read hot regions statistics of the stores.
+func (h *balanceHotReadRegionScheduler) GetStatus() map[uint64]*HotRegionsStat {
+	h.RLock()
+	defer h.RUnlock()
+	status := make(map[uint64]*HotRegionsStat, len(h.statistics))
+	for id, stat := range h.statistics {
+		clone := *stat
+		status[id] = &clone
+	}
+	return status
+}
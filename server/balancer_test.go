@@ -14,12 +14,13 @@
 package server
 
 import (
+	"fmt"
 	"math"
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 type testClusterInfo struct {
@@ -64,10 +65,20 @@ func (c *testClusterInfo) addLeaderStore(storeID uint64, leaderCount int) {
 	c.putStore(store)
 }
 
+func (c *testClusterInfo) addLabelsLeaderStore(storeID uint64, leaderCount int, labels map[string]string) {
+	c.addLeaderStore(storeID, leaderCount)
+	store := c.getStore(storeID)
+	for k, v := range labels {
+		store.Labels = append(store.Labels, &metapb.StoreLabel{Key: k, Value: v})
+	}
+	c.putStore(store)
+}
+
 func (c *testClusterInfo) addRegionStore(storeID uint64, regionCount int) {
 	store := newStoreInfo(&metapb.Store{Id: storeID})
 	store.status.LastHeartbeatTS = time.Now()
 	store.status.RegionCount = regionCount
+	store.status.RegionSize = uint64(regionCount) * defaultRegionSize
 	store.status.Capacity = uint64(1024)
 	store.status.Available = store.status.Capacity
 	c.putStore(store)
@@ -93,9 +104,31 @@ func (c *testClusterInfo) addLeaderRegion(regionID uint64, leaderID uint64, foll
 	c.putRegion(newRegionInfo(region, leader))
 }
 
+func (c *testClusterInfo) addLeaderRegionInRange(regionID uint64, start, end string, size uint64, leaderID uint64, followerIds ...uint64) {
+	region := &metapb.Region{
+		Id:          regionID,
+		StartKey:    []byte(start),
+		EndKey:      []byte(end),
+		RegionEpoch: &metapb.RegionEpoch{Version: 1},
+	}
+	leader, _ := c.allocPeer(leaderID)
+	region.Peers = []*metapb.Peer{leader}
+	for _, id := range followerIds {
+		peer, _ := c.allocPeer(id)
+		region.Peers = append(region.Peers, peer)
+	}
+	regionInfo := newRegionInfo(region, leader)
+	regionInfo.ApproximateSize = size
+	c.putRegion(regionInfo)
+}
+
 func (c *testClusterInfo) LoadRegion(regionID uint64, followerIds ...uint64) {
 	//  regions load from etcd will have no leader
-	region := &metapb.Region{Id: regionID}
+	region := &metapb.Region{
+		Id:       regionID,
+		StartKey: []byte(fmt.Sprintf("%020d", regionID)),
+		EndKey:   []byte(fmt.Sprintf("%020d", regionID+1)),
+	}
 	region.Peers = []*metapb.Peer{}
 	for _, id := range followerIds {
 		peer, _ := c.allocPeer(id)
@@ -118,6 +151,20 @@ func (c *testClusterInfo) addLeaderRegionWithWriteInfo(regionID uint64, leaderID
 	c.putRegion(r)
 }
 
+func (c *testClusterInfo) addLeaderRegionWithReadInfo(regionID uint64, leaderID uint64, readBytes uint64, followerIds ...uint64) {
+	region := &metapb.Region{Id: regionID}
+	leader, _ := c.allocPeer(leaderID)
+	region.Peers = []*metapb.Peer{leader}
+	for _, id := range followerIds {
+		peer, _ := c.allocPeer(id)
+		region.Peers = append(region.Peers, peer)
+	}
+	r := newRegionInfo(region, leader)
+	r.ReadBytes = readBytes
+	c.updateReadStatus(r)
+	c.putRegion(r)
+}
+
 func (c *testClusterInfo) updateLeaderCount(storeID uint64, leaderCount int) {
 	store := c.getStore(storeID)
 	store.status.LeaderCount = leaderCount
@@ -127,6 +174,13 @@ func (c *testClusterInfo) updateLeaderCount(storeID uint64, leaderCount int) {
 func (c *testClusterInfo) updateRegionCount(storeID uint64, regionCount int) {
 	store := c.getStore(storeID)
 	store.status.RegionCount = regionCount
+	store.status.RegionSize = uint64(regionCount) * defaultRegionSize
+	c.putStore(store)
+}
+
+func (c *testClusterInfo) updateRegionSize(storeID uint64, regionSize uint64) {
+	store := c.getStore(storeID)
+	store.status.RegionSize = regionSize
 	c.putStore(store)
 }
 
@@ -136,6 +190,12 @@ func (c *testClusterInfo) updateSnapshotCount(storeID uint64, snapshotCount int)
 	c.putStore(store)
 }
 
+func (c *testClusterInfo) updateReceivingSnapshotCount(storeID uint64, snapshotCount int) {
+	store := c.getStore(storeID)
+	store.status.ReceivingSnapCount = uint32(snapshotCount)
+	c.putStore(store)
+}
+
 func (c *testClusterInfo) updateStorageRatio(storeID uint64, usedRatio, availableRatio float64) {
 	store := c.getStore(storeID)
 	store.status.Capacity = uint64(1024)
@@ -153,6 +213,8 @@ func (c *testClusterInfo) updateStorageWrittenBytes(storeID uint64, BytesWritten
 func newTestScheduleConfig() (*ScheduleConfig, *scheduleOption) {
 	cfg := NewConfig()
 	cfg.adjust()
+	// The regions are checked by dispatch in the tests, not by the patrol.
+	cfg.Schedule.PatrolRegionInterval.Duration = 0
 	opt := newScheduleOption(cfg)
 	return &cfg.Schedule, opt
 }
@@ -191,13 +253,30 @@ func (s *testBalanceSpeedSuite) TestBalanceSpeed(c *C) {
 		{10000, 9901, false},
 	}
 
-	s.testBalanceSpeed(c, testCases, 1)
-	s.testBalanceSpeed(c, testCases, 10)
-	s.testBalanceSpeed(c, testCases, 100)
-	s.testBalanceSpeed(c, testCases, 1000)
+	_, opt := newTestScheduleConfig()
+	s.testBalanceSpeed(c, testCases, 1, opt)
+	s.testBalanceSpeed(c, testCases, 10, opt)
+	s.testBalanceSpeed(c, testCases, 100, opt)
+	s.testBalanceSpeed(c, testCases, 1000, opt)
 }
 
-func (s *testBalanceSpeedSuite) testBalanceSpeed(c *C, tests []testBalanceSpeedCase, capaGB uint64) {
+func (s *testBalanceSpeedSuite) TestTolerantSizeRatio(c *C) {
+	testCases := []testBalanceSpeedCase{
+		// diff >= 5
+		{4, 0, false},
+		{5, 0, true},
+		{10, 5, true},
+		{10, 6, false},
+		{100, 95, true},
+		{100, 96, false},
+	}
+
+	cfg, opt := newTestScheduleConfig()
+	cfg.TolerantSizeRatio = 5
+	s.testBalanceSpeed(c, testCases, 1, opt)
+}
+
+func (s *testBalanceSpeedSuite) testBalanceSpeed(c *C, tests []testBalanceSpeedCase, capaGB uint64, opt *scheduleOption) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	tc := newTestClusterInfo(cluster)
 
@@ -206,7 +285,7 @@ func (s *testBalanceSpeedSuite) testBalanceSpeed(c *C, tests []testBalanceSpeedC
 		tc.addLeaderStore(2, int(t.targetCount))
 		source := cluster.getStore(1)
 		target := cluster.getStore(2)
-		c.Assert(shouldBalance(source, target, LeaderKind), Equals, t.expectedResult)
+		c.Assert(shouldBalance(source, target, LeaderKind, opt), Equals, t.expectedResult)
 	}
 
 	for _, t := range tests {
@@ -214,7 +293,7 @@ func (s *testBalanceSpeedSuite) testBalanceSpeed(c *C, tests []testBalanceSpeedC
 		tc.addRegionStore(2, int(t.targetCount))
 		source := cluster.getStore(1)
 		target := cluster.getStore(2)
-		c.Assert(shouldBalance(source, target, RegionKind), Equals, t.expectedResult)
+		c.Assert(shouldBalance(source, target, RegionKind, opt), Equals, t.expectedResult)
 	}
 }
 
@@ -337,6 +416,98 @@ func (s *testBalanceLeaderSchedulerSuite) TestBalanceSelector(c *C) {
 	checkTransferLeader(c, s.schedule(), 3, 1)
 }
 
+func (s *testBalanceLeaderSchedulerSuite) TestLeaderWeights(c *C) {
+	cfg, opt := newTestScheduleConfig()
+	cfg.LeaderWeightLabel = "zone"
+	cfg.LeaderWeights = "z1:75,z2:25"
+	s.cluster.setLeaderWeights(opt.GetLeaderWeights())
+	lb := newBalanceLeaderScheduler(opt)
+
+	// Stores:     1    2    3    4
+	// Zone:       z1   z1   z2   z2
+	// Leaders:    30   30   30   36
+	// Score:      20   20   60   72
+	// Region1:    F    -    L    -
+	// Region2:    -    F    -    L
+	s.tc.addLabelsLeaderStore(1, 30, map[string]string{"zone": "z1"})
+	s.tc.addLabelsLeaderStore(2, 30, map[string]string{"zone": "z1"})
+	s.tc.addLabelsLeaderStore(3, 30, map[string]string{"zone": "z2"})
+	s.tc.addLabelsLeaderStore(4, 36, map[string]string{"zone": "z2"})
+	s.tc.addLeaderRegion(1, 3, 1)
+	s.tc.addLeaderRegion(2, 4, 2)
+	checkTransferLeader(c, lb.Schedule(s.cluster), 4, 2)
+
+	// 75% of the leaders are in z1.
+	s.tc.updateLeaderCount(1, 45)
+	s.tc.updateLeaderCount(2, 45)
+	s.tc.updateLeaderCount(3, 15)
+	s.tc.updateLeaderCount(4, 15)
+	c.Assert(lb.Schedule(s.cluster), IsNil)
+
+	// Stores in z2 should have no leader.
+	cfg.LeaderWeights = "z1:100"
+	s.cluster.setLeaderWeights(opt.GetLeaderWeights())
+	s.tc.updateLeaderCount(1, 10)
+	s.tc.updateLeaderCount(2, 10)
+	s.tc.updateLeaderCount(3, 1)
+	s.tc.updateLeaderCount(4, 0)
+	checkTransferLeader(c, lb.Schedule(s.cluster), 3, 1)
+
+	// The weights of the new stores are set without scheduling.
+	s.tc.addLabelsLeaderStore(5, 0, map[string]string{"zone": "z1"})
+	c.Assert(s.cluster.getStore(5).status.labelLeaderWeight, Equals, s.cluster.getStore(1).status.labelLeaderWeight)
+	c.Assert(s.cluster.getStore(5).leaderWeight(), Greater, 0.0)
+	c.Assert(s.cluster.getStore(3).leaderWeight(), Equals, 0.0)
+
+	// Leader weights are not used if the label is not set.
+	cfg.LeaderWeightLabel = ""
+	s.cluster.setLeaderWeights(opt.GetLeaderWeights())
+	for _, store := range s.cluster.getStores() {
+		c.Assert(store.status.labelLeaderWeight, Equals, 1.0)
+	}
+}
+
+func (s *testBalanceLeaderSchedulerSuite) TestRejectLeader(c *C) {
+	cfg, opt := newTestScheduleConfig()
+	cfg.RejectLeaderLabels = "zone=dr"
+	lb := newBalanceLeaderScheduler(opt)
+
+	// Stores:     1    2    3
+	// Zone:       dr   z1   z2
+	// Leaders:    0    20   10
+	// Region1:    F    L    F
+	s.tc.addLabelsLeaderStore(1, 0, map[string]string{"zone": "dr"})
+	s.tc.addLabelsLeaderStore(2, 20, map[string]string{"zone": "z1"})
+	s.tc.addLabelsLeaderStore(3, 10, map[string]string{"zone": "z2"})
+	s.tc.addLeaderRegion(1, 2, 1, 3)
+	for i := 0; i < 10; i++ {
+		checkTransferLeader(c, lb.Schedule(s.cluster), 2, 3)
+	}
+
+	// The leaders are not moved to store 1 even if store 3 is unavailable.
+	s.tc.setStoreBusy(3, true)
+	c.Assert(lb.Schedule(s.cluster), IsNil)
+}
+
+func (s *testBalanceLeaderSchedulerSuite) TestStoreWeights(c *C) {
+	// Stores:     1    2
+	// Weight:     2    1
+	// Leaders:    60   45
+	// Score:      30   45
+	// Region1:    F    L
+	s.tc.addLeaderStore(1, 60)
+	s.tc.addLeaderStore(2, 45)
+	s.tc.addLeaderRegion(1, 2, 1)
+	c.Assert(s.cluster.setStoreWeight(&StoreWeight{StoreID: 1, Leader: 2, Region: 1}), IsNil)
+	checkTransferLeader(c, s.schedule(), 2, 1)
+
+	// Store 1 should have no leader.
+	c.Assert(s.cluster.setStoreWeight(&StoreWeight{StoreID: 1, Leader: 0, Region: 1}), IsNil)
+	c.Assert(s.schedule(), IsNil)
+
+	c.Assert(s.cluster.setStoreWeight(&StoreWeight{StoreID: 3, Leader: 1, Region: 1}), NotNil)
+}
+
 var _ = Suite(&testBalanceRegionSchedulerSuite{})
 
 type testBalanceRegionSchedulerSuite struct{}
@@ -377,6 +548,94 @@ func (s *testBalanceRegionSchedulerSuite) TestBalance(c *C) {
 	c.Assert(sb.Schedule(cluster), NotNil)
 }
 
+func (s *testBalanceRegionSchedulerSuite) TestBalanceLowSpace(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	sb := newBalanceRegionScheduler(opt)
+
+	opt.SetMaxReplicas(1)
+
+	tc.addRegionStore(1, 10)
+	tc.addRegionStore(2, 10)
+	tc.addRegionStore(3, 10)
+	tc.addRegionStore(4, 10)
+	tc.addLeaderRegion(1, 1)
+	c.Assert(sb.Schedule(cluster), IsNil)
+
+	// Store 1 is short of space, and stores 2 and 3 use more than the
+	// high-space-ratio.
+	tc.updateStorageRatio(1, 0.95, 0.05)
+	tc.updateStorageRatio(2, 0.85, 0.15)
+	tc.updateStorageRatio(3, 0.85, 0.15)
+	sb.cache.delete(1)
+	checkTransferPeer(c, sb.Schedule(cluster), 1, 4)
+
+	// No store can take the region.
+	tc.updateStorageRatio(4, 0.85, 0.15)
+	c.Assert(sb.Schedule(cluster), IsNil)
+}
+
+func (s *testBalanceRegionSchedulerSuite) TestBalancePendingPeers(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	sb := newBalanceRegionScheduler(opt)
+
+	opt.SetMaxReplicas(1)
+
+	tc.addRegionStore(1, 16)
+	tc.addRegionStore(2, 6)
+	tc.addRegionStore(3, 7)
+	tc.addLeaderRegion(1, 1)
+
+	// Store 2 is still catching up with too many peers.
+	store := cluster.getStore(2)
+	store.status.PendingPeerCount = int(opt.GetMaxPendingPeerCount()) + 1
+	cluster.putStore(store)
+	checkTransferPeer(c, sb.Schedule(cluster), 1, 3)
+
+	// Store 2 has fewer pending peers than store 3 with the same score.
+	tc.updateRegionCount(2, 7)
+	tc.updateRegionSize(2, 7*defaultRegionSize)
+	store = cluster.getStore(2)
+	store.status.PendingPeerCount = 1
+	cluster.putStore(store)
+	store = cluster.getStore(3)
+	store.status.PendingPeerCount = 2
+	cluster.putStore(store)
+	checkTransferPeer(c, sb.Schedule(cluster), 1, 2)
+}
+
+func (s *testBalanceRegionSchedulerSuite) TestBalanceBySize(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	sb := newBalanceRegionScheduler(opt)
+
+	opt.SetMaxReplicas(1)
+
+	// Store 1 has more regions, but they are much smaller.
+	tc.addRegionStore(1, 10)
+	tc.addRegionStore(2, 4)
+	tc.updateRegionSize(1, 10*1024*1024)
+	tc.updateRegionSize(2, 4*defaultRegionSize)
+	tc.addLeaderRegion(1, 2)
+	checkTransferPeer(c, sb.Schedule(cluster), 2, 1)
+
+	// The sizes are close.
+	tc.updateRegionSize(1, 3*defaultRegionSize)
+	c.Assert(sb.Schedule(cluster), IsNil)
+
+	// Store 1 can carry double regions.
+	c.Assert(cluster.setStoreWeight(&StoreWeight{StoreID: 1, Leader: 1, Region: 2}), IsNil)
+	sb.cache.delete(2)
+	checkTransferPeer(c, sb.Schedule(cluster), 2, 1)
+}
+
 func (s *testBalanceRegionSchedulerSuite) TestReplicas3(c *C) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	tc := newTestClusterInfo(cluster)
@@ -517,6 +776,13 @@ func (s *testReplicaCheckerSuite) TestBasic(c *C) {
 	// If snapshotCount < MaxSnapshotCount, we can add peer again.
 	tc.updateSnapshotCount(4, 1)
 	checkAddPeer(c, rc.Check(region), 4)
+	// The leader in store 1 sends too many snapshots.
+	store := cluster.getStore(1)
+	store.status.SendingSnapCount = 4
+	cluster.putStore(store)
+	c.Assert(rc.Check(region), IsNil)
+	store.status.SendingSnapCount = 0
+	cluster.putStore(store)
 
 	// Test storageThresholdFilter.
 	// If availableRatio < storageAvailableRatioThreshold(0.2), we can not add peer.
@@ -539,16 +805,25 @@ func (s *testReplicaCheckerSuite) TestBasic(c *C) {
 	checkRemovePeer(c, rc.Check(region), 1)
 	region.RemoveStorePeer(1)
 
-	// Peer in store 2 is down, remove it.
+	// Peer in store 2 is down, replace it with a peer in store 1.
 	tc.setStoreDown(2)
 	downPeer := &pdpb.PeerStats{
 		Peer:        region.GetStorePeer(2),
 		DownSeconds: 24 * 60 * 60,
 	}
 	region.DownPeers = append(region.DownPeers, downPeer)
-	checkRemovePeer(c, rc.Check(region), 2)
+	checkTransferPeer(c, rc.Check(region), 2, 1)
+	// No heartbeat of store 2 is received, and the leader doesn't report the
+	// peer is down.
 	region.DownPeers = nil
 	c.Assert(rc.Check(region), IsNil)
+	// Store 2 misses its heartbeats for too long.
+	store = cluster.getStore(2)
+	store.status.LastHeartbeatTS = time.Now().Add(-opt.GetMaxStoreDownTime() - time.Minute)
+	cluster.putStore(store)
+	checkTransferPeer(c, rc.Check(region), 2, 1)
+	tc.setStoreUp(2)
+	c.Assert(rc.Check(region), IsNil)
 
 	// Peer in store 3 is offline, transfer peer to store 1.
 	tc.setStoreOffline(3)
@@ -621,13 +896,57 @@ func (s *testReplicaCheckerSuite) TestOffline(c *C) {
 	// Transfer peer to store 4.
 	checkTransferPeer(c, rc.Check(region), 3, 4)
 
-	// Store 5 has a different zone, we can keep it safe.
-	tc.addLabelsStore(5, 5, map[string]string{"zone": "z4", "rack": "r1", "host": "h1"})
+	// Store 5 has a different zone and smaller region score.
+	tc.addLabelsStore(5, 1, map[string]string{"zone": "z4", "rack": "r1", "host": "h1"})
 	checkTransferPeer(c, rc.Check(region), 3, 5)
 	tc.updateSnapshotCount(5, 10)
 	c.Assert(rc.Check(region), IsNil)
 }
 
+func (s *testReplicaCheckerSuite) TestRejectLeader(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	cfg, opt := newTestScheduleConfig()
+	cfg.RejectLeaderLabels = "zone=dr"
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"zone": "dr"})
+	tc.addLabelsStore(2, 1, map[string]string{"zone": "dr"})
+	tc.addLabelsStore(3, 1, map[string]string{"zone": "z1"})
+	tc.addLeaderRegion(1, 1, 2, 3)
+	checkTransferLeader(c, rc.Check(cluster.getRegion(1)), 1, 3)
+
+	// No follower is able to take the leader.
+	tc.setStoreBusy(3, true)
+	c.Assert(rc.Check(cluster.getRegion(1)), IsNil)
+	tc.setStoreBusy(3, false)
+	tc.addLeaderRegion(1, 3, 1, 2)
+	c.Assert(rc.Check(cluster.getRegion(1)), IsNil)
+}
+
+func (s *testReplicaCheckerSuite) TestOfflineIsolation(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	opt.rep = newTestReplication(3, "zone", "host")
+
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"zone": "z1", "host": "h1"})
+	tc.addLabelsStore(2, 1, map[string]string{"zone": "z2", "host": "h1"})
+	tc.addLabelsStore(3, 1, map[string]string{"zone": "z3", "host": "h1"})
+	tc.addLabelsStore(4, 9, map[string]string{"zone": "z1", "host": "h2"})
+	tc.addLabelsStore(5, 1, map[string]string{"zone": "z2", "host": "h2"})
+	tc.addLeaderRegion(1, 1, 2, 3)
+
+	// Store 5 has a smaller region score, but only store 4 keeps the
+	// replicas in different zones after store 1 is gone.
+	tc.setStoreOffline(1)
+	checkTransferPeer(c, rc.Check(tc.getRegion(1)), 1, 4)
+}
+
 func (s *testReplicaCheckerSuite) TestDistinctScore(c *C) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	tc := newTestClusterInfo(cluster)
@@ -746,7 +1065,11 @@ func checkAddPeer(c *C, bop Operator, storeID uint64) {
 	case *regionOperator:
 		op = t.Ops[0].(*changePeerOperator)
 	}
-	c.Assert(op.ChangePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddNode)
+	changeType := pdpb.ConfChangeType_AddNode
+	if op.ChangePeer.GetPeer().GetRole() == metapb.PeerRole_Learner {
+		changeType = pdpb.ConfChangeType_AddLearnerNode
+	}
+	c.Assert(op.ChangePeer.GetChangeType(), Equals, changeType)
 	c.Assert(op.ChangePeer.GetPeer().GetStoreId(), Equals, storeID)
 }
 
@@ -876,3 +1199,85 @@ func (s *testBalanceHotRegionSchedulerSuite) TestBalance(c *C) {
 	// so one of the leader will transfer to another store.
 	checkTransferLeaderFrom(c, hb.Schedule(cluster), 1)
 }
+
+func (s *testBalanceHotRegionSchedulerSuite) TestHotStats(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 1)
+	tc.updateStorageWrittenBytes(1, 60*1024*1024)
+
+	tc.addLeaderRegionWithWriteInfo(1, 1, 512*1024*regionHeartBeatReportInterval, 2)
+	tc.addLeaderRegionWithWriteInfo(2, 2, 512*1024*regionHeartBeatReportInterval, 1, 3)
+	tc.addLeaderRegionWithReadInfo(3, 1, 512*1024*regionHeartBeatReportInterval, 2)
+	defer func(threshold int) { hotRegionLowThreshold = threshold }(hotRegionLowThreshold)
+	hotRegionLowThreshold = 0
+
+	// The statistics are calculated from the hot cache without the schedulers.
+	asPeer, asLeader := calcHotWriteRegionStats(cluster)
+	c.Assert(asPeer, HasLen, 3)
+	c.Assert(asPeer[1].RegionsCount, Equals, 2)
+	c.Assert(asPeer[1].WrittenBytes, Equals, uint64(2*512*1024))
+	c.Assert(asPeer[3].RegionsCount, Equals, 1)
+	c.Assert(asLeader[1].RegionsCount, Equals, 1)
+	c.Assert(asLeader[3].RegionsCount, Equals, 0)
+
+	read := calcHotReadRegionStats(cluster)
+	c.Assert(read, HasLen, 1)
+	c.Assert(read[1].ReadBytes, Equals, uint64(512*1024))
+
+	stats := cluster.getStoresWriteStat()
+	c.Assert(stats.BytesWritten[1], Equals, uint64(60*1024*1024))
+	c.Assert(stats.BytesWritten, HasLen, 3)
+}
+
+var _ = Suite(&testBalanceHotReadRegionSchedulerSuite{})
+
+type testBalanceHotReadRegionSchedulerSuite struct{}
+
+func (s *testBalanceHotReadRegionSchedulerSuite) TestBalance(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	hb := newBalanceHotReadRegionScheduler(opt)
+
+	// Add stores 1, 2, 3, 4.
+	tc.addRegionStore(1, 3)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addRegionStore(4, 2)
+
+	// Region 1, 2, 3 and 4 are read hot regions.
+	//| region_id | leader_sotre | follower_store | follower_store | read_bytes |
+	//|-----------|--------------|----------------|----------------|------------|
+	//|     1     |       1      |        2       |       3        |    512KB   |
+	//|     2     |       1      |        3       |       4        |    512KB   |
+	//|     3     |       1      |        2       |       4        |    512KB   |
+	//|     4     |       2      |        1       |       3        |    512KB   |
+	tc.addLeaderRegionWithReadInfo(1, 1, 512*1024*regionHeartBeatReportInterval, 2, 3)
+	tc.addLeaderRegionWithReadInfo(2, 1, 512*1024*regionHeartBeatReportInterval, 3, 4)
+	tc.addLeaderRegionWithReadInfo(3, 1, 512*1024*regionHeartBeatReportInterval, 2, 4)
+	tc.addLeaderRegionWithReadInfo(4, 2, 512*1024*regionHeartBeatReportInterval, 1, 3)
+	defer func(threshold int) { hotRegionLowThreshold = threshold }(hotRegionLowThreshold)
+	hotRegionLowThreshold = 0
+
+	// The read flow of store 1 exceeds the average, one of its leaders is
+	// transferred to a store with less read flow.
+	op := hb.Schedule(cluster)
+	checkTransferLeaderFrom(c, op, 1)
+	c.Assert(op.(*regionOperator).Ops[0].(*transferLeaderOperator).NewLeader.GetStoreId(), Not(Equals), uint64(2))
+	c.Assert(hb.GetStatus()[1].ReadBytes, Equals, uint64(3*512*1024))
+
+	// The read flow of each store is the average after the leaders are
+	// transferred to store 3 and 4.
+	tc.addLeaderRegionWithReadInfo(1, 3, 512*1024*regionHeartBeatReportInterval, 1, 2)
+	tc.addLeaderRegionWithReadInfo(2, 4, 512*1024*regionHeartBeatReportInterval, 1, 3)
+	c.Assert(hb.Schedule(cluster), IsNil)
+
+	// The cold regions are not counted.
+	c.Assert(cluster.readStatistics.len(), Equals, 4)
+	tc.addLeaderRegionWithReadInfo(5, 1, 1024, 2, 3)
+	c.Assert(cluster.readStatistics.len(), Equals, 4)
+}
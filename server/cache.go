@@ -21,8 +21,8 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/gogo/protobuf/proto"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 var (
@@ -74,6 +74,45 @@ func (s *storesInfo) blockStore(storeID uint64) error {
 	return nil
 }
 
+// setLeaderWeights sets the label leader weight of each store by its value of
+// the label. The weights are normalized so that the average weight of the up
+// stores is 1. If weights is nil, all stores have the same weight.
+func (s *storesInfo) setLeaderWeights(label string, weights map[string]float64) {
+	counts := make(map[string]int)
+	var total int
+	for _, store := range s.stores {
+		if store.isUp() {
+			counts[store.getLabelValue(label)]++
+			total++
+		}
+	}
+	for _, store := range s.stores {
+		if weights == nil {
+			store.status.labelLeaderWeight = 1
+			continue
+		}
+		value := store.getLabelValue(label)
+		count := counts[value]
+		if count == 0 {
+			count = 1
+		}
+		store.status.labelLeaderWeight = weights[value] * float64(total) / float64(count)
+	}
+}
+
+func (s *storesInfo) setOpInfluence(influence opInfluence) {
+	for id, store := range s.stores {
+		store.status.influence = influence.getStoreInfluence(id)
+	}
+}
+
+func (s *storesInfo) setStoreWeight(weight *StoreWeight) {
+	if store, ok := s.stores[weight.StoreID]; ok {
+		store.status.LeaderWeight = weight.Leader
+		store.status.RegionWeight = weight.Region
+	}
+}
+
 func (s *storesInfo) unblockStore(storeID uint64) {
 	store, ok := s.stores[storeID]
 	if !ok {
@@ -114,10 +153,24 @@ func (s *storesInfo) setRegionCount(storeID uint64, regionCount int) {
 	}
 }
 
+func (s *storesInfo) setRegionSize(storeID uint64, regionSize uint64) {
+	if store, ok := s.stores[storeID]; ok {
+		store.status.RegionSize = regionSize
+	}
+}
+
+func (s *storesInfo) setPendingPeerCount(storeID uint64, pendingPeerCount int) {
+	if store, ok := s.stores[storeID]; ok {
+		store.status.PendingPeerCount = pendingPeerCount
+	}
+}
+
 // regionMap wraps a map[uint64]*RegionInfo and supports randomly pick a region.
 type regionMap struct {
 	m   map[uint64]*regionEntry
 	ids []uint64
+	// totalSize is the total size of the regions.
+	totalSize uint64
 }
 
 type regionEntry struct {
@@ -148,11 +201,21 @@ func (rm *regionMap) Get(id uint64) *RegionInfo {
 	return nil
 }
 
+// TotalSize returns the total size of the regions.
+func (rm *regionMap) TotalSize() uint64 {
+	if rm == nil {
+		return 0
+	}
+	return rm.totalSize
+}
+
 func (rm *regionMap) Put(region *RegionInfo) {
 	if old, ok := rm.m[region.GetId()]; ok {
+		rm.totalSize += region.regionSize() - old.regionSize()
 		old.RegionInfo = region
 		return
 	}
+	rm.totalSize += region.regionSize()
 	rm.m[region.GetId()] = &regionEntry{
 		RegionInfo: region,
 		pos:        len(rm.ids),
@@ -172,6 +235,7 @@ func (rm *regionMap) Delete(id uint64) {
 		return
 	}
 	if old, ok := rm.m[id]; ok {
+		rm.totalSize -= old.regionSize()
 		len := rm.Len()
 		last := rm.m[rm.ids[len-1]]
 		last.pos = old.pos
@@ -182,18 +246,20 @@ func (rm *regionMap) Delete(id uint64) {
 }
 
 type regionsInfo struct {
-	tree      *regionTree
-	regions   *regionMap            // regionID -> regionInfo
-	leaders   map[uint64]*regionMap // storeID -> regionID -> regionInfo
-	followers map[uint64]*regionMap // storeID -> regionID -> regionInfo
+	tree         *regionTree
+	regions      *regionMap            // regionID -> regionInfo
+	leaders      map[uint64]*regionMap // storeID -> regionID -> regionInfo
+	followers    map[uint64]*regionMap // storeID -> regionID -> regionInfo
+	pendingPeers map[uint64]*regionMap // storeID -> regionID -> regionInfo
 }
 
 func newRegionsInfo() *regionsInfo {
 	return &regionsInfo{
-		tree:      newRegionTree(),
-		regions:   newRegionMap(),
-		leaders:   make(map[uint64]*regionMap),
-		followers: make(map[uint64]*regionMap),
+		tree:         newRegionTree(),
+		regions:      newRegionMap(),
+		leaders:      make(map[uint64]*regionMap),
+		followers:    make(map[uint64]*regionMap),
+		pendingPeers: make(map[uint64]*regionMap),
 	}
 }
 
@@ -205,20 +271,27 @@ func (r *regionsInfo) getRegion(regionID uint64) *RegionInfo {
 	return region.clone()
 }
 
-func (r *regionsInfo) setRegion(region *RegionInfo) {
+// setRegion puts the region, the regions overlapped by it are removed from
+// the tree and returned.
+func (r *regionsInfo) setRegion(region *RegionInfo) []*RegionInfo {
 	if origin := r.regions.Get(region.GetId()); origin != nil {
 		r.removeRegion(origin)
 	}
-	r.addRegion(region)
+	return r.addRegion(region)
 }
 
-func (r *regionsInfo) addRegion(region *RegionInfo) {
+func (r *regionsInfo) addRegion(region *RegionInfo) []*RegionInfo {
 	// Add to tree and regions.
-	r.tree.update(region.Region)
+	var overlaps []*RegionInfo
+	for _, item := range r.tree.update(region.Region) {
+		if origin := r.regions.Get(item.GetId()); origin != nil {
+			overlaps = append(overlaps, origin)
+		}
+	}
 	r.regions.Put(region)
 
 	if region.Leader == nil {
-		return
+		return overlaps
 	}
 
 	// Add to leaders and followers.
@@ -242,6 +315,18 @@ func (r *regionsInfo) addRegion(region *RegionInfo) {
 			store.Put(region)
 		}
 	}
+
+	// Add the regions to the stores of their pending peers.
+	for _, peer := range region.PendingPeers {
+		storeID := peer.GetStoreId()
+		store, ok := r.pendingPeers[storeID]
+		if !ok {
+			store = newRegionMap()
+			r.pendingPeers[storeID] = store
+		}
+		store.Put(region)
+	}
+	return overlaps
 }
 
 func (r *regionsInfo) removeRegion(region *RegionInfo) {
@@ -255,6 +340,27 @@ func (r *regionsInfo) removeRegion(region *RegionInfo) {
 		r.leaders[storeID].Delete(region.GetId())
 		r.followers[storeID].Delete(region.GetId())
 	}
+	for _, peer := range region.PendingPeers {
+		r.pendingPeers[peer.GetStoreId()].Delete(region.GetId())
+	}
+}
+
+// updateFlow updates the flow of the cached region in place.
+func (r *regionsInfo) updateFlow(region *RegionInfo) {
+	if origin := r.regions.Get(region.GetId()); origin != nil {
+		origin.WrittenBytes = region.WrittenBytes
+		origin.ReadBytes = region.ReadBytes
+		origin.ReadKeys = region.ReadKeys
+	}
+}
+
+// getOverlaps returns the regions whose ranges overlap the region.
+func (r *regionsInfo) getOverlaps(region *RegionInfo) []*metapb.Region {
+	var regions []*metapb.Region
+	for _, item := range r.tree.getOverlaps(region.Region) {
+		regions = append(regions, item.region)
+	}
+	return regions
 }
 
 func (r *regionsInfo) searchRegion(regionKey []byte) *RegionInfo {
@@ -265,6 +371,23 @@ func (r *regionsInfo) searchRegion(regionKey []byte) *RegionInfo {
 	return r.getRegion(region.GetId())
 }
 
+func (r *regionsInfo) searchPrevRegion(regionKey []byte) *RegionInfo {
+	region := r.tree.searchPrev(regionKey)
+	if region == nil {
+		return nil
+	}
+	return r.getRegion(region.GetId())
+}
+
+func (r *regionsInfo) scanRegions(startKey, endKey []byte, limit int) []*RegionInfo {
+	metas := r.tree.scanRange(startKey, endKey, limit)
+	regions := make([]*RegionInfo, 0, len(metas))
+	for _, meta := range metas {
+		regions = append(regions, r.getRegion(meta.GetId()))
+	}
+	return regions
+}
+
 func (r *regionsInfo) getRegions() []*RegionInfo {
 	regions := make([]*RegionInfo, 0, r.regions.Len())
 	for _, region := range r.regions.m {
@@ -289,6 +412,16 @@ func (r *regionsInfo) getStoreRegionCount(storeID uint64) int {
 	return r.getStoreLeaderCount(storeID) + r.getStoreFollowerCount(storeID)
 }
 
+func (r *regionsInfo) getStoreRegionSize(storeID uint64) uint64 {
+	return r.leaders[storeID].TotalSize() + r.followers[storeID].TotalSize()
+}
+
+// getStorePendingPeerCount returns the number of the peers on the store which
+// are still catching up with their leaders.
+func (r *regionsInfo) getStorePendingPeerCount(storeID uint64) int {
+	return r.pendingPeers[storeID].Len()
+}
+
 func (r *regionsInfo) getStoreLeaderCount(storeID uint64) int {
 	return r.leaders[storeID].Len()
 }
@@ -335,6 +468,20 @@ type clusterInfo struct {
 
 	activeRegions   int
 	writeStatistics *lruCache
+	readStatistics  *lruCache
+	placements      *placementRules
+	priorityRanges  *priorityKeyRanges
+	namespaces      *namespaceRules
+	storeLimits     map[uint64]*StoreLimit
+
+	// leaderWeightLabel and leaderWeights are the config of the label leader
+	// weights, the weights are updated when the config or the stores change.
+	leaderWeightLabel string
+	leaderWeights     map[string]float64
+
+	// regionSyncer records the region changes for the followers, it is nil
+	// if the changes are not synced.
+	regionSyncer *regionSyncer
 }
 
 func newClusterInfo(id IDAllocator) *clusterInfo {
@@ -343,6 +490,11 @@ func newClusterInfo(id IDAllocator) *clusterInfo {
 		stores:          newStoresInfo(),
 		regions:         newRegionsInfo(),
 		writeStatistics: newLRUCache(writeStatLRUMaxLen),
+		readStatistics:  newLRUCache(readStatLRUMaxLen),
+		placements:      newPlacementRules(),
+		priorityRanges:  newPriorityKeyRanges(),
+		namespaces:      newNamespaceRules(),
+		storeLimits:     make(map[uint64]*StoreLimit),
 	}
 }
 
@@ -366,12 +518,52 @@ func loadClusterInfo(id IDAllocator, kv *kv) (*clusterInfo, error) {
 	}
 	log.Infof("load %v stores cost %v", c.stores.getStoreCount(), time.Since(start))
 
+	weights, err := kv.loadStoreWeights()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, weight := range weights {
+		c.stores.setStoreWeight(weight)
+	}
+
+	limits, err := kv.loadStoreLimits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, limit := range limits {
+		c.storeLimits[limit.StoreID] = limit
+	}
+
 	start = time.Now()
 	if err := kv.loadRegions(c.regions, kvRangeLimit); err != nil {
 		return nil, errors.Trace(err)
 	}
 	log.Infof("load %v regions cost %v", c.regions.getRegionCount(), time.Since(start))
 
+	placements, err := kv.loadPlacements()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, placement := range placements {
+		c.placements.set(placement)
+	}
+
+	priorityRanges, err := kv.loadPriorityRanges()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, keyRange := range priorityRanges {
+		c.priorityRanges.set(keyRange)
+	}
+
+	namespaces, err := kv.loadNamespaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, namespace := range namespaces {
+		c.namespaces.set(namespace)
+	}
+
 	return c, nil
 }
 
@@ -432,6 +624,8 @@ func (c *clusterInfo) putStoreLocked(store *storeInfo) error {
 		}
 	}
 	c.stores.setStore(store)
+	// The up stores may be changed.
+	c.stores.setLeaderWeights(c.leaderWeightLabel, c.leaderWeights)
 	return nil
 }
 
@@ -441,6 +635,39 @@ func (c *clusterInfo) blockStore(storeID uint64) error {
 	return errors.Trace(c.stores.blockStore(storeID))
 }
 
+// setStoreWeight persists and sets the weights of a store.
+func (c *clusterInfo) setStoreWeight(weight *StoreWeight) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.stores.getStore(weight.StoreID) == nil {
+		return errors.Trace(errStoreNotFound(weight.StoreID))
+	}
+	if c.kv != nil {
+		if err := c.kv.saveStoreWeight(weight); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.stores.setStoreWeight(weight)
+	return nil
+}
+
+// setLeaderWeights updates the config of the label leader weights and the
+// weights of the stores.
+func (c *clusterInfo) setLeaderWeights(label string, weights map[string]float64) {
+	c.Lock()
+	defer c.Unlock()
+	c.leaderWeightLabel, c.leaderWeights = label, weights
+	c.stores.setLeaderWeights(label, weights)
+}
+
+// setOpInfluence sets the pending influence of the running operators on the
+// stores.
+func (c *clusterInfo) setOpInfluence(influence opInfluence) {
+	c.Lock()
+	defer c.Unlock()
+	c.stores.setOpInfluence(influence)
+}
+
 func (c *clusterInfo) unblockStore(storeID uint64) {
 	c.Lock()
 	defer c.Unlock()
@@ -465,12 +692,13 @@ func (c *clusterInfo) getStoreCount() int {
 	return c.stores.getStoreCount()
 }
 
-func (c *clusterInfo) getStoresWriteStat() map[uint64]uint64 {
-	res := make(map[uint64]uint64)
+func (c *clusterInfo) getStoresWriteStat() *StoreHotStats {
+	stats := newStoreHotStats()
 	for _, s := range c.getStores() {
-		res[s.GetId()] = s.status.GetBytesWritten()
+		stats.BytesWritten[s.GetId()] = s.status.GetBytesWritten()
+		stats.KeysWritten[s.GetId()] = s.status.GetKeysWritten()
 	}
-	return res
+	return stats
 }
 
 func (c *clusterInfo) getClusterTotalWrittenBytes() uint64 {
@@ -489,7 +717,16 @@ func (c *clusterInfo) getRegion(regionID uint64) *RegionInfo {
 	return c.regions.getRegion(regionID)
 }
 
-// updateWriteStatCache updates statistic for a region if it's hot, or remove it from statistics if it cools down
+// rollingAverage returns the flow averaged with the previous one, the weight
+// of the previous flow decays by hotRegionDecayRatio at each heartbeat, so a
+// single burst or pause of the flow doesn't change the statistics much.
+func rollingAverage(prev, cur uint64) uint64 {
+	return uint64(float64(prev)*hotRegionDecayRatio + float64(cur)*(1-hotRegionDecayRatio))
+}
+
+// updateWriteStatCache updates statistic for a region if it's hot, or remove
+// it from statistics if it cools down. The written bytes are the rolling
+// average of the flow reported by the heartbeats.
 func (c *clusterInfo) updateWriteStatCache(region *RegionInfo, hotRegionThreshold uint64) {
 	var v *RegionStat
 	key := region.GetId()
@@ -506,9 +743,10 @@ func (c *clusterInfo) updateWriteStatCache(region *RegionInfo, hotRegionThreshol
 	if isExist {
 		v = value.(*RegionStat)
 		newItem.HotDegree = v.HotDegree + 1
+		newItem.WrittenBytes = rollingAverage(v.WrittenBytes, region.WrittenBytes)
 	}
 
-	if region.WrittenBytes < hotRegionThreshold {
+	if newItem.WrittenBytes < hotRegionThreshold {
 		if !isExist {
 			return
 		}
@@ -519,17 +757,61 @@ func (c *clusterInfo) updateWriteStatCache(region *RegionInfo, hotRegionThreshol
 		// eliminate some noise
 		newItem.HotDegree = v.HotDegree - 1
 		newItem.antiCount = v.antiCount - 1
-		newItem.WrittenBytes = v.WrittenBytes
 	}
 	c.writeStatistics.add(key, newItem)
 }
 
+// updateReadStatCache updates statistic for a region if it's read hot, or
+// remove it from statistics if it cools down. The read flow is the rolling
+// average of the flow reported by the heartbeats.
+func (c *clusterInfo) updateReadStatCache(region *RegionInfo, readBytes, readKeys uint64) {
+	var v *RegionStat
+	key := region.GetId()
+	value, isExist := c.readStatistics.peek(key)
+	newItem := &RegionStat{
+		RegionID:       region.GetId(),
+		ReadBytes:      readBytes,
+		ReadKeys:       readKeys,
+		LastUpdateTime: time.Now(),
+		StoreID:        region.Leader.GetStoreId(),
+		version:        region.GetRegionEpoch().GetVersion(),
+		antiCount:      hotRegionAntiCount,
+	}
+
+	if isExist {
+		v = value.(*RegionStat)
+		newItem.HotDegree = v.HotDegree + 1
+		newItem.ReadBytes = rollingAverage(v.ReadBytes, readBytes)
+		newItem.ReadKeys = rollingAverage(v.ReadKeys, readKeys)
+	}
+
+	if newItem.ReadBytes < hotRegionMinReadRate {
+		if !isExist {
+			return
+		}
+		if v.antiCount <= 0 {
+			c.readStatistics.remove(key)
+			return
+		}
+		// eliminate some noise
+		newItem.HotDegree = v.HotDegree - 1
+		newItem.antiCount = v.antiCount - 1
+	}
+	c.readStatistics.add(key, newItem)
+}
+
 func (c *clusterInfo) searchRegion(regionKey []byte) *RegionInfo {
 	c.RLock()
 	defer c.RUnlock()
 	return c.regions.searchRegion(regionKey)
 }
 
+func (c *clusterInfo) searchPrevRegion(regionKey []byte) *RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.searchPrevRegion(regionKey)
+}
+
 func (c *clusterInfo) putRegion(region *RegionInfo) error {
 	c.Lock()
 	defer c.Unlock()
@@ -546,6 +828,51 @@ func (c *clusterInfo) putRegionLocked(region *RegionInfo) error {
 	return nil
 }
 
+// dropRegion removes the region from the cache, it's learned again from the
+// next heartbeat. It returns false if the region is not cached.
+func (c *clusterInfo) dropRegion(regionID uint64) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	region := c.regions.regions.Get(regionID)
+	if region == nil {
+		return false
+	}
+	c.regions.removeRegion(region)
+	c.writeStatistics.remove(regionID)
+	c.readStatistics.remove(regionID)
+	for _, p := range region.Peers {
+		c.updateStoreStatus(p.GetStoreId())
+	}
+	return true
+}
+
+// dropRegions removes all the regions from the cache and returns how many
+// are removed.
+func (c *clusterInfo) dropRegions() int {
+	c.Lock()
+	defer c.Unlock()
+
+	count := c.regions.getRegionCount()
+	c.regions = newRegionsInfo()
+	c.activeRegions = 0
+	for _, stats := range []*lruCache{c.writeStatistics, c.readStatistics} {
+		for _, item := range stats.elems() {
+			stats.remove(item.key)
+		}
+	}
+	for _, store := range c.stores.getStores() {
+		c.updateStoreStatus(store.GetId())
+	}
+	return count
+}
+
+func (c *clusterInfo) scanRegions(startKey, endKey []byte, limit int) []*RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.scanRegions(startKey, endKey, limit)
+}
+
 func (c *clusterInfo) getRegions() []*RegionInfo {
 	c.RLock()
 	defer c.RUnlock()
@@ -625,11 +952,73 @@ func (c *clusterInfo) getFollowerStores(region *RegionInfo) []*storeInfo {
 	return stores
 }
 
-// isPrepared if the cluster information is collected
-func (c *clusterInfo) isPrepared() bool {
+// getWarmUpStatus returns how many regions and stores have heartbeated.
+func (c *clusterInfo) getWarmUpStatus() *WarmUpStatus {
+	c.RLock()
+	defer c.RUnlock()
+
+	status := &WarmUpStatus{
+		RegionCount:   c.regions.regions.Len(),
+		ActiveRegions: c.activeRegions,
+	}
+	for _, store := range c.stores.getStores() {
+		if store.isTombstone() {
+			continue
+		}
+		status.StoreCount++
+		if !store.status.LastHeartbeatTS.IsZero() {
+			status.ActiveStores++
+		}
+	}
+	return status
+}
+
+// isInitialized checks whether the first region is fully replicated or has
+// split, the cluster is not ready for serving before it.
+func (c *clusterInfo) isInitialized(maxReplicas int) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.regions.getRegionCount() > 1 {
+		return true
+	}
+	region := c.regions.searchRegion(nil)
+	return region != nil && len(region.GetPeers()) >= maxReplicas
+}
+
+// getReplicationStatus counts the regions which are not replicated as
+// configured or have unhealthy peers.
+func (c *clusterInfo) getReplicationStatus(maxReplicas int) *ReplicationStatus {
 	c.RLock()
 	defer c.RUnlock()
-	return float64(c.regions.regions.Len())*collectFactor <= float64(c.activeRegions)
+
+	status := &ReplicationStatus{MaxReplicas: maxReplicas}
+	for _, region := range c.regions.regions.m {
+		if peers := len(region.GetPeers()); peers < maxReplicas {
+			status.UnderReplicatedRegions++
+		} else if peers > maxReplicas {
+			status.OverReplicatedRegions++
+		}
+		if len(region.DownPeers) > 0 {
+			status.DownPeerRegions++
+		}
+		if len(region.PendingPeers) > 0 {
+			status.PendingPeerRegions++
+		}
+	}
+	return status
+}
+
+// getStoreStateCounts returns the number of the stores in each state.
+func (c *clusterInfo) getStoreStateCounts() map[string]int {
+	c.RLock()
+	defer c.RUnlock()
+
+	counts := make(map[string]int)
+	for _, store := range c.stores.stores {
+		counts[store.GetState().String()]++
+	}
+	return counts
 }
 
 // handleStoreHeartbeat updates the store status.
@@ -644,14 +1033,70 @@ func (c *clusterInfo) handleStoreHeartbeat(stats *pdpb.StoreStats) error {
 	}
 	store.status.StoreStats = proto.Clone(stats).(*pdpb.StoreStats)
 	store.status.LastHeartbeatTS = time.Now()
+	store.updateSnapshotBandwidth(stats)
 
 	c.stores.setStore(store)
 	return nil
 }
 
+// newRangeCluster returns a copy of the cluster with only the regions in
+// [startKey, endKey), the stores count the leaders and regions in the range,
+// so the balancers spread the range as a standalone cluster.
+func (c *clusterInfo) newRangeCluster(startKey, endKey []byte) *clusterInfo {
+	c.RLock()
+	defer c.RUnlock()
+
+	rc := newClusterInfo(c.id)
+	rc.meta = c.meta
+	rc.placements = c.placements
+	rc.priorityRanges = c.priorityRanges
+	rc.namespaces = c.namespaces
+	for _, region := range c.regions.scanRegions(startKey, endKey, 0) {
+		rc.regions.setRegion(region.clone())
+	}
+	for _, store := range c.stores.getStores() {
+		rc.stores.setStore(store)
+		rc.updateStoreStatus(store.GetId())
+	}
+	return rc
+}
+
 func (c *clusterInfo) updateStoreStatus(id uint64) {
 	c.stores.setLeaderCount(id, c.regions.getStoreLeaderCount(id))
 	c.stores.setRegionCount(id, c.regions.getStoreRegionCount(id))
+	c.stores.setRegionSize(id, c.regions.getStoreRegionSize(id))
+	c.stores.setPendingPeerCount(id, c.regions.getStorePendingPeerCount(id))
+}
+
+// warmUpRegions sets the leaders of the loaded regions with the regions
+// synced from the previous leader, the regions whose epochs are changed are
+// skipped.
+func (c *clusterInfo) warmUpRegions(regions []*RegionInfo) int {
+	c.Lock()
+	defer c.Unlock()
+
+	var warmed int
+	for _, region := range regions {
+		origin := c.regions.getRegion(region.GetId())
+		if origin == nil || origin.Leader != nil || region.Leader == nil {
+			continue
+		}
+		r, o := region.GetRegionEpoch(), origin.GetRegionEpoch()
+		if r.GetVersion() != o.GetVersion() || r.GetConfVer() != o.GetConfVer() {
+			continue
+		}
+		if origin.GetStorePeer(region.Leader.GetStoreId()).GetId() != region.Leader.GetId() {
+			continue
+		}
+		origin.Leader = region.Leader
+		c.regions.setRegion(origin)
+		c.activeRegions++
+		warmed++
+		for _, p := range origin.Peers {
+			c.updateStoreStatus(p.GetStoreId())
+		}
+	}
+	return warmed
 }
 
 // handleRegionHeartbeat updates the region information.
@@ -664,8 +1109,14 @@ func (c *clusterInfo) handleRegionHeartbeat(region *RegionInfo) error {
 
 	// Save to KV if meta is updated.
 	// Save to cache if meta or leader is updated, or contains any down/pending peer.
-	var saveKV, saveCache bool
+	var saveKV, saveCache, leaderChanged bool
 	if origin == nil {
+		// The heartbeat of a merged region may arrive late.
+		for _, item := range c.regions.getOverlaps(region) {
+			if region.GetRegionEpoch().GetVersion() < item.GetRegionEpoch().GetVersion() {
+				return errors.Trace(errRegionIsStale(region.Region, item))
+			}
+		}
 		log.Infof("[region %d] Insert new region {%v}", region.GetId(), region)
 		saveKV, saveCache = true, true
 	} else {
@@ -688,6 +1139,9 @@ func (c *clusterInfo) handleRegionHeartbeat(region *RegionInfo) error {
 			if origin.Leader.GetId() == 0 {
 				c.activeRegions++
 			}
+			saveCache, leaderChanged = true, true
+		}
+		if region.ApproximateSize != origin.ApproximateSize || region.ApproximateKeys != origin.ApproximateKeys {
 			saveCache = true
 		}
 		if len(region.DownPeers) > 0 || len(region.PendingPeers) > 0 {
@@ -705,7 +1159,20 @@ func (c *clusterInfo) handleRegionHeartbeat(region *RegionInfo) error {
 	}
 
 	if saveCache {
-		c.regions.setRegion(region)
+		// The overlapped regions are merged or stale.
+		overlaps := c.regions.setRegion(region)
+		for _, item := range overlaps {
+			log.Infof("[region %d] Removed by the overlapped region %d", item.GetId(), region.GetId())
+			c.regions.removeRegion(item)
+			if c.kv != nil {
+				if err := c.kv.deleteRegion(item.Region); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			for _, p := range item.Peers {
+				c.updateStoreStatus(p.GetStoreId())
+			}
+		}
 
 		// Update related stores.
 		if origin != nil {
@@ -718,7 +1185,15 @@ func (c *clusterInfo) handleRegionHeartbeat(region *RegionInfo) error {
 		}
 	}
 
+	if (saveKV || leaderChanged) && c.regionSyncer != nil {
+		c.regionSyncer.record(region)
+	}
+
 	c.updateWriteStatus(region)
+	c.updateReadStatus(region)
+	if !saveCache {
+		c.regions.updateFlow(region)
+	}
 
 	return nil
 }
@@ -749,3 +1224,20 @@ func (c *clusterInfo) updateWriteStatus(region *RegionInfo) {
 	}
 	c.updateWriteStatCache(region, hotRegionThreshold)
 }
+
+// updateReadStatus records the read flow of the region, the reads are served
+// by the leader. Unlike the written bytes, the read flow of the region is
+// kept as reported, and only the statistics are converted to the rates.
+func (c *clusterInfo) updateReadStatus(region *RegionInfo) {
+	interval := float64(regionHeartBeatReportInterval)
+	v, isExist := c.readStatistics.peek(region.GetId())
+	if isExist {
+		interval = time.Now().Sub(v.(*RegionStat).LastUpdateTime).Seconds()
+		if interval < minHotRegionReportInterval {
+			return
+		}
+	}
+	readBytesPerSec := uint64(float64(region.ReadBytes) / interval)
+	readKeysPerSec := uint64(float64(region.ReadKeys) / interval)
+	c.updateReadStatCache(region, readBytesPerSec, readKeysPerSec)
+}
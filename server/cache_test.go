@@ -19,8 +19,8 @@ import (
 
 	"github.com/juju/errors"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 var _ = Suite(&testStoresInfoSuite{})
@@ -238,6 +238,7 @@ func (s *testClusterInfoSuite) Test(c *C) {
 	tests = append(tests, s.testStoreHeartbeat)
 	tests = append(tests, s.testRegionHeartbeat)
 	tests = append(tests, s.testRegionSplitAndMerge)
+	tests = append(tests, s.testRegionOverlaps)
 
 	// Test without kv.
 	{
@@ -259,6 +260,78 @@ func (s *testClusterInfoSuite) Test(c *C) {
 	}
 }
 
+func (s *testClusterInfoSuite) TestHotStatDecay(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	leader := &metapb.Peer{Id: 1, StoreId: 1}
+	region := newRegionInfo(&metapb.Region{Id: 1, Peers: []*metapb.Peer{leader}}, leader)
+	getStat := func() *RegionStat {
+		v, ok := cluster.writeStatistics.peek(1)
+		if !ok {
+			return nil
+		}
+		return v.(*RegionStat)
+	}
+
+	region.WrittenBytes = 100
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat(), IsNil)
+	region.WrittenBytes = 200
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat().WrittenBytes, Equals, uint64(200))
+
+	// A burst or a pause only moves the statistics by half.
+	region.WrittenBytes = 400
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat().WrittenBytes, Equals, uint64(300))
+	c.Assert(getStat().HotDegree, Equals, 1)
+	region.WrittenBytes = 0
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat().WrittenBytes, Equals, uint64(150))
+	c.Assert(getStat().HotDegree, Equals, 2)
+
+	// The region is removed after it cools down twice.
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat().WrittenBytes, Equals, uint64(75))
+	c.Assert(getStat().HotDegree, Equals, 1)
+	cluster.updateWriteStatCache(region, 150)
+	c.Assert(getStat(), IsNil)
+}
+
+func (s *testClusterInfoSuite) TestDropRegion(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	tc.addRegionStore(1, 0)
+	tc.addRegionStore(2, 0)
+	tc.addLeaderRegionInRange(1, "", "b", 10, 1, 2)
+	tc.addLeaderRegionInRange(2, "b", "", 10, 2, 1)
+	tc.updateRegionCount(1, 2)
+	tc.updateRegionCount(2, 2)
+	region := cluster.getRegion(1)
+	region.WrittenBytes = 200
+	cluster.updateWriteStatCache(region, 150)
+	_, ok := cluster.writeStatistics.peek(1)
+	c.Assert(ok, IsTrue)
+
+	c.Assert(cluster.dropRegion(3), IsFalse)
+	c.Assert(cluster.dropRegion(1), IsTrue)
+	c.Assert(cluster.getRegion(1), IsNil)
+	c.Assert(cluster.searchRegion([]byte("a")), IsNil)
+	c.Assert(cluster.getStore(1).status.LeaderCount, Equals, 0)
+	c.Assert(cluster.getStore(1).status.RegionCount, Equals, 1)
+	_, ok = cluster.writeStatistics.peek(1)
+	c.Assert(ok, IsFalse)
+
+	// The dropped region is learned again from the heartbeat.
+	c.Assert(cluster.handleRegionHeartbeat(region), IsNil)
+	c.Assert(cluster.getRegion(1), NotNil)
+	c.Assert(cluster.getStore(1).status.LeaderCount, Equals, 1)
+
+	c.Assert(cluster.dropRegions(), Equals, 2)
+	c.Assert(cluster.getRegionCount(), Equals, 0)
+	c.Assert(cluster.getStore(2).status.RegionCount, Equals, 0)
+	c.Assert(cluster.dropRegions(), Equals, 0)
+}
+
 func (s *testClusterInfoSuite) TestLoadClusterInfo(c *C) {
 	server, cleanup := mustRunTestServer(c)
 	defer cleanup()
@@ -405,6 +478,8 @@ func (s *testClusterInfoSuite) testRegionHeartbeat(c *C, cache *clusterInfo) {
 		region.PendingPeers = []*metapb.Peer{region.Peers[rand.Intn(len(region.Peers))]}
 		c.Assert(cache.handleRegionHeartbeat(region), IsNil)
 		checkRegions(c, cache.regions, regions[:i+1])
+		pendingStoreID := region.PendingPeers[0].GetStoreId()
+		c.Assert(cache.regions.getStorePendingPeerCount(pendingStoreID), Equals, 1)
 
 		// Clear down peers.
 		region.DownPeers = nil
@@ -415,6 +490,7 @@ func (s *testClusterInfoSuite) testRegionHeartbeat(c *C, cache *clusterInfo) {
 		region.PendingPeers = nil
 		c.Assert(cache.handleRegionHeartbeat(region), IsNil)
 		checkRegions(c, cache.regions, regions[:i+1])
+		c.Assert(cache.regions.getStorePendingPeerCount(pendingStoreID), Equals, 0)
 	}
 
 	regionCounts := make(map[uint64]int)
@@ -491,6 +567,8 @@ func heartbeatRegions(c *C, cache *clusterInfo, regions []*metapb.Region) {
 			c.Assert(result.GetId(), Not(Equals), r.GetId())
 		}
 	}
+	// The overlapped regions are removed.
+	c.Assert(cache.getRegionCount(), Equals, len(regions))
 }
 
 func (s *testClusterInfoSuite) testRegionSplitAndMerge(c *C, cache *clusterInfo) {
@@ -529,6 +607,39 @@ func (s *testClusterInfoSuite) testRegionSplitAndMerge(c *C, cache *clusterInfo)
 	}
 }
 
+func (s *testClusterInfoSuite) testRegionOverlaps(c *C, cache *clusterInfo) {
+	for _, store := range newTestStores(3) {
+		c.Assert(cache.putStore(store), IsNil)
+	}
+	regions := newTestRegions(2, 2)
+	for _, region := range regions {
+		region.RegionEpoch = &metapb.RegionEpoch{Version: 1}
+		c.Assert(cache.handleRegionHeartbeat(region), IsNil)
+	}
+	c.Assert(cache.getRegionCount(), Equals, 2)
+	c.Assert(cache.getStoreRegionCount(1), Equals, 2)
+
+	// Region 1 is merged into region 0.
+	merged := regions[0].clone()
+	merged.EndKey = regions[1].EndKey
+	merged.RegionEpoch = &metapb.RegionEpoch{Version: 2}
+	c.Assert(cache.handleRegionHeartbeat(merged), IsNil)
+	c.Assert(cache.getRegionCount(), Equals, 1)
+	c.Assert(cache.getRegion(1), IsNil)
+	c.Assert(cache.getStoreRegionCount(1), Equals, 1)
+	c.Assert(cache.stores.getStore(1).status.RegionCount, Equals, 1)
+	if kv := cache.kv; kv != nil {
+		ok, err := kv.loadRegion(1, &metapb.Region{})
+		c.Assert(ok, IsFalse)
+		c.Assert(err, IsNil)
+	}
+
+	// The late heartbeat of the merged region is stale.
+	c.Assert(cache.handleRegionHeartbeat(regions[1]), NotNil)
+	c.Assert(cache.getRegionCount(), Equals, 1)
+	checkRegion(c, cache.searchRegion(regions[1].StartKey), merged)
+}
+
 var _ = Suite(&testClusterUtilSuite{})
 
 type testClusterUtilSuite struct{}
@@ -599,6 +710,29 @@ func (s *testRegionMapSuite) TestRegionMap(c *C) {
 	s.check(c, rm, 2, 3)
 }
 
+func (s *testRegionMapSuite) TestTotalSize(c *C) {
+	var empty *regionMap
+	c.Assert(empty.TotalSize(), Equals, uint64(0))
+
+	rm := newRegionMap()
+	r1, r2 := s.regionInfo(1), s.regionInfo(2)
+	r2.ApproximateSize = 10
+	rm.Put(r1)
+	rm.Put(r2)
+	// Unknown sizes are counted as the default size.
+	c.Assert(rm.TotalSize(), Equals, uint64(defaultRegionSize+10))
+
+	r2 = r2.clone()
+	r2.ApproximateSize = 20
+	rm.Put(r2)
+	c.Assert(rm.TotalSize(), Equals, uint64(defaultRegionSize+20))
+
+	rm.Delete(1)
+	c.Assert(rm.TotalSize(), Equals, uint64(20))
+	rm.Delete(2)
+	c.Assert(rm.TotalSize(), Equals, uint64(0))
+}
+
 func (s *testRegionMapSuite) regionInfo(id uint64) *RegionInfo {
 	return &RegionInfo{
 		Region: &metapb.Region{
@@ -0,0 +1,255 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/apiutil"
+	"golang.org/x/net/context"
+)
+
+const (
+	// pdClockPath serves the local clock of a member.
+	pdClockPath = "/pd/clock"
+
+	clockCheckInterval = 10 * time.Second
+	clockProbeTimeout  = time.Second
+	clockProbeSamples  = 3
+	// The drift estimated with a long round trip is too inaccurate to use.
+	maxClockProbeRTT = 500 * time.Millisecond
+)
+
+// serveClock writes the local clock in nanoseconds since the Unix epoch.
+func serveClock(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, time.Now().UnixNano())
+}
+
+// clockMonitor runs on the leader and estimates the clock drifts of the other
+// members, the leader stops advancing the timestamps if any of them drifts
+// too much, since the timestamps would go back after the leader changes.
+type clockMonitor struct {
+	sync.RWMutex
+	s *Server
+	// drifts is the clock of each member minus the clock of the leader.
+	drifts map[string]time.Duration
+	// clients are the http clients for each url scheme.
+	clients map[string]*http.Client
+}
+
+func newClockMonitor(s *Server) *clockMonitor {
+	return &clockMonitor{
+		s:       s,
+		drifts:  make(map[string]time.Duration),
+		clients: make(map[string]*http.Client),
+	}
+}
+
+func (m *clockMonitor) run() {
+	defer m.s.wg.Done()
+
+	ticker := time.NewTicker(clockCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		m.check()
+		select {
+		case <-ticker.C:
+		case <-m.s.client.Ctx().Done():
+			return
+		}
+	}
+}
+
+// check probes the clocks of the other members if this server is the leader.
+func (m *clockMonitor) check() {
+	drifts := make(map[string]time.Duration)
+	if m.s.IsLeader() {
+		members, err := GetMembers(m.s.client)
+		if err != nil {
+			log.Errorf("clock monitor: failed to get members: %v", err)
+			return
+		}
+		for _, member := range members {
+			if member.GetMemberId() == m.s.ID() {
+				continue
+			}
+			drift, err := m.probe(member.GetClientUrls())
+			if err != nil {
+				log.Warnf("clock monitor: failed to probe member %s: %v", member.GetName(), err)
+				continue
+			}
+			if absDuration(drift) > m.s.cfg.TsoMaxClockDrift.Duration {
+				log.Errorf("clock monitor: clock of member %s drifts %v from the leader, stop advancing timestamps", member.GetName(), drift)
+			}
+			drifts[member.GetName()] = drift
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for name := range m.drifts {
+		if _, ok := drifts[name]; !ok {
+			tsoClockDriftGauge.DeleteLabelValues(name)
+		}
+	}
+	for name, drift := range drifts {
+		tsoClockDriftGauge.WithLabelValues(name).Set(drift.Seconds())
+	}
+	m.drifts = drifts
+}
+
+// getMaxDrift returns the member whose clock drifts the most from the leader.
+func (m *clockMonitor) getMaxDrift() (string, time.Duration) {
+	m.RLock()
+	defer m.RUnlock()
+
+	var (
+		maxName  string
+		maxDrift time.Duration
+	)
+	for name, drift := range m.drifts {
+		if absDuration(drift) > absDuration(maxDrift) {
+			maxName, maxDrift = name, drift
+		}
+	}
+	return maxName, maxDrift
+}
+
+// probe estimates the clock drift of a member from the sample with the
+// shortest round trip, assuming the member reads its clock halfway.
+func (m *clockMonitor) probe(urls []string) (time.Duration, error) {
+	var (
+		drift  time.Duration
+		minRTT time.Duration
+		err    error
+	)
+	for _, u := range urls {
+		for i := 0; i < clockProbeSamples; i++ {
+			var (
+				start = time.Now()
+				peer  time.Time
+			)
+			peer, err = m.readClock(u)
+			if err != nil {
+				break
+			}
+			rtt := time.Since(start)
+			if minRTT == 0 || rtt < minRTT {
+				minRTT = rtt
+				drift = peer.Sub(start.Add(rtt / 2))
+			}
+		}
+		if minRTT != 0 {
+			break
+		}
+	}
+	if minRTT == 0 {
+		return 0, errors.Trace(err)
+	}
+	if minRTT > maxClockProbeRTT {
+		return 0, errors.Errorf("round trip %v is too long", minRTT)
+	}
+	return drift, nil
+}
+
+func (m *clockMonitor) readClock(addr string) (time.Time, error) {
+	urls, err := ParseUrls(addr)
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	u := urls[0]
+
+	// Use unix socket in tests.
+	scheme := u.Scheme
+	switch scheme {
+	case "unix":
+		u.Scheme = "http"
+	case "unixs":
+		u.Scheme = "https"
+	}
+	u.Path = pdClockPath
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	ctx, cancel := context.WithTimeout(m.s.client.Ctx(), clockProbeTimeout)
+	defer cancel()
+	resp, err := m.getClient(scheme).Do(req.WithContext(ctx))
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return zeroTime, errors.Errorf("read clock failed: %s", strings.TrimSpace(string(data)))
+	}
+	nanos, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func (m *clockMonitor) getClient(scheme string) *http.Client {
+	m.Lock()
+	defer m.Unlock()
+
+	client, ok := m.clients[scheme]
+	if !ok {
+		tr := apiutil.NewHTTPTransport(scheme)
+		tr.TLSClientConfig = m.s.tlsConfig
+		client = &http.Client{Transport: tr}
+		m.clients[scheme] = client
+	}
+	return client
+}
+
+// checkClockDrift returns true if the physical time should not be advanced
+// due to clock drift. prev is the last issued physical time, which may be fast
+// forwarded ahead of the clock, so the clock is checked against the one when
+// prev was issued rather than prev itself.
+func (s *Server) checkClockDrift(prev *atomicObject, now time.Time) bool {
+	maxDrift := s.cfg.TsoMaxClockDrift.Duration
+	if back := prev.clock.Sub(now); prev.clock != zeroTime && back > maxDrift {
+		log.Errorf("clock drift: the clock jumps back %v, prev: %v, now: %v", back, prev.clock, now)
+		tsoClockDriftCounter.WithLabelValues("clock").Inc()
+		return true
+	}
+	// The monitor logs the drifts of the members.
+	if _, drift := s.clockMonitor.getMaxDrift(); absDuration(drift) > maxDrift {
+		tsoClockDriftCounter.WithLabelValues("peer").Inc()
+		return true
+	}
+	return false
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
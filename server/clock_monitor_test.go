@@ -0,0 +1,88 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testClockMonitorSuite{})
+
+type testClockMonitorSuite struct{}
+
+func (s *testClockMonitorSuite) TestCheckClockDrift(c *C) {
+	cfg := NewTestSingleConfig()
+	c.Assert(cfg.adjust(), IsNil)
+	svr := &Server{cfg: cfg}
+	svr.clockMonitor = newClockMonitor(svr)
+	maxDrift := cfg.TsoMaxClockDrift.Duration
+	now := time.Now()
+
+	// The physical time may be fast forwarded ahead of the clock.
+	prev := &atomicObject{physical: now.Add(time.Hour), clock: now}
+	c.Assert(svr.checkClockDrift(prev, now), IsFalse)
+	c.Assert(svr.checkClockDrift(prev, now.Add(-maxDrift)), IsFalse)
+	// The clock jumps back.
+	c.Assert(svr.checkClockDrift(prev, now.Add(-2*maxDrift)), IsTrue)
+	c.Assert(svr.checkClockDrift(&atomicObject{physical: now}, now.Add(-2*maxDrift)), IsFalse)
+
+	// The clock of a member drifts too much in either direction.
+	svr.clockMonitor.drifts = map[string]time.Duration{"pd1": maxDrift / 2, "pd2": -2 * maxDrift}
+	name, drift := svr.clockMonitor.getMaxDrift()
+	c.Assert(name, Equals, "pd2")
+	c.Assert(drift, Equals, -2*maxDrift)
+	c.Assert(svr.checkClockDrift(prev, now), IsTrue)
+	svr.clockMonitor.drifts = map[string]time.Duration{"pd1": maxDrift / 2}
+	c.Assert(svr.checkClockDrift(prev, now), IsFalse)
+}
+
+func (s *testClockMonitorSuite) TestProbe(c *C) {
+	svrs, cleanup := newMultiTestServers(c, 3)
+	defer cleanup()
+	leader := mustWaitLeader(c, svrs)
+
+	leader.clockMonitor.check()
+	leader.clockMonitor.RLock()
+	drifts := leader.clockMonitor.drifts
+	leader.clockMonitor.RUnlock()
+	c.Assert(drifts, HasLen, 2)
+	for _, svr := range svrs {
+		if svr == leader {
+			continue
+		}
+		drift, ok := drifts[svr.Name()]
+		c.Assert(ok, IsTrue)
+		c.Assert(absDuration(drift), Less, maxClockProbeRTT)
+
+		// Followers don't probe.
+		svr.clockMonitor.check()
+		_, drift = svr.clockMonitor.getMaxDrift()
+		c.Assert(drift, Equals, time.Duration(0))
+	}
+
+	// The leader stops advancing the timestamps if a member drifts too much.
+	leader.clockMonitor.Lock()
+	leader.clockMonitor.drifts = map[string]time.Duration{"pd": time.Hour}
+	leader.clockMonitor.Unlock()
+	time.Sleep(2 * updateTimestampStep)
+	physical := leader.ts.Load().(*atomicObject).physical
+	time.Sleep(3 * updateTimestampStep)
+	c.Assert(leader.ts.Load().(*atomicObject).physical, Equals, physical)
+
+	leader.clockMonitor.check()
+	time.Sleep(3 * updateTimestampStep)
+	c.Assert(leader.ts.Load().(*atomicObject).physical.After(physical), IsTrue)
+}
@@ -23,8 +23,8 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 const (
@@ -57,6 +57,10 @@ type RaftCluster struct {
 	cachedCluster *clusterInfo
 
 	coordinator *coordinator
+	heatmap     *heatmapCollector
+	drains      *drainEstimator
+
+	heartbeatIntervals *heartbeatIntervals
 
 	wg   sync.WaitGroup
 	quit chan struct{}
@@ -67,6 +71,25 @@ type RaftCluster struct {
 // ClusterStatus saves some state information
 type ClusterStatus struct {
 	RaftBootstrapTime time.Time `json:"raft_bootstrap_time,omitempty"`
+	// IsInitialized is true once the first region is fully replicated or has
+	// split.
+	IsInitialized bool `json:"is_initialized"`
+	// ReplicationStatus and StoreCounts are nil if the cluster is not running.
+	ReplicationStatus *ReplicationStatus `json:"replication_status,omitempty"`
+	// StoreCounts is the number of the stores by state.
+	StoreCounts map[string]int `json:"store_counts,omitempty"`
+	// WarmUp is nil if the cluster is not running.
+	WarmUp *WarmUpStatus `json:"warm_up,omitempty"`
+}
+
+// ReplicationStatus is the number of the regions which are not replicated as
+// configured or have unhealthy peers.
+type ReplicationStatus struct {
+	MaxReplicas            int `json:"max_replicas"`
+	UnderReplicatedRegions int `json:"under_replicated_regions"`
+	OverReplicatedRegions  int `json:"over_replicated_regions"`
+	DownPeerRegions        int `json:"down_peer_regions"`
+	PendingPeerRegions     int `json:"pending_peer_regions"`
 }
 
 func newRaftCluster(s *Server, clusterID uint64) *RaftCluster {
@@ -75,6 +98,10 @@ func newRaftCluster(s *Server, clusterID uint64) *RaftCluster {
 		running:     false,
 		clusterID:   clusterID,
 		clusterRoot: s.getClusterRootPath(),
+		heatmap:     newHeatmapCollector(),
+		drains:      newDrainEstimator(),
+
+		heartbeatIntervals: newHeartbeatIntervals(s.scheduleOpt),
 	}
 }
 
@@ -105,6 +132,16 @@ func (c *RaftCluster) start() error {
 	if cluster == nil {
 		return nil
 	}
+	if c.s.regionSyncer != nil {
+		// Warm up with the regions synced as a follower before recording the
+		// changes of the new term.
+		if warmed := cluster.warmUpRegions(c.s.staleCache.getRegions()); warmed > 0 {
+			log.Infof("raft cluster: warm up %d regions from the region syncer", warmed)
+		}
+		c.s.regionSyncer.reset()
+		cluster.regionSyncer = c.s.regionSyncer
+	}
+	cluster.setLeaderWeights(c.s.scheduleOpt.GetLeaderWeights())
 	c.cachedCluster = cluster
 	c.coordinator = newCoordinator(c.cachedCluster, c.s.scheduleOpt)
 	c.quit = make(chan struct{})
@@ -161,11 +198,18 @@ func (s *Server) GetScheduleConfig() *ScheduleConfig {
 }
 
 // SetScheduleConfig sets the balance config information.
-func (s *Server) SetScheduleConfig(cfg ScheduleConfig) {
+func (s *Server) SetScheduleConfig(cfg ScheduleConfig) error {
+	if _, err := s.CheckConfig(&cfg, nil); err != nil {
+		return errors.Trace(err)
+	}
 	s.scheduleOpt.store(&cfg)
 	s.scheduleOpt.persist(s.kv)
+	if cluster := s.GetRaftCluster(); cluster != nil {
+		cluster.cachedCluster.setLeaderWeights(s.scheduleOpt.GetLeaderWeights())
+	}
 	s.cfg.Schedule = cfg
 	log.Infof("schedule config is updated: %+v, old: %+v", cfg, s.cfg.Schedule)
+	return nil
 }
 
 // GetReplicationConfig get the replication config
@@ -176,11 +220,15 @@ func (s *Server) GetReplicationConfig() *ReplicationConfig {
 }
 
 // SetReplicationConfig sets the replication config
-func (s *Server) SetReplicationConfig(cfg ReplicationConfig) {
+func (s *Server) SetReplicationConfig(cfg ReplicationConfig) error {
+	if _, err := s.CheckConfig(nil, &cfg); err != nil {
+		return errors.Trace(err)
+	}
 	s.scheduleOpt.rep.store(&cfg)
 	s.scheduleOpt.persist(s.kv)
 	s.cfg.Replication = cfg
 	log.Infof("replication is updated: %+v, old: %+v", cfg, s.cfg.Replication)
+	return nil
 }
 
 func (s *Server) getClusterRootPath() string {
@@ -204,8 +252,8 @@ func (s *Server) GetCluster() *metapb.Cluster {
 	}
 }
 
-// GetClusterStatus gets cluster status
-func (s *Server) GetClusterStatus() (*ClusterStatus, error) {
+// GetRaftClusterStatus gets the status of the raft cluster.
+func (s *Server) GetRaftClusterStatus() (*ClusterStatus, error) {
 	s.cluster.Lock()
 	defer s.cluster.Unlock()
 	err := s.cluster.loadClusterStatus()
@@ -214,6 +262,12 @@ func (s *Server) GetClusterStatus() (*ClusterStatus, error) {
 	}
 	clone := &ClusterStatus{}
 	*clone = *s.cluster.status
+	if s.cluster.running {
+		cluster, maxReplicas := s.cluster.cachedCluster, s.scheduleOpt.GetMaxReplicas()
+		clone.IsInitialized = cluster.isInitialized(maxReplicas)
+		clone.ReplicationStatus = cluster.getReplicationStatus(maxReplicas)
+		clone.StoreCounts = cluster.getStoreStateCounts()
+	}
 	return clone, nil
 }
 
@@ -359,11 +413,23 @@ func (c *RaftCluster) GetRegionByKey(regionKey []byte) (*metapb.Region, *metapb.
 	return region.Region, region.Leader
 }
 
+// GetPrevRegionInfoByKey gets regionInfo of the region before the one that
+// contains the key.
+func (c *RaftCluster) GetPrevRegionInfoByKey(regionKey []byte) *RegionInfo {
+	return c.cachedCluster.searchPrevRegion(regionKey)
+}
+
 // GetRegionInfoByKey gets regionInfo by region key from cluster.
 func (c *RaftCluster) GetRegionInfoByKey(regionKey []byte) *RegionInfo {
 	return c.cachedCluster.searchRegion(regionKey)
 }
 
+// ScanRegions gets the regions in key order from startKey to endKey, at most
+// limit regions are returned if limit > 0.
+func (c *RaftCluster) ScanRegions(startKey, endKey []byte, limit int) []*RegionInfo {
+	return c.cachedCluster.scanRegions(startKey, endKey, limit)
+}
+
 // GetRegionByID gets region and leader peer by regionID from cluster.
 func (c *RaftCluster) GetRegionByID(regionID uint64) (*metapb.Region, *metapb.Peer) {
 	region := c.cachedCluster.getRegion(regionID)
@@ -378,6 +444,23 @@ func (c *RaftCluster) GetRegionInfoByID(regionID uint64) *RegionInfo {
 	return c.cachedCluster.getRegion(regionID)
 }
 
+// DropCacheRegion removes the region from the cache, so it's learned again
+// from the next heartbeat. The region saved in the storage is kept.
+func (c *RaftCluster) DropCacheRegion(regionID uint64) error {
+	if !c.cachedCluster.dropRegion(regionID) {
+		return errors.Errorf("region %d not found", regionID)
+	}
+	log.Warnf("[region %d] dropped from the cache", regionID)
+	return nil
+}
+
+// DropCacheRegions removes all the regions from the cache, they are learned
+// again from the heartbeats. The regions saved in the storage are kept.
+func (c *RaftCluster) DropCacheRegions() {
+	count := c.cachedCluster.dropRegions()
+	log.Warnf("%d regions are dropped from the cache", count)
+}
+
 // GetRegions gets regions from cluster.
 func (c *RaftCluster) GetRegions() []*metapb.Region {
 	return c.cachedCluster.getMetaRegions()
@@ -467,7 +550,32 @@ func (c *RaftCluster) RemoveStore(storeID uint64) error {
 
 	store.State = metapb.StoreState_Offline
 	log.Warnf("[store %d] store %s has been Offline", store.GetId(), store.GetAddress())
-	return cluster.putStore(store)
+	if err := cluster.putStore(store); err != nil {
+		return errors.Trace(err)
+	}
+	c.updateStoreDrains()
+	return nil
+}
+
+// SetStoreWeight sets the leader and region weights of a store, a store with
+// double weights carries about double leaders and regions.
+func (c *RaftCluster) SetStoreWeight(storeID uint64, leader, region float64) error {
+	if leader < 0 || region < 0 {
+		return errors.Errorf("invalid store weights leader %v region %v", leader, region)
+	}
+	store := c.cachedCluster.getStore(storeID)
+	if store == nil {
+		return errors.Trace(errStoreNotFound(storeID))
+	}
+	if store.isTombstone() {
+		return errors.New("store has been removed")
+	}
+	weight := &StoreWeight{
+		StoreID: storeID,
+		Leader:  leader,
+		Region:  region,
+	}
+	return errors.Trace(c.cachedCluster.setStoreWeight(weight))
 }
 
 // BuryStore marks a store as tombstone in cluster.
@@ -503,6 +611,69 @@ func (c *RaftCluster) BuryStore(storeID uint64, force bool) error {
 	return cluster.putStore(store)
 }
 
+// RestoreStore cancels the removal of an offline store, the regions are not
+// moved out of it any more.
+// State transition: Offline -> Up.
+func (c *RaftCluster) RestoreStore(storeID uint64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	cluster := c.cachedCluster
+
+	store := cluster.getStore(storeID)
+	if store == nil {
+		return errors.Trace(errStoreNotFound(storeID))
+	}
+
+	// Restore an up store should be OK, nothing to do.
+	if store.isUp() {
+		return nil
+	}
+
+	if store.isTombstone() {
+		return errors.New("store has been removed")
+	}
+
+	store.State = metapb.StoreState_Up
+	log.Warnf("[store %d] store %s has been Up", store.GetId(), store.GetAddress())
+	if err := cluster.putStore(store); err != nil {
+		return errors.Trace(err)
+	}
+	c.updateStoreDrains()
+	return nil
+}
+
+// SetStoreLabels adds or updates the labels of a store, the other labels of
+// the store are kept. TiKV overwrites the labels with its own config when it
+// puts the store again after restarting.
+func (c *RaftCluster) SetStoreLabels(storeID uint64, labels []*metapb.StoreLabel) error {
+	c.Lock()
+	defer c.Unlock()
+
+	cluster := c.cachedCluster
+
+	store := cluster.getStore(storeID)
+	if store == nil {
+		return errors.Trace(errStoreNotFound(storeID))
+	}
+	if store.isTombstone() {
+		return errors.New("store has been removed")
+	}
+
+	for _, label := range labels {
+		if label.GetKey() == "" || label.GetValue() == "" {
+			return errors.Errorf("invalid store label %v", label)
+		}
+		if old := store.getLabel(label.GetKey()); old != nil {
+			old.Value = label.GetValue()
+		} else {
+			store.Labels = append(store.Labels, label)
+		}
+	}
+	log.Infof("[store %d] set labels %v", store.GetId(), store.GetLabels())
+	return cluster.putStore(store)
+}
+
 func (c *RaftCluster) checkStores() {
 	cluster := c.cachedCluster
 	for _, store := range cluster.getMetaStores() {
@@ -592,10 +763,20 @@ func (c *RaftCluster) runBackgroundJobs(interval time.Duration) {
 		case <-ticker.C:
 			c.checkStores()
 			c.collectMetrics()
+			c.saveHotRegionHistory()
+			c.collectHeatmap()
+			c.updateStoreDrains()
+			c.gcHeartbeatIntervals()
 		}
 	}
 }
 
+// GetWarmUpStatus returns whether the cluster information is collected
+// enough to schedule.
+func (c *RaftCluster) GetWarmUpStatus() *WarmUpStatus {
+	return c.coordinator.getWarmUpStatus()
+}
+
 // GetConfig gets config from cluster.
 func (c *RaftCluster) GetConfig() *metapb.Cluster {
 	return c.cachedCluster.getMeta()
@@ -20,8 +20,8 @@ import (
 
 	"github.com/coreos/etcd/clientv3"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
@@ -372,6 +372,12 @@ func (s *testClusterSuite) testRemoveStore(c *C, clusterID uint64, store *metapb
 		s.resetStoreState(c, store.GetId(), metapb.StoreState_Up)
 		err = cluster.BuryStore(store.GetId(), false)
 		c.Assert(err, NotNil)
+		// Case 4: RestoreStore should be OK.
+		s.resetStoreState(c, store.GetId(), metapb.StoreState_Up)
+		err = cluster.RestoreStore(store.GetId())
+		c.Assert(err, IsNil)
+		restoredStore := s.getStore(c, clusterID, store.GetId())
+		c.Assert(restoredStore.GetState(), Equals, metapb.StoreState_Up)
 	}
 
 	// When store is offline:
@@ -388,6 +394,12 @@ func (s *testClusterSuite) testRemoveStore(c *C, clusterID uint64, store *metapb
 		c.Assert(err, IsNil)
 		buriedStore := s.getStore(c, clusterID, store.GetId())
 		c.Assert(buriedStore.GetState(), Equals, metapb.StoreState_Tombstone)
+		// Case 3: RestoreStore should be OK.
+		s.resetStoreState(c, store.GetId(), metapb.StoreState_Offline)
+		err = cluster.RestoreStore(store.GetId())
+		c.Assert(err, IsNil)
+		restoredStore := s.getStore(c, clusterID, store.GetId())
+		c.Assert(restoredStore.GetState(), Equals, metapb.StoreState_Up)
 	}
 
 	// When store is tombstone:
@@ -402,6 +414,10 @@ func (s *testClusterSuite) testRemoveStore(c *C, clusterID uint64, store *metapb
 		c.Assert(err, IsNil)
 		buriedStore := s.getStore(c, clusterID, store.GetId())
 		c.Assert(buriedStore.GetState(), Equals, metapb.StoreState_Tombstone)
+		// Case 3: RestoreStore should fail.
+		s.resetStoreState(c, store.GetId(), metapb.StoreState_Tombstone)
+		err = cluster.RestoreStore(store.GetId())
+		c.Assert(err, NotNil)
 	}
 
 	{
@@ -505,3 +521,208 @@ func (s *testClusterSuite) TestGetPDMembers(c *C) {
 	// A more strict test can be found at api/member_test.go
 	c.Assert(len(resp.GetMembers()), Not(Equals), 0)
 }
+
+func (s *testClusterSuite) TestGetAllStores(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+
+	store := s.newStore(c, 0, "127.0.0.1:23456")
+	_, err := putStore(c, s.grpcPDClient, clusterID, store)
+	c.Assert(err, IsNil)
+
+	getAllStores := func(excludeTombstone bool) []*metapb.Store {
+		req := &pdpb.GetAllStoresRequest{
+			Header:                 newRequestHeader(clusterID),
+			ExcludeTombstoneStores: excludeTombstone,
+		}
+		resp, err := s.grpcPDClient.GetAllStores(context.Background(), req)
+		c.Assert(err, IsNil)
+		return resp.GetStores()
+	}
+
+	stores := getAllStores(false)
+	c.Assert(len(stores), Equals, s.getRaftCluster(c).cachedCluster.getStoreCount())
+
+	s.resetStoreState(c, store.GetId(), metapb.StoreState_Tombstone)
+	c.Assert(getAllStores(false), HasLen, len(stores))
+	for _, s := range getAllStores(true) {
+		c.Assert(s.GetState(), Not(Equals), metapb.StoreState_Tombstone)
+	}
+	c.Assert(len(getAllStores(true)), Less, len(stores))
+}
+
+func (s *testClusterSuite) TestGetRegionUnhealthyPeers(c *C) {
+	c.Assert(s.svr.newGetRegionResponse(nil).GetRegion(), IsNil)
+
+	peers := []*metapb.Peer{s.newPeer(c, 1, 0), s.newPeer(c, 2, 0), s.newPeer(c, 3, 0)}
+	region := newRegionInfo(s.newRegion(c, 0, []byte("a"), []byte("b"), peers, nil), peers[0])
+	region.DownPeers = []*pdpb.PeerStats{{Peer: peers[1], DownSeconds: 60}}
+	region.PendingPeers = []*metapb.Peer{peers[2]}
+
+	resp := s.svr.newGetRegionResponse(region)
+	c.Assert(resp.GetRegion(), DeepEquals, region.Region)
+	c.Assert(resp.GetLeader(), DeepEquals, peers[0])
+	c.Assert(resp.GetDownPeers(), DeepEquals, region.DownPeers)
+	c.Assert(resp.GetPendingPeers(), DeepEquals, region.PendingPeers)
+}
+
+func (s *testClusterSuite) TestGetClusterStatus(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+
+	resp, err := s.grpcPDClient.GetClusterStatus(context.Background(), &pdpb.GetClusterStatusRequest{
+		Header: newRequestHeader(clusterID),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetHeader().GetError(), IsNil)
+	c.Assert(resp.GetRaftBootstrapTime(), Greater, int64(0))
+
+	status, err := s.svr.GetRaftClusterStatus()
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetIsInitialized(), Equals, status.IsInitialized)
+	c.Assert(resp.GetReplicationStatus().GetMaxReplicas(), Equals, uint64(s.svr.cfg.Replication.MaxReplicas))
+	c.Assert(resp.GetStoreCounts(), HasLen, 3)
+	for _, count := range resp.GetStoreCounts() {
+		c.Assert(count.GetCount(), Equals, uint64(status.StoreCounts[count.GetState().String()]))
+	}
+}
+
+func (s *testClusterSuite) TestScatterRegion(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+	region := s.getRegion(c, clusterID, []byte("abc"))
+
+	// The leader of the region is unknown before the first heartbeat.
+	req := &pdpb.ScatterRegionRequest{
+		Header:   newRequestHeader(clusterID),
+		RegionId: region.GetId(),
+	}
+	_, err := s.grpcPDClient.ScatterRegion(context.Background(), req)
+	c.Assert(err, NotNil)
+
+	// The region must be given if PD doesn't know it.
+	req.RegionId = region.GetId() + 1000
+	_, err = s.grpcPDClient.ScatterRegion(context.Background(), req)
+	c.Assert(err, NotNil)
+	req.Region = &metapb.Region{Id: req.RegionId, Peers: region.GetPeers()}
+	req.Leader = region.GetPeers()[0]
+	_, err = s.grpcPDClient.ScatterRegion(context.Background(), req)
+	c.Assert(err, IsNil)
+}
+
+func (s *testClusterSuite) TestGetOperator(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+	region := s.getRegion(c, clusterID, []byte("abc"))
+
+	req := &pdpb.GetOperatorRequest{
+		Header:   newRequestHeader(clusterID),
+		RegionId: region.GetId(),
+	}
+	resp, err := s.grpcPDClient.GetOperator(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetStatus(), Equals, pdpb.OperatorStatus_NONE)
+
+	peer := region.GetPeers()[0]
+	regionInfo := newRegionInfo(region, peer)
+	op := newRegionOperator(regionInfo, RegionKind, newAddPeerOperator(region.GetId(), &metapb.Peer{Id: 1000, StoreId: 2}), newRemovePeerOperator(region.GetId(), peer))
+	co := s.getRaftCluster(c).coordinator
+	c.Assert(co.addOperator(op), IsTrue)
+	resp, err = s.grpcPDClient.GetOperator(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetRegionId(), Equals, region.GetId())
+	c.Assert(resp.GetStatus(), Equals, pdpb.OperatorStatus_WAITING)
+	c.Assert(resp.GetKind(), Equals, "region")
+	c.Assert(resp.GetSteps(), HasLen, 2)
+	c.Assert(resp.GetFinishedSteps(), Equals, uint32(0))
+
+	// The last operator is returned after it's finished.
+	op.Ops[0].SetState(OperatorFinished)
+	op.SetState(OperatorTimeOut)
+	co.removeOperator(op)
+	resp, err = s.grpcPDClient.GetOperator(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetStatus(), Equals, pdpb.OperatorStatus_TIMEOUT)
+	c.Assert(resp.GetFinishedSteps(), Equals, uint32(1))
+}
+
+func (s *testClusterSuite) TestGCSafePoint(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+
+	getSafePoint := func() uint64 {
+		req := &pdpb.GetGCSafePointRequest{Header: newRequestHeader(clusterID)}
+		resp, err := s.grpcPDClient.GetGCSafePoint(context.Background(), req)
+		c.Assert(err, IsNil)
+		return resp.GetSafePoint()
+	}
+	updateSafePoint := func(safePoint uint64) uint64 {
+		req := &pdpb.UpdateGCSafePointRequest{
+			Header:    newRequestHeader(clusterID),
+			SafePoint: safePoint,
+		}
+		resp, err := s.grpcPDClient.UpdateGCSafePoint(context.Background(), req)
+		c.Assert(err, IsNil)
+		return resp.GetNewSafePoint()
+	}
+
+	base := getSafePoint()
+	c.Assert(updateSafePoint(base+100), Equals, base+100)
+	c.Assert(getSafePoint(), Equals, base+100)
+	// The safe point is never moved backward.
+	c.Assert(updateSafePoint(base+50), Equals, base+100)
+	c.Assert(getSafePoint(), Equals, base+100)
+}
+
+func (s *testClusterSuite) TestServiceGCSafePoint(c *C) {
+	clusterID := s.svr.clusterID
+	s.tryBootstrapCluster(c, s.grpcPDClient, clusterID, "127.0.0.1:0")
+
+	updateServiceSafePoint := func(serviceID string, ttl int64, safePoint uint64) (*pdpb.UpdateServiceGCSafePointResponse, error) {
+		req := &pdpb.UpdateServiceGCSafePointRequest{
+			Header:    newRequestHeader(clusterID),
+			ServiceId: []byte(serviceID),
+			Ttl:       ttl,
+			SafePoint: safePoint,
+		}
+		return s.grpcPDClient.UpdateServiceGCSafePoint(context.Background(), req)
+	}
+	updateSafePoint := func(safePoint uint64) uint64 {
+		req := &pdpb.UpdateGCSafePointRequest{
+			Header:    newRequestHeader(clusterID),
+			SafePoint: safePoint,
+		}
+		resp, err := s.grpcPDClient.UpdateGCSafePoint(context.Background(), req)
+		c.Assert(err, IsNil)
+		return resp.GetNewSafePoint()
+	}
+
+	base := updateSafePoint(0)
+	resp, err := updateServiceSafePoint("backup", 3600, base+100)
+	c.Assert(err, IsNil)
+	c.Assert(string(resp.GetServiceId()), Equals, "backup")
+	c.Assert(resp.GetMinSafePoint(), Equals, base+100)
+	c.Assert(resp.GetTtl(), Greater, int64(3590))
+	resp, err = updateServiceSafePoint("cdc", 3600, base+50)
+	c.Assert(err, IsNil)
+	c.Assert(string(resp.GetServiceId()), Equals, "cdc")
+	c.Assert(resp.GetMinSafePoint(), Equals, base+50)
+
+	// The GC safe point is blocked by the services.
+	c.Assert(updateSafePoint(base+200), Equals, base+50)
+	// The service safe point can't be less than the GC safe point.
+	_, err = updateServiceSafePoint("restore", 3600, base+10)
+	c.Assert(err, NotNil)
+
+	// Remove a service.
+	resp, err = updateServiceSafePoint("cdc", 0, 0)
+	c.Assert(err, IsNil)
+	c.Assert(string(resp.GetServiceId()), Equals, "backup")
+	c.Assert(updateSafePoint(base+200), Equals, base+100)
+
+	// The expired services are removed.
+	min, err := s.svr.getMinServiceSafePoint(time.Now().Add(2 * time.Hour))
+	c.Assert(err, IsNil)
+	c.Assert(min, IsNil)
+	c.Assert(updateSafePoint(base+200), Equals, base+200)
+}
@@ -19,8 +19,8 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/gogo/protobuf/proto"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 func (c *RaftCluster) handleRegionHeartbeat(region *RegionInfo) (*pdpb.RegionHeartbeatResponse, error) {
@@ -34,36 +34,75 @@ func (c *RaftCluster) handleRegionHeartbeat(region *RegionInfo) (*pdpb.RegionHea
 }
 
 func (c *RaftCluster) handleAskSplit(request *pdpb.AskSplitRequest) (*pdpb.AskSplitResponse, error) {
-	reqRegion := request.GetRegion()
-	startKey := reqRegion.GetStartKey()
-	region, _ := c.GetRegionByKey(startKey)
+	if err := c.checkAskSplitEpoch(request.GetRegion()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	id, err := c.allocSplitID(len(request.GetRegion().GetPeers()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	split := &pdpb.AskSplitResponse{
+		NewRegionId: id.GetNewRegionId(),
+		NewPeerIds:  id.GetNewPeerIds(),
+	}
+
+	return split, nil
+}
+
+func (c *RaftCluster) handleAskBatchSplit(request *pdpb.AskBatchSplitRequest) (*pdpb.AskBatchSplitResponse, error) {
+	if request.GetSplitCount() == 0 {
+		return nil, errors.New("invalid split count 0")
+	}
+	if err := c.checkAskSplitEpoch(request.GetRegion()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ids := make([]*pdpb.SplitID, 0, request.GetSplitCount())
+	for i := uint32(0); i < request.GetSplitCount(); i++ {
+		id, err := c.allocSplitID(len(request.GetRegion().GetPeers()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+
+	return &pdpb.AskBatchSplitResponse{Ids: ids}, nil
+}
+
+// checkAskSplitEpoch returns an error if the request epoch is less than the
+// current region epoch.
+func (c *RaftCluster) checkAskSplitEpoch(reqRegion *metapb.Region) error {
+	region, _ := c.GetRegionByKey(reqRegion.GetStartKey())
 
-	// If the request epoch is less than current region epoch, then returns an error.
 	reqRegionEpoch := reqRegion.GetRegionEpoch()
 	regionEpoch := region.GetRegionEpoch()
 	if reqRegionEpoch.GetVersion() < regionEpoch.GetVersion() ||
 		reqRegionEpoch.GetConfVer() < regionEpoch.GetConfVer() {
-		return nil, errors.Errorf("invalid region epoch, request: %v, currenrt: %v", reqRegionEpoch, regionEpoch)
+		return errors.Errorf("invalid region epoch, request: %v, currenrt: %v", reqRegionEpoch, regionEpoch)
 	}
+	return nil
+}
 
+// allocSplitID allocates the IDs of a new region and its peers.
+func (c *RaftCluster) allocSplitID(peerCount int) (*pdpb.SplitID, error) {
 	newRegionID, err := c.s.idAlloc.Alloc()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	peerIDs := make([]uint64, len(request.Region.Peers))
+	peerIDs := make([]uint64, peerCount)
 	for i := 0; i < len(peerIDs); i++ {
 		if peerIDs[i], err = c.s.idAlloc.Alloc(); err != nil {
 			return nil, errors.Trace(err)
 		}
 	}
 
-	split := &pdpb.AskSplitResponse{
+	return &pdpb.SplitID{
 		NewRegionId: newRegionID,
 		NewPeerIds:  peerIDs,
-	}
-
-	return split, nil
+	}, nil
 }
 
 func (c *RaftCluster) checkSplitRegion(left *metapb.Region, right *metapb.Region) error {
@@ -105,3 +144,33 @@ func (c *RaftCluster) handleReportSplit(request *pdpb.ReportSplitRequest) (*pdpb
 
 	return &pdpb.ReportSplitResponse{}, nil
 }
+
+func (c *RaftCluster) handleReportBatchSplit(request *pdpb.ReportBatchSplitRequest) (*pdpb.ReportBatchSplitResponse, error) {
+	regions := request.GetRegions()
+	if len(regions) < 2 {
+		return nil, errors.New("invalid batch split regions")
+	}
+	for i := 0; i < len(regions)-1; i++ {
+		if err := c.checkSplitRegion(regions[i], regions[i+1]); err != nil {
+			log.Warnf("report batch split region is invalid - %v, %v", request, errors.ErrorStack(err))
+			return nil, errors.Trace(err)
+		}
+	}
+
+	// Build origin region by using the first and the last regions, the last
+	// one keeps the ID of the origin region.
+	last := regions[len(regions)-1]
+	originRegion := proto.Clone(last).(*metapb.Region)
+	originRegion.RegionEpoch = nil
+	originRegion.StartKey = regions[0].GetStartKey()
+
+	// Wrap each new region as a split Operator, and add it into history cache.
+	for _, region := range regions[:len(regions)-1] {
+		op := newSplitOperator(originRegion, region, last)
+		c.coordinator.histories.add(originRegion.GetId(), op)
+		log.Infof("[region %d] region batch split, generate new region: %v", originRegion.GetId(), region)
+		c.coordinator.postEvent(op, evtEnd)
+	}
+
+	return &pdpb.ReportBatchSplitResponse{}, nil
+}
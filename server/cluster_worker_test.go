@@ -21,8 +21,8 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 )
 
@@ -446,8 +446,10 @@ func (s *testClusterWorkerSuite) TestHeartbeatSplit2(c *C) {
 	r2 := splitRegion(c, r1, []byte("m"), r2ID, r2PeerIDs)
 	leaderPeer2 := s.chooseRegionLeader(c, r2)
 
-	resp := s.heartbeatRegion(c, s.clusterID, 0, r2, leaderPeer2, true)
-	c.Assert(resp, IsNil)
+	// The first heartbeat of the new region only resets its interval.
+	resp := s.heartbeatRegion(c, s.clusterID, 0, r2, leaderPeer2, false)
+	c.Assert(resp.GetChangePeer(), IsNil)
+	c.Assert(resp.GetHeartbeatIntervalSecs(), Equals, uint64(0))
 
 	mustGetRegion(c, cluster, []byte("m"), r2)
 }
@@ -546,8 +548,9 @@ func (s *testClusterWorkerSuite) TestHeartbeatSplitAddPeer(c *C) {
 
 	// Sync r2.
 	leaderPeer2 := s.chooseRegionLeader(c, r2)
-	resp = s.heartbeatRegion(c, s.clusterID, 0, r2, leaderPeer2, true)
-	c.Assert(resp, IsNil)
+	resp = s.heartbeatRegion(c, s.clusterID, 0, r2, leaderPeer2, false)
+	c.Assert(resp.GetChangePeer(), IsNil)
+	c.Assert(resp.GetHeartbeatIntervalSecs(), Equals, uint64(0))
 }
 
 func (s *testClusterWorkerSuite) TestStoreHeartbeat(c *C) {
@@ -602,3 +605,63 @@ func (s *testClusterWorkerSuite) TestReportSplit(c *C) {
 	c.Assert(op.Origin.GetPeers(), HasLen, 1)
 	c.Assert(op.Origin.GetPeers()[0], DeepEquals, peer)
 }
+
+func (s *testClusterWorkerSuite) TestAskBatchSplit(c *C) {
+	cluster := s.svr.GetRaftCluster()
+	c.Assert(cluster, NotNil)
+
+	r, _ := cluster.GetRegionByKey([]byte("a"))
+	req := &pdpb.AskBatchSplitRequest{
+		Header:     newRequestHeader(s.clusterID),
+		Region:     r,
+		SplitCount: 3,
+	}
+	resp, err := s.grpcPDClient.AskBatchSplit(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetIds(), HasLen, 3)
+	allocated := make(map[uint64]struct{})
+	for _, id := range resp.GetIds() {
+		c.Assert(id.GetNewPeerIds(), HasLen, len(r.GetPeers()))
+		for _, newID := range append(id.GetNewPeerIds(), id.GetNewRegionId()) {
+			c.Assert(allocated, Not(HasKey), newID)
+			allocated[newID] = struct{}{}
+		}
+	}
+
+	req.SplitCount = 0
+	_, err = s.grpcPDClient.AskBatchSplit(context.Background(), req)
+	c.Assert(err, NotNil)
+}
+
+func (s *testClusterWorkerSuite) TestReportBatchSplit(c *C) {
+	cluster := s.svr.GetRaftCluster()
+	c.Assert(cluster, NotNil)
+
+	// Mock a report batch split request.
+	peer := s.newPeer(c, 999, 0)
+	regions := []*metapb.Region{
+		s.newRegion(c, 2, []byte("aaa"), []byte("bbb"), []*metapb.Peer{peer}, nil),
+		s.newRegion(c, 3, []byte("bbb"), []byte("ccc"), []*metapb.Peer{peer}, nil),
+		s.newRegion(c, 1, []byte("ccc"), []byte("ddd"), []*metapb.Peer{peer}, nil),
+	}
+	req := &pdpb.ReportBatchSplitRequest{
+		Header:  newRequestHeader(s.clusterID),
+		Regions: regions,
+	}
+	_, err := s.grpcPDClient.ReportBatchSplit(context.Background(), req)
+	c.Assert(err, IsNil)
+
+	value, ok := cluster.coordinator.histories.get(1)
+	c.Assert(ok, IsTrue)
+	op := value.(*splitOperator)
+	c.Assert(op.Left, DeepEquals, regions[1])
+	c.Assert(op.Right, DeepEquals, regions[2])
+	c.Assert(op.Origin.GetRegionEpoch(), IsNil)
+	c.Assert(op.Origin.GetStartKey(), BytesEquals, regions[0].GetStartKey())
+	c.Assert(op.Origin.GetEndKey(), BytesEquals, regions[2].GetEndKey())
+
+	// The regions must be adjacent.
+	req.Regions = []*metapb.Region{regions[0], regions[2]}
+	_, err = s.grpcPDClient.ReportBatchSplit(context.Background(), req)
+	c.Assert(err, NotNil)
+}
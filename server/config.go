@@ -14,21 +14,27 @@
 package server
 
 import (
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/coreos/etcd/embed"
+	"github.com/coreos/etcd/pkg/transport"
 	"github.com/juju/errors"
 	"github.com/pingcap/pd/pkg/logutil"
+	"github.com/pingcap/pd/pkg/metapb"
 	"github.com/pingcap/pd/pkg/metricutil"
 	"github.com/pingcap/pd/pkg/testutil"
 	"github.com/pingcap/pd/pkg/typeutil"
+	"github.com/pingcap/pd/pkg/urlutil"
 )
 
 // Config is the pd server configuration.
@@ -51,6 +57,10 @@ type Config struct {
 	// Join to an existing pd cluster, a string of endpoints.
 	Join string `toml:"join" json:"join"`
 
+	// DCLocation is the data center of the member, the members in the same
+	// data center elect a local TSO allocator.
+	DCLocation string `toml:"dc-location" json:"dc-location"`
+
 	// LeaderLease time, if leader doesn't update its TTL
 	// in etcd after lease time, etcd will expire the leader key
 	// and other servers can campaign the leader again.
@@ -64,15 +74,38 @@ type Config struct {
 	LogFileDeprecated  string `toml:"log-file" json:"log-file"`
 	LogLevelDeprecated string `toml:"log-level" json:"log-level"`
 
-	// TsoSaveInterval is the interval to save timestamp.
+	// TsoSaveInterval is the window of the timestamps saved in etcd at a time,
+	// the new leader starts after the saved timestamp.
 	TsoSaveInterval typeutil.Duration `toml:"tso-save-interval" json:"tso-save-interval"`
 
+	// TsoMaxClockDrift is the max drift tolerated of the local clock and the
+	// clocks of the other members, the leader stops advancing the physical
+	// time when the local clock jumps back or a member drifts more than it.
+	// A new leader whose clock is behind the saved window more than it raises
+	// alarms, and starts after the window anyway.
+	TsoMaxClockDrift typeutil.Duration `toml:"tso-max-clock-drift" json:"tso-max-clock-drift"`
+
+	// TsoClientQuota is the max timestamps per second allocated to one client,
+	// 0 (the default) means no limit. The clients are told apart by their
+	// tokens, the clients without tokens on the same host share the quota.
+	// Each PD limits its own clients, so a client connecting to N members
+	// gets up to N times the quota.
+	TsoClientQuota uint64 `toml:"tso-client-quota" json:"tso-client-quota"`
+
 	Metric metricutil.MetricConfig `toml:"metric" json:"metric"`
 
 	Schedule ScheduleConfig `toml:"schedule" json:"schedule"`
 
 	Replication ReplicationConfig `toml:"replication" json:"replication"`
 
+	Security SecurityConfig `toml:"security" json:"security"`
+
+	GRPC GRPCConfig `toml:"grpc" json:"grpc"`
+
+	RateLimit RateLimitConfig `toml:"rate-limit" json:"rate-limit"`
+
+	Auth AuthConfig `toml:"auth" json:"auth"`
+
 	// QuotaBackendBytes Raise alarms when backend size exceeds the given quota. 0 means use the default quota.
 	// the default size is 2GB, the maximum is 8GB.
 	QuotaBackendBytes typeutil.ByteSize `toml:"quota-backend-bytes" json:"quota-backend-bytes"`
@@ -116,11 +149,16 @@ func NewConfig() *Config {
 	fs.StringVar(&cfg.Log.Level, "L", "", "log level: debug, info, warn, error, fatal (default 'info')")
 	fs.StringVar(&cfg.Log.File.Filename, "log-file", "", "log file path")
 
+	fs.StringVar(&cfg.Security.CAPath, "cacert", "", "path of file that contains list of trusted TLS CAs")
+	fs.StringVar(&cfg.Security.CertPath, "cert", "", "path of file that contains X509 certificate in PEM format")
+	fs.StringVar(&cfg.Security.KeyPath, "key", "", "path of file that contains X509 key in PEM format")
+
 	return cfg
 }
 
 const (
 	defaultLeaderLease             = int64(3)
+	defaultTsoMaxClockDrift        = time.Second
 	defaultNextRetryDelay          = time.Second
 	defaultAutoCompactionRetention = 1
 
@@ -162,6 +200,12 @@ func adjustFloat64(v *float64, defValue float64) {
 	}
 }
 
+func adjustByteSize(v *typeutil.ByteSize, defValue uint64) {
+	if *v == 0 {
+		*v = typeutil.ByteSize(defValue)
+	}
+}
+
 func adjustDuration(v *typeutil.Duration, defValue time.Duration) {
 	if v.Duration == 0 {
 		v.Duration = defValue
@@ -214,7 +258,16 @@ func (c *Config) validate() error {
 	if c.Join != "" && c.InitialCluster != "" {
 		return errors.New("-initial-cluster and -join can not be provided at the same time")
 	}
-	return nil
+	if err := c.Security.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if d := c.TsoSaveInterval.Duration; d != 0 && d < minTsoSaveInterval {
+		return errors.Errorf("tso-save-interval %v is less than %v", d, minTsoSaveInterval)
+	}
+	if err := c.Auth.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.RateLimit.validate())
 }
 
 func (c *Config) adjust() error {
@@ -247,6 +300,7 @@ func (c *Config) adjust() error {
 	adjustInt64(&c.LeaderLease, defaultLeaderLease)
 
 	adjustDuration(&c.TsoSaveInterval, time.Duration(defaultLeaderLease)*time.Second)
+	adjustDuration(&c.TsoMaxClockDrift, defaultTsoMaxClockDrift)
 
 	if c.nextRetryDelay == 0 {
 		c.nextRetryDelay = defaultNextRetryDelay
@@ -286,11 +340,13 @@ func (c *Config) configFromFile(path string) error {
 
 // ScheduleConfig is the schedule configuration.
 type ScheduleConfig struct {
-	// If the snapshot count of one store is greater than this value,
-	// it will never be used as a source or target store.
+	// If the sending, receiving or applying snapshot count of one store is
+	// greater than this value, it will never be used as a source or target
+	// store, and no peer is added to the regions led by it.
 	MaxSnapshotCount uint64 `toml:"max-snapshot-count,omitempty" json:"max-snapshot-count"`
 	// MaxStoreDownTime is the max duration after which
 	// a store will be considered to be down if it hasn't reported heartbeats.
+	// The peers on the down stores are replaced by the replica checker.
 	MaxStoreDownTime typeutil.Duration `toml:"max-store-down-time,omitempty" json:"max-store-down-time"`
 	// LeaderScheduleLimit is the max coexist leader schedules.
 	LeaderScheduleLimit uint64 `toml:"leader-schedule-limit,omitempty" json:"leader-schedule-limit"`
@@ -298,23 +354,123 @@ type ScheduleConfig struct {
 	RegionScheduleLimit uint64 `toml:"region-schedule-limit,omitempty" json:"region-schedule-limit"`
 	// ReplicaScheduleLimit is the max coexist replica schedules.
 	ReplicaScheduleLimit uint64 `toml:"replica-schedule-limit,omitempty" json:"replica-schedule-limit"`
+	// MergeScheduleLimit is the max coexist region merges.
+	MergeScheduleLimit uint64 `toml:"merge-schedule-limit,omitempty" json:"merge-schedule-limit"`
+	// TolerantSizeRatio is the number of regions or leaders by which the
+	// stores may differ without being balanced, 0 means the tolerated
+	// difference grows with the square root of the count.
+	TolerantSizeRatio float64 `toml:"tolerant-size-ratio,omitempty" json:"tolerant-size-ratio"`
+	// SchedulerMaxWaitingOperator is the max number of operators of each
+	// scheduler waiting for the schedule limit, they run in the order of
+	// priorities when the running operators finish.
+	SchedulerMaxWaitingOperator uint64 `toml:"scheduler-max-waiting-operator,omitempty" json:"scheduler-max-waiting-operator"`
+	// MaxPendingPeerCount is the max number of pending peers of a store, the
+	// store with more pending peers is not a target of balancing.
+	MaxPendingPeerCount uint64 `toml:"max-pending-peer-count,omitempty" json:"max-pending-peer-count"`
+	// SnapshotBandwidth is the estimated bandwidth used by one snapshot
+	// transfer of the stores which haven't reported their snapshots.
+	SnapshotBandwidth typeutil.ByteSize `toml:"snapshot-bandwidth,omitempty" json:"snapshot-bandwidth"`
+	// MaxStoreSnapshotBandwidth is the max estimated snapshot traffic of one
+	// store, peer movements which exceed it will not be dispatched.
+	// 0 means no limit.
+	MaxStoreSnapshotBandwidth typeutil.ByteSize `toml:"max-store-snapshot-bandwidth,omitempty" json:"max-store-snapshot-bandwidth"`
+	// StoreBalanceRate is the max number of add-peer or remove-peer
+	// operators of one store per minute, 0 means no limit. It can be
+	// overridden for each store by the store limits.
+	StoreBalanceRate float64 `toml:"store-balance-rate,omitempty" json:"store-balance-rate"`
+	// LeaderWeightLabel is the label key used to distribute leaders by
+	// LeaderWeights, such as "zone".
+	LeaderWeightLabel string `toml:"leader-weight-label,omitempty" json:"leader-weight-label"`
+	// LeaderWeights is the expected proportion of leaders on the stores with
+	// each value of LeaderWeightLabel, such as "z1:70,z2:30". Stores with
+	// other values will have no leader.
+	LeaderWeights string `toml:"leader-weights,omitempty" json:"leader-weights"`
+	// RejectLeaderLabels are the labels of the stores which should have no
+	// leader, such as "zone=dr,disk=hdd". A store with any of the labels
+	// rejects leaders.
+	RejectLeaderLabels string `toml:"reject-leader-labels,omitempty" json:"reject-leader-labels"`
+	// HighSpaceRatio is the ratio of the used space of a store, above which
+	// no region is scheduled onto the store.
+	HighSpaceRatio float64 `toml:"high-space-ratio,omitempty" json:"high-space-ratio"`
+	// LowSpaceRatio is the ratio of the available space of a store, below
+	// which the store is short of space, and its regions are moved to other
+	// stores even if the regions are balanced.
+	LowSpaceRatio float64 `toml:"low-space-ratio,omitempty" json:"low-space-ratio"`
+	// WarmUpRegionRatio is the ratio of the regions which should have
+	// heartbeated before the new leader starts scheduling.
+	WarmUpRegionRatio float64 `toml:"warm-up-region-ratio,omitempty" json:"warm-up-region-ratio"`
+	// WarmUpStoreRatio is the ratio of the stores which should have
+	// heartbeated before the new leader starts scheduling, 0 means no check.
+	WarmUpStoreRatio float64 `toml:"warm-up-store-ratio,omitempty" json:"warm-up-store-ratio"`
+	// MaxStoreHeartbeatInterval is the interval which idle stores are asked
+	// to send store heartbeats at, 0 means the stores use their own config.
+	MaxStoreHeartbeatInterval typeutil.Duration `toml:"max-store-heartbeat-interval,omitempty" json:"max-store-heartbeat-interval"`
+	// MaxRegionHeartbeatInterval is the interval which the leaders of idle
+	// regions are asked to send region heartbeats at, 0 means the leaders
+	// use their own config. Operators of the idle regions are dispatched
+	// at their next heartbeats, so it delays scheduling of them.
+	MaxRegionHeartbeatInterval typeutil.Duration `toml:"max-region-heartbeat-interval,omitempty" json:"max-region-heartbeat-interval"`
+	// PatrolRegionInterval is the interval at which a batch of regions is
+	// checked by the replica and merge checkers without their heartbeats,
+	// so the regions which never heartbeat are repaired too.
+	PatrolRegionInterval typeutil.Duration `toml:"patrol-region-interval,omitempty" json:"patrol-region-interval"`
+	// MaxMergeRegionSize is the max approximate size of the regions which
+	// are merged into their adjacent regions, 0 disables region merge.
+	MaxMergeRegionSize typeutil.ByteSize `toml:"max-merge-region-size,omitempty" json:"max-merge-region-size"`
+	// MaxMergeRegionKeys is the max approximate number of keys of the
+	// regions to merge, 0 means no limit.
+	MaxMergeRegionKeys uint64 `toml:"max-merge-region-keys,omitempty" json:"max-merge-region-keys"`
+	// RegionSplitSize is the approximate size above which the regions are
+	// asked to split in their heartbeat responses, 0 disables it.
+	RegionSplitSize typeutil.ByteSize `toml:"region-split-size,omitempty" json:"region-split-size"`
+	// RegionSplitKeys is the approximate number of keys above which the
+	// regions are asked to split, 0 disables it.
+	RegionSplitKeys uint64 `toml:"region-split-keys,omitempty" json:"region-split-keys"`
+	// EnableRaftLearner makes the schedulers add a voter as a learner first
+	// and promote it after it catches up, the stores must support learners.
+	EnableRaftLearner bool `toml:"enable-raft-learner" json:"enable-raft-learner"`
+	// EnableJointConsensus makes the schedulers swap a voter between stores
+	// atomically in a joint consensus, the stores must support ChangePeerV2.
+	EnableJointConsensus bool `toml:"enable-joint-consensus" json:"enable-joint-consensus"`
 }
 
 const (
-	defaultMaxReplicas          = 3
-	defaultMaxSnapshotCount     = 3
-	defaultMaxStoreDownTime     = time.Hour
-	defaultLeaderScheduleLimit  = 1024
-	defaultRegionScheduleLimit  = 12
-	defaultReplicaScheduleLimit = 16
+	defaultMaxReplicas                 = 3
+	defaultMaxSnapshotCount            = 3
+	defaultMaxStoreDownTime            = time.Hour
+	defaultPatrolRegionInterval        = 100 * time.Millisecond
+	defaultLeaderScheduleLimit         = 1024
+	defaultRegionScheduleLimit         = 12
+	defaultReplicaScheduleLimit        = 16
+	defaultMergeScheduleLimit          = 8
+	defaultSchedulerMaxWaitingOperator = 3
+	defaultMaxPendingPeerCount         = 16
+	defaultSnapshotBandwidth           = 16 * 1024 * 1024
+	defaultHighSpaceRatio              = 0.8
+	defaultLowSpaceRatio               = 0.1
+	defaultWarmUpRegionRatio           = 0.8
 )
 
 func (c *ScheduleConfig) adjust() {
 	adjustUint64(&c.MaxSnapshotCount, defaultMaxSnapshotCount)
 	adjustDuration(&c.MaxStoreDownTime, defaultMaxStoreDownTime)
+	adjustDuration(&c.PatrolRegionInterval, defaultPatrolRegionInterval)
 	adjustUint64(&c.LeaderScheduleLimit, defaultLeaderScheduleLimit)
 	adjustUint64(&c.RegionScheduleLimit, defaultRegionScheduleLimit)
 	adjustUint64(&c.ReplicaScheduleLimit, defaultReplicaScheduleLimit)
+	adjustUint64(&c.MergeScheduleLimit, defaultMergeScheduleLimit)
+	adjustUint64(&c.SchedulerMaxWaitingOperator, defaultSchedulerMaxWaitingOperator)
+	adjustUint64(&c.MaxPendingPeerCount, defaultMaxPendingPeerCount)
+	adjustByteSize(&c.SnapshotBandwidth, defaultSnapshotBandwidth)
+	if c.HighSpaceRatio == 0 {
+		c.HighSpaceRatio = defaultHighSpaceRatio
+	}
+	if c.LowSpaceRatio == 0 {
+		c.LowSpaceRatio = defaultLowSpaceRatio
+	}
+	if c.WarmUpRegionRatio == 0 {
+		c.WarmUpRegionRatio = defaultWarmUpRegionRatio
+	}
 }
 
 // ReplicationConfig is the replication configuration.
@@ -383,6 +539,10 @@ func (o *scheduleOption) GetMaxStoreDownTime() time.Duration {
 	return o.load().MaxStoreDownTime.Duration
 }
 
+func (o *scheduleOption) GetPatrolRegionInterval() time.Duration {
+	return o.load().PatrolRegionInterval.Duration
+}
+
 func (o *scheduleOption) GetLeaderScheduleLimit() uint64 {
 	return o.load().LeaderScheduleLimit
 }
@@ -395,17 +555,315 @@ func (o *scheduleOption) GetReplicaScheduleLimit() uint64 {
 	return o.load().ReplicaScheduleLimit
 }
 
+func (o *scheduleOption) GetMergeScheduleLimit() uint64 {
+	return o.load().MergeScheduleLimit
+}
+
+func (o *scheduleOption) GetTolerantSizeRatio() float64 {
+	return o.load().TolerantSizeRatio
+}
+
+func (o *scheduleOption) GetSchedulerMaxWaitingOperator() uint64 {
+	return o.load().SchedulerMaxWaitingOperator
+}
+
+func (o *scheduleOption) GetSnapshotBandwidth() uint64 {
+	return uint64(o.load().SnapshotBandwidth)
+}
+
+func (o *scheduleOption) GetMaxStoreSnapshotBandwidth() uint64 {
+	return uint64(o.load().MaxStoreSnapshotBandwidth)
+}
+
+func (o *scheduleOption) GetMaxPendingPeerCount() uint64 {
+	return o.load().MaxPendingPeerCount
+}
+
+func (o *scheduleOption) GetStoreBalanceRate() float64 {
+	return o.load().StoreBalanceRate
+}
+
+func (o *scheduleOption) GetHighSpaceRatio() float64 {
+	return o.load().HighSpaceRatio
+}
+
+func (o *scheduleOption) GetLowSpaceRatio() float64 {
+	return o.load().LowSpaceRatio
+}
+
+func (o *scheduleOption) GetWarmUpRegionRatio() float64 {
+	return o.load().WarmUpRegionRatio
+}
+
+func (o *scheduleOption) GetWarmUpStoreRatio() float64 {
+	return o.load().WarmUpStoreRatio
+}
+
+func (o *scheduleOption) GetMaxStoreHeartbeatInterval() time.Duration {
+	return o.load().MaxStoreHeartbeatInterval.Duration
+}
+
+func (o *scheduleOption) GetMaxRegionHeartbeatInterval() time.Duration {
+	return o.load().MaxRegionHeartbeatInterval.Duration
+}
+
+func (o *scheduleOption) GetMaxMergeRegionSize() uint64 {
+	return uint64(o.load().MaxMergeRegionSize)
+}
+
+func (o *scheduleOption) GetMaxMergeRegionKeys() uint64 {
+	return o.load().MaxMergeRegionKeys
+}
+
+func (o *scheduleOption) GetRegionSplitSize() uint64 {
+	return uint64(o.load().RegionSplitSize)
+}
+
+func (o *scheduleOption) GetRegionSplitKeys() uint64 {
+	return o.load().RegionSplitKeys
+}
+
+func (o *scheduleOption) IsRaftLearnerEnabled() bool {
+	return o.load().EnableRaftLearner
+}
+
+func (o *scheduleOption) IsJointConsensusEnabled() bool {
+	return o.load().EnableJointConsensus
+}
+
+// GetLeaderWeights returns the leader weight label and the weights of its
+// values, the weights are nil if they are not configured.
+func (o *scheduleOption) GetLeaderWeights() (string, map[string]float64) {
+	cfg := o.load()
+	if cfg.LeaderWeightLabel == "" {
+		return "", nil
+	}
+	weights, err := parseLeaderWeights(cfg.LeaderWeights)
+	if err != nil {
+		return "", nil
+	}
+	return cfg.LeaderWeightLabel, weights
+}
+
+// GetRejectLeaderLabels returns the labels of the stores which should have
+// no leader.
+func (o *scheduleOption) GetRejectLeaderLabels() []*metapb.StoreLabel {
+	labels, err := parseRejectLeaderLabels(o.load().RejectLeaderLabels)
+	if err != nil {
+		return nil
+	}
+	return labels
+}
+
 func (o *scheduleOption) persist(kv *kv) error {
 	return kv.saveScheduleOption(o)
 }
 
-// ParseUrls parse a string into multiple urls.
+// parseLeaderWeights parses weights like "z1:70,z2:30" and normalizes them so
+// that their sum is 1.
+func parseLeaderWeights(s string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	var sum float64
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.Split(strings.TrimSpace(item), ":")
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid leader weight %q", item)
+		}
+		if _, ok := weights[kv[0]]; ok {
+			return nil, errors.Errorf("duplicated leader weight %q", item)
+		}
+		w, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil || w < 0 {
+			return nil, errors.Errorf("invalid leader weight %q", item)
+		}
+		weights[kv[0]] = w
+		sum += w
+	}
+	if sum == 0 {
+		return nil, errors.New("the sum of leader weights should be greater than 0")
+	}
+	for k := range weights {
+		weights[k] /= sum
+	}
+	return weights, nil
+}
+
+// parseRejectLeaderLabels parses labels like "zone=dr,disk=hdd".
+func parseRejectLeaderLabels(s string) ([]*metapb.StoreLabel, error) {
+	var labels []*metapb.StoreLabel
+	if strings.TrimSpace(s) == "" {
+		return labels, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.Split(strings.TrimSpace(item), "=")
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, errors.Errorf("invalid reject leader label %q", item)
+		}
+		labels = append(labels, &metapb.StoreLabel{Key: kv[0], Value: kv[1]})
+	}
+	return labels, nil
+}
+
+// SecurityConfig is the configuration for the TLS of the client and peer
+// endpoints. TLS is enabled if the certificate is set, and the clients are
+// required to present certificates signed by the CA if it is set.
+type SecurityConfig struct {
+	// CAPath is the path of file that contains list of trusted TLS CAs.
+	CAPath string `toml:"cacert-path" json:"cacert-path"`
+	// CertPath is the path of file that contains X509 certificate in PEM format.
+	CertPath string `toml:"cert-path" json:"cert-path"`
+	// KeyPath is the path of file that contains X509 key in PEM format.
+	KeyPath string `toml:"key-path" json:"key-path"`
+	// CertAllowedCN is the common names allowed in the client certificates,
+	// empty means any certificate signed by the CA is allowed.
+	CertAllowedCN []string `toml:"cert-allowed-cn" json:"cert-allowed-cn"`
+}
+
+func (s SecurityConfig) validate() error {
+	if (s.CertPath == "") != (s.KeyPath == "") {
+		return errors.New("cert-path and key-path must be provided at the same time")
+	}
+	if len(s.CertAllowedCN) > 0 && s.CAPath == "" {
+		return errors.New("cert-allowed-cn requires cacert-path")
+	}
+	return nil
+}
+
+func (s SecurityConfig) toTLSInfo() transport.TLSInfo {
+	return transport.TLSInfo{
+		CertFile:       s.CertPath,
+		KeyFile:        s.KeyPath,
+		TrustedCAFile:  s.CAPath,
+		ClientCertAuth: s.CAPath != "",
+	}
+}
+
+// ToTLSConfig generates the TLS config to connect to PD with the
+// certificate, it returns nil if TLS is not enabled.
+func (s SecurityConfig) ToTLSConfig() (*tls.Config, error) {
+	if s.CertPath == "" {
+		return nil, nil
+	}
+	info := transport.TLSInfo{
+		CertFile:      s.CertPath,
+		KeyFile:       s.KeyPath,
+		TrustedCAFile: s.CAPath,
+	}
+	tlsConfig, err := info.ClientConfig()
+	return tlsConfig, errors.Trace(err)
+}
+
+// GRPCConfig is the configuration of the gRPC server, the zero values mean
+// the defaults of etcd, which creates the gRPC server. The keepalive options
+// are not used by the TLS connections, which are served by the HTTP/2 server
+// of etcd.
+type GRPCConfig struct {
+	// KeepaliveTime is the idle time after which the server pings the client,
+	// etcd uses 2h by default.
+	KeepaliveTime typeutil.Duration `toml:"keepalive-time" json:"keepalive-time"`
+	// KeepaliveTimeout is the time to wait for the ping ack before closing
+	// the connection, etcd uses 20s by default.
+	KeepaliveTimeout typeutil.Duration `toml:"keepalive-timeout" json:"keepalive-timeout"`
+	// KeepaliveMinTime is the min interval the clients are allowed to ping,
+	// the connections pinging more frequently are closed. etcd uses 5s by
+	// default, and doesn't allow the clients to ping without active streams.
+	KeepaliveMinTime typeutil.Duration `toml:"keepalive-min-time" json:"keepalive-min-time"`
+	// MaxConcurrentStreams is the max number of the concurrent streams of
+	// each connection, 0 means no limit. etcd doesn't limit the streams, so
+	// PD rejects the streams over the limit with ResourceExhausted.
+	MaxConcurrentStreams uint32 `toml:"max-concurrent-streams" json:"max-concurrent-streams"`
+	// MaxRequestBytes is the max size of the requests, etcd uses 1.5MiB by
+	// default. The gRPC server accepts the messages up to 512KiB larger than
+	// it, and it limits the requests of the embedded etcd too.
+	MaxRequestBytes typeutil.ByteSize `toml:"max-request-bytes" json:"max-request-bytes"`
+}
+
+func (c GRPCConfig) apply(cfg *embed.Config) {
+	if c.KeepaliveTime.Duration > 0 {
+		cfg.GRPCKeepAliveInterval = c.KeepaliveTime.Duration
+	}
+	if c.KeepaliveTimeout.Duration > 0 {
+		cfg.GRPCKeepAliveTimeout = c.KeepaliveTimeout.Duration
+	}
+	if c.KeepaliveMinTime.Duration > 0 {
+		cfg.GRPCKeepAliveMinTime = c.KeepaliveMinTime.Duration
+	}
+	if c.MaxRequestBytes > 0 {
+		cfg.MaxRequestBytes = uint(c.MaxRequestBytes)
+	}
+}
+
+// RateLimitConfig is the rate limits of the unary gRPC methods. The keys are
+// the method names like "GetRegion", and the values are the requests per
+// second. The requests exceeding the limits are rejected, the methods not
+// configured are not limited.
+type RateLimitConfig struct {
+	// MethodRates limits the requests from all clients.
+	MethodRates map[string]float64 `toml:"method-rates" json:"method-rates"`
+	// ClientRates limits the requests from each client, requests from the
+	// same host are treated as the same client.
+	ClientRates map[string]float64 `toml:"client-rates" json:"client-rates"`
+}
+
+func (c RateLimitConfig) validate() error {
+	for _, rates := range []map[string]float64{c.MethodRates, c.ClientRates} {
+		for method, rate := range rates {
+			if rate <= 0 {
+				return errors.Errorf("invalid rate %v of %s", rate, method)
+			}
+		}
+	}
+	return nil
+}
+
+// AuthConfig is the credentials of the users allowed to call the mutating
+// HTTP APIs, the authentication is enabled if any credential is set. The
+// credentials are not exposed by the config API.
+type AuthConfig struct {
+	// Tokens maps the static tokens, which are sent as "Bearer" tokens, to
+	// the names of the users.
+	Tokens map[string]string `toml:"tokens" json:"-"`
+	// Users maps the names of the basic auth users to the passwords.
+	Users map[string]string `toml:"users" json:"-"`
+}
+
+func (c AuthConfig) validate() error {
+	for token, user := range c.Tokens {
+		if token == "" || user == "" {
+			return errors.New("auth token and its user name can not be empty")
+		}
+	}
+	for user, password := range c.Users {
+		if user == "" || password == "" {
+			return errors.New("auth user name and password can not be empty")
+		}
+	}
+	return nil
+}
+
+// String hides the tokens and passwords, the config is printed in the logs.
+func (c AuthConfig) String() string {
+	tokenUsers := make([]string, 0, len(c.Tokens))
+	for _, user := range c.Tokens {
+		tokenUsers = append(tokenUsers, user)
+	}
+	users := make([]string, 0, len(c.Users))
+	for user := range c.Users {
+		users = append(users, user)
+	}
+	sort.Strings(tokenUsers)
+	sort.Strings(users)
+	return fmt.Sprintf("{Tokens:%v Users:%v}", tokenUsers, users)
+}
+
+// ParseUrls parse a string into multiple urls, IPv6 addresses should be
+// bracketed like http://[::1]:2379.
 // Export for api.
 func ParseUrls(s string) ([]url.URL, error) {
 	items := strings.Split(s, ",")
 	urls := make([]url.URL, 0, len(items))
 	for _, item := range items {
-		u, err := url.Parse(item)
+		u, err := urlutil.ParseURL(item)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -453,6 +911,10 @@ func (c *Config) genEmbedEtcdConfig() (*embed.Config, error) {
 		return nil, errors.Trace(err)
 	}
 
+	cfg.ClientTLSInfo = c.Security.toTLSInfo()
+	c.GRPC.apply(cfg)
+	cfg.PeerTLSInfo = c.Security.toTLSInfo()
+
 	return cfg, nil
 }
 
@@ -0,0 +1,299 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ConfigChange is a changed config item.
+type ConfigChange struct {
+	Name string      `json:"name"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// ConfigCheckResult shows what would change if a config is applied.
+type ConfigCheckResult struct {
+	Changes            []*ConfigChange `json:"changes"`
+	AffectedSchedulers []string        `json:"affected-schedulers"`
+}
+
+// scheduleConfigs contains the config items which can be changed online.
+type scheduleConfigs struct {
+	schedule *ScheduleConfig
+	rep      *ReplicationConfig
+}
+
+// configValidator checks an invariant of the config change, cluster is nil if
+// it is not bootstrapped.
+type configValidator func(cluster *RaftCluster, old, new *scheduleConfigs) error
+
+// configValidators are applied to all config changes in order.
+var configValidators = []configValidator{
+	validateMaxReplicas,
+	validateLocationLabels,
+	validateSnapshotLimits,
+	validateStoreBalanceRate,
+	validateTolerantSizeRatio,
+	validateRegionSplitSize,
+	validateSpaceRatios,
+	validateLeaderWeights,
+	validateRejectLeaderLabels,
+	validateWarmUpRatios,
+	validateHeartbeatIntervals,
+}
+
+func validateMaxReplicas(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if new.rep.MaxReplicas == 0 {
+		return errors.New("max-replicas should be greater than 0")
+	}
+	// Only check increased value, so that other items still can be changed
+	// when some stores are down.
+	if cluster == nil || new.rep.MaxReplicas <= old.rep.MaxReplicas {
+		return nil
+	}
+	var upStores uint64
+	for _, store := range cluster.cachedCluster.getStores() {
+		if store.isUp() {
+			upStores++
+		}
+	}
+	if new.rep.MaxReplicas > upStores {
+		return errors.Errorf("max-replicas %d is greater than the up store count %d", new.rep.MaxReplicas, upStores)
+	}
+	return nil
+}
+
+func validateLocationLabels(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	labels := make(map[string]struct{})
+	for _, label := range new.rep.LocationLabels {
+		if label == "" {
+			return errors.New("location-labels should not contain empty label")
+		}
+		if _, ok := labels[label]; ok {
+			return errors.Errorf("location-labels contains duplicated label %s", label)
+		}
+		labels[label] = struct{}{}
+	}
+	return nil
+}
+
+func validateSnapshotLimits(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if new.schedule.MaxSnapshotCount == 0 {
+		return errors.New("max-snapshot-count should be greater than 0")
+	}
+	if new.schedule.SnapshotBandwidth == 0 {
+		return errors.New("snapshot-bandwidth should be greater than 0")
+	}
+	limit := new.schedule.MaxStoreSnapshotBandwidth
+	if limit != 0 && limit < new.schedule.SnapshotBandwidth {
+		return errors.Errorf("max-store-snapshot-bandwidth %d is less than snapshot-bandwidth %d, no peer can be moved", limit, new.schedule.SnapshotBandwidth)
+	}
+	return nil
+}
+
+func validateStoreBalanceRate(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if r := new.schedule.StoreBalanceRate; r < 0 {
+		return errors.Errorf("store-balance-rate %v should not be negative", r)
+	}
+	return nil
+}
+
+func validateTolerantSizeRatio(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if r := new.schedule.TolerantSizeRatio; r < 0 {
+		return errors.Errorf("tolerant-size-ratio %v should not be negative", r)
+	}
+	return nil
+}
+
+// validateRegionSplitSize prevents the split regions from being merged back.
+func validateRegionSplitSize(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	split, merge := new.schedule.RegionSplitSize, new.schedule.MaxMergeRegionSize
+	if split != 0 && split <= merge {
+		return errors.Errorf("region-split-size %v should be greater than max-merge-region-size %v", split, merge)
+	}
+	keys, mergeKeys := new.schedule.RegionSplitKeys, new.schedule.MaxMergeRegionKeys
+	if keys != 0 && keys <= mergeKeys {
+		return errors.Errorf("region-split-keys %v should be greater than max-merge-region-keys %v", keys, mergeKeys)
+	}
+	return nil
+}
+
+func validateSpaceRatios(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	high, low := new.schedule.HighSpaceRatio, new.schedule.LowSpaceRatio
+	if high <= 0 || high >= 1 {
+		return errors.Errorf("high-space-ratio %v should be in (0, 1)", high)
+	}
+	if low <= 0 || low >= 1 {
+		return errors.Errorf("low-space-ratio %v should be in (0, 1)", low)
+	}
+	// The stores short of space should not be the targets.
+	if low > 1-high {
+		return errors.Errorf("low-space-ratio %v is greater than the available ratio %v of high-space-ratio %v", low, 1-high, high)
+	}
+	return nil
+}
+
+func validateLeaderWeights(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if new.schedule.LeaderWeightLabel == "" {
+		if new.schedule.LeaderWeights != "" {
+			return errors.New("leader-weights is set without leader-weight-label")
+		}
+		return nil
+	}
+	_, err := parseLeaderWeights(new.schedule.LeaderWeights)
+	return errors.Trace(err)
+}
+
+func validateRejectLeaderLabels(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	_, err := parseRejectLeaderLabels(new.schedule.RejectLeaderLabels)
+	return errors.Trace(err)
+}
+
+func validateWarmUpRatios(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	if r := new.schedule.WarmUpRegionRatio; r <= 0 || r > 1 {
+		return errors.Errorf("warm-up-region-ratio %v should be in (0, 1]", r)
+	}
+	if r := new.schedule.WarmUpStoreRatio; r < 0 || r > 1 {
+		return errors.Errorf("warm-up-store-ratio %v should be in [0, 1]", r)
+	}
+	return nil
+}
+
+func validateHeartbeatIntervals(cluster *RaftCluster, old, new *scheduleConfigs) error {
+	// The store should heartbeat at least twice before it is shown as down.
+	if d := new.schedule.MaxStoreHeartbeatInterval.Duration; d != 0 && (d < time.Second || d > defaultStoreDownTime/2) {
+		return errors.Errorf("max-store-heartbeat-interval %v should be 0 or in [1s, %v]", d, defaultStoreDownTime/2)
+	}
+	if d := new.schedule.MaxRegionHeartbeatInterval.Duration; d != 0 && d < time.Second {
+		return errors.Errorf("max-region-heartbeat-interval %v should be 0 or at least 1s", d)
+	}
+	return nil
+}
+
+// configResourceKinds maps the config items to the kinds of the schedulers
+// which are affected by them.
+var configResourceKinds = map[string][]ResourceKind{
+	"leader-schedule-limit":          {LeaderKind},
+	"region-schedule-limit":          {RegionKind},
+	"tolerant-size-ratio":            {LeaderKind, RegionKind},
+	"scheduler-max-waiting-operator": {LeaderKind, RegionKind},
+	"max-snapshot-count":             {RegionKind},
+	"max-pending-peer-count":         {RegionKind},
+	"snapshot-bandwidth":             {RegionKind},
+	"max-store-snapshot-bandwidth":   {RegionKind},
+	"store-balance-rate":             {RegionKind},
+	"enable-raft-learner":            {RegionKind},
+	"enable-joint-consensus":         {RegionKind},
+	"high-space-ratio":               {RegionKind},
+	"low-space-ratio":                {RegionKind},
+	"max-store-down-time":            {LeaderKind, RegionKind},
+	"location-labels":                {RegionKind},
+	"leader-weight-label":            {LeaderKind},
+	"leader-weights":                 {LeaderKind},
+	"reject-leader-labels":           {LeaderKind},
+}
+
+// diffConfig returns the changed items, the items are named by json tags.
+func diffConfig(old, new interface{}) ([]*ConfigChange, error) {
+	oldItems, err := configItems(old)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newItems, err := configItems(new)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var changes []*ConfigChange
+	for name, v := range newItems {
+		if !reflect.DeepEqual(oldItems[name], v) {
+			changes = append(changes, &ConfigChange{Name: name, Old: oldItems[name], New: v})
+		}
+	}
+	return changes, nil
+}
+
+func configItems(cfg interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	items := make(map[string]interface{})
+	if err = json.Unmarshal(data, &items); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return items, nil
+}
+
+// CheckConfig validates the config change without applying it, and returns
+// the changed items and the affected schedulers. A nil config means it is
+// not changed.
+func (s *Server) CheckConfig(schedule *ScheduleConfig, rep *ReplicationConfig) (*ConfigCheckResult, error) {
+	old := &scheduleConfigs{
+		schedule: s.GetScheduleConfig(),
+		rep:      s.GetReplicationConfig(),
+	}
+	new := &scheduleConfigs{
+		schedule: schedule,
+		rep:      rep,
+	}
+	if new.schedule == nil {
+		new.schedule = old.schedule
+	}
+	if new.rep == nil {
+		new.rep = old.rep
+	}
+
+	cluster := s.GetRaftCluster()
+	for _, validate := range configValidators {
+		if err := validate(cluster, old, new); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	result := &ConfigCheckResult{}
+	for _, pair := range [][2]interface{}{{old.schedule, new.schedule}, {old.rep, new.rep}} {
+		changes, err := diffConfig(pair[0], pair[1])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Changes = append(result.Changes, changes...)
+	}
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Name < result.Changes[j].Name
+	})
+
+	if cluster != nil {
+		kinds := make(map[ResourceKind]struct{})
+		for _, change := range result.Changes {
+			for _, kind := range configResourceKinds[change.Name] {
+				kinds[kind] = struct{}{}
+			}
+		}
+		for name, kind := range cluster.coordinator.getSchedulerKinds() {
+			if _, ok := kinds[kind]; ok {
+				result.AffectedSchedulers = append(result.AffectedSchedulers, name)
+			}
+		}
+		sort.Strings(result.AffectedSchedulers)
+	}
+	return result, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testConfigCheckSuite{})
+
+type testConfigCheckSuite struct {
+	testClusterBaseSuite
+}
+
+func (s *testConfigCheckSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = newTestServer(c)
+	s.client = s.svr.client
+	go s.svr.Run()
+	mustWaitLeader(c, []*Server{s.svr})
+	s.grpcPDClient = mustNewGrpcClient(c, s.svr.GetAddr())
+}
+
+func (s *testConfigCheckSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testConfigCheckSuite) TestCheckConfig(c *C) {
+	// Not bootstrapped, the store count is not checked.
+	rep := s.svr.GetReplicationConfig()
+	rep.MaxReplicas = 5
+	result, err := s.svr.CheckConfig(nil, rep)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 1)
+	c.Assert(result.Changes[0].Name, Equals, "max-replicas")
+	c.Assert(result.AffectedSchedulers, HasLen, 0)
+
+	s.bootstrapCluster(c, s.svr.clusterID, "127.0.0.1:0")
+	cluster := s.svr.GetRaftCluster()
+	c.Assert(cluster, NotNil)
+	cluster.coordinator.addScheduler(newBalanceLeaderScheduler(cluster.coordinator.opt), minScheduleInterval)
+	cluster.coordinator.addScheduler(newBalanceRegionScheduler(cluster.coordinator.opt), minScheduleInterval)
+
+	result, err = s.svr.CheckConfig(nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 0)
+
+	// Only 1 store is up.
+	_, err = s.svr.CheckConfig(nil, rep)
+	c.Assert(err, NotNil)
+	c.Assert(s.svr.SetReplicationConfig(*rep), NotNil)
+	c.Assert(s.svr.GetReplicationConfig().MaxReplicas, Equals, uint64(3))
+	rep.MaxReplicas = 0
+	_, err = s.svr.CheckConfig(nil, rep)
+	c.Assert(err, NotNil)
+	// Decreasing is always allowed.
+	rep.MaxReplicas = 1
+	_, err = s.svr.CheckConfig(nil, rep)
+	c.Assert(err, IsNil)
+
+	rep = s.svr.GetReplicationConfig()
+	rep.LocationLabels = []string{"zone", "zone"}
+	_, err = s.svr.CheckConfig(nil, rep)
+	c.Assert(err, NotNil)
+	rep.LocationLabels = []string{"zone", "host"}
+	result, err = s.svr.CheckConfig(nil, rep)
+	c.Assert(err, IsNil)
+	c.Assert(result.AffectedSchedulers, DeepEquals, []string{"balance-region-scheduler"})
+
+	schedule := s.svr.GetScheduleConfig()
+	schedule.MaxStoreSnapshotBandwidth = schedule.SnapshotBandwidth / 2
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, NotNil)
+	schedule = s.svr.GetScheduleConfig()
+	schedule.StoreBalanceRate = -1
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, NotNil)
+	// The stores short of space should not be the targets.
+	schedule = s.svr.GetScheduleConfig()
+	schedule.HighSpaceRatio = 0.95
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, NotNil)
+	schedule.LowSpaceRatio = 0.05
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, IsNil)
+
+	// The split regions should not be merged back.
+	schedule = s.svr.GetScheduleConfig()
+	schedule.MaxMergeRegionSize = 64 * 1024 * 1024
+	schedule.RegionSplitSize = schedule.MaxMergeRegionSize
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, NotNil)
+	schedule.RegionSplitSize = 2 * schedule.MaxMergeRegionSize
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, IsNil)
+
+	// Stores should heartbeat before they are shown as down.
+	schedule = s.svr.GetScheduleConfig()
+	schedule.MaxStoreHeartbeatInterval.Duration = time.Minute
+	_, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, NotNil)
+	schedule.MaxStoreHeartbeatInterval.Duration = 30 * time.Second
+	schedule.MaxRegionHeartbeatInterval.Duration = 10 * time.Minute
+	result, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 2)
+
+	schedule = s.svr.GetScheduleConfig()
+	schedule.LeaderScheduleLimit++
+	schedule.RegionScheduleLimit++
+	result, err = s.svr.CheckConfig(schedule, nil)
+	c.Assert(err, IsNil)
+	c.Assert(result.Changes, HasLen, 2)
+	c.Assert(result.Changes[0].Name, Equals, "leader-schedule-limit")
+	c.Assert(result.Changes[1].Name, Equals, "region-schedule-limit")
+	c.Assert(result.AffectedSchedulers, DeepEquals, []string{"balance-leader-scheduler", "balance-region-scheduler"})
+	// Dry run doesn't change the config.
+	c.Assert(s.svr.GetScheduleConfig().LeaderScheduleLimit, Equals, schedule.LeaderScheduleLimit-1)
+	c.Assert(s.svr.SetScheduleConfig(*schedule), IsNil)
+	c.Assert(s.svr.GetScheduleConfig(), DeepEquals, schedule)
+}
+
+func (s *testConfigCheckSuite) TestParseRejectLeaderLabels(c *C) {
+	labels, err := parseRejectLeaderLabels("zone=dr, disk=hdd")
+	c.Assert(err, IsNil)
+	c.Assert(labels, DeepEquals, []*metapb.StoreLabel{{Key: "zone", Value: "dr"}, {Key: "disk", Value: "hdd"}})
+	labels, err = parseRejectLeaderLabels("")
+	c.Assert(err, IsNil)
+	c.Assert(labels, HasLen, 0)
+
+	for _, s := range []string{"zone", "zone=", "=dr", "zone=dr,", "zone=dr=1"} {
+		_, err = parseRejectLeaderLabels(s)
+		c.Assert(err, NotNil)
+	}
+}
+
+func (s *testConfigCheckSuite) TestParseLeaderWeights(c *C) {
+	weights, err := parseLeaderWeights("z1:3, z2:1")
+	c.Assert(err, IsNil)
+	c.Assert(weights, DeepEquals, map[string]float64{"z1": 0.75, "z2": 0.25})
+
+	for _, s := range []string{"", "z1", "z1:a", "z1:-1", ":1", "z1:0", "z1:1,z1:2"} {
+		_, err = parseLeaderWeights(s)
+		c.Assert(err, NotNil)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/logutil"
+)
+
+// configReloadInterval is the interval to check if the config file is
+// modified.
+const configReloadInterval = 10 * time.Second
+
+// configReloader reloads the config items which can be changed online from
+// the config file: the log level, the schedule and replication configs.
+type configReloader struct {
+	sync.Mutex
+	path    string
+	modTime time.Time
+	// last is the config loaded from the file last time. Only the items
+	// changed in the file are applied, so the changes made by the config API
+	// are kept.
+	last *Config
+}
+
+func newConfigReloader(cfg *Config) *configReloader {
+	r := &configReloader{
+		path: cfg.configFile,
+		last: cfg.clone(),
+	}
+	if r.path == "" {
+		return r
+	}
+	if st, err := os.Stat(r.path); err == nil {
+		r.modTime = st.ModTime()
+	}
+	if last, err := loadDynamicConfig(r.path); err == nil {
+		r.last = last
+	}
+	return r
+}
+
+// loadDynamicConfig loads the config items which can be changed online.
+func loadDynamicConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if err := cfg.configFromFile(path); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.LogLevelDeprecated != "" && cfg.Log.Level == "" {
+		cfg.Log.Level = cfg.LogLevelDeprecated
+	}
+	cfg.Schedule.adjust()
+	cfg.Replication.adjust()
+	return cfg, nil
+}
+
+// overlayConfig sets the fields of cfg which are changed from old to new,
+// cfg, old and new are pointers to the same type of struct.
+func overlayConfig(cfg, old, new interface{}) bool {
+	c, o, n := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	changed := false
+	for i := 0; i < n.NumField(); i++ {
+		if !reflect.DeepEqual(o.Field(i).Interface(), n.Field(i).Interface()) {
+			c.Field(i).Set(n.Field(i))
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ReloadConfig reloads the config file and applies the changed items which
+// can be changed online. The changes are validated like the config API.
+func (s *Server) ReloadConfig() error {
+	r := s.reloader
+	r.Lock()
+	defer r.Unlock()
+
+	if r.path == "" {
+		return errors.New("no config file is specified")
+	}
+	if st, err := os.Stat(r.path); err == nil {
+		r.modTime = st.ModTime()
+	}
+	cfg, err := loadDynamicConfig(r.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedule *ScheduleConfig
+		rep      *ReplicationConfig
+	)
+	if newSchedule := s.GetScheduleConfig(); overlayConfig(newSchedule, &r.last.Schedule, &cfg.Schedule) {
+		schedule = newSchedule
+	}
+	if newRep := s.GetReplicationConfig(); overlayConfig(newRep, &r.last.Replication, &cfg.Replication) {
+		rep = newRep
+	}
+	if _, err = s.CheckConfig(schedule, rep); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Log.Level != r.last.Log.Level && cfg.Log.Level != "" {
+		if err = logutil.SetLevel(cfg.Log.Level); err != nil {
+			return errors.Trace(err)
+		}
+		log.Infof("log level is changed to %s", cfg.Log.Level)
+	}
+	if schedule != nil {
+		if err = s.SetScheduleConfig(*schedule); err != nil {
+			return errors.Trace(err)
+		}
+		log.Infof("schedule config is reloaded: %+v", *schedule)
+	}
+	if rep != nil {
+		if err = s.SetReplicationConfig(*rep); err != nil {
+			return errors.Trace(err)
+		}
+		log.Infof("replication config is reloaded: %+v", *rep)
+	}
+	r.last = cfg
+	return nil
+}
+
+// configReloadLoop reloads the config file when it is modified.
+func (s *Server) configReloadLoop() {
+	defer s.wg.Done()
+
+	if s.reloader.path == "" {
+		return
+	}
+	ticker := time.NewTicker(configReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.client.Ctx().Done():
+			return
+		}
+		st, err := os.Stat(s.reloader.path)
+		if err != nil {
+			log.Errorf("stat config file err %v", err)
+			continue
+		}
+		s.reloader.Lock()
+		modified := !st.ModTime().Equal(s.reloader.modTime)
+		s.reloader.Unlock()
+		if !modified {
+			continue
+		}
+		log.Infof("config file %s is modified, reload it", s.reloader.path)
+		if err = s.ReloadConfig(); err != nil {
+			log.Errorf("reload config err %v", err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testConfigReloadSuite{})
+
+type testConfigReloadSuite struct{}
+
+func (s *testConfigReloadSuite) TestReloadConfig(c *C) {
+	svr, cleanup := mustRunTestServer(c)
+	defer cleanup()
+	defer log.SetLevel(log.GetLevel())
+
+	c.Assert(svr.ReloadConfig(), NotNil)
+
+	f, err := ioutil.TempFile("", "pd_config")
+	c.Assert(err, IsNil)
+	defer os.Remove(f.Name())
+	writeConfig := func(data string) {
+		c.Assert(ioutil.WriteFile(f.Name(), []byte(data), 0644), IsNil)
+	}
+	writeConfig(`
+[log]
+level = "info"
+[schedule]
+leader-schedule-limit = 8
+`)
+	cfg := svr.cfg.clone()
+	cfg.configFile = f.Name()
+	svr.reloader = newConfigReloader(cfg)
+
+	// The changes made by the API are kept if the items are not changed in
+	// the file.
+	schedule := *svr.GetScheduleConfig()
+	schedule.RegionScheduleLimit = 3
+	c.Assert(svr.SetScheduleConfig(schedule), IsNil)
+	writeConfig(`
+[log]
+level = "debug"
+[schedule]
+leader-schedule-limit = 16
+`)
+	c.Assert(svr.ReloadConfig(), IsNil)
+	c.Assert(log.GetLevel(), Equals, log.DebugLevel)
+	c.Assert(svr.GetScheduleConfig().LeaderScheduleLimit, Equals, uint64(16))
+	c.Assert(svr.GetScheduleConfig().RegionScheduleLimit, Equals, uint64(3))
+
+	// Nothing is applied if the config is invalid.
+	writeConfig(`
+[log]
+level = "whatever"
+[schedule]
+leader-schedule-limit = 32
+`)
+	c.Assert(svr.ReloadConfig(), NotNil)
+	c.Assert(svr.GetScheduleConfig().LeaderScheduleLimit, Equals, uint64(16))
+}
@@ -15,20 +15,26 @@ package server
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 )
 
 const (
 	runSchedulerCheckInterval = 3 * time.Second
-	collectFactor             = 0.8
+	operatorCheckInterval     = 10 * time.Second
+	drainRegionBatch          = 4
+	patrolScanRegionLimit     = 128
 	historiesCacheSize        = 1000
 	eventsCacheSize           = 1000
+	recordsCacheSize          = 10000
 	maxScheduleRetries        = 10
 	maxScheduleInterval       = time.Minute
 	minScheduleInterval       = time.Millisecond * 10
@@ -36,15 +42,20 @@ const (
 	scheduleIntervalFactor    = 1.3
 
 	writeStatLRUMaxLen            = 1000
+	readStatLRUMaxLen             = 1000
 	storeHotRegionsDefaultLen     = 100
 	hotRegionLimitFactor          = 0.75
 	hotRegionScheduleFactor       = 0.9
 	hotRegionMinWriteRate         = 16 * 1024
+	hotRegionMinReadRate          = 128 * 1024
 	regionHeartBeatReportInterval = 60
+	defaultRegionSize             = 64 * 1024 * 1024
 	storeHeartBeatReportInterval  = 10
 	minHotRegionReportInterval    = 3
 	hotRegionAntiCount            = 1
+	hotRegionDecayRatio           = 0.5
 	hotRegionScheduleName         = "balance-hot-region-scheduler"
+	hotReadRegionScheduleName     = "balance-hot-read-region-scheduler"
 )
 
 var (
@@ -60,30 +71,49 @@ type coordinator struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	cluster    *clusterInfo
-	opt        *scheduleOption
-	limiter    *scheduleLimiter
-	checker    *replicaChecker
-	operators  map[uint64]Operator
-	schedulers map[string]*scheduleController
+	cluster      *clusterInfo
+	opt          *scheduleOption
+	limiter      *scheduleLimiter
+	waiting      *waitingOperatorQueue
+	storeLimiter *storeLimiter
+	checker      *replicaChecker
+	merger       *mergeChecker
+	splitter     *splitChecker
+	scatterer    *regionScatterer
+	operators    map[uint64]Operator
+	schedulers   map[string]*scheduleController
 
 	histories *lruCache
 	events    *fifoCache
+	records   *fifoCache
+
+	// ready means enough regions and stores have heartbeated, it is never
+	// reset once set.
+	ready bool
+	// pauseUntil is the unix nanoseconds until which all the scheduling is
+	// paused, it is accessed atomically.
+	pauseUntil int64
 }
 
 func newCoordinator(cluster *clusterInfo, opt *scheduleOption) *coordinator {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &coordinator{
-		ctx:        ctx,
-		cancel:     cancel,
-		cluster:    cluster,
-		opt:        opt,
-		limiter:    newScheduleLimiter(),
-		checker:    newReplicaChecker(opt, cluster),
-		operators:  make(map[uint64]Operator),
-		schedulers: make(map[string]*scheduleController),
-		histories:  newLRUCache(historiesCacheSize),
-		events:     newFifoCache(eventsCacheSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		cluster:      cluster,
+		opt:          opt,
+		limiter:      newScheduleLimiter(),
+		waiting:      newWaitingOperatorQueue(),
+		storeLimiter: newStoreLimiter(cluster, opt),
+		checker:      newReplicaChecker(opt, cluster),
+		merger:       newMergeChecker(opt, cluster),
+		splitter:     newSplitChecker(opt),
+		scatterer:    newRegionScatterer(cluster, opt),
+		operators:    make(map[uint64]Operator),
+		schedulers:   make(map[string]*scheduleController),
+		histories:    newLRUCache(historiesCacheSize),
+		events:       newFifoCache(eventsCacheSize),
+		records:      newFifoCache(recordsCacheSize),
 	}
 }
 
@@ -96,28 +126,64 @@ func (c *coordinator) dispatch(region *RegionInfo) *pdpb.RegionHeartbeatResponse
 			return res
 		}
 		c.removeOperator(op)
+		// The source region is merged and doesn't heartbeat any more.
+		if sourceID := getMergeSource(op); sourceID != 0 {
+			if source := c.getOperator(sourceID); source != nil {
+				source.SetState(OperatorFinished)
+				c.removeOperator(source)
+			}
+		}
+	}
+
+	// Check split. The large regions are asked to split even if the replicas
+	// can't be scheduled, they pile up when the scheduling is busy or paused.
+	if split := c.splitter.Check(region); split != nil {
+		return &pdpb.RegionHeartbeatResponse{SplitRegion: split}
 	}
 
 	// Check replica operator.
-	if c.limiter.operatorCount(RegionKind) >= c.opt.GetReplicaScheduleLimit() {
+	if !c.checkReady() || c.isSchedulePaused() {
 		return nil
 	}
-	if op := c.checker.Check(region); op != nil {
-		if c.addOperator(op) {
+	if !c.allowReplicaCheck() && !c.cluster.isPriorityRegion(region) {
+		return nil
+	}
+	if op := c.checkReplica(region); op != nil {
+		w := c.newReplicaOperator(op)
+		if c.limiter.operatorCount(w.kind) < w.limit && c.addOperator(op) {
 			res, _ := op.Do(region)
 			return res
 		}
+		c.addWaitingOperator(w)
+		return nil
+	}
+
+	// Check merge operator.
+	if !c.allowMerge() {
+		return nil
+	}
+	if ops := c.merger.Check(region); ops != nil {
+		if c.addMergeOperators(ops) {
+			res, _ := ops[0].Do(region)
+			return res
+		}
 	}
 
 	return nil
 }
 
 func (c *coordinator) run() {
+	c.restoreSchedulePause()
+
+	c.wg.Add(2)
+	go c.runOperatorChecker()
+	go c.runRegionPatrol()
+
 	ticker := time.NewTicker(runSchedulerCheckInterval)
 	defer ticker.Stop()
 	log.Info("coordinator: Start collect cluster information")
 	for {
-		if c.shouldRun() {
+		if c.checkReady() {
 			log.Info("coordinator: Cluster information is prepared")
 			break
 		}
@@ -129,9 +195,175 @@ func (c *coordinator) run() {
 		}
 	}
 	log.Info("coordinator: Run scheduler")
-	c.addScheduler(newBalanceLeaderScheduler(c.opt), minScheduleInterval)
-	c.addScheduler(newBalanceRegionScheduler(c.opt), minScheduleInterval)
-	c.addScheduler(newBalanceHotRegionScheduler(c.opt), minSlowScheduleInterval)
+	c.restoreSchedulers()
+}
+
+// runOperatorChecker cancels the timed out operators periodically, an
+// operator is never finished by heartbeats if the region has no leader. It
+// drains the offline stores too.
+func (c *coordinator) runOperatorChecker() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(operatorCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.removeTimeoutOperators(time.Now())
+			c.drainOfflineStores()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// removeTimeoutOperators cancels the operators running longer than their
+// timeouts, and replaces them by the operators from the replica checker.
+func (c *coordinator) removeTimeoutOperators(now time.Time) {
+	var timeouts []Operator
+	c.Lock()
+	for _, op := range c.operators {
+		if isOperatorTimeout(op, now) {
+			log.Warnf("coordinator: operator timeout, cancel it: %+v", op)
+			op.SetState(OperatorTimeOut)
+			c.removeOperatorLocked(op)
+			timeouts = append(timeouts, op)
+		}
+	}
+	c.Unlock()
+
+	for _, op := range timeouts {
+		region := c.cluster.getRegion(op.GetRegionID())
+		if region == nil || c.isSchedulePaused() || !c.allowReplicaCheck() {
+			continue
+		}
+		if newOp := c.checkReplica(region); newOp != nil {
+			c.scheduleOperator(c.newReplicaOperator(newOp))
+		}
+	}
+	c.promoteWaitingOperators(now)
+}
+
+// drainOfflineStores checks some regions on each offline store without
+// waiting for their heartbeats, so the regions are moved away at the priority
+// of the replica checker. The store is buried once it has no region.
+func (c *coordinator) drainOfflineStores() {
+	if !c.checkReady() || c.isSchedulePaused() {
+		return
+	}
+	for _, store := range c.cluster.getStores() {
+		if !store.isOffline() {
+			continue
+		}
+		for i := 0; i < drainRegionBatch; i++ {
+			if !c.allowReplicaCheck() {
+				return
+			}
+			// Pick the regions of the followers and the leaders alternately.
+			region := c.cluster.randFollowerRegion(store.GetId())
+			if i%2 == 1 || region == nil {
+				if leaderRegion := c.cluster.randLeaderRegion(store.GetId()); leaderRegion != nil {
+					region = leaderRegion
+				}
+			}
+			if region == nil {
+				break
+			}
+			if c.getOperator(region.GetId()) != nil {
+				continue
+			}
+			if op := c.checkReplica(region); op != nil {
+				c.scheduleOperator(c.newReplicaOperator(op))
+			}
+		}
+	}
+}
+
+// runRegionPatrol walks all the regions in key order, a batch at each
+// patrol-region-interval, and checks them as if they heartbeated. The
+// priority key ranges are checked before each batch.
+func (c *coordinator) runRegionPatrol() {
+	defer c.wg.Done()
+
+	timer := time.NewTimer(runSchedulerCheckInterval)
+	defer timer.Stop()
+
+	var key []byte
+	for {
+		select {
+		case <-timer.C:
+			interval := c.opt.GetPatrolRegionInterval()
+			if interval > 0 {
+				c.patrolPriorityRanges()
+				key = c.patrolRegions(key)
+			} else {
+				interval = runSchedulerCheckInterval
+			}
+			timer.Reset(interval)
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// patrolRegions checks a batch of regions from the key, and returns the start
+// key of the next batch, which is nil after the last region.
+func (c *coordinator) patrolRegions(startKey []byte) []byte {
+	if !c.checkReady() || c.isSchedulePaused() {
+		return startKey
+	}
+	regions := c.cluster.scanRegions(startKey, nil, patrolScanRegionLimit)
+	for _, region := range regions {
+		if c.getOperator(region.GetId()) != nil {
+			continue
+		}
+		if c.allowReplicaCheck() {
+			if op := c.checkReplica(region); op != nil {
+				c.scheduleOperator(c.newReplicaOperator(op))
+				continue
+			}
+		}
+		if !c.allowMerge() {
+			continue
+		}
+		if ops := c.merger.Check(region); ops != nil {
+			c.addMergeOperators(ops)
+		}
+	}
+	if len(regions) < patrolScanRegionLimit {
+		return nil
+	}
+	return regions[len(regions)-1].GetEndKey()
+}
+
+// checkReplica leaves the joint consensus and removes the orphan learner of
+// the region first, then checks the region with the replica checker.
+func (c *coordinator) checkReplica(region *RegionInfo) Operator {
+	if op := newLeaveJoint(region); op != nil {
+		return op
+	}
+	if op := c.checkOrphanPeer(region); op != nil {
+		return op
+	}
+	return c.checker.Check(region)
+}
+
+// checkOrphanPeer removes the learner left behind by the last operator of the
+// region, which timed out or was replaced after adding the learner but before
+// promoting it. The learner is not counted as a replica, so the replica
+// checker would keep it forever.
+func (c *coordinator) checkOrphanPeer(region *RegionInfo) Operator {
+	op := c.getLatestOperator(region.GetId())
+	if op == nil || (op.GetState() != OperatorTimeOut && op.GetState() != OperatorReplaced) {
+		return nil
+	}
+	orphans := getOrphanLearners(op, region)
+	if len(orphans) == 0 {
+		return nil
+	}
+	log.Warnf("coordinator: region %d has orphan learner %v left by %+v", region.GetId(), orphans[0], op)
+	return newRemovePeer(region, orphans[0])
 }
 
 func (c *coordinator) stop() {
@@ -149,6 +381,28 @@ func (c *coordinator) getHotWriteRegions() *StoreHotRegionInfos {
 	return s.Scheduler.(*balanceHotRegionScheduler).GetStatus()
 }
 
+func (c *coordinator) getHotReadRegions() map[uint64]*HotRegionsStat {
+	c.RLock()
+	defer c.RUnlock()
+	s, ok := c.schedulers[hotReadRegionScheduleName]
+	if !ok {
+		return nil
+	}
+	return s.Scheduler.(*balanceHotReadRegionScheduler).GetStatus()
+}
+
+// getSchedulerKinds returns the resource kinds of the running schedulers.
+func (c *coordinator) getSchedulerKinds() map[string]ResourceKind {
+	c.RLock()
+	defer c.RUnlock()
+
+	kinds := make(map[string]ResourceKind, len(c.schedulers))
+	for name, s := range c.schedulers {
+		kinds[name] = s.GetResourceKind()
+	}
+	return kinds
+}
+
 func (c *coordinator) getSchedulers() []string {
 	c.RLock()
 	defer c.RUnlock()
@@ -165,7 +419,7 @@ func (c *coordinator) collectSchedulerMetrics() {
 	defer c.RUnlock()
 	for _, s := range c.schedulers {
 		var allowScheduler float64
-		if s.AllowSchedule() {
+		if !s.isPaused() && !c.isSchedulePaused() && s.AllowSchedule() {
 			allowScheduler = 1
 		}
 		limit := float64(s.GetResourceLimit())
@@ -202,13 +456,61 @@ func (c *coordinator) collectHotSpotMetrics() {
 }
 
 func (c *coordinator) shouldRun() bool {
-	return c.cluster.isPrepared()
+	return c.getWarmUpStatus().Ready
+}
+
+func (c *coordinator) isReady() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.ready
 }
 
+// checkReady marks the coordinator ready if enough regions and stores have
+// heartbeated.
+func (c *coordinator) checkReady() bool {
+	if c.isReady() {
+		return true
+	}
+	if !c.shouldRun() {
+		return false
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.ready = true
+	return true
+}
+
+// WarmUpStatus is the progress of collecting the cluster information after
+// the leader is elected, no operator is created until it is ready.
+type WarmUpStatus struct {
+	Ready         bool `json:"ready"`
+	RegionCount   int  `json:"region_count"`
+	ActiveRegions int  `json:"active_regions"`
+	StoreCount    int  `json:"store_count"`
+	ActiveStores  int  `json:"active_stores"`
+}
+
+func (c *coordinator) getWarmUpStatus() *WarmUpStatus {
+	status := c.cluster.getWarmUpStatus()
+	status.Ready = c.isReady() ||
+		(float64(status.RegionCount)*c.opt.GetWarmUpRegionRatio() <= float64(status.ActiveRegions) &&
+			float64(status.StoreCount)*c.opt.GetWarmUpStoreRatio() <= float64(status.ActiveStores))
+	return status
+}
+
+// addScheduler starts the scheduler and persists it, so it will be restored
+// after the leader changes.
 func (c *coordinator) addScheduler(scheduler Scheduler, interval time.Duration) error {
 	c.Lock()
 	defer c.Unlock()
 
+	if err := c.startSchedulerLocked(scheduler, interval); err != nil {
+		return err
+	}
+	return errors.Trace(c.saveSchedulersLocked())
+}
+
+func (c *coordinator) startSchedulerLocked(scheduler Scheduler, interval time.Duration) error {
 	if _, ok := c.schedulers[scheduler.GetName()]; ok {
 		return errSchedulerExisted
 	}
@@ -235,7 +537,48 @@ func (c *coordinator) removeScheduler(name string) error {
 
 	s.Stop()
 	delete(c.schedulers, name)
-	return nil
+	return errors.Trace(c.saveSchedulersLocked())
+}
+
+// pauseScheduler pauses the scheduler for the duration, 0 pauses it until
+// it's resumed. The scheduler resumes by itself when the pause expires.
+func (c *coordinator) pauseScheduler(name string, d time.Duration) error {
+	until := int64(math.MaxInt64)
+	if d > 0 {
+		until = time.Now().Add(d).UnixNano()
+	}
+	return errors.Trace(c.setSchedulerPauseUntil(name, until))
+}
+
+// resumeScheduler resumes the scheduler paused by pauseScheduler.
+func (c *coordinator) resumeScheduler(name string) error {
+	return errors.Trace(c.setSchedulerPauseUntil(name, 0))
+}
+
+func (c *coordinator) setSchedulerPauseUntil(name string, until int64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	s, ok := c.schedulers[name]
+	if !ok {
+		return errSchedulerNotFound
+	}
+	atomic.StoreInt64(&s.pauseUntil, until)
+	return errors.Trace(c.saveSchedulersLocked())
+}
+
+// getSchedulerStatuses returns the statuses of the schedulers sorted by the
+// names.
+func (c *coordinator) getSchedulerStatuses() []*SchedulerStatus {
+	c.RLock()
+	defer c.RUnlock()
+
+	statuses := make([]*SchedulerStatus, 0, len(c.schedulers))
+	for _, s := range c.schedulers {
+		statuses = append(statuses, s.getStatus())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
 }
 
 func (c *coordinator) runScheduler(s *scheduleController) {
@@ -249,11 +592,18 @@ func (c *coordinator) runScheduler(s *scheduleController) {
 		select {
 		case <-timer.C:
 			timer.Reset(s.GetInterval())
-			if !s.AllowSchedule() {
+			if s.isPaused() || c.isSchedulePaused() || !s.AllowSchedule() {
 				continue
 			}
+			c.updateOpInfluence()
 			if op := s.Schedule(c.cluster); op != nil {
-				c.addOperator(op)
+				c.scheduleOperator(&waitingOperator{
+					op:       op,
+					source:   s.GetName(),
+					priority: lowPriority,
+					kind:     s.GetResourceKind(),
+					limit:    s.GetResourceLimit(),
+				})
 			}
 
 		case <-s.Ctx().Done():
@@ -263,24 +613,223 @@ func (c *coordinator) runScheduler(s *scheduleController) {
 	}
 }
 
+// updateOpInfluence refreshes the influence of the running operators on the
+// stores, so the scheduler scores the stores with the pending changes.
+func (c *coordinator) updateOpInfluence() {
+	c.cluster.setOpInfluence(newOpInfluence(c.getOperators(), c.cluster))
+}
+
 func (c *coordinator) addOperator(op Operator) bool {
+	return c.addOperators(op)
+}
+
+// allowReplicaCheck checks whether the operators of the replica checker can
+// run or wait, the number of the waiting ones is limited by the
+// replica-schedule-limit too.
+func (c *coordinator) allowReplicaCheck() bool {
+	limit := c.opt.GetReplicaScheduleLimit()
+	return c.limiter.operatorCount(RegionKind) < limit || c.waiting.count(replicaCheckerName) < limit
+}
+
+// allowMerge checks whether the running merges are under the
+// merge-schedule-limit, each merge runs an operator on the target region.
+func (c *coordinator) allowMerge() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	var count uint64
+	for _, op := range c.operators {
+		if getMergeSource(op) != 0 {
+			count++
+		}
+	}
+	return count < c.opt.GetMergeScheduleLimit()
+}
+
+func (c *coordinator) newReplicaOperator(op Operator) *waitingOperator {
+	priority := highPriority
+	if region := c.cluster.getRegion(op.GetRegionID()); region != nil && c.cluster.isPriorityRegion(region) {
+		priority = urgentPriority
+	}
+	return &waitingOperator{
+		op:       op,
+		source:   replicaCheckerName,
+		priority: priority,
+		kind:     RegionKind,
+		limit:    c.opt.GetReplicaScheduleLimit(),
+	}
+}
+
+// scheduleOperator adds the operator if the running operators of its kind
+// are under the limit, otherwise the operator waits.
+func (c *coordinator) scheduleOperator(w *waitingOperator) bool {
+	if c.limiter.operatorCount(w.kind) < w.limit {
+		return c.addOperator(w.op)
+	}
+	return c.addWaitingOperator(w)
+}
+
+// addWaitingOperator puts the operator into the waiting queue, unless the
+// region has a running operator or a waiting one with a higher priority.
+func (c *coordinator) addWaitingOperator(w *waitingOperator) bool {
 	c.Lock()
 	defer c.Unlock()
-	regionID := op.GetRegionID()
 
-	if old, ok := c.operators[regionID]; ok {
-		if !isHigherPriorityOperator(op, old) {
+	if _, ok := c.operators[w.op.GetRegionID()]; ok {
+		return false
+	}
+	w.since = time.Now()
+	replaced, ok := c.waiting.push(w)
+	if replaced != nil {
+		replaced.SetState(OperatorReplaced)
+		collectOperatorCanceledMetrics(replaced)
+	}
+	return ok
+}
+
+// promoteWaitingOperators adds the waiting operators in order while the
+// running operators of their kinds are under the limits and the scheduling is
+// not paused. The operators waiting longer than their timeouts are canceled.
+func (c *coordinator) promoteWaitingOperators(now time.Time) {
+	for _, w := range c.waiting.elems() {
+		if now.Sub(w.since) > getOperatorTimeout(w.op.GetResourceKind()) {
+			log.Warnf("coordinator: waiting operator timeout, cancel it: %+v", w.op)
+			c.waiting.remove(w.op.GetRegionID())
+			w.op.SetState(OperatorTimeOut)
+			collectOperatorCanceledMetrics(w.op)
+			continue
+		}
+		if c.isSchedulePaused() || c.limiter.operatorCount(w.kind) >= w.limit {
+			continue
+		}
+		// Another promotion may have taken the operator.
+		if c.waiting.remove(w.op.GetRegionID()) != w.op {
+			continue
+		}
+		// The operator times out after it runs for a while.
+		if op, ok := w.op.(*regionOperator); ok {
+			op.Start = now
+		}
+		if !c.addOperator(w.op) {
+			c.waiting.push(w)
+		}
+	}
+}
+
+// addOperators adds the operators of different regions together, none of
+// them is added if any of them is not allowed.
+func (c *coordinator) addOperators(ops ...Operator) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.allowOperatorsLocked(ops...) {
+		return false
+	}
+
+	for _, op := range ops {
+		regionID := op.GetRegionID()
+		if old, ok := c.operators[regionID]; ok {
+			old.SetState(OperatorReplaced)
+			c.removeOperatorLocked(old)
+			log.Infof("coordinator: add operator %+v with higher priority, remove operator: %+v", op, old)
+		}
+
+		if op.GetResourceKind() != AdminKind {
+			c.storeLimiter.take(op, time.Now())
+		}
+		c.histories.add(regionID, op)
+		c.limiter.addOperator(op)
+		c.operators[regionID] = op
+		collectOperatorCounterMetrics(op)
+
+		if w := c.waiting.remove(regionID); w != nil && w != op {
+			w.SetState(OperatorReplaced)
+			collectOperatorCanceledMetrics(w)
+		}
+	}
+	return true
+}
+
+// allowOperatorsLocked checks whether all the operators can be added, by the
+// priorities of the existing operators and the store limits.
+func (c *coordinator) allowOperatorsLocked(ops ...Operator) bool {
+	for _, op := range ops {
+		if old, ok := c.operators[op.GetRegionID()]; ok && !isHigherPriorityOperator(op, old) {
+			return false
+		}
+		if op.GetResourceKind() != AdminKind && (!c.allowSnapshotLocked(op) || !c.storeLimiter.allow(op, time.Now())) {
 			return false
 		}
-		old.SetState(OperatorReplaced)
-		c.removeOperatorLocked(old)
-		log.Infof("coordinator: add operator %+v with higher priority, remove operator: %+v", op, old)
 	}
+	return true
+}
 
-	c.histories.add(regionID, op)
-	c.limiter.addOperator(op)
-	c.operators[regionID] = op
-	collectOperatorCounterMetrics(op)
+// addMergeOperators adds the operators created by the merge checker. The
+// IDs of the new peers are allocated after the operators are allowed, so the
+// merges rejected on every heartbeat, such as the ones whose target has an
+// operator, don't waste the IDs.
+func (c *coordinator) addMergeOperators(ops []Operator) bool {
+	c.RLock()
+	allowed := c.allowOperatorsLocked(ops...)
+	c.RUnlock()
+	if !allowed {
+		return false
+	}
+	if err := c.merger.allocPeers(ops); err != nil {
+		log.Errorf("coordinator: failed to allocate peers to merge region %d: %v", ops[0].GetRegionID(), err)
+		return false
+	}
+	return c.addOperators(ops...)
+}
+
+// scatterRegion adds the operator to scatter the region if it's needed.
+func (c *coordinator) scatterRegion(region *RegionInfo) error {
+	op, err := c.scatterer.scatter(region)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if op != nil && !c.addOperator(op) {
+		return errors.Errorf("failed to add scatter operator for region %d", region.GetId())
+	}
+	return nil
+}
+
+// allowSnapshotLocked checks whether the estimated snapshot traffic of the
+// stores involved by the operator stays under the limit after dispatching it.
+// The snapshot count of a store is the larger one of reported by heartbeats
+// and caused by running operators, which may not be reported yet. The
+// bandwidth of one snapshot is estimated from the snapshots reported by the
+// store, or the configured one if no snapshot is reported.
+func (c *coordinator) allowSnapshotLocked(op Operator) bool {
+	limit := c.opt.GetMaxStoreSnapshotBandwidth()
+	if limit == 0 {
+		return true
+	}
+	storeIDs := getSnapshotStores(op)
+	if len(storeIDs) == 0 {
+		return true
+	}
+
+	pendingCounts := make(map[uint64]uint64)
+	for _, o := range c.operators {
+		for _, id := range getSnapshotStores(o) {
+			pendingCounts[id]++
+		}
+	}
+
+	for _, id := range storeIDs {
+		count, bandwidth := pendingCounts[id], c.opt.GetSnapshotBandwidth()
+		if store := c.cluster.getStore(id); store != nil {
+			count = maxUint64(count, store.snapshotCount())
+			if b := store.getSnapshotBandwidth(); b != 0 {
+				bandwidth = b
+			}
+		}
+		if (count+1)*bandwidth > limit {
+			log.Debugf("coordinator: store %d snapshot bandwidth exceeds limit, skip operator %+v", id, op)
+			return false
+		}
+	}
 	return true
 }
 
@@ -296,8 +845,10 @@ func isHigherPriorityOperator(new Operator, old Operator) bool {
 
 func (c *coordinator) removeOperator(op Operator) {
 	c.Lock()
-	defer c.Unlock()
 	c.removeOperatorLocked(op)
+	c.Unlock()
+
+	c.promoteWaitingOperators(time.Now())
 }
 
 func (c *coordinator) removeOperatorLocked(op Operator) {
@@ -306,7 +857,9 @@ func (c *coordinator) removeOperatorLocked(op Operator) {
 	delete(c.operators, regionID)
 
 	c.histories.add(regionID, op)
+	c.records.add(regionID, newOperatorRecord(op, time.Now()))
 	collectOperatorCounterMetrics(op)
+	collectOperatorCanceledMetrics(op)
 }
 
 func (c *coordinator) getOperator(regionID uint64) Operator {
@@ -315,6 +868,18 @@ func (c *coordinator) getOperator(regionID uint64) Operator {
 	return c.operators[regionID]
 }
 
+// getLatestOperator returns the running operator of the region, or the last
+// finished one if there is no running operator.
+func (c *coordinator) getLatestOperator(regionID uint64) Operator {
+	if op := c.getOperator(regionID); op != nil {
+		return op
+	}
+	if op, ok := c.histories.peek(regionID); ok {
+		return op.(Operator)
+	}
+	return nil
+}
+
 func (c *coordinator) getOperators() []Operator {
 	c.RLock()
 	defer c.RUnlock()
@@ -387,10 +952,14 @@ type scheduleController struct {
 	Scheduler
 	opt          *scheduleOption
 	limiter      *scheduleLimiter
+	waiting      *waitingOperatorQueue
 	nextInterval time.Duration
 	minInterval  time.Duration
 	ctx          context.Context
 	cancel       context.CancelFunc
+	// pauseUntil is the unix nanoseconds until which the scheduler is
+	// paused, it is accessed atomically.
+	pauseUntil int64
 }
 
 func newScheduleController(c *coordinator, s Scheduler, minInterval time.Duration) *scheduleController {
@@ -399,6 +968,7 @@ func newScheduleController(c *coordinator, s Scheduler, minInterval time.Duratio
 		Scheduler:    s,
 		opt:          c.opt,
 		limiter:      c.limiter,
+		waiting:      c.waiting,
 		nextInterval: minInterval,
 		minInterval:  minInterval,
 		ctx:          ctx,
@@ -429,12 +999,53 @@ func (s *scheduleController) Schedule(cluster *clusterInfo) Operator {
 	return nil
 }
 
+func (s *scheduleController) isPaused() bool {
+	return time.Now().UnixNano() < atomic.LoadInt64(&s.pauseUntil)
+}
+
+// SchedulerStatus is the status of a running scheduler.
+type SchedulerStatus struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+	// PauseUntil is the unix time when the pause expires, 0 means the
+	// scheduler is paused until it's resumed.
+	PauseUntil int64 `json:"pause_until,omitempty"`
+}
+
+func (s *scheduleController) getStatus() *SchedulerStatus {
+	status := &SchedulerStatus{Name: s.GetName()}
+	until := atomic.LoadInt64(&s.pauseUntil)
+	if time.Now().UnixNano() >= until {
+		return status
+	}
+	status.Paused = true
+	if until != math.MaxInt64 {
+		status.PauseUntil = time.Unix(0, until).Unix()
+	}
+	return status
+}
+
 func (s *scheduleController) GetInterval() time.Duration {
 	return s.nextInterval
 }
 
+// AllowSchedule checks whether the operators of the scheduler can run or
+// wait.
 func (s *scheduleController) AllowSchedule() bool {
-	return s.limiter.operatorCount(s.GetResourceKind()) < s.GetResourceLimit()
+	if s.limiter.operatorCount(s.GetResourceKind()) < s.GetResourceLimit() {
+		return true
+	}
+	return s.waiting.count(s.GetName()) < s.opt.GetSchedulerMaxWaitingOperator()
+}
+
+// collectOperatorCanceledMetrics counts the removed operators which are not
+// finished, the ones neither timed out nor replaced are canceled by admin.
+func collectOperatorCanceledMetrics(op Operator) {
+	reason := getOperatorOutcome(op)
+	if reason == "finished" {
+		return
+	}
+	operatorCanceledCounter.WithLabelValues(op.GetResourceKind().String(), reason).Inc()
 }
 
 func collectOperatorCounterMetrics(op Operator) {
@@ -14,11 +14,12 @@
 package server
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 type testOperator struct {
@@ -147,7 +148,7 @@ func (s *testCoordinatorSuite) TestReplica(c *C) {
 	region.Peers = append(region.Peers, resp.GetChangePeer().GetPeer())
 	c.Assert(co.dispatch(region), IsNil)
 
-	// Peer in store 3 is down, remove peer in store 3 and add peer to store 4.
+	// Peer in store 3 is down, add peer to store 4 and remove peer in store 3.
 	tc.setStoreDown(3)
 	downPeer := &pdpb.PeerStats{
 		Peer:        region.GetStorePeer(3),
@@ -155,12 +156,12 @@ func (s *testCoordinatorSuite) TestReplica(c *C) {
 	}
 	region.DownPeers = append(region.DownPeers, downPeer)
 	resp = co.dispatch(region)
+	checkAddPeerResp(c, resp, 4)
+	region.Peers = append(region.Peers, resp.GetChangePeer().GetPeer())
+	resp = co.dispatch(region)
 	checkRemovePeerResp(c, resp, 3)
 	region.RemoveStorePeer(3)
 	region.DownPeers = nil
-	resp = co.dispatch(region)
-	checkAddPeerResp(c, resp, 4)
-	region.Peers = append(region.Peers, resp.GetChangePeer().GetPeer())
 	c.Assert(co.dispatch(region), IsNil)
 
 	// Remove peer from store 4.
@@ -172,6 +173,583 @@ func (s *testCoordinatorSuite) TestReplica(c *C) {
 	c.Assert(co.dispatch(region), IsNil)
 }
 
+func (s *testCoordinatorSuite) TestSchedulePause(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	// Turn off balance.
+	cfg, opt := newTestScheduleConfig()
+	cfg.LeaderScheduleLimit = 0
+	cfg.RegionScheduleLimit = 0
+
+	co := newCoordinator(cluster, opt)
+	co.run()
+	defer co.stop()
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 3)
+	tc.addLeaderRegion(1, 2, 3)
+	region := cluster.getRegion(1)
+
+	// The replica checker is halted until resumed.
+	c.Assert(co.pauseSchedule(0), IsNil)
+	c.Assert(co.getSchedulePauseStatus(), DeepEquals, &SchedulePauseStatus{Paused: true})
+	c.Assert(co.dispatch(region), IsNil)
+	c.Assert(co.resumeSchedule(), IsNil)
+	c.Assert(co.getSchedulePauseStatus().Paused, IsFalse)
+	checkAddPeerResp(c, co.dispatch(region), 1)
+	co.removeOperator(co.getOperator(1))
+
+	// The pause expires after the delay.
+	c.Assert(co.pauseSchedule(time.Hour), IsNil)
+	c.Assert(co.getSchedulePauseStatus().PauseUntil, Greater, time.Now().Unix())
+	c.Assert(co.dispatch(region), IsNil)
+	c.Assert(co.pauseSchedule(time.Millisecond), IsNil)
+	time.Sleep(10 * time.Millisecond)
+	c.Assert(co.isSchedulePaused(), IsFalse)
+	checkAddPeerResp(c, co.dispatch(region), 1)
+}
+
+func (s *testCoordinatorSuite) TestMerge(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	// Turn off balance.
+	cfg, opt := newTestScheduleConfig()
+	cfg.LeaderScheduleLimit = 0
+	cfg.MaxMergeRegionSize = 1024 * 1024
+
+	co := newCoordinator(cluster, opt)
+	co.run()
+	defer co.stop()
+	c.Assert(co.removeScheduler("balance-region-scheduler"), IsNil)
+
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addLeaderRegionInRange(1, "", "a", defaultRegionSize, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "a", "", 1024, 1, 2, 3)
+
+	// Merge region 2 into region 1.
+	resp := co.dispatch(cluster.getRegion(2))
+	c.Assert(resp.GetMerge().GetTarget().GetId(), Equals, uint64(1))
+	c.Assert(co.getOperator(1), NotNil)
+	c.Assert(co.dispatch(cluster.getRegion(1)), IsNil)
+
+	// Region 1 covers region 2 after merge.
+	region := cluster.getRegion(1)
+	region.EndKey = []byte{}
+	region.RegionEpoch.Version++
+	c.Assert(cluster.handleRegionHeartbeat(region), IsNil)
+	c.Assert(co.dispatch(region), IsNil)
+	c.Assert(co.getOperator(1), IsNil)
+	c.Assert(co.getOperator(2), IsNil)
+	c.Assert(cluster.getRegion(2), IsNil)
+}
+
+func (s *testCoordinatorSuite) TestMergeAllocPeer(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.MaxMergeRegionSize = 1024 * 1024
+	// The replica checker may allocate peers, turn it off and merge by the
+	// patrol.
+	cfg.ReplicaScheduleLimit = 0
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addRegionStore(4, 2)
+	tc.addLeaderRegionInRange(1, "", "a", defaultRegionSize, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "a", "", 1024, 1, 2, 4)
+
+	// The target has an operator, no peer is allocated for the merge.
+	c.Assert(co.addOperator(newTestOperator(1, LeaderKind)), IsTrue)
+	id, err := cluster.allocID()
+	c.Assert(err, IsNil)
+	co.patrolRegions(nil)
+	c.Assert(co.getOperator(2), IsNil)
+	nextID, err := cluster.allocID()
+	c.Assert(err, IsNil)
+	c.Assert(nextID, Equals, id+1)
+
+	// The peer is allocated once the operators are added.
+	co.removeOperator(co.getOperator(1))
+	co.patrolRegions(nil)
+	c.Assert(co.getOperator(1), NotNil)
+	steps := co.getOperator(2).(*regionOperator).Ops
+	c.Assert(steps[0].(*changePeerOperator).ChangePeer.GetPeer().GetStoreId(), Equals, uint64(3))
+	c.Assert(steps[0].(*changePeerOperator).ChangePeer.GetPeer().GetId(), Equals, nextID+1)
+}
+
+func (s *testCoordinatorSuite) TestMergeScheduleLimit(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.MaxMergeRegionSize = 1024 * 1024
+	cfg.MergeScheduleLimit = 1
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 5)
+	tc.addRegionStore(2, 5)
+	tc.addRegionStore(3, 5)
+	tc.addLeaderRegionInRange(1, "", "a", defaultRegionSize, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "a", "b", 1024, 1, 2, 3)
+	tc.addLeaderRegionInRange(3, "b", "c", defaultRegionSize, 1, 2, 3)
+	tc.addLeaderRegionInRange(4, "c", "d", defaultRegionSize, 1, 2, 3)
+	tc.addLeaderRegionInRange(5, "d", "", 1024, 1, 2, 3)
+
+	c.Assert(co.dispatch(cluster.getRegion(2)).GetMerge(), NotNil)
+	c.Assert(co.dispatch(cluster.getRegion(5)), IsNil)
+
+	// The limit is re-read at once.
+	cfg.MergeScheduleLimit = 2
+	c.Assert(co.dispatch(cluster.getRegion(5)).GetMerge().GetTarget().GetId(), Equals, uint64(4))
+}
+
+func (s *testCoordinatorSuite) TestSnapshotBandwidth(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	// Each store can send or receive 2 snapshots at the same time.
+	cfg, opt := newTestScheduleConfig()
+	cfg.SnapshotBandwidth = 10
+	cfg.MaxStoreSnapshotBandwidth = 20
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1)
+	tc.addLeaderRegion(2, 1)
+	tc.addLeaderRegion(3, 2)
+
+	addPeer := func(regionID, storeID uint64) Operator {
+		peer, _ := cluster.allocPeer(storeID)
+		return newAddPeer(cluster.getRegion(regionID), peer, false)
+	}
+
+	// Store 1 sends 2 snapshots.
+	c.Assert(co.addOperator(addPeer(1, 2)), IsTrue)
+	c.Assert(co.addOperator(addPeer(2, 3)), IsTrue)
+	// Store 3 reports that it is receiving 2 snapshots.
+	tc.updateReceivingSnapshotCount(3, 2)
+	c.Assert(co.addOperator(addPeer(3, 3)), IsFalse)
+	tc.updateReceivingSnapshotCount(3, 0)
+	c.Assert(co.addOperator(addPeer(3, 3)), IsTrue)
+
+	// Admin operators are not limited.
+	peer, _ := cluster.allocPeer(3)
+	op := newAdminOperator(cluster.getRegion(1), newAddPeerOperator(1, peer))
+	c.Assert(co.addOperator(op), IsTrue)
+
+	// Store 1 is free after the operators finish, but store 3 is not.
+	co.removeOperator(op)
+	co.removeOperator(co.getOperator(2))
+	c.Assert(co.addOperator(addPeer(1, 3)), IsTrue)
+	c.Assert(co.addOperator(addPeer(2, 3)), IsFalse)
+
+	// No limit.
+	cfg.MaxStoreSnapshotBandwidth = 0
+	c.Assert(co.addOperator(addPeer(2, 3)), IsTrue)
+}
+
+func (s *testCoordinatorSuite) TestEstimatedSnapshotBandwidth(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	cfg, opt := newTestScheduleConfig()
+	cfg.SnapshotBandwidth = 10
+	cfg.MaxStoreSnapshotBandwidth = 20
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1)
+	tc.addLeaderRegion(2, 2)
+	tc.addLeaderRegion(3, 2)
+	tc.addLeaderRegion(4, 2)
+
+	addPeer := func(regionID, storeID uint64) Operator {
+		peer, _ := cluster.allocPeer(storeID)
+		return newAddPeer(cluster.getRegion(regionID), peer, false)
+	}
+	heartbeat := func(storeID, bytes, durationMs uint64) {
+		c.Assert(cluster.handleStoreHeartbeat(&pdpb.StoreStats{
+			StoreId:            storeID,
+			SnapshotBytes:      bytes,
+			SnapshotDurationMs: durationMs,
+		}), IsNil)
+	}
+
+	// Store 3 transfers a snapshot at 20B/s, so it can only receive one.
+	heartbeat(3, 200, 10000)
+	c.Assert(cluster.getStore(3).getSnapshotBandwidth(), Equals, uint64(20))
+	c.Assert(co.addOperator(addPeer(1, 3)), IsTrue)
+	c.Assert(co.addOperator(addPeer(2, 3)), IsFalse)
+
+	// The estimation follows the recent snapshots.
+	heartbeat(3, 50, 10000)
+	c.Assert(cluster.getStore(3).getSnapshotBandwidth(), Equals, uint64(12))
+	c.Assert(co.addOperator(addPeer(2, 3)), IsFalse)
+	// The heartbeats without snapshots don't change the estimation.
+	heartbeat(3, 0, 0)
+	c.Assert(cluster.getStore(3).getSnapshotBandwidth(), Equals, uint64(12))
+	heartbeat(3, 50, 10000)
+	c.Assert(cluster.getStore(3).getSnapshotBandwidth(), Equals, uint64(8))
+	c.Assert(co.addOperator(addPeer(2, 3)), IsTrue)
+
+	// Store 2 uses the configured bandwidth without snapshots reported, it
+	// sends 2 snapshots at most.
+	c.Assert(cluster.getStore(2).getSnapshotBandwidth(), Equals, uint64(0))
+	c.Assert(co.addOperator(addPeer(3, 1)), IsTrue)
+	c.Assert(co.addOperator(addPeer(4, 1)), IsFalse)
+}
+
+func (s *testCoordinatorSuite) TestOperatorTimeout(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1, 2)
+	tc.addLeaderRegion(2, 1, 2, 3)
+	tc.addLeaderRegion(3, 1, 2, 3)
+
+	// Region 1 misses a replica, and its leader transfer hangs.
+	op1 := newTransferLeader(cluster.getRegion(1), cluster.getRegion(1).GetStorePeer(2))
+	op2 := newTransferLeader(cluster.getRegion(2), cluster.getRegion(2).GetStorePeer(2))
+	peer, _ := cluster.allocPeer(3)
+	op3 := newAdminOperator(cluster.getRegion(3), newAddPeerOperator(3, peer))
+	c.Assert(co.addOperators(op1, op2, op3), IsTrue)
+	op1.(*regionOperator).Start = time.Now().Add(-2 * time.Minute)
+
+	co.removeTimeoutOperators(time.Now())
+	c.Assert(op1.GetState(), Equals, OperatorTimeOut)
+	op4 := co.getOperator(1)
+	checkAddPeer(c, op4, 3)
+	c.Assert(co.getOperator(2), Equals, op2)
+
+	// Moving peers takes longer, admin operators never time out.
+	co.removeTimeoutOperators(time.Now().Add(5 * time.Minute))
+	c.Assert(co.getOperator(1), Equals, op4)
+	c.Assert(co.getOperator(2), IsNil)
+	c.Assert(op2.GetState(), Equals, OperatorTimeOut)
+	co.removeTimeoutOperators(time.Now().Add(time.Hour))
+	c.Assert(op4.GetState(), Equals, OperatorTimeOut)
+	c.Assert(co.getOperator(1), Not(Equals), op4)
+	c.Assert(co.getOperator(3), Equals, op3)
+}
+
+func (s *testCoordinatorSuite) TestRaftLearner(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	// Turn off balance.
+	cfg, opt := newTestScheduleConfig()
+	cfg.LeaderScheduleLimit = 0
+	cfg.RegionScheduleLimit = 0
+	cfg.EnableRaftLearner = true
+
+	co := newCoordinator(cluster, opt)
+	co.run()
+	defer co.stop()
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 3)
+
+	// Add a learner to store 1.
+	tc.addLeaderRegion(1, 2, 3)
+	region := cluster.getRegion(1)
+	resp := co.dispatch(region)
+	changePeer := resp.GetChangePeer()
+	c.Assert(changePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddLearnerNode)
+	c.Assert(changePeer.GetPeer().GetStoreId(), Equals, uint64(1))
+	c.Assert(changePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Learner)
+
+	// The learner is not promoted until it catches up.
+	learner := &metapb.Peer{
+		Id:      changePeer.GetPeer().GetId(),
+		StoreId: 1,
+		Role:    metapb.PeerRole_Learner,
+	}
+	region.Peers = append(region.Peers, learner)
+	region.PendingPeers = []*metapb.Peer{learner}
+	c.Assert(co.dispatch(region), IsNil)
+	region.PendingPeers = nil
+	resp = co.dispatch(region)
+	checkAddPeerResp(c, resp, 1)
+	c.Assert(resp.GetChangePeer().GetPeer().GetRole(), Equals, metapb.PeerRole_Voter)
+
+	learner.Role = metapb.PeerRole_Voter
+	c.Assert(co.dispatch(region), IsNil)
+	c.Assert(co.getOperator(1), IsNil)
+}
+
+func (s *testCoordinatorSuite) TestDrainOfflineStores(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addRegionStore(4, 0)
+	tc.addLeaderRegion(1, 1, 2, 3)
+	tc.addLeaderRegion(2, 3, 1, 2)
+
+	// Nothing to drain.
+	co.drainOfflineStores()
+	c.Assert(co.getOperators(), HasLen, 0)
+
+	// Both the follower and the leader on store 3 are moved to store 4.
+	tc.setStoreOffline(3)
+	co.drainOfflineStores()
+	c.Assert(co.getOperators(), HasLen, 2)
+	checkTransferPeer(c, co.getOperator(1), 3, 4)
+	checkTransferPeerWithLeaderTransfer(c, co.getOperator(2), 3, 4)
+}
+
+func (s *testCoordinatorSuite) TestOrphanPeer(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.EnableRaftLearner = true
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 3)
+	tc.addLeaderRegion(1, 2, 3)
+
+	// The learner is added, but the operator times out before promoting it.
+	region := cluster.getRegion(1)
+	op := co.checkReplica(region)
+	c.Assert(co.addOperator(op), IsTrue)
+	learner := op.(*regionOperator).Ops[0].(*changePeerOperator).ChangePeer.GetPeer()
+	c.Assert(learner.GetRole(), Equals, metapb.PeerRole_Learner)
+	region.Peers = append(region.Peers, learner)
+	cluster.putRegion(region)
+	co.removeTimeoutOperators(time.Now().Add(time.Hour))
+	checkRemovePeer(c, co.getOperator(1), 1)
+
+	// The learners added by the admin are kept.
+	co.removeOperator(co.getOperator(1))
+	region = cluster.getRegion(1)
+	c.Assert(co.checkOrphanPeer(region), IsNil)
+	admin := newAdminOperator(region, newChangePeerRoleOperator(1, learner, metapb.PeerRole_Voter))
+	admin.SetState(OperatorTimeOut)
+	co.histories.add(1, admin)
+	c.Assert(co.checkOrphanPeer(region), IsNil)
+}
+
+func (s *testCoordinatorSuite) TestJointConsensus(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.EnableJointConsensus = true
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addRegionStore(4, 0)
+	tc.addLeaderRegion(1, 1, 2, 3)
+	tc.setStoreOffline(1)
+	co.drainOfflineStores()
+	region := cluster.getRegion(1)
+
+	// The new peer is added as a learner.
+	resp := co.dispatch(region)
+	changePeer := resp.GetChangePeer()
+	c.Assert(changePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddLearnerNode)
+	c.Assert(changePeer.GetPeer().GetStoreId(), Equals, uint64(4))
+	incoming := &metapb.Peer{Id: changePeer.GetPeer().GetId(), StoreId: 4, Role: metapb.PeerRole_Learner}
+	region.Peers = append(region.Peers, incoming)
+
+	// The leader is transferred before it's demoted.
+	resp = co.dispatch(region)
+	c.Assert(resp.GetTransferLeader(), NotNil)
+	region.Leader = region.GetPeer(resp.GetTransferLeader().GetPeer().GetId())
+	demoting := region.GetStorePeer(1)
+
+	// The voters are swapped in one conf change.
+	resp = co.dispatch(region)
+	changes := resp.GetChangePeerV2().GetChanges()
+	c.Assert(changes, HasLen, 2)
+	c.Assert(changes[0].GetChangeType(), Equals, pdpb.ConfChangeType_AddNode)
+	c.Assert(changes[0].GetPeer().GetStoreId(), Equals, uint64(4))
+	c.Assert(changes[1].GetChangeType(), Equals, pdpb.ConfChangeType_AddLearnerNode)
+	c.Assert(changes[1].GetPeer().GetStoreId(), Equals, uint64(1))
+	incoming.Role, demoting.Role = metapb.PeerRole_IncomingVoter, metapb.PeerRole_DemotingVoter
+
+	// The joint consensus is left with an empty conf change.
+	resp = co.dispatch(region)
+	c.Assert(resp.GetChangePeerV2(), NotNil)
+	c.Assert(resp.GetChangePeerV2().GetChanges(), HasLen, 0)
+	incoming.Role, demoting.Role = metapb.PeerRole_Voter, metapb.PeerRole_Learner
+
+	// The demoted peer is removed at last.
+	resp = co.dispatch(region)
+	checkRemovePeerResp(c, resp, 1)
+	region.RemoveStorePeer(1)
+	c.Assert(co.dispatch(region), IsNil)
+	c.Assert(co.getOperator(1), IsNil)
+
+	// The region left in the joint consensus by an interrupted operator
+	// leaves it first.
+	region = cluster.getRegion(1)
+	region.GetStorePeer(2).Role = metapb.PeerRole_DemotingVoter
+	region.Peers = append(region.Peers, &metapb.Peer{Id: 100, StoreId: 1, Role: metapb.PeerRole_IncomingVoter})
+	op := co.checkReplica(region)
+	c.Assert(op, NotNil)
+	c.Assert(op.(*regionOperator).Ops[0].(*changePeerV2Operator).leave, IsTrue)
+	c.Assert(co.addOperator(op), IsTrue)
+	resp = co.dispatch(region)
+	c.Assert(resp.GetChangePeerV2().GetChanges(), HasLen, 0)
+	co.removeOperator(op)
+
+	// The learner added by the operator is removed if the operator times out
+	// before entering the joint consensus, and so is the demoted voter if it
+	// times out before removing it.
+	tc.addLeaderRegion(2, 2, 3, 4)
+	region = cluster.getRegion(2)
+	op = newTransferPeer(region, RegionKind, region.GetStorePeer(3), &metapb.Peer{Id: 101, StoreId: 1}, opt)
+	c.Assert(co.addOperator(op), IsTrue)
+	region.Peers = append(region.Peers, &metapb.Peer{Id: 101, StoreId: 1, Role: metapb.PeerRole_Learner})
+	cluster.putRegion(region)
+	co.removeTimeoutOperators(time.Now().Add(time.Hour))
+	checkRemovePeer(c, co.getOperator(2), 1)
+	co.removeOperator(co.getOperator(2))
+
+	region = cluster.getRegion(2)
+	op = newTransferPeer(region, RegionKind, region.GetStorePeer(3), &metapb.Peer{Id: 101, StoreId: 1}, opt)
+	c.Assert(co.addOperator(op), IsTrue)
+	region.GetStorePeer(1).Role = metapb.PeerRole_Voter
+	region.GetStorePeer(3).Role = metapb.PeerRole_Learner
+	cluster.putRegion(region)
+	co.removeTimeoutOperators(time.Now().Add(time.Hour))
+	checkRemovePeer(c, co.getOperator(2), 3)
+}
+
+func (s *testCoordinatorSuite) TestJointTransferPeer(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.EnableJointConsensus = true
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addLeaderRegion(1, 1)
+	tc.addLeaderRegion(2, 1, 2)
+
+	// The leader can't be demoted without a follower, the peers are changed
+	// one by one.
+	region := cluster.getRegion(1)
+	op := newTransferPeer(region, RegionKind, region.GetStorePeer(1), &metapb.Peer{Id: 100, StoreId: 2}, opt)
+	checkTransferPeer(c, op, 1, 2)
+
+	// The follower is swapped without transferring the leader.
+	region = cluster.getRegion(2)
+	op = newTransferPeer(region, RegionKind, region.GetStorePeer(2), &metapb.Peer{Id: 101, StoreId: 3}, opt)
+	steps := op.(*regionOperator).Ops
+	c.Assert(steps, HasLen, 4)
+	checkAddPeer(c, steps[0], 3)
+	c.Assert(steps[1].GetName(), Equals, "enter_joint")
+	c.Assert(steps[2].GetName(), Equals, "leave_joint")
+	checkRemovePeer(c, steps[3], 2)
+
+	// The learners are not swapped in the joint consensus.
+	cfg.EnableRaftLearner = true
+	learner := &metapb.Peer{Id: 102, StoreId: 2, Role: metapb.PeerRole_Learner}
+	region.Peers = []*metapb.Peer{region.Leader, learner}
+	op = newTransferPeer(region, RegionKind, learner, &metapb.Peer{Id: 103, StoreId: 3}, opt)
+	checkTransferPeer(c, op, 2, 3)
+}
+
+func (s *testCoordinatorSuite) TestOperatorRecords(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	co := newCoordinator(cluster, opt)
+
+	tc.addLeaderStore(1, 1)
+	tc.addLeaderStore(2, 1)
+	tc.addLeaderRegion(1, 1, 2)
+	tc.addLeaderRegion(2, 1, 2)
+
+	start := time.Now()
+	region1, region2 := cluster.getRegion(1), cluster.getRegion(2)
+	op1 := newTransferLeader(region1, region1.GetStorePeer(2))
+	op2 := newTransferLeader(region2, region2.GetStorePeer(2))
+	c.Assert(co.addOperators(op1), IsTrue)
+	c.Assert(co.addOperators(op2), IsTrue)
+	op1.SetState(OperatorFinished)
+	co.removeOperator(op1)
+	co.removeTimeoutOperators(time.Now().Add(time.Hour))
+	end := time.Now().Add(time.Second)
+
+	records := co.getOperatorRecords(0, start, end)
+	c.Assert(records, HasLen, 2)
+	c.Assert(records[0].RegionID, Equals, uint64(1))
+	c.Assert(records[0].Outcome, Equals, "finished")
+	c.Assert(records[1].RegionID, Equals, uint64(2))
+	c.Assert(records[1].Outcome, Equals, "timeout")
+	c.Assert(records[1].Start, Equals, op2.(*regionOperator).Start)
+
+	records = co.getOperatorRecords(2, start, end)
+	c.Assert(records, HasLen, 1)
+	c.Assert(records[0].Operator, Equals, op2)
+	c.Assert(co.getOperatorRecords(0, end, end.Add(time.Hour)), HasLen, 0)
+}
+
+func (s *testCoordinatorSuite) TestPatrolRegions(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.ReplicaScheduleLimit = 1000
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 200)
+	tc.addRegionStore(2, 200)
+	tc.addRegionStore(3, 0)
+	regionCount := patrolScanRegionLimit + 10
+	for i := 0; i < regionCount; i++ {
+		start, end := fmt.Sprintf("%04d", i), fmt.Sprintf("%04d", i+1)
+		if i == regionCount-1 {
+			end = ""
+		}
+		tc.addLeaderRegionInRange(uint64(i+1), start, end, defaultRegionSize, 1, 2)
+	}
+
+	// The regions are checked a batch at a time without heartbeats.
+	key := co.patrolRegions(nil)
+	c.Assert(string(key), Equals, fmt.Sprintf("%04d", patrolScanRegionLimit))
+	c.Assert(co.getOperators(), HasLen, patrolScanRegionLimit)
+	checkAddPeer(c, co.getOperator(1), 3)
+	c.Assert(co.patrolRegions(key), IsNil)
+	c.Assert(co.getOperators(), HasLen, regionCount)
+	checkAddPeer(c, co.getOperator(uint64(regionCount)), 3)
+
+	// Nothing is checked while the scheduling is paused.
+	c.Assert(co.pauseSchedule(0), IsNil)
+	c.Assert(co.patrolRegions(key), DeepEquals, key)
+}
+
 func (s *testCoordinatorSuite) TestPeerState(c *C) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	tc := newTestClusterInfo(cluster)
@@ -248,6 +826,51 @@ func (s *testCoordinatorSuite) TestShouldRun(c *C) {
 	}
 }
 
+func (s *testCoordinatorSuite) TestWarmUp(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 1
+	cfg.WarmUpStoreRatio = 0.5
+	co := newCoordinator(cluster, opt)
+
+	// Stores loaded from kv have not heartbeated.
+	for i := uint64(1); i <= 4; i++ {
+		cluster.putStore(newStoreInfo(&metapb.Store{Id: i}))
+	}
+	tc.LoadRegion(1, 1, 2, 3)
+	r := tc.getRegion(1)
+	r.Leader = r.Peers[0]
+	tc.handleRegionHeartbeat(r)
+	status := co.getWarmUpStatus()
+	c.Assert(status.Ready, IsFalse)
+	c.Assert(status.ActiveRegions, Equals, 1)
+	c.Assert(status.StoreCount, Equals, 4)
+	c.Assert(status.ActiveStores, Equals, 0)
+
+	// The replica checker doesn't run before the coordinator is ready.
+	opt.SetMaxReplicas(2)
+	c.Assert(co.dispatch(tc.getRegion(1)), IsNil)
+
+	tc.handleStoreHeartbeat(&pdpb.StoreStats{StoreId: 1})
+	c.Assert(co.shouldRun(), IsFalse)
+	tc.handleStoreHeartbeat(&pdpb.StoreStats{StoreId: 2})
+	c.Assert(co.shouldRun(), IsTrue)
+	// Make all the peers healthy so that the extra one can be removed.
+	tc.handleStoreHeartbeat(&pdpb.StoreStats{StoreId: 3})
+	c.Assert(co.dispatch(tc.getRegion(1)), NotNil)
+	c.Assert(co.isReady(), IsTrue)
+
+	co.run()
+	defer co.stop()
+	// It keeps ready even if some stores are added.
+	tc.addRegionStore(5, 0)
+	cluster.putStore(newStoreInfo(&metapb.Store{Id: 6}))
+	cluster.putStore(newStoreInfo(&metapb.Store{Id: 7}))
+	c.Assert(co.getWarmUpStatus().Ready, IsTrue)
+}
+
 func (s *testCoordinatorSuite) TestAddScheduler(c *C) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	tc := newTestClusterInfo(cluster)
@@ -358,8 +981,9 @@ func (s *testScheduleControllerSuite) TestController(c *C) {
 	cfg.LeaderScheduleLimit = 2
 	c.Assert(sc.GetResourceLimit(), Equals, uint64(1))
 
-	// limit = 2
+	// limit = 2, and no operator waits.
 	lb.limit = 2
+	cfg.SchedulerMaxWaitingOperator = 0
 	// count = 0
 	c.Assert(sc.AllowSchedule(), IsTrue)
 	op1 := newTestOperator(1, LeaderKind)
@@ -390,6 +1014,81 @@ func (s *testScheduleControllerSuite) TestController(c *C) {
 	c.Assert(sc.AllowSchedule(), IsTrue)
 }
 
+func (s *testScheduleControllerSuite) TestWaitingOperator(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.SchedulerMaxWaitingOperator = 2
+	cfg.ReplicaScheduleLimit = 1
+	co := newCoordinator(cluster, opt)
+	lb := newBalanceLeaderScheduler(opt)
+	lb.limit = 1
+	sc := newScheduleController(co, lb, minScheduleInterval)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	for id := uint64(1); id <= 5; id++ {
+		tc.addLeaderRegion(id, 1, 2)
+	}
+	newBalanceOperator := func(regionID uint64) *waitingOperator {
+		region := cluster.getRegion(regionID)
+		return &waitingOperator{
+			op:     newTransferLeader(region, region.GetStorePeer(2)),
+			source: sc.GetName(),
+			kind:   LeaderKind,
+			limit:  sc.GetResourceLimit(),
+		}
+	}
+
+	// The scheduler runs an operator, then 2 operators wait.
+	op1 := newBalanceOperator(1)
+	c.Assert(co.scheduleOperator(op1), IsTrue)
+	c.Assert(co.getOperator(1), Equals, op1.op)
+	c.Assert(co.scheduleOperator(newBalanceOperator(2)), IsTrue)
+	c.Assert(sc.AllowSchedule(), IsTrue)
+	c.Assert(co.scheduleOperator(newBalanceOperator(3)), IsTrue)
+	c.Assert(sc.AllowSchedule(), IsFalse)
+	c.Assert(co.getOperator(2), IsNil)
+	// A region has at most one operator.
+	c.Assert(co.scheduleOperator(newBalanceOperator(1)), IsFalse)
+	c.Assert(co.scheduleOperator(newBalanceOperator(3)), IsFalse)
+
+	// Replica repairs replace the waiting balance operators of the same
+	// regions, and wait before balance operators.
+	region := cluster.getRegion(3)
+	repair3 := newAddPeer(region, &metapb.Peer{Id: 100, StoreId: 3}, false)
+	region = cluster.getRegion(4)
+	repair4 := newAddPeer(region, &metapb.Peer{Id: 101, StoreId: 3}, false)
+	c.Assert(co.scheduleOperator(co.newReplicaOperator(repair3)), IsTrue)
+	c.Assert(co.scheduleOperator(co.newReplicaOperator(repair4)), IsTrue)
+	c.Assert(co.getOperator(3), Equals, repair3)
+	c.Assert(co.waiting.count(sc.GetName()), Equals, uint64(1))
+	c.Assert(co.waiting.count(replicaCheckerName), Equals, uint64(1))
+	co.removeOperator(repair3)
+	c.Assert(co.getOperator(4), Equals, repair4)
+	c.Assert(co.waiting.count(replicaCheckerName), Equals, uint64(0))
+
+	// The balance operators run in order when the running one finishes.
+	co.removeOperator(op1.op)
+	c.Assert(co.getOperator(2), NotNil)
+	c.Assert(co.getOperator(3), IsNil)
+
+	// Admin operators never wait, and replace the waiting operators.
+	c.Assert(co.scheduleOperator(newBalanceOperator(5)), IsTrue)
+	op := newAdminOperator(cluster.getRegion(5), newTransferLeaderOperator(5, region.Leader, region.GetStorePeer(2)))
+	c.Assert(co.addOperator(op), IsTrue)
+	c.Assert(co.getOperator(5), Equals, op)
+	c.Assert(co.waiting.count(sc.GetName()), Equals, uint64(0))
+
+	// Waiting operators time out.
+	c.Assert(co.scheduleOperator(newBalanceOperator(3)), IsTrue)
+	w := co.waiting.elems()[0]
+	co.promoteWaitingOperators(time.Now().Add(time.Hour))
+	c.Assert(w.op.GetState(), Equals, OperatorTimeOut)
+	c.Assert(co.waiting.elems(), HasLen, 0)
+}
+
 func (s *testScheduleControllerSuite) TestInterval(c *C) {
 	cluster := newClusterInfo(newMockIDAllocator())
 	_, opt := newTestScheduleConfig()
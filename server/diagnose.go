@@ -0,0 +1,129 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// StoreDiagnosis lists the filters which keep a store from being a source or
+// a target of scheduling.
+type StoreDiagnosis struct {
+	StoreID       uint64   `json:"store_id"`
+	SourceFilters []string `json:"source_filters,omitempty"`
+	TargetFilters []string `json:"target_filters,omitempty"`
+}
+
+// RegionDiagnosis explains why a region is or isn't scheduled. It's made in
+// dry-run mode, nothing is scheduled by it.
+type RegionDiagnosis struct {
+	RegionID uint64 `json:"region_id"`
+	// SchedulePaused is true if all the scheduling is paused.
+	SchedulePaused bool `json:"schedule_paused"`
+	// Operator is the running operator of the region.
+	Operator Operator `json:"operator,omitempty"`
+	// CheckerOperator is the operator the replica checker would create.
+	CheckerOperator Operator `json:"checker_operator,omitempty"`
+	// Stores are the stores of the peers with the filters which keep the
+	// peers from moving out, and the other stores with the filters which
+	// keep them from taking a peer of the region.
+	Stores []*StoreDiagnosis `json:"stores"`
+}
+
+type namedFilter struct {
+	name string
+	Filter
+}
+
+// getDiagnoseFilters returns the filters used by the schedulers and the
+// replica checker which don't depend on the region.
+func (c *coordinator) getDiagnoseFilters() []namedFilter {
+	return []namedFilter{
+		{"state", newStateFilter(c.opt)},
+		{"health", newHealthFilter(c.opt)},
+		{"block", newBlockFilter()},
+		{"snapshot-count", newSnapshotCountFilter(c.opt)},
+		{"pending-peer-count", newPendingPeerCountFilter(c.opt)},
+		{"storage-threshold", newStorageThresholdFilter(c.opt)},
+	}
+}
+
+func diagnoseStore(store *storeInfo, filters []namedFilter) *StoreDiagnosis {
+	diagnosis := &StoreDiagnosis{StoreID: store.GetId()}
+	for _, f := range filters {
+		if f.FilterSource(store) {
+			diagnosis.SourceFilters = append(diagnosis.SourceFilters, f.name)
+		}
+		if f.FilterTarget(store) {
+			diagnosis.TargetFilters = append(diagnosis.TargetFilters, f.name)
+		}
+	}
+	return diagnosis
+}
+
+// diagnoseStore returns the filters which reject the store.
+func (c *coordinator) diagnoseStore(storeID uint64) (*StoreDiagnosis, error) {
+	store := c.cluster.getStore(storeID)
+	if store == nil {
+		return nil, errors.Trace(errStoreNotFound(storeID))
+	}
+	return diagnoseStore(store, c.getDiagnoseFilters()), nil
+}
+
+// diagnoseRegion checks the region with the replica checker and the filters
+// without scheduling it.
+func (c *coordinator) diagnoseRegion(regionID uint64) (*RegionDiagnosis, error) {
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return nil, errors.Trace(errRegionNotFound(regionID))
+	}
+	diagnosis := &RegionDiagnosis{
+		RegionID:        regionID,
+		SchedulePaused:  c.isSchedulePaused(),
+		Operator:        c.getOperator(regionID),
+		CheckerOperator: c.checkReplica(region),
+	}
+
+	filters := c.getDiagnoseFilters()
+	filters = append(filters, namedFilter{"namespace", newNamespaceFilter(c.cluster, c.cluster.getRegionNamespace(region))})
+	// The peer of the worst store is moved first, the target should be
+	// allowed by the rule of the peer and not decrease the distinct score of
+	// the region.
+	if worstPeer, _ := c.checker.selectWorstPeer(region); worstPeer != nil {
+		rule := c.cluster.getRegionPeerRule(region, worstPeer, c.opt.GetMaxReplicas())
+		source := c.cluster.getStore(worstPeer.GetStoreId())
+		scoreGuard := newDistinctScoreFilter(c.opt.GetReplication(), c.cluster.getRegionStores(region), source)
+		filters = append(filters,
+			namedFilter{"placement", newPlacementRuleFilter(rule)},
+			namedFilter{"distinct-score", scoreGuard},
+		)
+	}
+
+	storeIDs := region.GetStoreIds()
+	for _, store := range c.cluster.getStores() {
+		d := diagnoseStore(store, filters)
+		if _, ok := storeIDs[store.GetId()]; ok {
+			d.TargetFilters = nil
+		} else {
+			d.SourceFilters = nil
+		}
+		diagnosis.Stores = append(diagnosis.Stores, d)
+	}
+	sort.Slice(diagnosis.Stores, func(i, j int) bool {
+		return diagnosis.Stores[i].StoreID < diagnosis.Stores[j].StoreID
+	})
+	return diagnosis, nil
+}
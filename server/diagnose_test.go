@@ -0,0 +1,63 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testDiagnoseSuite{})
+
+type testDiagnoseSuite struct{}
+
+func (s *testDiagnoseSuite) TestDiagnose(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1, 2)
+
+	// Store 1 is busy and store 3 is applying too many snapshots.
+	tc.setStoreBusy(1, true)
+	tc.updateSnapshotCount(3, int(opt.GetMaxSnapshotCount())+1)
+
+	d, err := co.diagnoseStore(3)
+	c.Assert(err, IsNil)
+	c.Assert(d.SourceFilters, DeepEquals, []string{"snapshot-count"})
+	c.Assert(d.TargetFilters, DeepEquals, []string{"snapshot-count"})
+	_, err = co.diagnoseStore(4)
+	c.Assert(err, NotNil)
+
+	diagnosis, err := co.diagnoseRegion(1)
+	c.Assert(err, IsNil)
+	c.Assert(diagnosis.Operator, IsNil)
+	c.Assert(diagnosis.CheckerOperator, IsNil)
+	c.Assert(diagnosis.Stores, HasLen, 3)
+	c.Assert(diagnosis.Stores[0], DeepEquals, &StoreDiagnosis{StoreID: 1, SourceFilters: []string{"health"}})
+	c.Assert(diagnosis.Stores[1], DeepEquals, &StoreDiagnosis{StoreID: 2})
+	c.Assert(diagnosis.Stores[2], DeepEquals, &StoreDiagnosis{StoreID: 3, TargetFilters: []string{"snapshot-count"}})
+
+	// The checker would add a peer once store 3 can take it.
+	tc.updateSnapshotCount(3, 0)
+	diagnosis, err = co.diagnoseRegion(1)
+	c.Assert(err, IsNil)
+	checkAddPeer(c, diagnosis.CheckerOperator, 3)
+	c.Assert(co.getOperator(1), IsNil)
+	_, err = co.diagnoseRegion(2)
+	c.Assert(err, NotNil)
+}
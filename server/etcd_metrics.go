@@ -0,0 +1,85 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const metricsPath = "/metrics"
+
+// etcdMetrics are the metrics of the embedded etcd re-exported by PD, so
+// etcd degradation can be caught with the PD metrics.
+var etcdMetrics = map[string]string{
+	"etcd_server_has_leader":                     "pd_etcd_server_has_leader",
+	"etcd_server_leader_changes_seen_total":      "pd_etcd_server_leader_changes_seen_total",
+	"etcd_server_proposals_committed_total":      "pd_etcd_server_proposals_committed_total",
+	"etcd_server_proposals_applied_total":        "pd_etcd_server_proposals_applied_total",
+	"etcd_server_proposals_pending":              "pd_etcd_server_proposals_pending",
+	"etcd_server_proposals_failed_total":         "pd_etcd_server_proposals_failed_total",
+	"etcd_disk_wal_fsync_duration_seconds":       "pd_etcd_disk_wal_fsync_duration_seconds",
+	"etcd_disk_backend_commit_duration_seconds":  "pd_etcd_disk_backend_commit_duration_seconds",
+	"etcd_debugging_mvcc_db_total_size_in_bytes": "pd_etcd_mvcc_db_total_size_in_bytes",
+}
+
+// etcdMetricsGatherer gathers the metrics and appends the copies of the etcd
+// metrics with PD names.
+type etcdMetricsGatherer struct {
+	prometheus.Gatherer
+}
+
+func (g etcdMetricsGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.Gatherer.Gather()
+	for _, mf := range mfs {
+		if name, ok := etcdMetrics[mf.GetName()]; ok {
+			mf = proto.Clone(mf).(*dto.MetricFamily)
+			mf.Name = proto.String(name)
+			mfs = append(mfs, mf)
+		}
+	}
+	sort.Slice(mfs, func(i, j int) bool { return mfs[i].GetName() < mfs[j].GetName() })
+	return mfs, err
+}
+
+// MetricsGatherer returns the gatherer of the metrics served and pushed by
+// PD, which exports the etcd metrics too.
+func MetricsGatherer() prometheus.Gatherer {
+	return etcdMetricsGatherer{prometheus.DefaultGatherer}
+}
+
+// serveMetrics serves the metrics of PD in place of the metrics endpoint of
+// etcd.
+func serveMetrics(w http.ResponseWriter, r *http.Request) {
+	mfs, err := MetricsGatherer().Gather()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	contentType := expfmt.Negotiate(r.Header)
+	w.Header().Set("Content-Type", string(contentType))
+	enc := expfmt.NewEncoder(w, contentType)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			log.Errorf("encode metrics err %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Suite(&testEtcdMetricsSuite{})
+
+type testEtcdMetricsSuite struct{}
+
+func (s *testEtcdMetricsSuite) TestExportEtcdMetrics(c *C) {
+	_, cleanup := mustRunTestServer(c)
+	defer cleanup()
+
+	mfs, err := MetricsGatherer().Gather()
+	c.Assert(err, IsNil)
+	names := make(map[string]struct{})
+	for i, mf := range mfs {
+		names[mf.GetName()] = struct{}{}
+		if i > 0 {
+			c.Assert(mfs[i-1].GetName() < mf.GetName(), IsTrue)
+		}
+	}
+	for name, pdName := range etcdMetrics {
+		if _, ok := names[name]; ok {
+			c.Assert(names, HasKey, pdName)
+		}
+	}
+	c.Assert(names, HasKey, "pd_etcd_server_has_leader")
+	c.Assert(names, HasKey, "pd_etcd_disk_wal_fsync_duration_seconds")
+
+	// The global gatherer is not changed.
+	mfs, err = prometheus.DefaultGatherer.Gather()
+	c.Assert(err, IsNil)
+	for _, mf := range mfs {
+		c.Assert(mf.GetName(), Not(Equals), "pd_etcd_server_has_leader")
+	}
+}
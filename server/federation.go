@@ -0,0 +1,519 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+	"math/rand"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// In federation mode, a PD hosts some logical clusters besides its own
+// cluster, so small clusters can share the PD deployment. Each logical
+// cluster is served by a tenant, which is a Server sharing the etcd client
+// and the leadership with the PD, but has its own cluster ID, metadata
+// prefix, coordinator and TSO allocator. The gRPC requests are routed to the
+// tenants by the cluster ID in the request header, and the HTTP API only
+// serves the PD's own cluster.
+
+// federationPath is the path to save the IDs of the logical clusters.
+var federationPath = path.Join(pdRootPath, "federation")
+
+func makeLogicalClusterKey(clusterID uint64) string {
+	return path.Join(federationPath, "clusters", strconv.FormatUint(clusterID, 10))
+}
+
+func newTenant(s *Server, clusterID uint64) *Server {
+	t := &Server{
+		cfg:          s.cfg,
+		scheduleOpt:  newScheduleOption(s.cfg),
+		client:       s.client,
+		clusterID:    clusterID,
+		rootPath:     path.Join(pdRootPath, strconv.FormatUint(clusterID, 10)),
+		leaderValue:  s.leaderValue,
+		staleCache:   newStaleCache(),
+		tsoQuota:     newTsoQuota(s.cfg.TsoClientQuota),
+		rateLimiter:  s.rateLimiter,
+		clockMonitor: s.clockMonitor,
+		memberPeers:  s.memberPeers,
+		id:           s.id,
+		primary:      s,
+	}
+	t.idAlloc = &idAllocator{s: t}
+	t.tsoBatcher = newTsoBatcher(t.getRespTS)
+	t.tsoProxy = newTsoProxy(t, s.forwarder)
+	t.kv = newKV(t)
+	t.hotRegions = s.hotRegions.forCluster(clusterID)
+	t.cluster = newRaftCluster(t, clusterID)
+	t.handler = newHandler(t)
+	return t
+}
+
+func (s *Server) getTenant(clusterID uint64) *Server {
+	s.tenantsMu.RLock()
+	defer s.tenantsMu.RUnlock()
+	return s.tenants[clusterID]
+}
+
+func (s *Server) getTenants() []*Server {
+	s.tenantsMu.RLock()
+	defer s.tenantsMu.RUnlock()
+	tenants := make([]*Server, 0, len(s.tenants))
+	for _, t := range s.tenants {
+		tenants = append(tenants, t)
+	}
+	return tenants
+}
+
+// GetLogicalClusters returns the IDs of the logical clusters hosted by the PD.
+func (s *Server) GetLogicalClusters() []uint64 {
+	s.tenantsMu.RLock()
+	defer s.tenantsMu.RUnlock()
+	ids := make([]uint64, 0, len(s.tenants))
+	for id := range s.tenants {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// CreateLogicalCluster creates a logical cluster with a new cluster ID, it
+// can only be called on the leader.
+func (s *Server) CreateLogicalCluster() (uint64, error) {
+	if !s.IsLeader() {
+		return 0, errors.Trace(errNotLeader)
+	}
+	// Generate a random cluster ID like the PD's own one.
+	clusterID := (uint64(time.Now().Unix()) << 32) + uint64(rand.Uint32())
+	if clusterID == s.clusterID {
+		return 0, errors.Errorf("cluster id %d conflicts", clusterID)
+	}
+	key := makeLogicalClusterKey(clusterID)
+	resp, err := s.leaderTxn(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, "")).
+		Commit()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return 0, errors.Trace(errTxnFailed)
+	}
+
+	// Publish the tenant after it starts, a failed one is removed from etcd
+	// so that the next leader doesn't load it.
+	t := newTenant(s, clusterID)
+	if err = s.startTenant(t); err != nil {
+		if _, e := s.leaderTxn().Then(clientv3.OpDelete(key)).Commit(); e != nil {
+			log.Errorf("remove logical cluster %d err %v", clusterID, e)
+		}
+		return 0, errors.Trace(err)
+	}
+	s.tenantsMu.Lock()
+	s.tenants[clusterID] = t
+	s.tenantsMu.Unlock()
+	log.Infof("logical cluster %d is created", clusterID)
+	return clusterID, nil
+}
+
+// loadTenants loads the logical clusters created by the previous leader.
+func (s *Server) loadTenants() error {
+	prefix := path.Join(federationPath, "clusters") + "/"
+	resp, err := kvGet(s.client, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.tenantsMu.Lock()
+	defer s.tenantsMu.Unlock()
+	for _, item := range resp.Kvs {
+		clusterID, err := strconv.ParseUint(path.Base(string(item.Key)), 10, 64)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := s.tenants[clusterID]; !ok {
+			s.tenants[clusterID] = newTenant(s, clusterID)
+		}
+	}
+	return nil
+}
+
+// startTenant makes the tenant serve with the leadership of the PD.
+func (s *Server) startTenant(t *Server) error {
+	t.leaderValue = s.leaderValue
+	if err := t.reloadScheduleOption(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := t.createRaftCluster(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := t.syncTimestamp(); err != nil {
+		t.stopRaftCluster()
+		return errors.Trace(err)
+	}
+	t.enableLeader(true)
+	return nil
+}
+
+// startTenants starts the tenants after the PD becomes the leader, a failed
+// tenant doesn't serve until the next election.
+func (s *Server) startTenants() {
+	if err := s.loadTenants(); err != nil {
+		log.Errorf("load logical clusters err %v", err)
+	}
+	for _, t := range s.getTenants() {
+		if err := s.startTenant(t); err != nil {
+			log.Errorf("start logical cluster %d err %v", t.clusterID, err)
+		}
+	}
+}
+
+func (s *Server) stopTenants() {
+	for _, t := range s.getTenants() {
+		if !t.IsLeader() {
+			continue
+		}
+		t.enableLeader(false)
+		t.stopRaftCluster()
+		t.ts.Store(&atomicObject{
+			physical: zeroTime,
+		})
+	}
+}
+
+func (s *Server) updateTenantTimestamps() {
+	for _, t := range s.getTenants() {
+		if !t.IsLeader() {
+			continue
+		}
+		if err := t.updateTimestamp(); err != nil {
+			log.Errorf("update timestamp of logical cluster %d err %v", t.clusterID, err)
+		}
+	}
+}
+
+// federationRouter routes the gRPC requests to the PD or its tenants by the
+// cluster ID in the request header.
+type federationRouter struct {
+	s *Server
+}
+
+func (r federationRouter) route(header *pdpb.RequestHeader) *Server {
+	if t := r.s.getTenant(header.GetClusterId()); t != nil {
+		return t
+	}
+	return r.s
+}
+
+// unary calls the handler of the routed server with its interceptor.
+func (r federationRouter) unary(ctx context.Context, request pdRequest, method string, handler func(s *Server, ctx context.Context, request interface{}) (interface{}, error)) (interface{}, error) {
+	s := r.route(request.GetHeader())
+	info := &grpc.UnaryServerInfo{
+		Server:     s,
+		FullMethod: pdServicePrefix + method,
+	}
+	return s.unaryInterceptor(ctx, request, info, func(ctx context.Context, request interface{}) (interface{}, error) {
+		return handler(s, ctx, request)
+	})
+}
+
+// stream peeks the first request to route the stream, and calls the handler
+// of the routed server with its interceptor.
+func (r federationRouter) stream(stream grpc.ServerStream, first pdRequest, method string, handler func(s *Server, stream grpc.ServerStream) error) error {
+	err := stream.RecvMsg(first)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s := r.route(first.GetHeader())
+	info := &grpc.StreamServerInfo{
+		FullMethod:     pdServicePrefix + method,
+		IsClientStream: true,
+		IsServerStream: true,
+	}
+	peeked := &peekedStream{ServerStream: stream, first: first.(proto.Message)}
+	return s.streamInterceptor(s, peeked, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return handler(s, stream)
+	})
+}
+
+func (r federationRouter) GetMembers(ctx context.Context, request *pdpb.GetMembersRequest) (*pdpb.GetMembersResponse, error) {
+	resp, err := r.unary(ctx, request, "GetMembers", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetMembers(ctx, request.(*pdpb.GetMembersRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetMembersResponse), nil
+}
+
+func (r federationRouter) Tso(stream pdpb.PD_TsoServer) error {
+	return r.stream(stream, &pdpb.TsoRequest{}, "Tso", func(s *Server, stream grpc.ServerStream) error {
+		return s.Tso(tsoServer{stream})
+	})
+}
+
+func (r federationRouter) Bootstrap(ctx context.Context, request *pdpb.BootstrapRequest) (*pdpb.BootstrapResponse, error) {
+	resp, err := r.unary(ctx, request, "Bootstrap", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.Bootstrap(ctx, request.(*pdpb.BootstrapRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.BootstrapResponse), nil
+}
+
+func (r federationRouter) IsBootstrapped(ctx context.Context, request *pdpb.IsBootstrappedRequest) (*pdpb.IsBootstrappedResponse, error) {
+	resp, err := r.unary(ctx, request, "IsBootstrapped", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.IsBootstrapped(ctx, request.(*pdpb.IsBootstrappedRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.IsBootstrappedResponse), nil
+}
+
+func (r federationRouter) AllocID(ctx context.Context, request *pdpb.AllocIDRequest) (*pdpb.AllocIDResponse, error) {
+	resp, err := r.unary(ctx, request, "AllocID", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.AllocID(ctx, request.(*pdpb.AllocIDRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.AllocIDResponse), nil
+}
+
+func (r federationRouter) GetStore(ctx context.Context, request *pdpb.GetStoreRequest) (*pdpb.GetStoreResponse, error) {
+	resp, err := r.unary(ctx, request, "GetStore", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetStore(ctx, request.(*pdpb.GetStoreRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetStoreResponse), nil
+}
+
+func (r federationRouter) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (*pdpb.PutStoreResponse, error) {
+	resp, err := r.unary(ctx, request, "PutStore", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.PutStore(ctx, request.(*pdpb.PutStoreRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.PutStoreResponse), nil
+}
+
+func (r federationRouter) StoreHeartbeat(ctx context.Context, request *pdpb.StoreHeartbeatRequest) (*pdpb.StoreHeartbeatResponse, error) {
+	resp, err := r.unary(ctx, request, "StoreHeartbeat", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.StoreHeartbeat(ctx, request.(*pdpb.StoreHeartbeatRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.StoreHeartbeatResponse), nil
+}
+
+func (r federationRouter) RegionHeartbeat(stream pdpb.PD_RegionHeartbeatServer) error {
+	return r.stream(stream, &pdpb.RegionHeartbeatRequest{}, "RegionHeartbeat", func(s *Server, stream grpc.ServerStream) error {
+		return s.RegionHeartbeat(regionHeartbeatServer{stream})
+	})
+}
+
+func (r federationRouter) GetRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	resp, err := r.unary(ctx, request, "GetRegion", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetRegion(ctx, request.(*pdpb.GetRegionRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetRegionResponse), nil
+}
+
+func (r federationRouter) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	resp, err := r.unary(ctx, request, "GetPrevRegion", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetPrevRegion(ctx, request.(*pdpb.GetRegionRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetRegionResponse), nil
+}
+
+func (r federationRouter) GetRegionByID(ctx context.Context, request *pdpb.GetRegionByIDRequest) (*pdpb.GetRegionResponse, error) {
+	resp, err := r.unary(ctx, request, "GetRegionByID", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetRegionByID(ctx, request.(*pdpb.GetRegionByIDRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetRegionResponse), nil
+}
+
+func (r federationRouter) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsRequest) (*pdpb.ScanRegionsResponse, error) {
+	resp, err := r.unary(ctx, request, "ScanRegions", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.ScanRegions(ctx, request.(*pdpb.ScanRegionsRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.ScanRegionsResponse), nil
+}
+
+func (r federationRouter) ScatterRegion(ctx context.Context, request *pdpb.ScatterRegionRequest) (*pdpb.ScatterRegionResponse, error) {
+	resp, err := r.unary(ctx, request, "ScatterRegion", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.ScatterRegion(ctx, request.(*pdpb.ScatterRegionRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.ScatterRegionResponse), nil
+}
+
+func (r federationRouter) GetOperator(ctx context.Context, request *pdpb.GetOperatorRequest) (*pdpb.GetOperatorResponse, error) {
+	resp, err := r.unary(ctx, request, "GetOperator", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetOperator(ctx, request.(*pdpb.GetOperatorRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetOperatorResponse), nil
+}
+
+func (r federationRouter) GetGCSafePoint(ctx context.Context, request *pdpb.GetGCSafePointRequest) (*pdpb.GetGCSafePointResponse, error) {
+	resp, err := r.unary(ctx, request, "GetGCSafePoint", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetGCSafePoint(ctx, request.(*pdpb.GetGCSafePointRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetGCSafePointResponse), nil
+}
+
+func (r federationRouter) UpdateGCSafePoint(ctx context.Context, request *pdpb.UpdateGCSafePointRequest) (*pdpb.UpdateGCSafePointResponse, error) {
+	resp, err := r.unary(ctx, request, "UpdateGCSafePoint", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.UpdateGCSafePoint(ctx, request.(*pdpb.UpdateGCSafePointRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.UpdateGCSafePointResponse), nil
+}
+
+func (r federationRouter) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb.UpdateServiceGCSafePointRequest) (*pdpb.UpdateServiceGCSafePointResponse, error) {
+	resp, err := r.unary(ctx, request, "UpdateServiceGCSafePoint", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.UpdateServiceGCSafePoint(ctx, request.(*pdpb.UpdateServiceGCSafePointRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.UpdateServiceGCSafePointResponse), nil
+}
+
+func (r federationRouter) AskSplit(ctx context.Context, request *pdpb.AskSplitRequest) (*pdpb.AskSplitResponse, error) {
+	resp, err := r.unary(ctx, request, "AskSplit", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.AskSplit(ctx, request.(*pdpb.AskSplitRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.AskSplitResponse), nil
+}
+
+func (r federationRouter) ReportSplit(ctx context.Context, request *pdpb.ReportSplitRequest) (*pdpb.ReportSplitResponse, error) {
+	resp, err := r.unary(ctx, request, "ReportSplit", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.ReportSplit(ctx, request.(*pdpb.ReportSplitRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.ReportSplitResponse), nil
+}
+
+func (r federationRouter) AskBatchSplit(ctx context.Context, request *pdpb.AskBatchSplitRequest) (*pdpb.AskBatchSplitResponse, error) {
+	resp, err := r.unary(ctx, request, "AskBatchSplit", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.AskBatchSplit(ctx, request.(*pdpb.AskBatchSplitRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.AskBatchSplitResponse), nil
+}
+
+func (r federationRouter) ReportBatchSplit(ctx context.Context, request *pdpb.ReportBatchSplitRequest) (*pdpb.ReportBatchSplitResponse, error) {
+	resp, err := r.unary(ctx, request, "ReportBatchSplit", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.ReportBatchSplit(ctx, request.(*pdpb.ReportBatchSplitRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.ReportBatchSplitResponse), nil
+}
+
+func (r federationRouter) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterConfigRequest) (*pdpb.GetClusterConfigResponse, error) {
+	resp, err := r.unary(ctx, request, "GetClusterConfig", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetClusterConfig(ctx, request.(*pdpb.GetClusterConfigRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetClusterConfigResponse), nil
+}
+
+func (r federationRouter) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterConfigRequest) (*pdpb.PutClusterConfigResponse, error) {
+	resp, err := r.unary(ctx, request, "PutClusterConfig", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.PutClusterConfig(ctx, request.(*pdpb.PutClusterConfigRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.PutClusterConfigResponse), nil
+}
+
+func (r federationRouter) GetClusterStatus(ctx context.Context, request *pdpb.GetClusterStatusRequest) (*pdpb.GetClusterStatusResponse, error) {
+	resp, err := r.unary(ctx, request, "GetClusterStatus", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetClusterStatus(ctx, request.(*pdpb.GetClusterStatusRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetClusterStatusResponse), nil
+}
+
+func (r federationRouter) SyncRegions(stream pdpb.PD_SyncRegionsServer) error {
+	return r.stream(stream, &pdpb.SyncRegionRequest{}, "SyncRegions", func(s *Server, stream grpc.ServerStream) error {
+		return s.SyncRegions(syncRegionsServer{stream})
+	})
+}
+
+func (r federationRouter) GetAllStores(ctx context.Context, request *pdpb.GetAllStoresRequest) (*pdpb.GetAllStoresResponse, error) {
+	resp, err := r.unary(ctx, request, "GetAllStores", func(s *Server, ctx context.Context, request interface{}) (interface{}, error) {
+		return s.GetAllStores(ctx, request.(*pdpb.GetAllStoresRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pdpb.GetAllStoresResponse), nil
+}
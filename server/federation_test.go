@@ -0,0 +1,89 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+)
+
+var _ = Suite(&testFederationSuite{})
+
+type testFederationSuite struct {
+	testClusterBaseSuite
+}
+
+func (s *testFederationSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustRunTestServer(c)
+	s.grpcPDClient = mustNewGrpcClient(c, s.svr.GetAddr())
+}
+
+func (s *testFederationSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testFederationSuite) isBootstrapped(c *C, clusterID uint64) bool {
+	resp, err := s.grpcPDClient.IsBootstrapped(context.Background(), &pdpb.IsBootstrappedRequest{
+		Header: newRequestHeader(clusterID),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetHeader().GetClusterId(), Equals, clusterID)
+	return resp.GetBootstrapped()
+}
+
+func (s *testFederationSuite) TestLogicalCluster(c *C) {
+	clusterID, err := s.svr.CreateLogicalCluster()
+	c.Assert(err, IsNil)
+	c.Assert(clusterID, Not(Equals), s.svr.clusterID)
+	c.Assert(s.svr.GetLogicalClusters(), DeepEquals, []uint64{clusterID})
+
+	// The logical cluster is bootstrapped separately.
+	primaryBootstrapped := s.isBootstrapped(c, s.svr.clusterID)
+	c.Assert(s.isBootstrapped(c, clusterID), IsFalse)
+	s.bootstrapCluster(c, clusterID, "127.0.0.1:1")
+	c.Assert(s.isBootstrapped(c, clusterID), IsTrue)
+	c.Assert(s.isBootstrapped(c, s.svr.clusterID), Equals, primaryBootstrapped)
+
+	tenant := s.svr.getTenant(clusterID)
+	c.Assert(tenant.rootPath, Not(Equals), s.svr.rootPath)
+	c.Assert(tenant.GetRaftCluster(), NotNil)
+
+	// The logical cluster has its own TSO allocator.
+	tsoClient, err := s.grpcPDClient.Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	for i := 0; i < 3; i++ {
+		err = tsoClient.Send(&pdpb.TsoRequest{
+			Header: newRequestHeader(clusterID),
+			Count:  1,
+		})
+		c.Assert(err, IsNil)
+		resp, err := tsoClient.Recv()
+		c.Assert(err, IsNil)
+		c.Assert(resp.GetHeader().GetClusterId(), Equals, clusterID)
+		c.Assert(resp.GetCount(), Equals, uint32(1))
+	}
+
+	// The new leader loads the logical clusters.
+	s.svr.stopTenants()
+	c.Assert(tenant.IsLeader(), IsFalse)
+	s.svr.tenantsMu.Lock()
+	s.svr.tenants = make(map[uint64]*Server)
+	s.svr.tenantsMu.Unlock()
+	c.Assert(s.svr.loadTenants(), IsNil)
+	c.Assert(s.svr.GetLogicalClusters(), DeepEquals, []uint64{clusterID})
+	c.Assert(s.svr.startTenant(s.svr.getTenant(clusterID)), IsNil)
+	c.Assert(s.isBootstrapped(c, clusterID), IsTrue)
+}
@@ -134,6 +134,97 @@ func (f *healthFilter) FilterTarget(store *storeInfo) bool {
 	return f.filter(store)
 }
 
+// leaderWeightFilter filters the stores which should have no leader.
+type leaderWeightFilter struct{}
+
+func newLeaderWeightFilter() *leaderWeightFilter {
+	return &leaderWeightFilter{}
+}
+
+func (f *leaderWeightFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *leaderWeightFilter) FilterTarget(store *storeInfo) bool {
+	return store.leaderWeight() <= 0
+}
+
+// rejectLeaderFilter filters the stores whose labels reject leaders.
+type rejectLeaderFilter struct {
+	opt *scheduleOption
+}
+
+func newRejectLeaderFilter(opt *scheduleOption) *rejectLeaderFilter {
+	return &rejectLeaderFilter{opt: opt}
+}
+
+func (f *rejectLeaderFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *rejectLeaderFilter) FilterTarget(store *storeInfo) bool {
+	for _, label := range f.opt.GetRejectLeaderLabels() {
+		if store.getLabelValue(label.GetKey()) == label.GetValue() {
+			return true
+		}
+	}
+	return false
+}
+
+// placementLeaderFilter filters the stores which can't hold the leaders of
+// the placement.
+type placementLeaderFilter struct {
+	placement *KeyRangePlacement
+}
+
+func newPlacementLeaderFilter(placement *KeyRangePlacement) *placementLeaderFilter {
+	return &placementLeaderFilter{placement: placement}
+}
+
+func (f *placementLeaderFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *placementLeaderFilter) FilterTarget(store *storeInfo) bool {
+	return !f.placement.allowLeader(store)
+}
+
+// placementRuleFilter filters the stores which can't hold the peers of the
+// placement rule.
+type placementRuleFilter struct {
+	rule *PlacementRule
+}
+
+func newPlacementRuleFilter(rule *PlacementRule) *placementRuleFilter {
+	return &placementRuleFilter{rule: rule}
+}
+
+func (f *placementRuleFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *placementRuleFilter) FilterTarget(store *storeInfo) bool {
+	return !f.rule.allowStore(store)
+}
+
+// namespaceFilter filters the stores which are not in the namespace.
+type namespaceFilter struct {
+	cluster   *clusterInfo
+	namespace string
+}
+
+func newNamespaceFilter(cluster *clusterInfo, namespace string) *namespaceFilter {
+	return &namespaceFilter{cluster: cluster, namespace: namespace}
+}
+
+func (f *namespaceFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *namespaceFilter) FilterTarget(store *storeInfo) bool {
+	return f.cluster.getStoreNamespace(store.GetId()) != f.namespace
+}
+
 type snapshotCountFilter struct {
 	opt *scheduleOption
 }
@@ -156,13 +247,33 @@ func (f *snapshotCountFilter) FilterTarget(store *storeInfo) bool {
 	return f.filter(store)
 }
 
-// storageThresholdFilter ensures that we will not use an almost full store as a target.
-type storageThresholdFilter struct{}
+// pendingPeerCountFilter ensures that the stores still catching up with many
+// peers are not the targets.
+type pendingPeerCountFilter struct {
+	opt *scheduleOption
+}
 
-const storageAvailableRatioThreshold = 0.2
+func newPendingPeerCountFilter(opt *scheduleOption) *pendingPeerCountFilter {
+	return &pendingPeerCountFilter{opt: opt}
+}
+
+func (f *pendingPeerCountFilter) FilterSource(store *storeInfo) bool {
+	return false
+}
+
+func (f *pendingPeerCountFilter) FilterTarget(store *storeInfo) bool {
+	return store.pendingPeerCount() > f.opt.GetMaxPendingPeerCount()
+}
+
+// storageThresholdFilter ensures that we will not use an almost full store
+// as a target, the used ratio of the target should not exceed the
+// high-space-ratio.
+type storageThresholdFilter struct {
+	opt *scheduleOption
+}
 
 func newStorageThresholdFilter(opt *scheduleOption) *storageThresholdFilter {
-	return &storageThresholdFilter{}
+	return &storageThresholdFilter{opt: opt}
 }
 
 func (f *storageThresholdFilter) FilterSource(store *storeInfo) bool {
@@ -170,7 +281,24 @@ func (f *storageThresholdFilter) FilterSource(store *storeInfo) bool {
 }
 
 func (f *storageThresholdFilter) FilterTarget(store *storeInfo) bool {
-	return store.availableRatio() < storageAvailableRatioThreshold
+	return 1-store.availableRatio() > f.opt.GetHighSpaceRatio()
+}
+
+// lowSpaceFilter selects the stores short of space as the sources.
+type lowSpaceFilter struct {
+	opt *scheduleOption
+}
+
+func newLowSpaceFilter(opt *scheduleOption) *lowSpaceFilter {
+	return &lowSpaceFilter{opt: opt}
+}
+
+func (f *lowSpaceFilter) FilterSource(store *storeInfo) bool {
+	return !store.isLowSpace(f.opt.GetLowSpaceRatio())
+}
+
+func (f *lowSpaceFilter) FilterTarget(store *storeInfo) bool {
+	return false
 }
 
 // distinctScoreFilter ensures that distinct score will not decrease.
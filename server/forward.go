@@ -0,0 +1,569 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedKey is the gRPC metadata key of the requests forwarded by a
+// follower, the value is the original client. The requests with it are never
+// forwarded again, so there is no forwarding loop during the election. The
+// original client is ignored if the request is not sent by a member.
+const forwardedKey = "pd-forwarded-for"
+
+// leaderForwarder serves the gRPC requests on the leader, and forwards them
+// to the leader on the followers, so clients can send requests to any PD.
+// GetMembers and the stale reads are always served locally, and the Tso
+// streams are batched by the tsoProxy.
+type leaderForwarder struct {
+	s       *Server
+	router  federationRouter
+	streams *streamLimiter
+
+	sync.Mutex
+	leaderAddr string
+	conn       *grpc.ClientConn
+}
+
+func newLeaderForwarder(s *Server) *leaderForwarder {
+	return &leaderForwarder{
+		s:       s,
+		router:  federationRouter{s},
+		streams: newStreamLimiter(s.cfg.GRPC.MaxConcurrentStreams),
+	}
+}
+
+// acquireStream checks the stream limit of the connection. The streams
+// forwarded by the members are not limited, a follower forwards the streams
+// of all its clients with one connection.
+func (f *leaderForwarder) acquireStream(ctx context.Context) (func(), error) {
+	if _, ok := f.s.getForwardedFor(ctx); ok {
+		return func() {}, nil
+	}
+	return f.streams.acquire(ctx)
+}
+
+// getForwardedFor returns the original client of the request forwarded by a
+// member.
+func (s *Server) getForwardedFor(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[forwardedKey]) == 0 || !s.memberPeers.isMember(ctx) {
+		return "", false
+	}
+	return md[forwardedKey][0], true
+}
+
+// getLeaderClient returns the client of the leader if the request should be
+// forwarded, and the context to forward it with.
+func (f *leaderForwarder) getLeaderClient(ctx context.Context) (pdpb.PDClient, context.Context, error) {
+	// The leader only sees the certificate of the follower.
+	if err := f.s.checkCertCN(ctx); err != nil {
+		return nil, ctx, err
+	}
+	if f.s.IsLeader() || f.s.isClosed() {
+		return nil, ctx, nil
+	}
+	// The forwarded requests are never forwarded again to avoid loops, it
+	// doesn't matter whether the metadata is trusted.
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[forwardedKey]) > 0 {
+		return nil, ctx, nil
+	}
+	leader, err := f.s.GetLeader()
+	if err != nil || f.s.isSameLeader(leader) || len(leader.GetClientUrls()) == 0 {
+		// It fails with notLeaderError later.
+		return nil, ctx, nil
+	}
+	conn, err := f.getConn(leader.GetClientUrls()[0])
+	if err != nil {
+		return nil, ctx, errors.Trace(err)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	// The leader trusts the metadata of the follower, drop the one set by
+	// the client.
+	delete(md, tsoProxyKey)
+	md[forwardedKey] = []string{f.s.getTsoClient(ctx)}
+	return pdpb.NewPDClient(conn), metadata.NewOutgoingContext(ctx, md), nil
+}
+
+// getConn returns the connection to the leader, the connection to the
+// previous leader is closed.
+func (f *leaderForwarder) getConn(addr string) (*grpc.ClientConn, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.conn != nil && f.leaderAddr == addr {
+		return f.conn, nil
+	}
+
+	conn, err := f.dial(addr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.conn != nil {
+		f.conn.Close()
+	}
+	log.Infof("forward requests to leader %s", addr)
+	f.leaderAddr, f.conn = addr, conn
+	return conn, nil
+}
+
+// dial connects to the PD of addr.
+func (f *leaderForwarder) dial(addr string) (*grpc.ClientConn, error) {
+	opt := grpc.WithInsecure()
+	if f.s.tlsConfig != nil {
+		opt = grpc.WithTransportCredentials(credentials.NewTLS(f.s.tlsConfig))
+	}
+	conn, err := grpc.Dial(addr, grpc.WithDialer(func(addr string, d time.Duration) (net.Conn, error) {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// For tests.
+		if u.Scheme == "unix" || u.Scheme == "unixs" {
+			return net.DialTimeout("unix", u.Host, d)
+		}
+		return net.DialTimeout("tcp", u.Host, d)
+	}), opt)
+	return conn, errors.Trace(err)
+}
+
+func (f *leaderForwarder) close() {
+	f.Lock()
+	defer f.Unlock()
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+}
+
+// forwardStream proxies the messages between the client stream and the
+// leader stream until either of them is finished.
+func forwardStream(stream grpc.ServerStream, upstream grpc.ClientStream, cancel context.CancelFunc, newRequest, newResponse func() interface{}) error {
+	go func() {
+		for {
+			request := newRequest()
+			err := stream.RecvMsg(request)
+			if err == io.EOF {
+				upstream.CloseSend()
+				return
+			}
+			if err == nil {
+				err = upstream.SendMsg(request)
+			}
+			if err != nil {
+				// Stop receiving from the leader.
+				cancel()
+				return
+			}
+		}
+	}()
+
+	for {
+		response := newResponse()
+		err := upstream.RecvMsg(response)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = stream.SendMsg(response); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// GetMembers implements gRPC PDServer.
+func (f *leaderForwarder) GetMembers(ctx context.Context, request *pdpb.GetMembersRequest) (*pdpb.GetMembersResponse, error) {
+	return f.router.GetMembers(ctx, request)
+}
+
+// Tso implements gRPC PDServer.
+func (f *leaderForwarder) Tso(stream pdpb.PD_TsoServer) error {
+	if err := f.s.checkCertCN(stream.Context()); err != nil {
+		return err
+	}
+	release, err := f.acquireStream(stream.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+	// The first request is peeked to find the allocator.
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	peeked := tsoServer{&peekedStream{ServerStream: stream, first: first}}
+	if dc := first.GetDcLocation(); dc != "" {
+		return f.forwardLocalTso(dc, peeked)
+	}
+
+	client, _, err := f.getLeaderClient(stream.Context())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if client == nil {
+		return f.router.Tso(peeked)
+	}
+	s := f.router.route(first.GetHeader())
+	return s.tsoProxy.serve(peeked)
+}
+
+// Bootstrap implements gRPC PDServer.
+func (f *leaderForwarder) Bootstrap(ctx context.Context, request *pdpb.BootstrapRequest) (*pdpb.BootstrapResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.Bootstrap(ctx, request)
+	}
+	return f.router.Bootstrap(ctx, request)
+}
+
+// IsBootstrapped implements gRPC PDServer.
+func (f *leaderForwarder) IsBootstrapped(ctx context.Context, request *pdpb.IsBootstrappedRequest) (*pdpb.IsBootstrappedResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.IsBootstrapped(ctx, request)
+	}
+	return f.router.IsBootstrapped(ctx, request)
+}
+
+// AllocID implements gRPC PDServer.
+func (f *leaderForwarder) AllocID(ctx context.Context, request *pdpb.AllocIDRequest) (*pdpb.AllocIDResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.AllocID(ctx, request)
+	}
+	return f.router.AllocID(ctx, request)
+}
+
+// GetStore implements gRPC PDServer.
+func (f *leaderForwarder) GetStore(ctx context.Context, request *pdpb.GetStoreRequest) (*pdpb.GetStoreResponse, error) {
+	if f.router.route(request.GetHeader()).allowStaleRead(request.GetHeader()) {
+		return f.router.GetStore(ctx, request)
+	}
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetStore(ctx, request)
+	}
+	return f.router.GetStore(ctx, request)
+}
+
+// GetClusterStatus implements gRPC PDServer.
+func (f *leaderForwarder) GetClusterStatus(ctx context.Context, request *pdpb.GetClusterStatusRequest) (*pdpb.GetClusterStatusResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetClusterStatus(ctx, request)
+	}
+	return f.router.GetClusterStatus(ctx, request)
+}
+
+// SyncRegions implements gRPC PDServer. It is not forwarded since only the
+// leader can serve it.
+func (f *leaderForwarder) SyncRegions(stream pdpb.PD_SyncRegionsServer) error {
+	release, err := f.acquireStream(stream.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+	return f.router.SyncRegions(stream)
+}
+
+// GetAllStores implements gRPC PDServer.
+func (f *leaderForwarder) GetAllStores(ctx context.Context, request *pdpb.GetAllStoresRequest) (*pdpb.GetAllStoresResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetAllStores(ctx, request)
+	}
+	return f.router.GetAllStores(ctx, request)
+}
+
+// PutStore implements gRPC PDServer.
+func (f *leaderForwarder) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (*pdpb.PutStoreResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.PutStore(ctx, request)
+	}
+	return f.router.PutStore(ctx, request)
+}
+
+// StoreHeartbeat implements gRPC PDServer.
+func (f *leaderForwarder) StoreHeartbeat(ctx context.Context, request *pdpb.StoreHeartbeatRequest) (*pdpb.StoreHeartbeatResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.StoreHeartbeat(ctx, request)
+	}
+	return f.router.StoreHeartbeat(ctx, request)
+}
+
+// RegionHeartbeat implements gRPC PDServer.
+func (f *leaderForwarder) RegionHeartbeat(stream pdpb.PD_RegionHeartbeatServer) error {
+	release, err := f.acquireStream(stream.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+	client, ctx, err := f.getLeaderClient(stream.Context())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if client == nil {
+		return f.router.RegionHeartbeat(stream)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	upstream, err := client.RegionHeartbeat(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return forwardStream(stream, upstream, cancel,
+		func() interface{} { return &pdpb.RegionHeartbeatRequest{} },
+		func() interface{} { return &pdpb.RegionHeartbeatResponse{} })
+}
+
+// GetRegion implements gRPC PDServer.
+func (f *leaderForwarder) GetRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	if f.router.route(request.GetHeader()).allowStaleRead(request.GetHeader()) {
+		return f.router.GetRegion(ctx, request)
+	}
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetRegion(ctx, request)
+	}
+	return f.router.GetRegion(ctx, request)
+}
+
+// GetPrevRegion implements gRPC PDServer.
+func (f *leaderForwarder) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	if f.router.route(request.GetHeader()).allowStaleRead(request.GetHeader()) {
+		return f.router.GetPrevRegion(ctx, request)
+	}
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetPrevRegion(ctx, request)
+	}
+	return f.router.GetPrevRegion(ctx, request)
+}
+
+// GetRegionByID implements gRPC PDServer.
+func (f *leaderForwarder) GetRegionByID(ctx context.Context, request *pdpb.GetRegionByIDRequest) (*pdpb.GetRegionResponse, error) {
+	if f.router.route(request.GetHeader()).allowStaleRead(request.GetHeader()) {
+		return f.router.GetRegionByID(ctx, request)
+	}
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetRegionByID(ctx, request)
+	}
+	return f.router.GetRegionByID(ctx, request)
+}
+
+// ScanRegions implements gRPC PDServer.
+func (f *leaderForwarder) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsRequest) (*pdpb.ScanRegionsResponse, error) {
+	if f.router.route(request.GetHeader()).allowStaleRead(request.GetHeader()) {
+		return f.router.ScanRegions(ctx, request)
+	}
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.ScanRegions(ctx, request)
+	}
+	return f.router.ScanRegions(ctx, request)
+}
+
+// ScatterRegion implements gRPC PDServer.
+func (f *leaderForwarder) ScatterRegion(ctx context.Context, request *pdpb.ScatterRegionRequest) (*pdpb.ScatterRegionResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.ScatterRegion(ctx, request)
+	}
+	return f.router.ScatterRegion(ctx, request)
+}
+
+// GetOperator implements gRPC PDServer.
+func (f *leaderForwarder) GetOperator(ctx context.Context, request *pdpb.GetOperatorRequest) (*pdpb.GetOperatorResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetOperator(ctx, request)
+	}
+	return f.router.GetOperator(ctx, request)
+}
+
+// GetGCSafePoint implements gRPC PDServer.
+func (f *leaderForwarder) GetGCSafePoint(ctx context.Context, request *pdpb.GetGCSafePointRequest) (*pdpb.GetGCSafePointResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetGCSafePoint(ctx, request)
+	}
+	return f.router.GetGCSafePoint(ctx, request)
+}
+
+// UpdateGCSafePoint implements gRPC PDServer.
+func (f *leaderForwarder) UpdateGCSafePoint(ctx context.Context, request *pdpb.UpdateGCSafePointRequest) (*pdpb.UpdateGCSafePointResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.UpdateGCSafePoint(ctx, request)
+	}
+	return f.router.UpdateGCSafePoint(ctx, request)
+}
+
+// UpdateServiceGCSafePoint implements gRPC PDServer.
+func (f *leaderForwarder) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb.UpdateServiceGCSafePointRequest) (*pdpb.UpdateServiceGCSafePointResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.UpdateServiceGCSafePoint(ctx, request)
+	}
+	return f.router.UpdateServiceGCSafePoint(ctx, request)
+}
+
+// AskSplit implements gRPC PDServer.
+func (f *leaderForwarder) AskSplit(ctx context.Context, request *pdpb.AskSplitRequest) (*pdpb.AskSplitResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.AskSplit(ctx, request)
+	}
+	return f.router.AskSplit(ctx, request)
+}
+
+// ReportSplit implements gRPC PDServer.
+func (f *leaderForwarder) ReportSplit(ctx context.Context, request *pdpb.ReportSplitRequest) (*pdpb.ReportSplitResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.ReportSplit(ctx, request)
+	}
+	return f.router.ReportSplit(ctx, request)
+}
+
+// AskBatchSplit implements gRPC PDServer.
+func (f *leaderForwarder) AskBatchSplit(ctx context.Context, request *pdpb.AskBatchSplitRequest) (*pdpb.AskBatchSplitResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.AskBatchSplit(ctx, request)
+	}
+	return f.router.AskBatchSplit(ctx, request)
+}
+
+// ReportBatchSplit implements gRPC PDServer.
+func (f *leaderForwarder) ReportBatchSplit(ctx context.Context, request *pdpb.ReportBatchSplitRequest) (*pdpb.ReportBatchSplitResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.ReportBatchSplit(ctx, request)
+	}
+	return f.router.ReportBatchSplit(ctx, request)
+}
+
+// GetClusterConfig implements gRPC PDServer.
+func (f *leaderForwarder) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterConfigRequest) (*pdpb.GetClusterConfigResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.GetClusterConfig(ctx, request)
+	}
+	return f.router.GetClusterConfig(ctx, request)
+}
+
+// PutClusterConfig implements gRPC PDServer.
+func (f *leaderForwarder) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterConfigRequest) (*pdpb.PutClusterConfigResponse, error) {
+	client, ctx, err := f.getLeaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client != nil {
+		return client.PutClusterConfig(ctx, request)
+	}
+	return f.router.PutClusterConfig(ctx, request)
+}
@@ -0,0 +1,230 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"sort"
+	"sync"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var _ = Suite(&testForwardSuite{})
+
+type testForwardSuite struct {
+	testClusterBaseSuite
+	follower *Server
+}
+
+func (s *testForwardSuite) SetUpSuite(c *C) {
+	svrs, cleanup := newMultiTestServers(c, 3)
+	s.cleanup = func() { cleanup() }
+	s.svr = mustWaitLeader(c, svrs)
+	for _, svr := range svrs {
+		if svr != s.svr {
+			s.follower = svr
+		}
+	}
+	s.grpcPDClient = mustNewGrpcClient(c, s.follower.GetAddr())
+}
+
+func (s *testForwardSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testForwardSuite) TestForward(c *C) {
+	c.Assert(s.follower.IsLeader(), IsFalse)
+
+	// Unary requests.
+	s.bootstrapCluster(c, s.svr.clusterID, "127.0.0.1:0")
+	c.Assert(s.svr.GetRaftCluster(), NotNil)
+	resp, err := s.grpcPDClient.AllocID(context.Background(), &pdpb.AllocIDRequest{
+		Header: newRequestHeader(s.svr.clusterID),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetId(), Greater, uint64(0))
+
+	// Streams.
+	tsoClient, err := s.grpcPDClient.Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	var last int64
+	for i := 0; i < 3; i++ {
+		err = tsoClient.Send(&pdpb.TsoRequest{
+			Header: newRequestHeader(s.svr.clusterID),
+			Count:  1,
+		})
+		c.Assert(err, IsNil)
+		resp, err := tsoClient.Recv()
+		c.Assert(err, IsNil)
+		ts := resp.GetTimestamp().GetPhysical()<<18 + resp.GetTimestamp().GetLogical()
+		c.Assert(ts, Greater, last)
+		last = ts
+	}
+
+	// The forwarded requests are not forwarded again.
+	ctx := metadata.NewIncomingContext(newPeerContext("unix", ""), metadata.Pairs(forwardedKey, "client"))
+	client, _, err := s.follower.forwarder.getLeaderClient(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(client, IsNil)
+	client, ctx, err = s.follower.forwarder.getLeaderClient(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(client, NotNil)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.Assert(md[forwardedKey], HasLen, 1)
+
+	// The leader serves the requests itself.
+	client, _, err = s.svr.forwarder.getLeaderClient(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(client, IsNil)
+}
+
+func newPeerContext(network, addr string) context.Context {
+	var a net.Addr = &net.UnixAddr{Net: "unix"}
+	if network == "tcp" {
+		a, _ = net.ResolveTCPAddr("tcp", addr)
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: a})
+}
+
+func (s *testForwardSuite) TestSpoofMetadata(c *C) {
+	// A plain client can't name itself as another client or bypass the
+	// quota as a Tso proxy.
+	spoofed := metadata.Pairs(forwardedKey, "victim", tsoProxyKey, "pd")
+	ctx := metadata.NewIncomingContext(newPeerContext("tcp", "10.0.0.1:1234"), spoofed)
+	_, ok := s.follower.getForwardedFor(ctx)
+	c.Assert(ok, IsFalse)
+	c.Assert(s.follower.getTsoClient(ctx), Equals, "10.0.0.1")
+	c.Assert(s.follower.isTsoProxy(ctx), IsFalse)
+	// The request is not forwarded again, which only fails itself.
+	client, _, err := s.follower.forwarder.getLeaderClient(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(client, IsNil)
+	ctx = metadata.NewIncomingContext(newPeerContext("tcp", "10.0.0.1:1234"), metadata.Pairs(tsoProxyKey, "pd"))
+	client, ctx, err = s.follower.forwarder.getLeaderClient(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(client, NotNil)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.Assert(md[forwardedKey], DeepEquals, []string{"10.0.0.1"})
+	c.Assert(md[tsoProxyKey], HasLen, 0)
+
+	// Nothing is trusted without TLS, even the members.
+	c.Assert(s.follower.isTsoProxy(metadata.NewIncomingContext(newPeerContext("unix", ""), spoofed)), IsFalse)
+
+	// The members are trusted by the verified client certificates.
+	m := s.follower.memberPeers
+	m.cn = "pd"
+	defer func() { m.cn = "" }()
+	ctx = metadata.NewIncomingContext(newTLSPeerContext("pd", true), spoofed)
+	name, ok := s.follower.getForwardedFor(ctx)
+	c.Assert(ok, IsTrue)
+	c.Assert(name, Equals, "victim")
+	c.Assert(s.follower.getTsoClient(ctx), Equals, "victim")
+	c.Assert(s.follower.isTsoProxy(ctx), IsTrue)
+	for _, ctx := range []context.Context{
+		newTLSPeerContext("tidb", true),
+		newTLSPeerContext("pd", false),
+		newPeerContext("tcp", "10.0.0.2:1234"),
+	} {
+		ctx = metadata.NewIncomingContext(ctx, spoofed)
+		_, ok = s.follower.getForwardedFor(ctx)
+		c.Assert(ok, IsFalse)
+		c.Assert(s.follower.isTsoProxy(ctx), IsFalse)
+	}
+}
+
+func newTLSPeerContext(cn string, verified bool) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 1234},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
+
+func (s *testForwardSuite) TestTsoProxy(c *C) {
+	type tsRange struct {
+		physical, first, last int64
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		ranges []tsRange
+	)
+	for i := 0; i < 5; i++ {
+		tsoClient, err := s.grpcPDClient.Tso(context.Background())
+		c.Assert(err, IsNil)
+		wg.Add(1)
+		go func(count uint32) {
+			defer wg.Done()
+			defer tsoClient.CloseSend()
+			for j := 0; j < 50; j++ {
+				err := tsoClient.Send(&pdpb.TsoRequest{
+					Header: newRequestHeader(s.svr.clusterID),
+					Count:  count,
+				})
+				c.Assert(err, IsNil)
+				resp, err := tsoClient.Recv()
+				c.Assert(err, IsNil)
+				ts := resp.GetTimestamp()
+				mu.Lock()
+				ranges = append(ranges, tsRange{ts.GetPhysical(), ts.GetLogical() - int64(count) + 1, ts.GetLogical()})
+				mu.Unlock()
+			}
+		}(uint32(i + 1))
+	}
+	wg.Wait()
+
+	// The timestamps forwarded in batches never overlap.
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].physical != ranges[j].physical {
+			return ranges[i].physical < ranges[j].physical
+		}
+		return ranges[i].first < ranges[j].first
+	})
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].physical == ranges[i-1].physical {
+			c.Assert(ranges[i].first, Greater, ranges[i-1].last)
+		}
+	}
+
+	// The streams share the stream of the proxy. The proxy is not trusted
+	// without TLS, so the leader sees it as a single client.
+	c.Assert(s.follower.tsoProxy.stream, NotNil)
+	c.Assert(s.svr.tsoQuota.getStats(), HasLen, 1)
+
+	// The cluster ID is checked by the follower.
+	tsoClient, err := s.grpcPDClient.Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	err = tsoClient.Send(&pdpb.TsoRequest{
+		Header: newRequestHeader(s.svr.clusterID + 1),
+		Count:  1,
+	})
+	c.Assert(err, IsNil)
+	_, err = tsoClient.Recv()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+)
+
+// serviceSafePoint blocks the GC safe point from moving beyond SafePoint
+// before it expires, it's used by the services like backup which need the
+// old data for a while.
+type serviceSafePoint struct {
+	ServiceID string `json:"service_id"`
+	// ExpiredAt is a unix timestamp in seconds.
+	ExpiredAt int64  `json:"expired_at"`
+	SafePoint uint64 `json:"safe_point"`
+}
+
+// updateGCSafePoint saves the safe point if it's greater than the current
+// one, it returns the safe point after the update. The safe point is never
+// moved backward, because the data before it may be deleted already, and
+// never moved beyond the service safe points.
+func (s *Server) updateGCSafePoint(safePoint uint64) (uint64, error) {
+	s.gcSafePointMu.Lock()
+	defer s.gcSafePointMu.Unlock()
+
+	old, err := s.kv.loadGCSafePoint()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	min, err := s.getMinServiceSafePoint(time.Now())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if min != nil && safePoint > min.SafePoint {
+		log.Infof("gc safe point %d is blocked by service %s at %d", safePoint, min.ServiceID, min.SafePoint)
+		safePoint = min.SafePoint
+	}
+	if safePoint <= old {
+		if safePoint < old {
+			log.Warnf("gc safe point %d is less than the current %d, ignored", safePoint, old)
+		}
+		return old, nil
+	}
+	if err := s.kv.saveGCSafePoint(safePoint); err != nil {
+		return 0, errors.Trace(err)
+	}
+	log.Infof("update gc safe point from %d to %d", old, safePoint)
+	return safePoint, nil
+}
+
+// updateServiceSafePoint saves the safe point of the service which expires
+// after ttl seconds, or removes it if ttl is not positive. It returns the
+// service safe point with the minimum safe point after the update.
+func (s *Server) updateServiceSafePoint(serviceID string, ttl int64, safePoint uint64) (*serviceSafePoint, error) {
+	s.gcSafePointMu.Lock()
+	defer s.gcSafePointMu.Unlock()
+
+	now := time.Now()
+	if ttl <= 0 {
+		if err := s.kv.removeServiceSafePoint(serviceID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		log.Infof("remove service %s safe point", serviceID)
+	} else {
+		gcSafePoint, err := s.kv.loadGCSafePoint()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if safePoint < gcSafePoint {
+			return nil, errors.Errorf("service %s safe point %d is less than the gc safe point %d", serviceID, safePoint, gcSafePoint)
+		}
+		ssp := &serviceSafePoint{
+			ServiceID: serviceID,
+			ExpiredAt: now.Unix() + ttl,
+			SafePoint: safePoint,
+		}
+		if err := s.kv.saveServiceSafePoint(ssp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		log.Infof("update service %s safe point to %d, expires in %ds", serviceID, safePoint, ttl)
+	}
+	min, err := s.getMinServiceSafePoint(now)
+	return min, errors.Trace(err)
+}
+
+// getMinServiceSafePoint returns the service safe point with the minimum safe
+// point, or nil if there is none. The expired ones are removed.
+func (s *Server) getMinServiceSafePoint(now time.Time) (*serviceSafePoint, error) {
+	ssps, err := s.kv.loadServiceSafePoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var min *serviceSafePoint
+	for _, ssp := range ssps {
+		if ssp.ExpiredAt <= now.Unix() {
+			if err := s.kv.removeServiceSafePoint(ssp.ServiceID); err != nil {
+				return nil, errors.Trace(err)
+			}
+			log.Infof("service %s safe point %d expired", ssp.ServiceID, ssp.SafePoint)
+			continue
+		}
+		if min == nil || ssp.SafePoint < min.SafePoint {
+			min = ssp
+		}
+	}
+	return min, nil
+}
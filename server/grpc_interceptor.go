@@ -0,0 +1,191 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// The gRPC server is created by etcd with its own interceptors, so the
+// interceptors of PD are called by federationRouter after the request is
+// routed to the PD or a tenant.
+
+const pdServicePrefix = "/pdpb.PD/"
+
+// pdRequest is implemented by all requests of the PD service.
+type pdRequest interface {
+	GetHeader() *pdpb.RequestHeader
+}
+
+// checkRequest validates the request of the method. GetMembers can be served
+// by any PD, and the stale reads are served by followers.
+func (s *Server) checkRequest(method string, request interface{}) error {
+	req, ok := request.(pdRequest)
+	if !ok {
+		return nil
+	}
+	switch method {
+	case pdServicePrefix + "GetMembers":
+		return nil
+	case pdServicePrefix + "GetStore", pdServicePrefix + "GetRegion", pdServicePrefix + "GetRegionByID",
+		pdServicePrefix + "GetPrevRegion", pdServicePrefix + "ScanRegions":
+		if s.allowStaleRead(req.GetHeader()) {
+			return nil
+		}
+	}
+	return s.validateRequest(req.GetHeader())
+}
+
+// checkRateLimit rejects the request if its method is rate limited.
+func (s *Server) checkRateLimit(ctx context.Context, fullMethod string) error {
+	method := strings.TrimPrefix(fullMethod, pdServicePrefix)
+	ok, limit := s.rateLimiter.allow(method, s.getTsoClient(ctx), time.Now())
+	if ok {
+		return nil
+	}
+	grpcRateLimitedCounter.WithLabelValues(method, limit).Inc()
+	return grpc.Errorf(codes.ResourceExhausted, "%s is rate limited", method)
+}
+
+func grpcResult(err error) string {
+	if err != nil {
+		return "err"
+	}
+	return "ok"
+}
+
+// unaryInterceptor validates the request and records the metrics.
+func (s *Server) unaryInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	err := s.checkCertCN(ctx)
+	if err == nil {
+		err = s.checkRateLimit(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = s.checkRequest(info.FullMethod, request)
+	}
+	var resp interface{}
+	if err == nil {
+		resp, err = handler(ctx, request)
+	}
+	grpcDuration.WithLabelValues(info.FullMethod, grpcResult(err)).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// streamInterceptor validates each request received from the stream and
+// records the metrics.
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.checkCertCN(stream.Context()); err != nil {
+		return err
+	}
+	err := handler(srv, &validatedStream{
+		ServerStream: stream,
+		s:            s,
+		method:       info.FullMethod,
+	})
+	// The handlers trace the errors, unwrap them so the clients can get the
+	// codes of the validation errors.
+	if cause := errors.Cause(err); grpc.Code(cause) != codes.Unknown {
+		return cause
+	}
+	return err
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+	s      *Server
+	method string
+}
+
+func (vs *validatedStream) RecvMsg(m interface{}) error {
+	if err := vs.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	err := vs.s.checkRequest(vs.method, m)
+	grpcStreamMsgCounter.WithLabelValues(vs.method, grpcResult(err)).Inc()
+	return err
+}
+
+// peekedStream returns the request received by the router first.
+type peekedStream struct {
+	grpc.ServerStream
+	first proto.Message
+}
+
+func (ps *peekedStream) RecvMsg(m interface{}) error {
+	if ps.first != nil {
+		proto.Merge(m.(proto.Message), ps.first)
+		ps.first = nil
+		return nil
+	}
+	return ps.ServerStream.RecvMsg(m)
+}
+
+// tsoServer, regionHeartbeatServer and syncRegionsServer make typed streams
+// like the generated code.
+type tsoServer struct {
+	grpc.ServerStream
+}
+
+func (s tsoServer) Send(m *pdpb.TsoResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s tsoServer) Recv() (*pdpb.TsoRequest, error) {
+	m := &pdpb.TsoRequest{}
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type regionHeartbeatServer struct {
+	grpc.ServerStream
+}
+
+func (s regionHeartbeatServer) Send(m *pdpb.RegionHeartbeatResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s regionHeartbeatServer) Recv() (*pdpb.RegionHeartbeatRequest, error) {
+	m := &pdpb.RegionHeartbeatRequest{}
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type syncRegionsServer struct {
+	grpc.ServerStream
+}
+
+func (s syncRegionsServer) Send(m *pdpb.SyncRegionResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s syncRegionsServer) Recv() (*pdpb.SyncRegionRequest, error) {
+	m := &pdpb.SyncRegionRequest{}
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var _ = Suite(&testInterceptorSuite{})
+
+type testInterceptorSuite struct {
+	svr          *Server
+	cleanup      cleanUpFunc
+	grpcPDClient pdpb.PDClient
+}
+
+func (s *testInterceptorSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustRunTestServer(c)
+	s.grpcPDClient = mustNewGrpcClient(c, s.svr.GetAddr())
+}
+
+func (s *testInterceptorSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testInterceptorSuite) TestCheckRequest(c *C) {
+	valid := &pdpb.AllocIDRequest{Header: newRequestHeader(s.svr.clusterID)}
+	invalid := &pdpb.AllocIDRequest{Header: newRequestHeader(s.svr.clusterID + 1)}
+	c.Assert(s.svr.checkRequest(pdServicePrefix+"AllocID", valid), IsNil)
+	c.Assert(grpc.Code(s.svr.checkRequest(pdServicePrefix+"AllocID", invalid)), Equals, codes.FailedPrecondition)
+	// GetMembers is not validated.
+	members := &pdpb.GetMembersRequest{Header: newRequestHeader(s.svr.clusterID + 1)}
+	c.Assert(s.svr.checkRequest(pdServicePrefix+"GetMembers", members), IsNil)
+
+	// Followers only serve the stale reads.
+	s.svr.enableLeader(false)
+	defer s.svr.enableLeader(true)
+	c.Assert(s.svr.checkRequest(pdServicePrefix+"AllocID", valid), Equals, notLeaderError)
+	c.Assert(s.svr.staleCache.syncStores(s.svr.kv), IsNil)
+	s.svr.staleCache.setRegionsSynced(time.Now())
+	header := newRequestHeader(s.svr.clusterID)
+	header.MaxStalenessMs = 60000
+	c.Assert(s.svr.checkRequest(pdServicePrefix+"GetRegion", &pdpb.GetRegionRequest{Header: header}), IsNil)
+	c.Assert(s.svr.checkRequest(pdServicePrefix+"AskSplit", &pdpb.AskSplitRequest{Header: header}), Equals, notLeaderError)
+}
+
+func (s *testInterceptorSuite) TestValidate(c *C) {
+	// Unary calls.
+	_, err := s.grpcPDClient.AllocID(context.Background(), &pdpb.AllocIDRequest{
+		Header: newRequestHeader(s.svr.clusterID + 1),
+	})
+	c.Assert(grpc.Code(err), Equals, codes.FailedPrecondition)
+	resp, err := s.grpcPDClient.AllocID(context.Background(), &pdpb.AllocIDRequest{
+		Header: newRequestHeader(s.svr.clusterID),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetId(), Greater, uint64(0))
+
+	// Each request of the streams is validated.
+	tsoClient, err := s.grpcPDClient.Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	c.Assert(tsoClient.Send(&pdpb.TsoRequest{Header: newRequestHeader(s.svr.clusterID), Count: 1}), IsNil)
+	_, err = tsoClient.Recv()
+	c.Assert(err, IsNil)
+	c.Assert(tsoClient.Send(&pdpb.TsoRequest{Header: newRequestHeader(s.svr.clusterID + 1), Count: 1}), IsNil)
+	_, err = tsoClient.Recv()
+	c.Assert(grpc.Code(err), Equals, codes.FailedPrecondition)
+}
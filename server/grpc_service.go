@@ -16,18 +16,20 @@ package server
 import (
 	"fmt"
 	"io"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 )
 
 // notLeaderError is returned when current server is not the leader and not possible to process request.
-// TODO: work as proxy.
+// The requests are forwarded to the leader by leaderForwarder, so it is only
+// returned when there is no leader or the request is forwarded already.
 var notLeaderError = grpc.Errorf(codes.Unavailable, "not leader")
 
 // GetMembers implements gRPC PDServer.
@@ -45,15 +47,38 @@ func (s *Server) GetMembers(context.Context, *pdpb.GetMembersRequest) (*pdpb.Get
 		return nil, grpc.Errorf(codes.Unknown, err.Error())
 	}
 
+	dcs, err := s.loadDCLocations()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+	for _, m := range members {
+		m.DcLocation = dcs[m.GetMemberId()]
+	}
+	leader.DcLocation = dcs[leader.GetMemberId()]
+	localLeaders, _, err := s.loadLocalTsoLeaders()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+
 	return &pdpb.GetMembersResponse{
-		Header:  s.header(),
-		Members: members,
-		Leader:  leader,
+		Header:          s.header(),
+		Members:         members,
+		Leader:          leader,
+		LocalTsoLeaders: localLeaders,
 	}, nil
 }
 
 // Tso implements gRPC PDServer.
 func (s *Server) Tso(stream pdpb.PD_TsoServer) error {
+	ctx := stream.Context()
+	client := s.getTsoClient(ctx)
+	// The clients of a proxy are limited by the follower.
+	limited := !s.isTsoProxy(ctx)
+	if limited {
+		s.tsoQuota.addStream(client)
+		defer s.tsoQuota.removeStream(client)
+	}
+
 	for {
 		request, err := stream.Recv()
 		if err == io.EOF {
@@ -62,11 +87,13 @@ func (s *Server) Tso(stream pdpb.PD_TsoServer) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		if err = s.validateRequest(request.GetHeader()); err != nil {
-			return errors.Trace(err)
-		}
 		count := request.GetCount()
-		ts, err := s.getRespTS(count)
+		if limited {
+			if err = s.tsoQuota.wait(ctx, client, count); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		ts, err := s.tsoBatcher.getTS(count)
 		if err != nil {
 			return grpc.Errorf(codes.Unknown, err.Error())
 		}
@@ -83,10 +110,6 @@ func (s *Server) Tso(stream pdpb.PD_TsoServer) error {
 
 // Bootstrap implements gRPC PDServer.
 func (s *Server) Bootstrap(ctx context.Context, request *pdpb.BootstrapRequest) (*pdpb.BootstrapResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	cluster := s.GetRaftCluster()
 	if cluster != nil {
 		err := &pdpb.Error{
@@ -108,10 +131,6 @@ func (s *Server) Bootstrap(ctx context.Context, request *pdpb.BootstrapRequest)
 
 // IsBootstrapped implements gRPC PDServer.
 func (s *Server) IsBootstrapped(ctx context.Context, request *pdpb.IsBootstrappedRequest) (*pdpb.IsBootstrappedResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	cluster := s.GetRaftCluster()
 	return &pdpb.IsBootstrappedResponse{
 		Header:       s.header(),
@@ -121,12 +140,12 @@ func (s *Server) IsBootstrapped(ctx context.Context, request *pdpb.IsBootstrappe
 
 // AllocID implements gRPC PDServer.
 func (s *Server) AllocID(ctx context.Context, request *pdpb.AllocIDRequest) (*pdpb.AllocIDResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+	count := request.GetCount()
+	if count == 0 {
+		count = 1
 	}
-
 	// We can use an allocator for all types ID allocation.
-	id, err := s.idAlloc.Alloc()
+	id, err := s.idAlloc.AllocBatch(uint64(count))
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unknown, err.Error())
 	}
@@ -134,13 +153,21 @@ func (s *Server) AllocID(ctx context.Context, request *pdpb.AllocIDRequest) (*pd
 	return &pdpb.AllocIDResponse{
 		Header: s.header(),
 		Id:     id,
+		Count:  count,
 	}, nil
 }
 
 // GetStore implements gRPC PDServer.
 func (s *Server) GetStore(ctx context.Context, request *pdpb.GetStoreRequest) (*pdpb.GetStoreResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+	if s.allowStaleRead(request.GetHeader()) {
+		store := s.staleCache.getStore(request.GetStoreId())
+		if store == nil {
+			return nil, grpc.Errorf(codes.Unknown, "invalid store ID %d, not found", request.GetStoreId())
+		}
+		return &pdpb.GetStoreResponse{
+			Header: s.header(),
+			Store:  store,
+		}, nil
 	}
 
 	cluster := s.GetRaftCluster()
@@ -158,6 +185,29 @@ func (s *Server) GetStore(ctx context.Context, request *pdpb.GetStoreRequest) (*
 	}, nil
 }
 
+// GetAllStores implements gRPC PDServer.
+func (s *Server) GetAllStores(ctx context.Context, request *pdpb.GetAllStoresRequest) (*pdpb.GetAllStoresResponse, error) {
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.GetAllStoresResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	stores := cluster.GetStores()
+	if request.GetExcludeTombstoneStores() {
+		kept := stores[:0]
+		for _, store := range stores {
+			if store.GetState() != metapb.StoreState_Tombstone {
+				kept = append(kept, store)
+			}
+		}
+		stores = kept
+	}
+	return &pdpb.GetAllStoresResponse{
+		Header: s.header(),
+		Stores: stores,
+	}, nil
+}
+
 // checkStore2 returns an error response if the store exists and is in tombstone state.
 // It returns nil if it can't get the store.
 // Copied from server/command.go
@@ -176,10 +226,6 @@ func checkStore2(cluster *RaftCluster, storeID uint64) *pdpb.Error {
 
 // PutStore implements gRPC PDServer.
 func (s *Server) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (*pdpb.PutStoreResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.PutStoreResponse{Header: s.notBootstrappedHeader()}, nil
@@ -205,10 +251,6 @@ func (s *Server) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (*
 
 // StoreHeartbeat implements gRPC PDServer.
 func (s *Server) StoreHeartbeat(ctx context.Context, request *pdpb.StoreHeartbeatRequest) (*pdpb.StoreHeartbeatResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	if request.GetStats() == nil {
 		return nil, errors.Errorf("invalid store heartbeat command, but %v", request)
 	}
@@ -225,29 +267,42 @@ func (s *Server) StoreHeartbeat(ctx context.Context, request *pdpb.StoreHeartbea
 
 	err := cluster.cachedCluster.handleStoreHeartbeat(request.Stats)
 	if err != nil {
+		storeHeartbeatCounter.WithLabelValues("err").Inc()
 		return nil, grpc.Errorf(codes.Unknown, err.Error())
 	}
+	storeHeartbeatCounter.WithLabelValues("processed").Inc()
 
 	return &pdpb.StoreHeartbeatResponse{
-		Header: s.header(),
+		Header:                s.header(),
+		HeartbeatIntervalSecs: cluster.storeHeartbeatInterval(request.GetStats().GetStoreId()),
 	}, nil
 }
 
 // RegionHeartbeat implements gRPC PDServer.
 func (s *Server) RegionHeartbeat(server pdpb.PD_RegionHeartbeatServer) error {
+	queue := newHeartbeatQueue()
+	go func() {
+		for {
+			request, err := server.Recv()
+			if err != nil {
+				queue.close(err)
+				return
+			}
+			if !queue.push(request) {
+				regionHeartbeatCounter.WithLabelValues("collapsed").Inc()
+			}
+		}
+	}()
+
 	for {
-		request, err := server.Recv()
+		request, err := queue.pop()
 		if err == io.EOF {
 			return nil
 		}
 		if err != nil {
 			return errors.Trace(err)
 		}
-
-		if err = s.validateRequest(request.GetHeader()); err != nil {
-			// TODO: How to close this stream?
-			return errors.Trace(err)
-		}
+		regionHeartbeatCounter.WithLabelValues("processed").Inc()
 
 		cluster := s.GetRaftCluster()
 		if cluster == nil {
@@ -263,6 +318,10 @@ func (s *Server) RegionHeartbeat(server pdpb.PD_RegionHeartbeatServer) error {
 		region.DownPeers = request.GetDownPeers()
 		region.PendingPeers = request.GetPendingPeers()
 		region.WrittenBytes = request.GetBytesWritten()
+		region.ReadBytes = request.GetBytesRead()
+		region.ReadKeys = request.GetKeysRead()
+		region.ApproximateSize = request.GetApproximateSize()
+		region.ApproximateKeys = request.GetApproximateKeys()
 		if region.GetId() == 0 {
 			msg := fmt.Sprintf("invalid request region, %v", request)
 			err = sendErrorRegionHeartbeatResponse(server, s.clusterID, pdpb.ErrorType_UNKNOWN, msg)
@@ -300,9 +359,16 @@ func (s *Server) RegionHeartbeat(server pdpb.PD_RegionHeartbeatServer) error {
 			}
 			continue
 		}
-		if resp == nil {
+		// Every response carries the interval, a response is sent even
+		// without operator if the interval is changed.
+		interval, changed := cluster.regionHeartbeatInterval(region)
+		if resp == nil && !changed {
 			continue
 		}
+		if resp == nil {
+			resp = &pdpb.RegionHeartbeatResponse{}
+		}
+		resp.HeartbeatIntervalSecs = interval
 
 		resp.Header = s.header()
 		resp.RegionId = request.Region.Id
@@ -317,47 +383,197 @@ func (s *Server) RegionHeartbeat(server pdpb.PD_RegionHeartbeatServer) error {
 
 // GetRegion implements gRPC PDServer.
 func (s *Server) GetRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+	if s.allowStaleRead(request.GetHeader()) {
+		return s.newGetRegionResponse(s.staleCache.searchRegion(request.GetRegionKey())), nil
 	}
 
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.GetRegionResponse{Header: s.notBootstrappedHeader()}, nil
 	}
-	region, leader := cluster.GetRegionByKey(request.GetRegionKey())
+	return s.newGetRegionResponse(cluster.GetRegionInfoByKey(request.GetRegionKey())), nil
+}
+
+// newGetRegionResponse returns the region with its leader and unhealthy
+// peers, so clients can avoid routing requests to them.
+func (s *Server) newGetRegionResponse(region *RegionInfo) *pdpb.GetRegionResponse {
+	if region == nil {
+		return &pdpb.GetRegionResponse{Header: s.header()}
+	}
 	return &pdpb.GetRegionResponse{
-		Header: s.header(),
-		Region: region,
-		Leader: leader,
-	}, nil
+		Header:       s.header(),
+		Region:       region.Region,
+		Leader:       region.Leader,
+		DownPeers:    region.DownPeers,
+		PendingPeers: region.PendingPeers,
+	}
+}
+
+// GetPrevRegion implements gRPC PDServer.
+func (s *Server) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	if s.allowStaleRead(request.GetHeader()) {
+		return s.newGetRegionResponse(s.staleCache.searchPrevRegion(request.GetRegionKey())), nil
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.GetRegionResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	return s.newGetRegionResponse(cluster.GetPrevRegionInfoByKey(request.GetRegionKey())), nil
 }
 
 // GetRegionByID implements gRPC PDServer.
 func (s *Server) GetRegionByID(ctx context.Context, request *pdpb.GetRegionByIDRequest) (*pdpb.GetRegionResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+	if s.allowStaleRead(request.GetHeader()) {
+		return s.newGetRegionResponse(s.staleCache.getRegion(request.GetRegionId())), nil
 	}
 
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.GetRegionResponse{Header: s.notBootstrappedHeader()}, nil
 	}
-	id := request.GetRegionId()
-	region, leader := cluster.GetRegionByID(id)
-	return &pdpb.GetRegionResponse{
-		Header: s.header(),
-		Region: region,
-		Leader: leader,
+	return s.newGetRegionResponse(cluster.GetRegionInfoByID(request.GetRegionId())), nil
+}
+
+// ScanRegions implements gRPC PDServer.
+func (s *Server) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsRequest) (*pdpb.ScanRegionsResponse, error) {
+	startKey, endKey, limit := request.GetStartKey(), request.GetEndKey(), int(request.GetLimit())
+	if s.allowStaleRead(request.GetHeader()) {
+		return s.newScanRegionsResponse(s.staleCache.scanRegions(startKey, endKey, limit)), nil
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.ScanRegionsResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	return s.newScanRegionsResponse(cluster.ScanRegions(startKey, endKey, limit)), nil
+}
+
+// newScanRegionsResponse returns the regions with their leaders, the leader
+// is an empty peer if it's unknown.
+func (s *Server) newScanRegionsResponse(regions []*RegionInfo) *pdpb.ScanRegionsResponse {
+	resp := &pdpb.ScanRegionsResponse{Header: s.header()}
+	for _, region := range regions {
+		leader := region.Leader
+		if leader == nil {
+			leader = &metapb.Peer{}
+		}
+		resp.RegionMetas = append(resp.RegionMetas, region.Region)
+		resp.Leaders = append(resp.Leaders, leader)
+	}
+	return resp
+}
+
+// ScatterRegion implements gRPC PDServer.
+func (s *Server) ScatterRegion(ctx context.Context, request *pdpb.ScatterRegionRequest) (*pdpb.ScatterRegionResponse, error) {
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.ScatterRegionResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	region := cluster.GetRegionInfoByID(request.GetRegionId())
+	if region == nil {
+		if request.GetRegion() == nil {
+			return nil, grpc.Errorf(codes.Unknown, "invalid region ID %d, not found", request.GetRegionId())
+		}
+		region = newRegionInfo(request.GetRegion(), request.GetLeader())
+	}
+	if err := cluster.coordinator.scatterRegion(region); err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+	return &pdpb.ScatterRegionResponse{Header: s.header()}, nil
+}
+
+var operatorStatus = map[OperatorState]pdpb.OperatorStatus{
+	OperatorWaiting:  pdpb.OperatorStatus_WAITING,
+	OperatorRunning:  pdpb.OperatorStatus_RUNNING,
+	OperatorFinished: pdpb.OperatorStatus_FINISHED,
+	OperatorTimeOut:  pdpb.OperatorStatus_TIMEOUT,
+	OperatorReplaced: pdpb.OperatorStatus_REPLACED,
+}
+
+// GetOperator implements gRPC PDServer.
+func (s *Server) GetOperator(ctx context.Context, request *pdpb.GetOperatorRequest) (*pdpb.GetOperatorResponse, error) {
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.GetOperatorResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	resp := &pdpb.GetOperatorResponse{
+		Header:   s.header(),
+		RegionId: request.GetRegionId(),
+	}
+	op := cluster.coordinator.getLatestOperator(request.GetRegionId())
+	if op == nil {
+		return resp, nil
+	}
+	resp.Status = operatorStatus[op.GetState()]
+	resp.Kind = op.GetResourceKind().String()
+	for _, step := range getOperatorSteps(op) {
+		resp.Steps = append(resp.Steps, fmt.Sprint(step))
+		if step.GetState() == OperatorFinished {
+			resp.FinishedSteps++
+		}
+	}
+	return resp, nil
+}
+
+// GetGCSafePoint implements gRPC PDServer.
+func (s *Server) GetGCSafePoint(ctx context.Context, request *pdpb.GetGCSafePointRequest) (*pdpb.GetGCSafePointResponse, error) {
+	if s.GetRaftCluster() == nil {
+		return &pdpb.GetGCSafePointResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	safePoint, err := s.kv.loadGCSafePoint()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+	return &pdpb.GetGCSafePointResponse{
+		Header:    s.header(),
+		SafePoint: safePoint,
 	}, nil
 }
 
-// AskSplit implements gRPC PDServer.
-func (s *Server) AskSplit(ctx context.Context, request *pdpb.AskSplitRequest) (*pdpb.AskSplitResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+// UpdateGCSafePoint implements gRPC PDServer.
+func (s *Server) UpdateGCSafePoint(ctx context.Context, request *pdpb.UpdateGCSafePointRequest) (*pdpb.UpdateGCSafePointResponse, error) {
+	if s.GetRaftCluster() == nil {
+		return &pdpb.UpdateGCSafePointResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	safePoint, err := s.updateGCSafePoint(request.GetSafePoint())
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+	return &pdpb.UpdateGCSafePointResponse{
+		Header:       s.header(),
+		NewSafePoint: safePoint,
+	}, nil
+}
+
+// UpdateServiceGCSafePoint implements gRPC PDServer.
+func (s *Server) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb.UpdateServiceGCSafePointRequest) (*pdpb.UpdateServiceGCSafePointResponse, error) {
+	if s.GetRaftCluster() == nil {
+		return &pdpb.UpdateServiceGCSafePointResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	if len(request.GetServiceId()) == 0 {
+		return nil, grpc.Errorf(codes.Unknown, "missing service id")
+	}
+
+	min, err := s.updateServiceSafePoint(string(request.GetServiceId()), request.GetTtl(), request.GetSafePoint())
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+	resp := &pdpb.UpdateServiceGCSafePointResponse{Header: s.header()}
+	if min != nil {
+		resp.ServiceId = []byte(min.ServiceID)
+		resp.Ttl = min.ExpiredAt - time.Now().Unix()
+		resp.MinSafePoint = min.SafePoint
 	}
+	return resp, nil
+}
 
+// AskSplit implements gRPC PDServer.
+func (s *Server) AskSplit(ctx context.Context, request *pdpb.AskSplitRequest) (*pdpb.AskSplitResponse, error) {
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.AskSplitResponse{Header: s.notBootstrappedHeader()}, nil
@@ -382,10 +598,6 @@ func (s *Server) AskSplit(ctx context.Context, request *pdpb.AskSplitRequest) (*
 
 // ReportSplit implements gRPC PDServer.
 func (s *Server) ReportSplit(ctx context.Context, request *pdpb.ReportSplitRequest) (*pdpb.ReportSplitResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.ReportSplitResponse{Header: s.notBootstrappedHeader()}, nil
@@ -400,12 +612,44 @@ func (s *Server) ReportSplit(ctx context.Context, request *pdpb.ReportSplitReque
 	}, nil
 }
 
-// GetClusterConfig implements gRPC PDServer.
-func (s *Server) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterConfigRequest) (*pdpb.GetClusterConfigResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
+// AskBatchSplit implements gRPC PDServer.
+func (s *Server) AskBatchSplit(ctx context.Context, request *pdpb.AskBatchSplitRequest) (*pdpb.AskBatchSplitResponse, error) {
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.AskBatchSplitResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	if request.GetRegion().GetStartKey() == nil {
+		return nil, errors.New("missing region start key for split")
+	}
+	split, err := cluster.handleAskBatchSplit(request)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+
+	return &pdpb.AskBatchSplitResponse{
+		Header: s.header(),
+		Ids:    split.Ids,
+	}, nil
+}
+
+// ReportBatchSplit implements gRPC PDServer.
+func (s *Server) ReportBatchSplit(ctx context.Context, request *pdpb.ReportBatchSplitRequest) (*pdpb.ReportBatchSplitResponse, error) {
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &pdpb.ReportBatchSplitResponse{Header: s.notBootstrappedHeader()}, nil
 	}
+	_, err := cluster.handleReportBatchSplit(request)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+
+	return &pdpb.ReportBatchSplitResponse{
+		Header: s.header(),
+	}, nil
+}
 
+// GetClusterConfig implements gRPC PDServer.
+func (s *Server) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterConfigRequest) (*pdpb.GetClusterConfigResponse, error) {
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.GetClusterConfigResponse{Header: s.notBootstrappedHeader()}, nil
@@ -418,10 +662,6 @@ func (s *Server) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterC
 
 // PutClusterConfig implements gRPC PDServer.
 func (s *Server) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterConfigRequest) (*pdpb.PutClusterConfigResponse, error) {
-	if err := s.validateRequest(request.GetHeader()); err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
 		return &pdpb.PutClusterConfigResponse{Header: s.notBootstrappedHeader()}, nil
@@ -438,8 +678,55 @@ func (s *Server) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterC
 	}, nil
 }
 
-// validateRequest checks if Server is leader and clusterID is matched.
-// TODO: Call it in gRPC intercepter.
+// GetClusterStatus implements gRPC PDServer.
+func (s *Server) GetClusterStatus(ctx context.Context, request *pdpb.GetClusterStatusRequest) (*pdpb.GetClusterStatusResponse, error) {
+	if s.GetRaftCluster() == nil {
+		return &pdpb.GetClusterStatusResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	status, err := s.GetRaftClusterStatus()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unknown, err.Error())
+	}
+
+	resp := &pdpb.GetClusterStatusResponse{
+		Header:        s.header(),
+		IsInitialized: status.IsInitialized,
+	}
+	if !status.RaftBootstrapTime.IsZero() {
+		resp.RaftBootstrapTime = status.RaftBootstrapTime.UnixNano()
+	}
+	if rs := status.ReplicationStatus; rs != nil {
+		resp.ReplicationStatus = &pdpb.ReplicationStatus{
+			MaxReplicas:            uint64(rs.MaxReplicas),
+			UnderReplicatedRegions: uint64(rs.UnderReplicatedRegions),
+			OverReplicatedRegions:  uint64(rs.OverReplicatedRegions),
+			DownPeerRegions:        uint64(rs.DownPeerRegions),
+			PendingPeerRegions:     uint64(rs.PendingPeerRegions),
+		}
+	}
+	for _, state := range []metapb.StoreState{metapb.StoreState_Up, metapb.StoreState_Offline, metapb.StoreState_Tombstone} {
+		resp.StoreCounts = append(resp.StoreCounts, &pdpb.StoreStateCount{
+			State: pdpb.StoreState(state),
+			Count: uint64(status.StoreCounts[state.String()]),
+		})
+	}
+	return resp, nil
+}
+
+// SyncRegions implements gRPC PDServer.
+func (s *Server) SyncRegions(stream pdpb.PD_SyncRegionsServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.regionSyncer == nil {
+		return grpc.Errorf(codes.Unimplemented, "region syncer is not supported by cluster %d", s.clusterID)
+	}
+	return s.regionSyncer.serve(stream, request)
+}
+
+// validateRequest checks if Server is leader and clusterID is matched, it is
+// called by the interceptors.
 func (s *Server) validateRequest(header *pdpb.RequestHeader) error {
 	if !s.IsLeader() {
 		return notLeaderError
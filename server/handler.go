@@ -13,7 +13,14 @@
 
 package server
 
-import "github.com/juju/errors"
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
 
 var (
 	errNotBootstrapped  = errors.New("TiKV cluster not bootstrapped, please start TiKV first")
@@ -47,18 +54,168 @@ func (h *Handler) GetSchedulers() ([]string, error) {
 	return c.getSchedulers(), nil
 }
 
-// GetHotWriteRegions gets all hot regions status
+// GetHotWriteRegions gets the write hot regions status of the stores from the
+// hot cache, whether the hot region scheduler is running or not.
 func (h *Handler) GetHotWriteRegions() *StoreHotRegionInfos {
 	c, err := h.getCoordinator()
 	if err != nil {
 		return nil
 	}
-	return c.getHotWriteRegions()
+	asPeer, asLeader := calcHotWriteRegionStats(c.cluster)
+	return &StoreHotRegionInfos{
+		AsPeer:   asPeer,
+		AsLeader: asLeader,
+	}
+}
+
+// GetHotReadRegions gets the read hot regions status of the stores from the
+// hot cache.
+func (h *Handler) GetHotReadRegions() map[uint64]*HotRegionsStat {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil
+	}
+	return calcHotReadRegionStats(c.cluster)
+}
+
+// GetHotStores gets the write flow reported by the stores and the read flow
+// of the hot regions led by them.
+func (h *Handler) GetHotStores() *StoreHotStats {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil
+	}
+	stats := c.cluster.getStoresWriteStat()
+	for storeID, stat := range calcHotReadRegionStats(c.cluster) {
+		stats.HotBytesRead[storeID] = stat.ReadBytes
+	}
+	return stats
+}
+
+// GetHotRegionHistory gets the hot region snapshots saved in [start, end).
+func (h *Handler) GetHotRegionHistory(start, end time.Time, storeID uint64) ([]*HotRegionHistory, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	return cluster.GetHotRegionHistory(start, end, storeID)
+}
+
+// GetHeatmap gets the flow heatmap in [start, end) with at most n key ranges,
+// n defaults to 64 if it is 0.
+func (h *Handler) GetHeatmap(start, end time.Time, n int) (*Heatmap, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	if n == 0 {
+		n = defaultHeatmapBuckets
+	}
+	return cluster.GetHeatmap(start, end, n), nil
+}
+
+// GetStoreDrains returns the drain status of the offline and blocked stores.
+func (h *Handler) GetStoreDrains() ([]*StoreDrainStatus, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	return cluster.GetStoreDrains(), nil
+}
+
+// GetPriorityRanges returns all the priority key ranges.
+func (h *Handler) GetPriorityRanges() ([]*PriorityKeyRange, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	return cluster.GetPriorityRanges(), nil
+}
+
+// PutPriorityRange adds or updates a priority key range.
+func (h *Handler) PutPriorityRange(keyRange *PriorityKeyRange) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.PutPriorityRange(keyRange))
+}
+
+// RemovePriorityRange removes a priority key range.
+func (h *Handler) RemovePriorityRange(id string) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.RemovePriorityRange(id))
+}
+
+// GetPlacements returns all the key range placements.
+func (h *Handler) GetPlacements() ([]*KeyRangePlacement, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	return cluster.GetPlacements(), nil
+}
+
+// PutPlacement adds or updates a key range placement.
+func (h *Handler) PutPlacement(placement *KeyRangePlacement) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.PutPlacement(placement))
+}
+
+// RemovePlacement removes a key range placement.
+func (h *Handler) RemovePlacement(id string) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.RemovePlacement(id))
+}
+
+// GetNamespaces returns all the namespaces.
+func (h *Handler) GetNamespaces() ([]*Namespace, error) {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return nil, errors.Trace(errNotBootstrapped)
+	}
+	return cluster.GetNamespaces(), nil
+}
+
+// PutNamespace adds or updates a namespace.
+func (h *Handler) PutNamespace(namespace *Namespace) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.PutNamespace(namespace))
+}
+
+// RemoveNamespace removes a namespace.
+func (h *Handler) RemoveNamespace(name string) error {
+	cluster := h.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(errNotBootstrapped)
+	}
+	return errors.Trace(cluster.RemoveNamespace(name))
+}
+
+// GetTsoClientStats returns the timestamps consumed by each client.
+func (h *Handler) GetTsoClientStats() map[string]*TsoClientStat {
+	return h.s.tsoQuota.getStats()
 }
 
-// GetHotWriteStores gets all hot write stores status
-func (h *Handler) GetHotWriteStores() map[uint64]uint64 {
-	return h.s.cluster.cachedCluster.getStoresWriteStat()
+// GetTS allocates a timestamp, it is only available on the leader.
+func (h *Handler) GetTS() (pdpb.Timestamp, error) {
+	if !h.s.IsLeader() {
+		return pdpb.Timestamp{}, errors.Trace(errNotLeader)
+	}
+	ts, err := h.s.tsoBatcher.getTS(1)
+	return ts, errors.Trace(err)
 }
 
 // AddScheduler adds a scheduler.
@@ -79,6 +236,62 @@ func (h *Handler) RemoveScheduler(name string) error {
 	return errors.Trace(c.removeScheduler(name))
 }
 
+// PauseScheduler pauses a scheduler for the duration, 0 pauses it until it's
+// resumed.
+func (h *Handler) PauseScheduler(name string, d time.Duration) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.pauseScheduler(name, d))
+}
+
+// ResumeScheduler resumes a paused scheduler.
+func (h *Handler) ResumeScheduler(name string) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.resumeScheduler(name))
+}
+
+// GetSchedulerStatuses returns the statuses of the schedulers.
+func (h *Handler) GetSchedulerStatuses() ([]*SchedulerStatus, error) {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.getSchedulerStatuses(), nil
+}
+
+// PauseSchedule halts all the schedulers and checkers for the duration, 0
+// halts them until they are resumed.
+func (h *Handler) PauseSchedule(d time.Duration) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.pauseSchedule(d))
+}
+
+// ResumeSchedule resumes the scheduling paused by PauseSchedule.
+func (h *Handler) ResumeSchedule() error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.resumeSchedule())
+}
+
+// GetSchedulePauseStatus returns whether the scheduling is paused.
+func (h *Handler) GetSchedulePauseStatus() (*SchedulePauseStatus, error) {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.getSchedulePauseStatus(), nil
+}
+
 // AddBalanceLeaderScheduler adds a balance-leader-scheduler.
 func (h *Handler) AddBalanceLeaderScheduler() error {
 	return h.AddScheduler(newBalanceLeaderScheduler(h.opt))
@@ -99,11 +312,34 @@ func (h *Handler) AddShuffleLeaderScheduler() error {
 	return h.AddScheduler(newShuffleLeaderScheduler(h.opt))
 }
 
+// AddBalanceHotRegionScheduler adds a balance-hot-region-scheduler.
+func (h *Handler) AddBalanceHotRegionScheduler() error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.addScheduler(newBalanceHotRegionScheduler(h.opt), minSlowScheduleInterval))
+}
+
+// AddBalanceHotReadRegionScheduler adds a balance-hot-read-region-scheduler.
+func (h *Handler) AddBalanceHotReadRegionScheduler() error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.addScheduler(newBalanceHotReadRegionScheduler(h.opt), minSlowScheduleInterval))
+}
+
 // AddShuffleRegionScheduler adds a shuffle-region-scheduler.
 func (h *Handler) AddShuffleRegionScheduler() error {
 	return h.AddScheduler(newShuffleRegionScheduler(h.opt))
 }
 
+// AddScatterRangeScheduler adds a scatter-range-scheduler for the key range.
+func (h *Handler) AddScatterRangeScheduler(startKey, endKey []byte, name string) error {
+	return h.AddScheduler(newScatterRangeScheduler(h.opt, startKey, endKey, name))
+}
+
 // GetOperator returns the region operator.
 func (h *Handler) GetOperator(regionID uint64) (Operator, error) {
 	c, err := h.getCoordinator()
@@ -192,6 +428,34 @@ func (h *Handler) GetHistoryOperatorsOfKind(kind ResourceKind) ([]Operator, erro
 	return c.getHistoriesOfKind(kind), nil
 }
 
+// GetOperatorRecords returns the records of the operators which ended in the
+// time range, 0 regionID means all the regions.
+func (h *Handler) GetOperatorRecords(regionID uint64, start, end time.Time) ([]*OperatorRecord, error) {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.getOperatorRecords(regionID, start, end), nil
+}
+
+// DiagnoseRegion explains why the region is or isn't scheduled.
+func (h *Handler) DiagnoseRegion(regionID uint64) (*RegionDiagnosis, error) {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.diagnoseRegion(regionID)
+}
+
+// DiagnoseStore returns the filters which keep the store from being scheduled.
+func (h *Handler) DiagnoseStore(storeID uint64) (*StoreDiagnosis, error) {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.diagnoseStore(storeID)
+}
+
 // AddTransferLeaderOperator adds an operator to transfer leader to the store.
 func (h *Handler) AddTransferLeaderOperator(regionID uint64, storeID uint64) error {
 	c, err := h.getCoordinator()
@@ -207,6 +471,9 @@ func (h *Handler) AddTransferLeaderOperator(regionID uint64, storeID uint64) err
 	if newLeader == nil {
 		return errors.Errorf("region has no peer in store %v", storeID)
 	}
+	if newLeader.GetRole() != metapb.PeerRole_Voter {
+		return errors.Errorf("the %v peer in store %v can't be the leader", newLeader.GetRole(), storeID)
+	}
 
 	op := newTransferLeaderOperator(regionID, region.Leader, newLeader)
 	c.addOperator(newAdminOperator(region, op))
@@ -284,3 +551,168 @@ func (h *Handler) AddTransferPeerOperator(regionID uint64, fromStoreID, toStoreI
 	c.addOperator(newAdminOperator(region, addPeer, removePeer))
 	return nil
 }
+
+// ChangePeerRole adds an operator to change the role of the region peer in
+// the store. The leader should be transferred before it is demoted.
+func (h *Handler) ChangePeerRole(regionID uint64, storeID uint64, role metapb.PeerRole) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return errRegionNotFound(regionID)
+	}
+	peer := region.GetStorePeer(storeID)
+	if peer == nil {
+		return errors.Errorf("region has no peer in store %v", storeID)
+	}
+	if peer.GetRole() == role {
+		return nil
+	}
+	if role != metapb.PeerRole_Voter && peer.GetId() == region.Leader.GetId() {
+		return errors.Errorf("peer %v is the leader, transfer the leader first", peer.GetId())
+	}
+
+	op := newChangePeerRoleOperator(regionID, peer, role)
+	c.addOperator(newAdminOperator(region, op))
+	return nil
+}
+
+// AddAddPeerOperator adds an operator to add a peer of the region in the
+// store.
+func (h *Handler) AddAddPeerOperator(regionID uint64, storeID uint64) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return errRegionNotFound(regionID)
+	}
+	if region.GetStorePeer(storeID) != nil {
+		return errors.Errorf("region already has a peer in store %v", storeID)
+	}
+	if c.cluster.getStore(storeID) == nil {
+		return errStoreNotFound(storeID)
+	}
+	peer, err := c.cluster.allocPeer(storeID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	op := newAddPeerOperator(regionID, peer)
+	c.addOperator(newAdminOperator(region, op))
+	return nil
+}
+
+// AddRemovePeerOperator adds an operator to remove the region peer in the
+// store. The leader is transferred to a follower first if the peer is the
+// leader.
+func (h *Handler) AddRemovePeerOperator(regionID uint64, storeID uint64) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return errRegionNotFound(regionID)
+	}
+	peer := region.GetStorePeer(storeID)
+	if peer == nil {
+		return errors.Errorf("region has no peer in store %v", storeID)
+	}
+
+	var ops []Operator
+	if peer.GetId() == region.Leader.GetId() {
+		follower := region.GetFollower()
+		if follower == nil {
+			return errors.Errorf("peer %v is the leader and there is no follower to transfer the leader to", peer.GetId())
+		}
+		ops = append(ops, newTransferLeaderOperator(regionID, region.Leader, follower))
+	}
+	ops = append(ops, newRemovePeerOperator(regionID, peer))
+	c.addOperator(newAdminOperator(region, ops...))
+	return nil
+}
+
+// AddSplitRegionOperator adds an operator to split the region by the policy,
+// or at the keys if they are specified.
+func (h *Handler) AddSplitRegionOperator(regionID uint64, policy pdpb.CheckPolicy, keys [][]byte) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return errRegionNotFound(regionID)
+	}
+	if len(keys) > 0 {
+		policy = pdpb.CheckPolicy_USEKEY
+	}
+	if policy == pdpb.CheckPolicy_USEKEY {
+		if len(keys) == 0 {
+			return errors.New("missing keys to split region at")
+		}
+		start, end := region.GetStartKey(), region.GetEndKey()
+		for _, key := range keys {
+			if bytes.Compare(key, start) <= 0 || (len(end) > 0 && bytes.Compare(key, end) >= 0) {
+				return errors.Errorf("key %q is not inside region %v", key, regionID)
+			}
+		}
+	}
+
+	op := newSplitRegionOperator(region.Region, policy, keys)
+	c.addOperator(newAdminOperator(region, op))
+	return nil
+}
+
+// AddMergeRegionOperator adds the operators to merge the region into the
+// adjacent target region. Unlike the other admin operators, they finish once
+// the regions are merged, since the source region doesn't heartbeat any more.
+func (h *Handler) AddMergeRegionOperator(regionID uint64, targetID uint64) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	source := c.cluster.getRegion(regionID)
+	if source == nil {
+		return errRegionNotFound(regionID)
+	}
+	target := c.cluster.getRegion(targetID)
+	if target == nil {
+		return errRegionNotFound(targetID)
+	}
+	if !c.merger.isHealthy(source) || !c.merger.allowMerge(source, target) {
+		return errors.Errorf("region %v can't be merged into region %v", regionID, targetID)
+	}
+
+	ops := c.merger.newMergeOperators(source, target, AdminKind)
+	if ops == nil {
+		return errors.Errorf("failed to create operators to merge region %v into region %v", regionID, targetID)
+	}
+	if !c.addMergeOperators(ops) {
+		return errors.Errorf("failed to add operators to merge region %v into region %v", regionID, targetID)
+	}
+	return nil
+}
+
+// AddScatterRegionOperator adds an operator to scatter the region, as
+// ScatterRegion of gRPC does.
+func (h *Handler) AddScatterRegionOperator(regionID uint64) error {
+	c, err := h.getCoordinator()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	region := c.cluster.getRegion(regionID)
+	if region == nil {
+		return errRegionNotFound(regionID)
+	}
+	return errors.Trace(c.scatterRegion(region))
+}
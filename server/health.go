@@ -0,0 +1,123 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// pdService is the health checking service name of the PD gRPC service.
+	pdService = "pdpb.PD"
+	// pdLeaderService is the health checking service name which is serving
+	// only on the leader.
+	pdLeaderService = "pdpb.PD.leader"
+)
+
+// healthServer implements the standard gRPC health checking protocol. The
+// server and the PD service are serving when the server is started, the
+// leader service is serving only when the server is the leader.
+type healthServer struct {
+	s *Server
+}
+
+func newHealthServer(s *Server) *healthServer {
+	return &healthServer{s: s}
+}
+
+// Check implements gRPC HealthServer.
+func (h *healthServer) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	var serving bool
+	switch request.GetService() {
+	case "", pdService:
+		serving = !h.s.isClosed()
+	case pdLeaderService:
+		serving = !h.s.isClosed() && h.s.IsLeader()
+	default:
+		return nil, grpc.Errorf(codes.NotFound, "unknown service %s", request.GetService())
+	}
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	return &healthpb.HealthCheckResponse{Status: status}, nil
+}
+
+// MemberHealth is the health of a member probed by the serving member.
+type MemberHealth struct {
+	Name       string   `json:"name"`
+	MemberID   uint64   `json:"member_id"`
+	ClientUrls []string `json:"client_urls"`
+	PeerUrls   []string `json:"peer_urls"`
+	Reachable  bool     `json:"reachable"`
+	IsLeader   bool     `json:"is_leader"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// ClusterHealth is the health of the cluster seen by the serving member.
+type ClusterHealth struct {
+	// Quorum is true if the etcd cluster has a quorum to serve linearizable
+	// reads, the leader can't be elected without it.
+	Quorum  bool            `json:"quorum"`
+	Leader  string          `json:"leader"`
+	Members []*MemberHealth `json:"members"`
+}
+
+// GetClusterHealth probes the members by reading their clocks, which are
+// served by the members themselves without redirecting to the leader.
+func (s *Server) GetClusterHealth() (*ClusterHealth, error) {
+	members, err := GetMembers(s.client)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	health := &ClusterHealth{}
+	leader, err := getLeader(s.client, s.getLeaderPath())
+	health.Quorum = err == nil
+	if leader != nil {
+		health.Leader = leader.GetName()
+	}
+
+	var wg sync.WaitGroup
+	for _, m := range members {
+		member := &MemberHealth{
+			Name:       m.GetName(),
+			MemberID:   m.GetMemberId(),
+			ClientUrls: m.GetClientUrls(),
+			PeerUrls:   m.GetPeerUrls(),
+			IsLeader:   m.GetMemberId() == leader.GetMemberId(),
+		}
+		health.Members = append(health.Members, member)
+
+		wg.Add(1)
+		go func(m *pdpb.Member) {
+			defer wg.Done()
+			if err := s.probeMember(m); err != nil {
+				member.Error = err.Error()
+				return
+			}
+			member.Reachable = true
+		}(m)
+	}
+	wg.Wait()
+	return health, nil
+}
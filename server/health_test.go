@@ -0,0 +1,66 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var _ = Suite(&testHealthSuite{})
+
+type testHealthSuite struct{}
+
+func (s *testHealthSuite) checkHealth(c *C, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	c.Assert(err, IsNil)
+	return resp.GetStatus()
+}
+
+func (s *testHealthSuite) TestHealthCheck(c *C) {
+	svr, cleanup := mustRunTestServer(c)
+	defer cleanup()
+
+	conn, err := grpc.Dial(svr.GetAddr(), grpc.WithInsecure(), grpc.WithDialer(unixGrpcDialer))
+	c.Assert(err, IsNil)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", pdService, pdLeaderService} {
+		c.Assert(s.checkHealth(c, client, service), Equals, healthpb.HealthCheckResponse_SERVING)
+	}
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	c.Assert(err, NotNil)
+}
+
+func (s *testHealthSuite) TestFollowerHealth(c *C) {
+	// The server is started but not campaigning the leader.
+	svr, cleanup := newTestServer(c)
+	defer cleanup()
+
+	h := newHealthServer(svr)
+	resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pdService})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetStatus(), Equals, healthpb.HealthCheckResponse_SERVING)
+	resp, err = h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pdLeaderService})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetStatus(), Equals, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	svr.Close()
+	resp, err = h.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetStatus(), Equals, healthpb.HealthCheckResponse_NOT_SERVING)
+}
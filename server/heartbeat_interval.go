@@ -0,0 +1,136 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// regionIdleHeartbeats is the count of the successive idle heartbeats after
+// which the region is asked to heartbeat less often.
+const regionIdleHeartbeats = 3
+
+// regionActivity is what is known about a region from its last heartbeat.
+type regionActivity struct {
+	confVer    uint64
+	version    uint64
+	leaderID   uint64
+	idle       int
+	lengthened bool
+}
+
+// heartbeatIntervals decides the intervals which the stores and the leaders
+// of regions should send heartbeats at. Idle ones are asked to heartbeat at
+// the max intervals, others use their own config.
+type heartbeatIntervals struct {
+	sync.Mutex
+	opt     *scheduleOption
+	regions map[uint64]*regionActivity
+}
+
+func newHeartbeatIntervals(opt *scheduleOption) *heartbeatIntervals {
+	return &heartbeatIntervals{
+		opt:     opt,
+		regions: make(map[uint64]*regionActivity),
+	}
+}
+
+func isRegionActive(region *RegionInfo, a *regionActivity, hasOperator bool) bool {
+	return hasOperator ||
+		region.WrittenBytes > 0 || region.ReadBytes > 0 ||
+		len(region.DownPeers) > 0 || len(region.PendingPeers) > 0 ||
+		region.GetRegionEpoch().GetConfVer() != a.confVer ||
+		region.GetRegionEpoch().GetVersion() != a.version ||
+		region.Leader.GetId() != a.leaderID
+}
+
+// regionInterval returns the interval of the region's heartbeats, and whether
+// it is changed since the last heartbeat. 0 means the configured one. The
+// first heartbeat seen is always regarded as changed, the region may be
+// slowed down by the previous PD leader.
+func (h *heartbeatIntervals) regionInterval(region *RegionInfo, hasOperator bool) (time.Duration, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	a, ok := h.regions[region.GetId()]
+	if !ok {
+		a = &regionActivity{}
+		h.regions[region.GetId()] = a
+	}
+	if isRegionActive(region, a, hasOperator) {
+		a.idle = 0
+	} else {
+		a.idle++
+	}
+	a.confVer = region.GetRegionEpoch().GetConfVer()
+	a.version = region.GetRegionEpoch().GetVersion()
+	a.leaderID = region.Leader.GetId()
+
+	max := h.opt.GetMaxRegionHeartbeatInterval()
+	lengthened := max != 0 && a.idle >= regionIdleHeartbeats
+	changed := !ok || lengthened != a.lengthened
+	a.lengthened = lengthened
+	if lengthened {
+		return max, changed
+	}
+	return 0, changed
+}
+
+// storeInterval returns the interval of the store's heartbeats, 0 means the
+// configured one.
+func (h *heartbeatIntervals) storeInterval(store *storeInfo) time.Duration {
+	if !store.isUp() || store.status.GetIsBusy() ||
+		store.snapshotCount() > 0 || store.status.GetApplyingSnapCount() > 0 {
+		return 0
+	}
+	return h.opt.GetMaxStoreHeartbeatInterval()
+}
+
+// gc forgets the regions which are removed from the cluster.
+func (h *heartbeatIntervals) gc(c *clusterInfo) {
+	h.Lock()
+	defer h.Unlock()
+	for regionID := range h.regions {
+		if c.getRegion(regionID) == nil {
+			delete(h.regions, regionID)
+		}
+	}
+}
+
+func (c *RaftCluster) gcHeartbeatIntervals() {
+	c.heartbeatIntervals.gc(c.cachedCluster)
+}
+
+// regionHeartbeatInterval returns the interval in seconds which the leader of
+// the region should heartbeat at, and whether it is changed.
+func (c *RaftCluster) regionHeartbeatInterval(region *RegionInfo) (uint64, bool) {
+	// Collect the information as soon as possible before the warm-up.
+	if !c.coordinator.isReady() {
+		return 0, false
+	}
+	hasOperator := c.coordinator.getOperator(region.GetId()) != nil
+	interval, changed := c.heartbeatIntervals.regionInterval(region, hasOperator)
+	return uint64(interval.Seconds()), changed
+}
+
+// storeHeartbeatInterval returns the interval in seconds which the store
+// should heartbeat at.
+func (c *RaftCluster) storeHeartbeatInterval(storeID uint64) uint64 {
+	store := c.cachedCluster.getStore(storeID)
+	if store == nil || !c.coordinator.isReady() {
+		return 0
+	}
+	return uint64(c.heartbeatIntervals.storeInterval(store).Seconds())
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+var _ = Suite(&testHeartbeatIntervalSuite{})
+
+type testHeartbeatIntervalSuite struct{}
+
+func (s *testHeartbeatIntervalSuite) TestRegionInterval(c *C) {
+	cfg, opt := newTestScheduleConfig()
+	cfg.MaxRegionHeartbeatInterval.Duration = 10 * time.Minute
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	h := newHeartbeatIntervals(opt)
+
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+	// The first heartbeat is regarded as active, and the interval is sent to
+	// reset the one set by the previous leader.
+	interval, changed := h.regionInterval(region, false)
+	c.Assert(interval, Equals, time.Duration(0))
+	c.Assert(changed, IsTrue)
+
+	for i := 1; i < regionIdleHeartbeats; i++ {
+		interval, changed = h.regionInterval(region, false)
+		c.Assert(interval, Equals, time.Duration(0))
+		c.Assert(changed, IsFalse)
+	}
+	interval, changed = h.regionInterval(region, false)
+	c.Assert(interval, Equals, 10*time.Minute)
+	c.Assert(changed, IsTrue)
+	interval, changed = h.regionInterval(region, false)
+	c.Assert(interval, Equals, 10*time.Minute)
+	c.Assert(changed, IsFalse)
+
+	// Written bytes, operators and leader changes make the region active.
+	written := region.clone()
+	written.WrittenBytes = 1
+	interval, changed = h.regionInterval(written, false)
+	c.Assert(interval, Equals, time.Duration(0))
+	c.Assert(changed, IsTrue)
+	for i := 0; i < regionIdleHeartbeats; i++ {
+		h.regionInterval(region, false)
+	}
+	_, changed = h.regionInterval(region, true)
+	c.Assert(changed, IsTrue)
+	for i := 0; i < regionIdleHeartbeats; i++ {
+		h.regionInterval(region, false)
+	}
+	transferred := region.clone()
+	transferred.Leader = transferred.GetStorePeer(2)
+	interval, changed = h.regionInterval(transferred, false)
+	c.Assert(interval, Equals, time.Duration(0))
+	c.Assert(changed, IsTrue)
+
+	// 0 disables it.
+	cfg.MaxRegionHeartbeatInterval.Duration = 0
+	for i := 0; i < regionIdleHeartbeats; i++ {
+		interval, changed = h.regionInterval(transferred, false)
+		c.Assert(interval, Equals, time.Duration(0))
+		c.Assert(changed, IsFalse)
+	}
+
+	// Removed regions are forgotten.
+	h.gc(cluster)
+	c.Assert(h.regions, HasLen, 1)
+	cluster.regions.removeRegion(region)
+	h.gc(cluster)
+	c.Assert(h.regions, HasLen, 0)
+}
+
+func (s *testHeartbeatIntervalSuite) TestStoreInterval(c *C) {
+	cfg, opt := newTestScheduleConfig()
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	h := newHeartbeatIntervals(opt)
+
+	tc.addRegionStore(1, 10)
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, time.Duration(0))
+
+	cfg.MaxStoreHeartbeatInterval.Duration = 30 * time.Second
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, 30*time.Second)
+
+	stats := &pdpb.StoreStats{StoreId: 1, ReceivingSnapCount: 1}
+	c.Assert(cluster.handleStoreHeartbeat(stats), IsNil)
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, time.Duration(0))
+	stats = &pdpb.StoreStats{StoreId: 1, IsBusy: true}
+	c.Assert(cluster.handleStoreHeartbeat(stats), IsNil)
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, time.Duration(0))
+	stats = &pdpb.StoreStats{StoreId: 1}
+	c.Assert(cluster.handleStoreHeartbeat(stats), IsNil)
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, 30*time.Second)
+
+	tc.setStoreOffline(1)
+	c.Assert(h.storeInterval(cluster.getStore(1)), Equals, time.Duration(0))
+}
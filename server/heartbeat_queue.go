@@ -0,0 +1,94 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+// heartbeatQueue buffers the region heartbeats received from a stream. When
+// the heartbeats come faster than they are processed, the queued heartbeats
+// of the same region are collapsed into the latest one, so each region is
+// processed at most once in a cycle.
+type heartbeatQueue struct {
+	sync.Mutex
+	// order is the region IDs in the order of their first queued heartbeats.
+	order   []uint64
+	pending map[uint64]*pdpb.RegionHeartbeatRequest
+	notify  chan struct{}
+	err     error
+}
+
+func newHeartbeatQueue() *heartbeatQueue {
+	return &heartbeatQueue{
+		pending: make(map[uint64]*pdpb.RegionHeartbeatRequest),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// push queues the heartbeat, it returns false if an older heartbeat of the
+// region is replaced.
+func (q *heartbeatQueue) push(request *pdpb.RegionHeartbeatRequest) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	regionID := request.GetRegion().GetId()
+	_, collapsed := q.pending[regionID]
+	if !collapsed {
+		q.order = append(q.order, regionID)
+	}
+	q.pending[regionID] = request
+	q.wakeup()
+	return !collapsed
+}
+
+// close stops the queue, pop returns err after the queued heartbeats are
+// consumed.
+func (q *heartbeatQueue) close(err error) {
+	q.Lock()
+	defer q.Unlock()
+	q.err = err
+	q.wakeup()
+}
+
+func (q *heartbeatQueue) wakeup() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the next heartbeat, it blocks until there is one or the queue
+// is closed.
+func (q *heartbeatQueue) pop() (*pdpb.RegionHeartbeatRequest, error) {
+	for {
+		q.Lock()
+		if len(q.order) > 0 {
+			regionID := q.order[0]
+			q.order = q.order[1:]
+			request := q.pending[regionID]
+			delete(q.pending, regionID)
+			q.Unlock()
+			return request, nil
+		}
+		err := q.err
+		q.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		<-q.notify
+	}
+}
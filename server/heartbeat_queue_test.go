@@ -0,0 +1,82 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+var _ = Suite(&testHeartbeatQueueSuite{})
+
+type testHeartbeatQueueSuite struct{}
+
+func newTestHeartbeat(regionID, version uint64) *pdpb.RegionHeartbeatRequest {
+	return &pdpb.RegionHeartbeatRequest{
+		Region: &metapb.Region{
+			Id:          regionID,
+			RegionEpoch: &metapb.RegionEpoch{Version: version},
+		},
+	}
+}
+
+func (s *testHeartbeatQueueSuite) TestCollapse(c *C) {
+	q := newHeartbeatQueue()
+	c.Assert(q.push(newTestHeartbeat(1, 1)), IsTrue)
+	c.Assert(q.push(newTestHeartbeat(2, 1)), IsTrue)
+	c.Assert(q.push(newTestHeartbeat(1, 2)), IsFalse)
+	c.Assert(q.push(newTestHeartbeat(1, 3)), IsFalse)
+	q.close(io.EOF)
+
+	// Region 1 keeps its position with the latest heartbeat.
+	request, err := q.pop()
+	c.Assert(err, IsNil)
+	c.Assert(request.GetRegion().GetId(), Equals, uint64(1))
+	c.Assert(request.GetRegion().GetRegionEpoch().GetVersion(), Equals, uint64(3))
+	request, err = q.pop()
+	c.Assert(err, IsNil)
+	c.Assert(request.GetRegion().GetId(), Equals, uint64(2))
+	_, err = q.pop()
+	c.Assert(err, Equals, io.EOF)
+}
+
+func (s *testHeartbeatQueueSuite) TestBlockingPop(c *C) {
+	q := newHeartbeatQueue()
+	ch := make(chan uint64)
+	go func() {
+		for {
+			request, err := q.pop()
+			if err != nil {
+				close(ch)
+				return
+			}
+			ch <- request.GetRegion().GetId()
+		}
+	}()
+
+	select {
+	case <-ch:
+		c.Fatal("pop should block on an empty queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+	q.push(newTestHeartbeat(1, 1))
+	c.Assert(<-ch, Equals, uint64(1))
+	q.close(io.EOF)
+	_, ok := <-ch
+	c.Assert(ok, IsFalse)
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// heatmapMaxSamples is the number of samples kept in memory, samples are
+	// taken by the background jobs, so it keeps the flow of the last day.
+	heatmapMaxSamples = 24 * 60
+	// heatmapSampleBuckets is the number of key-range buckets in a sample.
+	heatmapSampleBuckets = 256
+	// defaultHeatmapBuckets is the number of key-range buckets returned.
+	defaultHeatmapBuckets = 64
+)
+
+type heatmapBucket struct {
+	startKey     []byte
+	writtenBytes uint64
+	readBytes    uint64
+}
+
+type heatmapSample struct {
+	time    time.Time
+	buckets []heatmapBucket
+}
+
+// Heatmap is a matrix of the flow, the rows are the time and the columns are
+// the key ranges [Keys[i], Keys[i+1]). The flow is in bytes per second.
+type Heatmap struct {
+	Keys         [][]byte    `json:"keys"`
+	Times        []time.Time `json:"times"`
+	WrittenBytes [][]uint64  `json:"written_bytes"`
+	ReadBytes    [][]uint64  `json:"read_bytes"`
+}
+
+// heatmapCollector aggregates the region flow by key ranges periodically.
+type heatmapCollector struct {
+	sync.RWMutex
+	samples []*heatmapSample
+}
+
+func newHeatmapCollector() *heatmapCollector {
+	return &heatmapCollector{}
+}
+
+// collect takes a sample from the regions. Regions are sorted by key and
+// divided into buckets which have the same number of regions.
+func (h *heatmapCollector) collect(regions []*RegionInfo, now time.Time) {
+	if len(regions) == 0 {
+		return
+	}
+	sort.Sort(regionsByStartKey(regions))
+
+	n := heatmapSampleBuckets
+	if len(regions) < n {
+		n = len(regions)
+	}
+	sample := &heatmapSample{
+		time:    now,
+		buckets: make([]heatmapBucket, n),
+	}
+	for i, region := range regions {
+		bucket := &sample.buckets[i*n/len(regions)]
+		if bucket.startKey == nil {
+			bucket.startKey = region.GetStartKey()
+		}
+		bucket.writtenBytes += region.WrittenBytes
+		bucket.readBytes += region.ReadBytes / regionHeartBeatReportInterval
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	h.samples = append(h.samples, sample)
+	if len(h.samples) > heatmapMaxSamples {
+		h.samples = h.samples[len(h.samples)-heatmapMaxSamples:]
+	}
+}
+
+// getHeatmap returns the heatmap of the samples taken in [start, end). The key
+// ranges are decided by the last sample, and merged into at most n buckets.
+func (h *heatmapCollector) getHeatmap(start, end time.Time, n int) *Heatmap {
+	h.RLock()
+	defer h.RUnlock()
+
+	heatmap := &Heatmap{}
+	var samples []*heatmapSample
+	for _, s := range h.samples {
+		if !s.time.Before(start) && s.time.Before(end) {
+			samples = append(samples, s)
+		}
+	}
+	if len(samples) == 0 || n <= 0 {
+		return heatmap
+	}
+
+	last := samples[len(samples)-1].buckets
+	if len(last) < n {
+		n = len(last)
+	}
+	for i := 0; i < n; i++ {
+		heatmap.Keys = append(heatmap.Keys, last[i*len(last)/n].startKey)
+	}
+
+	for _, s := range samples {
+		written := make([]uint64, n)
+		read := make([]uint64, n)
+		for _, b := range s.buckets {
+			i := searchKey(heatmap.Keys, b.startKey)
+			written[i] += b.writtenBytes
+			read[i] += b.readBytes
+		}
+		heatmap.Times = append(heatmap.Times, s.time)
+		heatmap.WrittenBytes = append(heatmap.WrittenBytes, written)
+		heatmap.ReadBytes = append(heatmap.ReadBytes, read)
+	}
+	return heatmap
+}
+
+// searchKey returns the index of the last key which is not greater than key.
+func searchKey(keys [][]byte, key []byte) int {
+	i := sort.Search(len(keys), func(i int) bool {
+		return bytes.Compare(keys[i], key) > 0
+	})
+	if i == 0 {
+		return 0
+	}
+	return i - 1
+}
+
+type regionsByStartKey []*RegionInfo
+
+func (r regionsByStartKey) Len() int      { return len(r) }
+func (r regionsByStartKey) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r regionsByStartKey) Less(i, j int) bool {
+	return bytes.Compare(r[i].GetStartKey(), r[j].GetStartKey()) < 0
+}
+
+func (c *RaftCluster) collectHeatmap() {
+	c.heatmap.collect(c.cachedCluster.getRegions(), time.Now())
+}
+
+// GetHeatmap returns the flow heatmap in [start, end) with at most n key ranges.
+func (c *RaftCluster) GetHeatmap(start, end time.Time, n int) *Heatmap {
+	return c.heatmap.getHeatmap(start, end, n)
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testHeatmapSuite{})
+
+type testHeatmapSuite struct{}
+
+func newHeatmapTestRegions(keys []string, written, read uint64) []*RegionInfo {
+	var regions []*RegionInfo
+	for i, key := range keys {
+		region := newRegionInfo(&metapb.Region{Id: uint64(i + 1), StartKey: []byte(key)}, nil)
+		region.WrittenBytes = written
+		region.ReadBytes = read * regionHeartBeatReportInterval
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+func (s *testHeatmapSuite) TestHeatmap(c *C) {
+	h := newHeatmapCollector()
+	now := time.Now()
+
+	// Regions are not sorted.
+	h.collect(newHeatmapTestRegions([]string{"c", "", "b", "a"}, 10, 1), now)
+	// The second sample has fewer regions.
+	h.collect(newHeatmapTestRegions([]string{"", "b"}, 20, 2), now.Add(time.Minute))
+
+	heatmap := h.getHeatmap(now, now.Add(2*time.Minute), 2)
+	c.Assert(heatmap.Keys, DeepEquals, [][]byte{{}, []byte("b")})
+	c.Assert(heatmap.Times, HasLen, 2)
+	c.Assert(heatmap.WrittenBytes, DeepEquals, [][]uint64{{20, 20}, {20, 20}})
+	c.Assert(heatmap.ReadBytes, DeepEquals, [][]uint64{{2, 2}, {2, 2}})
+
+	// The key ranges are decided by the last sample.
+	heatmap = h.getHeatmap(now, now.Add(2*time.Minute), 4)
+	c.Assert(heatmap.Keys, HasLen, 2)
+
+	heatmap = h.getHeatmap(now, now.Add(time.Minute), 4)
+	c.Assert(heatmap.Keys, HasLen, 4)
+	c.Assert(heatmap.WrittenBytes, DeepEquals, [][]uint64{{10, 10, 10, 10}})
+
+	heatmap = h.getHeatmap(now.Add(time.Hour), now.Add(2*time.Hour), 4)
+	c.Assert(heatmap.Keys, HasLen, 0)
+	c.Assert(heatmap.Times, HasLen, 0)
+}
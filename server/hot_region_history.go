@@ -0,0 +1,220 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	bolt "github.com/coreos/bbolt"
+	"github.com/juju/errors"
+)
+
+const (
+	// hotRegionHistoryRetention is how long the hot region snapshots are kept.
+	hotRegionHistoryRetention = 7 * 24 * time.Hour
+	// hotRegionHistoryLimit is the max number of the snapshots returned by a
+	// query, the later ones can be queried with a later start time.
+	hotRegionHistoryLimit = 1000
+
+	hotRegionHistoryFile        = "hot-region.db"
+	hotRegionHistoryOpenTimeout = 5 * time.Second
+)
+
+// HotRegionHistory is a snapshot of the hot region statistics at some time.
+type HotRegionHistory struct {
+	Time time.Time `json:"time"`
+	// Write is the write hot regions of each store as a peer and as the leader.
+	Write *StoreHotRegionInfos `json:"write"`
+	// Read is the read hot regions of each store as the leader.
+	Read map[uint64]*HotRegionsStat `json:"read"`
+}
+
+// filterByStore returns a copy of the history which only contains the
+// statistics of the given store.
+func (h *HotRegionHistory) filterByStore(storeID uint64) *HotRegionHistory {
+	ret := &HotRegionHistory{
+		Time: h.Time,
+		Write: &StoreHotRegionInfos{
+			AsPeer:   make(map[uint64]*HotRegionsStat),
+			AsLeader: make(map[uint64]*HotRegionsStat),
+		},
+		Read: make(map[uint64]*HotRegionsStat),
+	}
+	if h.Write != nil {
+		if stat, ok := h.Write.AsPeer[storeID]; ok {
+			ret.Write.AsPeer[storeID] = stat
+		}
+		if stat, ok := h.Write.AsLeader[storeID]; ok {
+			ret.Write.AsLeader[storeID] = stat
+		}
+	}
+	if stat, ok := h.Read[storeID]; ok {
+		ret.Read[storeID] = stat
+	}
+	return ret
+}
+
+func (h *HotRegionHistory) isEmpty() bool {
+	return (h.Write == nil || len(h.Write.AsPeer) == 0 && len(h.Write.AsLeader) == 0) && len(h.Read) == 0
+}
+
+// hotRegionStorage saves the hot region snapshots in a local bolt database
+// under the data directory instead of etcd, so the snapshots are not
+// replicated by raft. A snapshot is saved by the leader at that time, the
+// ones saved by the other members are lost after the leader changes.
+type hotRegionStorage struct {
+	db *bolt.DB
+	// bucket is the cluster ID, the tenants share the database of the PD.
+	bucket []byte
+}
+
+func newHotRegionStorage(dataDir string, clusterID uint64) (*hotRegionStorage, error) {
+	db, err := bolt.Open(filepath.Join(dataDir, hotRegionHistoryFile), 0600, &bolt.Options{Timeout: hotRegionHistoryOpenTimeout})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &hotRegionStorage{
+		db:     db,
+		bucket: uint64ToBytes(clusterID),
+	}, nil
+}
+
+// forCluster returns the storage of a tenant sharing the database.
+func (s *hotRegionStorage) forCluster(clusterID uint64) *hotRegionStorage {
+	return &hotRegionStorage{
+		db:     s.db,
+		bucket: uint64ToBytes(clusterID),
+	}
+}
+
+func (s *hotRegionStorage) close() error {
+	return errors.Trace(s.db.Close())
+}
+
+func hotRegionHistoryKey(t time.Time) []byte {
+	return uint64ToBytes(uint64(t.UnixNano()))
+}
+
+func (s *hotRegionStorage) save(history *HotRegionHistory) error {
+	value, err := json.Marshal(history)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(s.bucket)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(b.Put(hotRegionHistoryKey(history.Time), value))
+	})
+	return errors.Trace(err)
+}
+
+// load returns at most limit snapshots saved in [start, end).
+func (s *hotRegionStorage) load(start, end time.Time, limit int) ([]*HotRegionHistory, error) {
+	var histories []*HotRegionHistory
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b == nil {
+			return nil
+		}
+		endKey := hotRegionHistoryKey(end)
+		c := b.Cursor()
+		for k, v := c.Seek(hotRegionHistoryKey(start)); k != nil && len(histories) < limit; k, v = c.Next() {
+			if bytes.Compare(k, endKey) >= 0 {
+				break
+			}
+			history := &HotRegionHistory{}
+			if err := json.Unmarshal(v, history); err != nil {
+				return errors.Trace(err)
+			}
+			histories = append(histories, history)
+		}
+		return nil
+	})
+	return histories, errors.Trace(err)
+}
+
+// remove removes all hot region snapshots before the given time.
+func (s *hotRegionStorage) remove(before time.Time) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b == nil {
+			return nil
+		}
+		// Deleting by the cursor while iterating skips keys, so collect the
+		// keys first.
+		endKey := hotRegionHistoryKey(before)
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, endKey) < 0; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	})
+	return errors.Trace(err)
+}
+
+// saveHotRegionHistory saves current hot region statistics and removes the
+// expired ones.
+func (c *RaftCluster) saveHotRegionHistory() {
+	asPeer, asLeader := calcHotWriteRegionStats(c.cachedCluster)
+	now := time.Now()
+	history := &HotRegionHistory{
+		Time: now,
+		Write: &StoreHotRegionInfos{
+			AsPeer:   asPeer,
+			AsLeader: asLeader,
+		},
+		Read: calcHotReadRegionStats(c.cachedCluster),
+	}
+	if !history.isEmpty() {
+		if err := c.s.hotRegions.save(history); err != nil {
+			log.Errorf("save hot region history error: %v", err)
+		}
+	}
+	if err := c.s.hotRegions.remove(now.Add(-hotRegionHistoryRetention)); err != nil {
+		log.Errorf("remove hot region history error: %v", err)
+	}
+}
+
+// GetHotRegionHistory returns the hot region snapshots saved in [start, end),
+// at most hotRegionHistoryLimit snapshots are returned. If storeID is not 0,
+// only the statistics of the store are returned.
+func (c *RaftCluster) GetHotRegionHistory(start, end time.Time, storeID uint64) ([]*HotRegionHistory, error) {
+	histories, err := c.s.hotRegions.load(start, end, hotRegionHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if storeID == 0 {
+		return histories, nil
+	}
+
+	ret := make([]*HotRegionHistory, 0, len(histories))
+	for _, h := range histories {
+		if h = h.filterByStore(storeID); !h.isEmpty() {
+			ret = append(ret, h)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testHotRegionHistorySuite{})
+
+type testHotRegionHistorySuite struct {
+	dir string
+}
+
+func (s *testHotRegionHistorySuite) SetUpTest(c *C) {
+	var err error
+	s.dir, err = ioutil.TempDir("", "test_hot_region")
+	c.Assert(err, IsNil)
+}
+
+func (s *testHotRegionHistorySuite) TearDownTest(c *C) {
+	os.RemoveAll(s.dir)
+}
+
+func (s *testHotRegionHistorySuite) TestStorage(c *C) {
+	storage, err := newHotRegionStorage(s.dir, 1)
+	c.Assert(err, IsNil)
+
+	start := time.Unix(1000, 0)
+	for i := 0; i < 5; i++ {
+		history := &HotRegionHistory{
+			Time: start.Add(time.Duration(i) * time.Minute),
+			Write: &StoreHotRegionInfos{
+				AsPeer: map[uint64]*HotRegionsStat{
+					uint64(i % 2): {WrittenBytes: uint64(i), RegionsCount: 1},
+				},
+				AsLeader: map[uint64]*HotRegionsStat{},
+			},
+			Read: map[uint64]*HotRegionsStat{
+				2: {ReadBytes: uint64(i), RegionsCount: 1},
+			},
+		}
+		c.Assert(storage.save(history), IsNil)
+	}
+
+	histories, err := storage.load(start, start.Add(5*time.Minute), 10)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 5)
+	for i, h := range histories {
+		c.Assert(h.Time.Equal(start.Add(time.Duration(i)*time.Minute)), IsTrue)
+		c.Assert(h.Write.AsPeer[uint64(i%2)].WrittenBytes, Equals, uint64(i))
+		c.Assert(h.Read[2].ReadBytes, Equals, uint64(i))
+	}
+
+	// The number of the snapshots is limited.
+	histories, err = storage.load(start, start.Add(5*time.Minute), 2)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 2)
+	c.Assert(histories[1].Time.Equal(start.Add(time.Minute)), IsTrue)
+
+	histories, err = storage.load(start.Add(time.Minute), start.Add(3*time.Minute), 10)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 2)
+	c.Assert(histories[0].filterByStore(1).isEmpty(), IsFalse)
+	c.Assert(histories[0].filterByStore(0).isEmpty(), IsTrue)
+	c.Assert(histories[0].filterByStore(2).isEmpty(), IsFalse)
+	c.Assert(histories[0].filterByStore(2).Write.AsPeer, HasLen, 0)
+
+	c.Assert(storage.remove(start.Add(2*time.Minute)), IsNil)
+	histories, err = storage.load(start, start.Add(5*time.Minute), 10)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 3)
+
+	// The tenants don't see the snapshots of the others.
+	tenant := storage.forCluster(2)
+	histories, err = tenant.load(start, start.Add(5*time.Minute), 10)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 0)
+	c.Assert(tenant.remove(start.Add(5*time.Minute)), IsNil)
+
+	// The snapshots are kept after restarting.
+	c.Assert(storage.close(), IsNil)
+	storage, err = newHotRegionStorage(s.dir, 1)
+	c.Assert(err, IsNil)
+	defer storage.close()
+	histories, err = storage.load(start, start.Add(5*time.Minute), 10)
+	c.Assert(err, IsNil)
+	c.Assert(histories, HasLen, 3)
+}
@@ -24,6 +24,8 @@ import (
 
 const (
 	allocStep = uint64(1000)
+	// maxAllocBatch is the max number of IDs allocated in one call.
+	maxAllocBatch = uint64(100000)
 )
 
 // IDAllocator is the allocator to generate unique ID.
@@ -40,25 +42,45 @@ type idAllocator struct {
 }
 
 func (alloc *idAllocator) Alloc() (uint64, error) {
+	return alloc.AllocBatch(1)
+}
+
+// AllocBatch allocates count consecutive IDs and returns the first one. The
+// IDs are taken from the cached range, etcd is only written when the range
+// is exhausted.
+func (alloc *idAllocator) AllocBatch(count uint64) (uint64, error) {
+	if count == 0 || count > maxAllocBatch {
+		return 0, errors.Errorf("invalid id count %d", count)
+	}
+
 	alloc.mu.Lock()
 	defer alloc.mu.Unlock()
 
-	if alloc.base == alloc.end {
-		end, err := alloc.generate()
+	if alloc.end-alloc.base < count {
+		step := allocStep
+		if count > step {
+			step = count
+		}
+		end, err := alloc.generate(step)
 		if err != nil {
 			return 0, errors.Trace(err)
 		}
 
+		// The rest of the cached range can be used if the new range follows
+		// it, otherwise it is dropped.
+		if end-step != alloc.end {
+			alloc.base = end - step
+		}
 		alloc.end = end
-		alloc.base = alloc.end - allocStep
 	}
 
-	alloc.base++
+	id := alloc.base + 1
+	alloc.base += count
 
-	return alloc.base, nil
+	return id, nil
 }
 
-func (alloc *idAllocator) generate() (uint64, error) {
+func (alloc *idAllocator) generate(step uint64) (uint64, error) {
 	key := alloc.s.getAllocIDPath()
 	value, err := getValue(alloc.s.client, key)
 	if err != nil {
@@ -83,7 +105,7 @@ func (alloc *idAllocator) generate() (uint64, error) {
 		cmp = clientv3.Compare(clientv3.Value(key), "=", string(value))
 	}
 
-	end += allocStep
+	end += step
 	value = uint64ToBytes(end)
 	resp, err := alloc.s.leaderTxn(cmp).Then(clientv3.OpPut(key, string(value))).Commit()
 	if err != nil {
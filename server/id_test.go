@@ -18,7 +18,7 @@ import (
 
 	"github.com/coreos/etcd/clientv3"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 )
 
@@ -94,3 +94,37 @@ func (s *testAllocIDSuite) TestCommand(c *C) {
 		last = resp.GetId()
 	}
 }
+
+func (s *testAllocIDSuite) TestAllocBatch(c *C) {
+	mustGetLeader(c, s.client, s.svr.getLeaderPath())
+
+	last, err := s.alloc.Alloc()
+	c.Assert(err, IsNil)
+
+	// Batches larger than the step are still consecutive.
+	for _, count := range []uint64{1, 10, allocStep - 1, 3 * allocStep} {
+		id, err := s.alloc.AllocBatch(count)
+		c.Assert(err, IsNil)
+		c.Assert(id, Greater, last)
+		last = id + count - 1
+	}
+
+	_, err = s.alloc.AllocBatch(0)
+	c.Assert(err, NotNil)
+	_, err = s.alloc.AllocBatch(maxAllocBatch + 1)
+	c.Assert(err, NotNil)
+
+	req := &pdpb.AllocIDRequest{
+		Header: newRequestHeader(s.svr.clusterID),
+		Count:  100,
+	}
+	resp, err := s.grpcPDClient.AllocID(context.Background(), req)
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetCount(), Equals, uint32(100))
+	c.Assert(resp.GetId(), Greater, last)
+	last = resp.GetId() + 99
+
+	id, err := s.alloc.Alloc()
+	c.Assert(err, IsNil)
+	c.Assert(id, Greater, last)
+}
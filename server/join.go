@@ -22,15 +22,20 @@ import (
 	"github.com/coreos/etcd/wal"
 	"github.com/juju/errors"
 	"github.com/pingcap/pd/pkg/etcdutil"
+	"github.com/pingcap/pd/pkg/urlutil"
 )
 
-// TODO: support HTTPS
-func genClientV3Config(cfg *Config) clientv3.Config {
+func genClientV3Config(cfg *Config) (clientv3.Config, error) {
+	tlsConfig, err := cfg.Security.ToTLSConfig()
+	if err != nil {
+		return clientv3.Config{}, errors.Trace(err)
+	}
 	endpoints := strings.Split(cfg.Join, ",")
 	return clientv3.Config{
 		Endpoints:   endpoints,
 		DialTimeout: etcdutil.DefaultDialTimeout,
-	}
+		TLS:         tlsConfig,
+	}, nil
 }
 
 // PrepareJoinCluster sends MemberAdd command to PD cluster,
@@ -76,7 +81,7 @@ func PrepareJoinCluster(cfg *Config) error {
 		return nil
 	}
 
-	if cfg.Join == cfg.AdvertiseClientUrls {
+	if urlutil.ContainsAny(strings.Split(cfg.Join, ","), strings.Split(cfg.AdvertiseClientUrls, ",")) {
 		return errors.New("join self is forbidden")
 	}
 
@@ -91,7 +96,11 @@ func PrepareJoinCluster(cfg *Config) error {
 
 	// Below are cases without data directory.
 
-	client, err := clientv3.New(genClientV3Config(cfg))
+	clientCfg, err := genClientV3Config(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := clientv3.New(clientCfg)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -221,6 +221,11 @@ func (s *testJoinServerSuite) TestPDJoinsItself(c *C) {
 
 	_, err := startPdWith(cfg)
 	c.Assert(err, NotNil)
+
+	// The same IPv6 address in another form.
+	cfg.AdvertiseClientUrls = "http://[::1]:2379"
+	cfg.Join = "http://[0:0:0:0:0:0:0:1]:2379"
+	c.Assert(PrepareJoinCluster(cfg), NotNil)
 }
 
 // A failed PD re-joins the previous cluster.
@@ -18,13 +18,14 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"strconv"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 	"golang.org/x/net/context"
 )
 
@@ -104,6 +105,17 @@ func (kv *kv) saveRegion(region *metapb.Region) error {
 	return kv.saveProto(kv.regionPath(region.GetId()), region)
 }
 
+func (kv *kv) deleteRegion(region *metapb.Region) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.regionPath(region.GetId()))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
 func (kv *kv) loadScheduleOption(opt *scheduleOption) (bool, error) {
 	cfg := &Config{}
 	cfg.Schedule = *opt.load()
@@ -208,6 +220,342 @@ func (kv *kv) loadRegions(regions *regionsInfo, rangeLimit int64) error {
 	}
 }
 
+func (kv *kv) placementPath(id string) string {
+	return path.Join(kv.clusterPath, "placement", id)
+}
+
+func (kv *kv) savePlacement(placement *KeyRangePlacement) error {
+	value, err := json.Marshal(placement)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.placementPath(placement.ID), string(value))
+}
+
+func (kv *kv) removePlacement(id string) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.placementPath(id))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
+func (kv *kv) loadPlacements() ([]*KeyRangePlacement, error) {
+	resp, err := kvGet(kv.client, kv.placementPath("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	placements := make([]*KeyRangePlacement, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		placement := &KeyRangePlacement{}
+		if err := json.Unmarshal(item.Value, placement); err != nil {
+			return nil, errors.Trace(err)
+		}
+		placements = append(placements, placement)
+	}
+	return placements, nil
+}
+
+func (kv *kv) priorityRangePath(id string) string {
+	return path.Join(kv.clusterPath, "priority_range", id)
+}
+
+func (kv *kv) savePriorityRange(keyRange *PriorityKeyRange) error {
+	value, err := json.Marshal(keyRange)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.priorityRangePath(keyRange.ID), string(value))
+}
+
+func (kv *kv) removePriorityRange(id string) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.priorityRangePath(id))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
+func (kv *kv) loadPriorityRanges() ([]*PriorityKeyRange, error) {
+	resp, err := kvGet(kv.client, kv.priorityRangePath("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ranges := make([]*PriorityKeyRange, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		keyRange := &PriorityKeyRange{}
+		if err := json.Unmarshal(item.Value, keyRange); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ranges = append(ranges, keyRange)
+	}
+	return ranges, nil
+}
+
+func (kv *kv) namespacePath(name string) string {
+	return path.Join(kv.clusterPath, "namespace", name)
+}
+
+func (kv *kv) saveNamespace(namespace *Namespace) error {
+	value, err := json.Marshal(namespace)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.namespacePath(namespace.Name), string(value))
+}
+
+func (kv *kv) removeNamespace(name string) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.namespacePath(name))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
+func (kv *kv) loadNamespaces() ([]*Namespace, error) {
+	resp, err := kvGet(kv.client, kv.namespacePath("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespaces := make([]*Namespace, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		namespace := &Namespace{}
+		if err := json.Unmarshal(item.Value, namespace); err != nil {
+			return nil, errors.Trace(err)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+func (kv *kv) storeWeightPath(storeID uint64) string {
+	return path.Join(kv.clusterPath, "store_weight", fmt.Sprintf("%020d", storeID))
+}
+
+// StoreWeight is the relative capacity of a store for the leaders and the
+// regions, the scores of the store are divided by them.
+type StoreWeight struct {
+	StoreID uint64  `json:"store_id"`
+	Leader  float64 `json:"leader"`
+	Region  float64 `json:"region"`
+}
+
+func (kv *kv) saveStoreWeight(weight *StoreWeight) error {
+	value, err := json.Marshal(weight)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.storeWeightPath(weight.StoreID), string(value))
+}
+
+func (kv *kv) loadStoreWeights() ([]*StoreWeight, error) {
+	resp, err := kvGet(kv.client, path.Join(kv.clusterPath, "store_weight")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	weights := make([]*StoreWeight, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		weight := &StoreWeight{}
+		if err := json.Unmarshal(item.Value, weight); err != nil {
+			return nil, errors.Trace(err)
+		}
+		weights = append(weights, weight)
+	}
+	return weights, nil
+}
+
+func (kv *kv) storeLimitPath(storeID uint64) string {
+	return path.Join(kv.clusterPath, "store_limit", fmt.Sprintf("%020d", storeID))
+}
+
+// StoreLimit is the max number of the add-peer and remove-peer operators of
+// a store per minute, 0 means no limit. It overrides store-balance-rate.
+type StoreLimit struct {
+	StoreID    uint64  `json:"store_id"`
+	AddPeer    float64 `json:"add_peer"`
+	RemovePeer float64 `json:"remove_peer"`
+}
+
+func (kv *kv) saveStoreLimit(limit *StoreLimit) error {
+	value, err := json.Marshal(limit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.storeLimitPath(limit.StoreID), string(value))
+}
+
+func (kv *kv) loadStoreLimits() ([]*StoreLimit, error) {
+	resp, err := kvGet(kv.client, path.Join(kv.clusterPath, "store_limit")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	limits := make([]*StoreLimit, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		limit := &StoreLimit{}
+		if err := json.Unmarshal(item.Value, limit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
+func (kv *kv) schedulersPath() string {
+	return path.Join(kv.clusterPath, "schedulers")
+}
+
+func (kv *kv) saveSchedulers(states []*schedulerState) error {
+	value, err := json.Marshal(states)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.schedulersPath(), string(value))
+}
+
+func (kv *kv) loadSchedulers() ([]*schedulerState, bool, error) {
+	value, err := kv.load(kv.schedulersPath())
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if value == nil {
+		return nil, false, nil
+	}
+	var states []*schedulerState
+	if err := json.Unmarshal(value, &states); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return states, true, nil
+}
+
+func (kv *kv) schedulePausePath() string {
+	return path.Join(kv.clusterPath, "schedule_pause")
+}
+
+func (kv *kv) saveSchedulePause(status *SchedulePauseStatus) error {
+	value, err := json.Marshal(status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.schedulePausePath(), string(value))
+}
+
+func (kv *kv) loadSchedulePause() (*SchedulePauseStatus, error) {
+	value, err := kv.load(kv.schedulePausePath())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	status := &SchedulePauseStatus{}
+	if err := json.Unmarshal(value, status); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return status, nil
+}
+
+func (kv *kv) gcSafePointPath() string {
+	return path.Join(kv.s.rootPath, "gc", "safe_point")
+}
+
+func (kv *kv) saveGCSafePoint(safePoint uint64) error {
+	return kv.save(kv.gcSafePointPath(), strconv.FormatUint(safePoint, 16))
+}
+
+// loadGCSafePoint returns 0 if the safe point is never saved.
+func (kv *kv) loadGCSafePoint() (uint64, error) {
+	value, err := kv.load(kv.gcSafePointPath())
+	if err != nil || value == nil {
+		return 0, errors.Trace(err)
+	}
+	safePoint, err := strconv.ParseUint(string(value), 16, 64)
+	return safePoint, errors.Trace(err)
+}
+
+func (kv *kv) serviceSafePointPath(serviceID string) string {
+	return path.Join(kv.s.rootPath, "gc", "service_safe_point", serviceID)
+}
+
+func (kv *kv) saveServiceSafePoint(ssp *serviceSafePoint) error {
+	value, err := json.Marshal(ssp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return kv.save(kv.serviceSafePointPath(ssp.ServiceID), string(value))
+}
+
+func (kv *kv) removeServiceSafePoint(serviceID string) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.serviceSafePointPath(serviceID))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
+func (kv *kv) loadServiceSafePoints() ([]*serviceSafePoint, error) {
+	resp, err := kvGet(kv.client, kv.serviceSafePointPath("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ssps := make([]*serviceSafePoint, 0, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		ssp := &serviceSafePoint{}
+		if err := json.Unmarshal(item.Value, ssp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ssps = append(ssps, ssp)
+	}
+	return ssps, nil
+}
+
+func (kv *kv) memberLeaderPriorityPath(memberID uint64) string {
+	return path.Join(kv.s.rootPath, "member", fmt.Sprintf("%020d", memberID), "leader_priority")
+}
+
+func (kv *kv) saveMemberLeaderPriority(memberID uint64, priority int) error {
+	return kv.save(kv.memberLeaderPriorityPath(memberID), strconv.Itoa(priority))
+}
+
+// loadMemberLeaderPriority returns the leader priority of the member, 0 if
+// it's not set.
+func (kv *kv) loadMemberLeaderPriority(memberID uint64) (int, error) {
+	value, err := kv.load(kv.memberLeaderPriorityPath(memberID))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if value == nil {
+		return 0, nil
+	}
+	priority, err := strconv.Atoi(string(value))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return priority, nil
+}
+
+func (kv *kv) removeMemberLeaderPriority(memberID uint64) error {
+	resp, err := kv.txn().Then(clientv3.OpDelete(kv.memberLeaderPriorityPath(memberID))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.Trace(errTxnFailed)
+	}
+	return nil
+}
+
 func (kv *kv) loadProto(key string, msg proto.Message) (bool, error) {
 	value, err := kv.load(key)
 	if err != nil {
@@ -17,7 +17,7 @@ import (
 	"fmt"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 )
 
 var _ = Suite(&testKVSuite{})
@@ -106,6 +106,25 @@ func (s *testKVSuite) TestLoadStores(c *C) {
 	}
 }
 
+func (s *testKVSuite) TestStoreWeights(c *C) {
+	kv := newKV(s.server)
+
+	weights, err := kv.loadStoreWeights()
+	c.Assert(err, IsNil)
+	c.Assert(weights, HasLen, 0)
+
+	expect := []*StoreWeight{
+		{StoreID: 1, Leader: 2, Region: 1},
+		{StoreID: 2, Leader: 0, Region: 0.5},
+	}
+	for _, weight := range expect {
+		c.Assert(kv.saveStoreWeight(weight), IsNil)
+	}
+	weights, err = kv.loadStoreWeights()
+	c.Assert(err, IsNil)
+	c.Assert(weights, DeepEquals, expect)
+}
+
 func mustSaveRegions(c *C, kv *kv, n int) []*metapb.Region {
 	regions := make([]*metapb.Region, 0, n)
 	for i := 0; i < n; i++ {
@@ -24,14 +24,17 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 )
 
 var (
-	errNoLeader = errors.New("no leader")
+	errNoLeader  = errors.New("no leader")
+	errNotLeader = errors.New("not leader")
 )
 
+const campaignDelayCheckInterval = 200 * time.Millisecond
+
 // IsLeader returns whether server is leader or not.
 func (s *Server) IsLeader() bool {
 	return atomic.LoadInt64(&s.isLeaderValue) == 1
@@ -47,9 +50,19 @@ func (s *Server) enableLeader(b bool) {
 }
 
 func (s *Server) getLeaderPath() string {
+	if s.primary != nil {
+		// Tenants share the leadership with the PD.
+		return s.primary.getLeaderPath()
+	}
 	return path.Join(s.rootPath, "leader")
 }
 
+// getNextLeaderPath returns the path of the member name given by the resigned
+// leader, only the member campaigns until the key expires.
+func (s *Server) getNextLeaderPath() string {
+	return path.Join(s.rootPath, "next_leader")
+}
+
 func (s *Server) leaderLoop() {
 	defer s.wg.Done()
 
@@ -77,11 +90,15 @@ func (s *Server) leaderLoop() {
 				}
 			} else {
 				log.Infof("leader is %s, watch it", leader)
-				s.watchLeader()
+				s.watchLeader(s.getLeaderPath())
 				log.Info("leader changed, try to campaign leader")
 			}
 		}
 
+		if s.delayCampaign() {
+			time.Sleep(campaignDelayCheckInterval)
+			continue
+		}
 		if err = s.campaignLeader(); err != nil {
 			log.Errorf("campaign leader err %s", errors.ErrorStack(err))
 		}
@@ -184,6 +201,21 @@ func (s *Server) campaignLeader() error {
 	}
 	log.Debugf("campaign leader ok %s", s.Name())
 
+	// The leader key is deleted after the leader stops serving if it resigns,
+	// rather than waiting for the lease to expire.
+	resigned := false
+	defer func() {
+		if !resigned {
+			return
+		}
+		if err := s.resignLeader(); err != nil {
+			log.Errorf("resign leader err %s", err)
+		}
+	}()
+	if _, err = s.client.Delete(s.client.Ctx(), s.getNextLeaderPath()); err != nil {
+		log.Errorf("delete next leader err %s", err)
+	}
+
 	err = s.reloadScheduleOption()
 	if err != nil {
 		return errors.Trace(err)
@@ -203,13 +235,25 @@ func (s *Server) campaignLeader() error {
 		physical: zeroTime,
 	})
 
+	// Drop the resignation requested when the server was not the leader.
+	select {
+	case <-s.resignCh:
+	default:
+	}
 	s.enableLeader(true)
 	defer s.enableLeader(false)
 
+	s.startTenants()
+	defer s.stopTenants()
+
 	log.Infof("PD cluster leader %s is ready to serve", s.Name())
 
 	tsTicker := time.NewTicker(updateTimestampStep)
 	defer tsTicker.Stop()
+	syncTicker := time.NewTicker(localTsoSyncInterval)
+	defer syncTicker.Stop()
+	priorityTicker := time.NewTicker(leaderPriorityCheckInterval)
+	defer priorityTicker.Stop()
 
 	for {
 		select {
@@ -222,13 +266,100 @@ func (s *Server) campaignLeader() error {
 			if err = s.updateTimestamp(); err != nil {
 				return errors.Trace(err)
 			}
+			s.updateTenantTimestamps()
+		case <-syncTicker.C:
+			if err = s.syncLocalTimestamps(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-priorityTicker.C:
+			// Probing the members may be slow, don't block updating the TSO.
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.checkLeaderPriority()
+			}()
+		case <-s.resignCh:
+			log.Infof("PD cluster leader %s resigns", s.Name())
+			resigned = true
+			return nil
 		case <-ctx.Done():
 			return errors.New("server closed")
 		}
 	}
 }
 
-func (s *Server) watchLeader() {
+// ResignLeader resigns the leadership, the next leader is elected from the
+// other members if nextLeader is empty, or else only the member named
+// nextLeader campaigns until the leader lease expires. It returns without
+// waiting for the next leader.
+func (s *Server) ResignLeader(nextLeader string) error {
+	if !s.IsLeader() {
+		return errors.Trace(errNotLeader)
+	}
+	members, err := GetMembers(s.client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var candidates int
+	for _, member := range members {
+		if member.GetMemberId() == s.ID() {
+			continue
+		}
+		if nextLeader == "" || member.GetName() == nextLeader {
+			candidates++
+		}
+	}
+	if candidates == 0 {
+		if nextLeader != "" {
+			return errors.Errorf("member %s not found", nextLeader)
+		}
+		return errors.New("no other member to take over the leadership")
+	}
+
+	lease := time.Duration(s.cfg.LeaderLease) * time.Second
+	if nextLeader != "" {
+		ctx, cancel := context.WithTimeout(s.client.Ctx(), requestTimeout)
+		leaseResp, err := s.client.Grant(ctx, s.cfg.LeaderLease)
+		cancel()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		resp, err := s.leaderTxn().
+			Then(clientv3.OpPut(s.getNextLeaderPath(), nextLeader, clientv3.WithLease(leaseResp.ID))).
+			Commit()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !resp.Succeeded {
+			return errors.Trace(errNotLeader)
+		}
+	}
+	atomic.StoreInt64(&s.campaignDelayUntil, time.Now().Add(lease).UnixNano())
+	select {
+	case s.resignCh <- struct{}{}:
+	default:
+	}
+	log.Infof("PD cluster leader %s is resigning, next leader %q", s.Name(), nextLeader)
+	return nil
+}
+
+// delayCampaign checks whether the server should wait for the others to
+// campaign. The resigned leader waits for a lease, and the members other than
+// the next leader given by it wait until the next leader key expires.
+func (s *Server) delayCampaign() bool {
+	if time.Now().UnixNano() < atomic.LoadInt64(&s.campaignDelayUntil) {
+		return true
+	}
+	nextLeader, err := getValue(s.client, s.getNextLeaderPath())
+	if err != nil {
+		log.Errorf("get next leader err %s", err)
+		return false
+	}
+	return nextLeader != nil && string(nextLeader) != s.Name()
+}
+
+// watchLeader waits until the leader key is deleted.
+func (s *Server) watchLeader(leaderPath string) {
 	watcher := clientv3.NewWatcher(s.client)
 	defer watcher.Close()
 
@@ -236,7 +367,7 @@ func (s *Server) watchLeader() {
 	defer cancel()
 
 	for {
-		rch := watcher.Watch(ctx, s.getLeaderPath())
+		rch := watcher.Watch(ctx, leaderPath)
 		for wresp := range rch {
 			if wresp.Canceled {
 				return
@@ -19,11 +19,12 @@ import (
 
 	"github.com/juju/errors"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"golang.org/x/net/context"
 )
 
 var _ = Suite(&testGetLeaderSuite{})
+var _ = Suite(&testLeaderPrioritySuite{})
 
 type testGetLeaderSuite struct {
 	svr  *Server
@@ -91,3 +92,36 @@ func (s *testGetLeaderSuite) sendRequest(c *C, addr string) {
 		time.Sleep(10 * time.Millisecond)
 	}
 }
+
+type testLeaderPrioritySuite struct{}
+
+func (s *testLeaderPrioritySuite) TestLeaderPriority(c *C) {
+	svrs, cleanup := newMultiTestServers(c, 3)
+	defer cleanup()
+
+	leader := mustWaitLeader(c, svrs)
+	var next *Server
+	for _, svr := range svrs {
+		if svr != leader {
+			next = svr
+		}
+	}
+
+	// The leader is kept if no member has a higher priority.
+	c.Assert(leader.SetMemberLeaderPriority(leader.ID(), 2), IsNil)
+	c.Assert(leader.SetMemberLeaderPriority(next.ID(), 2), IsNil)
+	leader.checkLeaderPriority()
+	time.Sleep(time.Second)
+	c.Assert(leader.IsLeader(), IsTrue)
+
+	c.Assert(leader.SetMemberLeaderPriority(next.ID(), 3), IsNil)
+	leader.checkLeaderPriority()
+	c.Assert(mustWaitLeader(c, []*Server{next}), Equals, next)
+	priority, err := next.GetMemberLeaderPriority(next.ID())
+	c.Assert(err, IsNil)
+	c.Assert(priority, Equals, 3)
+	// The new leader has the highest priority.
+	next.checkLeaderPriority()
+	time.Sleep(time.Second)
+	c.Assert(next.IsLeader(), IsTrue)
+}
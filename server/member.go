@@ -0,0 +1,112 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/etcdutil"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+// leaderPriorityCheckInterval is the interval for the leader to check whether
+// a member of higher leader priority can take over the leadership.
+const leaderPriorityCheckInterval = time.Minute
+
+// RemoveMember removes the member from the etcd cluster, and its leader
+// priority.
+func (s *Server) RemoveMember(id uint64) error {
+	if _, err := etcdutil.RemoveEtcdMember(s.client, id); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.kv.removeMemberLeaderPriority(id); err != nil {
+		log.Errorf("[member %d] remove leader priority err %s", id, err)
+	}
+	log.Infof("[member %d] removed", id)
+	return nil
+}
+
+// GetMemberLeaderPriority returns the leader priority of the member, 0 if
+// it's not set.
+func (s *Server) GetMemberLeaderPriority(id uint64) (int, error) {
+	priority, err := s.kv.loadMemberLeaderPriority(id)
+	return priority, errors.Trace(err)
+}
+
+// SetMemberLeaderPriority sets the leader priority of the member. The leader
+// transfers the leadership to the reachable member of the highest priority
+// if it's higher than the leader's.
+func (s *Server) SetMemberLeaderPriority(id uint64, priority int) error {
+	if err := s.kv.saveMemberLeaderPriority(id, priority); err != nil {
+		return errors.Trace(err)
+	}
+	log.Infof("[member %d] set leader priority to %d", id, priority)
+	return nil
+}
+
+// probeMember checks whether the member is reachable by reading its clock,
+// which is served by the member itself without redirecting to the leader.
+func (s *Server) probeMember(member *pdpb.Member) error {
+	var err error
+	for _, u := range member.GetClientUrls() {
+		if _, err = s.clockMonitor.readClock(u); err == nil {
+			return nil
+		}
+	}
+	return errors.Trace(err)
+}
+
+// checkLeaderPriority transfers the leadership to the reachable member of
+// the highest leader priority if it's higher than the leader's.
+func (s *Server) checkLeaderPriority() {
+	members, err := GetMembers(s.client)
+	if err != nil {
+		log.Errorf("get members err %s", err)
+		return
+	}
+	priority, err := s.GetMemberLeaderPriority(s.ID())
+	if err != nil {
+		log.Errorf("get leader priority err %s", err)
+		return
+	}
+
+	var next *pdpb.Member
+	for _, member := range members {
+		if member.GetMemberId() == s.ID() {
+			continue
+		}
+		p, err := s.GetMemberLeaderPriority(member.GetMemberId())
+		if err != nil {
+			log.Errorf("[member %d] get leader priority err %s", member.GetMemberId(), err)
+			return
+		}
+		if p <= priority {
+			continue
+		}
+		if err = s.probeMember(member); err != nil {
+			log.Warnf("[member %d] skip the member of higher leader priority: %s", member.GetMemberId(), err)
+			continue
+		}
+		next, priority = member, p
+	}
+	if next == nil {
+		return
+	}
+	log.Infof("transfer the leadership to %s of higher leader priority %d", next.GetName(), priority)
+	if err = s.ResignLeader(next.GetName()); err != nil {
+		log.Errorf("resign leader err %s", err)
+	}
+}
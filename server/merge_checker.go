@@ -0,0 +1,173 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// mergeChecker merges the small regions into their adjacent regions, so the
+// empty regions left by deleted data don't accumulate.
+type mergeChecker struct {
+	opt     *scheduleOption
+	rep     *Replication
+	cluster *clusterInfo
+}
+
+func newMergeChecker(opt *scheduleOption, cluster *clusterInfo) *mergeChecker {
+	return &mergeChecker{
+		opt:     opt,
+		rep:     opt.GetReplication(),
+		cluster: cluster,
+	}
+}
+
+// Check returns the operators of the region and the target region to merge
+// into, or nil if the region should not be merged.
+func (m *mergeChecker) Check(region *RegionInfo) []Operator {
+	if !m.isSmall(region) || !m.isHealthy(region) {
+		return nil
+	}
+
+	// Merge into the smaller adjacent region.
+	var target *RegionInfo
+	if prev := m.cluster.searchPrevRegion(region.GetStartKey()); m.allowMerge(region, prev) {
+		target = prev
+	}
+	if len(region.GetEndKey()) > 0 {
+		if next := m.cluster.searchRegion(region.GetEndKey()); m.allowMerge(region, next) {
+			if target == nil || next.ApproximateSize < target.ApproximateSize {
+				target = next
+			}
+		}
+	}
+	if target == nil {
+		return nil
+	}
+	return m.newMergeOperators(region, target, RegionKind)
+}
+
+// isSmall checks the approximate size and keys reported by TiKV.
+func (m *mergeChecker) isSmall(region *RegionInfo) bool {
+	maxSize := m.opt.GetMaxMergeRegionSize()
+	if maxSize == 0 || region.ApproximateSize > maxSize {
+		return false
+	}
+	maxKeys := m.opt.GetMaxMergeRegionKeys()
+	return maxKeys == 0 || region.ApproximateKeys <= maxKeys
+}
+
+// isHealthy checks that the region has the right number of voters which are
+// all working, the regions with learners are not merged.
+func (m *mergeChecker) isHealthy(region *RegionInfo) bool {
+	return region.Leader != nil &&
+		len(region.DownPeers) == 0 && len(region.PendingPeers) == 0 &&
+		len(region.getNonVoterStoreIds()) == 0 &&
+		len(region.GetPeers()) == m.cluster.getRegionMaxReplicas(region, m.rep.GetMaxReplicas())
+}
+
+// allowMerge checks whether the source can be merged into the target.
+func (m *mergeChecker) allowMerge(source, target *RegionInfo) bool {
+	if target == nil || !m.isHealthy(target) {
+		return false
+	}
+	// The regions must be adjacent.
+	if !bytes.Equal(source.GetEndKey(), target.GetStartKey()) && !bytes.Equal(target.GetEndKey(), source.GetStartKey()) {
+		return false
+	}
+	// The regions of different placements or namespaces are not merged.
+	return m.cluster.getRegionPlacement(source) == m.cluster.getRegionPlacement(target) &&
+		m.cluster.getRegionNamespace(source) == m.cluster.getRegionNamespace(target)
+}
+
+// newMergeOperators moves the peers of the source to the stores of the
+// target peers first, since TiKV merges the regions whose peers are on the
+// same stores, then merges the source into the target. The new peers have no
+// IDs until allocPeers is called, so the operators which can't be added don't
+// waste the IDs.
+func (m *mergeChecker) newMergeOperators(source, target *RegionInfo, kind ResourceKind) []Operator {
+	var (
+		steps     []Operator
+		newPeers  []*metapb.Peer
+		leader    = source.Leader
+		sourceIDs = source.GetStoreIds()
+		targetIDs = target.GetStoreIds()
+	)
+	for storeID := range targetIDs {
+		if _, ok := sourceIDs[storeID]; ok {
+			continue
+		}
+		peer := &metapb.Peer{StoreId: storeID}
+		newPeers = append(newPeers, peer)
+		steps = append(steps, newAddPeerOperator(source.GetId(), peer))
+	}
+	for _, peer := range source.GetPeers() {
+		if _, ok := targetIDs[peer.GetStoreId()]; ok {
+			continue
+		}
+		if peer.GetId() == leader.GetId() {
+			newLeader := m.selectLeader(source, targetIDs, newPeers)
+			if newLeader == nil {
+				return nil
+			}
+			steps = append(steps, newTransferLeaderOperator(source.GetId(), leader, newLeader))
+			leader = newLeader
+		}
+		steps = append(steps, newRemovePeerOperator(source.GetId(), peer))
+	}
+	steps = append(steps, newMergeRegionOperator(source.Region, target.Region, false))
+
+	return []Operator{
+		newRegionOperator(source, kind, steps...),
+		newRegionOperator(target, kind, newMergeRegionOperator(source.Region, target.Region, true)),
+	}
+}
+
+// allocPeers allocates the IDs of the peers added by the merge operators.
+func (m *mergeChecker) allocPeers(ops []Operator) error {
+	for _, op := range ops {
+		o, ok := op.(*regionOperator)
+		if !ok {
+			continue
+		}
+		for _, step := range o.Ops {
+			if add, ok := step.(*changePeerOperator); ok && add.ChangePeer.GetPeer().GetId() == 0 {
+				id, err := m.cluster.allocID()
+				if err != nil {
+					return errors.Trace(err)
+				}
+				// The transfer leader step shares the peer.
+				add.ChangePeer.Peer.Id = id
+			}
+		}
+	}
+	return nil
+}
+
+// selectLeader returns a peer of the source on the stores of the target, the
+// existing peers are preferred.
+func (m *mergeChecker) selectLeader(source *RegionInfo, targetIDs map[uint64]struct{}, newPeers []*metapb.Peer) *metapb.Peer {
+	for _, peer := range source.GetPeers() {
+		if _, ok := targetIDs[peer.GetStoreId()]; ok {
+			return peer
+		}
+	}
+	if len(newPeers) > 0 {
+		return newPeers[0]
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+const mb = 1024 * 1024
+
+var _ = Suite(&testMergeCheckerSuite{})
+
+type testMergeCheckerSuite struct{}
+
+func (s *testMergeCheckerSuite) TestMergeChecker(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	cfg, opt := newTestScheduleConfig()
+	mc := newMergeChecker(opt, cluster)
+
+	tc.addRegionStore(1, 3)
+	tc.addRegionStore(2, 3)
+	tc.addRegionStore(3, 3)
+	tc.addRegionStore(4, 3)
+	tc.addLeaderRegionInRange(1, "", "a", 10*mb, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "a", "b", mb/2, 1, 2, 3)
+	tc.addLeaderRegionInRange(3, "b", "", mb/4, 2, 3, 4)
+
+	// Merge is disabled by default.
+	c.Assert(mc.Check(cluster.getRegion(2)), IsNil)
+
+	cfg.MaxMergeRegionSize = mb
+	c.Assert(mc.Check(cluster.getRegion(1)), IsNil)
+
+	// Merge into the smaller adjacent region, the peers are moved to the
+	// stores of the target first.
+	ops := mc.Check(cluster.getRegion(2))
+	c.Assert(ops, HasLen, 2)
+	steps := ops[0].(*regionOperator).Ops
+	c.Assert(steps, HasLen, 4)
+	c.Assert(steps[0].(*changePeerOperator).ChangePeer.GetPeer().GetStoreId(), Equals, uint64(4))
+	c.Assert(steps[1].(*transferLeaderOperator).NewLeader.GetStoreId(), Equals, uint64(2))
+	c.Assert(steps[2].(*changePeerOperator).ChangePeer.GetPeer().GetStoreId(), Equals, uint64(1))
+	s.checkMerge(c, steps[3], 2, 3, false)
+	c.Assert(ops[1].GetRegionID(), Equals, uint64(3))
+	s.checkMerge(c, ops[1].(*regionOperator).Ops[0], 2, 3, true)
+
+	// The ID of the new peer is allocated later.
+	peer := steps[0].(*changePeerOperator).ChangePeer.GetPeer()
+	c.Assert(peer.GetId(), Equals, uint64(0))
+	c.Assert(mc.allocPeers(ops), IsNil)
+	c.Assert(peer.GetId(), Not(Equals), uint64(0))
+
+	// The region has too many keys.
+	cfg.MaxMergeRegionKeys = 100
+	region := cluster.getRegion(2)
+	region.ApproximateKeys = 200
+	cluster.putRegion(region)
+	c.Assert(mc.Check(region), IsNil)
+	region.ApproximateKeys = 50
+	cluster.putRegion(region)
+
+	// Don't merge into an unhealthy region.
+	region3 := cluster.getRegion(3)
+	region3.PendingPeers = append(region3.PendingPeers, region3.GetStorePeer(4))
+	cluster.putRegion(region3)
+	ops = mc.Check(region)
+	c.Assert(ops, HasLen, 2)
+	c.Assert(ops[0].(*regionOperator).Ops, HasLen, 1)
+	s.checkMerge(c, ops[0].(*regionOperator).Ops[0], 2, 1, false)
+
+	// Don't merge the regions of different placements.
+	cluster.placements.set(&KeyRangePlacement{ID: "p", StartKey: []byte("a")})
+	c.Assert(mc.Check(region), IsNil)
+}
+
+func (s *testMergeCheckerSuite) checkMerge(c *C, op Operator, sourceID, targetID uint64, isPassive bool) {
+	merge := op.(*mergeRegionOperator)
+	c.Assert(merge.FromRegion.GetId(), Equals, sourceID)
+	c.Assert(merge.ToRegion.GetId(), Equals, targetID)
+	c.Assert(merge.IsPassive, Equals, isPassive)
+}
+
+func (s *testMergeCheckerSuite) TestMergeOperator(c *C) {
+	source := &metapb.Region{Id: 1, StartKey: []byte("a"), EndKey: []byte("b")}
+	target := &metapb.Region{Id: 2, StartKey: []byte("b"), EndKey: []byte("c")}
+
+	op := newMergeRegionOperator(source, target, false)
+	c.Assert(op.GetRegionID(), Equals, uint64(1))
+	res, finished := op.Do(newRegionInfo(source, nil))
+	c.Assert(finished, IsFalse)
+	c.Assert(res.GetMerge(), DeepEquals, &pdpb.Merge{Target: target})
+
+	passive := newMergeRegionOperator(source, target, true)
+	c.Assert(passive.GetRegionID(), Equals, uint64(2))
+	res, finished = passive.Do(newRegionInfo(target, nil))
+	c.Assert(res, IsNil)
+	c.Assert(finished, IsFalse)
+	merged := &metapb.Region{Id: 2, StartKey: []byte("a"), EndKey: []byte("c")}
+	_, finished = passive.Do(newRegionInfo(merged, nil))
+	c.Assert(finished, IsTrue)
+}
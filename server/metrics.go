@@ -41,6 +41,14 @@ var (
 			Help:      "Counter of schedule operators.",
 		}, []string{"type", "state"})
 
+	operatorCanceledCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operators_canceled_total",
+			Help:      "Counter of the operators canceled before they finish.",
+		}, []string{"kind", "reason"})
+
 	clusterStatusGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "pd",
@@ -65,6 +73,88 @@ var (
 			Help:      "Status of the scheduler.",
 		}, []string{"kind", "type"})
 
+	regionHeartbeatCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "scheduler",
+			Name:      "region_heartbeat",
+			Help:      "Counter of region heartbeats.",
+		}, []string{"type"})
+
+	storeHeartbeatCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "scheduler",
+			Name:      "store_heartbeat",
+			Help:      "Counter of store heartbeats.",
+		}, []string{"type"})
+
+	grpcDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pd",
+			Subsystem: "grpc",
+			Name:      "handle_requests_duration_seconds",
+			Help:      "Bucketed histogram of processing time (s) of handled unary gRPC requests.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 13),
+		}, []string{"method", "result"})
+
+	grpcStreamMsgCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "grpc",
+			Name:      "stream_requests_count",
+			Help:      "Counter of requests received from gRPC streams.",
+		}, []string{"method", "result"})
+
+	grpcRateLimitedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "grpc",
+			Name:      "rate_limited_requests_count",
+			Help:      "Counter of gRPC requests rejected by the rate limiter.",
+		}, []string{"method", "type"})
+
+	grpcRejectedStreamCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "grpc",
+			Name:      "rejected_streams_count",
+			Help:      "Counter of gRPC streams rejected by max-concurrent-streams.",
+		})
+
+	tsoBatchSizeHist = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "pd",
+			Subsystem: "tso",
+			Name:      "batch_size",
+			Help:      "Bucketed histogram of the number of the requests in a timestamp batch.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 13),
+		})
+
+	tsoAllocatedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "tso",
+			Name:      "allocated_timestamps_total",
+			Help:      "Counter of the allocated timestamps.",
+		})
+
+	tsoClockDriftGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "tso",
+			Name:      "clock_drift_seconds",
+			Help:      "Estimated clock drift (s) of the members against the leader.",
+		}, []string{"member"})
+
+	tsoClockDriftCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "tso",
+			Name:      "clock_drift_total",
+			Help:      "Counter of the clock drifts detected, the timestamp updates are refused except for the saved window.",
+		}, []string{"type"})
+
 	hotSpotStatusGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "pd",
@@ -78,8 +168,19 @@ func init() {
 	prometheus.MustRegister(txnCounter)
 	prometheus.MustRegister(txnDuration)
 	prometheus.MustRegister(operatorCounter)
+	prometheus.MustRegister(operatorCanceledCounter)
 	prometheus.MustRegister(clusterStatusGauge)
 	prometheus.MustRegister(timeJumpBackCounter)
 	prometheus.MustRegister(schedulerStatusGauge)
+	prometheus.MustRegister(regionHeartbeatCounter)
+	prometheus.MustRegister(storeHeartbeatCounter)
 	prometheus.MustRegister(hotSpotStatusGauge)
+	prometheus.MustRegister(grpcDuration)
+	prometheus.MustRegister(grpcStreamMsgCounter)
+	prometheus.MustRegister(grpcRateLimitedCounter)
+	prometheus.MustRegister(grpcRejectedStreamCounter)
+	prometheus.MustRegister(tsoBatchSizeHist)
+	prometheus.MustRegister(tsoAllocatedCounter)
+	prometheus.MustRegister(tsoClockDriftGauge)
+	prometheus.MustRegister(tsoClockDriftCounter)
 }
@@ -0,0 +1,308 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// defaultNamespace is the namespace of the regions and stores which are not
+// assigned to any namespace.
+const defaultNamespace = "global"
+
+// KeyRange is the key range [StartKey, EndKey), an empty EndKey means the
+// range is unbounded.
+type KeyRange struct {
+	StartKey []byte `json:"start_key"`
+	EndKey   []byte `json:"end_key"`
+}
+
+func (r *KeyRange) overlaps(other *KeyRange) bool {
+	return (len(other.EndKey) == 0 || bytes.Compare(r.StartKey, other.EndKey) < 0) &&
+		(len(r.EndKey) == 0 || bytes.Compare(other.StartKey, r.EndKey) < 0)
+}
+
+func (r *KeyRange) contains(region *metapb.Region) bool {
+	if bytes.Compare(region.GetStartKey(), r.StartKey) < 0 {
+		return false
+	}
+	if len(r.EndKey) == 0 {
+		return true
+	}
+	return len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), r.EndKey) <= 0
+}
+
+// Namespace groups the key ranges, such as the tables of a tenant, with the
+// stores dedicated to them. The regions in the ranges are only placed on the
+// stores, and the stores hold no other regions. The regions and stores out
+// of any namespace are in the default namespace, and each namespace is
+// balanced separately.
+type Namespace struct {
+	Name     string      `json:"name"`
+	Ranges   []*KeyRange `json:"ranges"`
+	StoreIDs []uint64    `json:"store_ids"`
+	// MaxReplicas is the number of replicas of the regions, 0 means using the
+	// max-replicas of the cluster.
+	MaxReplicas int `json:"max_replicas"`
+}
+
+func (ns *Namespace) validate() error {
+	if ns.Name == "" || ns.Name == defaultNamespace || strings.Contains(ns.Name, "/") {
+		return errors.Errorf("invalid namespace name %q", ns.Name)
+	}
+	for i, r := range ns.Ranges {
+		if len(r.EndKey) > 0 && bytes.Compare(r.StartKey, r.EndKey) >= 0 {
+			return errors.Errorf("invalid key range of namespace %s", ns.Name)
+		}
+		for _, other := range ns.Ranges[:i] {
+			if r.overlaps(other) {
+				return errors.Errorf("overlapped key ranges of namespace %s", ns.Name)
+			}
+		}
+	}
+	if ns.MaxReplicas < 0 {
+		return errors.Errorf("invalid max replicas %d of namespace %s", ns.MaxReplicas, ns.Name)
+	}
+	return nil
+}
+
+func (ns *Namespace) hasStore(storeID uint64) bool {
+	for _, id := range ns.StoreIDs {
+		if id == storeID {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceRules holds the namespaces, the key ranges and stores of them
+// don't overlap.
+type namespaceRules struct {
+	sync.RWMutex
+	namespaces map[string]*Namespace
+}
+
+func newNamespaceRules() *namespaceRules {
+	return &namespaceRules{
+		namespaces: make(map[string]*Namespace),
+	}
+}
+
+func (r *namespaceRules) check(namespace *Namespace) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	if err := namespace.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, ns := range r.namespaces {
+		if ns.Name == namespace.Name {
+			continue
+		}
+		for _, r1 := range ns.Ranges {
+			for _, r2 := range namespace.Ranges {
+				if r1.overlaps(r2) {
+					return errors.Errorf("namespace %s overlaps with %s", namespace.Name, ns.Name)
+				}
+			}
+		}
+		for _, id := range namespace.StoreIDs {
+			if ns.hasStore(id) {
+				return errors.Errorf("store %d is already in namespace %s", id, ns.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *namespaceRules) set(namespace *Namespace) {
+	r.Lock()
+	defer r.Unlock()
+	r.namespaces[namespace.Name] = namespace
+}
+
+func (r *namespaceRules) exist(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.namespaces[name]
+	return ok
+}
+
+func (r *namespaceRules) remove(name string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.namespaces, name)
+}
+
+func (r *namespaceRules) get(name string) *Namespace {
+	r.RLock()
+	defer r.RUnlock()
+	return r.namespaces[name]
+}
+
+// getAll returns the namespaces sorted by name.
+func (r *namespaceRules) getAll() []*Namespace {
+	r.RLock()
+	defer r.RUnlock()
+
+	namespaces := make([]*Namespace, 0, len(r.namespaces))
+	for _, ns := range r.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	return namespaces
+}
+
+func (r *namespaceRules) count() int {
+	r.RLock()
+	defer r.RUnlock()
+	return len(r.namespaces)
+}
+
+// getRegionNamespace returns the namespace with a key range containing the
+// region, or the default namespace.
+func (r *namespaceRules) getRegionNamespace(region *metapb.Region) string {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, ns := range r.namespaces {
+		for _, kr := range ns.Ranges {
+			if kr.contains(region) {
+				return ns.Name
+			}
+		}
+	}
+	return defaultNamespace
+}
+
+func (r *namespaceRules) getStoreNamespace(storeID uint64) string {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, ns := range r.namespaces {
+		if ns.hasStore(storeID) {
+			return ns.Name
+		}
+	}
+	return defaultNamespace
+}
+
+func (c *clusterInfo) getRegionNamespace(region *RegionInfo) string {
+	return c.namespaces.getRegionNamespace(region.Region)
+}
+
+func (c *clusterInfo) getStoreNamespace(storeID uint64) string {
+	return c.namespaces.getStoreNamespace(storeID)
+}
+
+// getNamespaceClusters returns a copy of the cluster for each namespace, with
+// only the regions and stores in it, so the schedulers balance each namespace
+// separately. It returns the cluster itself if there is no namespace.
+func (c *clusterInfo) getNamespaceClusters() []*clusterInfo {
+	if c.namespaces.count() == 0 {
+		return []*clusterInfo{c}
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	clusters := make(map[string]*clusterInfo)
+	getCluster := func(name string) *clusterInfo {
+		nc, ok := clusters[name]
+		if !ok {
+			nc = newClusterInfo(c.id)
+			nc.meta = c.meta
+			nc.placements = c.placements
+			nc.namespaces = c.namespaces
+			clusters[name] = nc
+		}
+		return nc
+	}
+	for _, region := range c.regions.getRegions() {
+		getCluster(c.getRegionNamespace(region)).regions.setRegion(region)
+	}
+	for _, store := range c.stores.getStores() {
+		nc := getCluster(c.getStoreNamespace(store.GetId()))
+		nc.stores.setStore(store)
+		nc.updateStoreStatus(store.GetId())
+	}
+
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*clusterInfo, 0, len(names))
+	for _, name := range names {
+		result = append(result, clusters[name])
+	}
+	return result
+}
+
+func (c *clusterInfo) putNamespace(namespace *Namespace) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.namespaces.check(namespace); err != nil {
+		return errors.Trace(err)
+	}
+	if c.kv != nil {
+		if err := c.kv.saveNamespace(namespace); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.namespaces.set(namespace)
+	return nil
+}
+
+func (c *clusterInfo) removeNamespace(name string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.namespaces.exist(name) {
+		return errors.Errorf("namespace %s not found", name)
+	}
+	if c.kv != nil {
+		if err := c.kv.removeNamespace(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.namespaces.remove(name)
+	return nil
+}
+
+// GetNamespaces returns all the namespaces.
+func (c *RaftCluster) GetNamespaces() []*Namespace {
+	return c.cachedCluster.namespaces.getAll()
+}
+
+// PutNamespace adds or updates a namespace. The regions and stores of it will
+// be scheduled by the replica checker.
+func (c *RaftCluster) PutNamespace(namespace *Namespace) error {
+	return c.cachedCluster.putNamespace(namespace)
+}
+
+// RemoveNamespace removes a namespace, its regions and stores are moved to
+// the default namespace.
+func (c *RaftCluster) RemoveNamespace(name string) error {
+	return c.cachedCluster.removeNamespace(name)
+}
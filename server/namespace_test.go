@@ -0,0 +1,154 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testNamespaceSuite{})
+
+type testNamespaceSuite struct{}
+
+func newTestNamespace(name string, start, end string, storeIDs ...uint64) *Namespace {
+	return &Namespace{
+		Name:     name,
+		Ranges:   []*KeyRange{{StartKey: []byte(start), EndKey: []byte(end)}},
+		StoreIDs: storeIDs,
+	}
+}
+
+func (s *testNamespaceSuite) TestNamespaceRules(c *C) {
+	rules := newNamespaceRules()
+
+	c.Assert(rules.check(&Namespace{}), NotNil)
+	c.Assert(rules.check(&Namespace{Name: defaultNamespace}), NotNil)
+	c.Assert(rules.check(&Namespace{Name: "a/b"}), NotNil)
+	c.Assert(rules.check(&Namespace{Name: "n1", MaxReplicas: -1}), NotNil)
+	c.Assert(rules.check(newTestNamespace("n1", "b", "a")), NotNil)
+	overlapped := newTestNamespace("n1", "a", "c")
+	overlapped.Ranges = append(overlapped.Ranges, &KeyRange{StartKey: []byte("b")})
+	c.Assert(rules.check(overlapped), NotNil)
+
+	n1 := newTestNamespace("n1", "b", "d", 1, 2)
+	c.Assert(rules.check(n1), IsNil)
+	rules.set(n1)
+	c.Assert(rules.check(newTestNamespace("n2", "c", "")), NotNil)
+	c.Assert(rules.check(newTestNamespace("n2", "d", "", 2)), NotNil)
+	c.Assert(rules.check(newTestNamespace("n1", "a", "c", 1)), IsNil)
+	n2 := newTestNamespace("n2", "d", "", 3)
+	c.Assert(rules.check(n2), IsNil)
+	rules.set(n2)
+	c.Assert(rules.getAll(), DeepEquals, []*Namespace{n1, n2})
+
+	c.Assert(rules.getRegionNamespace(&metapb.Region{StartKey: []byte("b"), EndKey: []byte("c")}), Equals, "n1")
+	c.Assert(rules.getRegionNamespace(&metapb.Region{StartKey: []byte("c"), EndKey: []byte("e")}), Equals, defaultNamespace)
+	c.Assert(rules.getRegionNamespace(&metapb.Region{StartKey: []byte("e")}), Equals, "n2")
+	c.Assert(rules.getStoreNamespace(2), Equals, "n1")
+	c.Assert(rules.getStoreNamespace(3), Equals, "n2")
+	c.Assert(rules.getStoreNamespace(4), Equals, defaultNamespace)
+
+	rules.remove("n2")
+	c.Assert(rules.exist("n2"), IsFalse)
+	c.Assert(rules.getStoreNamespace(3), Equals, defaultNamespace)
+}
+
+func (s *testNamespaceSuite) TestReplicaChecker(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	for id := uint64(1); id <= 5; id++ {
+		tc.addRegionStore(id, int(id))
+	}
+	tc.addLeaderRegionInRange(1, "t1", "t2", 10, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "t2", "t3", 10, 1, 2, 4)
+	c.Assert(rc.Check(cluster.getRegion(1)), IsNil)
+	c.Assert(rc.Check(cluster.getRegion(2)), IsNil)
+
+	ns := newTestNamespace("n1", "t1", "t2", 4, 5)
+	ns.MaxReplicas = 2
+	c.Assert(cluster.putNamespace(ns), IsNil)
+	c.Assert(cluster.getRegionMaxReplicas(cluster.getRegion(1), 3), Equals, 2)
+
+	// The region in n1 has too many replicas out of n1.
+	checkRemovePeer(c, rc.Check(cluster.getRegion(1)), 1)
+	tc.addLeaderRegionInRange(1, "t1", "t2", 10, 2, 3)
+	// Move the peers to the stores of n1.
+	checkTransferPeer(c, rc.Check(cluster.getRegion(1)), 2, 4)
+	tc.addLeaderRegionInRange(1, "t1", "t2", 10, 4, 3)
+	checkTransferPeer(c, rc.Check(cluster.getRegion(1)), 3, 5)
+	tc.addLeaderRegionInRange(1, "t1", "t2", 10, 4)
+	checkAddPeer(c, rc.Check(cluster.getRegion(1)), 5)
+	tc.addLeaderRegionInRange(1, "t1", "t2", 10, 4, 5)
+	c.Assert(rc.Check(cluster.getRegion(1)), IsNil)
+
+	// The region out of n1 is moved out of the stores of n1.
+	checkTransferPeer(c, rc.Check(cluster.getRegion(2)), 4, 3)
+
+	c.Assert(cluster.removeNamespace("n1"), IsNil)
+	c.Assert(cluster.removeNamespace("n1"), NotNil)
+	c.Assert(rc.Check(cluster.getRegion(2)), IsNil)
+}
+
+func (s *testNamespaceSuite) TestBalance(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	lb := newBalanceLeaderScheduler(opt)
+	rb := newBalanceRegionScheduler(opt)
+
+	// Stores 1, 2 are in n1, and stores 3, 4, 5 are in the default
+	// namespace. All the leaders and most of the regions are on store 1 and 3,
+	// store 5 has no region.
+	for id := uint64(1); id <= 5; id++ {
+		tc.addRegionStore(id, 0)
+	}
+	c.Assert(cluster.putNamespace(newTestNamespace("n1", "t1", "t2", 1, 2)), IsNil)
+	tc.addLeaderRegionInRange(1, "t11", "t12", 10, 1, 2)
+	tc.addLeaderRegionInRange(2, "t12", "t13", 10, 1, 2)
+	tc.addLeaderRegionInRange(3, "t13", "t14", 10, 1, 2)
+	tc.addLeaderRegionInRange(4, "t2", "t3", 10, 3, 4)
+	tc.addLeaderRegionInRange(5, "t3", "t4", 10, 3, 4)
+	tc.addLeaderRegionInRange(6, "t4", "t5", 10, 3, 4)
+	opt.SetMaxReplicas(2)
+
+	clusters := cluster.getNamespaceClusters()
+	c.Assert(clusters, HasLen, 2)
+	c.Assert(clusters[0].getRegionCount(), Equals, 3)
+	c.Assert(clusters[0].getStoreCount(), Equals, 3)
+	c.Assert(clusters[1].getRegionCount(), Equals, 3)
+	c.Assert(clusters[1].getStoreCount(), Equals, 2)
+
+	// The leaders are balanced in each namespace.
+	for i := 0; i < 10; i++ {
+		op := lb.Schedule(cluster)
+		c.Assert(op, NotNil)
+		if op.GetRegionID() <= 3 {
+			checkTransferLeader(c, op, 1, 2)
+		} else {
+			checkTransferLeader(c, op, 3, 4)
+		}
+	}
+
+	// The regions of the default namespace are moved to store 5 only.
+	for i := 0; i < 10; i++ {
+		op := rb.Schedule(cluster)
+		c.Assert(op, NotNil)
+		c.Assert(op.GetRegionID(), Greater, uint64(3))
+		checkAddPeer(c, op, 5)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "github.com/pingcap/pd/pkg/pdpb"
+
+// storeInfluence is the pending change of a store by the running operators.
+type storeInfluence struct {
+	leaderCount int
+	regionCount int
+	regionSize  int64
+}
+
+// opInfluence records the pending influence of the running operators on each
+// store. The schedulers score the stores with it, so they don't keep moving
+// resources to a store which looks underloaded only because the operators
+// moving resources to it are not finished yet.
+type opInfluence map[uint64]*storeInfluence
+
+// newOpInfluence sums the influence of the operators. An operator counts until
+// it is removed, even if some of its steps are finished.
+func newOpInfluence(ops []Operator, cluster *clusterInfo) opInfluence {
+	influence := make(opInfluence)
+	for _, op := range ops {
+		region := cluster.getRegion(op.GetRegionID())
+		if region == nil {
+			continue
+		}
+		influence.add(op, region)
+	}
+	return influence
+}
+
+func (m opInfluence) add(op Operator, region *RegionInfo) {
+	size := int64(region.regionSize())
+	for _, step := range getOperatorSteps(op) {
+		switch s := step.(type) {
+		case *transferLeaderOperator:
+			m.getOrCreate(s.OldLeader.GetStoreId()).leaderCount--
+			m.getOrCreate(s.NewLeader.GetStoreId()).leaderCount++
+		case *changePeerOperator:
+			if s.roleChange {
+				continue
+			}
+			store := m.getOrCreate(s.ChangePeer.GetPeer().GetStoreId())
+			if s.ChangePeer.GetChangeType() == pdpb.ConfChangeType_RemoveNode {
+				store.regionCount--
+				store.regionSize -= size
+			} else {
+				store.regionCount++
+				store.regionSize += size
+			}
+		}
+	}
+}
+
+func (m opInfluence) getOrCreate(storeID uint64) *storeInfluence {
+	s, ok := m[storeID]
+	if !ok {
+		s = &storeInfluence{}
+		m[storeID] = s
+	}
+	return s
+}
+
+// getStoreInfluence returns the influence of the store, it is zero if no
+// operator touches the store.
+func (m opInfluence) getStoreInfluence(storeID uint64) storeInfluence {
+	if s, ok := m[storeID]; ok {
+		return *s
+	}
+	return storeInfluence{}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testOpInfluenceSuite{})
+
+type testOpInfluenceSuite struct{}
+
+func (s *testOpInfluenceSuite) TestOpInfluence(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	tc.addRegionStore(1, 10)
+	tc.addRegionStore(2, 10)
+	tc.addRegionStore(3, 10)
+	tc.addRegionStore(4, 0)
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+	newPeer, _ := cluster.allocPeer(4)
+	_, opt := newTestScheduleConfig()
+
+	// Move the leader peer from store 1 to store 4, the leader is transferred
+	// to store 2 before the peer is removed.
+	op := newTransferPeer(region, RegionKind, region.GetStorePeer(1), newPeer, opt)
+	influence := newOpInfluence([]Operator{op}, cluster)
+	size := int64(region.regionSize())
+	c.Assert(influence.getStoreInfluence(1), DeepEquals, storeInfluence{leaderCount: -1, regionCount: -1, regionSize: -size})
+	c.Assert(influence.getStoreInfluence(2), DeepEquals, storeInfluence{leaderCount: 1})
+	c.Assert(influence.getStoreInfluence(3), DeepEquals, storeInfluence{})
+	c.Assert(influence.getStoreInfluence(4), DeepEquals, storeInfluence{regionCount: 1, regionSize: size})
+
+	// The operators of the missing regions are skipped.
+	c.Assert(newOpInfluence([]Operator{op}, newClusterInfo(newMockIDAllocator())), HasLen, 0)
+}
+
+func (s *testOpInfluenceSuite) TestBalanceLeader(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	lb := newBalanceLeaderScheduler(opt)
+
+	// Stores:     1    2    3    4
+	// Leaders:    1    2    3   10
+	// Region1:    F    F    F    L
+	tc.addLeaderStore(1, 1)
+	tc.addLeaderStore(2, 2)
+	tc.addLeaderStore(3, 3)
+	tc.addLeaderStore(4, 10)
+	tc.addLeaderRegion(1, 4, 1, 2, 3)
+
+	op := lb.Schedule(cluster)
+	checkTransferLeader(c, op, 4, 1)
+	cluster.setOpInfluence(newOpInfluence([]Operator{op}, cluster))
+	c.Assert(cluster.getStore(1).leaderScore(), Equals, float64(2))
+	c.Assert(cluster.getStore(4).leaderScore(), Equals, float64(9))
+
+	// Store 1 has 3 leaders with the running operators, so the leader is
+	// transferred to store 2 now.
+	cluster.setOpInfluence(opInfluence{1: {leaderCount: 2}, 4: {leaderCount: -2}})
+	checkTransferLeader(c, lb.Schedule(cluster), 4, 2)
+
+	// The influence is gone when the operators finish.
+	cluster.setOpInfluence(newOpInfluence(nil, cluster))
+	c.Assert(cluster.getStore(1).leaderScore(), Equals, float64(1))
+	checkTransferLeader(c, lb.Schedule(cluster), 4, 1)
+}
+
+func (s *testOpInfluenceSuite) TestBalanceRegion(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	sb := newBalanceRegionScheduler(opt)
+	opt.SetMaxReplicas(1)
+
+	tc.addRegionStore(1, 6)
+	tc.addRegionStore(2, 7)
+	tc.addRegionStore(3, 20)
+	tc.addLeaderRegion(1, 3)
+
+	op := sb.Schedule(cluster)
+	checkTransferPeer(c, op, 3, 1)
+
+	// With the pending regions store 1 is no longer the best target.
+	cluster.setOpInfluence(opInfluence{1: {regionCount: 2, regionSize: 2 * defaultRegionSize}})
+	checkTransferPeer(c, sb.Schedule(cluster), 3, 2)
+}
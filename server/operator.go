@@ -14,17 +14,36 @@
 package server
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
-const maxOperatorWaitTime = 5 * time.Minute
+// defaultOperatorTimeout is the max duration of the operators whose kinds
+// have no timeouts in operatorTimeouts.
+const defaultOperatorTimeout = 5 * time.Minute
+
+// operatorTimeouts are the max durations of the operators of each kind, the
+// operators are canceled after them. Transferring leaders is much faster than
+// moving peers with snapshots. Admin operators never time out.
+var operatorTimeouts = map[ResourceKind]time.Duration{
+	LeaderKind:   time.Minute,
+	RegionKind:   10 * time.Minute,
+	PriorityKind: 10 * time.Minute,
+}
+
+func getOperatorTimeout(kind ResourceKind) time.Duration {
+	if timeout, ok := operatorTimeouts[kind]; ok {
+		return timeout
+	}
+	return defaultOperatorTimeout
+}
 
 // ResourceKind distinguishes different kinds of resources.
 type ResourceKind int
@@ -274,7 +293,7 @@ func (op *regionOperator) GetName() string {
 }
 
 func (op *regionOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatResponse, bool) {
-	if time.Since(op.Start) > maxOperatorWaitTime {
+	if op.isTimeout(time.Now()) {
 		log.Errorf("[region %d] Operator timeout:%s", region.GetId(), op)
 		op.State = OperatorTimeOut
 		return nil, true
@@ -296,26 +315,187 @@ func (op *regionOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatResponse,
 	return nil, true
 }
 
+func (op *regionOperator) isTimeout(now time.Time) bool {
+	return now.Sub(op.Start) > getOperatorTimeout(op.Kind)
+}
+
+// isOperatorTimeout checks whether the operator runs longer than the timeout
+// of its kind.
+func isOperatorTimeout(op Operator, now time.Time) bool {
+	regionOp, ok := op.(*regionOperator)
+	return ok && regionOp.isTimeout(now)
+}
+
+// getOperatorSteps returns the steps of the operator, an operator without
+// sub operators is a step itself.
+func getOperatorSteps(op Operator) []Operator {
+	switch op := op.(type) {
+	case *adminOperator:
+		return op.Ops
+	case *regionOperator:
+		return op.Ops
+	default:
+		return []Operator{op}
+	}
+}
+
+// getSnapshotStores returns the stores which send or receive snapshots when
+// the operator runs. The leader store is the sender of all snapshots.
+func getSnapshotStores(op Operator) []uint64 {
+	var (
+		region *RegionInfo
+		ops    []Operator
+	)
+	switch o := op.(type) {
+	case *regionOperator:
+		region, ops = o.Region, o.Ops
+	case *adminOperator:
+		region, ops = o.Region, o.Ops
+	default:
+		return nil
+	}
+
+	var storeIDs []uint64
+	for _, o := range ops {
+		op, ok := o.(*changePeerOperator)
+		if !ok || op.ChangePeer.GetChangeType() == pdpb.ConfChangeType_RemoveNode {
+			continue
+		}
+		// Changing the role of a peer doesn't need a snapshot.
+		if !op.roleChange {
+			storeIDs = append(storeIDs, op.ChangePeer.GetPeer().GetStoreId())
+		}
+	}
+	if len(storeIDs) > 0 && region.Leader != nil {
+		storeIDs = append(storeIDs, region.Leader.GetStoreId())
+	}
+	return storeIDs
+}
+
+// getChangePeerStores returns the stores which add or remove peers when the
+// operator runs, changing the role of a peer is not counted.
+func getChangePeerStores(op Operator) (addStores, removeStores []uint64) {
+	for _, o := range getOperatorSteps(op) {
+		op, ok := o.(*changePeerOperator)
+		if !ok || op.roleChange {
+			continue
+		}
+		storeID := op.ChangePeer.GetPeer().GetStoreId()
+		if op.ChangePeer.GetChangeType() == pdpb.ConfChangeType_RemoveNode {
+			removeStores = append(removeStores, storeID)
+		} else {
+			addStores = append(addStores, storeID)
+		}
+	}
+	return addStores, removeStores
+}
+
 type changePeerOperator struct {
 	Name       string           `json:"name"`
 	RegionID   uint64           `json:"region_id"`
 	ChangePeer *pdpb.ChangePeer `json:"change_peer"`
 	State      OperatorState    `json:"state"`
+	roleChange bool
 }
 
+// newAddPeerOperator adds the peer with its role, the witnesses are added as
+// voters.
 func newAddPeerOperator(regionID uint64, peer *metapb.Peer) *changePeerOperator {
+	changeType := pdpb.ConfChangeType_AddNode
+	if role := peer.GetRole(); role == metapb.PeerRole_Learner || role == metapb.PeerRole_Observer {
+		changeType = pdpb.ConfChangeType_AddLearnerNode
+	}
 	return &changePeerOperator{
 		Name:     "add_peer",
 		RegionID: regionID,
 		ChangePeer: &pdpb.ChangePeer{
 			// FIXME: replace with actual ConfChangeType once eraftpb uses proto3.
-			ChangeType: pdpb.ConfChangeType_AddNode,
+			ChangeType: changeType,
 			Peer:       peer,
 		},
 		State: OperatorWaiting,
 	}
 }
 
+// newChangePeerRoleOperator changes the role of an existing peer, a voter is
+// demoted by AddLearnerNode and a learner is promoted by AddNode.
+func newChangePeerRoleOperator(regionID uint64, peer *metapb.Peer, role metapb.PeerRole) *changePeerOperator {
+	newPeer := &metapb.Peer{
+		Id:      peer.GetId(),
+		StoreId: peer.GetStoreId(),
+		Role:    role,
+	}
+	op := newAddPeerOperator(regionID, newPeer)
+	op.Name = "change_peer_role"
+	op.roleChange = true
+	return op
+}
+
+// newAddPeerSteps adds the peer in one step. If learner is true, a voter is
+// added as a learner first, and promoted after it has caught up with the
+// leader, so the quorum never counts a peer which is still receiving the
+// snapshot.
+func newAddPeerSteps(regionID uint64, peer *metapb.Peer, learner bool) []Operator {
+	if !learner || peer.GetRole() != metapb.PeerRole_Voter {
+		return []Operator{newAddPeerOperator(regionID, peer)}
+	}
+	learnerPeer := &metapb.Peer{
+		Id:      peer.GetId(),
+		StoreId: peer.GetStoreId(),
+		Role:    metapb.PeerRole_Learner,
+	}
+	return []Operator{
+		newAddPeerOperator(regionID, learnerPeer),
+		newChangePeerRoleOperator(regionID, learnerPeer, metapb.PeerRole_Voter),
+	}
+}
+
+// getOrphanLearners returns the learners which the operator added to promote,
+// but are still learners in the region since the operator was interrupted
+// before promoting them, and the voters it demoted to learners but didn't
+// remove. The learners added by the admin are not included.
+func getOrphanLearners(op Operator, region *RegionInfo) []*metapb.Peer {
+	var (
+		added    = make(map[uint64]struct{})
+		promoted []*metapb.Peer
+		orphans  []*metapb.Peer
+	)
+	for _, step := range getOperatorSteps(op) {
+		switch s := step.(type) {
+		case *changePeerOperator:
+			peer := s.ChangePeer.GetPeer()
+			if !s.roleChange {
+				if s.ChangePeer.GetChangeType() == pdpb.ConfChangeType_AddLearnerNode {
+					added[peer.GetId()] = struct{}{}
+				}
+				continue
+			}
+			if peer.GetRole() == metapb.PeerRole_Voter {
+				promoted = append(promoted, peer)
+			}
+		case *changePeerV2Operator:
+			if s.leave {
+				continue
+			}
+			promoted = append(promoted, s.Promote...)
+			for _, peer := range s.Demote {
+				if p := region.GetPeer(peer.GetId()); p != nil && p.GetRole() == metapb.PeerRole_Learner {
+					orphans = append(orphans, p)
+				}
+			}
+		}
+	}
+	for _, peer := range promoted {
+		if _, ok := added[peer.GetId()]; !ok {
+			continue
+		}
+		if p := region.GetPeer(peer.GetId()); p != nil && p.GetRole() == metapb.PeerRole_Learner {
+			orphans = append(orphans, p)
+		}
+	}
+	return orphans
+}
+
 func newRemovePeerOperator(regionID uint64, peer *metapb.Peer) *changePeerOperator {
 	return &changePeerOperator{
 		Name:     "remove_peer",
@@ -360,12 +540,12 @@ func (op *changePeerOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatRespo
 	// Check if operator is finished.
 	peer := op.ChangePeer.GetPeer()
 	switch op.ChangePeer.GetChangeType() {
-	case pdpb.ConfChangeType_AddNode:
+	case pdpb.ConfChangeType_AddNode, pdpb.ConfChangeType_AddLearnerNode:
 		if region.GetPendingPeer(peer.GetId()) != nil {
 			// Peer is added but not finished.
 			return nil, false
 		}
-		if region.GetPeer(peer.GetId()) != nil {
+		if p := region.GetPeer(peer.GetId()); p != nil && p.GetRole() == peer.GetRole() {
 			// Peer is added and finished.
 			op.State = OperatorFinished
 			return nil, true
@@ -387,6 +567,134 @@ func (op *changePeerOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatRespo
 	return res, false
 }
 
+// changePeerV2Operator swaps the voters in a joint consensus. The enter step
+// promotes the learners and demotes the voters at once, and the leave step
+// finishes the joint state, so the quorum never loses a voter during the
+// swap.
+type changePeerV2Operator struct {
+	Name         string             `json:"name"`
+	RegionID     uint64             `json:"region_id"`
+	Promote      []*metapb.Peer     `json:"promote"`
+	Demote       []*metapb.Peer     `json:"demote"`
+	ChangePeerV2 *pdpb.ChangePeerV2 `json:"change_peer_v2"`
+	State        OperatorState      `json:"state"`
+	leave        bool
+}
+
+// newEnterJointOperator promotes the learners to voters and demotes the
+// voters to learners in a joint consensus.
+func newEnterJointOperator(regionID uint64, promote, demote []*metapb.Peer) *changePeerV2Operator {
+	changes := make([]*pdpb.ChangePeer, 0, len(promote)+len(demote))
+	for _, p := range promote {
+		changes = append(changes, &pdpb.ChangePeer{
+			ChangeType: pdpb.ConfChangeType_AddNode,
+			Peer:       &metapb.Peer{Id: p.GetId(), StoreId: p.GetStoreId(), Role: metapb.PeerRole_Voter},
+		})
+	}
+	for _, p := range demote {
+		changes = append(changes, &pdpb.ChangePeer{
+			ChangeType: pdpb.ConfChangeType_AddLearnerNode,
+			Peer:       &metapb.Peer{Id: p.GetId(), StoreId: p.GetStoreId(), Role: metapb.PeerRole_Learner},
+		})
+	}
+	return &changePeerV2Operator{
+		Name:         "enter_joint",
+		RegionID:     regionID,
+		Promote:      promote,
+		Demote:       demote,
+		ChangePeerV2: &pdpb.ChangePeerV2{Changes: changes},
+		State:        OperatorWaiting,
+	}
+}
+
+// newLeaveJointOperator leaves the joint consensus entered by the enter step
+// with the same peers.
+func newLeaveJointOperator(regionID uint64, promote, demote []*metapb.Peer) *changePeerV2Operator {
+	return &changePeerV2Operator{
+		Name:         "leave_joint",
+		RegionID:     regionID,
+		Promote:      promote,
+		Demote:       demote,
+		ChangePeerV2: &pdpb.ChangePeerV2{},
+		State:        OperatorWaiting,
+		leave:        true,
+	}
+}
+
+func (op *changePeerV2Operator) String() string {
+	return fmt.Sprintf("%+v", *op)
+}
+
+func (op *changePeerV2Operator) GetRegionID() uint64 {
+	return op.RegionID
+}
+
+func (op *changePeerV2Operator) GetResourceKind() ResourceKind {
+	return RegionKind
+}
+
+func (op *changePeerV2Operator) GetState() OperatorState {
+	return op.State
+}
+
+func (op *changePeerV2Operator) SetState(state OperatorState) {
+	if op.State == OperatorFinished {
+		return
+	}
+	op.State = state
+}
+
+func (op *changePeerV2Operator) GetName() string {
+	return op.Name
+}
+
+// isFinished checks the roles of the peers. After entering the joint state,
+// the promoted peers are incoming voters and the demoted peers are demoting
+// voters, or they have left it already. After leaving, the promoted peers
+// are voters and the demoted peers are learners or removed.
+func (op *changePeerV2Operator) isFinished(region *RegionInfo) bool {
+	for _, p := range op.Promote {
+		peer := region.GetPeer(p.GetId())
+		if peer == nil {
+			return false
+		}
+		if role := peer.GetRole(); role != metapb.PeerRole_Voter && (op.leave || role != metapb.PeerRole_IncomingVoter) {
+			return false
+		}
+	}
+	for _, p := range op.Demote {
+		peer := region.GetPeer(p.GetId())
+		if peer == nil {
+			continue
+		}
+		if role := peer.GetRole(); role != metapb.PeerRole_Learner && (op.leave || role != metapb.PeerRole_DemotingVoter) {
+			return false
+		}
+	}
+	return true
+}
+
+func (op *changePeerV2Operator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatResponse, bool) {
+	if op.isFinished(region) {
+		op.State = OperatorFinished
+		return nil, true
+	}
+	// The learners are promoted after they catch up with the leader.
+	for _, p := range op.Promote {
+		if !op.leave && region.GetPendingPeer(p.GetId()) != nil {
+			return nil, false
+		}
+	}
+
+	log.Infof("[region %d] Do operator %s {promote %v, demote %v}", region.GetId(), op.Name, op.Promote, op.Demote)
+
+	op.State = OperatorRunning
+	res := &pdpb.RegionHeartbeatResponse{
+		ChangePeerV2: op.ChangePeerV2,
+	}
+	return res, false
+}
+
 type transferLeaderOperator struct {
 	Name      string        `json:"name"`
 	RegionID  uint64        `json:"region_id"`
@@ -448,3 +756,161 @@ func (op *transferLeaderOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatR
 	}
 	return res, false
 }
+
+// mergeRegionOperator merges the source region into the target region. The
+// operator of the source region asks TiKV to merge, and the passive one of
+// the target region waits until the target covers the source.
+type mergeRegionOperator struct {
+	Name       string         `json:"name"`
+	RegionID   uint64         `json:"region_id"`
+	FromRegion *metapb.Region `json:"from_region"`
+	ToRegion   *metapb.Region `json:"to_region"`
+	IsPassive  bool           `json:"is_passive"`
+	State      OperatorState  `json:"state"`
+}
+
+func newMergeRegionOperator(source, target *metapb.Region, isPassive bool) *mergeRegionOperator {
+	regionID := source.GetId()
+	if isPassive {
+		regionID = target.GetId()
+	}
+	return &mergeRegionOperator{
+		Name:       "merge_region",
+		RegionID:   regionID,
+		FromRegion: source,
+		ToRegion:   target,
+		IsPassive:  isPassive,
+		State:      OperatorWaiting,
+	}
+}
+
+func (op *mergeRegionOperator) String() string {
+	return fmt.Sprintf("%+v", *op)
+}
+
+func (op *mergeRegionOperator) GetRegionID() uint64 {
+	return op.RegionID
+}
+
+func (op *mergeRegionOperator) GetResourceKind() ResourceKind {
+	return RegionKind
+}
+
+func (op *mergeRegionOperator) GetState() OperatorState {
+	return op.State
+}
+
+func (op *mergeRegionOperator) SetState(state OperatorState) {
+	if op.State == OperatorFinished {
+		return
+	}
+	op.State = state
+}
+
+func (op *mergeRegionOperator) GetName() string {
+	return op.Name
+}
+
+func (op *mergeRegionOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatResponse, bool) {
+	if op.IsPassive {
+		// Check if the target covers the source.
+		if bytes.Equal(region.GetStartKey(), op.FromRegion.GetStartKey()) ||
+			bytes.Equal(region.GetEndKey(), op.FromRegion.GetEndKey()) {
+			op.State = OperatorFinished
+			return nil, true
+		}
+		return nil, false
+	}
+
+	log.Infof("[region %d] Do operator %s, merge into region %d", region.GetId(), op.Name, op.ToRegion.GetId())
+	op.State = OperatorRunning
+	res := &pdpb.RegionHeartbeatResponse{
+		Merge: &pdpb.Merge{
+			Target: op.ToRegion,
+		},
+	}
+	return res, false
+}
+
+// splitRegionOperator asks TiKV to split the region by the policy, or at the
+// keys if they are specified. It's finished once the range of the region
+// changes.
+type splitRegionOperator struct {
+	Name     string           `json:"name"`
+	RegionID uint64           `json:"region_id"`
+	StartKey []byte           `json:"start_key"`
+	EndKey   []byte           `json:"end_key"`
+	Policy   pdpb.CheckPolicy `json:"policy"`
+	Keys     [][]byte         `json:"keys"`
+	State    OperatorState    `json:"state"`
+}
+
+func newSplitRegionOperator(region *metapb.Region, policy pdpb.CheckPolicy, keys [][]byte) *splitRegionOperator {
+	return &splitRegionOperator{
+		Name:     "split_region",
+		RegionID: region.GetId(),
+		StartKey: region.GetStartKey(),
+		EndKey:   region.GetEndKey(),
+		Policy:   policy,
+		Keys:     keys,
+		State:    OperatorWaiting,
+	}
+}
+
+func (op *splitRegionOperator) String() string {
+	return fmt.Sprintf("%+v", *op)
+}
+
+func (op *splitRegionOperator) GetRegionID() uint64 {
+	return op.RegionID
+}
+
+func (op *splitRegionOperator) GetResourceKind() ResourceKind {
+	return RegionKind
+}
+
+func (op *splitRegionOperator) GetState() OperatorState {
+	return op.State
+}
+
+func (op *splitRegionOperator) SetState(state OperatorState) {
+	if op.State == OperatorFinished {
+		return
+	}
+	op.State = state
+}
+
+func (op *splitRegionOperator) GetName() string {
+	return op.Name
+}
+
+func (op *splitRegionOperator) Do(region *RegionInfo) (*pdpb.RegionHeartbeatResponse, bool) {
+	// Check if the region is split.
+	if !bytes.Equal(region.GetStartKey(), op.StartKey) || !bytes.Equal(region.GetEndKey(), op.EndKey) {
+		op.State = OperatorFinished
+		return nil, true
+	}
+
+	log.Infof("[region %d] Do operator %s, policy %v, keys %q", region.GetId(), op.Name, op.Policy, op.Keys)
+	op.State = OperatorRunning
+	res := &pdpb.RegionHeartbeatResponse{
+		SplitRegion: &pdpb.SplitRegion{
+			Policy: op.Policy,
+			Keys:   op.Keys,
+		},
+	}
+	return res, false
+}
+
+// getMergeSource returns the source region ID if the operator is the passive
+// one of a merge.
+func getMergeSource(op Operator) uint64 {
+	steps := getOperatorSteps(op)
+	if len(steps) == 0 {
+		return 0
+	}
+	if merge, ok := steps[len(steps)-1].(*mergeRegionOperator); ok && merge.IsPassive {
+		return merge.FromRegion.GetId()
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "time"
+
+// OperatorRecord is an operator removed from the coordinator, with its timing
+// and outcome, which tells why and when a region was scheduled.
+type OperatorRecord struct {
+	RegionID uint64       `json:"region_id"`
+	Kind     ResourceKind `json:"kind"`
+	// Outcome is one of "finished", "timeout", "replaced" and "canceled".
+	Outcome  string    `json:"outcome"`
+	Start    time.Time `json:"start"`
+	Finish   time.Time `json:"finish"`
+	Operator Operator  `json:"operator"`
+}
+
+func newOperatorRecord(op Operator, finish time.Time) *OperatorRecord {
+	record := &OperatorRecord{
+		RegionID: op.GetRegionID(),
+		Kind:     op.GetResourceKind(),
+		Outcome:  getOperatorOutcome(op),
+		Finish:   finish,
+		Operator: op,
+	}
+	switch op := op.(type) {
+	case *regionOperator:
+		record.Start = op.Start
+	case *adminOperator:
+		record.Start = op.Start
+	}
+	return record
+}
+
+// getOperatorOutcome returns how the operator ended.
+func getOperatorOutcome(op Operator) string {
+	switch op.GetState() {
+	case OperatorFinished:
+		return "finished"
+	case OperatorTimeOut:
+		return "timeout"
+	case OperatorReplaced:
+		return "replaced"
+	default:
+		return "canceled"
+	}
+}
+
+// getOperatorRecords returns the records of the operators which ended in
+// [start, end), 0 regionID means all the regions.
+func (c *coordinator) getOperatorRecords(regionID uint64, start, end time.Time) []*OperatorRecord {
+	var records []*OperatorRecord
+	for _, elem := range c.records.elems() {
+		record := elem.value.(*OperatorRecord)
+		if regionID != 0 && record.RegionID != regionID {
+			continue
+		}
+		if record.Finish.Before(start) || !record.Finish.Before(end) {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
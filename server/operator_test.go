@@ -18,7 +18,8 @@ import (
 	"time"
 
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 var _ = Suite(&testResouceKindSuite{})
@@ -195,8 +196,74 @@ func (o *testOperatorSuite) TestOperatorState(c *C) {
 	op.SetState(OperatorRunning)
 	c.Assert(op.GetState(), Equals, OperatorRunning)
 
-	regionOp.Start = regionOp.Start.Add(-maxOperatorWaitTime).Add(-time.Minute)
+	regionOp.Start = regionOp.Start.Add(-getOperatorTimeout(regionOp.Kind)).Add(-time.Minute)
 	op.Do(regionInfo)
 	c.Assert(op.GetState(), Equals, OperatorTimeOut)
 
 }
+
+func (o *testOperatorSuite) TestChangePeerRole(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+
+	// Demote the peer in store 3 to a learner.
+	op := newChangePeerRoleOperator(1, region.GetStorePeer(3), metapb.PeerRole_Learner)
+	c.Assert(getSnapshotStores(op), HasLen, 0)
+	res, finished := op.Do(region)
+	c.Assert(finished, IsFalse)
+	c.Assert(res.GetChangePeer().GetChangeType(), Equals, pdpb.ConfChangeType_AddLearnerNode)
+	c.Assert(res.GetChangePeer().GetPeer().GetId(), Equals, region.GetStorePeer(3).GetId())
+
+	region.GetStorePeer(3).Role = metapb.PeerRole_Learner
+	res, finished = op.Do(region)
+	c.Assert(res, IsNil)
+	c.Assert(finished, IsTrue)
+	c.Assert(region.GetVoters(), HasLen, 2)
+	c.Assert(region.GetFollowers(), HasLen, 1)
+	c.Assert(region.GetFollowers(), HasKey, uint64(2))
+
+	// The replica checker only counts voters.
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+	tc.addRegionStore(4, 1)
+	checkAddPeer(c, rc.Check(region), 4)
+
+	// Promote it back to a voter.
+	op = newChangePeerRoleOperator(1, region.GetStorePeer(3), metapb.PeerRole_Voter)
+	res, finished = op.Do(region)
+	c.Assert(finished, IsFalse)
+	c.Assert(res.GetChangePeer().GetChangeType(), Equals, pdpb.ConfChangeType_AddNode)
+	region.GetStorePeer(3).Role = metapb.PeerRole_Voter
+	_, finished = op.Do(region)
+	c.Assert(finished, IsTrue)
+	c.Assert(rc.Check(region), IsNil)
+}
+
+func (o *testOperatorSuite) TestSplitRegion(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	tc.addRegionStore(1, 1)
+	tc.addLeaderRegionInRange(1, "a", "c", 1, 1)
+	region := cluster.getRegion(1)
+
+	op := newSplitRegionOperator(region.Region, pdpb.CheckPolicy_USEKEY, [][]byte{[]byte("b")})
+	c.Assert(getSnapshotStores(op), HasLen, 0)
+	res, finished := op.Do(region)
+	c.Assert(finished, IsFalse)
+	c.Assert(op.GetState(), Equals, OperatorRunning)
+	c.Assert(res.GetSplitRegion().GetPolicy(), Equals, pdpb.CheckPolicy_USEKEY)
+	c.Assert(res.GetSplitRegion().GetKeys(), DeepEquals, [][]byte{[]byte("b")})
+
+	// The region keeps the right half after the split.
+	region = region.clone()
+	region.StartKey = []byte("b")
+	res, finished = op.Do(region)
+	c.Assert(res, IsNil)
+	c.Assert(finished, IsTrue)
+	c.Assert(op.GetState(), Equals, OperatorFinished)
+}
@@ -0,0 +1,534 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// The roles of the peers placed by the placement rules.
+var rulePeerRoles = map[string]metapb.PeerRole{
+	"voter":    metapb.PeerRole_Voter,
+	"learner":  metapb.PeerRole_Learner,
+	"observer": metapb.PeerRole_Observer,
+	"witness":  metapb.PeerRole_Witness,
+}
+
+// PlacementRule places Count peers of the Role, which is one of "voter",
+// "learner", "observer" and "witness", on the stores with all the labels of
+// Constraints.
+type PlacementRule struct {
+	Role        string               `json:"role"`
+	Count       int                  `json:"count"`
+	Constraints []*metapb.StoreLabel `json:"constraints,omitempty"`
+}
+
+func newRoleRule(role metapb.PeerRole) *PlacementRule {
+	return &PlacementRule{Role: strings.ToLower(role.String())}
+}
+
+func (r *PlacementRule) validate() error {
+	if _, ok := rulePeerRoles[r.Role]; !ok {
+		return errors.Errorf("invalid role %q", r.Role)
+	}
+	if r.Count <= 0 {
+		return errors.Errorf("invalid count %d", r.Count)
+	}
+	for _, label := range r.Constraints {
+		if label.GetKey() == "" || label.GetValue() == "" {
+			return errors.New("invalid constraint")
+		}
+	}
+	return nil
+}
+
+// peerRole returns the role of the peers placed by the rule.
+func (r *PlacementRule) peerRole() metapb.PeerRole {
+	return rulePeerRoles[r.Role]
+}
+
+// allowStore checks whether the store has all the labels of the constraints.
+func (r *PlacementRule) allowStore(store *storeInfo) bool {
+	if r == nil {
+		return true
+	}
+	for _, label := range r.Constraints {
+		if store.getLabelValue(label.GetKey()) != label.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+// KeyRangePlacement is the placement policy of the regions in the key range
+// [StartKey, EndKey), an empty EndKey means the range is unbounded.
+type KeyRangePlacement struct {
+	ID       string `json:"id"`
+	StartKey []byte `json:"start_key"`
+	EndKey   []byte `json:"end_key"`
+	// Replicas is the number of replicas of the regions, 0 means using the
+	// max-replicas of the cluster.
+	Replicas int `json:"replicas"`
+	// LeaderLabel limits the leaders to the stores with the label.
+	LeaderLabel *metapb.StoreLabel `json:"leader_label,omitempty"`
+	// Constraints limit the peers to the stores with all the labels.
+	Constraints []*metapb.StoreLabel `json:"constraints,omitempty"`
+	// Witnesses is the number of the replicas which are witnesses, they vote
+	// but only keep the raft log, so they don't count for data durability.
+	Witnesses int `json:"witnesses,omitempty"`
+	// WitnessConstraints limit the witnesses to the stores with all the
+	// labels instead of Constraints, such as the cheap stores.
+	WitnessConstraints []*metapb.StoreLabel `json:"witness_constraints,omitempty"`
+	// Learners is the number of the learners besides the replicas, they
+	// receive the data but don't vote, such as the replicas serving the
+	// analytical queries. 0 leaves the learners to the admin.
+	Learners int `json:"learners,omitempty"`
+	// LearnerConstraints limit the learners to the stores with all the
+	// labels instead of Constraints.
+	LearnerConstraints []*metapb.StoreLabel `json:"learner_constraints,omitempty"`
+	// Rules place the peers by their roles, such as 3 voters in zone z1 and 1
+	// learner in zone z2. The replicas, witnesses and learners above are the
+	// shorthand of a voter rule, a witness rule and a learner rule, they
+	// can't be used with the rules.
+	Rules []*PlacementRule `json:"rules,omitempty"`
+}
+
+func (p *KeyRangePlacement) validate() error {
+	if p.ID == "" || strings.Contains(p.ID, "/") {
+		return errors.Errorf("invalid placement id %q", p.ID)
+	}
+	if len(p.EndKey) > 0 && bytes.Compare(p.StartKey, p.EndKey) >= 0 {
+		return errors.Errorf("invalid key range of placement %s", p.ID)
+	}
+	if p.Replicas < 0 {
+		return errors.Errorf("invalid replicas %d of placement %s", p.Replicas, p.ID)
+	}
+	if p.LeaderLabel != nil && (p.LeaderLabel.GetKey() == "" || p.LeaderLabel.GetValue() == "") {
+		return errors.Errorf("invalid leader label of placement %s", p.ID)
+	}
+	for _, label := range p.Constraints {
+		if label.GetKey() == "" || label.GetValue() == "" {
+			return errors.Errorf("invalid constraint of placement %s", p.ID)
+		}
+	}
+	// At least one replica keeps the data.
+	if p.Witnesses < 0 || (p.Replicas > 0 && p.Witnesses >= p.Replicas) {
+		return errors.Errorf("invalid witnesses %d of placement %s", p.Witnesses, p.ID)
+	}
+	for _, label := range p.WitnessConstraints {
+		if label.GetKey() == "" || label.GetValue() == "" {
+			return errors.Errorf("invalid witness constraint of placement %s", p.ID)
+		}
+	}
+	if p.Learners < 0 {
+		return errors.Errorf("invalid learners %d of placement %s", p.Learners, p.ID)
+	}
+	for _, label := range p.LearnerConstraints {
+		if label.GetKey() == "" || label.GetValue() == "" {
+			return errors.Errorf("invalid learner constraint of placement %s", p.ID)
+		}
+	}
+	return errors.Trace(p.validateRules())
+}
+
+func (p *KeyRangePlacement) validateRules() error {
+	if len(p.Rules) == 0 {
+		return nil
+	}
+	if p.Replicas != 0 || len(p.Constraints) > 0 || p.Witnesses != 0 || len(p.WitnessConstraints) > 0 ||
+		p.Learners != 0 || len(p.LearnerConstraints) > 0 {
+		return errors.Errorf("the rules of placement %s can't be used with the replicas, witnesses or learners", p.ID)
+	}
+	var voters int
+	for _, rule := range p.Rules {
+		if err := rule.validate(); err != nil {
+			return errors.Errorf("invalid rule of placement %s: %v", p.ID, err)
+		}
+		if rule.peerRole() == metapb.PeerRole_Voter {
+			voters += rule.Count
+		}
+	}
+	// At least one replica keeps the data and serves as the leader.
+	if voters == 0 {
+		return errors.Errorf("placement %s has no voter", p.ID)
+	}
+	return nil
+}
+
+func (p *KeyRangePlacement) overlaps(other *KeyRangePlacement) bool {
+	return (len(other.EndKey) == 0 || bytes.Compare(p.StartKey, other.EndKey) < 0) &&
+		(len(p.EndKey) == 0 || bytes.Compare(other.StartKey, p.EndKey) < 0)
+}
+
+// contains checks whether the region is in the key range.
+func (p *KeyRangePlacement) contains(region *metapb.Region) bool {
+	if bytes.Compare(region.GetStartKey(), p.StartKey) < 0 {
+		return false
+	}
+	if len(p.EndKey) == 0 {
+		return true
+	}
+	return len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), p.EndKey) <= 0
+}
+
+// allowLeader checks whether the store can hold the leaders.
+func (p *KeyRangePlacement) allowLeader(store *storeInfo) bool {
+	if p == nil || p.LeaderLabel == nil {
+		return true
+	}
+	return store.getLabelValue(p.LeaderLabel.GetKey()) == p.LeaderLabel.GetValue()
+}
+
+// allowTransferPeer checks whether the target can hold the peer of the rule,
+// and the region still has a store which can hold the leader after moving the
+// peer from source to target.
+func (p *KeyRangePlacement) allowTransferPeer(rule *PlacementRule, stores []*storeInfo, source, target *storeInfo) bool {
+	if !rule.allowStore(target) {
+		return false
+	}
+	if p == nil || p.LeaderLabel == nil || p.allowLeader(target) || !p.allowLeader(source) {
+		return true
+	}
+	for _, store := range stores {
+		if store.GetId() != source.GetId() && p.allowLeader(store) {
+			return true
+		}
+	}
+	return false
+}
+
+// placementRules holds the key range placements, the ranges don't overlap.
+type placementRules struct {
+	sync.RWMutex
+	rules map[string]*KeyRangePlacement
+}
+
+func newPlacementRules() *placementRules {
+	return &placementRules{
+		rules: make(map[string]*KeyRangePlacement),
+	}
+}
+
+func (r *placementRules) check(placement *KeyRangePlacement) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	if err := placement.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, rule := range r.rules {
+		if rule.ID != placement.ID && rule.overlaps(placement) {
+			return errors.Errorf("placement %s overlaps with %s", placement.ID, rule.ID)
+		}
+	}
+	return nil
+}
+
+func (r *placementRules) set(placement *KeyRangePlacement) {
+	r.Lock()
+	defer r.Unlock()
+	r.rules[placement.ID] = placement
+}
+
+func (r *placementRules) exist(id string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.rules[id]
+	return ok
+}
+
+func (r *placementRules) remove(id string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.rules, id)
+}
+
+// getAll returns the placements sorted by start key.
+func (r *placementRules) getAll() []*KeyRangePlacement {
+	r.RLock()
+	defer r.RUnlock()
+
+	rules := make([]*KeyRangePlacement, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return bytes.Compare(rules[i].StartKey, rules[j].StartKey) < 0
+	})
+	return rules
+}
+
+// get returns the placement which contains the region, or nil if there is
+// no such placement.
+func (r *placementRules) get(region *metapb.Region) *KeyRangePlacement {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.contains(region) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// getRegionPlacement returns the placement of the region, nil means the
+// region has no specific placement.
+func (c *clusterInfo) getRegionPlacement(region *RegionInfo) *KeyRangePlacement {
+	return c.placements.get(region.Region)
+}
+
+// getRegionWitnesses returns the number of the witnesses of the region, which
+// are part of its max replicas. At least one replica is not a witness.
+func (c *clusterInfo) getRegionWitnesses(region *RegionInfo, maxReplicas int) int {
+	placement := c.getRegionPlacement(region)
+	if placement == nil || placement.Witnesses <= 0 {
+		return 0
+	}
+	if placement.Witnesses >= maxReplicas {
+		return maxReplicas - 1
+	}
+	return placement.Witnesses
+}
+
+// getRegionRules returns the placement rules of the region. Without the
+// rules, the voters and the witnesses make up the max replicas, and the
+// learners are placed only if the placement has them, otherwise they are left
+// to the admin.
+func (c *clusterInfo) getRegionRules(region *RegionInfo, maxReplicas int) []*PlacementRule {
+	placement := c.getRegionPlacement(region)
+	if placement != nil && len(placement.Rules) > 0 {
+		return placement.Rules
+	}
+	replicas := c.getRegionMaxReplicas(region, maxReplicas)
+	witnesses := c.getRegionWitnesses(region, replicas)
+	voters := newRoleRule(metapb.PeerRole_Voter)
+	voters.Count = replicas - witnesses
+	rules := []*PlacementRule{voters}
+	if placement == nil {
+		return rules
+	}
+	voters.Constraints = placement.Constraints
+	if witnesses > 0 {
+		rule := newRoleRule(metapb.PeerRole_Witness)
+		rule.Count, rule.Constraints = witnesses, placement.WitnessConstraints
+		rules = append(rules, rule)
+	}
+	if placement.Learners > 0 {
+		rule := newRoleRule(metapb.PeerRole_Learner)
+		rule.Count, rule.Constraints = placement.Learners, placement.LearnerConstraints
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// countRulePeers returns the number of the peers of the roles placed by the
+// rules, all the roles are counted if roles is empty.
+func countRulePeers(rules []*PlacementRule, roles ...metapb.PeerRole) int {
+	var count int
+	for _, rule := range rules {
+		if len(roles) == 0 {
+			count += rule.Count
+			continue
+		}
+		for _, role := range roles {
+			if rule.peerRole() == role {
+				count += rule.Count
+			}
+		}
+	}
+	return count
+}
+
+// getRegionPeerCount returns the number of all the peers of the region placed
+// by its rules, which are its max replicas and the learners if any.
+func (c *clusterInfo) getRegionPeerCount(region *RegionInfo, maxReplicas int) int {
+	return countRulePeers(c.getRegionRules(region, maxReplicas))
+}
+
+// getRegionMaxReplicas returns the replica count of the region, which are the
+// voters and witnesses. The placement of the region takes precedence over its
+// namespace.
+func (c *clusterInfo) getRegionMaxReplicas(region *RegionInfo, maxReplicas int) int {
+	placement := c.getRegionPlacement(region)
+	if placement != nil && len(placement.Rules) > 0 {
+		return countRulePeers(placement.Rules, metapb.PeerRole_Voter, metapb.PeerRole_Witness)
+	}
+	if placement != nil && placement.Replicas > 0 {
+		return placement.Replicas
+	}
+	if ns := c.namespaces.get(c.getRegionNamespace(region)); ns != nil && ns.MaxReplicas > 0 {
+		return ns.MaxReplicas
+	}
+	return maxReplicas
+}
+
+// ruleFit is how the peers of a region fit its placement rules.
+type ruleFit struct {
+	rules []*PlacementRule
+	// peers are the peers placed by each rule, which may be more than the
+	// count of the rule.
+	peers [][]*metapb.Peer
+	// orphans are the peers of the roles placed by the rules, but on the
+	// stores which no rule of their roles allows.
+	orphans []*metapb.Peer
+}
+
+// fitRegion matches the peers of the region with its placement rules. The
+// rules with more constraints take the peers first, so a rule which allows
+// any store doesn't take the peer in zone z1 from a rule of zone z1. The
+// peers of the roles without rules, such as the learners added by the admin,
+// are not matched.
+func (c *clusterInfo) fitRegion(region *RegionInfo, maxReplicas int) *ruleFit {
+	rules := c.getRegionRules(region, maxReplicas)
+	fit := &ruleFit{
+		rules: rules,
+		peers: make([][]*metapb.Peer, len(rules)),
+	}
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(rules[order[i]].Constraints) > len(rules[order[j]].Constraints)
+	})
+
+	namespace := c.getRegionNamespace(region)
+	matched := make(map[uint64]struct{})
+	// The rules take the peers up to their counts first, then the rest.
+	for _, all := range []bool{false, true} {
+		for _, i := range order {
+			for _, peer := range region.GetPeers() {
+				if _, ok := matched[peer.GetId()]; ok || peer.GetRole() != rules[i].peerRole() {
+					continue
+				}
+				if !all && len(fit.peers[i]) >= rules[i].Count {
+					break
+				}
+				store := c.getStore(peer.GetStoreId())
+				if store == nil || !rules[i].allowStore(store) || c.getStoreNamespace(store.GetId()) != namespace {
+					continue
+				}
+				matched[peer.GetId()] = struct{}{}
+				fit.peers[i] = append(fit.peers[i], peer)
+			}
+		}
+	}
+
+	for _, peer := range region.GetPeers() {
+		if _, ok := matched[peer.GetId()]; !ok && countRulePeers(rules, peer.GetRole()) > 0 {
+			fit.orphans = append(fit.orphans, peer)
+		}
+	}
+	return fit
+}
+
+// getPeerRule returns the rule which places the peer. An orphan peer belongs
+// to the first rule of its role short of peers, or the first rule of its
+// role if none is short. The peer of a role without rules can be on any
+// store.
+func (f *ruleFit) getPeerRule(peer *metapb.Peer) *PlacementRule {
+	for i, peers := range f.peers {
+		for _, p := range peers {
+			if p.GetId() == peer.GetId() {
+				return f.rules[i]
+			}
+		}
+	}
+	var rule *PlacementRule
+	for i, r := range f.rules {
+		if r.peerRole() != peer.GetRole() {
+			continue
+		}
+		if len(f.peers[i]) < r.Count {
+			return r
+		}
+		if rule == nil {
+			rule = r
+		}
+	}
+	if rule == nil {
+		return newRoleRule(peer.GetRole())
+	}
+	return rule
+}
+
+// isShort checks whether the rule has fewer peers than its count.
+func (f *ruleFit) isShort(rule *PlacementRule) bool {
+	for i, r := range f.rules {
+		if r == rule {
+			return len(f.peers[i]) < r.Count
+		}
+	}
+	return false
+}
+
+// getRegionPeerRule returns the rule which places the peer of the region.
+func (c *clusterInfo) getRegionPeerRule(region *RegionInfo, peer *metapb.Peer, maxReplicas int) *PlacementRule {
+	return c.fitRegion(region, maxReplicas).getPeerRule(peer)
+}
+
+func (c *clusterInfo) putPlacement(placement *KeyRangePlacement) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.placements.check(placement); err != nil {
+		return errors.Trace(err)
+	}
+	if c.kv != nil {
+		if err := c.kv.savePlacement(placement); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.placements.set(placement)
+	return nil
+}
+
+func (c *clusterInfo) removePlacement(id string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.placements.exist(id) {
+		return errors.Errorf("placement %s not found", id)
+	}
+	if c.kv != nil {
+		if err := c.kv.removePlacement(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.placements.remove(id)
+	return nil
+}
+
+// GetPlacements returns all the key range placements.
+func (c *RaftCluster) GetPlacements() []*KeyRangePlacement {
+	return c.cachedCluster.placements.getAll()
+}
+
+// PutPlacement adds or updates a key range placement. The replicas and leaders
+// of the regions in the range will be scheduled by the replica checker.
+func (c *RaftCluster) PutPlacement(placement *KeyRangePlacement) error {
+	return c.cachedCluster.putPlacement(placement)
+}
+
+// RemovePlacement removes a key range placement.
+func (c *RaftCluster) RemovePlacement(id string) error {
+	return c.cachedCluster.removePlacement(id)
+}
@@ -0,0 +1,347 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+var _ = Suite(&testPlacementSuite{})
+
+type testPlacementSuite struct{}
+
+func (s *testPlacementSuite) TestPlacementRules(c *C) {
+	rules := newPlacementRules()
+
+	c.Assert(rules.check(&KeyRangePlacement{}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "a/b"}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t1", StartKey: []byte("b"), EndKey: []byte("a")}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t1", Replicas: -1}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t1", LeaderLabel: &metapb.StoreLabel{Key: "zone"}}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t1", Constraints: []*metapb.StoreLabel{{Value: "ssd"}}}), NotNil)
+
+	t1 := &KeyRangePlacement{ID: "t1", StartKey: []byte("b"), EndKey: []byte("d")}
+	c.Assert(rules.check(t1), IsNil)
+	rules.set(t1)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t2", StartKey: []byte("c")}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t2", EndKey: []byte("c")}), NotNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t2", StartKey: []byte("a"), EndKey: []byte("b")}), IsNil)
+	c.Assert(rules.check(&KeyRangePlacement{ID: "t1", StartKey: []byte("a"), EndKey: []byte("c")}), IsNil)
+	t2 := &KeyRangePlacement{ID: "t2", StartKey: []byte("d")}
+	c.Assert(rules.check(t2), IsNil)
+	rules.set(t2)
+	c.Assert(rules.getAll(), DeepEquals, []*KeyRangePlacement{t1, t2})
+
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("b"), EndKey: []byte("c")}), Equals, t1)
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("c"), EndKey: []byte("d")}), Equals, t1)
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("c"), EndKey: []byte("e")}), IsNil)
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("a"), EndKey: []byte("c")}), IsNil)
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("e")}), Equals, t2)
+
+	rules.remove("t2")
+	c.Assert(rules.exist("t2"), IsFalse)
+	c.Assert(rules.get(&metapb.Region{StartKey: []byte("e")}), IsNil)
+}
+
+func (s *testPlacementSuite) TestReplicaChecker(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 4, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(2, 3, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(3, 2, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(4, 1, map[string]string{"zone": "z2"})
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	placement := &KeyRangePlacement{ID: "t1", Replicas: 4}
+	c.Assert(cluster.putPlacement(placement), IsNil)
+	checkAddPeer(c, rc.Check(region), 4)
+	placement.Replicas = 2
+	checkRemovePeer(c, rc.Check(region), 1)
+
+	// No peer is in z2, move the worst follower to z2.
+	placement.Replicas = 3
+	placement.LeaderLabel = &metapb.StoreLabel{Key: "zone", Value: "z2"}
+	checkTransferPeer(c, rc.Check(region), 2, 4)
+
+	// Transfer the leader to z2.
+	tc.addLeaderRegion(1, 1, 2, 4)
+	region = cluster.getRegion(1)
+	checkTransferLeader(c, rc.Check(region), 1, 4)
+
+	// The peer in z2 should not be moved.
+	tc.addLeaderRegion(1, 4, 1, 2)
+	region = cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	c.Assert(cluster.removePlacement("t1"), IsNil)
+	c.Assert(cluster.removePlacement("t1"), NotNil)
+}
+
+func (s *testPlacementSuite) TestConstraints(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"disk": "hdd"})
+	tc.addLabelsStore(2, 1, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(3, 1, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(4, 9, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(5, 1, map[string]string{"disk": "hdd"})
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	placement := &KeyRangePlacement{
+		ID:          "t1",
+		Constraints: []*metapb.StoreLabel{{Key: "disk", Value: "ssd"}},
+	}
+	c.Assert(cluster.putPlacement(placement), IsNil)
+	stores := cluster.getRegionStores(region)
+	rule := cluster.getRegionPeerRule(region, region.GetStorePeer(2), opt.GetMaxReplicas())
+	c.Assert(rule.allowStore(cluster.getStore(1)), IsFalse)
+	c.Assert(rule.allowStore(cluster.getStore(4)), IsTrue)
+	c.Assert(placement.allowTransferPeer(rule, stores, cluster.getStore(2), cluster.getStore(5)), IsFalse)
+	c.Assert(placement.allowTransferPeer(rule, stores, cluster.getStore(1), cluster.getStore(4)), IsTrue)
+
+	// Store 5 has a smaller region score, but only store 4 has an ssd.
+	checkTransferPeer(c, rc.Check(region), 1, 4)
+
+	// Remove the peer on hdd if the region has too many replicas.
+	tc.addLeaderRegion(1, 2, 1, 3, 4)
+	region = cluster.getRegion(1)
+	checkRemovePeer(c, rc.Check(region), 1)
+
+	// New peers are only added on ssd.
+	tc.addLeaderRegion(1, 2, 3)
+	region = cluster.getRegion(1)
+	checkAddPeer(c, rc.Check(region), 4)
+	tc.setStoreDown(4)
+	c.Assert(rc.Check(region), IsNil)
+}
+
+func (s *testPlacementSuite) TestWitness(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(2, 1, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(3, 1, map[string]string{"disk": "ssd"})
+	tc.addLabelsStore(4, 9, map[string]string{"disk": "hdd"})
+	tc.addLabelsStore(5, 1, map[string]string{"disk": "ssd"})
+
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Replicas: 3, Witnesses: 3}), NotNil)
+	placement := &KeyRangePlacement{
+		ID:                 "t1",
+		Replicas:           3,
+		Witnesses:          1,
+		WitnessConstraints: []*metapb.StoreLabel{{Key: "disk", Value: "hdd"}},
+	}
+	c.Assert(cluster.putPlacement(placement), IsNil)
+
+	// The witness is added on hdd as a voter.
+	tc.addLeaderRegion(1, 1, 2)
+	region := cluster.getRegion(1)
+	op := rc.Check(region)
+	checkAddPeer(c, op, 4)
+	step := op.(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddNode)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Witness)
+
+	// The witness doesn't count for the data, another voter is added.
+	tc.addLeaderRegion(1, 1, 4)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Witness)
+	region = cluster.getRegion(1)
+	step = rc.Check(region).(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddNode)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Voter)
+	c.Assert(step.ChangePeer.GetPeer().GetStoreId(), Not(Equals), uint64(4))
+
+	tc.addLeaderRegion(1, 1, 2, 4)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Witness)
+	region = cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+	c.Assert(region.GetFollowers(), HasLen, 1)
+
+	// The witness never gets the leader.
+	c.Assert(newTransferLeader(region, region.GetStorePeer(4)), IsNil)
+	c.Assert(newTransferLeader(region, region.GetStorePeer(2)), NotNil)
+
+	// The witness on ssd is moved to hdd and stays a witness.
+	tc.addLeaderRegion(1, 1, 2, 3)
+	setPeerRole(cluster, 1, 3, metapb.PeerRole_Witness)
+	region = cluster.getRegion(1)
+	op = rc.Check(region)
+	checkTransferPeer(c, op, 3, 4)
+	step = op.(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Witness)
+
+	// Extra witnesses are removed.
+	tc.addLeaderRegion(1, 1, 2, 3, 4)
+	setPeerRole(cluster, 1, 3, metapb.PeerRole_Witness)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Witness)
+	region = cluster.getRegion(1)
+	checkRemovePeer(c, rc.Check(region), 3)
+}
+
+func (s *testPlacementSuite) TestLearner(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"engine": "row"})
+	tc.addLabelsStore(2, 1, map[string]string{"engine": "row"})
+	tc.addLabelsStore(3, 1, map[string]string{"engine": "row"})
+	tc.addLabelsStore(4, 9, map[string]string{"engine": "column"})
+	tc.addLabelsStore(5, 1, map[string]string{"engine": "row"})
+	tc.addLabelsStore(6, 5, map[string]string{"engine": "column"})
+
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Learners: -1}), NotNil)
+	placement := &KeyRangePlacement{
+		ID:                 "t1",
+		Replicas:           3,
+		Constraints:        []*metapb.StoreLabel{{Key: "engine", Value: "row"}},
+		Learners:           1,
+		LearnerConstraints: []*metapb.StoreLabel{{Key: "engine", Value: "column"}},
+	}
+	c.Assert(cluster.putPlacement(placement), IsNil)
+
+	// The learner is added on the column store besides the replicas.
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region := cluster.getRegion(1)
+	op := rc.Check(region)
+	checkAddPeer(c, op, 6)
+	step := op.(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetChangeType(), Equals, pdpb.ConfChangeType_AddLearnerNode)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Learner)
+
+	// The learner is kept and never promoted.
+	tc.addLeaderRegion(1, 1, 2, 3, 4)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	// The learner on the offline store is replaced by another learner.
+	tc.setStoreOffline(4)
+	op = rc.Check(region)
+	checkTransferPeer(c, op, 4, 6)
+	step = op.(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Learner)
+	tc.setStoreUp(4)
+
+	// The learner on a row store is moved to a column store.
+	tc.addLeaderRegion(1, 1, 2, 3, 5)
+	setPeerRole(cluster, 1, 5, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	checkTransferPeer(c, rc.Check(region), 5, 6)
+
+	// Extra learners are removed.
+	tc.addLeaderRegion(1, 1, 2, 3, 4, 6)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Learner)
+	setPeerRole(cluster, 1, 6, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	checkRemovePeer(c, rc.Check(region), 4)
+
+	// The learners are left to the admin without the placement.
+	c.Assert(cluster.removePlacement("t1"), IsNil)
+	c.Assert(rc.Check(region), IsNil)
+}
+
+func (s *testPlacementSuite) TestRules(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	rc := newReplicaChecker(opt, cluster)
+
+	tc.addLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(2, 1, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(3, 2, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(4, 1, map[string]string{"zone": "z2"})
+	tc.addLabelsStore(5, 2, map[string]string{"zone": "z2"})
+	tc.addLabelsStore(6, 9, map[string]string{"zone": "z1"})
+
+	z1 := []*metapb.StoreLabel{{Key: "zone", Value: "z1"}}
+	z2 := []*metapb.StoreLabel{{Key: "zone", Value: "z2"}}
+	voters := &PlacementRule{Role: "voter", Count: 3, Constraints: z1}
+	learner := &PlacementRule{Role: "learner", Count: 1, Constraints: z2}
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Replicas: 3, Rules: []*PlacementRule{voters}}), NotNil)
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Rules: []*PlacementRule{{Role: "leader", Count: 1}}}), NotNil)
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Rules: []*PlacementRule{{Role: "voter"}}}), NotNil)
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Rules: []*PlacementRule{learner}}), NotNil)
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Rules: []*PlacementRule{voters, learner}}), IsNil)
+
+	tc.addLeaderRegion(1, 1, 2)
+	region := cluster.getRegion(1)
+	c.Assert(cluster.getRegionMaxReplicas(region, 5), Equals, 3)
+	c.Assert(cluster.getRegionPeerCount(region, 5), Equals, 4)
+
+	// 3 voters in z1.
+	checkAddPeer(c, rc.Check(region), 3)
+	tc.addLeaderRegion(1, 1, 2, 4)
+	region = cluster.getRegion(1)
+	checkTransferPeer(c, rc.Check(region), 4, 3)
+
+	// And 1 learner in z2.
+	tc.addLeaderRegion(1, 1, 2, 3)
+	region = cluster.getRegion(1)
+	op := rc.Check(region)
+	checkAddPeer(c, op, 4)
+	step := op.(*regionOperator).Ops[0].(*changePeerOperator)
+	c.Assert(step.ChangePeer.GetPeer().GetRole(), Equals, metapb.PeerRole_Learner)
+	tc.addLeaderRegion(1, 1, 2, 3, 4)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	// The extra voter is removed.
+	tc.addLeaderRegion(1, 1, 2, 3, 4, 6)
+	setPeerRole(cluster, 1, 4, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	checkRemovePeer(c, rc.Check(region), 6)
+
+	// 2 voters in z1 and 1 voter in z2, a voter is added in z2 then the
+	// extra voter in z1 is removed.
+	voters.Count = 2
+	c.Assert(cluster.putPlacement(&KeyRangePlacement{ID: "t1", Rules: []*PlacementRule{
+		{Role: "voter", Count: 1, Constraints: z2}, voters, learner,
+	}}), IsNil)
+	tc.addLeaderRegion(1, 1, 2, 3, 5)
+	setPeerRole(cluster, 1, 5, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	checkAddPeer(c, rc.Check(region), 4)
+	tc.addLeaderRegion(1, 1, 2, 3, 4, 5)
+	setPeerRole(cluster, 1, 5, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	checkRemovePeer(c, rc.Check(region), 3)
+	tc.addLeaderRegion(1, 1, 2, 4, 5)
+	setPeerRole(cluster, 1, 5, metapb.PeerRole_Learner)
+	region = cluster.getRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+}
+
+func setPeerRole(cluster *clusterInfo, regionID, storeID uint64, role metapb.PeerRole) {
+	region := cluster.getRegion(regionID)
+	region.GetStorePeer(storeID).Role = role
+	cluster.putRegion(region)
+}
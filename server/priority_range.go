@@ -0,0 +1,185 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// PriorityKeyRange is a key range [StartKey, EndKey) whose regions are
+// checked and repaired before the others, such as the ranges of the meta and
+// system tables. An empty EndKey means the range is unbounded.
+type PriorityKeyRange struct {
+	ID       string `json:"id"`
+	StartKey []byte `json:"start_key"`
+	EndKey   []byte `json:"end_key"`
+}
+
+func (r *PriorityKeyRange) validate() error {
+	if r.ID == "" || strings.Contains(r.ID, "/") {
+		return errors.Errorf("invalid priority range id %q", r.ID)
+	}
+	if len(r.EndKey) > 0 && bytes.Compare(r.StartKey, r.EndKey) >= 0 {
+		return errors.Errorf("invalid key range of priority range %s", r.ID)
+	}
+	return nil
+}
+
+// overlaps checks whether the region has any key in the range.
+func (r *PriorityKeyRange) overlaps(region *metapb.Region) bool {
+	return (len(r.EndKey) == 0 || bytes.Compare(region.GetStartKey(), r.EndKey) < 0) &&
+		(len(region.GetEndKey()) == 0 || bytes.Compare(r.StartKey, region.GetEndKey()) < 0)
+}
+
+// priorityKeyRanges holds the priority key ranges, the ranges may overlap.
+type priorityKeyRanges struct {
+	sync.RWMutex
+	ranges map[string]*PriorityKeyRange
+}
+
+func newPriorityKeyRanges() *priorityKeyRanges {
+	return &priorityKeyRanges{
+		ranges: make(map[string]*PriorityKeyRange),
+	}
+}
+
+func (r *priorityKeyRanges) set(keyRange *PriorityKeyRange) {
+	r.Lock()
+	defer r.Unlock()
+	r.ranges[keyRange.ID] = keyRange
+}
+
+func (r *priorityKeyRanges) exist(id string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.ranges[id]
+	return ok
+}
+
+func (r *priorityKeyRanges) remove(id string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.ranges, id)
+}
+
+// getAll returns the priority ranges sorted by start key.
+func (r *priorityKeyRanges) getAll() []*PriorityKeyRange {
+	r.RLock()
+	defer r.RUnlock()
+
+	ranges := make([]*PriorityKeyRange, 0, len(r.ranges))
+	for _, keyRange := range r.ranges {
+		ranges = append(ranges, keyRange)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return bytes.Compare(ranges[i].StartKey, ranges[j].StartKey) < 0
+	})
+	return ranges
+}
+
+// contains checks whether the region overlaps any of the priority ranges.
+func (r *priorityKeyRanges) contains(region *metapb.Region) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, keyRange := range r.ranges {
+		if keyRange.overlaps(region) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPriorityRegion checks whether the region is in a priority key range.
+func (c *clusterInfo) isPriorityRegion(region *RegionInfo) bool {
+	return c.priorityRanges.contains(region.Region)
+}
+
+func (c *clusterInfo) putPriorityRange(keyRange *PriorityKeyRange) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := keyRange.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.kv != nil {
+		if err := c.kv.savePriorityRange(keyRange); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.priorityRanges.set(keyRange)
+	return nil
+}
+
+func (c *clusterInfo) removePriorityRange(id string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.priorityRanges.exist(id) {
+		return errors.Errorf("priority range %s not found", id)
+	}
+	if c.kv != nil {
+		if err := c.kv.removePriorityRange(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.priorityRanges.remove(id)
+	return nil
+}
+
+// patrolPriorityRanges checks the regions in the priority key ranges before
+// the patrol of all the regions, at most patrolScanRegionLimit regions are
+// checked each time. Their replica operators wait at the urgent priority.
+func (c *coordinator) patrolPriorityRanges() {
+	if !c.checkReady() || c.isSchedulePaused() {
+		return
+	}
+	limit := patrolScanRegionLimit
+	for _, keyRange := range c.cluster.priorityRanges.getAll() {
+		if limit <= 0 {
+			return
+		}
+		regions := c.cluster.scanRegions(keyRange.StartKey, keyRange.EndKey, limit)
+		limit -= len(regions)
+		for _, region := range regions {
+			if c.getOperator(region.GetId()) != nil {
+				continue
+			}
+			if op := c.checkReplica(region); op != nil {
+				c.scheduleOperator(c.newReplicaOperator(op))
+			}
+		}
+	}
+}
+
+// GetPriorityRanges returns all the priority key ranges.
+func (c *RaftCluster) GetPriorityRanges() []*PriorityKeyRange {
+	return c.cachedCluster.priorityRanges.getAll()
+}
+
+// PutPriorityRange adds or updates a priority key range.
+func (c *RaftCluster) PutPriorityRange(keyRange *PriorityKeyRange) error {
+	return c.cachedCluster.putPriorityRange(keyRange)
+}
+
+// RemovePriorityRange removes a priority key range.
+func (c *RaftCluster) RemovePriorityRange(id string) error {
+	return c.cachedCluster.removePriorityRange(id)
+}
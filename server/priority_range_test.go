@@ -0,0 +1,92 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testPriorityRangeSuite{})
+
+type testPriorityRangeSuite struct{}
+
+func (s *testPriorityRangeSuite) TestPriorityKeyRanges(c *C) {
+	c.Assert((&PriorityKeyRange{}).validate(), NotNil)
+	c.Assert((&PriorityKeyRange{ID: "a/b"}).validate(), NotNil)
+	c.Assert((&PriorityKeyRange{ID: "t1", StartKey: []byte("b"), EndKey: []byte("a")}).validate(), NotNil)
+
+	ranges := newPriorityKeyRanges()
+	t1 := &PriorityKeyRange{ID: "t1", StartKey: []byte("b"), EndKey: []byte("d")}
+	t2 := &PriorityKeyRange{ID: "t2", StartKey: []byte("f")}
+	ranges.set(t2)
+	ranges.set(t1)
+	c.Assert(ranges.getAll(), DeepEquals, []*PriorityKeyRange{t1, t2})
+
+	// A region is in the range if it has any key in the range.
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("a"), EndKey: []byte("c")}), IsTrue)
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("c"), EndKey: []byte("e")}), IsTrue)
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("a"), EndKey: []byte("b")}), IsFalse)
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("d"), EndKey: []byte("f")}), IsFalse)
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("e")}), IsTrue)
+
+	ranges.remove("t2")
+	c.Assert(ranges.exist("t2"), IsFalse)
+	c.Assert(ranges.contains(&metapb.Region{StartKey: []byte("e")}), IsFalse)
+}
+
+func (s *testPriorityRangeSuite) TestPatrolPriorityRanges(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.ReplicaScheduleLimit = 1
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 4)
+	tc.addRegionStore(2, 4)
+	tc.addRegionStore(3, 0)
+	tc.addLeaderRegionInRange(1, "", "a", defaultRegionSize, 1, 2)
+	tc.addLeaderRegionInRange(2, "a", "b", defaultRegionSize, 1, 2)
+	tc.addLeaderRegionInRange(3, "b", "c", defaultRegionSize, 1, 2)
+	tc.addLeaderRegionInRange(4, "c", "", defaultRegionSize, 1, 2)
+	c.Assert(cluster.putPriorityRange(&PriorityKeyRange{ID: "meta", StartKey: []byte("c")}), IsNil)
+	c.Assert(cluster.isPriorityRegion(cluster.getRegion(4)), IsTrue)
+
+	// The replica-schedule-limit is used up, region 2 waits.
+	c.Assert(co.patrolRegions(nil), IsNil)
+	checkAddPeer(c, co.getOperator(1), 3)
+	c.Assert(co.waiting.elems(), HasLen, 1)
+
+	// The regions in the priority range are checked regardless of the limit,
+	// and their operators run first.
+	co.patrolPriorityRanges()
+	elems := co.waiting.elems()
+	c.Assert(elems, HasLen, 2)
+	c.Assert(elems[0].op.GetRegionID(), Equals, uint64(4))
+	c.Assert(elems[0].priority, Equals, urgentPriority)
+
+	op := co.getOperator(1)
+	op.SetState(OperatorFinished)
+	co.removeOperator(op)
+	co.promoteWaitingOperators(time.Now())
+	checkAddPeer(c, co.getOperator(4), 3)
+	c.Assert(co.getOperator(2), IsNil)
+
+	c.Assert(cluster.removePriorityRange("meta"), IsNil)
+	c.Assert(cluster.removePriorityRange("meta"), NotNil)
+	c.Assert(cluster.isPriorityRegion(cluster.getRegion(4)), IsFalse)
+}
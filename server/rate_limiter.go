@@ -0,0 +1,142 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiterGCInterval is the interval to remove the buckets of the idle
+// clients.
+const rateLimiterGCInterval = time.Minute
+
+// tokenBucket is refilled with rate tokens per second, and holds the tokens
+// of one second at most.
+type tokenBucket struct {
+	tokens   float64
+	lastTime time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		tokens:   bucketSize(rate),
+		lastTime: now,
+	}
+}
+
+func bucketSize(rate float64) float64 {
+	if rate < 1 {
+		return 1
+	}
+	return rate
+}
+
+func (b *tokenBucket) refill(rate float64, now time.Time) {
+	b.tokens += now.Sub(b.lastTime).Seconds() * rate
+	if size := bucketSize(rate); b.tokens > size {
+		b.tokens = size
+	}
+	b.lastTime = now
+}
+
+type clientMethod struct {
+	client string
+	method string
+}
+
+// rateLimiter rejects the unary gRPC requests exceeding the rates of their
+// methods, so a misbehaving client can't starve the others and the
+// heartbeats. A method can be limited for all clients and for each client,
+// requests from the same host are treated as the same client.
+type rateLimiter struct {
+	sync.Mutex
+	methodRates map[string]float64
+	clientRates map[string]float64
+	methods     map[string]*tokenBucket
+	clients     map[clientMethod]*tokenBucket
+	lastGC      time.Time
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		methodRates: cfg.MethodRates,
+		clientRates: cfg.ClientRates,
+		methods:     make(map[string]*tokenBucket),
+		clients:     make(map[clientMethod]*tokenBucket),
+		lastGC:      time.Now(),
+	}
+}
+
+// allow takes a token for the request, it returns false and the type of the
+// exceeded limit if the request should be rejected.
+func (l *rateLimiter) allow(method, client string, now time.Time) (bool, string) {
+	clientRate, limitClient := l.clientRates[method]
+	methodRate, limitMethod := l.methodRates[method]
+	if !limitClient && !limitMethod {
+		return true, ""
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.gc(now)
+
+	var clientBucket, methodBucket *tokenBucket
+	if limitClient {
+		key := clientMethod{client: client, method: method}
+		clientBucket = l.clients[key]
+		if clientBucket == nil {
+			clientBucket = newTokenBucket(clientRate, now)
+			l.clients[key] = clientBucket
+		}
+		clientBucket.refill(clientRate, now)
+		if clientBucket.tokens < 1 {
+			return false, "client"
+		}
+	}
+	if limitMethod {
+		methodBucket = l.methods[method]
+		if methodBucket == nil {
+			methodBucket = newTokenBucket(methodRate, now)
+			l.methods[method] = methodBucket
+		}
+		methodBucket.refill(methodRate, now)
+		if methodBucket.tokens < 1 {
+			return false, "method"
+		}
+	}
+
+	if clientBucket != nil {
+		clientBucket.tokens--
+	}
+	if methodBucket != nil {
+		methodBucket.tokens--
+	}
+	return true, ""
+}
+
+// gc removes the buckets of the clients idle for a while, their buckets are
+// full anyway.
+func (l *rateLimiter) gc(now time.Time) {
+	if now.Sub(l.lastGC) < rateLimiterGCInterval {
+		return
+	}
+	l.lastGC = now
+	for key, bucket := range l.clients {
+		if now.Sub(bucket.lastTime) >= rateLimiterGCInterval {
+			delete(l.clients, key)
+		}
+	}
+}
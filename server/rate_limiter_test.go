@@ -0,0 +1,94 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var _ = Suite(&testRateLimiterSuite{})
+
+type testRateLimiterSuite struct{}
+
+func (s *testRateLimiterSuite) TestRateLimiter(c *C) {
+	l := newRateLimiter(RateLimitConfig{
+		MethodRates: map[string]float64{"GetRegion": 3},
+		ClientRates: map[string]float64{"GetRegion": 2, "AllocID": 0.5},
+	})
+	now := time.Now()
+
+	// Each client can send 2 requests, and 3 requests in total.
+	for i := 0; i < 2; i++ {
+		ok, _ := l.allow("GetRegion", "a", now)
+		c.Assert(ok, IsTrue)
+	}
+	ok, limit := l.allow("GetRegion", "a", now)
+	c.Assert(ok, IsFalse)
+	c.Assert(limit, Equals, "client")
+	ok, _ = l.allow("GetRegion", "b", now)
+	c.Assert(ok, IsTrue)
+	ok, limit = l.allow("GetRegion", "b", now)
+	c.Assert(ok, IsFalse)
+	c.Assert(limit, Equals, "method")
+
+	// The tokens are refilled.
+	now = now.Add(time.Second)
+	ok, _ = l.allow("GetRegion", "b", now)
+	c.Assert(ok, IsTrue)
+
+	// A request is allowed every 2 seconds.
+	ok, _ = l.allow("AllocID", "a", now)
+	c.Assert(ok, IsTrue)
+	ok, _ = l.allow("AllocID", "a", now.Add(time.Second))
+	c.Assert(ok, IsFalse)
+	ok, _ = l.allow("AllocID", "a", now.Add(2*time.Second))
+	c.Assert(ok, IsTrue)
+
+	// Other methods are not limited.
+	for i := 0; i < 10; i++ {
+		ok, _ = l.allow("GetStore", "a", now)
+		c.Assert(ok, IsTrue)
+	}
+
+	// Idle clients are removed.
+	c.Assert(l.clients, HasLen, 3)
+	l.allow("AllocID", "a", now.Add(time.Hour))
+	c.Assert(l.clients, HasLen, 1)
+}
+
+func (s *testRateLimiterSuite) TestRateLimitedRequests(c *C) {
+	cfg := NewTestSingleConfig()
+	cfg.RateLimit.ClientRates = map[string]float64{"AllocID": 1}
+	c.Assert(cfg.RateLimit.validate(), IsNil)
+	svrs, cleanup := newTestServersWithCfgs(c, []*Config{cfg})
+	defer cleanup()
+	grpcPDClient := mustNewGrpcClient(c, svrs[0].GetAddr())
+
+	req := &pdpb.AllocIDRequest{Header: newRequestHeader(svrs[0].clusterID)}
+	_, err := grpcPDClient.AllocID(context.Background(), req)
+	c.Assert(err, IsNil)
+	_, err = grpcPDClient.AllocID(context.Background(), req)
+	c.Assert(grpc.Code(err), Equals, codes.ResourceExhausted)
+	_, err = grpcPDClient.GetMembers(context.Background(), &pdpb.GetMembersRequest{Header: req.Header})
+	c.Assert(err, IsNil)
+
+	cfg.RateLimit.MethodRates = map[string]float64{"GetRegion": 0}
+	c.Assert(cfg.RateLimit.validate(), NotNil)
+}
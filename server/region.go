@@ -17,8 +17,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/btree"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 // RegionInfo record detail region info
@@ -28,6 +28,12 @@ type RegionInfo struct {
 	DownPeers    []*pdpb.PeerStats
 	PendingPeers []*metapb.Peer
 	WrittenBytes uint64
+	ReadBytes    uint64
+	ReadKeys     uint64
+	// ApproximateSize is the approximate size of the region in bytes, 0 if
+	// unknown.
+	ApproximateSize uint64
+	ApproximateKeys uint64
 }
 
 func newRegionInfo(region *metapb.Region, leader *metapb.Peer) *RegionInfo {
@@ -52,7 +58,21 @@ func (r *RegionInfo) clone() *RegionInfo {
 		DownPeers:    downPeers,
 		PendingPeers: pendingPeers,
 		WrittenBytes: r.WrittenBytes,
+		ReadBytes:    r.ReadBytes,
+		ReadKeys:     r.ReadKeys,
+
+		ApproximateSize: r.ApproximateSize,
+		ApproximateKeys: r.ApproximateKeys,
+	}
+}
+
+// regionSize returns the size of the region to balance by, the regions of
+// unknown size are counted as the default size.
+func (r *RegionInfo) regionSize() uint64 {
+	if r.ApproximateSize == 0 {
+		return defaultRegionSize
 	}
+	return r.ApproximateSize
 }
 
 // GetPeer return the peer with specified peer id
@@ -116,12 +136,13 @@ func (r *RegionInfo) GetStoreIds() map[uint64]struct{} {
 	return stores
 }
 
-// GetFollowers return a map indicate the follow peers distributed
+// GetFollowers return a map indicate the follow peers distributed, learners,
+// observers and witnesses are not followers since they can't be the leader.
 func (r *RegionInfo) GetFollowers() map[uint64]*metapb.Peer {
 	peers := r.GetPeers()
 	followers := make(map[uint64]*metapb.Peer, len(peers))
 	for _, peer := range peers {
-		if r.Leader == nil || r.Leader.GetId() != peer.GetId() {
+		if peer.GetRole() == metapb.PeerRole_Voter && (r.Leader == nil || r.Leader.GetId() != peer.GetId()) {
 			followers[peer.GetStoreId()] = peer
 		}
 	}
@@ -131,13 +152,73 @@ func (r *RegionInfo) GetFollowers() map[uint64]*metapb.Peer {
 // GetFollower randomly return a follow peer
 func (r *RegionInfo) GetFollower() *metapb.Peer {
 	for _, peer := range r.GetPeers() {
-		if r.Leader == nil || r.Leader.GetId() != peer.GetId() {
+		if peer.GetRole() == metapb.PeerRole_Voter && (r.Leader == nil || r.Leader.GetId() != peer.GetId()) {
 			return peer
 		}
 	}
 	return nil
 }
 
+// GetVoters returns the peers which can vote and keep the data, witnesses
+// are not included.
+func (r *RegionInfo) GetVoters() []*metapb.Peer {
+	var voters []*metapb.Peer
+	for _, peer := range r.GetPeers() {
+		if peer.GetRole() == metapb.PeerRole_Voter {
+			voters = append(voters, peer)
+		}
+	}
+	return voters
+}
+
+// GetWitnesses returns the peers which vote but only keep the raft log.
+func (r *RegionInfo) GetWitnesses() []*metapb.Peer {
+	var witnesses []*metapb.Peer
+	for _, peer := range r.GetPeers() {
+		if peer.GetRole() == metapb.PeerRole_Witness {
+			witnesses = append(witnesses, peer)
+		}
+	}
+	return witnesses
+}
+
+// GetLearners returns the peers which receive the data but don't vote.
+func (r *RegionInfo) GetLearners() []*metapb.Peer {
+	var learners []*metapb.Peer
+	for _, peer := range r.GetPeers() {
+		if peer.GetRole() == metapb.PeerRole_Learner {
+			learners = append(learners, peer)
+		}
+	}
+	return learners
+}
+
+// getJointPeers returns the incoming and demoting voters, the region is in
+// the joint consensus if there is any.
+func (r *RegionInfo) getJointPeers() (incoming, demoting []*metapb.Peer) {
+	for _, peer := range r.GetPeers() {
+		switch peer.GetRole() {
+		case metapb.PeerRole_IncomingVoter:
+			incoming = append(incoming, peer)
+		case metapb.PeerRole_DemotingVoter:
+			demoting = append(demoting, peer)
+		}
+	}
+	return incoming, demoting
+}
+
+// getNonVoterStoreIds returns the stores of the learners, observers and
+// witnesses.
+func (r *RegionInfo) getNonVoterStoreIds() map[uint64]struct{} {
+	stores := make(map[uint64]struct{})
+	for _, peer := range r.GetPeers() {
+		if peer.GetRole() != metapb.PeerRole_Voter {
+			stores[peer.GetStoreId()] = struct{}{}
+		}
+	}
+	return stores
+}
+
 var _ btree.Item = &regionItem{}
 
 type regionItem struct {
@@ -177,8 +258,20 @@ func (t *regionTree) length() int {
 
 // update updates the tree with the region.
 // It finds and deletes all the overlapped regions first, and then
-// insert the region.
-func (t *regionTree) update(region *metapb.Region) {
+// insert the region. The overlapped regions are returned.
+func (t *regionTree) update(region *metapb.Region) []*metapb.Region {
+	var regions []*metapb.Region
+	for _, item := range t.getOverlaps(region) {
+		t.tree.Delete(item)
+		regions = append(regions, item.region)
+	}
+
+	t.tree.ReplaceOrInsert(&regionItem{region: region})
+	return regions
+}
+
+// getOverlaps returns the items whose ranges overlap the region.
+func (t *regionTree) getOverlaps(region *metapb.Region) []*regionItem {
 	item := &regionItem{region: region}
 
 	result := t.find(region)
@@ -195,12 +288,7 @@ func (t *regionTree) update(region *metapb.Region) {
 		overlaps = append(overlaps, over)
 		return true
 	})
-
-	for _, item := range overlaps {
-		t.tree.Delete(item)
-	}
-
-	t.tree.ReplaceOrInsert(item)
+	return overlaps
 }
 
 // remove removes a region if the region is in the tree.
@@ -225,6 +313,52 @@ func (t *regionTree) search(regionKey []byte) *metapb.Region {
 	return result.region
 }
 
+// searchPrev returns the region before the one that contains the key.
+func (t *regionTree) searchPrev(regionKey []byte) *metapb.Region {
+	item := t.find(&metapb.Region{StartKey: regionKey})
+	if item == nil {
+		return nil
+	}
+
+	var prev *regionItem
+	// The items are sorted by start key reversely, so ascend to find the
+	// previous one.
+	t.tree.AscendGreaterOrEqual(item, func(i btree.Item) bool {
+		if i.(*regionItem) == item {
+			return true
+		}
+		prev = i.(*regionItem)
+		return false
+	})
+	if prev == nil {
+		return nil
+	}
+	return prev.region
+}
+
+// scanRange returns the regions in key order, starting from the one that
+// contains startKey. It stops before the region starting at or after endKey,
+// an empty endKey means the end of the key space. limit <= 0 means no limit.
+func (t *regionTree) scanRange(startKey, endKey []byte, limit int) []*metapb.Region {
+	start := &regionItem{region: &metapb.Region{StartKey: startKey}}
+	if item := t.find(start.region); item != nil {
+		start = item
+	}
+
+	var regions []*metapb.Region
+	// The items are sorted by start key reversely, so descend to scan in
+	// key order.
+	t.tree.DescendLessOrEqual(start, func(i btree.Item) bool {
+		region := i.(*regionItem).region
+		if len(endKey) > 0 && bytes.Compare(region.GetStartKey(), endKey) >= 0 {
+			return false
+		}
+		regions = append(regions, region)
+		return limit <= 0 || len(regions) < limit
+	})
+	return regions
+}
+
 // This is a helper function to find an item.
 func (t *regionTree) find(region *metapb.Region) *regionItem {
 	item := &regionItem{region: region}
@@ -0,0 +1,139 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// The states to filter the regions by.
+const (
+	RegionStateDownPeer    = "down-peer"
+	RegionStatePendingPeer = "pending-peer"
+	RegionStateMissPeer    = "miss-peer"
+	RegionStateExtraPeer   = "extra-peer"
+	RegionStateOfflinePeer = "offline-peer"
+	RegionStateEmptyRegion = "empty-region"
+)
+
+// emptyRegionApproximateSize is the max approximate size of the regions
+// counted as empty, the regions of unknown size are not empty.
+const emptyRegionApproximateSize = 1024 * 1024
+
+func isEmptyRegion(region *RegionInfo) bool {
+	return region.ApproximateSize != 0 && region.ApproximateSize <= emptyRegionApproximateSize
+}
+
+// queryScanBatch is the number of regions scanned at a time by queries, so
+// the cluster is not locked for long.
+const queryScanBatch = 1024
+
+// RegionQuery lists the regions in key order from StartKey. Limit <= 0 means
+// no limit, StoreID and State only keep the regions with a peer on the store
+// and in the state if they are set.
+type RegionQuery struct {
+	StartKey []byte
+	Limit    int
+	StoreID  uint64
+	State    string
+}
+
+func (q *RegionQuery) validate() error {
+	switch q.State {
+	case "", RegionStateDownPeer, RegionStatePendingPeer, RegionStateMissPeer, RegionStateExtraPeer,
+		RegionStateOfflinePeer, RegionStateEmptyRegion:
+		return nil
+	}
+	return errors.Errorf("unknown region state %q", q.State)
+}
+
+func (c *clusterInfo) matchRegionQuery(region *RegionInfo, q *RegionQuery, maxReplicas int) bool {
+	if q.StoreID != 0 && region.GetStorePeer(q.StoreID) == nil {
+		return false
+	}
+	replicas := len(region.GetVoters()) + len(region.GetWitnesses())
+	switch q.State {
+	case RegionStateDownPeer:
+		return len(region.DownPeers) > 0
+	case RegionStatePendingPeer:
+		return len(region.PendingPeers) > 0
+	case RegionStateMissPeer:
+		return replicas < c.getRegionMaxReplicas(region, maxReplicas)
+	case RegionStateExtraPeer:
+		return replicas > c.getRegionMaxReplicas(region, maxReplicas)
+	case RegionStateOfflinePeer:
+		for _, peer := range region.GetPeers() {
+			if store := c.getStore(peer.GetStoreId()); store != nil && store.isOffline() {
+				return true
+			}
+		}
+		return false
+	case RegionStateEmptyRegion:
+		return isEmptyRegion(region)
+	}
+	return true
+}
+
+// walkRegions calls f with the regions matching the query one by one without
+// holding the lock, and returns the start key of the next page, which is nil
+// after the last region. It stops at the first error returned by f.
+func (c *clusterInfo) walkRegions(q *RegionQuery, maxReplicas int, f func(*RegionInfo) error) ([]byte, error) {
+	count := 0
+	key := q.StartKey
+	for {
+		batch := c.scanRegions(key, nil, queryScanBatch)
+		for _, region := range batch {
+			if q.Limit > 0 && count >= q.Limit {
+				return region.GetStartKey(), nil
+			}
+			if c.matchRegionQuery(region, q, maxReplicas) {
+				if err := f(region); err != nil {
+					return nil, errors.Trace(err)
+				}
+				count++
+			}
+		}
+		if len(batch) < queryScanBatch {
+			return nil, nil
+		}
+		key = batch[len(batch)-1].GetEndKey()
+		if len(key) == 0 {
+			return nil, nil
+		}
+	}
+}
+
+// queryRegions returns the regions matching the query, and the start key of
+// the next page, which is nil after the last region.
+func (c *clusterInfo) queryRegions(q *RegionQuery, maxReplicas int) ([]*RegionInfo, []byte) {
+	var regions []*RegionInfo
+	nextKey, _ := c.walkRegions(q, maxReplicas, func(region *RegionInfo) error {
+		regions = append(regions, region)
+		return nil
+	})
+	return regions, nextKey
+}
+
+// WalkRegions calls f with the regions matching the query in key order, so
+// the regions can be written out without being collected in memory. It
+// returns the start key of the next page, which is nil after the last region.
+func (c *RaftCluster) WalkRegions(q *RegionQuery, f func(*metapb.Region) error) ([]byte, error) {
+	if err := q.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.cachedCluster.walkRegions(q, c.s.scheduleOpt.GetMaxReplicas(), func(region *RegionInfo) error {
+		return f(region.Region)
+	})
+}
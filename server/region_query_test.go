@@ -0,0 +1,88 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+var _ = Suite(&testRegionQuerySuite{})
+
+type testRegionQuerySuite struct{}
+
+func (s *testRegionQuerySuite) TestQueryRegions(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	regionCount := queryScanBatch + 10
+	for i := 0; i < regionCount; i++ {
+		start, end := fmt.Sprintf("%05d", i), fmt.Sprintf("%05d", i+1)
+		if i == regionCount-1 {
+			end = ""
+		}
+		tc.addLeaderRegionInRange(uint64(i+1), start, end, 1, 1, 2, 3)
+	}
+
+	regions, nextKey := cluster.queryRegions(&RegionQuery{}, 3)
+	c.Assert(regions, HasLen, regionCount)
+	c.Assert(nextKey, IsNil)
+
+	// Pages across the scan batches.
+	regions, nextKey = cluster.queryRegions(&RegionQuery{StartKey: []byte("01000"), Limit: 20}, 3)
+	c.Assert(regions, HasLen, 20)
+	c.Assert(regions[0].GetId(), Equals, uint64(1001))
+	c.Assert(string(nextKey), Equals, "01020")
+	regions, nextKey = cluster.queryRegions(&RegionQuery{StartKey: nextKey, Limit: 20}, 3)
+	c.Assert(regions, HasLen, regionCount-1020)
+	c.Assert(nextKey, IsNil)
+
+	// Filter by the store and the states.
+	tc.addLeaderRegionInRange(2, "00001", "00002", 1, 1, 2)
+	tc.addLeaderRegionInRange(3, "00002", "00003", 1, 4, 2, 3, 5)
+	region := cluster.getRegion(4)
+	region.DownPeers = []*pdpb.PeerStats{{Peer: region.GetStorePeer(2), DownSeconds: 100}}
+	region.PendingPeers = []*metapb.Peer{region.GetStorePeer(3)}
+	cluster.putRegion(region)
+	checkQueryRegions(c, cluster, &RegionQuery{StoreID: 4}, 3)
+	checkQueryRegions(c, cluster, &RegionQuery{StoreID: 5, Limit: 1}, 3)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateMissPeer}, 2)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateExtraPeer}, 3)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateDownPeer}, 4)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStatePendingPeer}, 4)
+	checkQueryRegions(c, cluster, &RegionQuery{StoreID: 4, State: RegionStateDownPeer})
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateOfflinePeer})
+	tc.addRegionStore(5, 1)
+	tc.setStoreOffline(5)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateOfflinePeer}, 3)
+
+	// The regions of unknown size are not empty.
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateEmptyRegion, Limit: 3}, 1, 2, 3)
+	tc.addLeaderRegionInRange(1, "", "00001", 0, 1, 2, 3)
+	tc.addLeaderRegionInRange(2, "00001", "00002", defaultRegionSize, 1, 2)
+	checkQueryRegions(c, cluster, &RegionQuery{State: RegionStateEmptyRegion, Limit: 2}, 3, 4)
+
+	c.Assert((&RegionQuery{State: "down"}).validate(), NotNil)
+}
+
+func checkQueryRegions(c *C, cluster *clusterInfo, q *RegionQuery, regionIDs ...uint64) {
+	regions, _ := cluster.queryRegions(q, 3)
+	c.Assert(regions, HasLen, len(regionIDs))
+	for i, region := range regions {
+		c.Assert(region.GetId(), Equals, regionIDs[i])
+	}
+}
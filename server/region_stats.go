@@ -0,0 +1,82 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+// RegionStats is the statistics of the regions in a key range.
+type RegionStats struct {
+	Count int `json:"count"`
+	// EmptyCount is the number of the regions counted as empty.
+	EmptyCount int `json:"empty_count"`
+	// StorageSize and StorageKeys are the sum of the approximate size and
+	// keys, the regions of unknown size are not counted.
+	StorageSize  uint64 `json:"storage_size"`
+	StorageKeys  uint64 `json:"storage_keys"`
+	BytesWritten uint64 `json:"bytes_written"`
+	BytesRead    uint64 `json:"bytes_read"`
+	KeysRead     uint64 `json:"keys_read"`
+	// store id -> the number of the leaders or peers in the range.
+	StoreLeaderCount map[uint64]int `json:"store_leader_count"`
+	StorePeerCount   map[uint64]int `json:"store_peer_count"`
+}
+
+func newRegionStats() *RegionStats {
+	return &RegionStats{
+		StoreLeaderCount: make(map[uint64]int),
+		StorePeerCount:   make(map[uint64]int),
+	}
+}
+
+func (s *RegionStats) observe(region *RegionInfo) {
+	s.Count++
+	if isEmptyRegion(region) {
+		s.EmptyCount++
+	}
+	s.StorageSize += region.ApproximateSize
+	s.StorageKeys += region.ApproximateKeys
+	s.BytesWritten += region.WrittenBytes
+	s.BytesRead += region.ReadBytes
+	s.KeysRead += region.ReadKeys
+	if leader := region.Leader; leader != nil {
+		s.StoreLeaderCount[leader.GetStoreId()]++
+	}
+	for _, peer := range region.GetPeers() {
+		s.StorePeerCount[peer.GetStoreId()]++
+	}
+}
+
+// getRegionStats returns the statistics of the regions overlapping with
+// [startKey, endKey), an empty endKey means the range is unbounded.
+func (c *clusterInfo) getRegionStats(startKey, endKey []byte) *RegionStats {
+	stats := newRegionStats()
+	key := startKey
+	for {
+		batch := c.scanRegions(key, endKey, queryScanBatch)
+		for _, region := range batch {
+			stats.observe(region)
+		}
+		if len(batch) < queryScanBatch {
+			return stats
+		}
+		key = batch[len(batch)-1].GetEndKey()
+		if len(key) == 0 {
+			return stats
+		}
+	}
+}
+
+// GetRegionStats returns the statistics of the regions overlapping with
+// [startKey, endKey), an empty endKey means the range is unbounded.
+func (c *RaftCluster) GetRegionStats(startKey, endKey []byte) *RegionStats {
+	return c.cachedCluster.getRegionStats(startKey, endKey)
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testRegionStatsSuite{})
+
+type testRegionStatsSuite struct{}
+
+func (s *testRegionStatsSuite) TestRegionStats(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	regionCount := queryScanBatch + 10
+	for i := 0; i < regionCount; i++ {
+		start, end := fmt.Sprintf("%05d", i), fmt.Sprintf("%05d", i+1)
+		if i == regionCount-1 {
+			end = ""
+		}
+		tc.addLeaderRegionInRange(uint64(i+1), start, end, defaultRegionSize, 1, 2, 3)
+	}
+	region := cluster.getRegion(1)
+	region.ApproximateSize = 1024
+	region.ApproximateKeys = 10
+	region.WrittenBytes = 100
+	region.ReadBytes = 200
+	region.ReadKeys = 2
+	cluster.putRegion(region)
+
+	stats := cluster.getRegionStats(nil, nil)
+	c.Assert(stats.Count, Equals, regionCount)
+	c.Assert(stats.EmptyCount, Equals, 1)
+	c.Assert(stats.StorageSize, Equals, uint64(regionCount-1)*defaultRegionSize+1024)
+	c.Assert(stats.StorageKeys, Equals, uint64(10))
+	c.Assert(stats.BytesWritten, Equals, uint64(100))
+	c.Assert(stats.BytesRead, Equals, uint64(200))
+	c.Assert(stats.KeysRead, Equals, uint64(2))
+	c.Assert(stats.StoreLeaderCount, DeepEquals, map[uint64]int{1: regionCount})
+	c.Assert(stats.StorePeerCount, DeepEquals, map[uint64]int{1: regionCount, 2: regionCount, 3: regionCount})
+
+	// The regions overlapping with the range are counted.
+	stats = cluster.getRegionStats([]byte("000055"), []byte("00010"))
+	c.Assert(stats.Count, Equals, 5)
+	c.Assert(stats.EmptyCount, Equals, 0)
+	stats = cluster.getRegionStats([]byte("01000"), nil)
+	c.Assert(stats.Count, Equals, regionCount-1000)
+	stats = cluster.getRegionStats([]byte("z"), nil)
+	c.Assert(stats.Count, Equals, 1)
+}
@@ -0,0 +1,314 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+)
+
+const (
+	// syncHistorySize is the number of the recent region changes kept by the
+	// leader, followers falling behind them are synced with all the regions.
+	syncHistorySize = 10000
+	// syncBatchSize is the max number of regions in a response.
+	syncBatchSize = 100
+	// syncCheckInterval is the interval to check whether the leader is
+	// changed while syncing.
+	syncCheckInterval = time.Second
+)
+
+// regionSyncer streams the region changes from the leader to the followers,
+// so they keep a warm copy of the regions with their leaders, and the new
+// leader doesn't need to wait for every region heartbeat after failover.
+//
+// The leader keeps the recent changes in a history buffer. The indices of a
+// term start from the time the leader is elected, so the indices synced
+// from the previous terms are always behind the history, and the followers
+// are synced with all the regions then.
+type regionSyncer struct {
+	s *Server
+
+	sync.RWMutex
+	history      []*RegionInfo
+	historyStart uint64
+	historyEnd   uint64
+	// changed is closed when a change is recorded.
+	changed chan struct{}
+
+	// For followers, the leader synced from and the next index to sync.
+	syncedLeader uint64
+	nextIndex    uint64
+	// synced is set once all the regions are synced from the leader.
+	synced bool
+}
+
+func newRegionSyncer(s *Server) *regionSyncer {
+	return &regionSyncer{
+		s:       s,
+		changed: make(chan struct{}),
+	}
+}
+
+// reset clears the history when the server becomes the leader.
+func (rs *regionSyncer) reset() {
+	rs.Lock()
+	defer rs.Unlock()
+
+	index := uint64(time.Now().UnixNano())
+	if index < rs.historyEnd {
+		index = rs.historyEnd
+	}
+	rs.history = make([]*RegionInfo, syncHistorySize)
+	rs.historyStart, rs.historyEnd = index, index
+	rs.syncedLeader, rs.nextIndex, rs.synced = 0, 0, false
+}
+
+// record adds the changed region to the history.
+func (rs *regionSyncer) record(region *RegionInfo) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	if rs.history == nil {
+		return
+	}
+	rs.history[rs.historyEnd%syncHistorySize] = region
+	rs.historyEnd++
+	if rs.historyEnd-rs.historyStart > syncHistorySize {
+		rs.historyStart = rs.historyEnd - syncHistorySize
+	}
+	close(rs.changed)
+	rs.changed = make(chan struct{})
+}
+
+// getHistory returns at most limit changes from index, and the index after
+// them. ok is false if index is not in the history. The returned channel is
+// closed when there are more changes.
+func (rs *regionSyncer) getHistory(index uint64, limit int) (regions []*RegionInfo, next uint64, changed <-chan struct{}, ok bool) {
+	rs.RLock()
+	defer rs.RUnlock()
+
+	if rs.history == nil || index < rs.historyStart || index > rs.historyEnd {
+		return nil, rs.historyEnd, rs.changed, false
+	}
+	for next = index; next < rs.historyEnd && len(regions) < limit; next++ {
+		regions = append(regions, rs.history[next%syncHistorySize])
+	}
+	return regions, next, rs.changed, true
+}
+
+func newSyncRegionResponse(header *pdpb.ResponseHeader, regions []*RegionInfo, next uint64) *pdpb.SyncRegionResponse {
+	resp := &pdpb.SyncRegionResponse{
+		Header:        header,
+		Regions:       make([]*metapb.Region, 0, len(regions)),
+		RegionLeaders: make([]*metapb.Peer, 0, len(regions)),
+		NextIndex:     next,
+	}
+	for _, region := range regions {
+		leader := region.Leader
+		if leader == nil {
+			leader = &metapb.Peer{}
+		}
+		resp.Regions = append(resp.Regions, region.Region)
+		resp.RegionLeaders = append(resp.RegionLeaders, leader)
+	}
+	return resp
+}
+
+// serve sends the region changes to the follower until it disconnects or
+// the server is not the leader any more.
+func (rs *regionSyncer) serve(stream pdpb.PD_SyncRegionsServer, request *pdpb.SyncRegionRequest) error {
+	cluster := rs.s.GetRaftCluster()
+	if cluster == nil {
+		return errors.Trace(stream.Send(&pdpb.SyncRegionResponse{Header: rs.s.notBootstrappedHeader()}))
+	}
+
+	member, index := request.GetMember(), request.GetStartIndex()
+	log.Infof("region syncer: %s starts to sync from index %d", member.GetName(), index)
+	ticker := time.NewTicker(syncCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		regions, next, changed, ok := rs.getHistory(index, syncBatchSize)
+		if !ok {
+			log.Infof("region syncer: sync all regions to %s", member.GetName())
+			if err := rs.sendAll(stream, cluster.cachedCluster.getRegions(), next); err != nil {
+				return errors.Trace(err)
+			}
+			index = next
+			continue
+		}
+		if len(regions) > 0 {
+			if err := stream.Send(newSyncRegionResponse(rs.s.header(), regions, next)); err != nil {
+				return errors.Trace(err)
+			}
+			index = next
+			continue
+		}
+
+		select {
+		case <-changed:
+		case <-ticker.C:
+			if !rs.s.IsLeader() {
+				return errors.Trace(notLeaderError)
+			}
+			// Tell the follower it is still synced when nothing changes.
+			if err := stream.Send(newSyncRegionResponse(rs.s.header(), nil, index)); err != nil {
+				return errors.Trace(err)
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// sendAll sends all the regions in batches, only the last batch has the next
+// index, so the follower doesn't move forward if the stream is broken in
+// the middle.
+func (rs *regionSyncer) sendAll(stream pdpb.PD_SyncRegionsServer, regions []*RegionInfo, next uint64) error {
+	for len(regions) > syncBatchSize {
+		if err := stream.Send(newSyncRegionResponse(rs.s.header(), regions[:syncBatchSize], 0)); err != nil {
+			return errors.Trace(err)
+		}
+		regions = regions[syncBatchSize:]
+	}
+	return errors.Trace(stream.Send(newSyncRegionResponse(rs.s.header(), regions, next)))
+}
+
+// isSynced returns whether all the regions are synced from the leader.
+func (rs *regionSyncer) isSynced() bool {
+	rs.RLock()
+	defer rs.RUnlock()
+	return rs.synced
+}
+
+// syncLoop keeps syncing the regions from the leader while the server is a
+// follower.
+func (rs *regionSyncer) syncLoop() {
+	defer rs.s.wg.Done()
+
+	for !rs.s.isClosed() {
+		if err := rs.syncFromLeader(); err != nil && !rs.s.isClosed() {
+			log.Errorf("region syncer: sync from leader err %v", err)
+		}
+		time.Sleep(syncCheckInterval)
+	}
+}
+
+// syncFromLeader syncs the regions until the stream is broken, the follower
+// is not regarded as synced any more then.
+func (rs *regionSyncer) syncFromLeader() (err error) {
+	defer func() {
+		if err != nil {
+			rs.Lock()
+			rs.synced = false
+			rs.Unlock()
+		}
+	}()
+
+	if rs.s.IsLeader() {
+		return nil
+	}
+	leader, err := rs.s.GetLeader()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if rs.s.isSameLeader(leader) || len(leader.GetClientUrls()) == 0 {
+		return nil
+	}
+	conn, err := rs.s.forwarder.getConn(leader.GetClientUrls()[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	rs.Lock()
+	if rs.syncedLeader != leader.GetMemberId() {
+		rs.syncedLeader, rs.nextIndex, rs.synced = leader.GetMemberId(), 0, false
+	}
+	index := rs.nextIndex
+	rs.Unlock()
+
+	ctx, cancel := context.WithCancel(rs.s.client.Ctx())
+	defer cancel()
+	go rs.checkLeader(ctx, cancel, leader)
+
+	stream, err := pdpb.NewPDClient(conn).SyncRegions(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = stream.Send(&pdpb.SyncRegionRequest{
+		Header:     &pdpb.RequestHeader{ClusterId: rs.s.clusterID},
+		Member:     &pdpb.Member{Name: rs.s.Name(), MemberId: rs.s.ID()},
+		StartIndex: index,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if resp.GetHeader().GetError() != nil {
+			return errors.Errorf("%v", resp.GetHeader().GetError())
+		}
+		rs.apply(resp)
+	}
+}
+
+// checkLeader stops syncing when the leader is changed.
+func (rs *regionSyncer) checkLeader(ctx context.Context, cancel context.CancelFunc, leader *pdpb.Member) {
+	ticker := time.NewTicker(syncCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if rs.s.isClosed() || rs.s.IsLeader() {
+				cancel()
+				return
+			}
+			if current, err := rs.s.GetLeader(); err == nil && current.GetMemberId() != leader.GetMemberId() {
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (rs *regionSyncer) apply(resp *pdpb.SyncRegionResponse) {
+	regions := make([]*RegionInfo, 0, len(resp.GetRegions()))
+	for i, region := range resp.GetRegions() {
+		var leader *metapb.Peer
+		if i < len(resp.GetRegionLeaders()) && resp.GetRegionLeaders()[i].GetId() != 0 {
+			leader = resp.GetRegionLeaders()[i]
+		}
+		regions = append(regions, newRegionInfo(region, leader))
+	}
+	rs.s.staleCache.putRegions(regions)
+
+	if next := resp.GetNextIndex(); next != 0 {
+		rs.Lock()
+		rs.nextIndex, rs.synced = next, true
+		rs.Unlock()
+		rs.s.staleCache.setRegionsSynced(time.Now())
+	}
+}
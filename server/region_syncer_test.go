@@ -0,0 +1,120 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testRegionSyncerSuite{})
+
+type testRegionSyncerSuite struct {
+	testClusterBaseSuite
+}
+
+func (s *testRegionSyncerSuite) TestHistory(c *C) {
+	rs := newRegionSyncer(nil)
+	rs.record(newRegionInfo(&metapb.Region{Id: 1}, nil))
+	_, _, _, ok := rs.getHistory(0, syncBatchSize)
+	c.Assert(ok, IsFalse)
+
+	rs.reset()
+	start := rs.historyStart
+	_, next, changed, ok := rs.getHistory(start, syncBatchSize)
+	c.Assert(ok, IsTrue)
+	c.Assert(next, Equals, start)
+	for i := uint64(1); i <= 3; i++ {
+		rs.record(newRegionInfo(&metapb.Region{Id: i}, nil))
+	}
+	select {
+	case <-changed:
+	default:
+		c.Fatal("changed is not closed")
+	}
+
+	regions, next, _, ok := rs.getHistory(start, 2)
+	c.Assert(ok, IsTrue)
+	c.Assert(regions, HasLen, 2)
+	c.Assert(regions[1].GetId(), Equals, uint64(2))
+	regions, next, _, ok = rs.getHistory(next, 2)
+	c.Assert(ok, IsTrue)
+	c.Assert(regions, HasLen, 1)
+	c.Assert(next, Equals, start+3)
+
+	// The indices of the previous term are behind the history.
+	rs.reset()
+	_, _, _, ok = rs.getHistory(next, syncBatchSize)
+	c.Assert(ok, IsFalse)
+
+	// The overwritten changes are out of the history.
+	start = rs.historyStart
+	for i := 0; i <= syncHistorySize; i++ {
+		rs.record(newRegionInfo(&metapb.Region{Id: 1}, nil))
+	}
+	_, _, _, ok = rs.getHistory(start, syncBatchSize)
+	c.Assert(ok, IsFalse)
+	_, _, _, ok = rs.getHistory(start+1, syncBatchSize)
+	c.Assert(ok, IsTrue)
+}
+
+func (s *testRegionSyncerSuite) TestSyncRegions(c *C) {
+	svrs, cleanup := newMultiTestServers(c, 3)
+	defer cleanup()
+	s.svr = mustWaitLeader(c, svrs)
+	s.grpcPDClient = mustNewGrpcClient(c, s.svr.GetAddr())
+	s.bootstrapCluster(c, s.svr.clusterID, "127.0.0.1:0")
+
+	// The leader of the region is synced to the followers.
+	cluster := s.svr.GetRaftCluster().cachedCluster
+	region := cluster.getRegions()[0]
+	region.Leader = region.GetPeers()[0]
+	c.Assert(cluster.handleRegionHeartbeat(region), IsNil)
+	var followers []*Server
+	for _, svr := range svrs {
+		if svr == s.svr {
+			continue
+		}
+		followers = append(followers, svr)
+		for i := 0; ; i++ {
+			synced := svr.staleCache.getRegions()
+			if len(synced) == 1 && synced[0].Leader.GetId() == region.Leader.GetId() {
+				break
+			}
+			c.Assert(i, Less, 100)
+			time.Sleep(100 * time.Millisecond)
+		}
+		c.Assert(svr.regionSyncer.isSynced(), IsTrue)
+	}
+	// The regions of the followers are kept fresh while nothing changes.
+	time.Sleep(3 * syncCheckInterval)
+	for _, svr := range followers {
+		svr.staleCache.RLock()
+		c.Assert(time.Since(svr.staleCache.regionsSyncTime), Less, 2*syncCheckInterval)
+		svr.staleCache.RUnlock()
+	}
+
+	// The new leader is warmed up with the synced regions.
+	s.svr.Close()
+	leader := mustWaitLeader(c, followers)
+	for i := 0; leader.GetRaftCluster() == nil; i++ {
+		c.Assert(i, Less, 100)
+		time.Sleep(100 * time.Millisecond)
+	}
+	warmed := leader.GetRaftCluster().cachedCluster.getRegion(region.GetId())
+	c.Assert(warmed.Leader.GetId(), Equals, region.Leader.GetId())
+	c.Assert(leader.GetRaftCluster().cachedCluster.getWarmUpStatus().ActiveRegions, Equals, 1)
+}
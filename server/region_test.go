@@ -18,8 +18,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 var _ = Suite(&testRegionSuite{})
@@ -149,7 +149,7 @@ func (s *testRegionSuite) TestRegionTree(c *C) {
 
 	// overlaps with 0, A, B, C.
 	region0D := newRegionItem([]byte(""), []byte("d")).region
-	tree.update(region0D)
+	c.Assert(tree.update(region0D), DeepEquals, []*metapb.Region{region0, regionA, regionB})
 	c.Assert(tree.search([]byte{}), Equals, region0D)
 	c.Assert(tree.search([]byte("a")), Equals, region0D)
 	c.Assert(tree.search([]byte("b")), Equals, region0D)
@@ -167,6 +167,54 @@ func (s *testRegionSuite) TestRegionTree(c *C) {
 	c.Assert(tree.search([]byte("e")), Equals, regionE)
 }
 
+func (s *testRegionSuite) TestRegionTreeScanRange(c *C) {
+	tree := newRegionTree()
+	c.Assert(tree.scanRange([]byte{}, []byte{}, 0), HasLen, 0)
+
+	regionA := newRegion([]byte{}, []byte("b"))
+	regionB := newRegion([]byte("b"), []byte("d"))
+	regionC := newRegion([]byte("d"), []byte("f"))
+	regionD := newRegion([]byte("f"), []byte{})
+	for _, region := range []*metapb.Region{regionD, regionB, regionA, regionC} {
+		tree.update(region)
+	}
+
+	all := []*metapb.Region{regionA, regionB, regionC, regionD}
+	c.Assert(tree.scanRange([]byte{}, []byte{}, 0), DeepEquals, all)
+	c.Assert(tree.scanRange([]byte{}, []byte{}, 2), DeepEquals, all[:2])
+	// The region containing the start key is included.
+	c.Assert(tree.scanRange([]byte("c"), []byte{}, 0), DeepEquals, all[1:])
+	c.Assert(tree.scanRange([]byte("c"), []byte("e"), 0), DeepEquals, all[1:3])
+	// The end key is exclusive.
+	c.Assert(tree.scanRange([]byte("c"), []byte("d"), 0), DeepEquals, all[1:2])
+	c.Assert(tree.scanRange([]byte("z"), []byte{}, 0), DeepEquals, all[3:])
+
+	// Scan starts from the next region if the start key is in a hole.
+	tree.remove(regionB)
+	c.Assert(tree.scanRange([]byte("c"), []byte{}, 1), DeepEquals, all[2:3])
+}
+
+func (s *testRegionSuite) TestRegionTreeSearchPrev(c *C) {
+	tree := newRegionTree()
+	regionA := newRegion([]byte{}, []byte("b"))
+	regionB := newRegion([]byte("b"), []byte("d"))
+	regionC := newRegion([]byte("d"), []byte{})
+	for _, region := range []*metapb.Region{regionC, regionA, regionB} {
+		tree.update(region)
+	}
+
+	c.Assert(tree.searchPrev([]byte{}), IsNil)
+	c.Assert(tree.searchPrev([]byte("a")), IsNil)
+	c.Assert(tree.searchPrev([]byte("b")), Equals, regionA)
+	c.Assert(tree.searchPrev([]byte("c")), Equals, regionA)
+	c.Assert(tree.searchPrev([]byte("z")), Equals, regionB)
+
+	// There is no region for the key in a hole.
+	tree.remove(regionB)
+	c.Assert(tree.searchPrev([]byte("c")), IsNil)
+	c.Assert(tree.searchPrev([]byte("d")), Equals, regionA)
+}
+
 func splitRegions(regions []*metapb.Region) []*metapb.Region {
 	results := make([]*metapb.Region, 0, len(regions)*2)
 	for _, region := range regions {
@@ -0,0 +1,108 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"container/heap"
+
+	"github.com/juju/errors"
+)
+
+// The orders to get the top regions by.
+const (
+	RegionTopReadFlow  = "readflow"
+	RegionTopWriteFlow = "writeflow"
+	RegionTopSize      = "size"
+	RegionTopConfVer   = "confver"
+)
+
+func regionTopValue(region *RegionInfo, by string) uint64 {
+	switch by {
+	case RegionTopReadFlow:
+		return region.ReadBytes
+	case RegionTopWriteFlow:
+		return region.WrittenBytes
+	case RegionTopSize:
+		return region.ApproximateSize
+	case RegionTopConfVer:
+		return region.GetRegionEpoch().GetConfVer()
+	}
+	return 0
+}
+
+// regionTopHeap is a min heap of the regions, so the region with the lowest
+// value is replaced when a higher one is found.
+type regionTopHeap struct {
+	by      string
+	regions []*RegionInfo
+}
+
+func (h *regionTopHeap) Len() int { return len(h.regions) }
+
+func (h *regionTopHeap) Less(i, j int) bool {
+	vi, vj := regionTopValue(h.regions[i], h.by), regionTopValue(h.regions[j], h.by)
+	if vi != vj {
+		return vi < vj
+	}
+	return h.regions[i].GetId() > h.regions[j].GetId()
+}
+
+func (h *regionTopHeap) Swap(i, j int) { h.regions[i], h.regions[j] = h.regions[j], h.regions[i] }
+
+func (h *regionTopHeap) Push(x interface{}) { h.regions = append(h.regions, x.(*RegionInfo)) }
+
+func (h *regionTopHeap) Pop() interface{} {
+	last := h.regions[len(h.regions)-1]
+	h.regions = h.regions[:len(h.regions)-1]
+	return last
+}
+
+// getTopRegions returns at most n regions with the highest values of the
+// order in descending order, the regions of the same value are ordered by ID.
+func (c *clusterInfo) getTopRegions(by string, n int) []*RegionInfo {
+	h := &regionTopHeap{by: by}
+	var key []byte
+	for {
+		batch := c.scanRegions(key, nil, queryScanBatch)
+		for _, region := range batch {
+			heap.Push(h, region)
+			if h.Len() > n {
+				heap.Pop(h)
+			}
+		}
+		if len(batch) < queryScanBatch {
+			break
+		}
+		key = batch[len(batch)-1].GetEndKey()
+		if len(key) == 0 {
+			break
+		}
+	}
+	regions := make([]*RegionInfo, h.Len())
+	for i := len(regions) - 1; i >= 0; i-- {
+		regions[i] = heap.Pop(h).(*RegionInfo)
+	}
+	return regions
+}
+
+// GetTopRegions returns at most n regions with the highest read flow, write
+// flow, size or conf version given by the order.
+func (c *RaftCluster) GetTopRegions(by string, n int) ([]*RegionInfo, error) {
+	switch by {
+	case RegionTopReadFlow, RegionTopWriteFlow, RegionTopSize, RegionTopConfVer:
+	default:
+		return nil, errors.Errorf("unknown order %q of the top regions", by)
+	}
+	return c.cachedCluster.getTopRegions(by, n), nil
+}
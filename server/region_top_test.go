@@ -0,0 +1,61 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testRegionTopSuite{})
+
+type testRegionTopSuite struct{}
+
+func (s *testRegionTopSuite) TestTopRegions(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	regionCount := queryScanBatch + 10
+	for i := 0; i < regionCount; i++ {
+		start, end := fmt.Sprintf("%05d", i), fmt.Sprintf("%05d", i+1)
+		if i == regionCount-1 {
+			end = ""
+		}
+		tc.addLeaderRegionInRange(uint64(i+1), start, end, defaultRegionSize, 1, 2, 3)
+	}
+	for _, id := range []uint64{5, uint64(regionCount), 100} {
+		region := cluster.getRegion(id)
+		region.ReadBytes = id * 10
+		region.WrittenBytes = 2000 - id
+		region.ApproximateSize = defaultRegionSize + id
+		region.RegionEpoch.ConfVer = id
+		cluster.putRegion(region)
+	}
+
+	checkTopRegions := func(by string, n int, ids ...uint64) {
+		regions := cluster.getTopRegions(by, n)
+		c.Assert(regions, HasLen, len(ids))
+		for i, region := range regions {
+			c.Assert(region.GetId(), Equals, ids[i], Commentf("%s", by))
+		}
+	}
+	checkTopRegions(RegionTopReadFlow, 2, uint64(regionCount), 100)
+	checkTopRegions(RegionTopWriteFlow, 3, 5, 100, uint64(regionCount))
+	checkTopRegions(RegionTopSize, 1, uint64(regionCount))
+	checkTopRegions(RegionTopConfVer, 3, uint64(regionCount), 100, 5)
+	// The regions of the same value are ordered by ID.
+	checkTopRegions(RegionTopReadFlow, 5, uint64(regionCount), 100, 5, 1, 2)
+	checkTopRegions(RegionTopReadFlow, 0)
+}
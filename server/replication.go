@@ -108,5 +108,12 @@ func compareStoreScore(storeA *storeInfo, scoreA float64, storeB *storeInfo, sco
 	if storeA.regionScore() > storeB.regionScore() {
 		return -1
 	}
+	// The store with fewer pending peers is better.
+	if storeA.pendingPeerCount() < storeB.pendingPeerCount() {
+		return 1
+	}
+	if storeA.pendingPeerCount() > storeB.pendingPeerCount() {
+		return -1
+	}
 	return 0
 }
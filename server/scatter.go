@@ -0,0 +1,171 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+// regionScatterer spreads the peers and the leaders of the regions, it's
+// used for the regions split before importing data, which are all on the
+// stores of the origin region. The stores for peers are chosen in rounds, a
+// store gets another scattered peer only after all the other available
+// stores get one. The leaders go to the stores with the fewest scattered
+// leaders.
+type regionScatterer struct {
+	sync.Mutex
+	cluster  *clusterInfo
+	opt      *scheduleOption
+	selector Selector
+	// peers is the stores chosen in the current round.
+	peers map[uint64]struct{}
+	// leaders is the count of scattered leaders of each store.
+	leaders map[uint64]uint64
+}
+
+func newRegionScatterer(cluster *clusterInfo, opt *scheduleOption) *regionScatterer {
+	filters := []Filter{
+		newStateFilter(opt),
+		newHealthFilter(opt),
+		newSnapshotCountFilter(opt),
+		newStorageThresholdFilter(opt),
+	}
+	return &regionScatterer{
+		cluster:  cluster,
+		opt:      opt,
+		selector: newRandomSelector(filters),
+		peers:    make(map[uint64]struct{}),
+		leaders:  make(map[uint64]uint64),
+	}
+}
+
+// scatter returns the operator to scatter the region, or nil if the region
+// doesn't need to be changed.
+func (r *regionScatterer) scatter(region *RegionInfo) (Operator, error) {
+	if region.Leader == nil {
+		return nil, errors.Errorf("region %d has no leader", region.GetId())
+	}
+	if len(region.DownPeers) > 0 || len(region.PendingPeers) > 0 {
+		return nil, errors.Errorf("region %d has unhealthy peers", region.GetId())
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	var (
+		adds, removes []Operator
+		peers         []*metapb.Peer
+	)
+	regionStores := r.cluster.getRegionStores(region)
+	fit := r.cluster.fitRegion(region, r.opt.GetMaxReplicas())
+	excluded := region.GetStoreIds()
+	for _, peer := range region.GetPeers() {
+		if _, ok := r.peers[peer.GetStoreId()]; !ok {
+			r.peers[peer.GetStoreId()] = struct{}{}
+			peers = append(peers, peer)
+			continue
+		}
+		newPeer, err := r.selectPeer(regionStores, fit.getPeerRule(peer), peer, excluded)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if newPeer == nil {
+			peers = append(peers, peer)
+			continue
+		}
+		newPeer.Role = peer.GetRole()
+		excluded[newPeer.GetStoreId()] = struct{}{}
+		r.peers[newPeer.GetStoreId()] = struct{}{}
+		adds = append(adds, newAddPeerOperator(region.GetId(), newPeer))
+		removes = append(removes, newRemovePeerOperator(region.GetId(), peer))
+		peers = append(peers, newPeer)
+	}
+
+	ops := adds
+	if leader := r.selectLeader(region, peers); leader.GetId() != region.Leader.GetId() {
+		ops = append(ops, newTransferLeaderOperator(region.GetId(), region.Leader, leader))
+	}
+	ops = append(ops, removes...)
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	kind := LeaderKind
+	if len(adds) > 0 {
+		kind = RegionKind
+	}
+	return newRegionOperator(region, kind, ops...), nil
+}
+
+// selectPeer allocates a peer to replace the peer on a store which is chosen
+// in the current round and allowed by the rule of the peer. It returns nil if
+// there is no suitable store.
+func (r *regionScatterer) selectPeer(regionStores []*storeInfo, rule *PlacementRule, peer *metapb.Peer, excluded map[uint64]struct{}) (*metapb.Peer, error) {
+	source := r.cluster.getStore(peer.GetStoreId())
+	if source == nil {
+		return nil, nil
+	}
+	stores := r.cluster.getStores()
+	filters := []Filter{
+		newExcludedFilter(nil, excluded),
+		newDistinctScoreFilter(r.opt.GetReplication(), regionStores, source),
+		newPlacementRuleFilter(rule),
+	}
+	target := r.selector.SelectTarget(stores, append(filters, newExcludedFilter(nil, r.peers))...)
+	if target == nil {
+		// All the available stores are chosen, start a new round.
+		r.peers = make(map[uint64]struct{})
+		target = r.selector.SelectTarget(stores, filters...)
+	}
+	if target == nil {
+		return nil, nil
+	}
+	newPeer, err := r.cluster.allocPeer(target.GetId())
+	return newPeer, errors.Trace(err)
+}
+
+// selectLeader chooses the voter whose store has the fewest scattered
+// leaders, the current leader is preferred if there is a tie. The stores
+// which reject leaders are skipped.
+func (r *regionScatterer) selectLeader(region *RegionInfo, peers []*metapb.Peer) *metapb.Peer {
+	rejectLeader := newRejectLeaderFilter(r.opt)
+	var candidates []*metapb.Peer
+	for _, peer := range peers {
+		if peer.GetRole() != metapb.PeerRole_Voter {
+			continue
+		}
+		if store := r.cluster.getStore(peer.GetStoreId()); store == nil || rejectLeader.FilterTarget(store) {
+			continue
+		}
+		candidates = append(candidates, peer)
+	}
+	var leader *metapb.Peer
+	for _, peer := range candidates {
+		if peer.GetId() == region.Leader.GetId() {
+			leader = peer
+		}
+	}
+	for _, peer := range candidates {
+		if leader == nil || r.leaders[peer.GetStoreId()] < r.leaders[leader.GetStoreId()] {
+			leader = peer
+		}
+	}
+	if leader == nil {
+		return region.Leader
+	}
+	r.leaders[leader.GetStoreId()]++
+	return leader
+}
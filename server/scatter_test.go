@@ -0,0 +1,113 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testScatterSuite{})
+
+type testScatterSuite struct{}
+
+// applyScatter returns the stores of the peers and the leader of the region
+// after the scatter operator finishes.
+func applyScatter(c *C, region *RegionInfo, op Operator) (map[uint64]struct{}, uint64) {
+	stores := region.GetStoreIds()
+	leader := region.Leader.GetStoreId()
+	if op == nil {
+		return stores, leader
+	}
+	for _, o := range op.(*regionOperator).Ops {
+		switch o := o.(type) {
+		case *changePeerOperator:
+			storeID := o.ChangePeer.GetPeer().GetStoreId()
+			if o.Name == "add_peer" {
+				stores[storeID] = struct{}{}
+			} else {
+				c.Assert(storeID, Not(Equals), leader)
+				delete(stores, storeID)
+			}
+		case *transferLeaderOperator:
+			leader = o.NewLeader.GetStoreId()
+			_, ok := stores[leader]
+			c.Assert(ok, IsTrue)
+		}
+	}
+	return stores, leader
+}
+
+func (s *testScatterSuite) TestScatterRegions(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	for id := uint64(1); id <= 6; id++ {
+		tc.addRegionStore(id, 0)
+	}
+	// All the regions are on store 1, 2, 3 after split.
+	for id := uint64(1); id <= 12; id++ {
+		tc.addLeaderRegion(id, 1, 2, 3)
+	}
+
+	scatterer := newRegionScatterer(cluster, opt)
+	peerCounts := make(map[uint64]int)
+	leaderCounts := make(map[uint64]int)
+	for id := uint64(1); id <= 12; id++ {
+		region := cluster.getRegion(id)
+		op, err := scatterer.scatter(region)
+		c.Assert(err, IsNil)
+		stores, leader := applyScatter(c, region, op)
+		c.Assert(stores, HasLen, 3)
+		for storeID := range stores {
+			peerCounts[storeID]++
+		}
+		leaderCounts[leader]++
+	}
+
+	// The stores are chosen in rounds, so the peers are spread evenly. The
+	// leaders can only go to the stores of the region.
+	for id := uint64(1); id <= 6; id++ {
+		c.Assert(peerCounts[id], Equals, 6)
+		c.Assert(leaderCounts[id], Not(Equals), 0)
+		c.Assert(leaderCounts[id], LessEqual, 3)
+	}
+}
+
+func (s *testScatterSuite) TestScatterUnhealthyRegion(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	_, opt := newTestScheduleConfig()
+	for id := uint64(1); id <= 4; id++ {
+		tc.addRegionStore(id, 0)
+	}
+	tc.addLeaderRegion(1, 1, 2, 3)
+	scatterer := newRegionScatterer(cluster, opt)
+
+	region := cluster.getRegion(1)
+	region.PendingPeers = append(region.PendingPeers, region.GetStorePeer(2))
+	_, err := scatterer.scatter(region)
+	c.Assert(err, NotNil)
+
+	// Stores which are not up are not chosen.
+	tc.setStoreOffline(4)
+	region = cluster.getRegion(1)
+	op, err := scatterer.scatter(region)
+	c.Assert(err, IsNil)
+	c.Assert(op, IsNil)
+	op, err = scatterer.scatter(region)
+	c.Assert(err, IsNil)
+	stores, _ := applyScatter(c, region, op)
+	_, ok := stores[4]
+	c.Assert(ok, IsFalse)
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+)
+
+// SchedulePauseStatus is the state of the cluster-wide scheduling pause, it's
+// persisted so the pause survives the leader change.
+type SchedulePauseStatus struct {
+	Paused bool `json:"paused"`
+	// PauseUntil is the unix time when the pause expires, 0 means the
+	// scheduling is paused until it's resumed.
+	PauseUntil int64 `json:"pause_until,omitempty"`
+}
+
+// pauseSchedule halts all the schedulers and checkers for the duration, 0
+// halts them until resumeSchedule is called. The running operators and the
+// operators added by the admin are not affected.
+func (c *coordinator) pauseSchedule(d time.Duration) error {
+	status := &SchedulePauseStatus{Paused: true}
+	until := int64(math.MaxInt64)
+	if d > 0 {
+		t := time.Now().Add(d)
+		status.PauseUntil = t.Unix()
+		until = t.UnixNano()
+	}
+	if err := c.saveSchedulePause(status); err != nil {
+		return errors.Trace(err)
+	}
+	atomic.StoreInt64(&c.pauseUntil, until)
+	log.Warnf("coordinator: scheduling is paused, %+v", status)
+	return nil
+}
+
+// resumeSchedule resumes the scheduling paused by pauseSchedule.
+func (c *coordinator) resumeSchedule() error {
+	if err := c.saveSchedulePause(&SchedulePauseStatus{}); err != nil {
+		return errors.Trace(err)
+	}
+	atomic.StoreInt64(&c.pauseUntil, 0)
+	log.Info("coordinator: scheduling is resumed")
+	return nil
+}
+
+func (c *coordinator) isSchedulePaused() bool {
+	return time.Now().UnixNano() < atomic.LoadInt64(&c.pauseUntil)
+}
+
+func (c *coordinator) getSchedulePauseStatus() *SchedulePauseStatus {
+	until := atomic.LoadInt64(&c.pauseUntil)
+	if time.Now().UnixNano() >= until {
+		return &SchedulePauseStatus{}
+	}
+	status := &SchedulePauseStatus{Paused: true}
+	if until != math.MaxInt64 {
+		status.PauseUntil = time.Unix(0, until).Unix()
+	}
+	return status
+}
+
+func (c *coordinator) saveSchedulePause(status *SchedulePauseStatus) error {
+	if c.cluster.kv == nil {
+		return nil
+	}
+	return errors.Trace(c.cluster.kv.saveSchedulePause(status))
+}
+
+// restoreSchedulePause loads the scheduling pause set on the previous leader.
+func (c *coordinator) restoreSchedulePause() {
+	if c.cluster.kv == nil {
+		return
+	}
+	status, err := c.cluster.kv.loadSchedulePause()
+	if err != nil {
+		log.Errorf("coordinator: failed to load schedule pause: %v", err)
+		return
+	}
+	if status == nil || !status.Paused {
+		return
+	}
+	until := int64(math.MaxInt64)
+	if status.PauseUntil != 0 {
+		until = time.Unix(status.PauseUntil, 0).UnixNano()
+	}
+	atomic.StoreInt64(&c.pauseUntil, until)
+	log.Warnf("coordinator: scheduling is paused by the previous leader, %+v", status)
+}
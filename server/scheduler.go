@@ -19,7 +19,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/pd/pkg/metapb"
 )
 
 // Scheduler is an interface to schedule resources.
@@ -37,13 +37,20 @@ type grantLeaderScheduler struct {
 	opt     *scheduleOption
 	name    string
 	storeID uint64
+	filters []Filter
 }
 
 func newGrantLeaderScheduler(opt *scheduleOption, storeID uint64) *grantLeaderScheduler {
+	var filters []Filter
+	filters = append(filters, newStateFilter(opt))
+	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newRejectLeaderFilter(opt))
+
 	return &grantLeaderScheduler{
 		opt:     opt,
 		name:    fmt.Sprintf("grant-leader-scheduler-%d", storeID),
 		storeID: storeID,
+		filters: filters,
 	}
 }
 
@@ -68,11 +75,21 @@ func (s *grantLeaderScheduler) Cleanup(cluster *clusterInfo) {
 }
 
 func (s *grantLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
+	// Wait until the store is able to serve the leaders.
+	store := cluster.getStore(s.storeID)
+	if store == nil || filterTarget(store, s.filters) {
+		return nil
+	}
 	region := cluster.randFollowerRegion(s.storeID)
 	if region == nil {
 		return nil
 	}
-	return newTransferLeader(region, region.GetStorePeer(s.storeID))
+	// The peer may still be catching up after the store restarts.
+	peer := region.GetStorePeer(s.storeID)
+	if region.GetPendingPeer(peer.GetId()) != nil || region.GetDownPeer(peer.GetId()) != nil {
+		return nil
+	}
+	return newTransferLeader(region, peer)
 }
 
 type evictLeaderScheduler struct {
@@ -84,8 +101,10 @@ type evictLeaderScheduler struct {
 
 func newEvictLeaderScheduler(opt *scheduleOption, storeID uint64) *evictLeaderScheduler {
 	var filters []Filter
+	filters = append(filters, newBlockFilter())
 	filters = append(filters, newStateFilter(opt))
 	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newRejectLeaderFilter(opt))
 
 	return &evictLeaderScheduler{
 		opt:      opt,
@@ -135,8 +154,11 @@ type shuffleLeaderScheduler struct {
 
 func newShuffleLeaderScheduler(opt *scheduleOption) *shuffleLeaderScheduler {
 	var filters []Filter
+	filters = append(filters, newBlockFilter())
 	filters = append(filters, newStateFilter(opt))
 	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newLeaderWeightFilter())
+	filters = append(filters, newRejectLeaderFilter(opt))
 
 	return &shuffleLeaderScheduler{
 		opt:      opt,
@@ -173,6 +195,9 @@ func (s *shuffleLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
 		if region == nil {
 			return nil
 		}
+		if !cluster.getRegionPlacement(region).allowLeader(cluster.getStore(newLeader.GetStoreId())) {
+			return nil
+		}
 		// Mark the selected store.
 		s.selected = region.Leader
 		return newTransferLeader(region, newLeader)
@@ -182,9 +207,15 @@ func (s *shuffleLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
 	storeID := s.selected.GetStoreId()
 	s.selected = nil
 
+	// Don't transfer leaders back to a blocked store.
+	store := cluster.getStore(storeID)
+	if store == nil || store.isBlocked() || newRejectLeaderFilter(s.opt).FilterTarget(store) {
+		return nil
+	}
+
 	// Transfer a leader to the selected store.
 	region := cluster.randFollowerRegion(storeID)
-	if region == nil {
+	if region == nil || !cluster.getRegionPlacement(region).allowLeader(store) {
 		return nil
 	}
 	return newTransferLeader(region, region.GetStorePeer(storeID))
@@ -192,6 +223,7 @@ func (s *shuffleLeaderScheduler) Schedule(cluster *clusterInfo) Operator {
 
 type shuffleRegionScheduler struct {
 	opt      *scheduleOption
+	rep      *Replication
 	selector Selector
 }
 
@@ -199,9 +231,12 @@ func newShuffleRegionScheduler(opt *scheduleOption) *shuffleRegionScheduler {
 	var filters []Filter
 	filters = append(filters, newStateFilter(opt))
 	filters = append(filters, newHealthFilter(opt))
+	filters = append(filters, newSnapshotCountFilter(opt))
+	filters = append(filters, newStorageThresholdFilter(opt))
 
 	return &shuffleRegionScheduler{
 		opt:      opt,
+		rep:      opt.GetReplication(),
 		selector: newRandomSelector(filters),
 	}
 }
@@ -228,18 +263,83 @@ func (s *shuffleRegionScheduler) Schedule(cluster *clusterInfo) Operator {
 		return nil
 	}
 
+	// Leave the unhealthy regions, the witnesses and the learners to the
+	// replica checker.
+	if len(region.GetPeers()) != cluster.getRegionPeerCount(region, s.rep.GetMaxReplicas()) ||
+		len(region.DownPeers) > 0 || len(region.PendingPeers) > 0 || oldPeer.GetRole() != metapb.PeerRole_Voter {
+		return nil
+	}
+
+	// scoreGuard guarantees that the distinct score will not decrease.
+	stores := cluster.getRegionStores(region)
+	source := cluster.getStore(oldPeer.GetStoreId())
+	scoreGuard := newDistinctScoreFilter(s.rep, stores, source)
 	excludedFilter := newExcludedFilter(nil, region.GetStoreIds())
-	newPeer := scheduleAddPeer(cluster, s.selector, excludedFilter)
+	newPeer := scheduleAddPeer(cluster, s.selector, excludedFilter, scoreGuard)
 	if newPeer == nil {
 		return nil
 	}
 
-	return newTransferPeer(region, RegionKind, oldPeer, newPeer)
+	target := cluster.getStore(newPeer.GetStoreId())
+	rule := cluster.getRegionPeerRule(region, oldPeer, s.opt.GetMaxReplicas())
+	if !cluster.getRegionPlacement(region).allowTransferPeer(rule, stores, source, target) {
+		return nil
+	}
+	return newTransferPeer(region, RegionKind, oldPeer, newPeer, s.opt)
+}
+
+// scatterRangeScheduler balances the leaders and regions in a key range
+// among the stores, as if the range were a standalone cluster. So the
+// regions of a hot table are spread out even when the whole cluster looks
+// balanced.
+type scatterRangeScheduler struct {
+	opt           *scheduleOption
+	name          string
+	rangeName     string
+	startKey      []byte
+	endKey        []byte
+	balanceLeader *balanceLeaderScheduler
+	balanceRegion *balanceRegionScheduler
+}
+
+func newScatterRangeScheduler(opt *scheduleOption, startKey, endKey []byte, rangeName string) *scatterRangeScheduler {
+	return &scatterRangeScheduler{
+		opt:           opt,
+		name:          "scatter-range-scheduler-" + rangeName,
+		rangeName:     rangeName,
+		startKey:      startKey,
+		endKey:        endKey,
+		balanceLeader: newBalanceLeaderScheduler(opt),
+		balanceRegion: newBalanceRegionScheduler(opt),
+	}
+}
+
+func (s *scatterRangeScheduler) GetName() string {
+	return s.name
 }
 
-func newAddPeer(region *RegionInfo, peer *metapb.Peer) Operator {
-	addPeer := newAddPeerOperator(region.GetId(), peer)
-	return newRegionOperator(region, RegionKind, addPeer)
+func (s *scatterRangeScheduler) GetResourceKind() ResourceKind {
+	return RegionKind
+}
+
+func (s *scatterRangeScheduler) GetResourceLimit() uint64 {
+	return s.opt.GetRegionScheduleLimit()
+}
+
+func (s *scatterRangeScheduler) Prepare(cluster *clusterInfo) error { return nil }
+
+func (s *scatterRangeScheduler) Cleanup(cluster *clusterInfo) {}
+
+func (s *scatterRangeScheduler) Schedule(cluster *clusterInfo) Operator {
+	rc := cluster.newRangeCluster(s.startKey, s.endKey)
+	if op := s.balanceLeader.Schedule(rc); op != nil {
+		return op
+	}
+	return s.balanceRegion.Schedule(rc)
+}
+
+func newAddPeer(region *RegionInfo, peer *metapb.Peer, learner bool) Operator {
+	return newRegionOperator(region, RegionKind, newAddPeerSteps(region.GetId(), peer, learner)...)
 }
 
 func newRemovePeer(region *RegionInfo, peer *metapb.Peer) Operator {
@@ -254,26 +354,82 @@ func newRemovePeer(region *RegionInfo, peer *metapb.Peer) Operator {
 	return newRegionOperator(region, RegionKind, removePeer)
 }
 
-func newTransferPeer(region *RegionInfo, kind ResourceKind, oldPeer, newPeer *metapb.Peer) Operator {
-	addPeer := newAddPeerOperator(region.GetId(), newPeer)
-	removePeer := newRemovePeerOperator(region.GetId(), oldPeer)
+// newTransferPeer moves the peer to another store. If the joint consensus is
+// enabled, a voter is swapped atomically: the new peer is added as a learner,
+// promoted while the old peer is demoted in a joint consensus, and the old
+// peer is removed after leaving the joint state. Otherwise the new peer is
+// added and the old one is removed in separate conf changes.
+func newTransferPeer(region *RegionInfo, kind ResourceKind, oldPeer, newPeer *metapb.Peer, opt *scheduleOption) Operator {
+	newPeer.Role = oldPeer.GetRole()
+	if opt.IsJointConsensusEnabled() && oldPeer.GetRole() == metapb.PeerRole_Voter {
+		if op := newJointTransferPeer(region, kind, oldPeer, newPeer); op != nil {
+			return op
+		}
+	}
+	steps := newAddPeerSteps(region.GetId(), newPeer, opt.IsRaftLearnerEnabled())
 	if region.Leader != nil && region.Leader.GetId() == oldPeer.GetId() {
 		newLeader := newPeer
 		if follower := region.GetFollower(); follower != nil {
 			newLeader = follower
 		}
-		transferLeader := newTransferLeaderOperator(region.GetId(), region.Leader, newLeader)
-		return newRegionOperator(region, kind, addPeer, transferLeader, removePeer)
+		steps = append(steps, newTransferLeaderOperator(region.GetId(), region.Leader, newLeader))
 	}
-	return newRegionOperator(region, kind, addPeer, removePeer)
+	steps = append(steps, newRemovePeerOperator(region.GetId(), oldPeer))
+	return newRegionOperator(region, kind, steps...)
+}
+
+// newJointTransferPeer swaps the voters in a joint consensus. The leader
+// can't be demoted, so it's transferred to a follower first, it returns nil
+// if there is no follower.
+func newJointTransferPeer(region *RegionInfo, kind ResourceKind, oldPeer, newPeer *metapb.Peer) Operator {
+	learner := &metapb.Peer{
+		Id:      newPeer.GetId(),
+		StoreId: newPeer.GetStoreId(),
+		Role:    metapb.PeerRole_Learner,
+	}
+	steps := []Operator{newAddPeerOperator(region.GetId(), learner)}
+	if region.Leader != nil && region.Leader.GetId() == oldPeer.GetId() {
+		follower := region.GetFollower()
+		if follower == nil {
+			return nil
+		}
+		steps = append(steps, newTransferLeaderOperator(region.GetId(), region.Leader, follower))
+	}
+	promote, demote := []*metapb.Peer{learner}, []*metapb.Peer{oldPeer}
+	steps = append(steps,
+		newEnterJointOperator(region.GetId(), promote, demote),
+		newLeaveJointOperator(region.GetId(), promote, demote),
+		newRemovePeerOperator(region.GetId(), oldPeer),
+	)
+	return newRegionOperator(region, kind, steps...)
+}
+
+// newLeaveJoint leaves the joint consensus the region is left in, since the
+// operator which entered it timed out or was replaced.
+func newLeaveJoint(region *RegionInfo) Operator {
+	incoming, demoting := region.getJointPeers()
+	if len(incoming) == 0 && len(demoting) == 0 {
+		return nil
+	}
+	return newRegionOperator(region, RegionKind, newLeaveJointOperator(region.GetId(), incoming, demoting))
 }
 
+// newPriorityTransferLeader returns nil if the new leader is not a voter, as
+// newTransferLeader does.
 func newPriorityTransferLeader(region *RegionInfo, newLeader *metapb.Peer) Operator {
+	if newLeader.GetRole() != metapb.PeerRole_Voter {
+		return nil
+	}
 	transferLeader := newTransferLeaderOperator(region.GetId(), region.Leader, newLeader)
 	return newRegionOperator(region, PriorityKind, transferLeader)
 }
 
+// newTransferLeader returns nil if the new leader is not a voter, the
+// learners, observers and witnesses can't be the leader.
 func newTransferLeader(region *RegionInfo, newLeader *metapb.Peer) Operator {
+	if newLeader.GetRole() != metapb.PeerRole_Voter {
+		return nil
+	}
 	transferLeader := newTransferLeaderOperator(region.GetId(), region.Leader, newLeader)
 	return newRegionOperator(region, LeaderKind, transferLeader)
 }
@@ -0,0 +1,196 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+)
+
+// schedulerState is the persisted state of a running scheduler, it is enough
+// to recreate the scheduler on the new leader. Hot region statistics are not
+// included since they are rebuilt from the heartbeats in a few minutes.
+type schedulerState struct {
+	Type   string   `json:"type"`
+	Args   []string `json:"args,omitempty"`
+	Paused bool     `json:"paused,omitempty"`
+	// PauseUntil is the unix time when the pause expires, 0 means the
+	// scheduler is paused until it's resumed.
+	PauseUntil int64 `json:"pause_until,omitempty"`
+}
+
+type schedulerCreator func(opt *scheduleOption, args []string) (Scheduler, error)
+
+var schedulerCreators = map[string]schedulerCreator{
+	"balance-leader": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newBalanceLeaderScheduler(opt), nil
+	},
+	"balance-region": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newBalanceRegionScheduler(opt), nil
+	},
+	"balance-hot-region": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newBalanceHotRegionScheduler(opt), nil
+	},
+	"balance-hot-read-region": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newBalanceHotReadRegionScheduler(opt), nil
+	},
+	"grant-leader": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		storeID, err := parseSchedulerStoreID(args)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newGrantLeaderScheduler(opt, storeID), nil
+	},
+	"evict-leader": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		storeID, err := parseSchedulerStoreID(args)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newEvictLeaderScheduler(opt, storeID), nil
+	},
+	"shuffle-leader": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newShuffleLeaderScheduler(opt), nil
+	},
+	"shuffle-region": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		return newShuffleRegionScheduler(opt), nil
+	},
+	"scatter-range": func(opt *scheduleOption, args []string) (Scheduler, error) {
+		if len(args) != 3 {
+			return nil, errors.New("should specify the range and the name")
+		}
+		return newScatterRangeScheduler(opt, []byte(args[0]), []byte(args[1]), args[2]), nil
+	},
+}
+
+func parseSchedulerStoreID(args []string) (uint64, error) {
+	if len(args) != 1 {
+		return 0, errors.New("missing store id")
+	}
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	return storeID, errors.Trace(err)
+}
+
+// getSchedulerState returns the state of the scheduler, or nil if the
+// scheduler can't be persisted.
+func getSchedulerState(s Scheduler) *schedulerState {
+	switch s := s.(type) {
+	case *balanceLeaderScheduler:
+		return &schedulerState{Type: "balance-leader"}
+	case *balanceRegionScheduler:
+		return &schedulerState{Type: "balance-region"}
+	case *balanceHotRegionScheduler:
+		return &schedulerState{Type: "balance-hot-region"}
+	case *balanceHotReadRegionScheduler:
+		return &schedulerState{Type: "balance-hot-read-region"}
+	case *grantLeaderScheduler:
+		return &schedulerState{Type: "grant-leader", Args: []string{strconv.FormatUint(s.storeID, 10)}}
+	case *evictLeaderScheduler:
+		return &schedulerState{Type: "evict-leader", Args: []string{strconv.FormatUint(s.storeID, 10)}}
+	case *shuffleLeaderScheduler:
+		return &schedulerState{Type: "shuffle-leader"}
+	case *shuffleRegionScheduler:
+		return &schedulerState{Type: "shuffle-region"}
+	case *scatterRangeScheduler:
+		return &schedulerState{Type: "scatter-range", Args: []string{string(s.startKey), string(s.endKey), s.rangeName}}
+	default:
+		return nil
+	}
+}
+
+func createScheduler(opt *scheduleOption, state *schedulerState) (Scheduler, time.Duration, error) {
+	create, ok := schedulerCreators[state.Type]
+	if !ok {
+		return nil, 0, errors.Errorf("unknown scheduler type %s", state.Type)
+	}
+	s, err := create(opt, state.Args)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	if state.Type == "balance-hot-region" || state.Type == "balance-hot-read-region" {
+		return s, minSlowScheduleInterval, nil
+	}
+	return s, minScheduleInterval, nil
+}
+
+// saveSchedulersLocked persists the states of the running schedulers.
+func (c *coordinator) saveSchedulersLocked() error {
+	if c.cluster.kv == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.schedulers))
+	for name := range c.schedulers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	states := make([]*schedulerState, 0, len(names))
+	for _, name := range names {
+		s := c.schedulers[name]
+		if state := getSchedulerState(s.Scheduler); state != nil {
+			status := s.getStatus()
+			state.Paused, state.PauseUntil = status.Paused, status.PauseUntil
+			states = append(states, state)
+		}
+	}
+	return errors.Trace(c.cluster.kv.saveSchedulers(states))
+}
+
+// restoreSchedulers starts the schedulers persisted by the previous leader,
+// or the default schedulers if there is no persisted state.
+func (c *coordinator) restoreSchedulers() {
+	var (
+		states []*schedulerState
+		ok     bool
+		err    error
+	)
+	if c.cluster.kv != nil {
+		states, ok, err = c.cluster.kv.loadSchedulers()
+		if err != nil {
+			log.Errorf("coordinator: failed to load schedulers: %v", err)
+		}
+	}
+	if !ok {
+		states = []*schedulerState{
+			{Type: "balance-leader"},
+			{Type: "balance-region"},
+			{Type: "balance-hot-region"},
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for _, state := range states {
+		s, interval, err := createScheduler(c.opt, state)
+		if err == nil {
+			err = c.startSchedulerLocked(s, interval)
+		}
+		if err != nil {
+			log.Errorf("coordinator: failed to restore scheduler %v: %v", state, err)
+			continue
+		}
+		if state.Paused {
+			until := int64(math.MaxInt64)
+			if state.PauseUntil != 0 {
+				until = time.Unix(state.PauseUntil, 0).UnixNano()
+			}
+			atomic.StoreInt64(&c.schedulers[s.GetName()].pauseUntil, until)
+		}
+	}
+}
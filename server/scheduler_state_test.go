@@ -0,0 +1,146 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testSchedulerStateSuite{})
+
+type testSchedulerStateSuite struct {
+	svr     *Server
+	cleanup cleanUpFunc
+}
+
+func (s *testSchedulerStateSuite) SetUpSuite(c *C) {
+	s.svr, s.cleanup = mustRunTestServer(c)
+}
+
+func (s *testSchedulerStateSuite) TearDownSuite(c *C) {
+	s.cleanup()
+}
+
+func (s *testSchedulerStateSuite) TestRestoreSchedulers(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	cluster.kv = s.svr.kv
+	tc := newTestClusterInfo(cluster)
+	tc.addLeaderStore(1, 1)
+	tc.addLeaderStore(2, 1)
+	_, opt := newTestScheduleConfig()
+
+	// The default schedulers are started if nothing is persisted.
+	co := newCoordinator(cluster, opt)
+	co.run()
+	c.Assert(co.schedulers, HasLen, 3)
+	c.Assert(co.removeScheduler("balance-region-scheduler"), IsNil)
+	c.Assert(co.addScheduler(newEvictLeaderScheduler(opt, 1), minScheduleInterval), IsNil)
+	c.Assert(co.addScheduler(newGrantLeaderScheduler(opt, 3), minScheduleInterval), NotNil)
+	c.Assert(co.pauseScheduler("balance-leader-scheduler", 0), IsNil)
+	c.Assert(co.pauseScheduler("balance-hot-region-scheduler", time.Hour), IsNil)
+	c.Assert(co.pauseScheduler("balance-region-scheduler", 0), NotNil)
+	co.stop()
+	tc.unblockStore(1)
+	c.Assert(cluster.getStore(1).isBlocked(), IsFalse)
+
+	// The new leader restores the schedulers.
+	co = newCoordinator(cluster, opt)
+	co.run()
+	defer co.stop()
+	c.Assert(co.getSchedulers(), HasLen, 3)
+	c.Assert(co.schedulers, HasKey, "balance-leader-scheduler")
+	c.Assert(co.schedulers, HasKey, "balance-hot-region-scheduler")
+	c.Assert(co.schedulers, HasKey, "evict-leader-scheduler-1")
+	c.Assert(cluster.getStore(1).isBlocked(), IsTrue)
+
+	// The paused schedulers are still paused, until they are resumed or the
+	// pauses expire.
+	statuses := co.getSchedulerStatuses()
+	c.Assert(statuses, HasLen, 3)
+	c.Assert(statuses[0].Name, Equals, "balance-hot-region-scheduler")
+	c.Assert(statuses[0].Paused, IsTrue)
+	c.Assert(statuses[0].PauseUntil, Greater, time.Now().Unix())
+	c.Assert(statuses[1], DeepEquals, &SchedulerStatus{Name: "balance-leader-scheduler", Paused: true})
+	c.Assert(statuses[2], DeepEquals, &SchedulerStatus{Name: "evict-leader-scheduler-1"})
+	c.Assert(co.resumeScheduler("balance-leader-scheduler"), IsNil)
+	c.Assert(co.pauseScheduler("balance-hot-region-scheduler", time.Millisecond), IsNil)
+	time.Sleep(10 * time.Millisecond)
+	for _, status := range co.getSchedulerStatuses() {
+		c.Assert(status.Paused, IsFalse)
+	}
+	c.Assert(co.resumeScheduler("balance-region-scheduler"), NotNil)
+	c.Assert(co.resumeScheduler("balance-hot-region-scheduler"), IsNil)
+	states, _, err := s.svr.kv.loadSchedulers()
+	c.Assert(err, IsNil)
+	for _, state := range states {
+		c.Assert(state.Paused, IsFalse)
+		c.Assert(state.PauseUntil, Equals, int64(0))
+	}
+}
+
+func (s *testSchedulerStateSuite) TestRestoreSchedulePause(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	cluster.kv = s.svr.kv
+	_, opt := newTestScheduleConfig()
+
+	co := newCoordinator(cluster, opt)
+	c.Assert(co.pauseSchedule(time.Hour), IsNil)
+	until := co.getSchedulePauseStatus().PauseUntil
+
+	// The new leader is still paused until the time.
+	co = newCoordinator(cluster, opt)
+	co.restoreSchedulePause()
+	c.Assert(co.getSchedulePauseStatus(), DeepEquals, &SchedulePauseStatus{Paused: true, PauseUntil: until})
+
+	// The pause without delay is restored too.
+	c.Assert(co.pauseSchedule(0), IsNil)
+	co = newCoordinator(cluster, opt)
+	co.restoreSchedulePause()
+	c.Assert(co.getSchedulePauseStatus(), DeepEquals, &SchedulePauseStatus{Paused: true})
+
+	c.Assert(co.resumeSchedule(), IsNil)
+	co = newCoordinator(cluster, opt)
+	co.restoreSchedulePause()
+	c.Assert(co.isSchedulePaused(), IsFalse)
+}
+
+func (s *testSchedulerStateSuite) TestCreateScheduler(c *C) {
+	_, opt := newTestScheduleConfig()
+	for _, sched := range []Scheduler{
+		newBalanceLeaderScheduler(opt),
+		newBalanceRegionScheduler(opt),
+		newBalanceHotRegionScheduler(opt),
+		newBalanceHotReadRegionScheduler(opt),
+		newGrantLeaderScheduler(opt, 1),
+		newEvictLeaderScheduler(opt, 2),
+		newShuffleLeaderScheduler(opt),
+		newShuffleRegionScheduler(opt),
+		newScatterRangeScheduler(opt, []byte("a"), []byte("b"), "t1"),
+	} {
+		state := getSchedulerState(sched)
+		c.Assert(state, NotNil)
+		created, _, err := createScheduler(opt, state)
+		c.Assert(err, IsNil)
+		c.Assert(created.GetName(), Equals, sched.GetName())
+	}
+
+	_, _, err := createScheduler(opt, &schedulerState{Type: "evict-leader"})
+	c.Assert(err, NotNil)
+	_, _, err = createScheduler(opt, &schedulerState{Type: "scatter-range", Args: []string{"a", "b"}})
+	c.Assert(err, NotNil)
+	_, _, err = createScheduler(opt, &schedulerState{Type: "unknown"})
+	c.Assert(err, NotNil)
+}
@@ -13,7 +13,11 @@
 
 package server
 
-import . "github.com/pingcap/check"
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+)
 
 var _ = Suite(&testShuffleLeaderSuite{})
 
@@ -53,3 +57,173 @@ func (s *testShuffleLeaderSuite) TestShuffle(c *C) {
 		c.Assert(op.NewLeader.GetStoreId(), Equals, sourceID)
 	}
 }
+
+var _ = Suite(&testEvictLeaderSuite{})
+
+type testEvictLeaderSuite struct{}
+
+func (s *testEvictLeaderSuite) TestEvict(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+
+	// Add stores 1,2,3
+	tc.addLeaderStore(1, 1)
+	tc.addLeaderStore(2, 1)
+	tc.addLeaderStore(3, 0)
+	// Add regions 1,2 with leaders in stores 1,2
+	tc.addLeaderRegion(1, 1, 2, 3)
+	tc.addLeaderRegion(2, 2, 1, 3)
+
+	el1 := newEvictLeaderScheduler(opt, 1)
+	c.Assert(el1.Prepare(cluster), IsNil)
+	el2 := newEvictLeaderScheduler(opt, 2)
+	c.Assert(el2.Prepare(cluster), IsNil)
+
+	// The leaders are not transferred to the other evicted store.
+	for i := 0; i < 10; i++ {
+		checkTransferLeader(c, el1.Schedule(cluster), 1, 3)
+		checkTransferLeader(c, el2.Schedule(cluster), 2, 3)
+	}
+
+	// Other schedulers don't transfer leaders to the evicted stores.
+	sl := newShuffleLeaderScheduler(opt)
+	for i := 0; i < 10; i++ {
+		if op := sl.Schedule(cluster); op != nil {
+			c.Assert(op.(*regionOperator).Ops[0].(*transferLeaderOperator).NewLeader.GetStoreId(), Equals, uint64(3))
+		}
+	}
+
+	// Store 2 can get leaders after its scheduler is removed.
+	el2.Cleanup(cluster)
+	targets := make(map[uint64]struct{})
+	for i := 0; i < 100; i++ {
+		op := el1.Schedule(cluster).(*regionOperator).Ops[0].(*transferLeaderOperator)
+		targets[op.NewLeader.GetStoreId()] = struct{}{}
+	}
+	c.Assert(targets, HasKey, uint64(2))
+}
+
+var _ = Suite(&testGrantLeaderSuite{})
+
+type testGrantLeaderSuite struct{}
+
+func (s *testGrantLeaderSuite) TestGrant(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	gl := newGrantLeaderScheduler(opt, 1)
+	c.Assert(gl.Schedule(cluster), IsNil)
+
+	// Add stores 1,2,3
+	tc.addLeaderStore(1, 0)
+	tc.addLeaderStore(2, 1)
+	tc.addLeaderStore(3, 0)
+	// Add region 1 with leader in store 2 and followers in stores 1,3
+	tc.addLeaderRegion(1, 2, 1, 3)
+
+	// Don't grant leaders to a busy or down store.
+	tc.setStoreBusy(1, true)
+	c.Assert(gl.Schedule(cluster), IsNil)
+	tc.setStoreBusy(1, false)
+	tc.setStoreDown(1)
+	c.Assert(gl.Schedule(cluster), IsNil)
+	tc.setStoreUp(1)
+	checkTransferLeader(c, gl.Schedule(cluster), 2, 1)
+
+	// Don't grant leaders to a pending peer.
+	region := cluster.getRegion(1)
+	region.PendingPeers = append(region.PendingPeers, region.GetStorePeer(1))
+	cluster.putRegion(region)
+	c.Assert(gl.Schedule(cluster), IsNil)
+	region.PendingPeers = nil
+	cluster.putRegion(region)
+	checkTransferLeader(c, gl.Schedule(cluster), 2, 1)
+}
+
+var _ = Suite(&testShuffleRegionSuite{})
+
+type testShuffleRegionSuite struct{}
+
+func (s *testShuffleRegionSuite) TestShuffle(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	opt.rep = newTestReplication(3, "zone")
+	sr := newShuffleRegionScheduler(opt)
+	c.Assert(sr.Schedule(cluster), IsNil)
+
+	// Add stores 1,2,3,4 and store 4 is in the same zone as store 1.
+	tc.addLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	tc.addLabelsStore(2, 1, map[string]string{"zone": "z2"})
+	tc.addLabelsStore(3, 1, map[string]string{"zone": "z3"})
+	tc.addLabelsStore(4, 0, map[string]string{"zone": "z1"})
+	// Add region 1 with leader in store 1 and followers in stores 2,3
+	tc.addLeaderRegion(1, 1, 2, 3)
+
+	// Only the peer in store 1 can be moved without breaking the isolation.
+	scheduled := false
+	for i := 0; i < 100; i++ {
+		if op := sr.Schedule(cluster); op != nil {
+			checkTransferPeer(c, op, 1, 4)
+			scheduled = true
+		}
+	}
+	c.Assert(scheduled, IsTrue)
+
+	// Don't shuffle an unhealthy region.
+	region := cluster.getRegion(1)
+	region.PendingPeers = append(region.PendingPeers, region.GetStorePeer(2))
+	cluster.putRegion(region)
+	for i := 0; i < 100; i++ {
+		c.Assert(sr.Schedule(cluster), IsNil)
+	}
+}
+
+var _ = Suite(&testScatterRangeSuite{})
+
+type testScatterRangeSuite struct{}
+
+func (s *testScatterRangeSuite) TestScatterRange(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+
+	_, opt := newTestScheduleConfig()
+	sr := newScatterRangeScheduler(opt, []byte("t1"), []byte("t2"), "t1")
+	c.Assert(sr.GetName(), Equals, "scatter-range-scheduler-t1")
+	c.Assert(sr.Schedule(cluster), IsNil)
+
+	for id := uint64(1); id <= 4; id++ {
+		tc.addRegionStore(id, 0)
+	}
+	// The leaders in [t1, t2) are all in store 1, the ones out of the range
+	// make the whole cluster look balanced.
+	for i := uint64(1); i <= 6; i++ {
+		start, end := fmt.Sprintf("t1%d", i), fmt.Sprintf("t1%d", i+1)
+		tc.addLeaderRegionInRange(i, start, end, 10, 1, 2, 3)
+	}
+	tc.addLeaderRegionInRange(7, "", "t1", 10, 2, 1, 3)
+	tc.addLeaderRegionInRange(8, "t2", "t3", 10, 3, 1, 2)
+	tc.addLeaderRegionInRange(9, "t3", "", 10, 2, 1, 3)
+	for i := 0; i < 100; i++ {
+		op := sr.Schedule(cluster)
+		c.Assert(op, NotNil)
+		c.Assert(op.GetRegionID(), LessEqual, uint64(6))
+		checkTransferLeaderFrom(c, op, 1)
+	}
+
+	// Spread the leaders in the range, then the peers are moved to store 4.
+	for i := uint64(1); i <= 6; i++ {
+		start, end := fmt.Sprintf("t1%d", i), fmt.Sprintf("t1%d", i+1)
+		tc.addLeaderRegionInRange(i, start, end, 10, i%3+1, (i+1)%3+1, (i+2)%3+1)
+	}
+	for i := 0; i < 100; i++ {
+		op := sr.Schedule(cluster)
+		c.Assert(op, NotNil)
+		c.Assert(op.GetRegionID(), LessEqual, uint64(6))
+		checkAddPeer(c, op, 4)
+	}
+}
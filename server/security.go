@@ -0,0 +1,135 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// The TLS handshakes are done by etcd, so the common names of the client
+// certificates are checked when the gRPC and HTTP requests are served.
+
+func (s *Server) isAllowedCN(state *tls.ConnectionState) bool {
+	allowed := s.cfg.Security.CertAllowedCN
+	if len(allowed) == 0 {
+		return true
+	}
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return false
+	}
+	cn := state.PeerCertificates[0].Subject.CommonName
+	for _, name := range allowed {
+		if cn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCertCN checks the client certificate of the gRPC request.
+func (s *Server) checkCertCN(ctx context.Context) error {
+	if len(s.cfg.Security.CertAllowedCN) == 0 {
+		return nil
+	}
+	var state *tls.ConnectionState
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &info.State
+		}
+	}
+	if !s.isAllowedCN(state) {
+		return grpc.Errorf(codes.Unauthenticated, "client certificate is not allowed")
+	}
+	return nil
+}
+
+// certCNHandler rejects the HTTP requests whose client certificates are not
+// allowed.
+func (s *Server) certCNHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.isAllowedCN(r.TLS) {
+			http.Error(w, "client certificate is not allowed", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// memberPeers verifies whether a gRPC request is sent by a PD member. The
+// metadata set by the members, like the original client of a forwarded
+// request, is only trusted from them. A member is verified by the common name
+// of its client certificate, which is the same as the one of this PD, so the
+// metadata is never trusted if TLS or the client certificate verification is
+// disabled. The addresses are not used, any process on the host of a member
+// could set the metadata then.
+type memberPeers struct {
+	cn string
+}
+
+func newMemberPeers(s *Server) *memberPeers {
+	m := &memberPeers{}
+	if s.cfg.Security.CAPath != "" {
+		m.cn = loadCertCN(s.cfg.Security.CertPath)
+	}
+	return m
+}
+
+// isMember checks the peer of the gRPC request.
+func (m *memberPeers) isMember(ctx context.Context) bool {
+	if m.cn == "" {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return false
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName == m.cn
+}
+
+func loadCertCN(path string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Errorf("read certificate %s err %v", path, err)
+		return ""
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		log.Errorf("no certificate in %s", path)
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		log.Errorf("parse certificate %s err %v", path, err)
+		return ""
+	}
+	return cert.Subject.CommonName
+}
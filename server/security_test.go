@@ -0,0 +1,191 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+)
+
+var _ = Suite(&testSecuritySuite{})
+
+type testSecuritySuite struct {
+	dir string
+	ca  *x509.Certificate
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSecuritySuite) SetUpSuite(c *C) {
+	var err error
+	s.dir, err = ioutil.TempDir("/tmp", "test_pd_security")
+	c.Assert(err, IsNil)
+	s.ca, s.key = s.genCert(c, "ca", nil, nil)
+	s.genCert(c, "pd-server", s.ca, s.key)
+	s.genCert(c, "client", s.ca, s.key)
+	s.genCert(c, "other", s.ca, s.key)
+}
+
+func (s *testSecuritySuite) TearDownSuite(c *C) {
+	os.RemoveAll(s.dir)
+}
+
+// genCert writes the certificate and the key of the common name, the
+// certificate is self-signed if parent is nil.
+func (s *testSecuritySuite) genCert(c *C, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	c.Assert(err, IsNil)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	c.Assert(err, IsNil)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	c.Assert(ioutil.WriteFile(s.certPath(cn), certPEM, 0600), IsNil)
+	c.Assert(ioutil.WriteFile(s.keyPath(cn), keyPEM, 0600), IsNil)
+
+	cert, err := x509.ParseCertificate(der)
+	c.Assert(err, IsNil)
+	return cert, key
+}
+
+func (s *testSecuritySuite) certPath(cn string) string {
+	return filepath.Join(s.dir, cn+".pem")
+}
+
+func (s *testSecuritySuite) keyPath(cn string) string {
+	return filepath.Join(s.dir, cn+"-key.pem")
+}
+
+func (s *testSecuritySuite) clientTLSConfig(c *C, cn string) *tls.Config {
+	tlsConfig, err := SecurityConfig{
+		CAPath:   s.certPath("ca"),
+		CertPath: s.certPath(cn),
+		KeyPath:  s.keyPath(cn),
+	}.ToTLSConfig()
+	c.Assert(err, IsNil)
+	return tlsConfig
+}
+
+func freeTCPAddr(c *C) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func (s *testSecuritySuite) TestValidate(c *C) {
+	c.Assert(SecurityConfig{}.validate(), IsNil)
+	c.Assert(SecurityConfig{CertPath: "cert"}.validate(), NotNil)
+	c.Assert(SecurityConfig{CertPath: "cert", KeyPath: "key", CertAllowedCN: []string{"client"}}.validate(), NotNil)
+
+	tlsConfig, err := SecurityConfig{}.ToTLSConfig()
+	c.Assert(err, IsNil)
+	c.Assert(tlsConfig, IsNil)
+}
+
+func (s *testSecuritySuite) TestTLS(c *C) {
+	clientAddr, peerAddr := freeTCPAddr(c), freeTCPAddr(c)
+	cfg := NewTestSingleConfig()
+	cfg.ClientUrls = "https://" + clientAddr
+	cfg.AdvertiseClientUrls = cfg.ClientUrls
+	cfg.PeerUrls = "https://" + peerAddr
+	cfg.AdvertisePeerUrls = cfg.PeerUrls
+	cfg.InitialCluster = fmt.Sprintf("pd=%s", cfg.PeerUrls)
+	cfg.Security = SecurityConfig{
+		CAPath:        s.certPath("ca"),
+		CertPath:      s.certPath("pd-server"),
+		KeyPath:       s.keyPath("pd-server"),
+		CertAllowedCN: []string{"pd-server", "client"},
+	}
+	defer cleanServer(cfg)
+
+	svr := CreateServer(cfg)
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	c.Assert(svr.StartEtcd(api), IsNil)
+	defer svr.Close()
+	go svr.Run()
+	mustWaitLeader(c, []*Server{svr})
+
+	getMembers := func(tlsConfig *tls.Config) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, clientAddr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithBlock())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = pdpb.NewPDClient(conn).GetMembers(ctx, &pdpb.GetMembersRequest{})
+		return err
+	}
+	getAPI := func(tlsConfig *tls.Config) (int, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(cfg.ClientUrls + pdAPIPrefix)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	c.Assert(getMembers(s.clientTLSConfig(c, "client")), IsNil)
+	err := getMembers(s.clientTLSConfig(c, "other"))
+	c.Assert(grpc.Code(err), Equals, codes.Unauthenticated)
+
+	code, err := getAPI(s.clientTLSConfig(c, "client"))
+	c.Assert(err, IsNil)
+	c.Assert(code, Equals, http.StatusOK)
+	code, err = getAPI(s.clientTLSConfig(c, "other"))
+	c.Assert(err, IsNil)
+	c.Assert(code, Equals, http.StatusForbidden)
+
+	// The clients without certificates are rejected by the handshake.
+	_, err = getAPI(&tls.Config{RootCAs: s.clientTLSConfig(c, "client").RootCAs})
+	c.Assert(err, NotNil)
+}
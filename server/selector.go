@@ -56,13 +56,23 @@ func (s *balanceSelector) SelectTarget(stores []*storeInfo, filters ...Filter) *
 		if filterTarget(store, filters) {
 			continue
 		}
-		if result == nil || result.resourceScore(s.kind) > store.resourceScore(s.kind) {
+		if result == nil || s.betterTarget(store, result) {
 			result = store
 		}
 	}
 	return result
 }
 
+// betterTarget compares the scores of the stores, the store with fewer
+// pending peers is better if the scores are the same, as it is loaded less.
+func (s *balanceSelector) betterTarget(store, than *storeInfo) bool {
+	score, thanScore := store.resourceScore(s.kind), than.resourceScore(s.kind)
+	if score != thanScore {
+		return score < thanScore
+	}
+	return store.pendingPeerCount() < than.pendingPeerCount()
+}
+
 type randomSelector struct {
 	filters []Filter
 }
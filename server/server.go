@@ -14,6 +14,7 @@
 package server
 
 import (
+	"crypto/tls"
 	"math/rand"
 	"net/http"
 	"path"
@@ -29,9 +30,11 @@ import (
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/juju/errors"
 	"github.com/ngaut/systimemon"
-	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/pd/pkg/etcdutil"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/urlutil"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
@@ -60,6 +63,11 @@ type Server struct {
 	// leader value saved in etcd leader key.
 	// Every write will use this to check leader validation.
 	leaderValue string
+	// resignCh notifies the leader to resign.
+	resignCh chan struct{}
+	// campaignDelayUntil is the unix nanoseconds before which the resigned
+	// leader doesn't campaign.
+	campaignDelayUntil int64
 
 	wg sync.WaitGroup
 
@@ -68,6 +76,18 @@ type Server struct {
 	// for tso
 	ts            atomic.Value
 	lastSavedTime time.Time
+	tsoQuota      *tsoQuota
+	tsoBatcher    *tsoBatcher
+	tsoProxy      *tsoProxy
+	// localTSO is nil if the dc location is not set.
+	localTSO *localTSO
+	// clockMonitor checks the clocks of the other members on the leader.
+	clockMonitor *clockMonitor
+	// memberPeers verifies the gRPC requests sent by the other members.
+	memberPeers *memberPeers
+
+	// for limiting the rates of the unary gRPC requests.
+	rateLimiter *rateLimiter
 
 	// for id allocator, we can use one allocator for
 	// store, region and peer, because we just need
@@ -76,6 +96,8 @@ type Server struct {
 
 	// for kv operation.
 	kv *kv
+	// for saving the hot region history locally.
+	hotRegions *hotRegionStorage
 
 	// for API operation.
 	handler *Handler
@@ -84,6 +106,28 @@ type Server struct {
 	clusterLock sync.RWMutex
 	cluster     *RaftCluster
 
+	// for stale read on followers.
+	staleCache *staleCache
+	// for syncing regions to followers, nil for tenants.
+	regionSyncer *regionSyncer
+
+	// for reloading the config file.
+	reloader *configReloader
+
+	// for federation mode, primary is the PD hosting the tenant.
+	primary   *Server
+	tenantsMu sync.RWMutex
+	tenants   map[uint64]*Server
+
+	// for forwarding gRPC requests to the leader.
+	forwarder *leaderForwarder
+
+	// for connecting to PD with TLS, nil if TLS is not enabled.
+	tlsConfig *tls.Config
+
+	// for updating the GC safe point.
+	gcSafePointMu sync.Mutex
+
 	msgID uint64
 
 	id uint64
@@ -113,10 +157,25 @@ func CreateServer(cfg *Config) *Server {
 		cfg:           cfg,
 		scheduleOpt:   newScheduleOption(cfg),
 		isLeaderValue: 0,
+		resignCh:      make(chan struct{}, 1),
 		closed:        1,
+		staleCache:    newStaleCache(),
+		reloader:      newConfigReloader(cfg),
+		tsoQuota:      newTsoQuota(cfg.TsoClientQuota),
+		rateLimiter:   newRateLimiter(cfg.RateLimit),
+		tenants:       make(map[uint64]*Server),
 	}
 
 	s.handler = newHandler(s)
+	s.forwarder = newLeaderForwarder(s)
+	s.regionSyncer = newRegionSyncer(s)
+	s.tsoBatcher = newTsoBatcher(s.getRespTS)
+	s.tsoProxy = newTsoProxy(s, s.forwarder)
+	if cfg.DCLocation != "" {
+		s.localTSO = newLocalTSO(s, cfg.DCLocation)
+	}
+	s.clockMonitor = newClockMonitor(s)
+	s.memberPeers = newMemberPeers(s)
 	return s
 }
 
@@ -126,12 +185,21 @@ func (s *Server) StartEtcd(apiHandler http.Handler) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	s.tlsConfig, err = s.cfg.Security.ToTLSConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	etcdCfg.UserHandlers = map[string]http.Handler{
+		pdClockPath: s.certCNHandler(http.HandlerFunc(serveClock)),
+		metricsPath: http.HandlerFunc(serveMetrics),
+	}
 	if apiHandler != nil {
-		etcdCfg.UserHandlers = map[string]http.Handler{
-			pdAPIPrefix: apiHandler,
-		}
+		etcdCfg.UserHandlers[pdAPIPrefix] = s.certCNHandler(apiHandler)
+	}
+	etcdCfg.ServiceRegister = func(gs *grpc.Server) {
+		pdpb.RegisterPDServer(gs, s.forwarder)
+		healthpb.RegisterHealthServer(gs, newHealthServer(s))
 	}
-	etcdCfg.ServiceRegister = func(gs *grpc.Server) { pdpb.RegisterPDServer(gs, s) }
 
 	log.Info("start embed etcd")
 
@@ -145,7 +213,7 @@ func (s *Server) StartEtcd(apiHandler http.Handler) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err = etcdutil.CheckClusterID(etcd.Server.Cluster().ID(), urlmap); err != nil {
+	if err = etcdutil.CheckClusterID(etcd.Server.Cluster().ID(), urlmap, s.tlsConfig); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -155,6 +223,7 @@ func (s *Server) StartEtcd(apiHandler http.Handler) error {
 	client, err := clientv3.New(clientv3.Config{
 		Endpoints:   endpoints,
 		DialTimeout: etcdTimeout,
+		TLS:         s.tlsConfig,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -181,7 +250,7 @@ func (s *Server) StartEtcd(apiHandler http.Handler) error {
 	for _, m := range etcdMembers.Members {
 		if s.ID() == m.ID {
 			etcdPeerURLs := strings.Join(m.PeerURLs, ",")
-			if s.cfg.AdvertisePeerUrls != etcdPeerURLs {
+			if !urlutil.Equal(strings.Split(s.cfg.AdvertisePeerUrls, ","), m.PeerURLs) {
 				log.Infof("update advertise peer urls from %s to %s", s.cfg.AdvertisePeerUrls, etcdPeerURLs)
 				s.cfg.AdvertisePeerUrls = etcdPeerURLs
 			}
@@ -196,6 +265,10 @@ func (s *Server) StartEtcd(apiHandler http.Handler) error {
 	s.rootPath = path.Join(pdRootPath, strconv.FormatUint(s.clusterID, 10))
 	s.idAlloc = &idAllocator{s: s}
 	s.kv = newKV(s)
+	s.hotRegions, err = newHotRegionStorage(s.cfg.DataDir, s.clusterID)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	s.cluster = newRaftCluster(s, s.clusterID)
 
 	// Server has started.
@@ -255,6 +328,13 @@ func (s *Server) Close() {
 	}
 
 	s.wg.Wait()
+	s.forwarder.close()
+
+	if s.hotRegions != nil {
+		if err := s.hotRegions.close(); err != nil {
+			log.Errorf("close hot region storage error: %v", err)
+		}
+	}
 
 	log.Info("close server")
 }
@@ -270,6 +350,16 @@ func (s *Server) Run() {
 	// address before run, so we set leader value here.
 	s.leaderValue = s.marshalLeader()
 
+	s.wg.Add(4)
+	go s.staleCacheLoop()
+	go s.regionSyncer.syncLoop()
+	go s.configReloadLoop()
+	go s.clockMonitor.run()
+	if s.localTSO != nil {
+		s.wg.Add(1)
+		go s.localTSO.run()
+	}
+
 	s.wg.Add(1)
 	s.leaderLoop()
 }
@@ -284,11 +374,28 @@ func (s *Server) GetHandler() *Handler {
 	return s.handler
 }
 
+// GetPDServer returns the PD gRPC service, the calls are forwarded, routed
+// and validated like the gRPC requests.
+func (s *Server) GetPDServer() pdpb.PDServer {
+	return s.forwarder
+}
+
 // GetEndpoints returns the etcd endpoints for outer use.
 func (s *Server) GetEndpoints() []string {
 	return s.client.Endpoints()
 }
 
+// GetTLSConfig returns the TLS config to connect to PD, it is nil if TLS is
+// not enabled.
+func (s *Server) GetTLSConfig() *tls.Config {
+	return s.tlsConfig
+}
+
+// GetAuthConfig returns the credentials to authenticate the HTTP requests.
+func (s *Server) GetAuthConfig() AuthConfig {
+	return s.cfg.Auth
+}
+
 // GetClient returns builtin etcd client.
 func (s *Server) GetClient() *clientv3.Client {
 	return s.client
@@ -15,15 +15,24 @@ package server
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/embed"
 	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 	"github.com/pingcap/pd/pkg/testutil"
+	"github.com/pingcap/pd/pkg/typeutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 func TestServer(t *testing.T) {
@@ -318,3 +327,87 @@ func (s *testServerSuite) TestCheckClusterID(c *C) {
 	_, err := NewServer(cfgA)
 	c.Assert(err, NotNil)
 }
+
+func (s *testServerSuite) TestCloseWaitsLoops(c *C) {
+	f, err := ioutil.TempFile("", "pd_config")
+	c.Assert(err, IsNil)
+	defer os.Remove(f.Name())
+
+	// Run the config reload loop and the local TSO allocator too.
+	cfg := NewTestSingleConfig()
+	cfg.configFile = f.Name()
+	cfg.DCLocation = "dc1"
+	svrs, cleanup := newTestServersWithCfgs(c, []*Config{cfg})
+	defer cleanup()
+
+	// The loops exit on close rather than on their next tick, and Close waits
+	// for them.
+	start := time.Now()
+	svrs[0].Close()
+	c.Assert(time.Since(start) < configReloadInterval, IsTrue)
+	buf := make([]byte, 1<<20)
+	stacks := string(buf[:runtime.Stack(buf, true)])
+	for _, loop := range []string{"(*Server).configReloadLoop", "(*clockMonitor).run", "(*localTSO).run"} {
+		c.Assert(strings.Contains(stacks, loop), IsFalse, Commentf("%s is still running", loop))
+	}
+}
+
+func (s *testServerSuite) TestGRPCOptions(c *C) {
+	cfg := NewTestSingleConfig()
+	etcdCfg, err := cfg.genEmbedEtcdConfig()
+	c.Assert(err, IsNil)
+	c.Assert(etcdCfg.GRPCKeepAliveInterval, Equals, embed.DefaultGRPCKeepAliveInterval)
+	c.Assert(etcdCfg.MaxRequestBytes, Equals, uint(embed.DefaultMaxRequestBytes))
+
+	cfg.GRPC = GRPCConfig{
+		KeepaliveTime:        typeutil.NewDuration(time.Minute),
+		KeepaliveTimeout:     typeutil.NewDuration(10 * time.Second),
+		KeepaliveMinTime:     typeutil.NewDuration(10 * time.Second),
+		MaxConcurrentStreams: 1,
+		MaxRequestBytes:      typeutil.ByteSize(64 * 1024),
+	}
+	etcdCfg, err = cfg.genEmbedEtcdConfig()
+	c.Assert(err, IsNil)
+	c.Assert(etcdCfg.GRPCKeepAliveInterval, Equals, time.Minute)
+	c.Assert(etcdCfg.GRPCKeepAliveTimeout, Equals, 10*time.Second)
+	c.Assert(etcdCfg.GRPCKeepAliveMinTime, Equals, 10*time.Second)
+	c.Assert(etcdCfg.MaxRequestBytes, Equals, uint(64*1024))
+
+	svrs, cleanup := newTestServersWithCfgs(c, []*Config{cfg})
+	defer cleanup()
+	mustWaitLeader(c, svrs)
+	grpcPDClient := mustNewGrpcClient(c, svrs[0].GetAddr())
+
+	// The messages larger than max-request-bytes and the gRPC overhead are
+	// rejected.
+	header := newRequestHeader(svrs[0].clusterID)
+	store := &metapb.Store{Id: 1, Address: strings.Repeat("a", 1024*1024)}
+	_, err = grpcPDClient.PutStore(context.Background(), &pdpb.PutStoreRequest{Header: header, Store: store})
+	c.Assert(err, NotNil)
+
+	// The connection can only have one stream.
+	heartbeat := func(ctx context.Context) error {
+		stream, err := grpcPDClient.RegionHeartbeat(ctx)
+		c.Assert(err, IsNil)
+		req := &pdpb.RegionHeartbeatRequest{Header: header, Region: &metapb.Region{Id: 1}}
+		if err = stream.Send(req); err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Assert(heartbeat(ctx), IsNil)
+	err = heartbeat(context.Background())
+	c.Assert(grpc.Code(err), Equals, codes.ResourceExhausted)
+
+	// The stream is released after it ends.
+	cancel()
+	for i := 0; i < 100; i++ {
+		if err = heartbeat(context.Background()); grpc.Code(err) != codes.ResourceExhausted {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(err, IsNil)
+}
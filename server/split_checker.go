@@ -0,0 +1,60 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+// splitHintInterval is the interval to ask a region to split again, TiKV
+// needs some time to find the split keys and report the new regions.
+const splitHintInterval = time.Minute
+
+// splitChecker asks the regions larger than the thresholds to split, based
+// on the approximate size and keys reported in their heartbeats.
+type splitChecker struct {
+	opt    *scheduleOption
+	hinted *idCache
+}
+
+func newSplitChecker(opt *scheduleOption) *splitChecker {
+	return &splitChecker{
+		opt:    opt,
+		hinted: newIDCache(splitHintInterval, splitHintInterval),
+	}
+}
+
+// Check returns the split to send to the region, or nil if the region is not
+// large enough or has been asked to split recently.
+func (s *splitChecker) Check(region *RegionInfo) *pdpb.SplitRegion {
+	if !s.isLarge(region) || s.hinted.get(region.GetId()) {
+		return nil
+	}
+	s.hinted.set(region.GetId())
+	log.Infof("[region %d] ask to split, approximate size %d, approximate keys %d",
+		region.GetId(), region.ApproximateSize, region.ApproximateKeys)
+	return &pdpb.SplitRegion{Policy: pdpb.CheckPolicy_APPROXIMATE}
+}
+
+// isLarge checks the approximate size and keys reported by TiKV.
+func (s *splitChecker) isLarge(region *RegionInfo) bool {
+	if maxSize := s.opt.GetRegionSplitSize(); maxSize != 0 && region.ApproximateSize > maxSize {
+		return true
+	}
+	maxKeys := s.opt.GetRegionSplitKeys()
+	return maxKeys != 0 && region.ApproximateKeys > maxKeys
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math"
+	"sync/atomic"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+var _ = Suite(&testSplitCheckerSuite{})
+
+type testSplitCheckerSuite struct{}
+
+func (s *testSplitCheckerSuite) TestSplitChecker(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	sc := newSplitChecker(opt)
+
+	tc.addRegionStore(1, 3)
+	tc.addLeaderRegionInRange(1, "", "a", 200*mb, 1)
+	tc.addLeaderRegionInRange(2, "a", "b", 50*mb, 1)
+	tc.addLeaderRegionInRange(3, "b", "", 50*mb, 1)
+	region := cluster.getRegion(3)
+	region.ApproximateKeys = 2000000
+	c.Assert(cluster.putRegion(region), IsNil)
+
+	// Split is disabled by default.
+	c.Assert(sc.Check(cluster.getRegion(1)), IsNil)
+
+	cfg.RegionSplitSize = 100 * mb
+	c.Assert(sc.Check(cluster.getRegion(1)), DeepEquals, &pdpb.SplitRegion{Policy: pdpb.CheckPolicy_APPROXIMATE})
+	c.Assert(sc.Check(cluster.getRegion(2)), IsNil)
+	c.Assert(sc.Check(cluster.getRegion(3)), IsNil)
+	// The region is not asked again until the split hint interval passes.
+	c.Assert(sc.Check(cluster.getRegion(1)), IsNil)
+
+	cfg.RegionSplitKeys = 1000000
+	c.Assert(sc.Check(cluster.getRegion(2)), IsNil)
+	c.Assert(sc.Check(cluster.getRegion(3)), NotNil)
+}
+
+func (s *testSplitCheckerSuite) TestDispatchSplit(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.RegionSplitSize = 100 * mb
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 2)
+	tc.addRegionStore(2, 2)
+	tc.addRegionStore(3, 2)
+	tc.addLeaderRegionInRange(1, "", "a", 200*mb, 1, 2)
+	tc.addLeaderRegionInRange(2, "a", "", 200*mb, 1, 2, 3)
+
+	// The split is asked first, and the missing replica is repaired in the
+	// next heartbeat.
+	resp := co.dispatch(cluster.getRegion(1))
+	c.Assert(resp.GetSplitRegion().GetPolicy(), Equals, pdpb.CheckPolicy_APPROXIMATE)
+	checkAddPeerResp(c, co.dispatch(cluster.getRegion(1)), 3)
+	resp = co.dispatch(cluster.getRegion(2))
+	c.Assert(resp.GetSplitRegion().GetPolicy(), Equals, pdpb.CheckPolicy_APPROXIMATE)
+	c.Assert(co.dispatch(cluster.getRegion(2)), IsNil)
+}
+
+func (s *testSplitCheckerSuite) TestDispatchSplitWhenBusy(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.WarmUpRegionRatio = 0
+	cfg.RegionSplitSize = 100 * mb
+	cfg.ReplicaScheduleLimit = 1
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 3)
+	tc.addRegionStore(2, 3)
+	tc.addRegionStore(3, 3)
+	tc.addLeaderRegionInRange(1, "", "a", 50*mb, 1, 2)
+	tc.addLeaderRegionInRange(2, "a", "b", 50*mb, 1, 2)
+	tc.addLeaderRegionInRange(3, "b", "", 200*mb, 1, 2)
+
+	// The replica schedule limit is saturated by region 1, region 2 has to
+	// wait, but region 3 is still asked to split.
+	checkAddPeerResp(c, co.dispatch(cluster.getRegion(1)), 3)
+	c.Assert(co.dispatch(cluster.getRegion(2)), IsNil)
+	c.Assert(co.allowReplicaCheck(), IsFalse)
+	resp := co.dispatch(cluster.getRegion(3))
+	c.Assert(resp.GetSplitRegion().GetPolicy(), Equals, pdpb.CheckPolicy_APPROXIMATE)
+
+	// So is it when the scheduling is paused.
+	co.splitter = newSplitChecker(opt)
+	atomic.StoreInt64(&co.pauseUntil, math.MaxInt64)
+	c.Assert(co.isSchedulePaused(), IsTrue)
+	resp = co.dispatch(cluster.getRegion(3))
+	c.Assert(resp.GetSplitRegion().GetPolicy(), Equals, pdpb.CheckPolicy_APPROXIMATE)
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+const staleCacheSyncInterval = 10 * time.Second
+
+// staleCache holds the cluster meta on followers, so that read-only requests
+// which allow bounded staleness can be served without the leader. The stores
+// are loaded from etcd, and the regions with their leaders are synced from
+// the leader by the region syncer. The stores and the regions are synced
+// separately, the cache is fresh only if both of them are.
+type staleCache struct {
+	sync.RWMutex
+	stores          *storesInfo
+	regions         *regionsInfo
+	storesSyncTime  time.Time
+	regionsSyncTime time.Time
+}
+
+func newStaleCache() *staleCache {
+	return &staleCache{
+		stores:  newStoresInfo(),
+		regions: newRegionsInfo(),
+	}
+}
+
+// syncStores reloads the stores, the regions are synced from the leader.
+func (c *staleCache) syncStores(kv *kv) error {
+	stores := newStoresInfo()
+	start := time.Now()
+	if err := kv.loadStores(stores, kvRangeLimit); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.stores, c.storesSyncTime = stores, start
+	return nil
+}
+
+// putRegions updates the regions synced from the leader.
+func (c *staleCache) putRegions(regions []*RegionInfo) {
+	c.Lock()
+	defer c.Unlock()
+	for _, region := range regions {
+		c.regions.setRegion(region)
+	}
+}
+
+// setRegionsSynced records the time when the regions are known to be synced
+// with the leader.
+func (c *staleCache) setRegionsSynced(t time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.regionsSyncTime = t
+}
+
+// getRegions returns the regions with their leaders.
+func (c *staleCache) getRegions() []*RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.getRegions()
+}
+
+// isFresh checks whether the cache is synced within maxStaleness.
+func (c *staleCache) isFresh(maxStaleness time.Duration) bool {
+	c.RLock()
+	defer c.RUnlock()
+	return time.Since(c.storesSyncTime) <= maxStaleness &&
+		time.Since(c.regionsSyncTime) <= maxStaleness
+}
+
+func (c *staleCache) getStore(storeID uint64) *metapb.Store {
+	c.RLock()
+	defer c.RUnlock()
+	if store := c.stores.getStore(storeID); store != nil {
+		return store.Store
+	}
+	return nil
+}
+
+func (c *staleCache) getRegion(regionID uint64) *RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.getRegion(regionID)
+}
+
+func (c *staleCache) searchRegion(regionKey []byte) *RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.searchRegion(regionKey)
+}
+
+func (c *staleCache) searchPrevRegion(regionKey []byte) *RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.searchPrevRegion(regionKey)
+}
+
+func (c *staleCache) scanRegions(startKey, endKey []byte, limit int) []*RegionInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.regions.scanRegions(startKey, endKey, limit)
+}
+
+// staleCacheLoop keeps the stores of the stale cache synced while the server
+// is a follower.
+func (s *Server) staleCacheLoop() {
+	defer s.wg.Done()
+
+	ctx := s.client.Ctx()
+	ticker := time.NewTicker(staleCacheSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if s.IsLeader() {
+			continue
+		}
+		if err := s.staleCache.syncStores(s.kv); err != nil && !s.isClosed() {
+			log.Errorf("sync stale cache err %v", err)
+		}
+	}
+}
+
+// allowStaleRead checks whether a read-only request can be served by the
+// follower with the stale cache.
+func (s *Server) allowStaleRead(header *pdpb.RequestHeader) bool {
+	if s.IsLeader() || header.GetClusterId() != s.clusterID {
+		return false
+	}
+	maxStaleness := time.Duration(header.GetMaxStalenessMs()) * time.Millisecond
+	return maxStaleness > 0 && s.staleCache.isFresh(maxStaleness)
+}
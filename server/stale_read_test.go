@@ -0,0 +1,105 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+)
+
+var _ = Suite(&testStaleReadSuite{})
+
+type testStaleReadSuite struct {
+	server  *Server
+	cleanup cleanUpFunc
+}
+
+func (s *testStaleReadSuite) SetUpTest(c *C) {
+	s.server, s.cleanup = mustRunTestServer(c)
+}
+
+func (s *testStaleReadSuite) TearDownTest(c *C) {
+	s.cleanup()
+}
+
+func (s *testStaleReadSuite) TestStaleCache(c *C) {
+	kv := newKV(s.server)
+	cache := newStaleCache()
+	c.Assert(cache.isFresh(time.Hour), IsFalse)
+
+	c.Assert(kv.saveStore(&metapb.Store{Id: 1}), IsNil)
+	c.Assert(cache.syncStores(kv), IsNil)
+	c.Assert(cache.isFresh(time.Hour), IsFalse)
+	cache.putRegions([]*RegionInfo{newRegionInfo(&metapb.Region{Id: 2, StartKey: []byte("a"), EndKey: []byte("b")}, nil)})
+	cache.setRegionsSynced(time.Now())
+	c.Assert(cache.isFresh(time.Hour), IsTrue)
+
+	c.Assert(cache.getStore(1).GetId(), Equals, uint64(1))
+	c.Assert(cache.getStore(2), IsNil)
+	c.Assert(cache.getRegion(2).GetId(), Equals, uint64(2))
+	c.Assert(cache.searchRegion([]byte("a")).GetId(), Equals, uint64(2))
+	c.Assert(cache.searchRegion([]byte("b")), IsNil)
+
+	// The regions synced long ago are stale.
+	cache.setRegionsSynced(time.Now().Add(-time.Minute))
+	c.Assert(cache.isFresh(time.Hour), IsTrue)
+	c.Assert(cache.isFresh(time.Second), IsFalse)
+
+	// Leader never serves stale reads.
+	header := &pdpb.RequestHeader{ClusterId: s.server.clusterID, MaxStalenessMs: 1000}
+	c.Assert(s.server.allowStaleRead(header), IsFalse)
+}
+
+func (s *testStaleReadSuite) TestStaleRegions(c *C) {
+	leader, down := &metapb.Peer{Id: 3, StoreId: 1}, &metapb.Peer{Id: 4, StoreId: 2}
+	region := newRegionInfo(&metapb.Region{
+		Id:       2,
+		StartKey: []byte("a"),
+		EndKey:   []byte("b"),
+		Peers:    []*metapb.Peer{leader, down},
+	}, leader)
+	region.DownPeers = []*pdpb.PeerStats{{Peer: down, DownSeconds: 60}}
+	region.PendingPeers = []*metapb.Peer{down}
+	next := newRegionInfo(&metapb.Region{Id: 5, StartKey: []byte("b"), Peers: []*metapb.Peer{{Id: 6, StoreId: 1}}}, nil)
+	s.server.staleCache.putRegions([]*RegionInfo{region, next})
+	c.Assert(s.server.staleCache.syncStores(s.server.kv), IsNil)
+	s.server.staleCache.setRegionsSynced(time.Now())
+
+	s.server.enableLeader(false)
+	defer s.server.enableLeader(true)
+	header := &pdpb.RequestHeader{ClusterId: s.server.clusterID, MaxStalenessMs: 60000}
+	ctx := context.Background()
+
+	// The leaders and the unhealthy peers are returned as the leader does.
+	checkRegion := func(resp *pdpb.GetRegionResponse, err error) {
+		c.Assert(err, IsNil)
+		c.Assert(resp.GetRegion().GetId(), Equals, uint64(2))
+		c.Assert(resp.GetLeader(), DeepEquals, leader)
+		c.Assert(resp.GetDownPeers(), DeepEquals, region.DownPeers)
+		c.Assert(resp.GetPendingPeers(), DeepEquals, region.PendingPeers)
+	}
+	checkRegion(s.server.GetRegion(ctx, &pdpb.GetRegionRequest{Header: header, RegionKey: []byte("a")}))
+	checkRegion(s.server.GetPrevRegion(ctx, &pdpb.GetRegionRequest{Header: header, RegionKey: []byte("b")}))
+	checkRegion(s.server.GetRegionByID(ctx, &pdpb.GetRegionByIDRequest{Header: header, RegionId: 2}))
+
+	// The unknown leader is an empty peer.
+	resp, err := s.server.ScanRegions(ctx, &pdpb.ScanRegionsRequest{Header: header, StartKey: []byte("a")})
+	c.Assert(err, IsNil)
+	c.Assert(resp.GetRegionMetas(), HasLen, 2)
+	c.Assert(resp.GetLeaders(), DeepEquals, []*metapb.Peer{leader, {}})
+}
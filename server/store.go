@@ -14,13 +14,21 @@
 package server
 
 import (
+	"math"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
-	"github.com/pingcap/kvproto/pkg/metapb"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/metapb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
+// minLeaderWeight is used to calculate the leader score of the stores with 0
+// leader weight, so that they are always preferred to be the source.
+const minLeaderWeight = 1e-6
+
+// minRegionWeight is like minLeaderWeight, but for the regions.
+const minRegionWeight = 1e-6
+
 // storeInfo contains information about a store.
 // TODO: Export this to API directly.
 type storeInfo struct {
@@ -74,19 +82,58 @@ func (s *storeInfo) leaderCount() uint64 {
 	return uint64(s.status.LeaderCount)
 }
 
+// leaderWeight returns the weight of the store by its label and its own
+// weight.
+func (s *storeInfo) leaderWeight() float64 {
+	return s.status.labelLeaderWeight * s.status.LeaderWeight
+}
+
+// leaderScore counts the leaders being transferred by the running operators
+// too, see opInfluence.
 func (s *storeInfo) leaderScore() float64 {
-	return float64(s.status.LeaderCount)
+	count := math.Max(float64(s.status.LeaderCount+s.status.influence.leaderCount), 0)
+	return count / math.Max(s.leaderWeight(), minLeaderWeight)
 }
 
 func (s *storeInfo) regionCount() uint64 {
 	return uint64(s.status.RegionCount)
 }
 
+// regionScore weights the store by the size of its regions, so a store with
+// many small regions isn't treated as one with as many huge ones. The regions
+// being moved by the running operators are counted too.
 func (s *storeInfo) regionScore() float64 {
 	if s.status.GetCapacity() == 0 {
 		return 0
 	}
-	return float64(s.status.RegionCount) / float64(s.status.GetCapacity())
+	size := math.Max(float64(int64(s.status.RegionSize)+s.status.influence.regionSize), 0)
+	return size / float64(s.status.GetCapacity()) / math.Max(s.status.RegionWeight, minRegionWeight)
+}
+
+func (s *storeInfo) pendingPeerCount() uint64 {
+	return uint64(s.status.PendingPeerCount)
+}
+
+// snapshotCount returns the number of snapshots being sent or received.
+func (s *storeInfo) snapshotCount() uint64 {
+	return uint64(s.status.GetSendingSnapCount()) + uint64(s.status.GetReceivingSnapCount())
+}
+
+// updateSnapshotBandwidth updates the estimated snapshot bandwidth with the
+// snapshots reported by the heartbeat, the recent ones weigh more.
+func (s *storeInfo) updateSnapshotBandwidth(stats *pdpb.StoreStats) {
+	if stats.GetSnapshotBytes() == 0 || stats.GetSnapshotDurationMs() == 0 {
+		return
+	}
+	bandwidth := stats.GetSnapshotBytes() * 1000 / stats.GetSnapshotDurationMs()
+	if s.status.snapshotBandwidth != 0 {
+		bandwidth = (s.status.snapshotBandwidth + bandwidth) / 2
+	}
+	s.status.snapshotBandwidth = bandwidth
+}
+
+func (s *storeInfo) getSnapshotBandwidth() uint64 {
+	return s.status.snapshotBandwidth
 }
 
 func (s *storeInfo) storageSize() uint64 {
@@ -100,6 +147,12 @@ func (s *storeInfo) availableRatio() float64 {
 	return float64(s.status.GetAvailable()) / float64(s.status.GetCapacity())
 }
 
+// isLowSpace checks whether the available space of the store is less than the
+// ratio of its capacity, a store which doesn't report its capacity is not.
+func (s *storeInfo) isLowSpace(lowSpaceRatio float64) bool {
+	return s.status.GetCapacity() != 0 && s.availableRatio() < lowSpaceRatio
+}
+
 func (s *storeInfo) resourceCount(kind ResourceKind) uint64 {
 	switch kind {
 	case LeaderKind:
@@ -123,12 +176,16 @@ func (s *storeInfo) resourceScore(kind ResourceKind) float64 {
 }
 
 func (s *storeInfo) getLabelValue(key string) string {
+	return s.getLabel(key).GetValue()
+}
+
+func (s *storeInfo) getLabel(key string) *metapb.StoreLabel {
 	for _, label := range s.GetLabels() {
 		if label.GetKey() == key {
-			return label.GetValue()
+			return label
 		}
 	}
-	return ""
+	return nil
 }
 
 func (s *storeInfo) getLocationID(keys []string) string {
@@ -148,25 +205,53 @@ type StoreStatus struct {
 	*pdpb.StoreStats
 
 	// Blocked means that the store is blocked from balance.
-	blocked         bool
-	LeaderCount     int
-	RegionCount     int
+	blocked bool
+	// labelLeaderWeight is the relative leader capacity of the store by its
+	// label value, leaders are balanced by the leader count divided by it. 0
+	// means no leader.
+	labelLeaderWeight float64
+	LeaderCount       int
+	RegionCount       int
+	RegionSize        uint64
+	// PendingPeerCount is the number of the peers which are still catching
+	// up with their leaders, by applying snapshots or logs.
+	PendingPeerCount int
+	// LeaderWeight and RegionWeight are the relative capacities of the store
+	// set by the users, 1 by default.
+	LeaderWeight    float64
+	RegionWeight    float64
 	LastHeartbeatTS time.Time `json:"last_heartbeat_ts"`
+	// influence is the pending change by the running operators.
+	influence storeInfluence
+	// snapshotBandwidth is the bandwidth used by one snapshot transfer
+	// estimated from the snapshots reported by the heartbeats, 0 if no
+	// snapshot is reported yet.
+	snapshotBandwidth uint64
 }
 
 func newStoreStatus() *StoreStatus {
 	return &StoreStatus{
-		StoreStats: &pdpb.StoreStats{},
+		StoreStats:        &pdpb.StoreStats{},
+		labelLeaderWeight: 1,
+		LeaderWeight:      1,
+		RegionWeight:      1,
 	}
 }
 
 func (s *StoreStatus) clone() *StoreStatus {
 	return &StoreStatus{
-		StoreStats:      proto.Clone(s.StoreStats).(*pdpb.StoreStats),
-		blocked:         s.blocked,
-		LeaderCount:     s.LeaderCount,
-		RegionCount:     s.RegionCount,
-		LastHeartbeatTS: s.LastHeartbeatTS,
+		StoreStats:        proto.Clone(s.StoreStats).(*pdpb.StoreStats),
+		blocked:           s.blocked,
+		labelLeaderWeight: s.labelLeaderWeight,
+		influence:         s.influence,
+		LeaderCount:       s.LeaderCount,
+		RegionCount:       s.RegionCount,
+		RegionSize:        s.RegionSize,
+		PendingPeerCount:  s.PendingPeerCount,
+		LeaderWeight:      s.LeaderWeight,
+		RegionWeight:      s.RegionWeight,
+		LastHeartbeatTS:   s.LastHeartbeatTS,
+		snapshotBandwidth: s.snapshotBandwidth,
 	}
 }
 
@@ -0,0 +1,162 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/pd/pkg/typeutil"
+)
+
+// drainRateWindow is how long the samples are used to calculate the drain rate.
+const drainRateWindow = 10 * time.Minute
+
+type drainSample struct {
+	time      time.Time
+	remaining uint64
+}
+
+// storeDrain records the progress of draining a store.
+type storeDrain struct {
+	kind      ResourceKind
+	startTime time.Time
+	total     uint64
+	samples   []drainSample
+}
+
+// StoreDrainStatus is the progress of draining a store. Regions are drained
+// from offline stores, and leaders are drained from blocked stores.
+type StoreDrainStatus struct {
+	StoreID       uint64    `json:"store_id"`
+	Kind          string    `json:"kind"`
+	StartTime     time.Time `json:"start_time"`
+	Total         uint64    `json:"total"`
+	Remaining     uint64    `json:"remaining"`
+	RemainingSize uint64    `json:"remaining_size"`
+	// Rate is the number of resources drained per second.
+	Rate float64 `json:"rate"`
+	// ETA is the estimated time left, it is nil if the store makes no
+	// progress in the last window.
+	ETA *typeutil.Duration `json:"eta,omitempty"`
+}
+
+// drainEstimator estimates the time left to drain the offline and blocked
+// stores by the rate observed recently.
+type drainEstimator struct {
+	sync.RWMutex
+	drains map[uint64]*storeDrain
+}
+
+func newDrainEstimator() *drainEstimator {
+	return &drainEstimator{
+		drains: make(map[uint64]*storeDrain),
+	}
+}
+
+// drainKind returns the kind of resources being drained from the store, or
+// UnKnownKind if the store is not draining.
+func drainKind(store *storeInfo) ResourceKind {
+	switch {
+	case store.isOffline():
+		return RegionKind
+	case store.isUp() && store.isBlocked():
+		return LeaderKind
+	default:
+		return UnKnownKind
+	}
+}
+
+// update takes a sample of the draining stores.
+func (e *drainEstimator) update(stores []*storeInfo, now time.Time) {
+	e.Lock()
+	defer e.Unlock()
+
+	draining := make(map[uint64]struct{})
+	for _, store := range stores {
+		kind := drainKind(store)
+		if kind == UnKnownKind {
+			continue
+		}
+		draining[store.GetId()] = struct{}{}
+
+		remaining := store.resourceCount(kind)
+		d, ok := e.drains[store.GetId()]
+		if !ok || d.kind != kind {
+			d = &storeDrain{
+				kind:      kind,
+				startTime: now,
+				total:     remaining,
+			}
+			e.drains[store.GetId()] = d
+		}
+		d.samples = append(d.samples, drainSample{time: now, remaining: remaining})
+		// Keeps one sample before the window to cover the whole window.
+		for len(d.samples) > 2 && now.Sub(d.samples[1].time) > drainRateWindow {
+			d.samples = d.samples[1:]
+		}
+	}
+	for id := range e.drains {
+		if _, ok := draining[id]; !ok {
+			delete(e.drains, id)
+		}
+	}
+}
+
+// getStatus returns the drain status of the store with its current remaining
+// resources, or nil if the store is not draining.
+func (e *drainEstimator) getStatus(store *storeInfo, now time.Time) *StoreDrainStatus {
+	e.RLock()
+	defer e.RUnlock()
+
+	d, ok := e.drains[store.GetId()]
+	if !ok || d.kind != drainKind(store) {
+		return nil
+	}
+	status := &StoreDrainStatus{
+		StoreID:       store.GetId(),
+		Kind:          d.kind.String(),
+		StartTime:     d.startTime,
+		Total:         d.total,
+		Remaining:     store.resourceCount(d.kind),
+		RemainingSize: store.storageSize(),
+	}
+	first := d.samples[0]
+	elapsed := now.Sub(first.time).Seconds()
+	if elapsed <= 0 || first.remaining <= status.Remaining {
+		return status
+	}
+	status.Rate = float64(first.remaining-status.Remaining) / elapsed
+	eta := typeutil.NewDuration(time.Duration(float64(status.Remaining) / status.Rate * float64(time.Second)))
+	status.ETA = &eta
+	return status
+}
+
+func (c *RaftCluster) updateStoreDrains() {
+	c.drains.update(c.cachedCluster.getStores(), time.Now())
+}
+
+// GetStoreDrains returns the drain status of the offline and blocked stores.
+func (c *RaftCluster) GetStoreDrains() []*StoreDrainStatus {
+	now := time.Now()
+	var drains []*StoreDrainStatus
+	for _, store := range c.cachedCluster.getStores() {
+		if status := c.drains.getStatus(store, now); status != nil {
+			drains = append(drains, status)
+		}
+	}
+	sort.Slice(drains, func(i, j int) bool { return drains[i].StoreID < drains[j].StoreID })
+	return drains
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testStoreDrainSuite{})
+
+type testStoreDrainSuite struct{}
+
+func (s *testStoreDrainSuite) TestDrainEstimator(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	e := newDrainEstimator()
+	now := time.Now()
+
+	tc.addRegionStore(1, 100)
+	tc.addLeaderStore(2, 50)
+	tc.addRegionStore(3, 10)
+	tc.setStoreOffline(1)
+	tc.blockStore(2)
+
+	e.update(cluster.getStores(), now)
+	c.Assert(e.getStatus(cluster.getStore(3), now), IsNil)
+
+	// No progress yet.
+	status := e.getStatus(cluster.getStore(1), now)
+	c.Assert(status.Kind, Equals, "region")
+	c.Assert(status.Total, Equals, uint64(100))
+	c.Assert(status.Remaining, Equals, uint64(100))
+	c.Assert(status.ETA, IsNil)
+
+	// 40 regions are drained in 1 minute, the rest need 1.5 minutes.
+	tc.updateRegionCount(1, 60)
+	status = e.getStatus(cluster.getStore(1), now.Add(time.Minute))
+	c.Assert(status.Remaining, Equals, uint64(60))
+	c.Assert(status.ETA.Duration, Equals, 90*time.Second)
+
+	// 25 leaders are drained in 5 minutes.
+	tc.updateLeaderCount(2, 25)
+	e.update(cluster.getStores(), now.Add(5*time.Minute))
+	status = e.getStatus(cluster.getStore(2), now.Add(5*time.Minute))
+	c.Assert(status.Kind, Equals, "leader")
+	c.Assert(status.ETA.Duration, Equals, 5*time.Minute)
+
+	// Old samples out of the window are not used.
+	e.update(cluster.getStores(), now.Add(20*time.Minute))
+	e.update(cluster.getStores(), now.Add(30*time.Minute))
+	c.Assert(e.getStatus(cluster.getStore(2), now.Add(30*time.Minute)).ETA, IsNil)
+
+	// Stores not draining any more are removed.
+	tc.unblockStore(2)
+	e.update(cluster.getStores(), now.Add(31*time.Minute))
+	c.Assert(e.getStatus(cluster.getStore(2), now.Add(31*time.Minute)), IsNil)
+	c.Assert(e.drains, HasLen, 1)
+}
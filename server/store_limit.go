@@ -0,0 +1,151 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// getStoreLimit returns the limit of the store, a store without its own
+// limit uses store-balance-rate.
+func (c *clusterInfo) getStoreLimit(storeID uint64, opt *scheduleOption) *StoreLimit {
+	c.RLock()
+	defer c.RUnlock()
+	if limit, ok := c.storeLimits[storeID]; ok {
+		return limit
+	}
+	rate := opt.GetStoreBalanceRate()
+	return &StoreLimit{
+		StoreID:    storeID,
+		AddPeer:    rate,
+		RemovePeer: rate,
+	}
+}
+
+// setStoreLimit persists and sets the limit of a store.
+func (c *clusterInfo) setStoreLimit(limit *StoreLimit) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.stores.getStore(limit.StoreID) == nil {
+		return errors.Trace(errStoreNotFound(limit.StoreID))
+	}
+	if c.kv != nil {
+		if err := c.kv.saveStoreLimit(limit); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.storeLimits[limit.StoreID] = limit
+	return nil
+}
+
+// GetStoreLimits returns the limits of all stores.
+func (c *RaftCluster) GetStoreLimits() []*StoreLimit {
+	stores := c.cachedCluster.getMetaStores()
+	limits := make([]*StoreLimit, 0, len(stores))
+	for _, store := range stores {
+		limits = append(limits, c.GetStoreLimit(store.GetId()))
+	}
+	return limits
+}
+
+// GetStoreLimit returns the limit of a store.
+func (c *RaftCluster) GetStoreLimit(storeID uint64) *StoreLimit {
+	return c.cachedCluster.getStoreLimit(storeID, c.s.scheduleOpt)
+}
+
+// SetStoreLimit sets the max number of the add-peer and remove-peer
+// operators of a store per minute, 0 means no limit.
+func (c *RaftCluster) SetStoreLimit(storeID uint64, addPeer, removePeer float64) error {
+	if addPeer < 0 || removePeer < 0 {
+		return errors.Errorf("invalid store limit add-peer %v remove-peer %v", addPeer, removePeer)
+	}
+	limit := &StoreLimit{
+		StoreID:    storeID,
+		AddPeer:    addPeer,
+		RemovePeer: removePeer,
+	}
+	return errors.Trace(c.cachedCluster.setStoreLimit(limit))
+}
+
+// storeLimiter limits the rates of the add-peer and remove-peer operators of
+// each store, so that a store is not flooded with snapshots or deletions.
+type storeLimiter struct {
+	sync.Mutex
+	cluster *clusterInfo
+	opt     *scheduleOption
+	add     map[uint64]*tokenBucket
+	remove  map[uint64]*tokenBucket
+}
+
+func newStoreLimiter(cluster *clusterInfo, opt *scheduleOption) *storeLimiter {
+	return &storeLimiter{
+		cluster: cluster,
+		opt:     opt,
+		add:     make(map[uint64]*tokenBucket),
+		remove:  make(map[uint64]*tokenBucket),
+	}
+}
+
+// allow checks whether the stores have tokens for the peers added and
+// removed by the operator.
+func (l *storeLimiter) allow(op Operator, now time.Time) bool {
+	l.Lock()
+	defer l.Unlock()
+	for _, bucket := range l.getBuckets(op, now) {
+		if bucket.tokens < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// take takes the tokens of the peers added and removed by the operator.
+func (l *storeLimiter) take(op Operator, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	for _, bucket := range l.getBuckets(op, now) {
+		bucket.tokens--
+	}
+}
+
+// getBuckets returns the refilled buckets of the limited stores of the
+// operator. The limits are per minute, while the buckets are per second.
+func (l *storeLimiter) getBuckets(op Operator, now time.Time) []*tokenBucket {
+	var buckets []*tokenBucket
+	addStores, removeStores := getChangePeerStores(op)
+	for _, id := range addStores {
+		if rate := l.cluster.getStoreLimit(id, l.opt).AddPeer; rate != 0 {
+			buckets = append(buckets, getStoreBucket(l.add, id, rate/60, now))
+		}
+	}
+	for _, id := range removeStores {
+		if rate := l.cluster.getStoreLimit(id, l.opt).RemovePeer; rate != 0 {
+			buckets = append(buckets, getStoreBucket(l.remove, id, rate/60, now))
+		}
+	}
+	return buckets
+}
+
+func getStoreBucket(buckets map[uint64]*tokenBucket, storeID uint64, rate float64, now time.Time) *tokenBucket {
+	bucket, ok := buckets[storeID]
+	if !ok {
+		bucket = newTokenBucket(rate, now)
+		buckets[storeID] = bucket
+	}
+	bucket.refill(rate, now)
+	return bucket
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/metapb"
+)
+
+var _ = Suite(&testStoreLimitSuite{})
+
+type testStoreLimitSuite struct{}
+
+func (s *testStoreLimitSuite) TestStoreLimiter(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.StoreBalanceRate = 60
+	l := newStoreLimiter(cluster, opt)
+	now := time.Now()
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addRegionStore(3, 1)
+	tc.addLeaderRegion(1, 1, 2)
+	region := cluster.getRegion(1)
+	addPeer := func(storeID uint64) Operator {
+		peer, _ := cluster.allocPeer(storeID)
+		return newAddPeer(region, peer, false)
+	}
+	allow := func(op Operator) bool {
+		if l.allow(op, now) {
+			l.take(op, now)
+			return true
+		}
+		return false
+	}
+
+	// Each store can add or remove a peer every second.
+	c.Assert(allow(addPeer(3)), IsTrue)
+	c.Assert(allow(addPeer(3)), IsFalse)
+	c.Assert(allow(newRemovePeer(region, region.GetStorePeer(2))), IsTrue)
+	c.Assert(allow(newRemovePeer(region, region.GetStorePeer(2))), IsFalse)
+	peer, _ := cluster.allocPeer(3)
+	c.Assert(allow(newTransferPeer(region, RegionKind, region.GetStorePeer(1), peer, opt)), IsFalse)
+	now = now.Add(time.Second)
+	c.Assert(allow(newTransferPeer(region, RegionKind, region.GetStorePeer(1), peer, opt)), IsTrue)
+
+	// Changing the role of a peer is not limited.
+	learner := &metapb.Peer{Id: 100, StoreId: 3, Role: metapb.PeerRole_Learner}
+	op := newRegionOperator(region, RegionKind, newChangePeerRoleOperator(1, learner, metapb.PeerRole_Voter))
+	c.Assert(allow(op), IsTrue)
+
+	// Store 3 has its own limits, 0 means no limit.
+	c.Assert(cluster.setStoreLimit(&StoreLimit{StoreID: 3, AddPeer: 0, RemovePeer: 6}), IsNil)
+	c.Assert(cluster.setStoreLimit(&StoreLimit{StoreID: 4}), NotNil)
+	for i := 0; i < 3; i++ {
+		c.Assert(allow(addPeer(3)), IsTrue)
+	}
+	tc.addLeaderRegion(2, 1, 3)
+	region = cluster.getRegion(2)
+	c.Assert(allow(newRemovePeer(region, region.GetStorePeer(3))), IsTrue)
+	now = now.Add(5 * time.Second)
+	c.Assert(allow(newRemovePeer(region, region.GetStorePeer(3))), IsFalse)
+	now = now.Add(5 * time.Second)
+	c.Assert(allow(newRemovePeer(region, region.GetStorePeer(3))), IsTrue)
+
+	// No limit.
+	cfg.StoreBalanceRate = 0
+	for i := 0; i < 3; i++ {
+		c.Assert(allow(addPeer(2)), IsTrue)
+	}
+}
+
+func (s *testStoreLimitSuite) TestCoordinator(c *C) {
+	cluster := newClusterInfo(newMockIDAllocator())
+	tc := newTestClusterInfo(cluster)
+	cfg, opt := newTestScheduleConfig()
+	cfg.StoreBalanceRate = 1
+	co := newCoordinator(cluster, opt)
+
+	tc.addRegionStore(1, 1)
+	tc.addRegionStore(2, 1)
+	tc.addLeaderRegion(1, 1)
+	tc.addLeaderRegion(2, 1)
+	addPeer := func(regionID, storeID uint64) Operator {
+		peer, _ := cluster.allocPeer(storeID)
+		return newAddPeer(cluster.getRegion(regionID), peer, false)
+	}
+
+	c.Assert(co.addOperator(addPeer(1, 2)), IsTrue)
+	c.Assert(co.addOperator(addPeer(2, 2)), IsFalse)
+
+	// Admin operators are not limited.
+	peer, _ := cluster.allocPeer(2)
+	c.Assert(co.addOperator(newAdminOperator(cluster.getRegion(2), newAddPeerOperator(2, peer))), IsTrue)
+}
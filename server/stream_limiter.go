@@ -0,0 +1,67 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+// streamLimiter limits the concurrent gRPC streams of each connection. etcd
+// creates the gRPC server without a stream limit and doesn't allow to set
+// one, so the limit is checked when PD starts to serve a stream, and the
+// streams over it are rejected instead of being queued by the client. The
+// connections are told apart by their remote addresses.
+type streamLimiter struct {
+	max uint32
+
+	sync.Mutex
+	streams map[string]uint32
+}
+
+func newStreamLimiter(max uint32) *streamLimiter {
+	return &streamLimiter{
+		max:     max,
+		streams: make(map[string]uint32),
+	}
+}
+
+// acquire counts a new stream of the connection, the returned function must
+// be called when the stream ends.
+func (l *streamLimiter) acquire(ctx context.Context) (func(), error) {
+	p, ok := peer.FromContext(ctx)
+	if l.max == 0 || !ok {
+		return func() {}, nil
+	}
+	addr := p.Addr.String()
+
+	l.Lock()
+	defer l.Unlock()
+	if l.streams[addr] >= l.max {
+		grpcRejectedStreamCounter.Inc()
+		return nil, grpc.Errorf(codes.ResourceExhausted, "too many streams of %s, the limit is %d", addr, l.max)
+	}
+	l.streams[addr]++
+	return func() {
+		l.Lock()
+		defer l.Unlock()
+		if l.streams[addr]--; l.streams[addr] == 0 {
+			delete(l.streams, addr)
+		}
+	}, nil
+}
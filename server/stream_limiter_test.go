@@ -0,0 +1,57 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var _ = Suite(&testStreamLimiterSuite{})
+
+type testStreamLimiterSuite struct{}
+
+func (s *testStreamLimiterSuite) TestStreamLimiter(c *C) {
+	l := newStreamLimiter(2)
+	a, b := newPeerContext("tcp", "10.0.0.1:1"), newPeerContext("tcp", "10.0.0.1:2")
+
+	// Each connection can have 2 streams.
+	release1, err := l.acquire(a)
+	c.Assert(err, IsNil)
+	_, err = l.acquire(a)
+	c.Assert(err, IsNil)
+	_, err = l.acquire(a)
+	c.Assert(grpc.Code(err), Equals, codes.ResourceExhausted)
+	_, err = l.acquire(b)
+	c.Assert(err, IsNil)
+
+	// The released stream can be reused.
+	release1()
+	_, err = l.acquire(a)
+	c.Assert(err, IsNil)
+
+	// The streams are not limited without the limit or the peer.
+	l = newStreamLimiter(0)
+	for i := 0; i < 3; i++ {
+		_, err = l.acquire(a)
+		c.Assert(err, IsNil)
+	}
+	l = newStreamLimiter(1)
+	for i := 0; i < 3; i++ {
+		_, err = l.acquire(context.Background())
+		c.Assert(err, IsNil)
+	}
+}
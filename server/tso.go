@@ -21,7 +21,7 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/juju/errors"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
 )
 
 const (
@@ -29,6 +29,9 @@ const (
 	updateTimestampStep  = 50 * time.Millisecond
 	updateTimestampGuard = time.Millisecond
 	maxLogical           = int64(1 << 18)
+	// minTsoSaveInterval keeps the save window from being used up between
+	// two updates.
+	minTsoSaveInterval = 2 * updateTimestampStep
 )
 
 var (
@@ -38,6 +41,8 @@ var (
 type atomicObject struct {
 	physical time.Time
 	logical  int64
+	// clock is the local clock when the physical time is set.
+	clock time.Time
 }
 
 func (s *Server) getTimestampPath() string {
@@ -55,18 +60,23 @@ func (s *Server) loadTimestamp() (time.Time, error) {
 	return parseTimestamp(data)
 }
 
-// save timestamp, if lastTs is 0, we think the timestamp doesn't exist, so create it,
-// otherwise, update it.
-func (s *Server) saveTimestamp(now time.Time) error {
+// saveTimestamp saves the high watermark of the timestamps with a
+// compare-and-swap on the previous one, if prev is zero, we think the
+// timestamp doesn't exist, so create it, otherwise, update it.
+func (s *Server) saveTimestamp(prev, now time.Time) error {
 	data := uint64ToBytes(uint64(now.UnixNano()))
 	key := s.getTimestampPath()
 
-	resp, err := s.leaderTxn().Then(clientv3.OpPut(key, string(data))).Commit()
+	cmp := clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	if prev != zeroTime {
+		cmp = clientv3.Compare(clientv3.Value(key), "=", string(uint64ToBytes(uint64(prev.UnixNano()))))
+	}
+	resp, err := s.leaderTxn(cmp).Then(clientv3.OpPut(key, string(data))).Commit()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	if !resp.Succeeded {
-		return errors.New("save timestamp failed, maybe we lost leader")
+		return errors.New("save timestamp failed, maybe we lost leader or the timestamp is changed")
 	}
 
 	s.lastSavedTime = now
@@ -80,20 +90,24 @@ func (s *Server) syncTimestamp() error {
 		return errors.Trace(err)
 	}
 
-	var now time.Time
-
-	for {
-		now = time.Now()
-		if wait := last.Sub(now) + updateTimestampGuard; wait > 0 {
-			log.Warnf("wait %v to guarantee valid generated timestamp", wait)
-			time.Sleep(wait)
-			continue
-		}
-		break
+	// Fast forward past the high watermark saved by the previous leader
+	// instead of waiting for the clock, so the timestamps stay monotonic
+	// even if the clock of this server is behind.
+	clock := time.Now()
+	now := clock
+	if now.Sub(last) <= updateTimestampGuard {
+		log.Warnf("fast forward timestamp past the saved one: last %v now %v", last, now)
+		now = last.Add(updateTimestampGuard + time.Millisecond)
+	}
+	// The previous leader saves a window ahead, the clocks of the leaders
+	// drift more if this one is still behind it.
+	if behind := last.Sub(clock) - s.cfg.TsoSaveInterval.Duration; behind > s.cfg.TsoMaxClockDrift.Duration {
+		log.Errorf("clock drift: the clock is %v behind the saved timestamp window, last: %v, now: %v", behind, last, clock)
+		tsoClockDriftCounter.WithLabelValues("window").Inc()
 	}
 
 	save := now.Add(s.cfg.TsoSaveInterval.Duration)
-	if err = s.saveTimestamp(save); err != nil {
+	if err = s.saveTimestamp(last, save); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -101,6 +115,7 @@ func (s *Server) syncTimestamp() error {
 
 	current := &atomicObject{
 		physical: now,
+		clock:    clock,
 	}
 	s.ts.Store(current)
 
@@ -108,23 +123,36 @@ func (s *Server) syncTimestamp() error {
 }
 
 func (s *Server) updateTimestamp() error {
-	prev := s.ts.Load().(*atomicObject).physical
+	prevObject := s.ts.Load().(*atomicObject)
+	prev := prevObject.physical
 	now := time.Now()
 
+	// Refuse to advance rather than issue timestamps which may go back.
+	if s.checkClockDrift(prevObject, now) {
+		return nil
+	}
+
 	since := now.Sub(prev)
 	if since > 3*updateTimestampStep {
 		log.Warnf("clock offset: %v, prev: %v, now: %v", since, prev, now)
 	}
+	next := now
 	// Avoid the same physical time stamp
 	if since <= updateTimestampGuard {
-		log.Warnf("invalid physical timestamp, prev: %v, now: %v, re-update later", prev, now)
-		return nil
+		// The physical time is fast forwarded or the clock jumps back, move
+		// it forward slowly before the logical part is used up.
+		if atomic.LoadInt64(&prevObject.logical) <= maxLogical/2 {
+			return nil
+		}
+		log.Warnf("logical part is used up before the clock catches up, prev: %v, now: %v", prev, now)
+		next = prev.Add(time.Millisecond)
 	}
 
-	if now.Sub(s.lastSavedTime) >= 0 {
+	// Save the next window before the physical time reaches the saved one.
+	if s.lastSavedTime.Sub(next) <= updateTimestampGuard {
 		last := s.lastSavedTime
-		save := now.Add(s.cfg.TsoSaveInterval.Duration)
-		if err := s.saveTimestamp(save); err != nil {
+		save := next.Add(s.cfg.TsoSaveInterval.Duration)
+		if err := s.saveTimestamp(last, save); err != nil {
 			return errors.Trace(err)
 		}
 
@@ -132,7 +160,8 @@ func (s *Server) updateTimestamp() error {
 	}
 
 	current := &atomicObject{
-		physical: now,
+		physical: next,
+		clock:    now,
 	}
 	s.ts.Store(current)
 
@@ -142,9 +171,15 @@ func (s *Server) updateTimestamp() error {
 const maxRetryCount = 100
 
 func (s *Server) getRespTS(count uint32) (pdpb.Timestamp, error) {
+	return allocTimestamp(&s.ts, count)
+}
+
+// allocTimestamp allocates count timestamps from ts, the returned timestamp
+// is the highest one.
+func allocTimestamp(ts *atomic.Value, count uint32) (pdpb.Timestamp, error) {
 	var resp pdpb.Timestamp
 	for i := 0; i < maxRetryCount; i++ {
-		current, ok := s.ts.Load().(*atomicObject)
+		current, ok := ts.Load().(*atomicObject)
 		if !ok || current.physical == zeroTime {
 			log.Errorf("we haven't synced timestamp ok, wait and retry, retry count %d", i)
 			time.Sleep(200 * time.Millisecond)
@@ -158,6 +193,7 @@ func (s *Server) getRespTS(count uint32) (pdpb.Timestamp, error) {
 			time.Sleep(updateTimestampStep)
 			continue
 		}
+		tsoAllocatedCounter.Add(float64(count))
 		return resp, nil
 	}
 	return resp, errors.New("can not get timestamp")
@@ -0,0 +1,106 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+
+	"github.com/pingcap/pd/pkg/pdpb"
+)
+
+// maxTsoBatchCount is the max timestamps allocated in a batch, it leaves
+// enough logical space for the batches in the same physical time.
+const maxTsoBatchCount = maxLogical / 16
+
+type tsoBatchRequest struct {
+	count uint32
+	ts    pdpb.Timestamp
+	err   error
+	// done receives true if the request should lead the next batch.
+	done chan bool
+}
+
+// tsoBatcher groups the concurrent timestamp requests of the streams, so
+// they share one logical allocation. There is no dedicated goroutine, the
+// request arriving when no batch is in progress leads the batch, and the
+// requests arriving meanwhile wait and are allocated in the next batch,
+// which is led by the first of them.
+type tsoBatcher struct {
+	// alloc allocates the timestamps of a batch, from the local TSO on the
+	// leader or from the leader on the followers.
+	alloc func(count uint32) (pdpb.Timestamp, error)
+
+	sync.Mutex
+	pending []*tsoBatchRequest
+	// running is true if a batch is in progress, pending is empty if not.
+	running bool
+}
+
+func newTsoBatcher(alloc func(count uint32) (pdpb.Timestamp, error)) *tsoBatcher {
+	return &tsoBatcher{alloc: alloc}
+}
+
+// getTS allocates count timestamps, the returned timestamp is the highest
+// one like getRespTS.
+func (b *tsoBatcher) getTS(count uint32) (pdpb.Timestamp, error) {
+	req := &tsoBatchRequest{count: count, done: make(chan bool, 1)}
+	b.Lock()
+	b.pending = append(b.pending, req)
+	lead := !b.running
+	b.running = true
+	b.Unlock()
+
+	if !lead && !<-req.done {
+		return req.ts, req.err
+	}
+	b.allocate()
+	return req.ts, req.err
+}
+
+// allocate allocates the timestamps for a batch of the pending requests, it
+// is called by the first pending request.
+func (b *tsoBatcher) allocate() {
+	b.Lock()
+	n, total := 1, b.pending[0].count
+	for ; n < len(b.pending); n++ {
+		if int64(total)+int64(b.pending[n].count) > maxTsoBatchCount {
+			break
+		}
+		total += b.pending[n].count
+	}
+	batch := b.pending[:n:n]
+	b.pending = b.pending[n:]
+	b.Unlock()
+	tsoBatchSizeHist.Observe(float64(len(batch)))
+
+	ts, err := b.alloc(total)
+	// Each request gets the highest timestamp of its own range.
+	logical := ts.Logical - int64(total)
+	for _, req := range batch {
+		logical += int64(req.count)
+		req.ts, req.err = pdpb.Timestamp{Physical: ts.Physical, Logical: logical}, err
+	}
+
+	b.Lock()
+	if len(b.pending) > 0 {
+		b.pending[0].done <- true
+	} else {
+		b.running = false
+	}
+	b.Unlock()
+	// The first one is the leader itself.
+	for _, req := range batch[1:] {
+		req.done <- false
+	}
+}
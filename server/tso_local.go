@@ -0,0 +1,509 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// The local leaders and the global leader synchronize the timestamps every
+// localTsoSyncInterval. Each local leader fast forwards past a global
+// timestamp and publishes its physical time, then the global leader fast
+// forwards past the published ones. So the global transactions see the
+// local transactions committed an interval before, and vice versa.
+const localTsoSyncInterval = time.Second
+
+// getLocalTsoRootPath returns the path of the local allocators, they are
+// shared by the tenants.
+func (s *Server) getLocalTsoRootPath() string {
+	if s.primary != nil {
+		return s.primary.getLocalTsoRootPath()
+	}
+	return path.Join(s.rootPath, "local-tso")
+}
+
+func (s *Server) getDCMemberPath(memberID uint64) string {
+	return path.Join(s.getLocalTsoRootPath(), "member", fmt.Sprintf("%020d", memberID))
+}
+
+func (s *Server) getDCPath(dc string) string {
+	return path.Join(s.getLocalTsoRootPath(), "dc", dc)
+}
+
+// loadDCLocations returns the data centers of the members.
+func (s *Server) loadDCLocations() (map[uint64]string, error) {
+	prefix := path.Join(s.getLocalTsoRootPath(), "member") + "/"
+	resp, err := kvGet(s.client, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dcs := make(map[uint64]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var memberID uint64
+		if _, err := fmt.Sscanf(strings.TrimPrefix(string(kv.Key), prefix), "%d", &memberID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		dcs[memberID] = string(kv.Value)
+	}
+	return dcs, nil
+}
+
+// loadLocalTsoLeaders returns the members serving the local allocators, and
+// the highest physical time published by them.
+func (s *Server) loadLocalTsoLeaders() ([]*pdpb.Member, time.Time, error) {
+	prefix := path.Join(s.getLocalTsoRootPath(), "dc") + "/"
+	resp, err := kvGet(s.client, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, zeroTime, errors.Trace(err)
+	}
+	var (
+		leaders []*pdpb.Member
+		maxTS   time.Time
+	)
+	for _, kv := range resp.Kvs {
+		dc, name := path.Split(strings.TrimPrefix(string(kv.Key), prefix))
+		switch name {
+		case "leader":
+			leader := &pdpb.Member{}
+			if err = leader.Unmarshal(kv.Value); err != nil {
+				return nil, zeroTime, errors.Trace(err)
+			}
+			leader.DcLocation = strings.TrimSuffix(dc, "/")
+			leaders = append(leaders, leader)
+		case "synced":
+			ts, err := parseTimestamp(kv.Value)
+			if err != nil {
+				return nil, zeroTime, errors.Trace(err)
+			}
+			if ts.After(maxTS) {
+				maxTS = ts
+			}
+		}
+	}
+	return leaders, maxTS, nil
+}
+
+// syncLocalTimestamps fast forwards the global timestamp past the physical
+// times published by the local leaders, it is called by the leader.
+func (s *Server) syncLocalTimestamps() error {
+	_, maxTS, err := s.loadLocalTsoLeaders()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	prev := s.ts.Load().(*atomicObject).physical
+	if prev.After(maxTS) {
+		return nil
+	}
+
+	next := maxTS.Add(updateTimestampGuard + time.Millisecond)
+	if s.lastSavedTime.Sub(next) <= updateTimestampGuard {
+		save := next.Add(s.cfg.TsoSaveInterval.Duration)
+		if err = s.saveTimestamp(s.lastSavedTime, save); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	log.Infof("fast forward timestamp past the local timestamps: prev %v local %v", prev, maxTS)
+	s.ts.Store(&atomicObject{
+		physical: next,
+		clock:    time.Now(),
+	})
+	return nil
+}
+
+// localTSO is the local allocator of the data center of the server. The
+// members in the same data center elect a leader to serve the local
+// timestamps, which start after the global timestamps issued before, so the
+// transactions confined to the data center don't need to reach the global
+// leader in another data center.
+type localTSO struct {
+	s  *Server
+	dc string
+
+	ts            atomic.Value
+	lastSavedTime time.Time
+	isLeaderValue int64
+	tsoBatcher    *tsoBatcher
+}
+
+func newLocalTSO(s *Server, dc string) *localTSO {
+	l := &localTSO{
+		s:  s,
+		dc: dc,
+	}
+	l.ts.Store(&atomicObject{physical: zeroTime})
+	l.tsoBatcher = newTsoBatcher(l.getRespTS)
+	return l
+}
+
+func (l *localTSO) getLeaderPath() string {
+	return path.Join(l.s.getDCPath(l.dc), "leader")
+}
+
+func (l *localTSO) getTimestampPath() string {
+	return path.Join(l.s.getDCPath(l.dc), "timestamp")
+}
+
+func (l *localTSO) getSyncedPath() string {
+	return path.Join(l.s.getDCPath(l.dc), "synced")
+}
+
+func (l *localTSO) leaderCmp() clientv3.Cmp {
+	return clientv3.Compare(clientv3.Value(l.getLeaderPath()), "=", l.s.leaderValue)
+}
+
+func (l *localTSO) isLeader() bool {
+	return atomic.LoadInt64(&l.isLeaderValue) == 1
+}
+
+func (l *localTSO) run() {
+	s := l.s
+	defer s.wg.Done()
+
+	if _, err := s.client.Put(s.client.Ctx(), s.getDCMemberPath(s.ID()), l.dc); err != nil {
+		log.Errorf("local tso: failed to save the dc location %s: %v", l.dc, err)
+	}
+
+	for !s.isClosed() {
+		leader, err := getLeader(s.client, l.getLeaderPath())
+		if err != nil {
+			log.Errorf("local tso: get leader of %s err %v", l.dc, err)
+			time.Sleep(200 * time.Millisecond)
+			continue
+		}
+		if leader != nil {
+			if s.isSameLeader(leader) {
+				// The key is left by the previous campaign, delete it and
+				// campaign again.
+				key := l.getLeaderPath()
+				if _, err = s.txn().If(clientv3.Compare(clientv3.Value(key), "=", s.leaderValue)).Then(clientv3.OpDelete(key)).Commit(); err != nil {
+					log.Errorf("local tso: resign leader of %s err %v", l.dc, err)
+					time.Sleep(200 * time.Millisecond)
+					continue
+				}
+			} else {
+				s.watchLeader(l.getLeaderPath())
+			}
+		}
+
+		// The watch returns when the server is closed too.
+		if s.isClosed() {
+			return
+		}
+		if err = l.campaign(); err != nil {
+			log.Errorf("local tso: campaign leader of %s err %s", l.dc, errors.ErrorStack(err))
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+}
+
+func (l *localTSO) campaign() error {
+	s := l.s
+	lessor := clientv3.NewLease(s.client)
+	defer lessor.Close()
+
+	ctx, cancel := context.WithTimeout(s.client.Ctx(), requestTimeout)
+	leaseResp, err := lessor.Grant(ctx, s.cfg.LeaderLease)
+	cancel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	leaderKey := l.getLeaderPath()
+	resp, err := s.txn().
+		If(clientv3.Compare(clientv3.CreateRevision(leaderKey), "=", 0)).
+		Then(clientv3.OpPut(leaderKey, s.leaderValue, clientv3.WithLease(clientv3.LeaseID(leaseResp.ID)))).
+		Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.New("campaign local tso leader failed, other server may campaign ok")
+	}
+
+	ctx, cancel = context.WithCancel(s.client.Ctx())
+	defer cancel()
+	ch, err := lessor.KeepAlive(ctx, clientv3.LeaseID(leaseResp.ID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err = l.syncTimestamp(); err != nil {
+		return errors.Trace(err)
+	}
+	defer l.ts.Store(&atomicObject{
+		physical: zeroTime,
+	})
+
+	atomic.StoreInt64(&l.isLeaderValue, 1)
+	defer atomic.StoreInt64(&l.isLeaderValue, 0)
+
+	log.Infof("local tso leader of %s is ready to serve", l.dc)
+
+	tsTicker := time.NewTicker(updateTimestampStep)
+	defer tsTicker.Stop()
+	syncTicker := time.NewTicker(localTsoSyncInterval)
+	defer syncTicker.Stop()
+
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				log.Infof("local tso: keep alive channel of %s is closed", l.dc)
+				return nil
+			}
+		case <-tsTicker.C:
+			if err = l.updateTimestamp(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-syncTicker.C:
+			if err = l.syncGlobalTimestamp(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-ctx.Done():
+			return errors.New("server closed")
+		}
+	}
+}
+
+func (l *localTSO) loadTimestamp() (time.Time, error) {
+	data, err := getValue(l.s.client, l.getTimestampPath())
+	if err != nil {
+		return zeroTime, errors.Trace(err)
+	}
+	if len(data) == 0 {
+		return zeroTime, nil
+	}
+	return parseTimestamp(data)
+}
+
+// saveTimestamp is like Server.saveTimestamp, but on the local paths.
+func (l *localTSO) saveTimestamp(prev, now time.Time) error {
+	key := l.getTimestampPath()
+	cmp := clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	if prev != zeroTime {
+		cmp = clientv3.Compare(clientv3.Value(key), "=", string(uint64ToBytes(uint64(prev.UnixNano()))))
+	}
+	resp, err := l.s.txn().If(cmp, l.leaderCmp()).Then(clientv3.OpPut(key, string(uint64ToBytes(uint64(now.UnixNano()))))).Commit()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Succeeded {
+		return errors.New("save local timestamp failed, maybe we lost leader or the timestamp is changed")
+	}
+
+	l.lastSavedTime = now
+	return nil
+}
+
+// syncTimestamp starts the local timestamps after both the local and the
+// global windows saved before.
+func (l *localTSO) syncTimestamp() error {
+	last, err := l.loadTimestamp()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	global, err := l.s.loadTimestamp()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	clock := time.Now()
+	now, start := clock, last
+	if global.After(start) {
+		start = global
+	}
+	if now.Sub(start) <= updateTimestampGuard {
+		now = start.Add(updateTimestampGuard + time.Millisecond)
+	}
+
+	save := now.Add(l.s.cfg.TsoSaveInterval.Duration)
+	if err = l.saveTimestamp(last, save); err != nil {
+		return errors.Trace(err)
+	}
+
+	log.Infof("sync and save local timestamp of %s: last %v global %v save %v", l.dc, last, global, save)
+	l.ts.Store(&atomicObject{
+		physical: now,
+		clock:    clock,
+	})
+	return nil
+}
+
+// updateTimestamp is like Server.updateTimestamp, but on the local paths.
+func (l *localTSO) updateTimestamp() error {
+	prevObject := l.ts.Load().(*atomicObject)
+	prev := prevObject.physical
+	now := time.Now()
+	if l.s.checkClockDrift(prevObject, now) {
+		return nil
+	}
+
+	next := now
+	if now.Sub(prev) <= updateTimestampGuard {
+		if atomic.LoadInt64(&prevObject.logical) <= maxLogical/2 {
+			return nil
+		}
+		next = prev.Add(time.Millisecond)
+	}
+
+	if l.lastSavedTime.Sub(next) <= updateTimestampGuard {
+		if err := l.saveTimestamp(l.lastSavedTime, next.Add(l.s.cfg.TsoSaveInterval.Duration)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	l.ts.Store(&atomicObject{
+		physical: next,
+		clock:    now,
+	})
+	return nil
+}
+
+// syncGlobalTimestamp fast forwards the local timestamp past a global one, and
+// publishes the local physical time for the global leader.
+func (l *localTSO) syncGlobalTimestamp() error {
+	var (
+		global pdpb.Timestamp
+		err    error
+	)
+	if l.s.IsLeader() {
+		global, err = l.s.tsoBatcher.getTS(1)
+	} else {
+		global, err = l.s.tsoProxy.getTS(1)
+	}
+	if err != nil {
+		// Keep serving the local timestamps without the global leader.
+		log.Warnf("local tso: failed to get the global timestamp: %v", err)
+		return nil
+	}
+
+	next := l.ts.Load().(*atomicObject).physical
+	if globalPhysical := time.Unix(0, global.GetPhysical()*int64(time.Millisecond)); !next.After(globalPhysical) {
+		next = globalPhysical.Add(time.Millisecond)
+		if l.lastSavedTime.Sub(next) <= updateTimestampGuard {
+			if err = l.saveTimestamp(l.lastSavedTime, next.Add(l.s.cfg.TsoSaveInterval.Duration)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		log.Infof("local tso: fast forward timestamp of %s past the global timestamp %v", l.dc, globalPhysical)
+		l.ts.Store(&atomicObject{
+			physical: next,
+			clock:    time.Now(),
+		})
+	}
+
+	_, err = l.s.txn().If(l.leaderCmp()).Then(clientv3.OpPut(l.getSyncedPath(), string(uint64ToBytes(uint64(next.UnixNano()))))).Commit()
+	return errors.Trace(err)
+}
+
+func (l *localTSO) getRespTS(count uint32) (pdpb.Timestamp, error) {
+	if !l.isLeader() {
+		return pdpb.Timestamp{}, errors.Errorf("not the local tso leader of %s", l.dc)
+	}
+	return allocTimestamp(&l.ts, count)
+}
+
+// serve serves a Tso stream of the local timestamps.
+func (l *localTSO) serve(stream pdpb.PD_TsoServer) error {
+	s := l.s
+	ctx := stream.Context()
+	client := s.getTsoClient(ctx)
+	s.tsoQuota.addStream(client)
+	defer s.tsoQuota.removeStream(client)
+
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if clusterID := request.GetHeader().GetClusterId(); clusterID != s.clusterID {
+			return grpc.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", s.clusterID, clusterID)
+		}
+		if dc := request.GetDcLocation(); dc != l.dc {
+			return grpc.Errorf(codes.FailedPrecondition, "mismatch dc location, need %s but got %s", l.dc, dc)
+		}
+		count := request.GetCount()
+		if err = s.tsoQuota.wait(ctx, client, count); err != nil {
+			return errors.Trace(err)
+		}
+		ts, err := l.tsoBatcher.getTS(count)
+		if err != nil {
+			return grpc.Errorf(codes.Unknown, err.Error())
+		}
+		response := &pdpb.TsoResponse{
+			Header:    s.header(),
+			Timestamp: &ts,
+			Count:     count,
+		}
+		if err := stream.Send(response); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// forwardLocalTso serves the local Tso stream of the data center if this
+// server is the local leader, or forwards it to the local leader.
+func (f *leaderForwarder) forwardLocalTso(dc string, stream pdpb.PD_TsoServer) error {
+	s := f.s
+	if l := s.localTSO; l != nil && l.dc == dc && l.isLeader() {
+		return l.serve(stream)
+	}
+	ctx := stream.Context()
+	if _, ok := s.getForwardedFor(ctx); ok {
+		return grpc.Errorf(codes.Unavailable, "not the local tso leader of %s", dc)
+	}
+	leader, err := getLeader(s.client, path.Join(s.getDCPath(dc), "leader"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if leader == nil || s.isSameLeader(leader) || len(leader.GetClientUrls()) == 0 {
+		return grpc.Errorf(codes.Unavailable, "no local tso leader of %s", dc)
+	}
+
+	// The clients are expected to connect to the local leaders returned by
+	// GetMembers, so each of the few forwarded streams has its own
+	// connection.
+	conn, err := f.dial(leader.GetClientUrls()[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+	md := metadata.Pairs(forwardedKey, s.getTsoClient(ctx))
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(ctx, md))
+	defer cancel()
+	upstream, err := pdpb.NewPDClient(conn).Tso(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return forwardStream(stream, upstream, cancel,
+		func() interface{} { return &pdpb.TsoRequest{} },
+		func() interface{} { return &pdpb.TsoResponse{} })
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+)
+
+var _ = Suite(&testLocalTsoSuite{})
+
+type testLocalTsoSuite struct{}
+
+func mustGetTS(c *C, client pdpb.PDClient, clusterID uint64, dc string) int64 {
+	tsoClient, err := client.Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	err = tsoClient.Send(&pdpb.TsoRequest{
+		Header:     newRequestHeader(clusterID),
+		Count:      1,
+		DcLocation: dc,
+	})
+	c.Assert(err, IsNil)
+	resp, err := tsoClient.Recv()
+	c.Assert(err, IsNil)
+	return resp.GetTimestamp().GetPhysical()<<18 + resp.GetTimestamp().GetLogical()
+}
+
+func (s *testLocalTsoSuite) TestLocalTso(c *C) {
+	cfgs := NewTestMultiConfig(3)
+	cfgs[0].DCLocation = "dc1"
+	cfgs[1].DCLocation = "dc1"
+	cfgs[2].DCLocation = "dc2"
+	svrs, cleanup := newTestServersWithCfgs(c, cfgs)
+	defer cleanup()
+	leader := mustWaitLeader(c, svrs)
+	clusterID := leader.clusterID
+
+	// Wait for the local leaders of both data centers.
+	var resp *pdpb.GetMembersResponse
+	for i := 0; i < 100; i++ {
+		var err error
+		resp, err = leader.GetMembers(context.Background(), &pdpb.GetMembersRequest{})
+		c.Assert(err, IsNil)
+		if len(resp.GetLocalTsoLeaders()) == 2 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Assert(resp.GetLocalTsoLeaders(), HasLen, 2)
+	for _, m := range resp.GetMembers() {
+		if m.GetName() == cfgs[2].Name {
+			c.Assert(m.GetDcLocation(), Equals, "dc2")
+		} else {
+			c.Assert(m.GetDcLocation(), Equals, "dc1")
+		}
+	}
+	localLeaders := make(map[string]string)
+	for _, m := range resp.GetLocalTsoLeaders() {
+		localLeaders[m.GetDcLocation()] = m.GetName()
+	}
+	c.Assert(localLeaders["dc2"], Equals, cfgs[2].Name)
+	c.Assert(localLeaders["dc1"], Not(Equals), cfgs[2].Name)
+
+	// Every member serves the local timestamps of any data center.
+	for _, svr := range svrs {
+		client := mustNewGrpcClient(c, svr.GetAddr())
+		for _, dc := range []string{"dc1", "dc2"} {
+			for {
+				// Wait for the local leader to be ready.
+				tsoClient, err := client.Tso(context.Background())
+				c.Assert(err, IsNil)
+				err = tsoClient.Send(&pdpb.TsoRequest{Header: newRequestHeader(clusterID), Count: 1, DcLocation: dc})
+				c.Assert(err, IsNil)
+				_, err = tsoClient.Recv()
+				tsoClient.CloseSend()
+				if err == nil {
+					break
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			c.Assert(mustGetTS(c, client, clusterID, dc), Greater, int64(0))
+		}
+	}
+
+	// The timestamps are synchronized in both directions.
+	global := mustGetTS(c, mustNewGrpcClient(c, leader.GetAddr()), clusterID, "")
+	time.Sleep(2 * localTsoSyncInterval)
+	local := mustGetTS(c, mustNewGrpcClient(c, svrs[0].GetAddr()), clusterID, "dc2")
+	c.Assert(local, Greater, global)
+	time.Sleep(2 * localTsoSyncInterval)
+	c.Assert(mustGetTS(c, mustNewGrpcClient(c, leader.GetAddr()), clusterID, ""), Greater, local)
+
+	// Unknown data center.
+	tsoClient, err := mustNewGrpcClient(c, leader.GetAddr()).Tso(context.Background())
+	c.Assert(err, IsNil)
+	defer tsoClient.CloseSend()
+	err = tsoClient.Send(&pdpb.TsoRequest{Header: newRequestHeader(clusterID), Count: 1, DcLocation: "dc3"})
+	c.Assert(err, IsNil)
+	_, err = tsoClient.Recv()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/pd/pkg/pdpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// tsoProxyKey is the gRPC metadata key of the Tso stream opened by the proxy
+// of a follower. The follower limits the quotas of its clients, so the
+// leader doesn't limit the stream again. The batches mix the clients, which
+// can't be limited by the leader without blocking each other, so the quotas
+// are not shared by the members. It is ignored if the stream is not opened by
+// a member.
+const tsoProxyKey = "pd-tso-proxy"
+
+func (s *Server) isTsoProxy(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md[tsoProxyKey]) > 0 && s.memberPeers.isMember(ctx)
+}
+
+// tsoProxy serves the Tso streams on a follower. The concurrent requests of
+// all streams are batched, and each batch is forwarded to the leader over a
+// single stream, so the leader doesn't see a stream per client.
+type tsoProxy struct {
+	*tsoBatcher
+	s         *Server
+	forwarder *leaderForwarder
+
+	// The batches are forwarded one by one, so the stream is only used by the
+	// batch in progress.
+	conn   *grpc.ClientConn
+	stream pdpb.PD_TsoClient
+	cancel context.CancelFunc
+}
+
+func newTsoProxy(s *Server, forwarder *leaderForwarder) *tsoProxy {
+	p := &tsoProxy{
+		s:         s,
+		forwarder: forwarder,
+	}
+	p.tsoBatcher = newTsoBatcher(p.forward)
+	return p
+}
+
+// forward allocates count timestamps from the leader.
+func (p *tsoProxy) forward(count uint32) (pdpb.Timestamp, error) {
+	stream, err := p.getStream()
+	if err != nil {
+		return pdpb.Timestamp{}, errors.Trace(err)
+	}
+	request := &pdpb.TsoRequest{
+		Header: &pdpb.RequestHeader{ClusterId: p.s.clusterID},
+		Count:  count,
+	}
+	err = stream.Send(request)
+	var response *pdpb.TsoResponse
+	if err == nil {
+		response, err = stream.Recv()
+	}
+	if err != nil {
+		p.closeStream()
+		return pdpb.Timestamp{}, errors.Trace(err)
+	}
+	return *response.GetTimestamp(), nil
+}
+
+// getStream returns the stream to the current leader, the stream to the
+// previous leader is closed.
+func (p *tsoProxy) getStream() (pdpb.PD_TsoClient, error) {
+	leader, err := p.s.GetLeader()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if leader == nil || p.s.isSameLeader(leader) || len(leader.GetClientUrls()) == 0 {
+		return nil, errors.Trace(errNoLeader)
+	}
+	conn, err := p.forwarder.getConn(leader.GetClientUrls()[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if p.stream != nil && p.conn == conn {
+		return p.stream, nil
+	}
+	p.closeStream()
+
+	md := metadata.Pairs(forwardedKey, p.s.Name(), tsoProxyKey, p.s.Name())
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(p.s.client.Ctx(), md))
+	stream, err := pdpb.NewPDClient(conn).Tso(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	p.conn, p.stream, p.cancel = conn, stream, cancel
+	return stream, nil
+}
+
+func (p *tsoProxy) closeStream() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.conn, p.stream, p.cancel = nil, nil, nil
+}
+
+// serve serves a Tso stream of a client with the timestamps from the leader.
+func (p *tsoProxy) serve(stream pdpb.PD_TsoServer) error {
+	ctx := stream.Context()
+	client := p.s.getTsoClient(ctx)
+	p.s.tsoQuota.addStream(client)
+	defer p.s.tsoQuota.removeStream(client)
+
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if clusterID := request.GetHeader().GetClusterId(); clusterID != p.s.clusterID {
+			return grpc.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", p.s.clusterID, clusterID)
+		}
+		count := request.GetCount()
+		if err = p.s.tsoQuota.wait(ctx, client, count); err != nil {
+			return errors.Trace(err)
+		}
+		ts, err := p.getTS(count)
+		if err != nil {
+			return grpc.Errorf(codes.Unknown, err.Error())
+		}
+		response := &pdpb.TsoResponse{
+			Header:    p.s.header(),
+			Timestamp: &ts,
+			Count:     count,
+		}
+		if err := stream.Send(response); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// authorizationKey is the gRPC metadata key of the token of the client.
+const authorizationKey = "authorization"
+
+// TsoClientStat records the timestamps consumed by a client.
+type TsoClientStat struct {
+	Streams  int    `json:"streams"`
+	Consumed uint64 `json:"consumed"`
+	Waited   uint64 `json:"waited"`
+	// Waiting is the number of the requests waiting for the quota.
+	Waiting int `json:"waiting"`
+
+	// tokens is the remaining quota of the client, it becomes negative when
+	// a request larger than the quota is allowed.
+	tokens   float64
+	lastTime time.Time
+	// waiters are the requests waiting for the quota in order, the timer
+	// allows the first one when the quota is refilled.
+	waiters []*tsoWaiter
+	timer   *time.Timer
+}
+
+type tsoWaiter struct {
+	count uint32
+	ready chan struct{}
+}
+
+// tsoQuota limits the timestamps consumed by each client with a token bucket.
+// Streams from the same client share the quota, and a client which exceeds
+// it has to wait until the quota is refilled, so an aggressive client can't
+// starve the others. The waiting requests of a client are allowed in order,
+// and a stream has at most one request in flight, so the streams of the
+// client take turns and a busy stream can't starve the others.
+type tsoQuota struct {
+	sync.Mutex
+	// limit is the timestamps per second for each client, 0 means no limit.
+	limit   uint64
+	clients map[string]*TsoClientStat
+}
+
+func newTsoQuota(limit uint64) *tsoQuota {
+	return &tsoQuota{
+		limit:   limit,
+		clients: make(map[string]*TsoClientStat),
+	}
+}
+
+func (q *tsoQuota) addStream(client string) {
+	q.Lock()
+	defer q.Unlock()
+
+	stat, ok := q.clients[client]
+	if !ok {
+		stat = &TsoClientStat{
+			tokens:   float64(q.limit),
+			lastTime: time.Now(),
+		}
+		q.clients[client] = stat
+	}
+	stat.Streams++
+}
+
+func (q *tsoQuota) removeStream(client string) {
+	q.Lock()
+	defer q.Unlock()
+
+	stat, ok := q.clients[client]
+	if !ok {
+		return
+	}
+	stat.Streams--
+	if stat.Streams <= 0 {
+		if stat.timer != nil {
+			stat.timer.Stop()
+		}
+		delete(q.clients, client)
+	}
+}
+
+// required returns the tokens required to allow count timestamps, a request
+// larger than the quota is allowed when the bucket is full.
+func (q *tsoQuota) required(count uint32) float64 {
+	return math.Min(float64(count), float64(q.limit))
+}
+
+func (q *tsoQuota) refill(stat *TsoClientStat, now time.Time) {
+	stat.tokens += now.Sub(stat.lastTime).Seconds() * float64(q.limit)
+	if stat.tokens > float64(q.limit) {
+		stat.tokens = float64(q.limit)
+	}
+	stat.lastTime = now
+}
+
+// wait blocks until the client's quota allows count timestamps.
+func (q *tsoQuota) wait(ctx context.Context, client string, count uint32) error {
+	q.Lock()
+	stat, ok := q.clients[client]
+	if !ok {
+		q.Unlock()
+		return nil
+	}
+	stat.Consumed += uint64(count)
+	if q.limit == 0 {
+		q.Unlock()
+		return nil
+	}
+	q.refill(stat, time.Now())
+	if len(stat.waiters) == 0 && stat.tokens >= q.required(count) {
+		stat.tokens -= float64(count)
+		q.Unlock()
+		return nil
+	}
+	w := &tsoWaiter{count: count, ready: make(chan struct{})}
+	stat.waiters = append(stat.waiters, w)
+	stat.Waited++
+	stat.Waiting++
+	if len(stat.waiters) == 1 {
+		q.schedule(stat)
+	}
+	q.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		q.cancel(stat, w)
+		return ctx.Err()
+	}
+}
+
+// schedule allows the first waiter after the quota is refilled for it.
+func (q *tsoQuota) schedule(stat *TsoClientStat) {
+	lack := q.required(stat.waiters[0].count) - stat.tokens
+	d := time.Duration(lack / float64(q.limit) * float64(time.Second))
+	stat.timer = time.AfterFunc(d, func() { q.dispatch(stat) })
+}
+
+// dispatch allows the waiters in order while the quota is enough.
+func (q *tsoQuota) dispatch(stat *TsoClientStat) {
+	q.Lock()
+	defer q.Unlock()
+
+	stat.timer = nil
+	q.refill(stat, time.Now())
+	for len(stat.waiters) > 0 && stat.tokens >= q.required(stat.waiters[0].count) {
+		w := stat.waiters[0]
+		stat.waiters = stat.waiters[1:]
+		stat.Waiting--
+		stat.tokens -= float64(w.count)
+		close(w.ready)
+	}
+	if len(stat.waiters) > 0 {
+		q.schedule(stat)
+	}
+}
+
+// cancel removes the waiter whose stream is closed.
+func (q *tsoQuota) cancel(stat *TsoClientStat, w *tsoWaiter) {
+	q.Lock()
+	defer q.Unlock()
+
+	for i, waiter := range stat.waiters {
+		if waiter != w {
+			continue
+		}
+		stat.waiters = append(stat.waiters[:i], stat.waiters[i+1:]...)
+		stat.Waiting--
+		if i == 0 && stat.timer != nil {
+			stat.timer.Stop()
+			stat.timer = nil
+			if len(stat.waiters) > 0 {
+				q.schedule(stat)
+			}
+		}
+		return
+	}
+}
+
+func (q *tsoQuota) getStats() map[string]*TsoClientStat {
+	q.Lock()
+	defer q.Unlock()
+
+	stats := make(map[string]*TsoClientStat, len(q.clients))
+	for client, stat := range q.clients {
+		clone := *stat
+		clone.waiters, clone.timer = nil, nil
+		stats[client] = &clone
+	}
+	return stats
+}
+
+// getTsoClient returns the client name of a stream. The clients are named by
+// the users of their tokens, which are sent in the "authorization" metadata
+// like the HTTP bearer tokens, otherwise the streams from the same host are
+// treated as the same client. Streams forwarded by followers are named by the
+// original clients.
+func (s *Server) getTsoClient(ctx context.Context) string {
+	if client, ok := s.getForwardedFor(ctx); ok {
+		return client
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[authorizationKey]) > 0 {
+		if user, ok := s.cfg.Auth.authenticateBearer(md[authorizationKey][0]); ok {
+			return user
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
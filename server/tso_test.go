@@ -14,13 +14,21 @@
 package server
 
 import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	. "github.com/pingcap/check"
-	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/pd/pkg/pdpb"
+	dto "github.com/prometheus/client_model/go"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 var _ = Suite(&testTsoSuite{})
@@ -104,3 +112,253 @@ func (s *testTsoSuite) TestTso(c *C) {
 
 	wg.Wait()
 }
+
+func (s *testTsoSuite) TestBatch(c *C) {
+	type tsRange struct {
+		physical, first, last int64
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		ranges []tsRange
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(count uint32) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ts, err := s.svr.tsoBatcher.getTS(count)
+				c.Assert(err, IsNil)
+				mu.Lock()
+				ranges = append(ranges, tsRange{ts.Physical, ts.Logical - int64(count) + 1, ts.Logical})
+				mu.Unlock()
+			}
+		}(uint32(i + 1))
+	}
+	wg.Wait()
+
+	// The allocated ranges never overlap.
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].physical != ranges[j].physical {
+			return ranges[i].physical < ranges[j].physical
+		}
+		return ranges[i].first < ranges[j].first
+	})
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].physical == ranges[i-1].physical {
+			c.Assert(ranges[i].first, Greater, ranges[i-1].last)
+		}
+	}
+	c.Assert(s.svr.tsoBatcher.running, IsFalse)
+	c.Assert(s.svr.tsoBatcher.pending, HasLen, 0)
+}
+
+func (s *testTsoSuite) TestMetrics(c *C) {
+	getAllocated := func() float64 {
+		var m dto.Metric
+		c.Assert(tsoAllocatedCounter.Write(&m), IsNil)
+		return m.GetCounter().GetValue()
+	}
+	allocated := getAllocated()
+	s.testGetTimestamp(c, 10)
+	c.Assert(getAllocated()-allocated, GreaterEqual, float64(10))
+
+	// The metrics are served by the client URLs.
+	client := &http.Client{Transport: &http.Transport{
+		Dial: func(_, _ string) (net.Conn, error) {
+			return net.Dial("unix", unixStripper.Replace(s.svr.GetAddr()))
+		},
+	}}
+	resp, err := client.Get("http://pd/metrics")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(body), "pd_tso_allocated_timestamps_total"), IsTrue)
+	c.Assert(strings.Contains(string(body), "pd_etcd_server_has_leader"), IsTrue)
+}
+
+var _ = Suite(&testTsoSaveSuite{})
+
+type testTsoSaveSuite struct{}
+
+func (s *testTsoSaveSuite) TestFastForward(c *C) {
+	svr, cleanup := mustRunTestServer(c)
+	defer cleanup()
+
+	getDrifts := func() float64 {
+		var m dto.Metric
+		c.Assert(tsoClockDriftCounter.WithLabelValues("window").Write(&m), IsNil)
+		return m.GetCounter().GetValue()
+	}
+
+	// The new leader fast forwards past the timestamp saved by the previous
+	// leader whose clock is ahead, instead of waiting. The drift is alarmed.
+	last := time.Now().Add(time.Minute)
+	_, err := svr.client.Put(context.Background(), svr.getTimestampPath(), string(uint64ToBytes(uint64(last.UnixNano()))))
+	c.Assert(err, IsNil)
+	drifts := getDrifts()
+	start := time.Now()
+	c.Assert(svr.syncTimestamp(), IsNil)
+	c.Assert(getDrifts(), Equals, drifts+1)
+	c.Assert(time.Since(start), Less, 10*time.Second)
+	c.Assert(svr.ts.Load().(*atomicObject).physical.After(last), IsTrue)
+	saved, err := svr.loadTimestamp()
+	c.Assert(err, IsNil)
+	c.Assert(saved.Equal(svr.lastSavedTime), IsTrue)
+
+	// The timestamp keeps increasing while the clock is behind, it doesn't
+	// stall after the logical part is used up.
+	for i := 0; i < 10; i++ {
+		ts, err := svr.getRespTS(uint32(maxLogical/2 + 1))
+		c.Assert(err, IsNil)
+		c.Assert(svr.updateTimestamp(), IsNil)
+		next, err := svr.getRespTS(1)
+		c.Assert(err, IsNil)
+		c.Assert(next.GetPhysical(), Greater, ts.GetPhysical())
+	}
+
+	// The timestamp is not saved if it is changed by others.
+	_, err = svr.client.Put(context.Background(), svr.getTimestampPath(), string(uint64ToBytes(uint64(last.UnixNano()))))
+	c.Assert(err, IsNil)
+	c.Assert(svr.saveTimestamp(svr.lastSavedTime, svr.lastSavedTime.Add(time.Second)), NotNil)
+}
+
+func (s *testTsoSaveSuite) TestClockJumpBack(c *C) {
+	svr, cleanup := mustRunTestServer(c)
+	defer cleanup()
+	mustWaitLeader(c, []*Server{svr})
+
+	// The leader stops advancing the timestamps when its clock jumps back.
+	prev := svr.ts.Load().(*atomicObject)
+	svr.ts.Store(&atomicObject{
+		physical: prev.physical,
+		clock:    time.Now().Add(time.Minute),
+	})
+	physical := svr.ts.Load().(*atomicObject).physical
+	c.Assert(svr.updateTimestamp(), IsNil)
+	time.Sleep(3 * updateTimestampStep)
+	c.Assert(svr.ts.Load().(*atomicObject).physical, Equals, physical)
+	_, err := svr.getRespTS(1)
+	c.Assert(err, IsNil)
+}
+
+var _ = Suite(&testTsoQuotaSuite{})
+
+type testTsoQuotaSuite struct{}
+
+func (s *testTsoQuotaSuite) TestQuota(c *C) {
+	q := newTsoQuota(100)
+	q.addStream("a")
+	q.addStream("a")
+	q.addStream("b")
+	ctx := context.Background()
+
+	// Client a exceeds the quota and has to wait, client b is not affected.
+	c.Assert(q.wait(ctx, "a", 60), IsNil)
+	start := time.Now()
+	c.Assert(q.wait(ctx, "a", 60), IsNil)
+	c.Assert(time.Since(start), Greater, 100*time.Millisecond)
+	start = time.Now()
+	c.Assert(q.wait(ctx, "b", 100), IsNil)
+	c.Assert(time.Since(start), Less, 100*time.Millisecond)
+
+	stats := q.getStats()
+	c.Assert(stats["a"].Streams, Equals, 2)
+	c.Assert(stats["a"].Consumed, Equals, uint64(120))
+	c.Assert(stats["a"].Waited, Equals, uint64(1))
+	c.Assert(stats["b"].Waited, Equals, uint64(0))
+
+	// Stats are removed after all streams of the client are closed.
+	q.removeStream("b")
+	c.Assert(q.getStats(), HasLen, 1)
+
+	// No limit.
+	q = newTsoQuota(0)
+	q.addStream("a")
+	c.Assert(q.wait(ctx, "a", 1000), IsNil)
+	c.Assert(q.getStats()["a"].Consumed, Equals, uint64(1000))
+}
+
+func (s *testTsoQuotaSuite) TestQuotaConfig(c *C) {
+	dir := c.MkDir()
+	for _, t := range []struct {
+		content string
+		quota   uint64
+	}{
+		// No limit if it's unset or 0.
+		{"", 0},
+		{"tso-client-quota = 0", 0},
+		{"tso-client-quota = 100", 100},
+	} {
+		path := dir + "/pd.toml"
+		c.Assert(ioutil.WriteFile(path, []byte(t.content), 0644), IsNil)
+		cfg := NewConfig()
+		c.Assert(cfg.configFromFile(path), IsNil)
+		c.Assert(cfg.adjust(), IsNil)
+		c.Assert(cfg.TsoClientQuota, Equals, t.quota)
+	}
+}
+
+func (s *testTsoQuotaSuite) TestFairQueue(c *C) {
+	q := newTsoQuota(1000)
+	q.addStream("a")
+	ctx := context.Background()
+	c.Assert(q.wait(ctx, "a", 1000), IsNil)
+
+	// The waiting requests are allowed in order, a large request doesn't
+	// block the others forever.
+	var (
+		mu    sync.Mutex
+		order []uint32
+		wg    sync.WaitGroup
+	)
+	for i, count := range []uint32{500, 2000, 100} {
+		wg.Add(1)
+		go func(count uint32) {
+			defer wg.Done()
+			c.Assert(q.wait(ctx, "a", count), IsNil)
+			mu.Lock()
+			order = append(order, count)
+			mu.Unlock()
+		}(count)
+		// Queue them in order.
+		for j := 0; q.getStats()["a"].Waiting < i+1; j++ {
+			c.Assert(j, Less, 100)
+			time.Sleep(time.Millisecond)
+		}
+		mu.Lock()
+		c.Assert(order, HasLen, 0)
+		mu.Unlock()
+	}
+	wg.Wait()
+	c.Assert(order, DeepEquals, []uint32{500, 2000, 100})
+
+	// A canceled request leaves the queue.
+	cctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- q.wait(cctx, "a", 1000) }()
+	for i := 0; q.getStats()["a"].Waiting == 0; i++ {
+		c.Assert(i, Less, 100)
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	c.Assert(<-done, NotNil)
+	c.Assert(q.getStats()["a"].Waiting, Equals, 0)
+	c.Assert(q.wait(ctx, "a", 1), IsNil)
+}
+
+func (s *testTsoQuotaSuite) TestTsoClient(c *C) {
+	svr := &Server{cfg: NewConfig()}
+	svr.cfg.Auth.Tokens = map[string]string{"t1": "tidb-1"}
+	svr.memberPeers = newMemberPeers(svr)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	c.Assert(svr.getTsoClient(ctx), Equals, "10.0.0.1")
+
+	// The clients behind the same address are told apart by their tokens.
+	tokenCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationKey, "Bearer t1"))
+	c.Assert(svr.getTsoClient(tokenCtx), Equals, "tidb-1")
+	tokenCtx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationKey, "Bearer t2"))
+	c.Assert(svr.getTsoClient(tokenCtx), Equals, "10.0.0.1")
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// operatorPriority is the priority of the operators waiting to run. Admin
+// operators never wait, they run before all the waiting operators.
+type operatorPriority int
+
+const (
+	// lowPriority is the priority of the operators of the schedulers.
+	lowPriority operatorPriority = iota
+	// highPriority is the priority of the operators repairing replicas.
+	highPriority
+	// urgentPriority is the priority of the operators repairing replicas of
+	// the regions in the priority key ranges.
+	urgentPriority
+)
+
+// replicaCheckerName is the source of the operators of the replica checker.
+const replicaCheckerName = "replica-checker"
+
+// waitingOperator is an operator which exceeds the schedule limit of its
+// source, it runs when the running operators of the kind are under the limit.
+type waitingOperator struct {
+	op       Operator
+	source   string
+	priority operatorPriority
+	kind     ResourceKind
+	limit    uint64
+	since    time.Time
+}
+
+// waitingOperatorQueue holds the waiting operators, at most one for each
+// region. The operators of higher priorities run first, and the ones of the
+// same priority run in order.
+type waitingOperatorQueue struct {
+	sync.RWMutex
+	buckets [urgentPriority + 1][]*waitingOperator
+	regions map[uint64]*waitingOperator
+	sources map[string]uint64
+}
+
+func newWaitingOperatorQueue() *waitingOperatorQueue {
+	return &waitingOperatorQueue{
+		regions: make(map[uint64]*waitingOperator),
+		sources: make(map[string]uint64),
+	}
+}
+
+// push adds the operator to the queue, an operator of the same region is
+// replaced if it has a lower priority. It returns the replaced operator and
+// whether the operator is added.
+func (q *waitingOperatorQueue) push(w *waitingOperator) (Operator, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	var replaced Operator
+	if old, ok := q.regions[w.op.GetRegionID()]; ok {
+		if old.priority >= w.priority {
+			return nil, false
+		}
+		q.removeLocked(old)
+		replaced = old.op
+	}
+	q.buckets[w.priority] = append(q.buckets[w.priority], w)
+	q.regions[w.op.GetRegionID()] = w
+	q.sources[w.source]++
+	return replaced, true
+}
+
+// remove removes the waiting operator of the region.
+func (q *waitingOperatorQueue) remove(regionID uint64) Operator {
+	q.Lock()
+	defer q.Unlock()
+
+	w, ok := q.regions[regionID]
+	if !ok {
+		return nil
+	}
+	q.removeLocked(w)
+	return w.op
+}
+
+func (q *waitingOperatorQueue) removeLocked(w *waitingOperator) {
+	bucket := q.buckets[w.priority]
+	for i := range bucket {
+		if bucket[i] == w {
+			q.buckets[w.priority] = append(bucket[:i:i], bucket[i+1:]...)
+			break
+		}
+	}
+	delete(q.regions, w.op.GetRegionID())
+	q.sources[w.source]--
+}
+
+// elems returns the waiting operators in the order to run.
+func (q *waitingOperatorQueue) elems() []*waitingOperator {
+	q.RLock()
+	defer q.RUnlock()
+
+	elems := make([]*waitingOperator, 0, len(q.regions))
+	for i := len(q.buckets) - 1; i >= 0; i-- {
+		elems = append(elems, q.buckets[i]...)
+	}
+	return elems
+}
+
+// count returns the number of the waiting operators of the source.
+func (q *waitingOperatorQueue) count(source string) uint64 {
+	q.RLock()
+	defer q.RUnlock()
+	return q.sources[source]
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testWaitingOperatorSuite{})
+
+type testWaitingOperatorSuite struct{}
+
+func (s *testWaitingOperatorSuite) TestQueue(c *C) {
+	q := newWaitingOperatorQueue()
+	newWaiting := func(regionID uint64, source string, priority operatorPriority) *waitingOperator {
+		return &waitingOperator{
+			op:       newTestOperator(regionID, RegionKind),
+			source:   source,
+			priority: priority,
+		}
+	}
+
+	w1 := newWaiting(1, "balance", lowPriority)
+	w2 := newWaiting(2, "balance", lowPriority)
+	w3 := newWaiting(3, replicaCheckerName, highPriority)
+	for _, w := range []*waitingOperator{w1, w2, w3} {
+		replaced, ok := q.push(w)
+		c.Assert(ok, IsTrue)
+		c.Assert(replaced, IsNil)
+	}
+	c.Assert(q.elems(), DeepEquals, []*waitingOperator{w3, w1, w2})
+	c.Assert(q.count("balance"), Equals, uint64(2))
+
+	// An operator of the same region is only replaced by a higher priority.
+	_, ok := q.push(newWaiting(3, "balance", lowPriority))
+	c.Assert(ok, IsFalse)
+	w4 := newWaiting(1, replicaCheckerName, highPriority)
+	replaced, ok := q.push(w4)
+	c.Assert(ok, IsTrue)
+	c.Assert(replaced, Equals, w1.op)
+	c.Assert(q.elems(), DeepEquals, []*waitingOperator{w3, w4, w2})
+	c.Assert(q.count("balance"), Equals, uint64(1))
+	c.Assert(q.count(replicaCheckerName), Equals, uint64(2))
+
+	c.Assert(q.remove(3), Equals, w3.op)
+	c.Assert(q.remove(3), IsNil)
+	c.Assert(q.elems(), DeepEquals, []*waitingOperator{w4, w2})
+}